@@ -53,7 +53,16 @@ const PUBLIC_EXPORTS: PublicExport[] = [
 
   // Utility exports - Public API
   { file: 'src/index.ts', exportName: 'extractSchemaShape', exportType: 'value', critical: false },
+  { file: 'src/index.ts', exportName: 'createToolMetrics', exportType: 'value', critical: false },
   { file: 'src/index.ts', exportName: 'ZodRawShape', exportType: 'type', critical: false },
+
+  // Validation exports - Public API (containerization-assist-mcp/validation)
+  { file: 'src/validation/index.ts', exportName: 'createComposeValidator', exportType: 'value', critical: false },
+  { file: 'src/validation/index.ts', exportName: 'createK8sApiVersionValidator', exportType: 'value', critical: false },
+  { file: 'src/validation/index.ts', exportName: 'createK8sLabelConventionValidator', exportType: 'value', critical: false },
+  { file: 'src/validation/index.ts', exportName: 'runValidationPhases', exportType: 'value', critical: false },
+  { file: 'src/validation/index.ts', exportName: 'buildValidationPhases', exportType: 'value', critical: false },
+  { file: 'src/validation/index.ts', exportName: 'applySeverityPolicy', exportType: 'value', critical: false },
 ];
 
 function checkExportExists(filePath: string, exportName: string, exportType: 'value' | 'type'): boolean {
@@ -40,6 +40,13 @@ export const DEFAULT_CHAIN_HINTS: ChainHintsRegistry = {
   },
 
 
+  [TOOL_NAME.DEPLOY]: {
+    success:
+      'Manifests applied. Next: Call verify-deploy to wait for the rollout and check health. After a dry run, call deploy again without dryRun.',
+    failure:
+      'Deploy failed. Fix the manifest named in the error (a dryRun: true call checks changes without applying them), then retry deploy.',
+  },
+
   [TOOL_NAME.FIX_DOCKERFILE]: {
     success:
       'Dockerfile fixes applied successfully. Next: Call build-image to test the fixed Dockerfile.',
@@ -52,8 +59,20 @@ export const DEFAULT_CHAIN_HINTS: ChainHintsRegistry = {
     failure: 'Manifest generation failed. Ensure you have a valid image and try again.',
   },
 
+  [TOOL_NAME.OPTIMIZE_DOCKERFILE]: {
+    success:
+      'Dockerfile optimization analysis complete. Next: Apply optimizedDockerfile if provided, review the remaining suggestions, then call build-image.',
+    failure: 'Dockerfile optimization failed. Check that the Dockerfile path or content is valid.',
+  },
+
+  [TOOL_NAME.LIST_WORKFLOWS]: {
+    success:
+      'Workflows listed. Next: Call resume-workflow with the workflowId of the one to continue, or pass nextCursor to see more.',
+    failure: 'Workflows could not be listed. Check that workflow state is enabled and readable.',
+  },
+
   [TOOL_NAME.PREPARE_CLUSTER]: {
-    success: 'Cluster preparation successful. Next: Use `kubectl apply -f <manifest-folder>` to deploy your manifests to the cluster, then call verify-deploy to check deployment status.',
+    success: 'Cluster preparation successful. Next: call deploy with your manifests (dryRun: true to validate them first), then call verify-deploy to check deployment status.',
     failure:
       'Cluster preparation found issues. Check connectivity, permissions, and namespace configuration.',
   },
@@ -64,6 +83,18 @@ export const DEFAULT_CHAIN_HINTS: ChainHintsRegistry = {
       'Image push failed. Check registry credentials, network connectivity, and image tag format.',
   },
 
+  [TOOL_NAME.RESUME_WORKFLOW]: {
+    success:
+      'Workflow state loaded. Next: Call the nextStage tool with the outputs of the completed stages instead of re-running them.',
+    failure: 'Workflow state could not be read. Start the workflow over with analyze-repo.',
+  },
+
+  [TOOL_NAME.ROLLBACK_DEPLOY]: {
+    success: 'Rollback completed. Next: Call verify-deploy to confirm the deployment is healthy.',
+    failure:
+      'Rollback failed. Review the rollout history and pod status, then fix the deployment manually.',
+  },
+
   [TOOL_NAME.SCAN_IMAGE]: {
     success:
       'Security scan passed! Proceed with push-image to push to a registry, or continue with deployment preparation.',
@@ -16,13 +16,14 @@ import {
 } from '@/mcp/mcp-server';
 import { createOrchestrator } from './orchestrator';
 import type { OrchestratorConfig, ExecuteRequest, ToolOrchestrator } from './orchestrator-types';
-import type { Result } from '@/types';
+import { toolCapabilities, type Result } from '@/types';
 import type {
   AppRuntime,
   AppRuntimeConfig,
   ToolInputMap,
   ToolResultMap,
   ExecutionMetadata,
+  ToolDescriptor,
 } from '@/types/runtime';
 import { createToolLoggerFile, getLogFilePath } from '@/lib/tool-logger';
 import { checkDockerHealth, checkKubernetesHealth } from '@/infra/health/checks';
@@ -91,6 +92,17 @@ export function createApp(config: AppRuntimeConfig = {}): AppRuntime {
     aliasToOriginalMap,
   };
   if (config.policyPath !== undefined) orchestratorConfig.policyPath = config.policyPath;
+  if (config.maxConcurrentToolExecutions !== undefined) {
+    orchestratorConfig.maxConcurrentToolExecutions = config.maxConcurrentToolExecutions;
+  }
+  if (config.maxQueuedToolExecutions !== undefined) {
+    orchestratorConfig.maxQueuedToolExecutions = config.maxQueuedToolExecutions;
+  }
+  if (config.metrics) orchestratorConfig.metrics = config.metrics;
+  if (config.circuitBreaker) orchestratorConfig.circuitBreaker = config.circuitBreaker;
+  if (config.resultCache) orchestratorConfig.resultCache = config.resultCache;
+  if (config.audit) orchestratorConfig.audit = config.audit;
+  if (config.workflowState) orchestratorConfig.workflowState = config.workflowState;
 
   const toolList = Array.from(toolsMap.values());
 
@@ -127,21 +139,24 @@ export function createApp(config: AppRuntimeConfig = {}): AppRuntime {
       toolName: T,
       params: ToolInputMap[T],
       metadata?: ExecutionMetadata,
-    ): Promise<Result<ToolResultMap[T]>> =>
-      orchestratedExecute({
+    ): Promise<Result<ToolResultMap[T]>> => {
+      const { retry, ...rest } = metadata ?? {};
+      return orchestratedExecute({
         toolName: toolName as string,
         params,
+        ...(retry && { retry }),
         metadata: {
-          ...(metadata?.signal && { signal: metadata.signal }),
-          ...(metadata?.progress !== undefined && { progress: metadata.progress }),
-          ...(metadata?.sendNotification && { sendNotification: metadata.sendNotification }),
+          ...(rest.signal && { signal: rest.signal }),
+          ...(rest.progress !== undefined && { progress: rest.progress }),
+          ...(rest.sendNotification && { sendNotification: rest.sendNotification }),
           loggerContext: {
-            transport: metadata?.transport || 'programmatic',
-            requestId: metadata?.requestId,
-            ...metadata,
+            transport: rest.transport || 'programmatic',
+            requestId: rest.requestId,
+            ...rest,
           },
         },
-      }) as Promise<Result<ToolResultMap[T]>>,
+      }) as Promise<Result<ToolResultMap[T]>>;
+    },
 
     /**
      * Start MCP server with the specified transport
@@ -159,6 +174,7 @@ export function createApp(config: AppRuntimeConfig = {}): AppRuntime {
         name: 'containerization-assist',
         version: '1.0.0',
         outputFormat,
+        ...(config.audit && { audit: config.audit }),
       };
 
       const mcpServer = createMCPServer(toolList, serverOptions, orchestratedExecute);
@@ -205,6 +221,35 @@ export function createApp(config: AppRuntimeConfig = {}): AppRuntime {
         ...(t.category && { category: t.category }),
       })),
 
+    /**
+     * List all available tools with their metadata and capabilities
+     */
+    listToolMetadata: () =>
+      toolList.map(
+        (t): ToolDescriptor => ({
+          name: t.name as ToolName,
+          description: t.description,
+          ...(t.version && { version: t.version }),
+          ...(t.category && { category: t.category }),
+          metadata: t.metadata,
+          capabilities: toolCapabilities(t),
+        }),
+      ),
+
+    /**
+     * Names of the tools in a category
+     */
+    filterByCategory: (category) =>
+      toolList.filter((t) => t.category === category).map((t) => t.name as ToolName),
+
+    /**
+     * Whether a registered tool supports a capability
+     */
+    hasCapability: (toolName, capability) => {
+      const tool = toolsMap.get(toolName);
+      return tool ? toolCapabilities(tool).includes(capability) : false;
+    },
+
     /**
      * Perform health check
      */
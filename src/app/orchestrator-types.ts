@@ -5,6 +5,12 @@
 
 import type { Result } from '@/types/index';
 import type { ChainHintsRegistry } from './chain-hints';
+import type { ToolMetrics } from '@/lib/tool-metrics';
+import type { RetryPolicy } from '@/lib/retry';
+import type { CircuitBreaker } from '@/lib/circuit-breaker';
+import type { ResultCache } from '@/lib/result-cache';
+import type { AuditLog } from '@/lib/audit';
+import type { WorkflowStateStore } from '@/lib/workflow-state';
 
 /**
  * Request to execute a tool
@@ -13,6 +19,11 @@ export interface ExecuteRequest {
   toolName: string;
   params: unknown;
   metadata?: ExecuteMetadata;
+  /**
+   * Retry transient failures with exponential backoff. Unset fields fall back
+   * to DEFAULT_RETRY_POLICY; omit to run the tool once.
+   */
+  retry?: Partial<RetryPolicy>;
 }
 
 /**
@@ -28,11 +39,37 @@ export interface ExecuteMetadata {
   sendNotification?: (notification: unknown) => Promise<void>;
 }
 
+/**
+ * Options for running several tool calls at once
+ */
+export interface ExecuteParallelOptions {
+  /** Calls running at once within this batch (default 4) */
+  maxConcurrency?: number;
+  /** Cancels the batch: passed to running calls, unstarted calls are skipped */
+  signal?: AbortSignal;
+  /**
+   * Cancel the batch once a call fails, as if the signal had aborted. Skipped
+   * calls carry the failed call's index in guidance.details.failedIndex.
+   */
+  stopOnFailure?: boolean;
+}
+
 /**
  * Orchestrator interface
  */
 export interface ToolOrchestrator {
   execute(request: ExecuteRequest): Promise<Result<unknown>>;
+  /**
+   * Execute several tools concurrently. Results are returned in input order;
+   * a failing call does not affect the others. Calls that have not started
+   * when the signal aborts fail with a cancellation error.
+   */
+  executeParallel(
+    calls: ExecuteRequest[],
+    options?: ExecuteParallelOptions,
+  ): Promise<Result<unknown>[]>;
+  /** Number of executions waiting for a concurrency slot (0 when unlimited) */
+  getQueueDepth(): number;
   close(): void;
 }
 
@@ -47,4 +84,18 @@ export interface OrchestratorConfig {
   chainHints?: ChainHintsRegistry;
   /** Reverse mapping from alias to original tool name (alias -> original) */
   aliasToOriginalMap?: Record<string, string>;
+  /** Maximum tools executing at once across the server (unset or 0 = unlimited) */
+  maxConcurrentToolExecutions?: number;
+  /** Executions allowed to wait for a slot; more fail with QUEUE_FULL (unset or 0 = unbounded) */
+  maxQueuedToolExecutions?: number;
+  /** Recorder for per-tool latency and outcome metrics */
+  metrics?: ToolMetrics;
+  /** Per-tool circuit breaker; tools with open circuits are rejected without running */
+  circuitBreaker?: CircuitBreaker;
+  /** Cache for results of tools that set metadata.cacheTtlMs (unset = no caching) */
+  resultCache?: ResultCache;
+  /** Receives one record per execution (tool, caller ids, argument digest, outcome) */
+  audit?: AuditLog;
+  /** Saves completed pipeline stages per session so workflows survive a restart */
+  workflowState?: WorkflowStateStore;
 }
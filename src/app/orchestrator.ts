@@ -4,16 +4,33 @@
  */
 
 import { z, type ZodTypeAny } from 'zod';
-import { type Result, Success, Failure } from '@/types/index';
+import { type Result, Success, Failure, isCancelled } from '@/types/index';
 import { createLogger } from '@/lib/logger';
 import { createToolContext, type ToolContext } from '@/mcp/context';
 import type { Server } from '@modelcontextprotocol/sdk/server/index.js';
-import { ERROR_MESSAGES } from '@/lib/errors';
-import type { ToolOrchestrator, OrchestratorConfig, ExecuteRequest } from './orchestrator-types';
+import { ERROR_MESSAGES, extractErrorMessage } from '@/lib/errors';
+import type {
+  ToolOrchestrator,
+  OrchestratorConfig,
+  ExecuteRequest,
+  ExecuteParallelOptions,
+} from './orchestrator-types';
 import type { Logger } from 'pino';
 import type { Tool } from '@/types/tool';
 import { createStandardizedToolTracker } from '@/lib/tool-helpers';
+import { createSemaphore, QUEUE_FULL, type Semaphore } from '@/lib/concurrency';
 import { logToolExecution, createToolLogEntry } from '@/lib/tool-logger';
+import { withRetry, DEFAULT_RETRY_POLICY } from '@/lib/retry';
+import { digestArgs } from '@/lib/result-cache';
+import { pluralize } from '@/lib/summary-helpers';
+import { formatArgumentIssues, VALIDATION_FAILED } from '@/lib/zod-utils';
+import type { AuditRecord } from '@/lib/audit';
+import {
+  createPipelineRecorder,
+  isPipelineStage,
+  DEFAULT_WORKFLOW_ID,
+  type PipelineRecorder,
+} from '@/lib/workflow-state';
 import { loadAndMergeRegoPolicies, type RegoEvaluator } from '@/config/policy-rego';
 import { readdirSync, existsSync } from 'node:fs';
 import { join, dirname, resolve } from 'node:path';
@@ -81,22 +98,34 @@ function createContextForTool(
   request: ExecuteRequest,
   logger: Logger,
   policy?: RegoEvaluator,
+  config?: OrchestratorConfig,
 ): ToolContext {
   const metadata = request.metadata;
+  const store = config?.workflowState;
 
   return createToolContext(logger, {
     ...(metadata?.signal && { signal: metadata.signal }),
     ...(metadata?.progress !== undefined && { progress: metadata.progress }),
     ...(metadata?.sendNotification && { sendNotification: metadata.sendNotification }),
     ...(policy && { policy }),
+    ...(store && { workflow: { store, id: workflowIdFor(request) } }),
   });
 }
 
+/**
+ * Workflow that a request's pipeline stages belong to: the caller's session
+ */
+function workflowIdFor(request: ExecuteRequest): string {
+  const sessionId = request.metadata?.loggerContext?.sessionId;
+  return typeof sessionId === 'string' && sessionId.length > 0 ? sessionId : DEFAULT_WORKFLOW_ID;
+}
+
 interface ExecutionEnvironment<T extends Tool<ZodTypeAny, any>> {
   registry: Map<string, T>;
   logger: Logger;
   config: OrchestratorConfig;
   server?: Server;
+  pipelineRecorder?: PipelineRecorder;
 }
 
 /**
@@ -115,7 +144,30 @@ export function createOrchestrator<T extends Tool<ZodTypeAny, any>>(options: {
   let policyCache: RegoEvaluator | undefined;
   let policyLoadPromise: Promise<void> | undefined;
 
+  // Server-wide cap on concurrent tool executions; excess requests queue FIFO,
+  // and are rejected once the queue holds maxQueuedToolExecutions requests
+  const maxQueue = config.maxQueuedToolExecutions;
+  const semaphore: Semaphore | undefined =
+    config.maxConcurrentToolExecutions && config.maxConcurrentToolExecutions > 0
+      ? createSemaphore(config.maxConcurrentToolExecutions, {
+          ...(maxQueue !== undefined && maxQueue > 0 && { maxQueue }),
+        })
+      : undefined;
+
+  const pipelineRecorder =
+    config.workflowState && createPipelineRecorder(config.workflowState, logger);
+
   async function execute(request: ExecuteRequest): Promise<Result<unknown>> {
+    const { audit } = config;
+    if (!audit) return runExecution(request);
+
+    const startTime = Date.now();
+    const result = await runExecution(request);
+    audit.record(createAuditRecord(request, result.ok, startTime));
+    return result;
+  }
+
+  async function runExecution(request: ExecuteRequest): Promise<Result<unknown>> {
     const { toolName } = request;
     const tool = registry.get(toolName);
 
@@ -161,12 +213,126 @@ export function createOrchestrator<T extends Tool<ZodTypeAny, any>>(options: {
     // Wait for policy loading to complete if in progress
     await policyLoadPromise;
 
-    return await executeWithOrchestration(tool, request, {
+    const env = {
       registry,
       logger: contextualLogger,
       config,
       ...(server && { server }),
-    }, policyCache);
+      ...(pipelineRecorder && { pipelineRecorder }),
+    };
+
+    if (!semaphore) {
+      return await executeWithOrchestration(tool, request, env, policyCache);
+    }
+
+    if (semaphore.activeCount() >= semaphore.limit) {
+      contextualLogger.debug(
+        { queueDepth: semaphore.queueDepth() + 1, limit: semaphore.limit },
+        'Concurrency limit reached, queuing tool execution',
+      );
+    }
+
+    const slot = await semaphore.acquire(request.metadata?.signal);
+    if (!slot.ok && slot.guidance?.details?.code === QUEUE_FULL) {
+      contextualLogger.warn({ limit: semaphore.limit, maxQueue }, 'Tool execution queue full');
+      return Failure(slot.error, {
+        ...slot.guidance,
+        hint: `${semaphore.limit} tool executions are running and ${maxQueue} more are queued`,
+        resolution: 'Retry the request shortly or raise MAX_QUEUED_TOOL_EXECUTIONS',
+      });
+    }
+    if (!slot.ok) {
+      return Failure(slot.error, {
+        message: slot.error,
+        hint: `Request was cancelled while queued behind ${semaphore.limit} running tool executions`,
+        resolution: 'Retry the request once fewer tools are running',
+      });
+    }
+
+    try {
+      return await executeWithOrchestration(tool, request, env, policyCache);
+    } finally {
+      slot.value();
+    }
+  }
+
+  /**
+   * Run calls on a bounded pool of workers, each taking the next unstarted call
+   */
+  async function executeParallel(
+    calls: ExecuteRequest[],
+    options: ExecuteParallelOptions = {},
+  ): Promise<Result<unknown>[]> {
+    const { signal } = options;
+    const limit =
+      options.maxConcurrency && options.maxConcurrency > 0
+        ? options.maxConcurrency
+        : DEFAULT_PARALLEL_CONCURRENCY;
+    const results: Result<unknown>[] = new Array(calls.length);
+    let next = 0;
+
+    // With stopOnFailure the first failed call aborts the rest of the batch
+    const stop = options.stopOnFailure ? new AbortController() : undefined;
+    const batch = linkAbortSignals(signal, stop?.signal);
+    let failedIndex: number | undefined;
+
+    const runCall = async (call: ExecuteRequest): Promise<Result<unknown>> => {
+      if (batch.signal?.aborted) {
+        return Failure(`Cancelled before ${call.toolName} started`, {
+          message: 'Parallel execution cancelled',
+          hint:
+            failedIndex === undefined
+              ? 'The batch was cancelled before this call got a worker'
+              : `Call ${failedIndex} failed and the batch stops on the first failure`,
+          resolution: 'Run the call again',
+          ...(failedIndex !== undefined && { details: { failedIndex } }),
+        });
+      }
+
+      const linked = linkAbortSignals(batch.signal, call.metadata?.signal);
+      try {
+        return await execute({
+          ...call,
+          metadata: { ...call.metadata, ...(linked.signal && { signal: linked.signal }) },
+        });
+      } catch (error) {
+        return Failure(extractErrorMessage(error));
+      } finally {
+        linked.dispose();
+      }
+    };
+
+    const worker = async (): Promise<void> => {
+      while (next < calls.length) {
+        const index = next++;
+        const result = await runCall(calls[index] as ExecuteRequest);
+        results[index] = result;
+        // Failures caused by an abort already in progress did not stop the batch
+        if (!result.ok && stop && !batch.signal?.aborted) {
+          failedIndex = index;
+          stop.abort();
+        }
+      }
+    };
+
+    try {
+      await Promise.all(Array.from({ length: Math.min(limit, calls.length) }, worker));
+    } finally {
+      batch.dispose();
+    }
+
+    const failed = results.filter((result) => !result.ok).length;
+    if (failed > 0) {
+      logger.debug(
+        { total: calls.length, failed, ...(failedIndex !== undefined && { failedIndex }) },
+        'Parallel tool execution finished with failures',
+      );
+    }
+    return results;
+  }
+
+  function getQueueDepth(): number {
+    return semaphore?.queueDepth() ?? 0;
   }
 
   function close(): void {
@@ -176,7 +342,58 @@ export function createOrchestrator<T extends Tool<ZodTypeAny, any>>(options: {
     }
   }
 
-  return { execute, close };
+  return { execute, executeParallel, getQueueDepth, close };
+}
+
+/**
+ * Audit record for an execution; arguments are only stored as a digest
+ */
+function createAuditRecord(request: ExecuteRequest, ok: boolean, startTime: number): AuditRecord {
+  const context = request.metadata?.loggerContext ?? {};
+  const text = (value: unknown): string | undefined =>
+    typeof value === 'string' && value.length > 0 ? value : undefined;
+  const sessionId = text(context.sessionId);
+  const requestId = text(context.requestId);
+
+  return {
+    timestamp: new Date(startTime).toISOString(),
+    tool: request.toolName,
+    ...(sessionId && { sessionId }),
+    ...(requestId && { requestId }),
+    argsDigest: digestArgs(request.params),
+    durationMs: Date.now() - startTime,
+    outcome: ok ? 'success' : 'failure',
+  };
+}
+
+/** Calls running at once in executeParallel when no limit is given */
+const DEFAULT_PARALLEL_CONCURRENCY = 4;
+
+/**
+ * Combine a batch signal with a call's own signal; either one aborts the call
+ */
+function linkAbortSignals(
+  batch?: AbortSignal,
+  own?: AbortSignal,
+): { signal?: AbortSignal; dispose: () => void } {
+  const noop = (): void => {};
+  if (!batch || !own) {
+    const signal = batch ?? own;
+    return signal ? { signal, dispose: noop } : { dispose: noop };
+  }
+
+  const controller = new AbortController();
+  const abort = (): void => controller.abort();
+  if (batch.aborted || own.aborted) abort();
+  batch.addEventListener('abort', abort, { once: true });
+  own.addEventListener('abort', abort, { once: true });
+  return {
+    signal: controller.signal,
+    dispose: () => {
+      batch.removeEventListener('abort', abort);
+      own.removeEventListener('abort', abort);
+    },
+  };
 }
 
 /**
@@ -192,20 +409,109 @@ async function executeWithOrchestration<T extends Tool<ZodTypeAny, any>>(
   const { logger } = env;
 
   // Validate parameters using Zod safeParse
-  const validation = validateParams(params, tool.schema);
+  const validation = validateParams(params, tool.schema, tool.name);
   if (!validation.ok) return validation;
   const validatedParams = validation.value;
 
-  const toolContext = createContextForTool(request, logger, policy);
+  const withNextSteps = (value: unknown): unknown =>
+    env.config.chainHintsMode === 'enabled' && tool.chainHints
+      ? { ...(value as object), nextSteps: tool.chainHints.success }
+      : value;
+
+  // Pipeline stages are saved before returning so a restart cannot lose them
+  const recordStage = async (output: unknown): Promise<void> => {
+    const stage = env.config.aliasToOriginalMap?.[tool.name] ?? tool.name;
+    if (!env.pipelineRecorder || !isPipelineStage(stage)) return;
+    await env.pipelineRecorder.record(workflowIdFor(request), stage, {
+      argsDigest: digestArgs(validatedParams),
+      output,
+      completedAt: new Date().toISOString(),
+    });
+  };
+
+  // Idempotent tools may reuse a recent result for identical arguments, as
+  // long as what the tool read (its fingerprint) is unchanged
+  const cache = env.config.resultCache;
+  let cacheTtlMs = cache?.ttlFor(tool.name, tool.metadata.cacheTtlMs);
+  let cacheArgs: unknown = validatedParams;
+  if (cache && cacheTtlMs && tool.cacheFingerprint) {
+    const fingerprint = await tool.cacheFingerprint(validatedParams).catch(() => undefined);
+    if (fingerprint === undefined) cacheTtlMs = undefined;
+    else cacheArgs = { args: validatedParams, fingerprint };
+  }
+  if (cache && cacheTtlMs) {
+    const cached = cache.get(tool.name, cacheArgs);
+    if (cached) {
+      env.config.metrics?.record(tool.name, 'cache_hit', 0);
+      logger.debug({ tool: tool.name }, 'Returning cached tool result');
+      // A new or restarted workflow still gets this stage recorded
+      await recordStage(cached.value);
+      return Success(withNextSteps(cached.value));
+    }
+  }
+
+  // Fail fast while the tool's circuit is open instead of waiting for another slow failure
+  const breaker = env.config.circuitBreaker;
+  if (breaker) {
+    const permit = breaker.acquire(tool.name);
+    if (!permit.ok) {
+      logger.warn({ tool: tool.name }, 'Circuit open, rejecting tool execution');
+      return permit;
+    }
+  }
+
+  // A call stopped by its caller, or aborted with the rest of its batch, says
+  // nothing about the tool's health, so it must not trip the breaker
+  const wasCancelled = (result?: Result<unknown>): boolean =>
+    request.metadata?.signal?.aborted === true || (result !== undefined && isCancelled(result));
+  const recordOutcome = (status: 'success' | 'failure' | 'cancelled'): void => {
+    if (status === 'success') breaker?.recordSuccess(tool.name);
+    else if (status === 'failure') breaker?.recordFailure(tool.name);
+    else breaker?.release(tool.name);
+  };
+
+  const toolContext = createContextForTool(request, logger, policy, env.config);
   const tracker = createStandardizedToolTracker(tool.name, {}, logger);
 
   const startTime = Date.now();
   const logEntry = createToolLogEntry(tool.name, validatedParams);
 
-  // Execute tool directly (single attempt)
+  // Execute tool directly, or with backoff when the caller asked for retries
+  const runHandler = async (): Promise<Result<unknown>> => {
+    if (!request.retry) return tool.handler(validatedParams, toolContext);
+
+    const retryPolicy = { ...DEFAULT_RETRY_POLICY, ...request.retry };
+    return withRetry(
+      async () => {
+        try {
+          return await tool.handler(validatedParams, toolContext);
+        } catch (error) {
+          // Thrown errors are classified like returned failures
+          return Failure(extractErrorMessage(error));
+        }
+      },
+      retryPolicy,
+      {
+        ...(request.metadata?.signal && { signal: request.metadata.signal }),
+        onRetry: (failure, attempt, delayMs) =>
+          logger.warn(
+            { error: failure.error, attempt, maxAttempts: retryPolicy.maxAttempts, delayMs },
+            'Tool execution failed with a retryable error, retrying',
+          ),
+      },
+    );
+  };
+
   try {
-    const result = await tool.handler(validatedParams, toolContext);
+    const result = await runHandler();
     const durationMs = Date.now() - startTime;
+    if (result.ok && cache && cacheTtlMs) {
+      cache.set(tool.name, cacheArgs, result.value, cacheTtlMs);
+    }
+    if (result.ok) await recordStage(result.value);
+    const status = result.ok ? 'success' : wasCancelled(result) ? 'cancelled' : 'failure';
+    recordOutcome(status);
+    env.config.metrics?.record(tool.name, status, durationMs);
 
     logEntry.output = result.ok ? result.value : { error: result.error };
     logEntry.success = result.ok;
@@ -221,16 +527,7 @@ async function executeWithOrchestration<T extends Tool<ZodTypeAny, any>>(
 
     // Add metadata to successful results
     if (result.ok) {
-      let valueWithMessages = result.value;
-
-      if (env.config.chainHintsMode === 'enabled' && tool.chainHints) {
-        valueWithMessages = {
-          ...valueWithMessages,
-          nextSteps: tool.chainHints.success,
-        };
-      }
-
-      result.value = valueWithMessages;
+      result.value = withNextSteps(result.value);
     } else if (result.guidance && tool.chainHints) {
       // Add failure hint to error guidance
       result.guidance.hint = tool.chainHints.failure;
@@ -240,6 +537,9 @@ async function executeWithOrchestration<T extends Tool<ZodTypeAny, any>>(
   } catch (error) {
     const durationMs = Date.now() - startTime;
     const errorMessage = (error as Error).message || 'Unknown error';
+    const status = wasCancelled() ? 'cancelled' : 'failure';
+    recordOutcome(status);
+    env.config.metrics?.record(tool.name, status, durationMs);
 
     logEntry.output = { error: errorMessage };
     logEntry.success = false;
@@ -255,13 +555,29 @@ async function executeWithOrchestration<T extends Tool<ZodTypeAny, any>>(
 }
 
 /**
- * Validate parameters against schema using safeParse
+ * Validate parameters against the tool's schema before it runs, so every
+ * tool rejects malformed arguments the same way: one VALIDATION_FAILED
+ * failure listing all problems, rather than whatever the tool would hit first
  */
-function validateParams<T extends z.ZodSchema>(params: unknown, schema: T): Result<z.infer<T>> {
+function validateParams<T extends z.ZodSchema>(
+  params: unknown,
+  schema: T,
+  toolName: string,
+): Result<z.infer<T>> {
   const parsed = schema.safeParse(params);
   if (!parsed.success) {
-    const issues = parsed.error.issues.map((i) => `${i.path.join('.')}: ${i.message}`).join(', ');
-    return Failure(ERROR_MESSAGES.VALIDATION_FAILED(issues));
+    const issues = formatArgumentIssues(parsed.error);
+    const message = ERROR_MESSAGES.VALIDATION_FAILED(
+      issues.map((i) => `${i.path}: ${i.message}`).join('; '),
+    );
+    // The same arguments will fail the same way
+    return Failure(message, {
+      message: `Invalid arguments for ${toolName}`,
+      hint: `${pluralize(issues.length, 'argument problem')} found before the tool ran`,
+      resolution: `Fix the listed arguments to match the ${toolName} input schema and retry`,
+      details: { code: VALIDATION_FAILED, tool: toolName, issues },
+      retryable: false,
+    });
   }
   return Success(parsed.data);
 }
@@ -0,0 +1,403 @@
+/**
+ * Workflow
+ *
+ * Runs a set of steps that declare dependencies on each other (a DAG).
+ * Independent steps run in parallel, each step receives the outputs of the
+ * steps it depends on, and a failed step only stops its own dependents;
+ * unrelated branches keep going. The graph is validated (unknown
+ * dependencies, duplicate ids, cycles) when the workflow is created, before
+ * anything runs.
+ *
+ * A step can declare a skipIf predicate over the workflow input and its
+ * dependency outputs, e.g. to skip Dockerfile generation when the analysis
+ * found a valid one. Such a step is marked skipped without running, and its
+ * dependents still run.
+ *
+ * With a state store, completed steps are persisted as they finish and a
+ * later run with the same input reuses them instead of running them again,
+ * e.g. after a server restart.
+ */
+
+import type { Logger } from 'pino';
+import { Success, Failure, type Result, type ErrorGuidance } from '@/types';
+import { createSemaphore } from '@/lib/concurrency';
+import { extractErrorMessage } from '@/lib/errors';
+import { digestArgs } from '@/lib/result-cache';
+import {
+  createWorkflowState,
+  type CompletedStage,
+  type WorkflowState,
+  type WorkflowStateStore,
+} from '@/lib/workflow-state';
+import type { ToolName } from '@/tools';
+import type { AppRuntime, ToolInputMap } from '@/types/runtime';
+
+/**
+ * What a step receives when it runs
+ */
+export interface StepContext<TInput = unknown> {
+  /** Input passed to runWorkflow, shared by every step */
+  input: TInput;
+  /** Outputs of the steps this step depends on, keyed by step id */
+  dependencies: Record<string, unknown>;
+  signal?: AbortSignal;
+}
+
+export interface WorkflowStep<TInput = unknown> {
+  id: string;
+  /** Ids of steps that must succeed (or be skipped by skipIf) before this one starts */
+  dependsOn?: string[];
+  /**
+   * Checked once the dependencies are done; when it returns true the step is
+   * skipped instead of run, and its dependents see no output for it
+   */
+  skipIf?: (context: StepContext<TInput>) => boolean;
+  run(context: StepContext<TInput>): Promise<Result<unknown>>;
+}
+
+export interface Workflow<TInput = unknown> {
+  readonly steps: ReadonlyMap<string, WorkflowStep<TInput>>;
+  /** Step ids in dependency order */
+  readonly order: readonly string[];
+}
+
+export type StepStatus = 'succeeded' | 'failed' | 'skipped';
+
+export interface StepOutcome {
+  status: StepStatus;
+  value?: unknown;
+  error?: string;
+  guidance?: ErrorGuidance;
+  /** For skipped steps: the dependency that did not succeed */
+  blockedBy?: string;
+  /** For skipped steps: skipIf matched, so the step was not needed and dependents ran */
+  skippedByCondition?: boolean;
+  durationMs?: number;
+  /** The step was not run; its output was restored from a previous run */
+  resumed?: boolean;
+}
+
+export interface WorkflowRun {
+  /** True when every step succeeded or was skipped by its skipIf predicate */
+  succeeded: boolean;
+  steps: Record<string, StepOutcome>;
+}
+
+export interface RunWorkflowOptions {
+  signal?: AbortSignal;
+  /** Maximum steps running at once (unset = unlimited) */
+  maxConcurrency?: number;
+  logger?: Logger;
+  /** Persist succeeded steps and reuse the ones a previous run completed */
+  state?: { store: WorkflowStateStore; workflowId: string };
+}
+
+/**
+ * Find a dependency cycle, returned as the path of step ids that closes it
+ */
+function findCycle<TInput>(steps: Map<string, WorkflowStep<TInput>>): string[] | undefined {
+  const visiting = new Set<string>();
+  const done = new Set<string>();
+
+  const visit = (id: string, path: string[]): string[] | undefined => {
+    if (visiting.has(id)) return [...path.slice(path.indexOf(id)), id];
+    if (done.has(id)) return undefined;
+
+    visiting.add(id);
+    for (const dependency of steps.get(id)?.dependsOn ?? []) {
+      const cycle = visit(dependency, [...path, id]);
+      if (cycle) return cycle;
+    }
+    visiting.delete(id);
+    done.add(id);
+    return undefined;
+  };
+
+  for (const id of steps.keys()) {
+    const cycle = visit(id, []);
+    if (cycle) return cycle;
+  }
+  return undefined;
+}
+
+/**
+ * Order steps so that every step comes after its dependencies
+ */
+function dependencyOrder<TInput>(steps: Map<string, WorkflowStep<TInput>>): string[] {
+  const order: string[] = [];
+  const placed = new Set<string>();
+
+  const place = (id: string): void => {
+    if (placed.has(id)) return;
+    placed.add(id);
+    for (const dependency of steps.get(id)?.dependsOn ?? []) place(dependency);
+    order.push(id);
+  };
+
+  for (const id of steps.keys()) place(id);
+  return order;
+}
+
+/**
+ * Validate steps and build a workflow
+ *
+ * @returns Failure if ids are duplicated, a dependency is unknown, or the
+ *   dependencies form a cycle
+ */
+export function createWorkflow<TInput = unknown>(
+  steps: WorkflowStep<TInput>[],
+): Result<Workflow<TInput>> {
+  const byId = new Map<string, WorkflowStep<TInput>>();
+
+  for (const step of steps) {
+    if (byId.has(step.id)) {
+      return Failure(`Duplicate workflow step id: ${step.id}`, {
+        message: 'Invalid workflow',
+        hint: 'Every step needs a unique id so dependents can refer to it',
+        resolution: `Rename one of the steps called ${step.id}`,
+      });
+    }
+    byId.set(step.id, step);
+  }
+
+  for (const step of steps) {
+    const unknown = (step.dependsOn ?? []).filter((dependency) => !byId.has(dependency));
+    if (unknown.length > 0) {
+      return Failure(`Step ${step.id} depends on unknown step(s): ${unknown.join(', ')}`, {
+        message: 'Invalid workflow',
+        hint: `Known steps: ${[...byId.keys()].join(', ')}`,
+        resolution: 'Add the missing steps or fix the dependsOn ids',
+        details: { step: step.id, unknown },
+      });
+    }
+  }
+
+  const cycle = findCycle(byId);
+  if (cycle) {
+    return Failure(`Workflow has a dependency cycle: ${cycle.join(' -> ')}`, {
+      message: 'Invalid workflow',
+      hint: 'Workflow steps must form a directed acyclic graph',
+      resolution: 'Remove one of the dependencies in the cycle',
+      details: { cycle },
+    });
+  }
+
+  return Success({ steps: byId, order: dependencyOrder(byId) });
+}
+
+/**
+ * Load the state of a previous run, or start over when there is none, it
+ * cannot be read, or it was recorded for a different input
+ */
+async function loadState(
+  store: WorkflowStateStore,
+  workflowId: string,
+  inputDigest: string,
+  logger?: Logger,
+): Promise<WorkflowState> {
+  try {
+    const previous = await store.load(workflowId);
+    if (previous?.inputDigest === inputDigest) return previous;
+    if (previous) {
+      logger?.info({ workflowId }, 'Workflow input changed, not resuming previous run');
+    }
+  } catch (error) {
+    logger?.warn(
+      { workflowId, error: extractErrorMessage(error) },
+      'Failed to load workflow state, starting over',
+    );
+  }
+  return createWorkflowState(workflowId, inputDigest);
+}
+
+/**
+ * Whether a recorded step can stand in for running the step again: it must
+ * have consumed the same dependencies with the same outputs
+ */
+function canResume(
+  recorded: CompletedStage | undefined,
+  dependsOn: string[],
+  argsDigest: string,
+): recorded is CompletedStage {
+  if (!recorded || recorded.argsDigest !== argsDigest) return false;
+  const previous = recorded.dependsOn ?? [];
+  return previous.length === dependsOn.length && previous.every((id) => dependsOn.includes(id));
+}
+
+/**
+ * Whether an outcome lets dependents run
+ */
+function isSatisfied(outcome: StepOutcome): boolean {
+  return outcome.status === 'succeeded' || outcome.skippedByCondition === true;
+}
+
+/**
+ * Run a workflow
+ *
+ * Every step starts as soon as all of its dependencies have succeeded.
+ * Steps whose dependencies failed or were skipped are skipped, except that
+ * a step skipped by its skipIf predicate does not block its dependents; a
+ * step (or predicate) that throws counts as failed. Once the signal is aborted, steps that have not
+ * started yet are skipped.
+ *
+ * With `options.state`, each succeeded step is saved to the store as soon as
+ * it finishes. A later run with the same workflow id and input skips steps
+ * whose recorded dependencies and dependency outputs are unchanged and
+ * returns their recorded output, marked `resumed`. Failing to save is logged
+ * and does not fail the run.
+ */
+export async function runWorkflow<TInput>(
+  workflow: Workflow<TInput>,
+  input: TInput,
+  options: RunWorkflowOptions = {},
+): Promise<WorkflowRun> {
+  const { signal, maxConcurrency, logger } = options;
+  const semaphore =
+    maxConcurrency && maxConcurrency > 0 ? createSemaphore(maxConcurrency) : undefined;
+  const started = new Map<string, Promise<StepOutcome>>();
+
+  const store = options.state?.store;
+  let state =
+    options.state &&
+    (await loadState(options.state.store, options.state.workflowId, digestArgs(input), logger));
+  // Saves are chained so a slow write never overwrites a newer one
+  let saving: Promise<void> = Promise.resolve();
+
+  const persist = (id: string, completed: CompletedStage): void => {
+    if (!store || !state) return;
+    state = { ...state, updatedAt: completed.completedAt };
+    state.stages = { ...state.stages, [id]: completed };
+    const snapshot = state;
+    saving = saving.then(() =>
+      store.save(snapshot).catch((error: unknown) => {
+        logger?.warn(
+          { workflowId: snapshot.id, step: id, error: extractErrorMessage(error) },
+          'Failed to save workflow state',
+        );
+      }),
+    );
+  };
+
+  const execute = async (step: WorkflowStep<TInput>): Promise<StepOutcome> => {
+    const dependencyIds = step.dependsOn ?? [];
+    const dependencyOutcomes = await Promise.all(dependencyIds.map((id) => start(id)));
+
+    const blocked = dependencyOutcomes.findIndex((outcome) => !isSatisfied(outcome));
+    if (blocked !== -1) {
+      logger?.debug({ step: step.id, blockedBy: dependencyIds[blocked] }, 'Workflow step skipped');
+      return { status: 'skipped', blockedBy: dependencyIds[blocked] as string };
+    }
+
+    const dependencies = Object.fromEntries(
+      dependencyIds.map((id, index) => [id, dependencyOutcomes[index]?.value]),
+    );
+    const context = { input, dependencies, ...(signal && { signal }) };
+    try {
+      if (step.skipIf?.(context)) {
+        logger?.info({ step: step.id }, 'Workflow step skipped by condition');
+        return { status: 'skipped', skippedByCondition: true };
+      }
+    } catch (error) {
+      return { status: 'failed', error: `skipIf failed: ${extractErrorMessage(error)}` };
+    }
+
+    const argsDigest = state ? digestArgs(dependencies) : '';
+    const recorded = state?.stages[step.id];
+    if (canResume(recorded, dependencyIds, argsDigest)) {
+      logger?.debug({ step: step.id }, 'Workflow step resumed from saved state');
+      return { status: 'succeeded', value: recorded.output, resumed: true };
+    }
+
+    const slot = semaphore ? await semaphore.acquire(signal) : undefined;
+    if (signal?.aborted || (slot && !slot.ok)) {
+      if (slot?.ok) slot.value();
+      return { status: 'skipped', error: 'Workflow cancelled' };
+    }
+
+    const startTime = Date.now();
+    try {
+      logger?.debug({ step: step.id }, 'Workflow step started');
+      const result = await step.run(context);
+      const durationMs = Date.now() - startTime;
+      if (result.ok) {
+        persist(step.id, {
+          argsDigest,
+          ...(dependencyIds.length > 0 && { dependsOn: dependencyIds }),
+          output: result.value,
+          completedAt: new Date().toISOString(),
+        });
+      }
+      return result.ok
+        ? { status: 'succeeded', value: result.value, durationMs }
+        : {
+            status: 'failed',
+            error: result.error,
+            ...(result.guidance && { guidance: result.guidance }),
+            durationMs,
+          };
+    } catch (error) {
+      return {
+        status: 'failed',
+        error: extractErrorMessage(error),
+        durationMs: Date.now() - startTime,
+      };
+    } finally {
+      if (slot?.ok) slot.value();
+    }
+  };
+
+  const start = (id: string): Promise<StepOutcome> => {
+    let outcome = started.get(id);
+    if (!outcome) {
+      // Steps are validated by createWorkflow, so the lookup always succeeds
+      outcome = execute(workflow.steps.get(id) as WorkflowStep<TInput>);
+      started.set(id, outcome);
+    }
+    return outcome;
+  };
+
+  const outcomes = await Promise.all(workflow.order.map((id) => start(id)));
+  await saving;
+  const steps = Object.fromEntries(workflow.order.map((id, index) => [id, outcomes[index]]));
+
+  for (const [id, outcome] of Object.entries(steps)) {
+    if (outcome?.status === 'failed') {
+      logger?.warn({ step: id, error: outcome.error }, 'Workflow step failed');
+    }
+  }
+
+  return {
+    succeeded: outcomes.every(isSatisfied),
+    steps: steps as Record<string, StepOutcome>,
+  };
+}
+
+/**
+ * Workflow step that executes a tool through the app runtime
+ *
+ * @param runtime - Runtime used to run the tool (see createApp)
+ * @param options.params - Builds the tool params from the workflow input and
+ *   dependency outputs
+ */
+export function toolStep<T extends ToolName, TInput = unknown>(
+  runtime: Pick<AppRuntime, 'execute'>,
+  options: {
+    id: string;
+    toolName: T;
+    dependsOn?: string[];
+    skipIf?: (context: StepContext<TInput>) => boolean;
+    params: (context: StepContext<TInput>) => ToolInputMap[T];
+  },
+): WorkflowStep<TInput> {
+  return {
+    id: options.id,
+    ...(options.dependsOn && { dependsOn: options.dependsOn }),
+    ...(options.skipIf && { skipIf: options.skipIf }),
+    run: (context) =>
+      runtime.execute(
+        options.toolName,
+        options.params(context),
+        context.signal && { signal: context.signal },
+      ),
+  };
+}
@@ -6,18 +6,28 @@
 
 import { program } from 'commander';
 import { createApp } from '@/app';
-import { config, logConfigSummaryIfDev } from '@/config/index';
+import {
+  config,
+  collectConfigProblems,
+  logConfigSummaryIfDev,
+  reloadConfig,
+} from '@/config/index';
+import { applyConfigFileEnv, isYamlConfigPath, loadConfigFile } from '@/config/config-file';
+import { installReloadHandler } from '@/config/reload';
 import { createLogger } from '@/lib/logger';
-import { exit, argv, env, cwd } from 'node:process';
+import { createFileWorkflowStateStore } from '@/lib/workflow-state';
+import { exit, argv, env } from 'node:process';
 import { readFileSync } from 'node:fs';
 import { join, dirname } from 'node:path';
 import { fileURLToPath } from 'node:url';
 import { checkDockerHealth, checkKubernetesHealth } from '@/infra/health/checks';
+import { DOCKER_UNAVAILABLE } from '@/infra/docker/daemon';
 import { validateDockerSocket } from '@/infra/docker/socket-validation';
 import { createInspectToolsCommand } from './commands/inspect-tools';
 import { provideContextualGuidance } from './guidance';
 import { validateOptions } from './validation';
 import { OUTPUTFORMAT } from '@/mcp/mcp-server';
+import { exportToolSchemas } from '@/mcp/schema-export';
 import {
   logStartup,
   logStartupSuccess,
@@ -46,14 +56,16 @@ program
   .description('MCP server for AI-powered containerization workflows')
   .version(packageJson.version)
   .argument('[command]', 'command to run (start, inspect-tools)', 'start')
-  .option('--config <path>', 'path to configuration file (.env)')
-  .option('--log-level <level>', 'logging level: debug, info, warn, error (default: info)', 'info')
-  .option('--workspace <path>', 'workspace directory path (default: current directory)', cwd())
+  .option('--config <path>', 'path to configuration file (.yaml/.yml server config or .rego policy)')
+  .option('--log-level <level>', 'logging level: debug, info, warn, error (default: info)')
+  .option('--workspace <path>', 'workspace directory path (default: current directory)')
   .option('--dev', 'enable development mode with debug logging')
   .option('--validate', 'validate configuration and exit')
   .option('--list-tools', 'list all registered MCP tools and exit')
+  .option('--export-schemas', 'print the JSON Schema of every tool input and exit')
   .option('--health-check', 'perform system health check and exit')
   .option('--docker-socket <path>', 'Docker socket path (default: platform-specific)', '')
+  .option('--auto-install-scanner', 'download a pinned Trivy into the workspace if none is found')
   .option(
     '--k8s-namespace <namespace>',
     'default Kubernetes namespace (default: default)',
@@ -67,15 +79,18 @@ Examples:
   $ containerization-assist-mcp                           Start server with stdio transport
   $ containerization-assist-mcp --dev --log-level debug  Start in development mode with debug logs
   $ containerization-assist-mcp --list-tools             Show all available MCP tools
+  $ containerization-assist-mcp --export-schemas         Print tool input schemas as JSON
   $ containerization-assist-mcp --health-check           Check system dependencies
   $ containerization-assist-mcp --validate               Validate configuration
+  $ containerization-assist-mcp --config server.yaml     Load settings from a YAML config file
 
-MCP Tools Available (13 total):
+MCP Tools Available (22 total):
   • Analysis: analyze-repo
-  • Dockerfile: generate-dockerfile, validate-dockerfile, fix-dockerfile
-  • Image: build-image, scan-image, tag-image, push-image
-  • Kubernetes: generate-k8s-manifests, prepare-cluster, deploy, verify-deploy
-  • Utilities: ops
+  • Dockerfile: generate-dockerfile, validate-dockerfile, fix-dockerfile, optimize-dockerfile
+  • Image: build-image, scan-image, scan-images-batch, tag-image, push-image,
+    analyze-image-layers, diff-images, scan-licenses
+  • Kubernetes: generate-k8s-manifests, prepare-cluster, deploy, verify-deploy, rollback-deploy
+  • Utilities: ops, resume-workflow, list-workflows, cleanup-workspace
 
 For detailed documentation, see: README.md
 For examples and tutorials, see: docs/examples/
@@ -84,9 +99,19 @@ Environment Variables:
   LOG_LEVEL                                    Logging level (debug, info, warn, error)
   WORKSPACE_DIR                                Working directory for operations
   DOCKER_SOCKET                                Docker daemon socket path
+  CONTAINER_RUNTIME                            Container runtime: auto, docker, podman (default: auto)
   K8S_NAMESPACE                                Default Kubernetes namespace
-  CONTAINERIZATION_ASSIST_POLICY_PATH          Policy file path (overridden by --config)
+  CONTAINERIZATION_ASSIST_POLICY_PATH          Policy file path (overridden by --config <file>.rego)
+  MAX_CONCURRENT_TOOL_EXECUTIONS               Max tools running at once (0 = unlimited)
+  MAX_QUEUED_TOOL_EXECUTIONS                   Max requests waiting for a slot (0 = unbounded)
+  CONTAINERIZATION_ASSIST_WORKFLOW_STATE_DIR   Directory for resumable workflow state (default: off)
+  CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_BYTES        Max saved state per workflow (0 = unlimited)
+  CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_TOTAL_BYTES  Max saved state in total (0 = unlimited)
+  CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_IDLE_MINUTES Remove state unused this long (0 = keep)
+  CONTAINERIZATION_ASSIST_AUTO_INSTALL_SCANNER            Download Trivy if missing (default: false)
   NODE_ENV                                     Environment (development, production)
+
+Configuration precedence (lowest to highest): YAML config file < environment < CLI flags.
 `,
   );
 
@@ -103,7 +128,9 @@ const command = program.args[0] ?? 'start';
  */
 function resolvePolicyConfig(options: { config?: string }): { policyPath?: string } {
   // Policy path: --config flag > env var > undefined (use defaults)
-  const policyPath = options.config || process.env.CONTAINERIZATION_ASSIST_POLICY_PATH;
+  // A YAML --config is a server config file; its policyPath arrives via the env var
+  const policyFlag = options.config && !isYamlConfigPath(options.config) ? options.config : undefined;
+  const policyPath = policyFlag || process.env.CONTAINERIZATION_ASSIST_POLICY_PATH;
 
   // Only include policyPath if it has a value (for exactOptionalPropertyTypes)
   if (policyPath) {
@@ -131,22 +158,54 @@ async function main(): Promise<void> {
       exit(1);
     }
 
+    // Apply YAML config file values beneath any environment variables already set
+    let fileManagedKeys: string[] = [];
+    if (options.config && isYamlConfigPath(options.config)) {
+      const fileResult = loadConfigFile(options.config);
+      if (!fileResult.ok) {
+        console.error(`❌ ${fileResult.error}`);
+        if (fileResult.guidance?.resolution) {
+          console.error(`  → ${fileResult.guidance.resolution}`);
+        }
+        exit(1);
+      }
+      fileResult.value.warnings.forEach((warning) => console.error(`⚠️  ${warning}`));
+      fileManagedKeys = applyConfigFileEnv(fileResult.value.env);
+      reloadConfig();
+    }
+
     // Validate CLI options
     const dockerValidation = validateDockerSocket(options);
     const validation = validateOptions(options, dockerValidation);
-    if (!validation.valid) {
+    // CLI flags override env-derived values, so validate the effective settings
+    const configProblems = collectConfigProblems({
+      ...config,
+      server: { ...config.server, logLevel: options.logLevel ?? config.server.logLevel },
+    });
+    if (!validation.valid || configProblems.length > 0) {
       console.error('❌ Configuration errors:');
       validation.errors.forEach((error: string) => console.error(`  • ${error}`));
+      configProblems.forEach((problem) => {
+        console.error(`  • ${problem.message}`);
+        console.error(`    → ${problem.suggestion}`);
+      });
       console.error('\nUse --help for usage information');
       exit(1);
     }
 
     // Set environment variables based on CLI options
-    if (options.logLevel) env.LOG_LEVEL = options.logLevel;
+    if (options.logLevel) {
+      env.LOG_LEVEL = options.logLevel;
+      fileManagedKeys = fileManagedKeys.filter((key) => key !== 'LOG_LEVEL');
+    }
     if (options.workspace) env.WORKSPACE_DIR = options.workspace;
     if (options.dockerSocket) process.env.DOCKER_SOCKET = options.dockerSocket;
+    if (options.autoInstallScanner) {
+      process.env.CONTAINERIZATION_ASSIST_AUTO_INSTALL_SCANNER = 'true';
+    }
     if (options.k8sNamespace) process.env.K8S_NAMESPACE = options.k8sNamespace;
     if (options.dev) process.env.NODE_ENV = 'development';
+    reloadConfig();
 
     // Log configuration summary in development mode
     logConfigSummaryIfDev();
@@ -201,6 +260,16 @@ async function main(): Promise<void> {
       logger: getLogger(),
       ...policyConfig,
       outputFormat: OUTPUTFORMAT.NATURAL_LANGUAGE,
+      maxConcurrentToolExecutions: config.orchestrator.maxConcurrentToolExecutions,
+      maxQueuedToolExecutions: config.orchestrator.maxQueuedToolExecutions,
+      ...(config.workflowState.dirPath && {
+        workflowState: createFileWorkflowStateStore(config.workflowState.dirPath, {
+          maxBytesPerWorkflow: config.workflowState.maxBytesPerWorkflow,
+          maxTotalBytes: config.workflowState.maxTotalBytes,
+          maxIdleMs: config.workflowState.maxIdleMinutes * 60_000,
+          logger: getLogger(),
+        }),
+      }),
     });
 
     if (options.listTools) {
@@ -222,6 +291,20 @@ async function main(): Promise<void> {
       process.exit(0);
     }
 
+    if (options.exportSchemas) {
+      getLogger().info('Exporting tool schemas');
+
+      const schemas = await exportToolSchemas((server) => app.bindToMCP(server, 'schema-export'));
+      if (!schemas.ok) {
+        console.error(`❌ ${schemas.error}`);
+        process.exit(1);
+      }
+
+      // stdout carries only the JSON so it can be redirected to a file
+      process.stdout.write(`${JSON.stringify(schemas.value, null, 2)}\n`);
+      process.exit(0);
+    }
+
     if (options.healthCheck) {
       getLogger().info('Performing health check');
 
@@ -280,12 +363,32 @@ async function main(): Promise<void> {
       !!process.env.MCP_QUIET,
     );
 
+    // Docker tools fail fast with DOCKER_UNAVAILABLE; warn about it at startup too
+    const docker = health.dependencies?.docker;
+    if (docker && !docker.available) {
+      getLogger().warn(
+        { code: DOCKER_UNAVAILABLE, socketPath: docker.socketPath, error: docker.error },
+        'Docker daemon is not reachable; image tools will fail until it is',
+      );
+      if (!process.env.MCP_QUIET) {
+        console.error(`⚠️  Docker: ${docker.error}`);
+        console.error(
+          '   → Start Docker, check access to the socket, or set CONTAINER_RUNTIME=podman',
+        );
+      }
+    }
+
     await app.startServer(transportConfig);
 
     logStartupSuccess(transportConfig, getLogger(), !!process.env.MCP_QUIET);
 
     // Install shutdown handlers
     installShutdownHandlers(app, getLogger(), !!process.env.MCP_QUIET);
+
+    // Reload runtime-safe settings (log level, timeouts) from the config file on SIGHUP
+    if (options.config && isYamlConfigPath(options.config)) {
+      installReloadHandler({ configPath: options.config, fileManagedKeys, logger: getLogger() });
+    }
   } catch (error) {
     logStartupFailure(error as Error, getLogger(), !!process.env.MCP_QUIET);
 
@@ -90,6 +90,7 @@ async function validateToolMetadata(): Promise<
   const validatableTools: ValidatableTool[] = toolsResult.value.map((tool) => ({
     name: tool.name,
     metadata: tool.metadata,
+    schema: tool.schema,
   }));
 
   const validationResult = await validateAllToolMetadata(validatableTools);
@@ -261,7 +262,7 @@ export function createInspectToolsCommand(): Command {
   // Validate command
   cmd
     .command('validate')
-    .description('Validate tool metadata consistency and compliance')
+    .description('Validate tool metadata and input schemas (exits 1 on issues)')
     .option('--json', 'Output as JSON')
     .action(async (options) => {
       try {
@@ -298,6 +299,11 @@ export function createInspectToolsCommand(): Command {
             `Overall: ${validation.valid.length}/${totalTools} tools valid (${validPercentage}%)`,
           );
         }
+
+        // Fail CI runs on tools that would publish an incomplete schema
+        if (validation.invalid.length > 0) {
+          process.exitCode = 1;
+        }
       } catch (error) {
         handleGenericError('Error during operation', error);
       }
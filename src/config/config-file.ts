@@ -0,0 +1,182 @@
+/**
+ * YAML Configuration File Support
+ *
+ * Loads a `.yaml`/`.yml` server configuration file and maps its nested keys
+ * onto the environment variables the rest of the config is built from.
+ *
+ * Precedence (lowest to highest): config file < environment < CLI flags.
+ * File values are only applied to variables that are not already set, and
+ * CLI flags are written to the environment afterwards.
+ *
+ * Relative paths in the file are resolved against the file's directory.
+ */
+
+import { readFileSync } from 'node:fs';
+import { dirname, isAbsolute, resolve } from 'node:path';
+import { parse as parseYaml } from 'yaml';
+import { Success, Failure, type Result } from '@/types';
+import { extractErrorMessage } from '@/lib/errors';
+
+/**
+ * Mapping from config file key paths to environment variable names
+ */
+export const CONFIG_FILE_KEYS: Readonly<Record<string, string>> = {
+  'server.logLevel': 'LOG_LEVEL',
+  'server.port': 'PORT',
+  'workspace.workspaceDir': 'WORKSPACE_DIR',
+  'workspace.maxFileSize': 'MAX_FILE_SIZE',
+  'docker.socketPath': 'DOCKER_SOCKET',
+  'docker.timeout': 'DOCKER_TIMEOUT',
+  'docker.runtime': 'CONTAINER_RUNTIME',
+  'orchestrator.maxConcurrentToolExecutions': 'MAX_CONCURRENT_TOOL_EXECUTIONS',
+  'orchestrator.maxQueuedToolExecutions': 'MAX_QUEUED_TOOL_EXECUTIONS',
+  'toolLogging.dirPath': 'CONTAINERIZATION_ASSIST_TOOL_LOGS_DIR_PATH',
+  'workflowState.dirPath': 'CONTAINERIZATION_ASSIST_WORKFLOW_STATE_DIR',
+  'workflowState.maxBytesPerWorkflow': 'CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_BYTES',
+  'workflowState.maxTotalBytes': 'CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_TOTAL_BYTES',
+  'workflowState.maxIdleMinutes': 'CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_IDLE_MINUTES',
+  'licenses.allowed': 'CONTAINERIZATION_ASSIST_ALLOWED_LICENSES',
+  'licenses.denied': 'CONTAINERIZATION_ASSIST_DENIED_LICENSES',
+  'scanner.autoInstall': 'CONTAINERIZATION_ASSIST_AUTO_INSTALL_SCANNER',
+  policyPath: 'CONTAINERIZATION_ASSIST_POLICY_PATH',
+};
+
+/**
+ * Keys that accept a YAML list, stored as a comma-separated variable
+ */
+const LIST_KEYS: ReadonlySet<string> = new Set(['licenses.allowed', 'licenses.denied']);
+
+/**
+ * Keys holding filesystem paths, resolved relative to the config file
+ */
+const PATH_KEYS: ReadonlySet<string> = new Set([
+  'workspace.workspaceDir',
+  'toolLogging.dirPath',
+  'workflowState.dirPath',
+  'policyPath',
+]);
+
+export interface ConfigFileValues {
+  /** Environment variable assignments derived from the file */
+  env: Record<string, string>;
+  /** Non-fatal issues such as unknown keys */
+  warnings: string[];
+}
+
+/**
+ * Whether a path refers to a YAML config file
+ */
+export function isYamlConfigPath(filePath: string): boolean {
+  return /\.ya?ml$/i.test(filePath);
+}
+
+/**
+ * Flatten nested YAML content into dotted key paths
+ */
+function flatten(value: unknown, prefix: string, out: Record<string, unknown>): void {
+  if (value !== null && typeof value === 'object' && !Array.isArray(value)) {
+    for (const [key, child] of Object.entries(value)) {
+      flatten(child, prefix ? `${prefix}.${key}` : key, out);
+    }
+    return;
+  }
+  out[prefix] = value;
+}
+
+/**
+ * Parse YAML config content into environment assignments
+ *
+ * @param baseDir - Directory relative paths are resolved against; left as written when omitted
+ */
+export function parseConfigFile(content: string, baseDir?: string): Result<ConfigFileValues> {
+  let parsed: unknown;
+  try {
+    parsed = parseYaml(content);
+  } catch (error) {
+    return Failure(`Invalid YAML in config file: ${extractErrorMessage(error)}`, {
+      message: 'Config file is not valid YAML',
+      hint: extractErrorMessage(error),
+      resolution: 'Fix the YAML syntax in the config file',
+    });
+  }
+
+  if (parsed === null || parsed === undefined) {
+    return Success({ env: {}, warnings: [] });
+  }
+
+  if (typeof parsed !== 'object' || Array.isArray(parsed)) {
+    return Failure('Config file must contain a YAML mapping at the top level', {
+      message: 'Unexpected config file structure',
+      resolution: 'Use nested keys such as "server:", "docker:", "workspace:"',
+    });
+  }
+
+  const flat: Record<string, unknown> = {};
+  flatten(parsed, '', flat);
+
+  const env: Record<string, string> = {};
+  const warnings: string[] = [];
+
+  for (const [key, value] of Object.entries(flat)) {
+    const envName = CONFIG_FILE_KEYS[key];
+    if (!envName) {
+      warnings.push(`Unknown config key ignored: ${key}`);
+      continue;
+    }
+    if (value === null || value === undefined) continue;
+    if (LIST_KEYS.has(key) && Array.isArray(value)) {
+      if (value.some((item) => item === null || typeof item === 'object')) {
+        warnings.push(`Config key ${key} must be a list of scalar values; ignored`);
+        continue;
+      }
+      env[envName] = value.map(String).join(',');
+      continue;
+    }
+    if (typeof value === 'object') {
+      warnings.push(`Config key ${key} must be a scalar value; ignored`);
+      continue;
+    }
+    env[envName] =
+      baseDir && PATH_KEYS.has(key) && !isAbsolute(String(value))
+        ? resolve(baseDir, String(value))
+        : String(value);
+  }
+
+  return Success({ env, warnings });
+}
+
+/**
+ * Load and parse a YAML config file from disk
+ */
+export function loadConfigFile(filePath: string): Result<ConfigFileValues> {
+  let content: string;
+  try {
+    content = readFileSync(filePath, 'utf-8');
+  } catch (error) {
+    return Failure(`Cannot read config file ${filePath}: ${extractErrorMessage(error)}`, {
+      message: 'Config file could not be read',
+      resolution: 'Check the --config path and file permissions',
+    });
+  }
+  return parseConfigFile(content, dirname(resolve(filePath)));
+}
+
+/**
+ * Apply config file values to the environment without overriding variables
+ * that are already set.
+ *
+ * @returns Names of the variables that were applied
+ */
+export function applyConfigFileEnv(
+  values: Record<string, string>,
+  env: NodeJS.ProcessEnv = process.env,
+): string[] {
+  const applied: string[] = [];
+  for (const [name, value] of Object.entries(values)) {
+    if (env[name] === undefined || env[name] === '') {
+      env[name] = value;
+      applied.push(name);
+    }
+  }
+  return applied;
+}
@@ -60,6 +60,10 @@ export const DEFAULT_TIMEOUTS = {
   healthCheck: 5_000,
   /** Trivy version check timeout: 15 seconds. */
   trivyVersionCheck: 15_000,
+  /** Scanner auto-install download timeout: 2 minutes. */
+  scannerInstall: 120_000,
+  /** Supply-chain tool (syft, cosign) availability check timeout: 15 seconds. */
+  toolVersionCheck: 15_000,
   /** Cluster stabilization wait: 5 seconds. */
   clusterStabilization: 5_000,
 } as const;
@@ -15,30 +15,95 @@ export type { RegoEvaluator, RegoPolicyResult, RegoPolicyViolation } from './pol
 export { loadPolicy, loadAndMergePolicies, clearPolicyCache } from './policy-io';
 export { applyPolicy } from './policy-eval';
 
-export const config = {
-  server: {
-    logLevel: parseStringEnv('LOG_LEVEL', 'info'),
-    port: parseIntEnv('PORT', 3000),
-  },
+// Export startup configuration validation
+export { validateConfig, collectConfigProblems, VALID_LOG_LEVELS } from './validation';
+export type { ConfigProblem, ValidatableConfig } from './validation';
 
-  workspace: {
-    workspaceDir: parseStringEnv('WORKSPACE_DIR', process.cwd()),
-    maxFileSize: parseIntEnv('MAX_FILE_SIZE', 10485760),
-  },
+interface ConfigValues {
+  server: { logLevel: string; port: number };
+  workspace: { workspaceDir: string; maxFileSize: number };
+  docker: { socketPath: string; timeout: number };
+  orchestrator: { maxConcurrentToolExecutions: number; maxQueuedToolExecutions: number };
+  toolLogging: { dirPath: string };
+  workflowState: {
+    dirPath: string;
+    maxBytesPerWorkflow: number;
+    maxTotalBytes: number;
+    maxIdleMinutes: number;
+  };
+}
 
-  docker: {
-    socketPath: parseStringEnv('DOCKER_SOCKET', autoDetectDockerSocket()),
-    timeout: parseIntEnv('DOCKER_TIMEOUT', 60000),
-  },
+/**
+ * Build configuration values from the current environment
+ */
+function buildConfig(): ConfigValues {
+  return {
+    server: {
+      logLevel: parseStringEnv('LOG_LEVEL', 'info'),
+      port: parseIntEnv('PORT', 3000),
+    },
+
+    workspace: {
+      workspaceDir: parseStringEnv('WORKSPACE_DIR', process.cwd()),
+      maxFileSize: parseIntEnv('MAX_FILE_SIZE', 10485760),
+    },
+
+    docker: {
+      socketPath: parseStringEnv('DOCKER_SOCKET', autoDetectDockerSocket()),
+      timeout: parseIntEnv('DOCKER_TIMEOUT', 60000),
+    },
+
+    orchestrator: {
+      maxConcurrentToolExecutions: parseIntEnv('MAX_CONCURRENT_TOOL_EXECUTIONS', 0),
+      maxQueuedToolExecutions: parseIntEnv('MAX_QUEUED_TOOL_EXECUTIONS', 0),
+    },
+
+    toolLogging: {
+      dirPath: parseStringEnv('CONTAINERIZATION_ASSIST_TOOL_LOGS_DIR_PATH', ''),
+    },
+
+    workflowState: {
+      dirPath: parseStringEnv('CONTAINERIZATION_ASSIST_WORKFLOW_STATE_DIR', ''),
+      maxBytesPerWorkflow: parseIntEnv('CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_BYTES', 0),
+      maxTotalBytes: parseIntEnv('CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_TOTAL_BYTES', 0),
+      maxIdleMinutes: parseIntEnv('CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_IDLE_MINUTES', 0),
+    },
+  };
+}
+
+const initial = buildConfig();
 
+export const config = {
+  server: initial.server,
+  workspace: initial.workspace,
+  docker: initial.docker,
+  orchestrator: initial.orchestrator,
   toolLogging: {
-    dirPath: parseStringEnv('CONTAINERIZATION_ASSIST_TOOL_LOGS_DIR_PATH', ''),
+    dirPath: initial.toolLogging.dirPath,
     get enabled() {
       return this.dirPath.trim().length > 0;
     },
   },
+  workflowState: initial.workflowState,
 } as const;
 
+/**
+ * Re-read configuration from the environment, updating `config` in place.
+ *
+ * Call after the environment changes at startup (config file values, CLI flags)
+ * so modules holding a reference to `config` observe the effective settings.
+ */
+export function reloadConfig(): void {
+  const fresh = buildConfig();
+  Object.assign(config.server, fresh.server);
+  Object.assign(config.workspace, fresh.workspace);
+  Object.assign(config.docker, fresh.docker);
+  Object.assign(config.orchestrator, fresh.orchestrator);
+  // toolLogging.enabled is a getter, so only the backing field is copied
+  Object.assign(config.toolLogging, { dirPath: fresh.toolLogging.dirPath });
+  Object.assign(config.workflowState, fresh.workflowState);
+}
+
 // Export the type for use throughout the application
 export type AppConfig = typeof config;
 
@@ -0,0 +1,121 @@
+/**
+ * Runtime Configuration Reload
+ *
+ * Re-reads the YAML config file on SIGHUP and applies the subset of settings
+ * that are safe to change while the server is running. Transport, workspace
+ * and orchestrator settings stay fixed until restart.
+ *
+ * Only variables that were originally populated from the config file are
+ * refreshed; values set by the environment or CLI flags keep precedence.
+ */
+
+import type { Logger } from 'pino';
+import { Success, Failure, type Result } from '@/types';
+import { loadConfigFile } from './config-file';
+import { collectConfigProblems } from './validation';
+import { config, reloadConfig } from './index';
+
+/**
+ * Environment variables that may change at runtime
+ */
+export const RELOADABLE_ENV_KEYS = ['LOG_LEVEL', 'DOCKER_TIMEOUT'] as const;
+
+export interface ReloadOptions {
+  /** Path to the YAML config file to re-read */
+  configPath: string;
+  /** Variables originally populated from the config file */
+  fileManagedKeys: readonly string[];
+  /** Logger whose level is updated on reload */
+  logger: Logger;
+}
+
+export interface ReloadSummary {
+  /** Changed variables with their previous and new values */
+  changes: Record<string, { from: string | undefined; to: string }>;
+}
+
+/**
+ * Re-read the config file and apply reloadable settings atomically.
+ * Either every change is applied or none is.
+ */
+export function reloadRuntimeConfig(options: ReloadOptions): Result<ReloadSummary> {
+  const { configPath, fileManagedKeys, logger } = options;
+
+  const fileResult = loadConfigFile(configPath);
+  if (!fileResult.ok) return fileResult;
+
+  const pending: Record<string, string> = {};
+  for (const key of RELOADABLE_ENV_KEYS) {
+    const value = fileResult.value.env[key];
+    if (value !== undefined && fileManagedKeys.includes(key) && process.env[key] !== value) {
+      pending[key] = value;
+    }
+  }
+
+  // Validate the candidate config before touching anything
+  const candidate = {
+    ...config,
+    server: { ...config.server, logLevel: pending.LOG_LEVEL ?? config.server.logLevel },
+    docker: {
+      ...config.docker,
+      timeout:
+        pending.DOCKER_TIMEOUT !== undefined
+          ? parseInt(pending.DOCKER_TIMEOUT, 10)
+          : config.docker.timeout,
+    },
+  };
+  const problems = collectConfigProblems(candidate, { ...process.env, ...pending });
+  if (problems.length > 0) {
+    return Failure(
+      `Config reload rejected: ${problems.map((p) => p.message).join('; ')}`,
+      {
+        message: 'Reloaded configuration is invalid; previous settings kept',
+        resolution: problems.map((p) => p.suggestion).join('\n'),
+        details: { problems },
+      },
+    );
+  }
+
+  const changes: ReloadSummary['changes'] = {};
+  for (const [key, value] of Object.entries(pending)) {
+    changes[key] = { from: process.env[key], to: value };
+    process.env[key] = value;
+  }
+
+  if (Object.keys(changes).length > 0) {
+    reloadConfig();
+    logger.level = config.server.logLevel;
+  }
+
+  return Success({ changes });
+}
+
+/**
+ * Install a SIGHUP handler that reloads runtime-safe settings
+ *
+ * @returns Function that removes the handler
+ */
+export function installReloadHandler(options: ReloadOptions): () => void {
+  const { logger, configPath } = options;
+
+  const handler = (): void => {
+    const result = reloadRuntimeConfig(options);
+    if (!result.ok) {
+      logger.error({ configPath, error: result.error }, 'Configuration reload failed');
+      return;
+    }
+
+    const changed = Object.keys(result.value.changes);
+    logger.info(
+      { configPath, changes: result.value.changes },
+      changed.length > 0
+        ? `Configuration reloaded: ${changed.join(', ')} updated`
+        : 'Configuration reloaded: no runtime settings changed',
+    );
+  };
+
+  process.on('SIGHUP', handler);
+  return () => {
+    process.off('SIGHUP', handler);
+  };
+}
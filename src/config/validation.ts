@@ -0,0 +1,175 @@
+/**
+ * Configuration Validation
+ *
+ * Checks the resolved configuration at startup and reports every problem at
+ * once, each with a suggested fix, so misconfigurations surface before the
+ * server starts rather than mid-request.
+ */
+
+import { Success, Failure, type Result } from '@/types';
+
+/**
+ * Log levels accepted by the logger
+ */
+export const VALID_LOG_LEVELS = ['trace', 'debug', 'info', 'warn', 'error', 'fatal'] as const;
+
+/**
+ * A single configuration problem with a recovery suggestion
+ */
+export interface ConfigProblem {
+  /** Dotted path to the offending setting (e.g. "docker.timeout") */
+  field: string;
+  message: string;
+  suggestion: string;
+}
+
+/**
+ * Subset of the application config that is validated at startup
+ */
+export interface ValidatableConfig {
+  readonly server: { readonly logLevel: string; readonly port: number };
+  readonly workspace: { readonly workspaceDir: string; readonly maxFileSize: number };
+  readonly docker: { readonly socketPath: string; readonly timeout: number };
+  readonly orchestrator: {
+    readonly maxConcurrentToolExecutions: number;
+    readonly maxQueuedToolExecutions?: number;
+  };
+}
+
+/**
+ * Integer settings keyed by the environment variable they are read from.
+ * parseIntEnv falls back to the default for unparseable input, so the raw
+ * strings are checked separately.
+ */
+const INTEGER_ENV_FIELDS: Record<string, string> = {
+  PORT: 'server.port',
+  MAX_FILE_SIZE: 'workspace.maxFileSize',
+  DOCKER_TIMEOUT: 'docker.timeout',
+  MAX_CONCURRENT_TOOL_EXECUTIONS: 'orchestrator.maxConcurrentToolExecutions',
+  MAX_QUEUED_TOOL_EXECUTIONS: 'orchestrator.maxQueuedToolExecutions',
+  CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_BYTES: 'workflowState.maxBytesPerWorkflow',
+  CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_TOTAL_BYTES: 'workflowState.maxTotalBytes',
+  CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_IDLE_MINUTES: 'workflowState.maxIdleMinutes',
+};
+
+/**
+ * Report integer variables whose raw value is not a whole number
+ */
+function collectMalformedIntegers(env: NodeJS.ProcessEnv): ConfigProblem[] {
+  const problems: ConfigProblem[] = [];
+  for (const [key, field] of Object.entries(INTEGER_ENV_FIELDS)) {
+    const raw = env[key];
+    if (raw && !/^\s*-?\d+\s*$/.test(raw)) {
+      problems.push({
+        field,
+        message: `${key} is not an integer: "${raw}"`,
+        suggestion: `Set ${key} to a whole number, or unset it to use the default`,
+      });
+    }
+  }
+  return problems;
+}
+
+/**
+ * Collect every problem in the given configuration
+ *
+ * @param cfg - Resolved configuration values
+ * @param env - Environment the values were parsed from (default: process.env)
+ */
+export function collectConfigProblems(
+  cfg: ValidatableConfig,
+  env: NodeJS.ProcessEnv = process.env,
+): ConfigProblem[] {
+  const problems = collectMalformedIntegers(env);
+  // Range checks on a malformed setting would only see its fallback value
+  const isWellFormed = (field: string): boolean => !problems.some((p) => p.field === field);
+
+  if (!(VALID_LOG_LEVELS as readonly string[]).includes(cfg.server.logLevel)) {
+    problems.push({
+      field: 'server.logLevel',
+      message: `Invalid log level: ${cfg.server.logLevel}`,
+      suggestion: `Set LOG_LEVEL to one of: ${VALID_LOG_LEVELS.join(', ')}`,
+    });
+  }
+
+  const { port } = cfg.server;
+  if (isWellFormed('server.port') && (!Number.isInteger(port) || port < 1 || port > 65535)) {
+    problems.push({
+      field: 'server.port',
+      message: `Port must be between 1 and 65535, got ${port}`,
+      suggestion: 'Set PORT to a valid TCP port (e.g. 3000)',
+    });
+  }
+
+  if (cfg.workspace.workspaceDir.trim().length === 0) {
+    problems.push({
+      field: 'workspace.workspaceDir',
+      message: 'Workspace directory is empty',
+      suggestion: 'Set WORKSPACE_DIR or pass --workspace <path>',
+    });
+  }
+
+  if (isWellFormed('workspace.maxFileSize') && cfg.workspace.maxFileSize <= 0) {
+    problems.push({
+      field: 'workspace.maxFileSize',
+      message: `MaxFileSize must be positive, got ${cfg.workspace.maxFileSize}`,
+      suggestion: 'Set MAX_FILE_SIZE to a size in bytes (default: 10485760)',
+    });
+  }
+
+  if (isWellFormed('docker.timeout') && cfg.docker.timeout <= 0) {
+    problems.push({
+      field: 'docker.timeout',
+      message: `Docker timeout must be positive, got ${cfg.docker.timeout}`,
+      suggestion: 'Set DOCKER_TIMEOUT to a duration in milliseconds (default: 60000)',
+    });
+  }
+
+  const maxConcurrent = cfg.orchestrator.maxConcurrentToolExecutions;
+  if (
+    isWellFormed('orchestrator.maxConcurrentToolExecutions') &&
+    (!Number.isInteger(maxConcurrent) || maxConcurrent < 0)
+  ) {
+    problems.push({
+      field: 'orchestrator.maxConcurrentToolExecutions',
+      message: `MaxConcurrentToolExecutions must be zero or a positive integer, got ${maxConcurrent}`,
+      suggestion: 'Set MAX_CONCURRENT_TOOL_EXECUTIONS to 0 (unlimited) or a positive limit',
+    });
+  }
+
+  const maxQueued = cfg.orchestrator.maxQueuedToolExecutions;
+  if (
+    isWellFormed('orchestrator.maxQueuedToolExecutions') &&
+    maxQueued !== undefined &&
+    (!Number.isInteger(maxQueued) || maxQueued < 0)
+  ) {
+    problems.push({
+      field: 'orchestrator.maxQueuedToolExecutions',
+      message: `MaxQueuedToolExecutions must be zero or a positive integer, got ${maxQueued}`,
+      suggestion: 'Set MAX_QUEUED_TOOL_EXECUTIONS to 0 (unbounded) or a positive limit',
+    });
+  }
+
+  return problems;
+}
+
+/**
+ * Validate configuration, failing with all problems enumerated in the guidance
+ */
+export function validateConfig(
+  cfg: ValidatableConfig,
+  env: NodeJS.ProcessEnv = process.env,
+): Result<void> {
+  const problems = collectConfigProblems(cfg, env);
+  if (problems.length === 0) {
+    return Success(undefined);
+  }
+
+  const summary = problems.map((p) => `${p.field}: ${p.message}`).join('; ');
+  return Failure(`Invalid configuration (${problems.length} problem(s)): ${summary}`, {
+    message: `Found ${problems.length} configuration problem(s)`,
+    hint: 'Configuration is read from environment variables and CLI flags at startup',
+    resolution: problems.map((p) => p.suggestion).join('\n'),
+    details: { problems },
+  });
+}
@@ -48,6 +48,7 @@ export type { TransportConfig } from './app/index.js';
  * - `ToolInputMap`: Type mapping from tool names to their input parameter schemas
  * - `ToolResultMap`: Type mapping from tool names to their output result types
  * - `ExecutionMetadata`: Metadata about tool execution (timing, errors, etc.)
+ * - `ToolDescriptor`: A registered tool's metadata and capabilities (from `listToolMetadata`)
  * - `CreateAppRuntime`: Factory function signature for creating runtimes
  *
  * @public
@@ -58,9 +59,53 @@ export type {
   ToolInputMap,
   ToolResultMap,
   ExecutionMetadata,
+  ToolDescriptor,
   CreateAppRuntime,
 } from './types/runtime.js';
 
+/**
+ * Workflow orchestration for running tools as a dependency graph.
+ *
+ * - `createWorkflow`: Validate steps (unknown dependencies, duplicates, cycles) and build a workflow
+ * - `runWorkflow`: Run independent steps in parallel, passing outputs to dependents
+ * - `toolStep`: Step that executes a tool through an `AppRuntime`
+ *
+ * A failed step skips its dependents while unrelated branches continue. A
+ * step with a `skipIf` predicate that matches is marked skipped
+ * (`skippedByCondition`) without blocking its dependents.
+ *
+ * @example
+ * ```typescript
+ * import { createApp, createWorkflow, runWorkflow, toolStep } from 'containerization-assist';
+ *
+ * const app = createApp();
+ * const workflow = createWorkflow([
+ *   toolStep(app, { id: 'analyze', toolName: 'analyze-repo', params: ({ input }) => input }),
+ *   toolStep(app, {
+ *     id: 'dockerfile',
+ *     toolName: 'generate-dockerfile',
+ *     dependsOn: ['analyze'],
+ *     params: ({ input }) => ({ repositoryPath: input.repositoryPath }),
+ *   }),
+ * ]);
+ * if (workflow.ok) {
+ *   const run = await runWorkflow(workflow.value, { repositoryPath: './my-app' });
+ * }
+ * ```
+ *
+ * @public
+ */
+export { createWorkflow, runWorkflow, toolStep } from './app/workflow.js';
+export type {
+  Workflow,
+  WorkflowStep,
+  WorkflowRun,
+  StepContext,
+  StepOutcome,
+  StepStatus,
+  RunWorkflowOptions,
+} from './app/workflow.js';
+
 /**
  * Core type definitions for MCP tools and result handling.
  *
@@ -208,9 +253,9 @@ export { tool } from './types/tool.js';
  *
  * Tools are organized by workflow stage:
  * 1. Analysis: `analyzeRepoTool` - Detect language, framework, and dependencies
- * 2. Dockerfile: `generateDockerfileTool`, `fixDockerfileTool`, `validateDockerfileTool`
+ * 2. Dockerfile: `generateDockerfileTool`, `fixDockerfileTool`, `optimizeDockerfileTool`, `validateDockerfileTool`
  * 3. Build: `buildImageTool`, `scanImageTool`, `tagImageTool`, `pushImageTool`
- * 4. Deploy: `generateK8sManifestsTool`, `prepareClusterTool`, `verifyDeployTool`
+ * 4. Deploy: `generateK8sManifestsTool`, `prepareClusterTool`, `verifyDeployTool`, `rollbackDeployTool`
  * 5. Operations: `opsTool` - Operational utilities
  *
  * @public
@@ -223,13 +268,224 @@ export {
   generateDockerfileTool,
   generateK8sManifestsTool,
   opsTool,
+  optimizeDockerfileTool,
   prepareClusterTool,
   pushImageTool,
+  rollbackDeployTool,
   scanImageTool,
   tagImageTool,
   verifyDeployTool,
 } from './tools/index.js';
 
+/**
+ * Tool execution metrics recorder.
+ *
+ * Pass an instance via `createApp({ metrics })` to record per-tool latency
+ * histograms and success/failure counters; runs stopped by the caller are
+ * counted with `status="cancelled"`, not as failures. `render()` returns the
+ * Prometheus text exposition format for serving from a scrape endpoint.
+ *
+ * @example
+ * ```typescript
+ * import { createApp, createToolMetrics } from 'containerization-assist';
+ *
+ * const metrics = createToolMetrics();
+ * const app = createApp({ metrics });
+ * // ... later, from your /metrics handler:
+ * res.end(metrics.render());
+ * ```
+ *
+ * @public
+ */
+export { createToolMetrics, TOOL_DURATION_BUCKETS } from './lib/tool-metrics.js';
+export type { ToolMetrics, ToolMetricSample } from './lib/tool-metrics.js';
+
+/**
+ * Per-tool circuit breaker.
+ *
+ * Pass an instance via `createApp({ circuitBreaker })` to stop running a tool
+ * after repeated consecutive failures. While a circuit is open, calls fail
+ * immediately with `guidance.details.code === 'CIRCUIT_OPEN'`; after the
+ * cool-down one trial call decides whether the circuit closes again.
+ * Cancelled calls, including batch siblings aborted by `stopOnFailure`, are
+ * not counted as failures.
+ *
+ * @example
+ * ```typescript
+ * import { createApp, createCircuitBreaker } from 'containerization-assist';
+ *
+ * const app = createApp({
+ *   circuitBreaker: createCircuitBreaker({ failureThreshold: 3, cooldownMs: 60_000 }),
+ * });
+ * ```
+ *
+ * @public
+ */
+export { createCircuitBreaker, CIRCUIT_OPEN } from './lib/circuit-breaker.js';
+export type { CircuitBreaker, CircuitBreakerOptions, CircuitState } from './lib/circuit-breaker.js';
+
+/**
+ * Failure code for requests rejected because the execution queue is full.
+ *
+ * Set `createApp({ maxConcurrentToolExecutions, maxQueuedToolExecutions })` to
+ * bound how many requests wait for a slot. Requests beyond that fail
+ * immediately with `guidance.details.code === 'QUEUE_FULL'` instead of
+ * queuing; the failure is retryable.
+ *
+ * @public
+ */
+export { QUEUE_FULL } from './lib/concurrency.js';
+
+/**
+ * Failure code for tool arguments that do not match the tool's input schema.
+ *
+ * Every tool validates its arguments before it runs. Malformed arguments fail
+ * with `guidance.details.code === 'VALIDATION_FAILED'`, and
+ * `guidance.details.issues` lists each problem as `{ path, message }`; the
+ * failure is not retryable.
+ *
+ * @public
+ */
+export { VALIDATION_FAILED } from './lib/zod-utils.js';
+export type { ArgumentIssue } from './lib/zod-utils.js';
+
+/**
+ * Result cache for idempotent tools.
+ *
+ * Pass an instance via `createApp({ resultCache })` to reuse results of tools
+ * that declare `metadata.cacheTtlMs` (e.g. analyze-repo) for identical
+ * arguments. A tool with a `cacheFingerprint` is only served from the cache
+ * while its inputs on disk are unchanged. Tools that change state, such as
+ * deploy, are never cached.
+ * Cache hits are recorded in metrics with `status="cache_hit"`.
+ *
+ * @example
+ * ```typescript
+ * import { createApp, createResultCache } from 'containerization-assist';
+ *
+ * const app = createApp({ resultCache: createResultCache({ ttlMs: { 'analyze-repo': 60_000 } }) });
+ * ```
+ *
+ * @public
+ */
+export { createResultCache } from './lib/result-cache.js';
+export type { ResultCache, ResultCacheOptions } from './lib/result-cache.js';
+
+/**
+ * Tool execution audit log.
+ *
+ * Pass an instance via `createApp({ audit })` to record every tool execution
+ * with its session and request ids, an argument digest (never the raw
+ * arguments), duration and outcome. Writes are buffered and best-effort;
+ * `droppedCount()` reports records that could not be written.
+ *
+ * When the sink can read records back (the JSONL and memory sinks can),
+ * `trace()` returns a session's executions in order, and servers started with
+ * `startServer` serve them as the `containerization://trace/{sessionId}`
+ * resource (query parameters `since` and `limit`).
+ *
+ * @example
+ * ```typescript
+ * import { createApp, createAuditLog, createJsonlAuditSink } from 'containerization-assist';
+ *
+ * const audit = createAuditLog(createJsonlAuditSink('/var/log/containerization-audit.jsonl'));
+ * const app = createApp({ audit });
+ *
+ * const trace = await audit.trace({ sessionId, since: '2025-01-01T12:00:00Z', limit: 50 });
+ * ```
+ *
+ * @public
+ */
+export { createAuditLog, createJsonlAuditSink, createMemoryAuditSink } from './lib/audit.js';
+export type {
+  AuditLog,
+  AuditRecord,
+  AuditSink,
+  AuditOutcome,
+  AuditTrace,
+  AuditTraceQuery,
+} from './lib/audit.js';
+
+/**
+ * Workflow state persistence.
+ *
+ * Pass a store via `createApp({ workflowState })` to save each completed
+ * pipeline stage (analyze-repo through verify-deploy) with its output, per
+ * session. After a restart the `resume-workflow` tool reports the completed
+ * stages and the next one to run. The same store can be given to
+ * `runWorkflow(..., { state })` so a programmatic workflow skips steps a
+ * previous run already completed.
+ *
+ * The file store can cap disk use: saves larger than `maxBytesPerWorkflow`
+ * throw a `DiskQuotaExceededError` (code `DISK_QUOTA_EXCEEDED`), and idle
+ * workflows are evicted, least recently used first, to stay under
+ * `maxTotalBytes`. With `maxIdleMs`, workflows left unused that long are
+ * removed; `store.cleanup()` applies these limits on demand, and the
+ * `cleanup-workspace` tool removes one session's state.
+ *
+ * @example
+ * ```typescript
+ * import { createApp, createFileWorkflowStateStore } from 'containerization-assist';
+ *
+ * const app = createApp({
+ *   workflowState: createFileWorkflowStateStore('/var/lib/ca/workflows', {
+ *     maxBytesPerWorkflow: 5 * 1024 * 1024,
+ *     maxTotalBytes: 500 * 1024 * 1024,
+ *     maxIdleMs: 7 * 24 * 60 * 60 * 1000,
+ *   }),
+ * });
+ * ```
+ *
+ * @public
+ */
+export {
+  createFileWorkflowStateStore,
+  createMemoryWorkflowStateStore,
+  DiskQuotaExceededError,
+  DISK_QUOTA_EXCEEDED,
+  PIPELINE_STAGES,
+} from './lib/workflow-state.js';
+export type {
+  WorkflowState,
+  WorkflowStateStore,
+  WorkflowStateUsage,
+  WorkflowSummary,
+  WorkflowQuery,
+  WorkflowPage,
+  WorkflowCleanupOptions,
+  WorkflowCleanupReport,
+  RemovedWorkflow,
+  FileWorkflowStateStoreOptions,
+  CompletedStage,
+  PipelineStage,
+} from './lib/workflow-state.js';
+
+/**
+ * Image reference parsing shared by the build, tag, push and scan tools.
+ *
+ * `parseImageRef` returns the components as written; `normalizeImageRef`
+ * fills in the implicit Docker Hub registry, `library/` namespace and
+ * `latest` tag.
+ *
+ * @example
+ * ```typescript
+ * import { parseImageRef, normalizeImageRef } from 'containerization-assist';
+ *
+ * const ref = parseImageRef('ubuntu');
+ * if (ref.ok) normalizeImageRef(ref.value); // 'docker.io/library/ubuntu:latest'
+ * ```
+ *
+ * @public
+ */
+export {
+  parseImageRef,
+  formatImageRef,
+  normalizeImageRef,
+  imageRefName,
+  DOCKER_HUB_REGISTRY,
+} from './lib/image-ref.js';
+export type { ImageRef } from './lib/image-ref.js';
+
 /**
  * Utility to extract the shape of a Zod schema for telemetry and type introspection.
  *
@@ -0,0 +1,322 @@
+/**
+ * Docker Buildx integration
+ *
+ * Multi-platform image builds run through the `docker buildx` CLI, since the
+ * Engine API used by the Docker client only builds for a single platform.
+ * Multi-platform results are pushed as a manifest list because the local
+ * image store cannot hold more than one platform per tag. Builds that import
+ * or export a BuildKit cache also go through buildx, whatever the platform count.
+ *
+ * @see https://docs.docker.com/build/building/multi-platform/
+ */
+
+import { execFile } from 'node:child_process';
+import { mkdtemp, readFile, rm } from 'node:fs/promises';
+import { tmpdir } from 'node:os';
+import { join } from 'node:path';
+import { promisify } from 'node:util';
+import type { Logger } from 'pino';
+
+import { extractErrorMessage } from '@/lib/errors';
+import { Success, Failure, type Result } from '@/types';
+import { DEFAULT_TIMEOUTS, LIMITS } from '@/config/constants';
+
+const execFileAsync = promisify(execFile);
+
+/**
+ * Executes a command and returns its output; injectable for tests
+ */
+export type CommandRunner = (
+  command: string,
+  args: string[],
+  options?: { timeout?: number; env?: NodeJS.ProcessEnv },
+) => Promise<{ stdout: string; stderr: string }>;
+
+const defaultRunner: CommandRunner = async (command, args, options) => {
+  const { stdout, stderr } = await execFileAsync(command, args, {
+    maxBuffer: LIMITS.MAX_SCAN_BUFFER,
+    ...(options?.timeout !== undefined && { timeout: options.timeout }),
+    ...(options?.env !== undefined && { env: options.env }),
+  });
+  return { stdout: String(stdout), stderr: String(stderr) };
+};
+
+/**
+ * Options for a buildx build
+ */
+export interface BuildxBuildOptions {
+  /** Build context directory */
+  context: string;
+  /** Dockerfile path relative to the context */
+  dockerfile?: string;
+  /** Image references to tag */
+  tags: string[];
+  /** Target platforms (e.g. linux/amd64, linux/arm64); empty builds for the builder's platform */
+  platforms: string[];
+  /** Build-time variables */
+  buildArgs?: Record<string, string>;
+  /** Cache import sources (e.g. type=registry,ref=..., type=gha) */
+  cacheFrom?: string[];
+  /** Cache export targets (e.g. type=registry,ref=...,mode=max, type=gha) */
+  cacheTo?: string[];
+  /** Path for buildx to write build metadata (digest) to */
+  metadataFile?: string;
+}
+
+/**
+ * Result of a buildx build
+ */
+export interface BuildxBuildResult {
+  /** Manifest list digest (multi-platform) or image manifest digest */
+  digest: string;
+  /** Image config digest, i.e. the local image ID for loaded builds */
+  imageId: string;
+  platforms: string[];
+  /** Whether the result was pushed to the registry */
+  pushed: boolean;
+  /** Build output lines */
+  logs: string[];
+  buildTime: number;
+  /** Layer cache usage, when parseable from the build output */
+  cache?: BuildCacheSummary;
+}
+
+/**
+ * Layer cache usage of a build
+ */
+export interface BuildCacheSummary {
+  /** Build steps served from cache */
+  hits: number;
+  /** Build steps that had to be executed */
+  misses: number;
+}
+
+/**
+ * Buildx availability details
+ */
+export interface BuildxInfo {
+  version: string;
+  builder: string;
+  /** Builder driver (docker, docker-container, kubernetes, remote), when reported */
+  driver?: string;
+  /** Platforms the active builder can target */
+  platforms: string[];
+}
+
+/**
+ * Whether the platforms list requires a multi-platform (manifest list) build
+ */
+export function isMultiPlatform(platforms: string[] | undefined): boolean {
+  return (platforms?.length ?? 0) > 1;
+}
+
+/**
+ * Construct the `docker` argument list for a buildx build.
+ *
+ * Multi-platform builds push a manifest list; single-platform builds load the
+ * result into the local image store. Build args are passed by name only; the
+ * values go in the child process environment (see {@link buildArgEnv}) so they
+ * never appear in the argument list, logs, or error details.
+ */
+export function buildBuildxArgs(options: BuildxBuildOptions): string[] {
+  const args = ['buildx', 'build'];
+
+  if (options.platforms.length > 0) {
+    args.push('--platform', options.platforms.join(','));
+  }
+
+  if (options.dockerfile) {
+    args.push('--file', options.dockerfile);
+  }
+
+  for (const tag of options.tags) {
+    args.push('--tag', tag);
+  }
+
+  for (const key of Object.keys(options.buildArgs ?? {})) {
+    args.push('--build-arg', key);
+  }
+
+  for (const source of options.cacheFrom ?? []) {
+    args.push('--cache-from', source);
+  }
+
+  for (const target of options.cacheTo ?? []) {
+    args.push('--cache-to', target);
+  }
+
+  if (options.metadataFile) {
+    args.push('--metadata-file', options.metadataFile);
+  }
+
+  args.push(isMultiPlatform(options.platforms) ? '--push' : '--load');
+  args.push('--progress', 'plain');
+  args.push(options.context);
+
+  return args;
+}
+
+/**
+ * Environment that supplies build arg values for `--build-arg KEY`.
+ * Docker reads the value of a name-only build arg from its own environment.
+ */
+function buildArgEnv(options: Pick<BuildxBuildOptions, 'buildArgs'>): NodeJS.ProcessEnv {
+  return { ...process.env, ...options.buildArgs, DOCKER_BUILDKIT: '1' };
+}
+
+/**
+ * Summarise layer cache hits and misses from `--progress plain` output.
+ *
+ * Only Dockerfile steps (`#N [stage 2/5] RUN ...`) are counted; internal
+ * steps such as loading the build definition are ignored.
+ *
+ * @returns undefined when the output contains no recognisable build steps
+ */
+export function parseCacheSummary(logs: string[]): BuildCacheSummary | undefined {
+  const steps = new Set<string>();
+  const cached = new Set<string>();
+
+  for (const line of logs) {
+    const step = line.match(/^#(\d+) \[[^\]]*\d+\/\d+\]/);
+    if (step?.[1]) {
+      steps.add(step[1]);
+      continue;
+    }
+    const hit = line.match(/^#(\d+) CACHED\b/);
+    if (hit?.[1]) {
+      cached.add(hit[1]);
+    }
+  }
+
+  if (steps.size === 0) return undefined;
+
+  const hits = [...steps].filter((id) => cached.has(id)).length;
+  return { hits, misses: steps.size - hits };
+}
+
+const BUILDX_SETUP_GUIDANCE =
+  'Install the buildx plugin (bundled with Docker Desktop and docker-buildx-plugin), then create a multi-platform builder: docker buildx create --name multiarch --driver docker-container --use && docker buildx inspect --bootstrap';
+
+/**
+ * Check that buildx is installed and a builder instance is available
+ */
+export async function checkBuildxAvailability(
+  logger: Logger,
+  runner: CommandRunner = defaultRunner,
+): Promise<Result<BuildxInfo>> {
+  let version: string;
+  try {
+    const { stdout } = await runner('docker', ['buildx', 'version'], {
+      timeout: DEFAULT_TIMEOUTS.docker,
+    });
+    version = stdout.trim().split(/\s+/)[1] ?? stdout.trim();
+  } catch (error) {
+    logger.debug({ error: extractErrorMessage(error) }, 'docker buildx version failed');
+    return Failure('Docker buildx is not available', {
+      message: 'Multi-platform builds require docker buildx',
+      hint: 'The docker CLI or its buildx plugin was not found',
+      resolution: BUILDX_SETUP_GUIDANCE,
+      details: { error: extractErrorMessage(error) },
+    });
+  }
+
+  try {
+    const { stdout } = await runner('docker', ['buildx', 'inspect'], {
+      timeout: DEFAULT_TIMEOUTS.docker,
+    });
+    const builder = stdout.match(/^Name:\s*(\S+)/m)?.[1] ?? 'default';
+    const driver = stdout.match(/^Driver:\s*(\S+)/m)?.[1];
+    const platformLine = stdout.match(/^\s*Platforms:\s*(.+)$/m)?.[1] ?? '';
+    const platforms = platformLine
+      .split(',')
+      .map((p) => p.trim().replace(/\*$/, ''))
+      .filter(Boolean);
+
+    return Success({ version, builder, platforms, ...(driver && { driver }) });
+  } catch (error) {
+    return Failure('No docker buildx builder instance is available', {
+      message: 'Buildx is installed but no usable builder was found',
+      hint: 'A builder instance is required to run buildx builds',
+      resolution: BUILDX_SETUP_GUIDANCE,
+      details: { error: extractErrorMessage(error) },
+    });
+  }
+}
+
+/**
+ * Cache export targets the given builder driver cannot write.
+ * The `docker` driver only supports inline cache (embedded in the image).
+ */
+export function unsupportedCacheExports(driver: string | undefined, cacheTo: string[]): string[] {
+  if (driver !== 'docker') return [];
+  return cacheTo.filter((target) => !/(^|,)type=inline(,|$)/.test(target));
+}
+
+/**
+ * Run a buildx build
+ */
+export async function runBuildxBuild(
+  options: Omit<BuildxBuildOptions, 'metadataFile'>,
+  logger: Logger,
+  runner: CommandRunner = defaultRunner,
+): Promise<Result<BuildxBuildResult>> {
+  const startTime = Date.now();
+  const metadataDir = await mkdtemp(join(tmpdir(), 'buildx-'));
+  const metadataFile = join(metadataDir, 'metadata.json');
+  const args = buildBuildxArgs({ ...options, metadataFile });
+  const pushed = isMultiPlatform(options.platforms);
+
+  try {
+    logger.info({ platforms: options.platforms, tags: options.tags, pushed }, 'Starting buildx build');
+    logger.debug({ args }, 'Executing docker buildx');
+
+    // buildx writes progress to stderr
+    const { stdout, stderr } = await runner('docker', args, {
+      timeout: DEFAULT_TIMEOUTS.dockerBuild,
+      env: buildArgEnv(options),
+    });
+    const logs = `${stderr}\n${stdout}`
+      .split('\n')
+      .map((line) => line.trimEnd())
+      .filter(Boolean);
+
+    let digest = '';
+    let imageId = '';
+    try {
+      const metadata = JSON.parse(await readFile(metadataFile, 'utf-8')) as Record<string, unknown>;
+      digest = String(metadata['containerimage.digest'] ?? '');
+      imageId = String(metadata['containerimage.config.digest'] ?? digest);
+    } catch {
+      logger.debug({ metadataFile }, 'Buildx metadata file not available');
+    }
+
+    const cache = parseCacheSummary(logs);
+
+    return Success({
+      digest,
+      imageId,
+      platforms: options.platforms,
+      pushed,
+      logs,
+      buildTime: Date.now() - startTime,
+      ...(cache && { cache }),
+    });
+  } catch (error) {
+    const stderr = (error as { stderr?: string }).stderr ?? '';
+    const logs = stderr.split('\n').filter(Boolean);
+    const errorMessage = extractErrorMessage(error);
+    logger.error({ error: errorMessage, platforms: options.platforms }, 'Buildx build failed');
+
+    return Failure(`Buildx build failed: ${errorMessage}`, {
+      message: pushed ? 'Multi-platform build failed' : 'BuildKit build failed',
+      hint: pushed
+        ? 'Multi-platform builds push to the registry; check that the tags include a registry you are logged in to'
+        : 'Check the build logs for the failing step',
+      resolution:
+        'Review the build logs. For cross-platform RUN steps, ensure QEMU emulation is installed: docker run --privileged --rm tonistiigi/binfmt --install all',
+      details: { buildLogs: logs.slice(-50), args },
+    });
+  } finally {
+    await rm(metadataDir, { recursive: true, force: true }).catch(() => undefined);
+  }
+}
@@ -7,9 +7,16 @@
 import Docker, { DockerOptions } from 'dockerode';
 import tar from 'tar-fs';
 import type { Logger } from 'pino';
-import { Success, Failure, type Result } from '@/types';
+import { Success, Failure, type ErrorGuidance, type Result } from '@/types';
+import { config as appConfig } from '@/config/index';
 import { extractDockerErrorGuidance } from './errors';
-import { autoDetectDockerSocket } from './socket-validation';
+import {
+  dockerUnavailableGuidance,
+  isDaemonConnectionError,
+  probeDockerDaemon,
+  type DockerDaemonInfo,
+} from './daemon';
+import { resolveContainerRuntime, type ContainerRuntimePreference } from './runtime';
 
 /**
  * Docker client configuration options.
@@ -23,6 +30,8 @@ export interface DockerClientConfig {
   port?: number;
   /** Connection timeout in milliseconds */
   timeout?: number;
+  /** Container runtime backend (defaults to CONTAINER_RUNTIME env, then auto-detection) */
+  runtime?: ContainerRuntimePreference;
 }
 
 /**
@@ -105,12 +114,38 @@ export interface DockerImageInfo {
   Size?: number;
   /** ISO 8601 timestamp when the image was created */
   Created?: string;
+  /** Layer diff IDs from the image root filesystem */
+  Layers?: string[];
+}
+
+/**
+ * One entry of an image's build history, newest first as Docker returns it.
+ */
+export interface DockerImageHistoryEntry {
+  /** Image ID for locally known layers, `<missing>` otherwise */
+  Id: string;
+  /** Unix timestamp (seconds) when the layer was created */
+  Created: number;
+  /** Command that created the layer, e.g. `RUN /bin/sh -c npm ci # buildkit` */
+  CreatedBy: string;
+  /** Tags of the image ending at this entry, when it is tagged locally */
+  Tags?: string[] | null;
+  /** Size of the layer in bytes; 0 for metadata-only instructions */
+  Size: number;
+  Comment?: string;
 }
 
 /**
  * Docker client interface for container operations.
  */
 export interface DockerClient {
+  /**
+   * Checks that the daemon answers on the configured socket.
+   * @param timeoutMs - Time to wait for an answer (default: 3 seconds)
+   * @returns Result containing the daemon version, or DOCKER_UNAVAILABLE guidance
+   */
+  ping: (timeoutMs?: number) => Promise<Result<DockerDaemonInfo>>;
+
   /**
    * Builds a Docker image from a Dockerfile.
    * @param options - Build configuration options
@@ -133,6 +168,20 @@ export interface DockerClient {
    */
   inspectImage: (imageId: string) => Promise<Result<DockerImageInfo>>;
 
+  /**
+   * Retrieves the build history of a Docker image.
+   * @param imageId - Image ID or tag
+   * @returns Result containing history entries, newest first, or error
+   */
+  getImageHistory: (imageId: string) => Promise<Result<DockerImageHistoryEntry[]>>;
+
+  /**
+   * Exports a Docker image as a tar archive, like `docker save`.
+   * @param imageId - Image ID or tag
+   * @returns Result containing the archive stream or error
+   */
+  saveImage: (imageId: string) => Promise<Result<NodeJS.ReadableStream>>;
+
   /**
    * Tags a Docker image with a new repository and tag.
    * @param imageId - ID of the image to tag
@@ -209,7 +258,13 @@ function generateDigestFromImageId(imageId: string, logger: Logger): string {
 /**
  * Create base Docker client implementation
  */
-function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
+function createBaseDockerClient(docker: Docker, logger: Logger, socketPath: string): DockerClient {
+  // A daemon that goes away mid-operation gets the same guidance as a failed ping
+  const guidanceFor = (error: unknown): ErrorGuidance =>
+    isDaemonConnectionError(error)
+      ? dockerUnavailableGuidance(socketPath, error)
+      : extractDockerErrorGuidance(error);
+
   // Helper function to fetch image info (used by both getImage and inspectImage)
   const fetchImageInfo = async (id: string): Promise<Result<DockerImageInfo>> => {
     try {
@@ -221,11 +276,12 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
         RepoTags: inspect.RepoTags,
         Size: inspect.Size,
         Created: inspect.Created,
+        ...(inspect.RootFS?.Layers && { Layers: inspect.RootFS.Layers }),
       };
 
       return Success(imageInfo);
     } catch (error) {
-      const guidance = extractDockerErrorGuidance(error);
+      const guidance = guidanceFor(error);
       const errorMessage = `Failed to get image: ${guidance.message}`;
 
       logger.error(
@@ -245,6 +301,17 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
   };
 
   return {
+    async ping(timeoutMs?: number): Promise<Result<DockerDaemonInfo>> {
+      const result = await probeDockerDaemon(docker, socketPath, timeoutMs);
+      if (!result.ok) {
+        logger.warn(
+          { socketPath, hint: result.guidance?.hint, code: result.guidance?.details?.code },
+          'Docker daemon is not reachable',
+        );
+      }
+      return result;
+    },
+
     async buildImage(options: DockerBuildOptions): Promise<Result<DockerBuildResult>> {
       const buildLogs: string[] = [];
       const buildWarnings: string[] = [];
@@ -283,7 +350,7 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
             (err: Error | null, res: DockerBuildResponse[]) => {
               if (err) {
                 // Log detailed error information before rejecting
-                const guidance = extractDockerErrorGuidance(err);
+                const guidance = guidanceFor(err);
                 logger.error(
                   {
                     error: guidance.message,
@@ -381,7 +448,7 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
         logger.debug({ buildResult }, 'Docker build completed successfully');
         return Success(buildResult);
       } catch (error) {
-        const guidance = extractDockerErrorGuidance(error);
+        const guidance = guidanceFor(error);
         const errorMessage = `Build failed: ${guidance.message}`;
 
         logger.error(
@@ -419,6 +486,54 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
       return fetchImageInfo(imageId);
     },
 
+    async getImageHistory(imageId: string): Promise<Result<DockerImageHistoryEntry[]>> {
+      try {
+        const history = (await docker.getImage(imageId).history()) as DockerImageHistoryEntry[];
+        return Success(history);
+      } catch (error) {
+        const guidance = guidanceFor(error);
+        const errorMessage = `Failed to get image history: ${guidance.message}`;
+
+        logger.error(
+          {
+            error: errorMessage,
+            hint: guidance.hint,
+            resolution: guidance.resolution,
+            errorDetails: guidance.details,
+            originalError: error,
+            imageId,
+          },
+          'Docker get image history failed',
+        );
+
+        return Failure(errorMessage, guidance);
+      }
+    },
+
+    async saveImage(imageId: string): Promise<Result<NodeJS.ReadableStream>> {
+      try {
+        const archive = await docker.getImage(imageId).get();
+        return Success(archive);
+      } catch (error) {
+        const guidance = guidanceFor(error);
+        const errorMessage = `Failed to save image: ${guidance.message}`;
+
+        logger.error(
+          {
+            error: errorMessage,
+            hint: guidance.hint,
+            resolution: guidance.resolution,
+            errorDetails: guidance.details,
+            originalError: error,
+            imageId,
+          },
+          'Docker save image failed',
+        );
+
+        return Failure(errorMessage, guidance);
+      }
+    },
+
     async tagImage(imageId: string, repository: string, tag: string): Promise<Result<void>> {
       try {
         const image = docker.getImage(imageId);
@@ -427,7 +542,7 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
         logger.info({ imageId, repository, tag }, 'Image tagged successfully');
         return Success(undefined);
       } catch (error) {
-        const guidance = extractDockerErrorGuidance(error);
+        const guidance = guidanceFor(error);
         const errorMessage = `Failed to tag image: ${guidance.message}`;
 
         logger.error(
@@ -480,7 +595,7 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
             (err: Error | null) => {
               if (err) {
                 // Log detailed error information before rejecting
-                const guidance = extractDockerErrorGuidance(err);
+                const guidance = guidanceFor(err);
                 logger.error(
                   {
                     error: guidance.message,
@@ -550,7 +665,7 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
         }
         return Success(result);
       } catch (error) {
-        const guidance = extractDockerErrorGuidance(error);
+        const guidance = guidanceFor(error);
         const errorMessage = `Failed to push image: ${guidance.message}`;
 
         logger.error(
@@ -580,7 +695,7 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
         logger.debug({ imageId }, 'Image removed');
         return Success(undefined);
       } catch (error) {
-        const guidance = extractDockerErrorGuidance(error);
+        const guidance = guidanceFor(error);
         const errorMessage = `Failed to remove image: ${guidance.message}`;
 
         logger.error(
@@ -609,7 +724,7 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
         logger.debug({ containerId }, 'Container removed');
         return Success(undefined);
       } catch (error) {
-        const guidance = extractDockerErrorGuidance(error);
+        const guidance = guidanceFor(error);
         const errorMessage = `Failed to remove container: ${guidance.message}`;
 
         logger.error(
@@ -642,7 +757,7 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
         );
         return Success(containers);
       } catch (error) {
-        const guidance = extractDockerErrorGuidance(error);
+        const guidance = guidanceFor(error);
         const errorMessage = `Failed to list containers: ${guidance.message}`;
 
         logger.error(
@@ -665,6 +780,9 @@ function createBaseDockerClient(docker: Docker, logger: Logger): DockerClient {
 
 /**
  * Create a Docker client with core operations
+ *
+ * Without an explicit `config.timeout` the request timeout is taken from
+ * DOCKER_TIMEOUT at creation time, so a reloaded value applies to the next client.
  * @param logger - Logger instance for debug output
  * @param config - Optional Docker client configuration
  * @returns DockerClient with build, get, tag, and push operations
@@ -676,8 +794,12 @@ export const createDockerClient = (logger: Logger, config?: DockerClientConfig):
   if (config?.socketPath) {
     socketPath = config.socketPath;
   } else {
-    socketPath = autoDetectDockerSocket();
-    logger.debug({ socketPath }, 'Auto-detected Docker socket');
+    const selection = resolveContainerRuntime(config?.runtime);
+    socketPath = selection.socketPath;
+    logger.debug(
+      { socketPath, runtime: selection.runtime, detected: selection.detected },
+      'Auto-detected container runtime socket',
+    );
   }
 
   // Create Docker client with detected socket path
@@ -692,8 +814,9 @@ export const createDockerClient = (logger: Logger, config?: DockerClientConfig):
     dockerOptions.socketPath = socketPath;
   }
 
-  if (config?.timeout) {
-    dockerOptions.timeout = config.timeout;
+  const timeout = config?.timeout ?? appConfig.docker.timeout;
+  if (timeout > 0) {
+    dockerOptions.timeout = timeout;
   }
 
   const docker = new Docker(dockerOptions);
@@ -701,5 +824,5 @@ export const createDockerClient = (logger: Logger, config?: DockerClientConfig):
   logger.debug({ dockerOptions }, 'Created Docker client');
 
   // Create and return client
-  return createBaseDockerClient(docker, logger);
+  return createBaseDockerClient(docker, logger, socketPath);
 };
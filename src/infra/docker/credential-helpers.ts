@@ -4,6 +4,10 @@
  * Provides integration with Docker credential helpers to automatically
  * retrieve authentication credentials for registries, similar to how
  * Docker CLI works with `az acr login` and other credential providers.
+ *
+ * Credentials are resolved from explicit arguments, then the
+ * CONTAINERIZATION_ASSIST_REGISTRY_AUTH environment variable, then the Docker
+ * config file and its credential helpers. Passwords are never logged.
  */
 
 import { readFile } from 'fs/promises';
@@ -13,11 +17,30 @@ import { spawn } from 'child_process';
 import type { Logger } from 'pino';
 import { Success, Failure, type Result } from '@/types';
 
+/**
+ * Environment variable holding registry credentials as JSON keyed by registry,
+ * in the same shape as the `auths` section of the Docker config, e.g.
+ * `{"myregistry.azurecr.io": {"username": "u", "password": "p"}}`
+ */
+export const REGISTRY_AUTH_ENV = 'CONTAINERIZATION_ASSIST_REGISTRY_AUTH';
+
+/** Server address Docker uses for Docker Hub credentials */
+const DOCKER_HUB_SERVER_ADDRESS = 'https://index.docker.io/v1/';
+
+/**
+ * Credentials for one registry: either username/password or base64 `user:password`
+ */
+export interface RegistryAuthEntry {
+  auth?: string;
+  username?: string;
+  password?: string;
+}
+
 /**
  * Docker configuration structure from ~/.docker/config.json
  */
 export interface DockerConfig {
-  auths?: Record<string, { auth?: string; username?: string; password?: string }>;
+  auths?: Record<string, RegistryAuthEntry>;
   credsStore?: string;
   credHelpers?: Record<string, string>;
 }
@@ -40,12 +63,25 @@ export interface DockerAuthConfig {
   serveraddress: string;
 }
 
+/**
+ * Where credentials were found, in resolution order
+ */
+export type RegistryCredentialSource = 'argument' | 'environment' | 'docker-config';
+
+export interface ResolvedRegistryCredentials {
+  auth: DockerAuthConfig;
+  source: RegistryCredentialSource;
+}
+
 /**
  * Read and parse Docker configuration file
+ *
+ * Honours DOCKER_CONFIG, the directory holding config.json, like the Docker CLI.
  */
 async function readDockerConfig(logger: Logger): Promise<Result<DockerConfig>> {
   try {
-    const configPath = join(homedir(), '.docker', 'config.json');
+    const configDir = process.env.DOCKER_CONFIG || join(homedir(), '.docker');
+    const configPath = join(configDir, 'config.json');
     const configContent = await readFile(configPath, 'utf-8');
     const config = JSON.parse(configContent) as DockerConfig;
 
@@ -63,7 +99,7 @@ async function readDockerConfig(logger: Logger): Promise<Result<DockerConfig>> {
     return Failure(`Failed to read Docker config: ${errorMessage}`, {
       message: 'Unable to read Docker configuration',
       hint: 'Docker config file is corrupted or inaccessible',
-      resolution: 'Check ~/.docker/config.json file permissions and format',
+      resolution: 'Check the permissions and format of config.json in DOCKER_CONFIG or ~/.docker',
       details: { error: errorMessage },
     });
   }
@@ -99,6 +135,96 @@ function normalizeRegistryHostname(registry: string): string {
   return hostname;
 }
 
+/**
+ * Server address Docker expects in an auth config: registry host with port,
+ * or the legacy v1 index URL for Docker Hub
+ */
+function registryServerAddress(registry: string): string {
+  if (normalizeRegistryHostname(registry) === 'docker.io') {
+    return DOCKER_HUB_SERVER_ADDRESS;
+  }
+
+  try {
+    const urlString = registry.includes('://') ? registry : `https://${registry}`;
+    return new URL(urlString).host.toLowerCase();
+  } catch {
+    return (registry.replace(/^https?:\/\//, '').split('/')[0] ?? registry).toLowerCase().trim();
+  }
+}
+
+/**
+ * Find the entry for a registry, matching keys written as hosts or URLs
+ * (e.g. `https://index.docker.io/v1/` for Docker Hub)
+ */
+function findAuthEntry(
+  auths: Record<string, RegistryAuthEntry> | undefined,
+  registry: string,
+): RegistryAuthEntry | undefined {
+  if (!auths) {
+    return undefined;
+  }
+  if (auths[registry]) {
+    return auths[registry];
+  }
+
+  const serverAddress = registryServerAddress(registry);
+  const key = Object.keys(auths).find((k) => registryServerAddress(k) === serverAddress);
+  return key ? auths[key] : undefined;
+}
+
+/**
+ * Username and password from an auth entry, decoding base64 `auth` when needed
+ */
+function decodeAuthEntry(
+  entry: RegistryAuthEntry | undefined,
+): { username: string; password: string } | undefined {
+  if (!entry || typeof entry !== 'object') {
+    return undefined;
+  }
+  if (entry.username && entry.password) {
+    return { username: entry.username, password: entry.password };
+  }
+  if (typeof entry.auth === 'string') {
+    const decoded = Buffer.from(entry.auth, 'base64').toString('utf-8');
+    const separator = decoded.indexOf(':');
+    const username = decoded.slice(0, separator);
+    const password = decoded.slice(separator + 1);
+    if (separator > 0 && password) {
+      return { username, password };
+    }
+  }
+  return undefined;
+}
+
+/**
+ * Parse REGISTRY_AUTH_ENV; an unset variable yields no entries
+ *
+ * SECURITY: parse errors never echo the variable's content.
+ */
+function readRegistryAuthEnv(): Result<Record<string, RegistryAuthEntry>> {
+  const raw = process.env[REGISTRY_AUTH_ENV]?.trim();
+  if (!raw) {
+    return Success({});
+  }
+
+  let parsed: unknown;
+  try {
+    parsed = JSON.parse(raw);
+  } catch {
+    parsed = undefined;
+  }
+
+  if (!parsed || typeof parsed !== 'object' || Array.isArray(parsed)) {
+    return Failure(`Invalid ${REGISTRY_AUTH_ENV}: expected a JSON object keyed by registry`, {
+      message: `${REGISTRY_AUTH_ENV} is not valid JSON credentials`,
+      hint: 'The variable must be a JSON object mapping registry hosts to credentials',
+      resolution: `Set ${REGISTRY_AUTH_ENV}='{"myregistry.azurecr.io": {"username": "...", "password": "..."}}' or unset it`,
+    });
+  }
+
+  return Success(parsed as Record<string, RegistryAuthEntry>);
+}
+
 function isAzureACR(serverUrl: string): boolean {
   let hostname: string;
 
@@ -232,7 +358,7 @@ async function executeCredentialHelper(
             message: 'Credential helper returned incomplete credentials',
             hint: 'Credential helper response missing required fields',
             resolution: 'Check credential helper configuration and re-run authentication (e.g., az acr login)',
-            details: { helperCommand, serverUrl, result },
+            details: { helperCommand, serverUrl },
           }));
           return;
         }
@@ -245,7 +371,7 @@ async function executeCredentialHelper(
           message: 'Invalid JSON response from credential helper',
           hint: 'Credential helper returned malformed JSON',
           resolution: 'Check credential helper configuration and try re-authenticating',
-          details: { helperCommand, serverUrl, stdout, error: errorMessage },
+          details: { helperCommand, serverUrl },
         }));
       }
     });
@@ -296,34 +422,10 @@ export async function getRegistryCredentials(
     logger.debug({ registry, normalizedRegistry }, 'Looking up credentials for registry');
 
     // Check if there are explicit credentials in auths section
-    if (config.auths?.[normalizedRegistry]) {
-      const auth = config.auths[normalizedRegistry];
-      if (auth.username && auth.password) {
-        logger.debug({ registry: normalizedRegistry }, 'Using explicit credentials from config');
-        return Success({
-          username: auth.username,
-          password: auth.password,
-          serveraddress: normalizedRegistry,
-        });
-      }
-
-      // Handle base64 encoded auth
-      if (auth.auth) {
-        try {
-          const decoded = Buffer.from(auth.auth, 'base64').toString('utf-8');
-          const [username, password] = decoded.split(':', 2);
-          if (username && password) {
-            logger.debug({ registry: normalizedRegistry }, 'Using base64 encoded credentials from config');
-            return Success({
-              username,
-              password,
-              serveraddress: normalizedRegistry,
-            });
-          }
-        } catch (decodeError) {
-          logger.warn({ decodeError, registry: normalizedRegistry }, 'Failed to decode base64 auth');
-        }
-      }
+    const configCredentials = decodeAuthEntry(findAuthEntry(config.auths, registry));
+    if (configCredentials) {
+      logger.debug({ registry: normalizedRegistry }, 'Using explicit credentials from config');
+      return Success({ ...configCredentials, serveraddress: registryServerAddress(registry) });
     }
 
     // Check for registry-specific credential helper
@@ -410,3 +512,48 @@ export async function getRegistryCredentials(
     });
   }
 }
+
+/**
+ * Resolve credentials for a registry
+ *
+ * Resolution order is explicit arguments, then REGISTRY_AUTH_ENV, then the
+ * Docker config (`auths`, `credHelpers`, `credsStore`). Returns Success(null)
+ * when no source has credentials, so the caller can proceed unauthenticated.
+ *
+ * @param registry - Registry host or URL, e.g. `myregistry.azurecr.io` or `docker.io`
+ * @param explicit - Credentials passed to the tool, used only when complete
+ */
+export async function resolveRegistryCredentials(
+  registry: string,
+  explicit: { username?: string; password?: string } | undefined,
+  logger: Logger,
+): Promise<Result<ResolvedRegistryCredentials | null>> {
+  if (explicit?.username && explicit.password) {
+    const serveraddress = registryServerAddress(registry);
+    logger.debug({ serveraddress, source: 'argument' }, 'Using provided registry credentials');
+    return Success({
+      auth: { username: explicit.username, password: explicit.password, serveraddress },
+      source: 'argument',
+    });
+  }
+
+  const envResult = readRegistryAuthEnv();
+  if (!envResult.ok) {
+    return envResult;
+  }
+  const envCredentials = decodeAuthEntry(findAuthEntry(envResult.value, registry));
+  if (envCredentials) {
+    const serveraddress = registryServerAddress(registry);
+    logger.debug(
+      { serveraddress, source: 'environment' },
+      `Using credentials from ${REGISTRY_AUTH_ENV}`,
+    );
+    return Success({ auth: { ...envCredentials, serveraddress }, source: 'environment' });
+  }
+
+  const configResult = await getRegistryCredentials(registry, logger);
+  if (!configResult.ok) {
+    return configResult;
+  }
+  return Success(configResult.value && { auth: configResult.value, source: 'docker-config' });
+}
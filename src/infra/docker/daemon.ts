@@ -0,0 +1,131 @@
+/**
+ * Docker daemon reachability
+ *
+ * A missing or unreadable socket otherwise surfaces as a raw `connect ENOENT`
+ * from deep inside a build or push. Tools that need the daemon probe it first
+ * and fail with DOCKER_UNAVAILABLE, naming the socket that was tried and how
+ * to recover; the client maps connection errors mid-operation the same way.
+ */
+
+import { Success, Failure, type ErrorGuidance, type Result } from '@/types';
+import { extractErrorMessage } from '@/lib/errors';
+
+export const DOCKER_UNAVAILABLE = 'DOCKER_UNAVAILABLE';
+
+/**
+ * Default time to wait for the daemon to answer a probe
+ */
+export const DEFAULT_DAEMON_TIMEOUT_MS = 3000;
+
+/**
+ * Daemon that answered a probe
+ */
+export interface DockerDaemonInfo {
+  socketPath: string;
+  version?: string;
+  apiVersion?: string;
+}
+
+/**
+ * Part of the dockerode API used to probe the daemon
+ */
+export interface DockerVersionSource {
+  version(): Promise<{ Version?: string; ApiVersion?: string }>;
+}
+
+const CONNECTION_ERROR_CODES = new Set(['ENOENT', 'EACCES', 'EPERM', 'ECONNREFUSED']);
+
+class DaemonTimeoutError extends Error {
+  constructor(readonly timeoutMs: number) {
+    super(`Docker daemon did not respond within ${timeoutMs}ms`);
+  }
+}
+
+function errorCode(error: unknown): string | undefined {
+  const code = (error as { code?: unknown } | null)?.code;
+  return typeof code === 'string' ? code : undefined;
+}
+
+/**
+ * Whether an error means the daemon socket could not be connected to at all,
+ * as opposed to the daemon rejecting a request
+ */
+export function isDaemonConnectionError(error: unknown): boolean {
+  if (error instanceof DaemonTimeoutError) return true;
+  const code = errorCode(error);
+  const syscall = (error as { syscall?: unknown } | null)?.syscall;
+  return code !== undefined && CONNECTION_ERROR_CODES.has(code) && syscall === 'connect';
+}
+
+function describeCause(socketPath: string, error: unknown): string {
+  if (error instanceof DaemonTimeoutError) {
+    return `The Docker daemon at ${socketPath} did not respond within ${error.timeoutMs}ms`;
+  }
+  switch (errorCode(error)) {
+    case 'ENOENT':
+      return `No Docker socket exists at ${socketPath}`;
+    case 'EACCES':
+    case 'EPERM':
+      return `Permission denied opening the Docker socket at ${socketPath}`;
+    case 'ECONNREFUSED':
+      return `Nothing is accepting connections on ${socketPath}`;
+    default:
+      return `Cannot connect to the Docker daemon at ${socketPath}: ${extractErrorMessage(error)}`;
+  }
+}
+
+/**
+ * Guidance for a daemon that cannot be reached through the given socket
+ */
+export function dockerUnavailableGuidance(socketPath: string, error: unknown): ErrorGuidance {
+  const cause = error instanceof DaemonTimeoutError ? 'ETIMEDOUT' : errorCode(error);
+  return {
+    message: 'Docker daemon is not reachable',
+    hint: describeCause(socketPath, error),
+    resolution:
+      'Start Docker (`sudo systemctl start docker` on Linux, Docker Desktop or Colima on Mac and Windows). ' +
+      'If it is running, check that your user can open the socket (for example by joining the docker group), ' +
+      'point DOCKER_SOCKET or --docker-socket at the right socket, ' +
+      'or set CONTAINER_RUNTIME=podman to use a Podman socket instead.',
+    retryable: error instanceof DaemonTimeoutError,
+    details: {
+      code: DOCKER_UNAVAILABLE,
+      socketPath,
+      ...(cause && { cause }),
+    },
+  };
+}
+
+/**
+ * Ask the daemon for its version, failing with DOCKER_UNAVAILABLE guidance
+ * when it does not answer within the timeout.
+ *
+ * @param docker - dockerode instance, or any source of `version()`
+ * @param socketPath - Socket the instance connects to, reported on failure
+ * @param timeoutMs - Time to wait for an answer
+ */
+export async function probeDockerDaemon(
+  docker: DockerVersionSource,
+  socketPath: string,
+  timeoutMs = DEFAULT_DAEMON_TIMEOUT_MS,
+): Promise<Result<DockerDaemonInfo>> {
+  let timer: NodeJS.Timeout | undefined;
+  try {
+    const version = await Promise.race([
+      docker.version(),
+      new Promise<never>((_, reject) => {
+        timer = setTimeout(() => reject(new DaemonTimeoutError(timeoutMs)), timeoutMs);
+      }),
+    ]);
+    return Success({
+      socketPath,
+      ...(version.Version && { version: version.Version }),
+      ...(version.ApiVersion && { apiVersion: version.ApiVersion }),
+    });
+  } catch (error) {
+    const guidance = dockerUnavailableGuidance(socketPath, error);
+    return Failure(`Docker is unavailable: ${guidance.hint}`, guidance);
+  } finally {
+    clearTimeout(timer);
+  }
+}
@@ -89,6 +89,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'The Docker registry hostname could not be found in DNS',
       resolution:
         'Check your internet connection and verify the registry URL is correct. For private registries, ensure DNS is configured properly.',
+      retryable: false,
       details: buildDetails(error as Error),
     }),
   ),
@@ -104,6 +105,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'Connection to Docker daemon was refused',
       resolution:
         'Ensure Docker is installed and running: `docker ps` should succeed. Check Docker daemon logs if the service is running.',
+      retryable: true,
       details: buildDetails(error as Error),
     }),
   ),
@@ -119,6 +121,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'The operation took too long and was cancelled',
       resolution:
         'Check network connectivity, firewall rules, or try again. For large images, consider increasing timeout settings.',
+      retryable: true,
       details: buildDetails(error as Error),
     }),
   ),
@@ -134,6 +137,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'The Docker daemon or registry closed the connection unexpectedly',
       resolution:
         'Retry the operation. If it persists, check Docker daemon health and network stability.',
+      retryable: true,
       details: buildDetails(error as Error),
     }),
   ),
@@ -149,6 +153,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'Temporary DNS resolution issue',
       resolution:
         'Retry the operation. If it persists, check your DNS configuration and network connectivity.',
+      retryable: true,
       details: buildDetails(error as Error),
     }),
   ),
@@ -164,6 +169,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'No network route to the Docker registry host',
       resolution:
         'Check firewall rules, VPN connection, and network configuration. Verify the registry is accessible from your network.',
+      retryable: true,
       details: buildDetails(error as Error),
     }),
   ),
@@ -179,6 +185,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'No network route to the Docker registry',
       resolution:
         'Check firewall rules, VPN connection, and network configuration. Verify the registry is accessible from your network.',
+      retryable: true,
       details: buildDetails(error as Error),
     }),
   ),
@@ -193,6 +200,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       message: 'Broken pipe - Connection was unexpectedly closed',
       hint: 'Docker daemon or registry closed the connection during operation',
       resolution: 'Retry the operation. Check Docker daemon logs for issues.',
+      retryable: true,
       details: buildDetails(error as Error),
     }),
   ),
@@ -209,6 +217,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'Invalid or missing registry credentials',
       resolution:
         'Run `docker login <registry>` to authenticate, or verify credentials in your Docker config (~/.docker/config.json).',
+      retryable: false,
       details: buildDetails(error as Error),
     }),
   ),
@@ -224,6 +233,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'Your credentials lack permission for this operation',
       resolution:
         'Verify you have push/pull permissions for this repository. Contact registry administrator if needed.',
+      retryable: false,
       details: buildDetails(error as Error),
     }),
   ),
@@ -239,6 +249,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'The requested image or tag does not exist in the registry',
       resolution:
         'Verify the image name and tag are correct. Use `docker images` to list local images or check registry catalog.',
+      retryable: false,
       details: buildDetails(error as Error),
     }),
   ),
@@ -263,6 +274,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
         resolution:
           'The registry is experiencing problems. Retry after a moment or check registry status page.',
         details: buildDetails(dockerError as Error),
+        retryable: true,
       };
     },
   ),
@@ -295,6 +307,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
     hint: 'Cannot connect to Docker socket',
     resolution:
       'Start Docker daemon: `sudo systemctl start docker` (Linux) or start Docker Desktop (Mac/Windows).',
+    retryable: false,
   }),
 
   // Dockerfile syntax errors
@@ -303,6 +316,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
     hint: 'Unknown or misspelled Dockerfile instruction',
     resolution:
       'Check your Dockerfile for syntax errors. Valid instructions include FROM, RUN, COPY, ADD, CMD, ENTRYPOINT, etc.',
+    retryable: false,
   }),
 
   customPattern(
@@ -315,6 +329,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       hint: 'Dockerfile structure is invalid',
       resolution:
         'Ensure your Dockerfile starts with a FROM instruction and follows proper syntax.',
+      retryable: false,
     }),
   ),
 
@@ -328,6 +343,7 @@ const dockerErrorPatterns: ErrorPattern[] = [
       message: error instanceof Error ? error.message : String(error),
       hint: 'Dockerfile not found in the specified location',
       resolution: 'Verify the Dockerfile path is correct and the file exists in the build context.',
+      retryable: false,
     }),
   ),
 ];
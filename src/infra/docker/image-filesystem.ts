@@ -0,0 +1,423 @@
+/**
+ * Image filesystem reconstruction
+ *
+ * Reads `docker save` output and replays the image layers in order to get
+ * the final file tree, honouring OCI whiteouts: `.wh.<name>` deletes a path
+ * from lower layers and `.wh..wh..opq` empties a directory. File contents are
+ * hashed while streaming and never kept, so images of any size can be
+ * compared. Both the legacy (`<id>/layer.tar`) and OCI (`blobs/sha256/...`)
+ * archive layouts are supported, with plain or gzip-compressed layers.
+ */
+
+import { createHash } from 'node:crypto';
+import { posix } from 'node:path';
+import { createGunzip } from 'node:zlib';
+import { extractErrorMessage } from '@/lib/errors';
+import { Success, Failure, type Result } from '@/types';
+
+export type ImageFileType = 'file' | 'directory' | 'symlink' | 'hardlink' | 'other';
+
+export interface ImageFileEntry {
+  /** Absolute path inside the image, e.g. `/usr/bin/node` */
+  path: string;
+  type: ImageFileType;
+  size: number;
+  mode: number;
+  /** sha256 of the content of regular files and hard links */
+  digest?: string;
+  /** Target of symlinks and hard links */
+  linkTarget?: string;
+}
+
+/** Final file tree of an image, keyed by absolute path */
+export type ImageFilesystem = Map<string, ImageFileEntry>;
+
+/** One entry of a layer: a file to add, or a whiteout removing lower-layer content */
+export type LayerChange = ImageFileEntry | { path: string; whiteout: 'path' | 'opaque' };
+
+interface TarEntry {
+  path: string;
+  type: ImageFileType;
+  size: number;
+  mode: number;
+  linkTarget?: string;
+  body: AsyncIterable<Buffer>;
+}
+
+const BLOCK_SIZE = 512;
+const WHITEOUT_PREFIX = '.wh.';
+const OPAQUE_WHITEOUT = '.wh..wh..opq';
+
+const TYPE_FLAGS: Record<string, ImageFileType> = {
+  '0': 'file',
+  '\0': 'file',
+  '7': 'file',
+  '1': 'hardlink',
+  '2': 'symlink',
+  '5': 'directory',
+};
+
+/**
+ * Pull-based reader over a chunked byte stream
+ */
+class ByteReader {
+  private buffer = Buffer.alloc(0);
+  private ended = false;
+
+  constructor(private readonly source: AsyncIterator<Buffer | Uint8Array | string>) {}
+
+  /** Buffer at least `n` bytes; false when the stream ends first */
+  private async fill(n: number): Promise<boolean> {
+    while (this.buffer.length < n && !this.ended) {
+      const next = await this.source.next();
+      if (next.done) {
+        this.ended = true;
+      } else {
+        const chunk = Buffer.from(next.value);
+        this.buffer = this.buffer.length > 0 ? Buffer.concat([this.buffer, chunk]) : chunk;
+      }
+    }
+    return this.buffer.length >= n;
+  }
+
+  /** Exactly `n` bytes, or undefined at end of stream */
+  async read(n: number): Promise<Buffer | undefined> {
+    if (!(await this.fill(n))) {
+      return undefined;
+    }
+    const bytes = this.buffer.subarray(0, n);
+    this.buffer = this.buffer.subarray(n);
+    return bytes;
+  }
+
+  /** Between 1 and `max` bytes */
+  async readSome(max: number): Promise<Buffer> {
+    if (!(await this.fill(1))) {
+      throw new Error('Unexpected end of tar stream');
+    }
+    const bytes = this.buffer.subarray(0, Math.min(max, this.buffer.length));
+    this.buffer = this.buffer.subarray(bytes.length);
+    return bytes;
+  }
+
+  async skip(n: number): Promise<void> {
+    let remaining = n;
+    while (remaining > 0) {
+      remaining -= (await this.readSome(remaining)).length;
+    }
+  }
+}
+
+const readString = (block: Buffer, offset: number, length: number): string => {
+  const field = block.subarray(offset, offset + length);
+  const end = field.indexOf(0);
+  return field.subarray(0, end < 0 ? length : end).toString('utf-8');
+};
+
+const readNumber = (block: Buffer, offset: number, length: number): number => {
+  // GNU base-256 encoding for values that do not fit in octal
+  if ((block[offset] ?? 0) & 0x80) {
+    return block
+      .subarray(offset + 1, offset + length)
+      .reduce((value, byte) => value * 256 + byte, (block[offset] ?? 0) & 0x7f);
+  }
+  const text = readString(block, offset, length).trim();
+  return text ? parseInt(text, 8) : 0;
+};
+
+const hasValidChecksum = (block: Buffer): boolean => {
+  let sum = 0;
+  for (let i = 0; i < BLOCK_SIZE; i++) {
+    sum += i >= 148 && i < 156 ? 0x20 : (block[i] ?? 0);
+  }
+  return sum === readNumber(block, 148, 8);
+};
+
+const readAll = async (body: AsyncIterable<Buffer>): Promise<Buffer> => {
+  const chunks: Buffer[] = [];
+  for await (const chunk of body) {
+    chunks.push(chunk);
+  }
+  return Buffer.concat(chunks);
+};
+
+/** Parse PAX extended header records (`<length> <key>=<value>\n`) */
+const parsePaxRecords = (data: Buffer): Record<string, string> => {
+  const records: Record<string, string> = {};
+  let offset = 0;
+  while (offset < data.length) {
+    const space = data.indexOf(0x20, offset);
+    const length = parseInt(data.subarray(offset, space).toString(), 10);
+    if (space < 0 || !length) break;
+    const record = data.subarray(space + 1, offset + length - 1).toString('utf-8');
+    const equals = record.indexOf('=');
+    if (equals > 0) {
+      records[record.slice(0, equals)] = record.slice(equals + 1);
+    }
+    offset += length;
+  }
+  return records;
+};
+
+/**
+ * Stream the entries of a tar archive
+ *
+ * Each entry's body must be consumed (or ignored) before the next entry is
+ * requested; unread content is skipped automatically.
+ */
+async function* readTarEntries(
+  source: AsyncIterable<Buffer | Uint8Array | string>,
+): AsyncGenerator<TarEntry> {
+  const reader = new ByteReader(source[Symbol.asyncIterator]());
+  let pax: Record<string, string> = {};
+  let longName: string | undefined;
+  let longLink: string | undefined;
+
+  for (;;) {
+    const block = await reader.read(BLOCK_SIZE);
+    if (!block || block.every((byte) => byte === 0)) {
+      return;
+    }
+    if (!hasValidChecksum(block)) {
+      throw new Error('Invalid tar header checksum');
+    }
+
+    const typeFlag = String.fromCharCode(block[156] ?? 0);
+    const size = pax.size ? Number(pax.size) : readNumber(block, 124, 12);
+    const padding = (BLOCK_SIZE - (size % BLOCK_SIZE)) % BLOCK_SIZE;
+    let remaining = size;
+    const body: AsyncIterable<Buffer> = {
+      async *[Symbol.asyncIterator]() {
+        while (remaining > 0) {
+          const chunk = await reader.readSome(remaining);
+          remaining -= chunk.length;
+          yield chunk;
+        }
+      },
+    };
+
+    // Metadata entries describe the entry that follows them
+    if (typeFlag === 'x' || typeFlag === 'L' || typeFlag === 'K' || typeFlag === 'g') {
+      const data = await readAll(body);
+      if (typeFlag === 'x') {
+        pax = parsePaxRecords(data);
+      } else if (typeFlag === 'L') {
+        longName = readString(data, 0, data.length);
+      } else if (typeFlag === 'K') {
+        longLink = readString(data, 0, data.length);
+      }
+      await reader.skip(padding);
+      continue;
+    }
+
+    const prefix = readString(block, 257, 6).startsWith('ustar') ? readString(block, 345, 155) : '';
+    const name = readString(block, 0, 100);
+    const linkTarget = pax.linkpath ?? longLink ?? readString(block, 157, 100);
+    yield {
+      path: pax.path ?? longName ?? (prefix ? `${prefix}/${name}` : name),
+      type: TYPE_FLAGS[typeFlag] ?? 'other',
+      size,
+      mode: readNumber(block, 100, 8),
+      ...(linkTarget && { linkTarget }),
+      body,
+    };
+
+    await reader.skip(remaining + padding);
+    pax = {};
+    longName = undefined;
+    longLink = undefined;
+  }
+}
+
+/**
+ * Gunzip a chunked stream, pulling input only as output is consumed
+ *
+ * Piping would keep reading the enclosing archive after the layer's tar
+ * parser stops, racing with the skip to the next archive entry.
+ */
+async function* gunzipChunks(source: AsyncIterable<Buffer>): AsyncGenerator<Buffer> {
+  const gunzip = createGunzip();
+  const output: Buffer[] = [];
+  let failure: Error | undefined;
+  gunzip.on('data', (chunk: Buffer) => output.push(chunk));
+  gunzip.on('error', (error) => {
+    failure = error;
+  });
+
+  for await (const chunk of source) {
+    await new Promise<void>((resolve) => gunzip.write(chunk, () => resolve()));
+    if (failure) throw failure;
+    yield* output.splice(0);
+  }
+  await new Promise<void>((resolve) => {
+    gunzip.once('error', () => resolve());
+    gunzip.end(() => resolve());
+  });
+  if (failure) throw failure;
+  yield* output.splice(0);
+}
+
+/** `./usr/bin/` -> `/usr/bin` */
+const normalizePath = (path: string): string =>
+  posix.join('/', path.replace(/^\.\//, '')).replace(/\/+$/, '') || '/';
+
+/**
+ * Read the changes of one layer, or undefined when the blob is not a layer
+ */
+async function readLayer(body: AsyncIterable<Buffer>): Promise<LayerChange[] | undefined> {
+  const iterator = body[Symbol.asyncIterator]();
+  const first = await iterator.next();
+  if (first.done || first.value[0] === 0x7b) {
+    // Empty or JSON (image config, OCI index)
+    return undefined;
+  }
+
+  const chained = (async function* () {
+    yield first.value;
+    for (let next = await iterator.next(); !next.done; next = await iterator.next()) {
+      yield next.value;
+    }
+  })();
+  const gzipped = first.value[0] === 0x1f && first.value[1] === 0x8b;
+  const content = gzipped ? gunzipChunks(chained) : chained;
+
+  const changes: LayerChange[] = [];
+  try {
+    for await (const entry of readTarEntries(content)) {
+      const path = normalizePath(entry.path);
+      const base = posix.basename(path);
+      if (base === OPAQUE_WHITEOUT) {
+        changes.push({ path: posix.dirname(path), whiteout: 'opaque' });
+        continue;
+      }
+      if (base.startsWith(WHITEOUT_PREFIX)) {
+        const target = posix.join(posix.dirname(path), base.slice(WHITEOUT_PREFIX.length));
+        changes.push({ path: target, whiteout: 'path' });
+        continue;
+      }
+      if (path === '/') {
+        continue;
+      }
+
+      let digest: string | undefined;
+      if (entry.type === 'file') {
+        const hash = createHash('sha256');
+        for await (const chunk of entry.body) {
+          hash.update(chunk);
+        }
+        digest = `sha256:${hash.digest('hex')}`;
+      }
+      changes.push({
+        path,
+        type: entry.type,
+        size: entry.size,
+        mode: entry.mode,
+        ...(digest && { digest }),
+        ...(entry.linkTarget && {
+          linkTarget:
+            entry.type === 'hardlink' ? normalizePath(entry.linkTarget) : entry.linkTarget,
+        }),
+      });
+    }
+  } catch {
+    return undefined;
+  }
+  return changes;
+}
+
+const removeTree = (fs: ImageFilesystem, path: string, keepRoot: boolean): void => {
+  const prefix = path === '/' ? '/' : `${path}/`;
+  for (const key of fs.keys()) {
+    if (key.startsWith(prefix) || (!keepRoot && key === path)) {
+      fs.delete(key);
+    }
+  }
+};
+
+/**
+ * Apply one layer on top of a filesystem
+ *
+ * Whiteouts only hide content from lower layers, so they are applied before
+ * the layer's own entries.
+ */
+export function applyLayer(fs: ImageFilesystem, changes: LayerChange[]): ImageFilesystem {
+  for (const change of changes) {
+    if ('whiteout' in change) {
+      removeTree(fs, change.path, change.whiteout === 'opaque');
+    }
+  }
+  for (const change of changes) {
+    if ('whiteout' in change) continue;
+
+    if (change.type !== 'directory' && fs.get(change.path)?.type === 'directory') {
+      removeTree(fs, change.path, true);
+    }
+    // Hard links share content with their target
+    const linked = change.type === 'hardlink' && change.linkTarget && fs.get(change.linkTarget);
+    fs.set(
+      change.path,
+      linked
+        ? { ...change, size: linked.size, ...(linked.digest && { digest: linked.digest }) }
+        : change,
+    );
+  }
+  return fs;
+}
+
+/**
+ * Reconstruct an image's final filesystem from `docker save` output
+ *
+ * @param archive - The image archive stream, e.g. from DockerClient.saveImage
+ */
+export async function readImageFilesystem(
+  archive: AsyncIterable<Buffer | Uint8Array | string>,
+): Promise<Result<ImageFilesystem>> {
+  const layers = new Map<string, LayerChange[]>();
+  let manifest: Array<{ Layers?: string[] }> | undefined;
+
+  try {
+    for await (const entry of readTarEntries(archive)) {
+      if (entry.type !== 'file') continue;
+
+      const path = entry.path.replace(/^\.\//, '');
+      if (path === 'manifest.json') {
+        manifest = JSON.parse((await readAll(entry.body)).toString('utf-8'));
+        continue;
+      }
+      const changes = await readLayer(entry.body);
+      if (changes) {
+        layers.set(path, changes);
+      }
+    }
+  } catch (error) {
+    return Failure(`Failed to read image archive: ${extractErrorMessage(error)}`, {
+      message: 'Image archive could not be read',
+      hint: 'The output of docker save was truncated or is not a tar archive',
+      resolution: 'Check that the image exists locally and that Docker is running, then retry',
+    });
+  }
+
+  const layerPaths = manifest?.[0]?.Layers;
+  if (!layerPaths) {
+    return Failure('Image archive has no manifest.json', {
+      message: 'Image archive is missing its manifest',
+      hint: 'The archive does not look like docker save output',
+      resolution: 'Save the image with `docker save <image>` and retry',
+    });
+  }
+
+  const fs: ImageFilesystem = new Map();
+  for (const layerPath of layerPaths) {
+    const changes = layers.get(layerPath.replace(/^\.\//, ''));
+    if (!changes) {
+      return Failure(`Layer ${layerPath} is missing or unreadable in the image archive`, {
+        message: 'Image layer could not be read',
+        hint: 'Only uncompressed and gzip-compressed layers are supported',
+        resolution: 'Re-pull or rebuild the image so its layers are stored locally',
+        details: { layer: layerPath },
+      });
+    }
+    applyLayer(fs, changes);
+  }
+  return Success(fs);
+}
@@ -8,6 +8,7 @@
 import type { Logger } from 'pino';
 import Docker from 'dockerode';
 import { Success, Failure, type Result } from '@/types';
+import { imageRefName, parseImageRef } from '@/lib/image-ref';
 
 // Configuration constants
 const DOCKER_HUB_REQUEST_TIMEOUT_MS = 7000; // 7 seconds
@@ -268,31 +269,6 @@ function getRegistryApiUrl(registryUrl: string): string {
   return baseUrl.endsWith('/v2/') ? baseUrl : `${baseUrl}/v2/`;
 }
 
-/**
- * Parse image name into repository and reference (tag or digest)
- */
-function parseImageName(imageName: string): { repository: string; reference: string } {
-  // Check for digest reference (contains @sha256:)
-  if (imageName.includes('@')) {
-    const parts = imageName.split('@');
-    const repository = parts[0] || imageName;
-    const digest = parts[1] || '';
-    return { repository, reference: digest };
-  }
-
-  // Check for tag reference (contains :)
-  const lastColonIndex = imageName.lastIndexOf(':');
-  if (lastColonIndex > imageName.lastIndexOf('/')) {
-    // Colon is for tag, not port
-    const repository = imageName.substring(0, lastColonIndex);
-    const tag = imageName.substring(lastColonIndex + 1);
-    return { repository, reference: tag };
-  }
-
-  // No tag or digest specified, assume latest
-  return { repository: imageName, reference: 'latest' };
-}
-
 /**
  * List tags from Docker Hub public API
  */
@@ -576,8 +552,10 @@ export async function checkImageExists(
     // Normalize imageName to avoid subtle failures due to whitespace
     const normalizedImageName = imageName.trim();
 
-    // Parse image name into components
-    const { repository, reference } = parseImageName(normalizedImageName);
+    // Parse image name into components; image IDs are logged as given
+    const parsed = parseImageRef(normalizedImageName);
+    const repository = parsed.ok ? imageRefName(parsed.value) : normalizedImageName;
+    const reference = parsed.ok ? (parsed.value.digest ?? parsed.value.tag) : undefined;
 
     logger.debug({ repository, reference }, 'Checking if image exists locally');
 
@@ -0,0 +1,117 @@
+/**
+ * Container runtime selection
+ *
+ * Chooses between the Docker daemon and Podman. Podman serves a
+ * Docker-compatible API on its socket, so both backends share the same
+ * dockerode-based client, result types and error guidance; only the
+ * socket differs. Rootless CI runners without a Docker daemon are picked up
+ * automatically when a Podman socket is present.
+ */
+
+import { homedir } from 'os';
+import { join } from 'path';
+import { findAvailableSocket, getDefaultDockerSockets } from './socket-validation';
+
+/**
+ * Supported container runtimes
+ */
+export type ContainerRuntime = 'docker' | 'podman';
+
+/**
+ * Runtime preference; `auto` prefers Docker and falls back to Podman
+ */
+export type ContainerRuntimePreference = ContainerRuntime | 'auto';
+
+export const CONTAINER_RUNTIMES: readonly ContainerRuntimePreference[] = [
+  'auto',
+  'docker',
+  'podman',
+];
+
+/**
+ * Selected runtime and the socket to connect to
+ */
+export interface RuntimeSelection {
+  runtime: ContainerRuntime;
+  socketPath: string;
+  /** Whether a live socket was found (false means a default fallback path) */
+  detected: boolean;
+}
+
+/**
+ * Get Podman API socket paths in order of preference (rootless first).
+ */
+export function getPodmanSockets(): string[] {
+  const sockets: string[] = [];
+  if (process.env.XDG_RUNTIME_DIR) {
+    sockets.push(join(process.env.XDG_RUNTIME_DIR, 'podman/podman.sock'));
+  }
+  if (typeof process.getuid === 'function') {
+    sockets.push(`/run/user/${process.getuid()}/podman/podman.sock`);
+  }
+  sockets.push(
+    '/run/podman/podman.sock',
+    join(homedir(), '.local/share/containers/podman/machine/podman.sock'),
+  );
+  return Array.from(new Set(sockets));
+}
+
+/**
+ * Parse a runtime preference string, defaulting to `auto` for unknown values
+ */
+export function parseRuntimePreference(value: string | undefined): ContainerRuntimePreference {
+  const normalized = value?.trim().toLowerCase();
+  return CONTAINER_RUNTIMES.includes(normalized as ContainerRuntimePreference)
+    ? (normalized as ContainerRuntimePreference)
+    : 'auto';
+}
+
+/**
+ * Resolve which container runtime to use and where its socket lives.
+ *
+ * @param preference - Explicit runtime or `auto` (default: CONTAINER_RUNTIME env)
+ * @param probe - Socket probe, injectable for tests
+ */
+export function resolveContainerRuntime(
+  preference: ContainerRuntimePreference = parseRuntimePreference(process.env.CONTAINER_RUNTIME),
+  probe: (paths: string[]) => string | null = findAvailableSocket,
+): RuntimeSelection {
+  const podmanSockets = getPodmanSockets();
+
+  // Windows Docker uses a named pipe that cannot be probed on the filesystem
+  if (process.platform === 'win32' && preference !== 'podman') {
+    return { runtime: 'docker', socketPath: 'npipe://./pipe/docker_engine', detected: false };
+  }
+
+  const dockerSockets = getDefaultDockerSockets();
+
+  if (preference === 'podman') {
+    const socket = probe(podmanSockets);
+    return {
+      runtime: 'podman',
+      socketPath: socket ?? podmanSockets[0] ?? '/run/podman/podman.sock',
+      detected: socket !== null,
+    };
+  }
+
+  const dockerSocket = probe(dockerSockets);
+  if (dockerSocket || preference === 'docker') {
+    return {
+      runtime: 'docker',
+      socketPath: dockerSocket ?? dockerSockets[0] ?? '/var/run/docker.sock',
+      detected: dockerSocket !== null,
+    };
+  }
+
+  // auto: Docker socket absent, fall back to Podman when available
+  const podmanSocket = probe(podmanSockets);
+  if (podmanSocket) {
+    return { runtime: 'podman', socketPath: podmanSocket, detected: true };
+  }
+
+  return {
+    runtime: 'docker',
+    socketPath: dockerSockets[0] ?? '/var/run/docker.sock',
+    detected: false,
+  };
+}
@@ -30,9 +30,19 @@ function getColimaSockets(): string[] {
 }
 
 /**
- * Find the first available Docker socket from the given paths (synchronous file-based check).
+ * Default Unix Docker socket paths in order of preference.
  */
-function findAvailableDockerSocket(socketPaths: string[]): string | null {
+export function getDefaultDockerSockets(): string[] {
+  return [
+    '/var/run/docker.sock', // Standard Unix Docker socket
+    ...getColimaSockets(), // Colima sockets
+  ];
+}
+
+/**
+ * Find the first available socket from the given paths (synchronous file-based check).
+ */
+export function findAvailableSocket(socketPaths: string[]): string | null {
   for (const socketPath of socketPaths) {
     try {
       if (existsSync(socketPath)) {
@@ -58,12 +68,7 @@ export function autoDetectDockerSocket(): string {
     return 'npipe://./pipe/docker_engine'; // Windows default pipe, not a socket
   }
 
-  const unixDefaultPaths = [
-    '/var/run/docker.sock', // Standard Unix Docker socket
-    ...getColimaSockets(), // Colima sockets
-  ];
-
-  const availableSocket = findAvailableDockerSocket(unixDefaultPaths);
+  const availableSocket = findAvailableSocket(getDefaultDockerSockets());
   return availableSocket || '/var/run/docker.sock'; // Fallback to default
 }
 
@@ -4,8 +4,7 @@
  */
 
 import type { Logger } from 'pino';
-import Docker from 'dockerode';
-import { autoDetectDockerSocket } from '@/infra/docker/socket-validation';
+import { createDockerClient } from '@/infra/docker/client';
 import { createKubernetesClient } from '@/infra/kubernetes/client';
 import { extractErrorMessage } from '@/lib/errors';
 
@@ -16,6 +15,8 @@ export interface DependencyStatus {
   available: boolean;
   version?: string;
   error?: string;
+  /** Docker socket that was probed */
+  socketPath?: string;
 }
 
 /**
@@ -26,6 +27,9 @@ const DEFAULT_TIMEOUT_MS = 3000;
 /**
  * Check Docker daemon health and connectivity
  *
+ * Probes the same socket the Docker tools connect to, so an unavailable
+ * result here means those tools will fail with DOCKER_UNAVAILABLE.
+ *
  * @param logger - Logger instance for diagnostic output
  * @param options - Optional configuration
  * @returns Docker availability status with version or error details
@@ -37,19 +41,20 @@ export async function checkDockerHealth(
   const timeout = options.timeout ?? DEFAULT_TIMEOUT_MS;
 
   try {
-    const socketPath = autoDetectDockerSocket();
-    const docker = new Docker({ socketPath });
-
-    const versionInfo = await Promise.race([
-      docker.version(),
-      new Promise<never>((_, reject) =>
-        setTimeout(() => reject(new Error('Docker connection timeout')), timeout),
-      ),
-    ]);
+    const result = await createDockerClient(logger).ping(timeout);
+    if (!result.ok) {
+      const socketPath = result.guidance?.details?.socketPath;
+      return {
+        available: false,
+        error: result.error,
+        ...(typeof socketPath === 'string' && { socketPath }),
+      };
+    }
 
     return {
       available: true,
-      version: versionInfo.Version,
+      socketPath: result.value.socketPath,
+      ...(result.value.version && { version: result.value.version }),
     };
   } catch (error) {
     logger.debug({ error }, 'Docker health check failed');
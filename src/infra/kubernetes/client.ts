@@ -10,6 +10,7 @@ import { Success, Failure, type Result } from '@/types';
 import { extractK8sErrorGuidance } from './errors';
 import { discoverAndValidateKubeconfig } from './kubeconfig-discovery';
 import { applyResource as applyK8sResource } from './resource-operations';
+import { waitForRollout, type RolloutProgressCallback } from './rollout';
 
 export interface DeploymentResult {
   ready: boolean;
@@ -17,6 +18,47 @@ export interface DeploymentResult {
   totalReplicas: number;
 }
 
+/**
+ * A recorded Deployment revision, backed by one of its ReplicaSets
+ */
+export interface DeploymentRevision {
+  revision: number;
+  replicaSetName: string;
+  images: string[];
+  /** Whether this revision is the Deployment's current pod template */
+  current: boolean;
+}
+
+/**
+ * Outcome of rolling a Deployment back to an earlier revision
+ */
+export interface DeploymentRollback {
+  /** Revision that was current before the rollback */
+  fromRevision: number;
+  /** Revision whose pod template was restored */
+  toRevision: number;
+}
+
+export interface PodStatus {
+  name: string;
+  phase: string;
+  ready: boolean;
+  restarts: number;
+  /** Waiting/terminated reason of the first unhealthy container, e.g. CrashLoopBackOff */
+  reason?: string;
+}
+
+/**
+ * A Kubernetes event recorded against a pod (scheduling, image pull, probe failures, ...)
+ */
+export interface PodEvent {
+  pod: string;
+  type: string;
+  reason: string;
+  message: string;
+  count: number;
+}
+
 export interface K8sManifest {
   apiVersion: string;
   kind: string;
@@ -38,8 +80,21 @@ export interface KubernetesClient {
     name: string,
     timeoutSeconds: number,
     pollIntervalMs?: number,
+    onProgress?: RolloutProgressCallback,
+    signal?: AbortSignal,
   ) => Promise<Result<DeploymentResult>>;
   ensureNamespace: (namespace: string) => Promise<Result<void>>;
+  listDeploymentRevisions: (
+    namespace: string,
+    name: string,
+  ) => Promise<Result<DeploymentRevision[]>>;
+  rollbackDeployment: (
+    namespace: string,
+    name: string,
+    toRevision?: number,
+  ) => Promise<Result<DeploymentRollback>>;
+  listDeploymentPods: (namespace: string, name: string) => Promise<Result<PodStatus[]>>;
+  listPodEvents: (namespace: string, podNames: string[]) => Promise<Result<PodEvent[]>>;
   ping: () => Promise<boolean>;
   namespaceExists: (namespace: string) => Promise<boolean>;
   checkPermissions: (namespace: string) => Promise<boolean>;
@@ -49,6 +104,18 @@ export interface KubernetesClient {
 // Constants for deployment polling
 const DEPLOYMENT_POLL_INTERVAL_MS = 5000; // 5 seconds
 
+const REVISION_ANNOTATION = 'deployment.kubernetes.io/revision';
+
+function revisionOf(metadata: k8s.V1ObjectMeta | undefined): number {
+  return Number(metadata?.annotations?.[REVISION_ANNOTATION] ?? 0);
+}
+
+function toLabelSelector(labels: Record<string, string> | undefined): string {
+  return Object.entries(labels ?? {})
+    .map(([key, value]) => `${key}=${value}`)
+    .join(',');
+}
+
 /**
  * Create a Kubernetes client with core operations
  *
@@ -161,6 +228,36 @@ export const createKubernetesClient = (
     }
   };
 
+  const k8sFailure = <T>(
+    error: unknown,
+    operation: string,
+    context: Record<string, unknown>,
+  ): Result<T> => {
+    const guidance = extractK8sErrorGuidance(error, operation);
+    const errorMessage = `Failed to ${operation}: ${guidance.message}`;
+    logger.error({ error: errorMessage, hint: guidance.hint, ...context }, `${operation} failed`);
+    return Failure(errorMessage, guidance);
+  };
+
+  const fetchRevisions = async (
+    namespace: string,
+    name: string,
+  ): Promise<{ deployment: k8s.V1Deployment; replicaSets: k8s.V1ReplicaSet[] }> => {
+    const deployment = await k8sApi.readNamespacedDeployment({ name, namespace });
+    const replicaSets = await k8sApi.listNamespacedReplicaSet({
+      namespace,
+      labelSelector: toLabelSelector(deployment.spec?.selector?.matchLabels),
+    });
+
+    // Only ReplicaSets owned by this Deployment carry its revision history
+    const owned = replicaSets.items.filter((rs) =>
+      rs.metadata?.ownerReferences?.some(
+        (ref) => ref.kind === 'Deployment' && ref.uid === deployment.metadata?.uid,
+      ),
+    );
+    return { deployment, replicaSets: owned };
+  };
+
   return {
     /**
      * Apply Kubernetes manifest (supports all resource types)
@@ -212,6 +309,177 @@ export const createKubernetesClient = (
       return fetchDeploymentStatus(namespace, name);
     },
 
+    /**
+     * List a Deployment's revision history
+     * Revisions are derived from the ReplicaSets the Deployment owns, newest first
+     *
+     * @param namespace - Kubernetes namespace containing the deployment
+     * @param name - Deployment name
+     * @returns Result with revisions sorted by revision number, descending
+     */
+    async listDeploymentRevisions(
+      namespace: string,
+      name: string,
+    ): Promise<Result<DeploymentRevision[]>> {
+      try {
+        const { deployment, replicaSets } = await fetchRevisions(namespace, name);
+        const currentRevision = revisionOf(deployment.metadata);
+
+        const revisions = replicaSets
+          .map((rs) => ({
+            revision: revisionOf(rs.metadata),
+            replicaSetName: rs.metadata?.name ?? '',
+            images: (rs.spec?.template?.spec?.containers ?? []).map((c) => c.image ?? ''),
+            current: revisionOf(rs.metadata) === currentRevision,
+          }))
+          .filter((revision) => revision.revision > 0)
+          .sort((a, b) => b.revision - a.revision);
+
+        return Success(revisions);
+      } catch (error) {
+        return k8sFailure(error, 'list deployment revisions', { namespace, name });
+      }
+    },
+
+    /**
+     * Roll a Deployment back to an earlier revision
+     * Equivalent to `kubectl rollout undo`: the pod template of the target
+     * ReplicaSet is copied back onto the Deployment, which then rolls out as
+     * a new revision.
+     *
+     * @param namespace - Kubernetes namespace containing the deployment
+     * @param name - Deployment name
+     * @param toRevision - Revision to restore (default: the one before current)
+     * @returns Result with the revision rolled back from and to
+     */
+    async rollbackDeployment(
+      namespace: string,
+      name: string,
+      toRevision?: number,
+    ): Promise<Result<DeploymentRollback>> {
+      try {
+        const { deployment, replicaSets } = await fetchRevisions(namespace, name);
+        const fromRevision = revisionOf(deployment.metadata);
+
+        const candidates = replicaSets
+          .filter((rs) => revisionOf(rs.metadata) !== fromRevision)
+          .sort((a, b) => revisionOf(b.metadata) - revisionOf(a.metadata));
+        const target =
+          toRevision === undefined
+            ? candidates[0]
+            : candidates.find((rs) => revisionOf(rs.metadata) === toRevision);
+
+        if (!target?.spec?.template || !deployment.spec) {
+          const available = candidates.map((rs) => revisionOf(rs.metadata));
+          return Failure(
+            toRevision === undefined
+              ? `Deployment ${name} has no previous revision to roll back to`
+              : `Revision ${toRevision} not found in the history of deployment ${name}`,
+            {
+              message: 'No rollback target revision',
+              hint:
+                available.length === 0
+                  ? 'The deployment has only been rolled out once, or its revision history was pruned (revisionHistoryLimit)'
+                  : `Available previous revisions: ${available.join(', ')}`,
+              resolution: `Inspect the history with: kubectl rollout history deployment/${name} -n ${namespace}`,
+              details: { currentRevision: fromRevision, availableRevisions: available },
+            },
+          );
+        }
+
+        // The pod-template-hash label is managed by the controller per ReplicaSet
+        const template = structuredClone(target.spec.template);
+        delete template.metadata?.labels?.['pod-template-hash'];
+
+        await k8sApi.replaceNamespacedDeployment({
+          name,
+          namespace,
+          body: { ...deployment, spec: { ...deployment.spec, template } },
+        });
+
+        const rollback = { fromRevision, toRevision: revisionOf(target.metadata) };
+        logger.info({ namespace, name, ...rollback }, 'Deployment rolled back');
+        return Success(rollback);
+      } catch (error) {
+        return k8sFailure(error, 'roll back deployment', { namespace, name, toRevision });
+      }
+    },
+
+    /**
+     * List the pods selected by a Deployment with their readiness
+     *
+     * @param namespace - Kubernetes namespace containing the deployment
+     * @param name - Deployment name
+     * @returns Result with one entry per pod
+     */
+    async listDeploymentPods(namespace: string, name: string): Promise<Result<PodStatus[]>> {
+      try {
+        const deployment = await k8sApi.readNamespacedDeployment({ name, namespace });
+        const pods = await coreApi.listNamespacedPod({
+          namespace,
+          labelSelector: toLabelSelector(deployment.spec?.selector?.matchLabels),
+        });
+
+        return Success(
+          pods.items.map((pod) => {
+            const statuses = pod.status?.containerStatuses ?? [];
+            const unhealthy = statuses.find((cs) => !cs.ready);
+            const reason =
+              unhealthy?.state?.waiting?.reason ?? unhealthy?.state?.terminated?.reason;
+            return {
+              name: pod.metadata?.name ?? '',
+              phase: pod.status?.phase ?? 'Unknown',
+              ready: statuses.length > 0 && statuses.every((cs) => cs.ready),
+              restarts: statuses.reduce((sum, cs) => sum + (cs.restartCount ?? 0), 0),
+              ...(reason && { reason }),
+            };
+          }),
+        );
+      } catch (error) {
+        return k8sFailure(error, 'list deployment pods', { namespace, name });
+      }
+    },
+
+    /**
+     * List events recorded against the given pods, most recent last
+     * Useful for explaining why pods are not becoming ready
+     *
+     * @param namespace - Kubernetes namespace containing the pods
+     * @param podNames - Pods to collect events for
+     * @returns Result with the events of all listed pods
+     */
+    async listPodEvents(namespace: string, podNames: string[]): Promise<Result<PodEvent[]>> {
+      if (podNames.length === 0) {
+        return Success([]);
+      }
+
+      try {
+        const events = await coreApi.listNamespacedEvent({
+          namespace,
+          fieldSelector: 'involvedObject.kind=Pod',
+        });
+        const wanted = new Set(podNames);
+
+        return Success(
+          events.items
+            .filter((event) => wanted.has(event.involvedObject.name ?? ''))
+            .sort(
+              (a, b) =>
+                new Date(a.lastTimestamp ?? 0).getTime() - new Date(b.lastTimestamp ?? 0).getTime(),
+            )
+            .map((event) => ({
+              pod: event.involvedObject.name ?? '',
+              type: event.type ?? 'Normal',
+              reason: event.reason ?? '',
+              message: event.message ?? '',
+              count: event.count ?? 1,
+            })),
+        );
+      } catch (error) {
+        return k8sFailure(error, 'list pod events', { namespace, podNames });
+      }
+    },
+
     /**
      * Check cluster connectivity with timeout
      * Tests connection to the Kubernetes API server
@@ -420,6 +688,7 @@ export const createKubernetesClient = (
      * @param name - Deployment name
      * @param timeoutSeconds - Maximum wait time in seconds
      * @param pollIntervalMs - Optional polling interval in milliseconds (default: 5000ms)
+     * @param onProgress - Optional callback invoked when the ready replica count changes
      * @returns Result with deployment status on success, error on timeout or failure
      */
     async waitForDeploymentReady(
@@ -427,53 +696,19 @@ export const createKubernetesClient = (
       name: string,
       timeoutSeconds: number,
       pollIntervalMs?: number,
+      onProgress?: RolloutProgressCallback,
+      signal?: AbortSignal,
     ): Promise<Result<DeploymentResult>> {
       try {
-        const pollInterval = pollIntervalMs ?? DEPLOYMENT_POLL_INTERVAL_MS;
-        const startTime = Date.now();
-        const maxWaitTime = timeoutSeconds * 1000;
-
-        logger.debug(
-          { namespace, name, timeoutSeconds, pollInterval },
-          'Waiting for deployment to be ready',
-        );
-
-        let lastStatusResult: Result<DeploymentResult> | undefined;
-
-        while (Date.now() - startTime < maxWaitTime) {
-          lastStatusResult = await fetchDeploymentStatus(namespace, name);
-
-          if (lastStatusResult.ok && lastStatusResult.value?.ready) {
-            logger.info(
-              {
-                namespace,
-                name,
-                readyReplicas: lastStatusResult.value.readyReplicas,
-                elapsedSeconds: Math.round((Date.now() - startTime) / 1000),
-              },
-              'Deployment is ready',
-            );
-            return lastStatusResult;
-          }
-
-          // Wait before checking again
-          await new Promise((resolve) => setTimeout(resolve, pollInterval));
-        }
-
-        // Timeout reached - use last status result to avoid redundant API call
-        const errorMessage = `Deployment did not become ready within ${timeoutSeconds} seconds. Check pod status and logs to diagnose deployment issues.`;
-
-        logger.error(
-          {
-            namespace,
-            name,
-            timeoutSeconds,
-            currentStatus: lastStatusResult?.ok ? lastStatusResult.value : undefined,
-          },
-          errorMessage,
-        );
-
-        return Failure(errorMessage);
+        return await waitForRollout(() => fetchDeploymentStatus(namespace, name), {
+          namespace,
+          name,
+          timeoutSeconds,
+          pollIntervalMs: pollIntervalMs ?? DEPLOYMENT_POLL_INTERVAL_MS,
+          logger,
+          ...(onProgress && { onProgress }),
+          ...(signal && { signal }),
+        });
       } catch (error) {
         const guidance = extractK8sErrorGuidance(error, 'wait for deployment ready');
         const errorMessage = `Failed to wait for deployment: ${guidance.message}`;
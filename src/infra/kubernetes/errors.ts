@@ -34,6 +34,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'Unable to locate or read kubeconfig file',
       resolution:
         'Set KUBECONFIG environment variable or ensure ~/.kube/config exists. Run `kubectl config view` to verify.',
+      retryable: false,
     },
   ),
 
@@ -52,6 +53,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'Connection to Kubernetes API server was refused',
       resolution:
         'Verify cluster is running: `kubectl cluster-info`. Check API server address in kubeconfig and ensure network connectivity.',
+      retryable: true,
     },
   ),
 
@@ -66,6 +68,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'The API server did not respond in time',
       resolution:
         'Check cluster connectivity and load. Verify firewall rules allow access to the API server. Try `kubectl get nodes` to test connectivity.',
+      retryable: true,
     },
   ),
 
@@ -80,6 +83,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'Invalid or expired credentials',
       resolution:
         'Refresh cluster credentials. For cloud providers: re-authenticate (e.g., `aws eks update-kubeconfig`, `gcloud container clusters get-credentials`).',
+      retryable: false,
     },
   ),
 
@@ -94,6 +98,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'Your user/service account lacks required permissions',
       resolution:
         'Verify RBAC permissions with `kubectl auth can-i <verb> <resource>`. Contact cluster administrator to grant necessary roles.',
+      retryable: false,
     },
   ),
 
@@ -108,6 +113,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'The requested resource does not exist in the cluster',
       resolution:
         'Verify resource name and namespace. Use `kubectl get <resource> -n <namespace>` to list available resources.',
+      retryable: false,
     },
   ),
 
@@ -122,6 +128,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'The target namespace has not been created',
       resolution:
         'Create the namespace: `kubectl create namespace <name>` or ensure it exists before deploying resources.',
+      retryable: false,
     },
   ),
 
@@ -136,6 +143,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'A resource with this name already exists',
       resolution:
         'Use a different name, delete the existing resource, or use `kubectl apply` instead of `create` to update it.',
+      retryable: false,
     },
   ),
 
@@ -150,6 +158,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'The resource specification is invalid',
       resolution:
         'Check the manifest against Kubernetes API documentation. Use `kubectl apply --dry-run=client` to validate syntax.',
+      retryable: false,
     },
   ),
 
@@ -164,6 +173,7 @@ const k8sErrorPatterns: ErrorPattern[] = [
       hint: 'The resource type or API version is not available in this cluster',
       resolution:
         'Check cluster version with `kubectl version` and update API versions in manifests. Some resources may require cluster upgrades.',
+      retryable: false,
     },
   ),
 ];
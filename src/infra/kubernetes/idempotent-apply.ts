@@ -12,6 +12,7 @@ import { type Result } from '@/types';
 import { applyResource as applyK8sResource } from './resource-operations';
 
 export interface ApplyOptions {
+  /** Server-side dry run: validated by the API server, nothing is persisted */
   dryRun?: boolean;
   force?: boolean;
   fieldManager?: string;
@@ -54,10 +55,12 @@ export function createIdempotentApply(
    */
   return async function applyResource(
     resource: K8sResource,
-    _options: ApplyOptions = {},
+    options: ApplyOptions = {},
   ): Promise<Result<K8sResource>> {
     // Use the consolidated resource operations module
-    return applyK8sResource(kc, resource, logger);
+    return applyK8sResource(kc, resource, logger, {
+      ...(options.dryRun !== undefined && { dryRun: options.dryRun }),
+    });
   };
 }
 
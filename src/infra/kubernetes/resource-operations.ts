@@ -28,24 +28,40 @@ export interface K8sResource {
  * Explicit interfaces for create method signatures
  */
 interface NamespacedCreateMethod {
-  (args: { namespace: string; body: unknown }): Promise<{ body?: K8sResource }>;
+  (args: { namespace: string; body: unknown; dryRun?: string }): Promise<{ body?: K8sResource }>;
 }
 
 interface ClusterCreateMethod {
-  (args: { body: unknown }): Promise<{ body?: K8sResource }>;
+  (args: { body: unknown; dryRun?: string }): Promise<{ body?: K8sResource }>;
 }
 
 /**
  * Explicit interfaces for patch method signatures
  */
 interface NamespacedPatchMethod {
-  (args: { name: string; namespace: string; body: unknown }): Promise<{ body?: K8sResource }>;
+  (args: {
+    name: string;
+    namespace: string;
+    body: unknown;
+    dryRun?: string;
+  }): Promise<{ body?: K8sResource }>;
 }
 
 interface ClusterPatchMethod {
-  (args: { name: string; body: unknown }): Promise<{ body?: K8sResource }>;
+  (args: { name: string; body: unknown; dryRun?: string }): Promise<{ body?: K8sResource }>;
+}
+
+export interface ApplyResourceOptions {
+  /**
+   * Validate and admit the change on the API server without persisting it
+   * (server-side dry run); the returned resource is what would be stored
+   */
+  dryRun?: boolean;
 }
 
+/** Value of the API server's dryRun query parameter that skips persisting */
+const DRY_RUN_ALL = 'All';
+
 /**
  * Helper function to check if an error is a 409 Conflict (AlreadyExists) error
  */
@@ -70,14 +86,19 @@ async function callCreateMethod(
   isNamespaced: boolean,
   namespace: string,
   resource: K8sResource,
+  dryRun?: string,
 ): Promise<{ body?: K8sResource }> {
   if (typeof createMethod !== 'function') {
     throw new TypeError('createMethod must be a function');
   }
 
   return isNamespaced
-    ? await (createMethod as NamespacedCreateMethod)({ namespace, body: resource })
-    : await (createMethod as ClusterCreateMethod)({ body: resource });
+    ? await (createMethod as NamespacedCreateMethod)({
+        namespace,
+        body: resource,
+        ...(dryRun && { dryRun }),
+      })
+    : await (createMethod as ClusterCreateMethod)({ body: resource, ...(dryRun && { dryRun }) });
 }
 
 /**
@@ -89,14 +110,24 @@ async function callPatchMethod(
   name: string,
   namespace: string,
   resource: K8sResource,
+  dryRun?: string,
 ): Promise<{ body?: K8sResource }> {
   if (typeof patchMethod !== 'function') {
     throw new TypeError('patchMethod must be a function');
   }
 
   return isNamespaced
-    ? await (patchMethod as NamespacedPatchMethod)({ name, namespace, body: resource })
-    : await (patchMethod as ClusterPatchMethod)({ name, body: resource });
+    ? await (patchMethod as NamespacedPatchMethod)({
+        name,
+        namespace,
+        body: resource,
+        ...(dryRun && { dryRun }),
+      })
+    : await (patchMethod as ClusterPatchMethod)({
+        name,
+        body: resource,
+        ...(dryRun && { dryRun }),
+      });
 }
 
 /**
@@ -105,17 +136,25 @@ async function callPatchMethod(
  *
  * Note: This function should be called sequentially per resource.
  * Creates the resource if it doesn't exist, or patches it if it already exists.
+ * With `dryRun`, both requests are sent as server-side dry runs, so the API
+ * server validates them and runs admission but nothing is changed.
  *
  * @param kc - Kubernetes config
  * @param resource - Resource to apply
  * @param logger - Logger instance
+ * @param options - Apply options
  * @returns Success with applied resource, or Failure with error guidance
  */
 export async function applyResource(
   kc: k8s.KubeConfig,
   resource: K8sResource,
   logger: Logger,
+  options: ApplyResourceOptions = {},
 ): Promise<Result<K8sResource>> {
+  const dryRun = options.dryRun ? DRY_RUN_ALL : undefined;
+  const outcome = (done: string): string =>
+    dryRun ? `Resource would be ${done} (dry run)` : `Resource ${done} successfully`;
+
   try {
     const namespace = resource.metadata.namespace || 'default';
     const name = resource.metadata.name;
@@ -141,10 +180,16 @@ export async function applyResource(
       }
 
       try {
-        const result = await callCreateMethod(createMethod, config.namespaced, namespace, resource);
+        const result = await callCreateMethod(
+          createMethod,
+          config.namespaced,
+          namespace,
+          resource,
+          dryRun,
+        );
 
         const resourceBody = result.body || (result as unknown as K8sResource);
-        logger.info({ kind, name, namespace }, 'Resource created successfully');
+        logger.info({ kind, name, namespace }, outcome('created'));
         return Success(resourceBody);
       } catch (createError) {
         // If resource already exists, try to update it with patch
@@ -162,10 +207,11 @@ export async function applyResource(
             name,
             namespace,
             resource,
+            dryRun,
           );
 
           const patchBody = patchResult.body || (patchResult as unknown as K8sResource);
-          logger.info({ kind, name, namespace }, 'Resource updated successfully');
+          logger.info({ kind, name, namespace }, outcome('updated'));
           return Success(patchBody);
         }
 
@@ -177,8 +223,8 @@ export async function applyResource(
       const objectApi = k8s.KubernetesObjectApi.makeApiClient(kc);
 
       try {
-        const result = await objectApi.create(resource as k8s.KubernetesObject);
-        logger.info({ kind, name, namespace }, 'Resource created successfully (generic API)');
+        const result = await objectApi.create(resource as k8s.KubernetesObject, undefined, dryRun);
+        logger.info({ kind, name, namespace }, `${outcome('created')} (generic API)`);
         // KubernetesObjectApi returns the resource directly
         return Success(result as K8sResource);
       } catch (createError) {
@@ -188,8 +234,12 @@ export async function applyResource(
             { kind, name, namespace },
             'Resource exists, updating with patch (generic API)',
           );
-          const patchResult = await objectApi.patch(resource as k8s.KubernetesObject);
-          logger.info({ kind, name, namespace }, 'Resource updated successfully (generic API)');
+          const patchResult = await objectApi.patch(
+            resource as k8s.KubernetesObject,
+            undefined,
+            dryRun,
+          );
+          logger.info({ kind, name, namespace }, `${outcome('updated')} (generic API)`);
           // KubernetesObjectApi returns the resource directly
           return Success(patchResult as K8sResource);
         }
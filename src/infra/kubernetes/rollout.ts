@@ -0,0 +1,129 @@
+/**
+ * Deployment Rollout Polling
+ *
+ * Polls a Deployment's status until all replicas are ready or a timeout is
+ * reached, reporting each change in ready replica count so long rollouts
+ * can surface progress (e.g. "3/5 pods ready") to the user.
+ */
+
+import type { Logger } from 'pino';
+import { Success, Failure, Cancelled, type Result } from '@/types';
+import type { DeploymentResult } from './client';
+
+/**
+ * Called whenever the ready replica count changes while waiting
+ */
+export type RolloutProgressCallback = (status: DeploymentResult) => void | Promise<void>;
+
+export interface WaitForRolloutOptions {
+  namespace: string;
+  name: string;
+  timeoutSeconds: number;
+  pollIntervalMs: number;
+  onProgress?: RolloutProgressCallback;
+  logger?: Logger;
+  /** Stops polling early; the last seen status is returned as the partial result */
+  signal?: AbortSignal;
+}
+
+/**
+ * Format a status as a short progress message
+ */
+export function formatRolloutProgress(status: DeploymentResult): string {
+  return `${status.readyReplicas}/${status.totalReplicas} pods ready`;
+}
+
+/**
+ * Resolve after the given delay, or as soon as the signal is aborted
+ */
+function sleep(ms: number, signal?: AbortSignal): Promise<void> {
+  return new Promise((resolve) => {
+    const done = (): void => {
+      clearTimeout(timer);
+      signal?.removeEventListener('abort', done);
+      resolve();
+    };
+    const timer = setTimeout(done, ms);
+    signal?.addEventListener('abort', done, { once: true });
+  });
+}
+
+/**
+ * Poll deployment status until ready or timed out
+ *
+ * Status lookup failures are treated as "not ready yet" and polling
+ * continues. On timeout the last successfully read status is returned in
+ * the failure details as `lastStatus`. If the signal is aborted, polling
+ * stops and a Cancelled result carries `{ lastStatus }` as its partial result.
+ *
+ * @param getStatus - Reads the current deployment status
+ * @param options - Target deployment, timing and progress callback
+ */
+export async function waitForRollout(
+  getStatus: () => Promise<Result<DeploymentResult>>,
+  options: WaitForRolloutOptions,
+): Promise<Result<DeploymentResult>> {
+  const { namespace, name, timeoutSeconds, pollIntervalMs, onProgress, logger, signal } = options;
+  const startTime = Date.now();
+  const maxWaitTime = timeoutSeconds * 1000;
+
+  logger?.debug(
+    { namespace, name, timeoutSeconds, pollInterval: pollIntervalMs },
+    'Waiting for deployment to be ready',
+  );
+
+  let lastStatus: DeploymentResult | undefined;
+
+  while (Date.now() - startTime < maxWaitTime) {
+    if (signal?.aborted) {
+      logger?.info({ namespace, name, currentStatus: lastStatus }, 'Rollout wait cancelled');
+      return Cancelled(
+        { ...(lastStatus && { lastStatus }) },
+        `Cancelled while waiting for deployment ${name} to become ready`,
+      );
+    }
+
+    const statusResult = await getStatus();
+
+    if (statusResult.ok) {
+      const changed =
+        !lastStatus ||
+        lastStatus.readyReplicas !== statusResult.value.readyReplicas ||
+        lastStatus.totalReplicas !== statusResult.value.totalReplicas;
+      lastStatus = statusResult.value;
+
+      if (changed && onProgress) {
+        await onProgress(lastStatus);
+      }
+
+      if (lastStatus.ready) {
+        logger?.info(
+          {
+            namespace,
+            name,
+            readyReplicas: lastStatus.readyReplicas,
+            elapsedSeconds: Math.round((Date.now() - startTime) / 1000),
+          },
+          'Deployment is ready',
+        );
+        return Success(lastStatus);
+      }
+    }
+
+    // Wait before checking again, waking early on cancellation
+    await sleep(pollIntervalMs, signal);
+  }
+
+  const errorMessage = `Deployment did not become ready within ${timeoutSeconds} seconds. Check pod status and logs to diagnose deployment issues.`;
+
+  logger?.error({ namespace, name, timeoutSeconds, currentStatus: lastStatus }, errorMessage);
+
+  return Failure(errorMessage, {
+    message: errorMessage,
+    hint: lastStatus
+      ? `Last seen: ${formatRolloutProgress(lastStatus)}`
+      : 'Deployment status could not be read while waiting',
+    resolution: `Check pod events with: kubectl describe pods -n ${namespace}`,
+    details: { namespace, name, timeoutSeconds, ...(lastStatus && { lastStatus }) },
+  });
+}
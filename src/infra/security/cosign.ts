@@ -0,0 +1,185 @@
+/**
+ * Cosign Image Signing
+ *
+ * Signs pushed images with Sigstore cosign, either with a key reference
+ * (file path, KMS URI or Kubernetes secret) or keyless via OIDC when no key
+ * is given. Images are signed by digest so the signature cannot drift to a
+ * different image if the tag is moved.
+ *
+ * @see https://docs.sigstore.dev/cosign/signing/signing_with_containers/
+ */
+
+import { execFile } from 'node:child_process';
+import { mkdtemp, rm, writeFile } from 'node:fs/promises';
+import { tmpdir } from 'node:os';
+import { join } from 'node:path';
+import { promisify } from 'node:util';
+import type { Logger } from 'pino';
+
+import type { DockerAuthConfig } from '@/infra/docker/credential-helpers';
+import { extractErrorMessage } from '@/lib/errors';
+import { Success, Failure, type Result } from '@/types';
+import { DEFAULT_TIMEOUTS, LIMITS } from '@/config/constants';
+
+const execFileAsync = promisify(execFile);
+
+export interface SignOptions {
+  /** Key reference (path, KMS URI, k8s://ns/secret); keyless OIDC signing when omitted */
+  keyRef?: string;
+  /**
+   * Registry credentials for uploading the signature. Without them cosign
+   * uses the Docker config and credential helpers of the current user.
+   */
+  auth?: DockerAuthConfig;
+}
+
+/**
+ * Details of a created signature
+ */
+export interface ImageSignature {
+  /** Registry reference of the signature artifact (e.g. repo:sha256-<hex>.sig) */
+  signatureRef: string;
+  /** Digest of the signature artifact, when reported by the signer */
+  signatureDigest?: string;
+  /** Whether keyless (OIDC) signing was used */
+  keyless: boolean;
+}
+
+/**
+ * Signs images in a registry; injectable for tests
+ */
+export interface ImageSigner {
+  name: string;
+  isAvailable: () => Promise<boolean>;
+  sign: (imageRef: string, options: SignOptions) => Promise<Result<ImageSignature>>;
+}
+
+/**
+ * Construct the cosign argument list for signing an image
+ */
+export function buildCosignSignArgs(imageRef: string, options: SignOptions): string[] {
+  const args = ['sign', '--yes'];
+  if (options.keyRef) {
+    args.push('--key', options.keyRef);
+  }
+  args.push(imageRef);
+  return args;
+}
+
+/**
+ * Docker config.json content granting access to a single registry
+ */
+export function buildSignerDockerConfig(auth: DockerAuthConfig): {
+  auths: Record<string, { auth: string }>;
+} {
+  const token = Buffer.from(`${auth.username}:${auth.password}`).toString('base64');
+  return { auths: { [auth.serveraddress]: { auth: token } } };
+}
+
+/**
+ * Environment for cosign and docker with the given credentials in a private
+ * DOCKER_CONFIG directory, so the password never appears in an argument list
+ */
+async function signerEnv(
+  auth: DockerAuthConfig | undefined,
+): Promise<{ env?: NodeJS.ProcessEnv; cleanup: () => Promise<void> }> {
+  if (!auth) return { cleanup: async () => undefined };
+
+  const dir = await mkdtemp(join(tmpdir(), 'cosign-auth-'));
+  await writeFile(join(dir, 'config.json'), JSON.stringify(buildSignerDockerConfig(auth)), {
+    mode: 0o600,
+  });
+  return {
+    env: { ...process.env, DOCKER_CONFIG: dir },
+    cleanup: () => rm(dir, { recursive: true, force: true }),
+  };
+}
+
+/**
+ * Sign with cosign, then resolve where the signature landed
+ */
+async function runCosignSign(
+  imageRef: string,
+  options: SignOptions,
+  env: NodeJS.ProcessEnv | undefined,
+  logger: Logger,
+): Promise<Result<ImageSignature>> {
+  const keyless = !options.keyRef;
+  const args = buildCosignSignArgs(imageRef, options);
+
+  try {
+    await execFileAsync('cosign', args, { maxBuffer: LIMITS.MAX_SCAN_BUFFER, ...(env && { env }) });
+  } catch (error) {
+    const stderr = (error as { stderr?: string }).stderr ?? '';
+    return Failure(`Cosign signing failed: ${extractErrorMessage(error)}`, {
+      message: 'Image signing failed',
+      hint: keyless
+        ? 'Keyless signing needs an OIDC identity token (e.g. SIGSTORE_ID_TOKEN or CI workload identity)'
+        : 'Check that the key reference is valid and COSIGN_PASSWORD is set for encrypted keys',
+      resolution: `Try signing manually: cosign ${args.join(' ')}`,
+      details: { stderr: stderr.slice(-2000) },
+    });
+  }
+
+  // Signing succeeded; resolving where the signature landed is best-effort
+  let signatureRef = '';
+  let signatureDigest: string | undefined;
+  try {
+    const { stdout } = await execFileAsync('cosign', ['triangulate', imageRef], {
+      timeout: DEFAULT_TIMEOUTS.docker,
+      ...(env && { env }),
+    });
+    signatureRef = stdout.trim();
+
+    const inspect = await execFileAsync(
+      'docker',
+      ['buildx', 'imagetools', 'inspect', signatureRef],
+      { timeout: DEFAULT_TIMEOUTS.docker, ...(env && { env }) },
+    );
+    signatureDigest = inspect.stdout.match(/^Digest:\s*(sha256:[a-f0-9]{64})/m)?.[1];
+  } catch (error) {
+    logger.debug(
+      { error: extractErrorMessage(error), signatureRef },
+      'Could not resolve signature digest',
+    );
+  }
+
+  return Success({
+    signatureRef,
+    keyless,
+    ...(signatureDigest && { signatureDigest }),
+  });
+}
+
+/**
+ * Create a cosign CLI-backed signer
+ */
+export function createCosignSigner(logger: Logger): ImageSigner {
+  return {
+    name: 'cosign',
+
+    async isAvailable(): Promise<boolean> {
+      try {
+        await execFileAsync('cosign', ['version'], { timeout: DEFAULT_TIMEOUTS.toolVersionCheck });
+        return true;
+      } catch (error) {
+        logger.debug({ error: extractErrorMessage(error) }, 'Cosign not available');
+        return false;
+      }
+    },
+
+    async sign(imageRef: string, options: SignOptions): Promise<Result<ImageSignature>> {
+      logger.info(
+        { imageRef, keyless: !options.keyRef, registryAuth: Boolean(options.auth) },
+        'Signing image with cosign',
+      );
+
+      const { env, cleanup } = await signerEnv(options.auth);
+      try {
+        return await runCosignSign(imageRef, options, env, logger);
+      } finally {
+        await cleanup();
+      }
+    },
+  };
+}
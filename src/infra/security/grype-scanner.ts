@@ -0,0 +1,233 @@
+/**
+ * Grype Security Scanner Implementation
+ *
+ * Integrates with the Grype CLI for container image vulnerability scanning.
+ * Findings are normalized into the same BasicScanResult shape as Trivy so
+ * callers do not need to know which scanner produced them.
+ *
+ * @see https://github.com/anchore/grype
+ */
+
+import { execFile } from 'node:child_process';
+import { promisify } from 'node:util';
+import type { Logger } from 'pino';
+
+import { extractErrorMessage } from '@/lib/errors';
+import { Result, Success, Failure } from '@/types';
+import type { BasicScanResult } from './scanner';
+import { validateImageId } from './trivy-scanner';
+import { DEFAULT_TIMEOUTS, LIMITS } from '@/config/constants';
+
+const execFileAsync = promisify(execFile);
+
+// Grype JSON output structures
+interface GrypeMatch {
+  vulnerability: {
+    id: string;
+    severity?: string;
+    description?: string;
+    fix?: {
+      versions?: string[];
+      state?: string;
+    };
+  };
+  relatedVulnerabilities?: Array<{
+    id: string;
+    description?: string;
+  }>;
+  artifact: {
+    name: string;
+    version: string;
+    type?: string;
+  };
+}
+
+export interface GrypeOutput {
+  matches?: GrypeMatch[];
+  descriptor?: {
+    name: string;
+    version: string;
+  };
+}
+
+type Severity = BasicScanResult['vulnerabilities'][number]['severity'];
+
+/**
+ * Map Grype severity (Critical, High, ...) to our standardized severity levels
+ */
+function mapGrypeSeverity(grypeSeverity: string | undefined): Severity {
+  switch ((grypeSeverity ?? '').toUpperCase()) {
+    case 'CRITICAL':
+      return 'CRITICAL';
+    case 'HIGH':
+      return 'HIGH';
+    case 'MEDIUM':
+      return 'MEDIUM';
+    case 'LOW':
+      return 'LOW';
+    case 'NEGLIGIBLE':
+      return 'NEGLIGIBLE';
+    default:
+      return 'UNKNOWN';
+  }
+}
+
+/**
+ * Parse Grype JSON output to our BasicScanResult format
+ */
+export function parseGrypeOutput(grypeOutput: GrypeOutput, imageId: string): BasicScanResult {
+  const vulnerabilities: BasicScanResult['vulnerabilities'] = [];
+
+  for (const match of grypeOutput.matches || []) {
+    const { vulnerability, artifact } = match;
+
+    // GHSA matches often carry the description only on the related CVE
+    const description =
+      vulnerability.description ||
+      match.relatedVulnerabilities?.find((related) => related.description)?.description ||
+      'No description available';
+
+    // Report the CVE alias for GHSA matches so IDs line up with other scanners
+    const cveAlias = vulnerability.id.startsWith('CVE-')
+      ? undefined
+      : match.relatedVulnerabilities?.find((related) => related.id.startsWith('CVE-'))?.id;
+
+    const vulnEntry: BasicScanResult['vulnerabilities'][number] = {
+      id: cveAlias ?? vulnerability.id,
+      severity: mapGrypeSeverity(vulnerability.severity),
+      package: artifact.name,
+      version: artifact.version,
+      description,
+    };
+
+    const fixedVersions = vulnerability.fix?.versions ?? [];
+    if (vulnerability.fix?.state === 'fixed' && fixedVersions.length > 0) {
+      vulnEntry.fixedVersion = fixedVersions.join(', ');
+    }
+
+    vulnerabilities.push(vulnEntry);
+  }
+
+  const count = (severity: Severity): number =>
+    vulnerabilities.filter((v) => v.severity === severity).length;
+
+  return {
+    imageId,
+    vulnerabilities,
+    totalVulnerabilities: vulnerabilities.length,
+    criticalCount: count('CRITICAL'),
+    highCount: count('HIGH'),
+    mediumCount: count('MEDIUM'),
+    lowCount: count('LOW'),
+    negligibleCount: count('NEGLIGIBLE'),
+    unknownCount: count('UNKNOWN'),
+    scanDate: new Date(),
+    scanner: 'grype',
+  };
+}
+
+/**
+ * Check if Grype is installed and accessible
+ */
+export async function checkGrypeAvailability(logger: Logger): Promise<Result<string>> {
+  try {
+    const { stdout } = await execFileAsync('grype', ['version'], {
+      timeout: DEFAULT_TIMEOUTS.toolVersionCheck,
+    });
+    // Grype version output format: "Version:            0.74.0"
+    const version = stdout.match(/^Version:\s*(\S+)/m)?.[1];
+    if (!version) {
+      logger.debug({ stdout }, 'Could not parse Grype version from output');
+      return Failure('Grype is installed but version could not be determined', {
+        message: 'Grype version check failed',
+        hint: 'Grype CLI may not be properly configured',
+        resolution: 'Try running: grype version',
+      });
+    }
+    return Success(version);
+  } catch (error) {
+    return Failure('Grype not installed or not in PATH', {
+      message: 'Grype CLI not found',
+      hint: 'Grype CLI is required for Grype security scanning',
+      resolution: 'Install Grype: https://github.com/anchore/grype#installation',
+      details: { error: extractErrorMessage(error) },
+    });
+  }
+}
+
+/**
+ * Scan a Docker image using Grype
+ */
+export async function scanImageWithGrype(
+  imageId: string,
+  logger: Logger,
+): Promise<Result<BasicScanResult>> {
+  if (!validateImageId(imageId)) {
+    return Failure('Invalid imageId format', {
+      message: 'ImageId contains invalid characters',
+      hint: 'ImageId must contain only alphanumeric characters, dots, colons, slashes, at-signs, underscores, and hyphens',
+      resolution: 'Verify the imageId is a valid Docker image identifier',
+      details: { imageId },
+    });
+  }
+
+  const availabilityCheck = await checkGrypeAvailability(logger);
+  if (!availabilityCheck.ok) {
+    return Failure(availabilityCheck.error, availabilityCheck.guidance);
+  }
+
+  logger.info({ grypeVersion: availabilityCheck.value, imageId }, 'Starting Grype scan');
+
+  try {
+    const args = [imageId, '--output', 'json', '--quiet'];
+    logger.debug({ args }, 'Executing Grype command');
+
+    const { stdout, stderr } = await execFileAsync('grype', args, {
+      maxBuffer: LIMITS.MAX_SCAN_BUFFER,
+      timeout: DEFAULT_TIMEOUTS['scan-image'],
+    });
+
+    if (stderr) {
+      logger.debug({ stderr }, 'Grype stderr output');
+    }
+
+    let grypeOutput: GrypeOutput;
+    try {
+      grypeOutput = JSON.parse(stdout);
+    } catch (parseError) {
+      return Failure('Failed to parse Grype output', {
+        message: 'Grype output parsing failed',
+        hint: 'Grype may have returned invalid JSON',
+        resolution: `Try running Grype manually to verify: grype ${imageId}`,
+        details: {
+          parseError: extractErrorMessage(parseError),
+          outputPreview: stdout.substring(0, 200),
+        },
+      });
+    }
+
+    const scanResult = parseGrypeOutput(grypeOutput, imageId);
+
+    logger.info(
+      {
+        imageId,
+        totalVulnerabilities: scanResult.totalVulnerabilities,
+        criticalCount: scanResult.criticalCount,
+        highCount: scanResult.highCount,
+      },
+      'Grype scan completed successfully',
+    );
+
+    return Success(scanResult);
+  } catch (error) {
+    const errorMessage = extractErrorMessage(error);
+    logger.error({ error: errorMessage, imageId }, 'Grype scan failed');
+
+    return Failure(`Grype scan failed: ${errorMessage}`, {
+      message: 'Security scan execution failed',
+      hint: 'Grype encountered an error while scanning the image',
+      resolution: `Check image exists and is accessible: docker image ls | grep ${imageId}`,
+      details: { error: errorMessage },
+    });
+  }
+}
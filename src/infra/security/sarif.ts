@@ -0,0 +1,202 @@
+/**
+ * SARIF Export
+ *
+ * Converts image scan findings into SARIF 2.1.0 so they can be uploaded to
+ * GitHub code scanning. Each CVE becomes one rule; each affected package
+ * becomes one result referencing that rule.
+ *
+ * @see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+ * @see https://docs.github.com/en/code-security/code-scanning/integrating-with-code-scanning/sarif-support-for-code-scanning
+ */
+
+import { mkdir, writeFile } from 'node:fs/promises';
+import { tmpdir } from 'node:os';
+import { isAbsolute, join } from 'node:path';
+import { pathToFileURL } from 'node:url';
+
+import { extractErrorMessage } from '@/lib/errors';
+import { Success, Failure, type Result } from '@/types';
+import type { BasicScanResult } from './scanner';
+
+type Vulnerability = BasicScanResult['vulnerabilities'][number];
+type SarifLevel = 'error' | 'warning' | 'note';
+
+export const SARIF_SCHEMA_URI = 'https://json.schemastore.org/sarif-2.1.0.json';
+
+export interface SarifRule {
+  id: string;
+  shortDescription: { text: string };
+  fullDescription: { text: string };
+  helpUri: string;
+  defaultConfiguration: { level: SarifLevel };
+  properties: { 'security-severity': string; tags: string[] };
+}
+
+export interface SarifResult {
+  ruleId: string;
+  ruleIndex: number;
+  level: SarifLevel;
+  message: { text: string };
+  locations: Array<{
+    physicalLocation: { artifactLocation: { uri: string } };
+    logicalLocations: Array<{ name: string; fullyQualifiedName: string; kind: string }>;
+  }>;
+  properties: { imageRef: string };
+}
+
+export interface SarifLog {
+  $schema: string;
+  version: '2.1.0';
+  runs: Array<{
+    tool: {
+      driver: {
+        name: string;
+        informationUri: string;
+        rules: SarifRule[];
+        properties?: Record<string, unknown>;
+      };
+    };
+    results: SarifResult[];
+  }>;
+}
+
+const LEVELS: Record<Vulnerability['severity'], SarifLevel> = {
+  CRITICAL: 'error',
+  HIGH: 'error',
+  MEDIUM: 'warning',
+  LOW: 'note',
+  NEGLIGIBLE: 'note',
+  UNKNOWN: 'note',
+};
+
+/** GitHub maps security-severity scores onto its critical/high/medium/low labels */
+const SECURITY_SEVERITY: Record<Vulnerability['severity'], string> = {
+  CRITICAL: '9.5',
+  HIGH: '8.0',
+  MEDIUM: '5.5',
+  LOW: '2.0',
+  NEGLIGIBLE: '0.0',
+  UNKNOWN: '0.0',
+};
+
+/**
+ * Default directory SARIF reports are written to
+ */
+export const DEFAULT_SARIF_DIR = join(tmpdir(), 'containerization-assist', 'sarif');
+
+function advisoryUri(id: string): string {
+  if (id.startsWith('GHSA-')) return `https://github.com/advisories/${id}`;
+  if (id.startsWith('CVE-')) return `https://nvd.nist.gov/vuln/detail/${id}`;
+  return `https://osv.dev/vulnerability/${id}`;
+}
+
+/**
+ * Artifact URI for a Dockerfile: repository-relative with forward slashes,
+ * or a file:// URI for an absolute path
+ */
+function artifactUri(dockerfilePath: string): string {
+  if (isAbsolute(dockerfilePath)) return pathToFileURL(dockerfilePath).href;
+  return dockerfilePath.replace(/\\/g, '/').replace(/^(\.\/)+/, '');
+}
+
+/**
+ * Convert scan findings to a SARIF 2.1.0 log
+ *
+ * Code scanning only accepts file locations, so results point at the
+ * Dockerfile the image was built from; the image itself is recorded in
+ * each result's properties.
+ *
+ * @param scanResult - Normalized scan result
+ * @param imageRef - Image the findings belong to
+ * @param dockerfilePath - Dockerfile the image was built from (default: Dockerfile)
+ */
+export function toSarif(
+  scanResult: BasicScanResult,
+  imageRef: string,
+  dockerfilePath = 'Dockerfile',
+): SarifLog {
+  const uri = artifactUri(dockerfilePath);
+  const rules: SarifRule[] = [];
+  const ruleIndex = new Map<string, number>();
+
+  for (const vuln of scanResult.vulnerabilities) {
+    if (ruleIndex.has(vuln.id)) continue;
+    ruleIndex.set(vuln.id, rules.length);
+    rules.push({
+      id: vuln.id,
+      shortDescription: { text: `${vuln.id}: ${vuln.package}` },
+      fullDescription: { text: vuln.description },
+      helpUri: advisoryUri(vuln.id),
+      defaultConfiguration: { level: LEVELS[vuln.severity] },
+      properties: {
+        'security-severity': SECURITY_SEVERITY[vuln.severity],
+        tags: ['security', 'vulnerability', vuln.severity],
+      },
+    });
+  }
+
+  const results: SarifResult[] = scanResult.vulnerabilities.map((vuln) => ({
+    ruleId: vuln.id,
+    ruleIndex: ruleIndex.get(vuln.id) ?? 0,
+    level: LEVELS[vuln.severity],
+    message: {
+      text:
+        `Package ${vuln.package}@${vuln.version} is affected by ${vuln.id} (${vuln.severity})` +
+        (vuln.fixedVersion ? `. Fixed in ${vuln.fixedVersion}.` : '. No fix available.'),
+    },
+    locations: [
+      {
+        physicalLocation: { artifactLocation: { uri } },
+        logicalLocations: [
+          {
+            name: vuln.package,
+            fullyQualifiedName: `${vuln.package}@${vuln.version}`,
+            kind: 'package',
+          },
+        ],
+      },
+    ],
+    properties: { imageRef },
+  }));
+
+  return {
+    $schema: SARIF_SCHEMA_URI,
+    version: '2.1.0',
+    runs: [
+      {
+        tool: {
+          driver: {
+            name: 'containerization-assist',
+            informationUri: 'https://github.com/Azure/containerization-assist',
+            rules,
+            ...(scanResult.scanner && { properties: { scanner: scanResult.scanner } }),
+          },
+        },
+        results,
+      },
+    ],
+  };
+}
+
+/**
+ * Write a SARIF log to disk
+ *
+ * @returns Path of the written report
+ */
+export async function writeSarifReport(
+  log: SarifLog,
+  imageRef: string,
+  outputDir: string = DEFAULT_SARIF_DIR,
+): Promise<Result<string>> {
+  try {
+    await mkdir(outputDir, { recursive: true });
+    const filePath = join(outputDir, `${imageRef.replace(/[^a-zA-Z0-9._-]+/g, '_')}.sarif`);
+    await writeFile(filePath, JSON.stringify(log, null, 2), 'utf-8');
+    return Success(filePath);
+  } catch (error) {
+    return Failure(`Failed to write SARIF report: ${extractErrorMessage(error)}`, {
+      message: 'SARIF report could not be written',
+      resolution: `Check that ${outputDir} is writable`,
+    });
+  }
+}
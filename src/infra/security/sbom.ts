@@ -0,0 +1,358 @@
+/**
+ * SBOM Generation
+ *
+ * Produces a CycloneDX or SPDX software bill of materials for a built image.
+ * Syft is used when installed; otherwise a minimal inventory listing the
+ * image and its layer digests is built from `docker inspect` data so that
+ * every build still yields a document for supply-chain review.
+ *
+ * @see https://github.com/anchore/syft
+ */
+
+import { execFile } from 'node:child_process';
+import { randomUUID } from 'node:crypto';
+import { mkdir, writeFile } from 'node:fs/promises';
+import { tmpdir } from 'node:os';
+import { join } from 'node:path';
+import { promisify } from 'node:util';
+import type { Logger } from 'pino';
+
+import { extractErrorMessage } from '@/lib/errors';
+import { Success, Failure, type Result } from '@/types';
+import { DEFAULT_TIMEOUTS, LIMITS } from '@/config/constants';
+import type { DockerImageInfo } from '@/infra/docker/client';
+
+const execFileAsync = promisify(execFile);
+
+export const SBOM_FORMATS = ['cyclonedx', 'spdx'] as const;
+
+export type SbomFormat = (typeof SBOM_FORMATS)[number];
+
+/**
+ * A source of SBOM documents
+ */
+export interface SbomGenerator {
+  name: string;
+  isAvailable: () => Promise<boolean>;
+  /** Generate the SBOM document as a JSON string */
+  generate: (imageRef: string, format: SbomFormat) => Promise<Result<string>>;
+}
+
+/**
+ * Reference to an SBOM written to disk
+ */
+export interface SbomArtifact {
+  format: SbomFormat;
+  /** Generator that produced the document (e.g. syft, inventory) */
+  generator: string;
+  /** Absolute path of the SBOM file */
+  path: string;
+  mediaType: string;
+}
+
+const MEDIA_TYPES: Record<SbomFormat, string> = {
+  cyclonedx: 'application/vnd.cyclonedx+json',
+  spdx: 'application/spdx+json',
+};
+
+const SYFT_OUTPUTS: Record<SbomFormat, string> = {
+  cyclonedx: 'cyclonedx-json',
+  spdx: 'spdx-json',
+};
+
+/**
+ * Default directory SBOM artifacts are written to
+ */
+export const DEFAULT_SBOM_DIR = join(tmpdir(), 'containerization-assist', 'sbom');
+
+/**
+ * Create a Syft-backed SBOM generator
+ */
+export function createSyftGenerator(logger: Logger): SbomGenerator {
+  return {
+    name: 'syft',
+
+    async isAvailable(): Promise<boolean> {
+      try {
+        await execFileAsync('syft', ['version'], { timeout: DEFAULT_TIMEOUTS.toolVersionCheck });
+        return true;
+      } catch (error) {
+        logger.debug({ error: extractErrorMessage(error) }, 'Syft not available');
+        return false;
+      }
+    },
+
+    async generate(imageRef: string, format: SbomFormat): Promise<Result<string>> {
+      const args = [`docker:${imageRef}`, '-o', SYFT_OUTPUTS[format], '--quiet'];
+      logger.debug({ args }, 'Executing syft');
+
+      try {
+        const { stdout } = await execFileAsync('syft', args, {
+          maxBuffer: LIMITS.MAX_SCAN_BUFFER,
+        });
+        return Success(stdout);
+      } catch (error) {
+        return Failure(`Syft SBOM generation failed: ${extractErrorMessage(error)}`, {
+          message: 'Syft could not catalog the image',
+          hint: 'The image must be available in the local Docker daemon',
+          resolution: `Try running syft manually: syft docker:${imageRef}`,
+        });
+      }
+    },
+  };
+}
+
+/**
+ * Strip the algorithm prefix from a layer digest
+ */
+function digestHex(digest: string): string {
+  return digest.includes(':') ? digest.slice(digest.indexOf(':') + 1) : digest;
+}
+
+/**
+ * Build a minimal layer-based inventory document
+ */
+export function buildInventoryDocument(
+  imageRef: string,
+  image: DockerImageInfo,
+  format: SbomFormat,
+): Record<string, unknown> {
+  const created = new Date().toISOString();
+  const layers = image.Layers ?? [];
+
+  if (format === 'cyclonedx') {
+    return {
+      bomFormat: 'CycloneDX',
+      specVersion: '1.5',
+      serialNumber: `urn:uuid:${randomUUID()}`,
+      version: 1,
+      metadata: {
+        timestamp: created,
+        tools: { components: [{ type: 'application', name: 'containerization-assist' }] },
+        component: { type: 'container', name: imageRef, version: image.Id },
+      },
+      components: layers.map((digest, index) => ({
+        type: 'file',
+        name: `layer-${index}`,
+        hashes: [{ alg: 'SHA-256', content: digestHex(digest) }],
+      })),
+    };
+  }
+
+  return {
+    spdxVersion: 'SPDX-2.3',
+    dataLicense: 'CC0-1.0',
+    SPDXID: 'SPDXRef-DOCUMENT',
+    name: imageRef,
+    documentNamespace: `https://containerization-assist/spdx/${randomUUID()}`,
+    creationInfo: { created, creators: ['Tool: containerization-assist'] },
+    packages: [
+      {
+        SPDXID: 'SPDXRef-Image',
+        name: imageRef,
+        versionInfo: image.Id,
+        downloadLocation: 'NOASSERTION',
+        filesAnalyzed: false,
+      },
+      ...layers.map((digest, index) => ({
+        SPDXID: `SPDXRef-Layer-${index}`,
+        name: `layer-${index}`,
+        downloadLocation: 'NOASSERTION',
+        filesAnalyzed: false,
+        checksums: [{ algorithm: 'SHA256', checksumValue: digestHex(digest) }],
+      })),
+    ],
+    relationships: [
+      {
+        spdxElementId: 'SPDXRef-DOCUMENT',
+        relationshipType: 'DESCRIBES',
+        relatedSpdxElement: 'SPDXRef-Image',
+      },
+      ...layers.map((_, index) => ({
+        spdxElementId: 'SPDXRef-Image',
+        relationshipType: 'CONTAINS',
+        relatedSpdxElement: `SPDXRef-Layer-${index}`,
+      })),
+    ],
+  };
+}
+
+/**
+ * Create a generator that inventories image layers from `docker inspect`
+ */
+export function createInventoryGenerator(
+  inspectImage: (imageRef: string) => Promise<Result<DockerImageInfo>>,
+): SbomGenerator {
+  return {
+    name: 'inventory',
+
+    async isAvailable(): Promise<boolean> {
+      return true;
+    },
+
+    async generate(imageRef: string, format: SbomFormat): Promise<Result<string>> {
+      const image = await inspectImage(imageRef);
+      if (!image.ok) return image;
+      return Success(JSON.stringify(buildInventoryDocument(imageRef, image.value, format), null, 2));
+    },
+  };
+}
+
+export interface GenerateSbomOptions {
+  /** Generators in order of preference; the first available one is used */
+  generators: SbomGenerator[];
+  logger: Logger;
+  /** Directory to write the SBOM file to (default: DEFAULT_SBOM_DIR) */
+  outputDir?: string;
+}
+
+export interface GenerateSbomResult {
+  artifact?: SbomArtifact;
+  /** Degradation notices, e.g. a fallback generator was used */
+  warnings: string[];
+}
+
+/**
+ * Generate an SBOM for an image and write it to disk.
+ *
+ * Never fails: problems are reported as warnings so SBOM generation cannot
+ * break an otherwise successful build.
+ */
+export async function generateSbom(
+  imageRef: string,
+  format: SbomFormat,
+  options: GenerateSbomOptions,
+): Promise<GenerateSbomResult> {
+  const { generators, logger, outputDir = DEFAULT_SBOM_DIR } = options;
+  const warnings: string[] = [];
+  const preferred = generators[0]?.name;
+
+  for (const generator of generators) {
+    if (!(await generator.isAvailable())) {
+      warnings.push(`SBOM generator '${generator.name}' is not installed`);
+      continue;
+    }
+
+    const document = await generator.generate(imageRef, format);
+    if (!document.ok) {
+      logger.warn({ generator: generator.name, error: document.error }, 'SBOM generation failed');
+      warnings.push(`SBOM generator '${generator.name}' failed: ${document.error}`);
+      continue;
+    }
+
+    if (generator.name !== preferred) {
+      warnings.push(
+        `Generated a minimal ${generator.name} SBOM; install syft for a full package-level SBOM`,
+      );
+    }
+
+    try {
+      await mkdir(outputDir, { recursive: true });
+      const fileName = `${imageRef.replace(/[^a-zA-Z0-9._-]+/g, '_')}.${format}.json`;
+      const filePath = join(outputDir, fileName);
+      await writeFile(filePath, document.value, 'utf-8');
+
+      logger.info({ path: filePath, format, generator: generator.name }, 'SBOM generated');
+      return {
+        artifact: { format, generator: generator.name, path: filePath, mediaType: MEDIA_TYPES[format] },
+        warnings,
+      };
+    } catch (error) {
+      warnings.push(`Failed to write SBOM: ${extractErrorMessage(error)}`);
+      return { warnings };
+    }
+  }
+
+  warnings.push('No SBOM generator is available; SBOM was not generated');
+  logger.warn({ imageRef }, 'No SBOM generator available');
+  return { warnings };
+}
+
+/**
+ * A package listed in an SBOM
+ */
+export interface SbomPackage {
+  name: string;
+  version: string;
+  /** Ecosystem from the package URL, e.g. npm, deb, pypi */
+  type?: string;
+  /** Declared licenses as SPDX IDs, expressions or free-text names */
+  licenses?: string[];
+}
+
+/** CycloneDX component types that are not installed packages */
+const NON_PACKAGE_COMPONENTS = new Set(['file', 'container', 'operating-system']);
+
+/** SPDX values meaning no license information */
+const NO_LICENSE = new Set(['NOASSERTION', 'NONE', '']);
+
+/** `pkg:npm/%40types/node@20.1.0?arch=x` -> `{ type: 'npm', key: 'pkg:npm/@types/node' }` */
+function parsePurl(purl: string | undefined): { type: string; key: string } | undefined {
+  const match = purl && /^pkg:([^/]+)\/([^@?#]+)/.exec(purl);
+  if (!match?.[1] || !match[2]) {
+    return undefined;
+  }
+  return { type: match[1], key: `pkg:${match[1]}/${decodeURIComponent(match[2])}` };
+}
+
+interface CycloneDxComponent {
+  type?: string;
+  group?: string;
+  name?: string;
+  version?: string;
+  purl?: string;
+  licenses?: Array<{ license?: { id?: string; name?: string }; expression?: string }>;
+}
+
+interface SpdxPackage {
+  name?: string;
+  versionInfo?: string;
+  licenseConcluded?: string;
+  licenseDeclared?: string;
+  externalRefs?: Array<{ referenceType?: string; referenceLocator?: string }>;
+}
+
+/**
+ * Packages listed in a CycloneDX or SPDX JSON document, keyed by package URL
+ *
+ * SPDX packages without a package URL (the image itself, layer inventories)
+ * are skipped, as are CycloneDX file, container and OS components.
+ */
+export function parseSbomPackages(document: string): Map<string, SbomPackage[]> {
+  const parsed = JSON.parse(document) as {
+    components?: CycloneDxComponent[];
+    packages?: SpdxPackage[];
+  };
+  const packages = new Map<string, SbomPackage[]>();
+  const add = (name: string, version: string, purl: string | undefined, licenses: string[]) => {
+    const ref = parsePurl(purl);
+    const key = ref?.key ?? name;
+    packages.set(key, [
+      ...(packages.get(key) ?? []),
+      {
+        name,
+        version,
+        ...(ref && { type: ref.type }),
+        ...(licenses.length > 0 && { licenses }),
+      },
+    ]);
+  };
+
+  for (const component of parsed.components ?? []) {
+    if (!component.name || NON_PACKAGE_COMPONENTS.has(component.type ?? '')) continue;
+    const name = component.group ? `${component.group}/${component.name}` : component.name;
+    const licenses = (component.licenses ?? [])
+      .map((entry) => entry.expression ?? entry.license?.id ?? entry.license?.name ?? '')
+      .filter((license) => !NO_LICENSE.has(license));
+    add(name, component.version ?? '', component.purl, licenses);
+  }
+  for (const pkg of parsed.packages ?? []) {
+    const purl = pkg.externalRefs?.find((ref) => ref.referenceType === 'purl')?.referenceLocator;
+    if (!pkg.name || !purl) continue;
+    const license = [pkg.licenseConcluded, pkg.licenseDeclared].find(
+      (value) => value !== undefined && !NO_LICENSE.has(value),
+    );
+    add(pkg.name, pkg.versionInfo ?? '', purl, license ? [license] : []);
+  }
+  return packages;
+}
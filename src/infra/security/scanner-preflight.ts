@@ -0,0 +1,258 @@
+/**
+ * Scanner Preflight
+ *
+ * Checks that Trivy is present and recent enough before a scan starts, so a
+ * missing binary fails with install instructions instead of a raw exec error
+ * and an outdated one is flagged in the scan result. When auto-install is
+ * enabled, a missing Trivy is fetched at a pinned version into the workspace.
+ */
+
+import { execFile } from 'node:child_process';
+import { createHash } from 'node:crypto';
+import { promises as fs } from 'node:fs';
+import { join, resolve } from 'node:path';
+import { promisify } from 'node:util';
+import type { Logger } from 'pino';
+
+import { extractErrorMessage } from '@/lib/errors';
+import { Result, Success, Failure, type ErrorGuidance } from '@/types';
+import { parseBoolEnv } from '@/config/env-utils';
+import { DEFAULT_TIMEOUTS } from '@/config/constants';
+import { checkTrivyAvailability, scannerInstallDir } from './trivy-scanner';
+
+const execFileAsync = promisify(execFile);
+
+export const SCANNER_UNAVAILABLE = 'SCANNER_UNAVAILABLE';
+
+/**
+ * Oldest Trivy release known to produce the JSON this scanner parses
+ */
+export const MIN_TRIVY_VERSION = '0.50.0';
+
+/**
+ * Trivy release fetched by auto-install
+ */
+export const PINNED_TRIVY_VERSION = '0.58.1';
+
+/**
+ * Environment variable enabling auto-install of a missing scanner
+ */
+export const AUTO_INSTALL_SCANNER_ENV = 'CONTAINERIZATION_ASSIST_AUTO_INSTALL_SCANNER';
+
+const TRIVY_RELEASES_URL = 'https://github.com/aquasecurity/trivy/releases/download';
+
+const TRIVY_RELEASE_OS: Record<string, string> = { linux: 'Linux', darwin: 'macOS' };
+const TRIVY_RELEASE_ARCH: Record<string, string> = { x64: '64bit', arm64: 'ARM64' };
+
+/**
+ * Outcome of a successful preflight
+ */
+export interface ScannerPreflight {
+  version: string;
+  /** Trivy executable to run: `trivy` from the PATH, or the absolute path of the installed one */
+  binary: string;
+  /** Whether the auto-installed Trivy is used */
+  installed: boolean;
+  warnings: string[];
+}
+
+export interface TrivyPreflightOptions {
+  /** Install the pinned Trivy release when none is found (default: from environment) */
+  autoInstall?: boolean;
+  /** Version probe, replaceable in tests */
+  probe?: (logger: Logger, binary?: string) => Promise<Result<string>>;
+  /** Installer, replaceable in tests */
+  install?: (version: string, logger: Logger) => Promise<Result<string>>;
+}
+
+/**
+ * Compare two dotted versions numerically, ignoring a leading `v` and any
+ * pre-release suffix
+ *
+ * @returns Negative when `a` is older than `b`, positive when newer, 0 when equal
+ */
+export function compareVersions(a: string, b: string): number {
+  const parts = (version: string): number[] =>
+    version
+      .replace(/^v/, '')
+      .replace(/[-+].*$/, '')
+      .split('.')
+      .map((part) => parseInt(part, 10) || 0);
+  const left = parts(a);
+  const right = parts(b);
+  for (let i = 0; i < Math.max(left.length, right.length); i++) {
+    const diff = (left[i] ?? 0) - (right[i] ?? 0);
+    if (diff !== 0) return diff;
+  }
+  return 0;
+}
+
+/**
+ * Guidance for a Trivy that is not installed
+ */
+export function trivyInstallGuidance(cause?: string): ErrorGuidance {
+  return {
+    message: 'Trivy CLI not found',
+    hint: 'Trivy CLI is required for security scanning',
+    resolution:
+      'Install Trivy: `brew install trivy` on macOS, `sudo apt-get install trivy` from the Aqua apt repository on Debian/Ubuntu, ' +
+      'or `curl -sfL https://raw.githubusercontent.com/aquasecurity/trivy/main/contrib/install.sh | sh -s -- -b /usr/local/bin`. ' +
+      'See https://aquasecurity.github.io/trivy/latest/getting-started/installation/. ' +
+      `Alternatively, set ${AUTO_INSTALL_SCANNER_ENV}=true or pass --auto-install-scanner ` +
+      `to download Trivy ${PINNED_TRIVY_VERSION} into the workspace.`,
+    details: {
+      code: SCANNER_UNAVAILABLE,
+      scanner: 'trivy',
+      minimumVersion: MIN_TRIVY_VERSION,
+      ...(cause && { cause }),
+    },
+  };
+}
+
+/**
+ * Check that Trivy is present and at least MIN_TRIVY_VERSION.
+ *
+ * A missing Trivy fails with SCANNER_UNAVAILABLE unless auto-install is
+ * enabled; an older one passes with a warning, since it may still scan.
+ */
+export async function preflightTrivy(
+  logger: Logger,
+  options: TrivyPreflightOptions = {},
+): Promise<Result<ScannerPreflight>> {
+  const {
+    autoInstall = parseBoolEnv(AUTO_INSTALL_SCANNER_ENV, false),
+    probe = checkTrivyAvailability,
+    install = installTrivy,
+  } = options;
+
+  let binary = 'trivy';
+  let installed = false;
+  let version = await probe(logger);
+  if (!version.ok) {
+    if (!autoInstall) {
+      return Failure(version.error, trivyInstallGuidance(version.error));
+    }
+
+    logger.info({ version: PINNED_TRIVY_VERSION }, 'Trivy not found, installing pinned release');
+    const installedBinary = await install(PINNED_TRIVY_VERSION, logger);
+    if (!installedBinary.ok) return installedBinary;
+
+    binary = installedBinary.value;
+    version = await probe(logger, binary);
+    if (!version.ok) {
+      return Failure(
+        `Trivy was installed to ${binary} but could not be run: ${version.error}`,
+        trivyInstallGuidance(version.error),
+      );
+    }
+    installed = true;
+  }
+
+  const warnings: string[] = [];
+  if (compareVersions(version.value, MIN_TRIVY_VERSION) < 0) {
+    const warning =
+      `Trivy ${version.value} is older than the minimum supported ${MIN_TRIVY_VERSION}; ` +
+      'results may be incomplete. Upgrade Trivy to get accurate findings.';
+    logger.warn({ version: version.value, minimum: MIN_TRIVY_VERSION }, warning);
+    warnings.push(warning);
+  }
+
+  return Success({ version: version.value, binary, installed, warnings });
+}
+
+/**
+ * Release asset name for the current platform, or undefined when Trivy
+ * publishes no build for it
+ */
+function trivyAssetName(version: string): string | undefined {
+  const os = TRIVY_RELEASE_OS[process.platform];
+  const arch = TRIVY_RELEASE_ARCH[process.arch];
+  if (!os || !arch) return undefined;
+  return `trivy_${version}_${os}-${arch}.tar.gz`;
+}
+
+async function download(url: string): Promise<Buffer> {
+  const response = await fetch(url, {
+    signal: AbortSignal.timeout(DEFAULT_TIMEOUTS.scannerInstall),
+  });
+  if (!response.ok) {
+    throw new Error(`GET ${url} returned ${response.status} ${response.statusText}`);
+  }
+  return Buffer.from(await response.arrayBuffer());
+}
+
+/**
+ * Binaries installed and verified by this process, by version. A binary
+ * already in the install directory is not trusted, since anyone who can
+ * write to the workspace could have put it there.
+ */
+const verifiedInstalls = new Map<string, string>();
+
+/**
+ * Download a Trivy release into the workspace scanner directory, verifying
+ * it against the published checksums. Each version is downloaded at most
+ * once per process.
+ *
+ * @returns Absolute path to the installed binary
+ */
+export async function installTrivy(version: string, logger: Logger): Promise<Result<string>> {
+  const verified = verifiedInstalls.get(version);
+  if (verified) return Success(verified);
+
+  const asset = trivyAssetName(version);
+  if (!asset) {
+    return Failure(
+      `Trivy cannot be auto-installed on ${process.platform}/${process.arch}`,
+      trivyInstallGuidance(`unsupported platform ${process.platform}/${process.arch}`),
+    );
+  }
+
+  const dir = scannerInstallDir();
+  const archive = join(dir, asset);
+  const baseUrl = `${TRIVY_RELEASES_URL}/v${version}`;
+
+  try {
+    const [tarball, checksums] = await Promise.all([
+      download(`${baseUrl}/${asset}`),
+      download(`${baseUrl}/trivy_${version}_checksums.txt`),
+    ]);
+
+    const expected = checksums
+      .toString('utf8')
+      .split('\n')
+      .map((line) => line.trim().split(/\s+/))
+      .find(([, name]) => name === asset)?.[0];
+    const actual = createHash('sha256').update(tarball).digest('hex');
+    if (!expected || expected !== actual) {
+      return Failure(`Checksum mismatch for ${asset}`, {
+        message: 'Downloaded Trivy release failed checksum verification',
+        hint: expected
+          ? `Expected sha256 ${expected}, got ${actual}`
+          : `${asset} is not listed in the release checksums`,
+        resolution: 'Retry the scan, or install Trivy manually',
+        retryable: true,
+        details: { code: SCANNER_UNAVAILABLE, scanner: 'trivy', version },
+      });
+    }
+
+    await fs.mkdir(dir, { recursive: true });
+    await fs.writeFile(archive, tarball);
+    await execFileAsync('tar', ['-xzf', archive, '-C', dir, 'trivy']);
+    await fs.rm(archive, { force: true });
+
+    const binary = resolve(dir, 'trivy');
+    verifiedInstalls.set(version, binary);
+    logger.info({ version, binary }, 'Installed Trivy');
+    return Success(binary);
+  } catch (error) {
+    const message = extractErrorMessage(error);
+    logger.error({ error: message, version }, 'Trivy auto-install failed');
+    return Failure(`Failed to install Trivy ${version}: ${message}`, {
+      message: 'Trivy auto-install failed',
+      hint: `Could not download or unpack ${asset}`,
+      resolution: `Check network access to ${TRIVY_RELEASES_URL}, or install Trivy manually`,
+      retryable: true,
+      details: { code: SCANNER_UNAVAILABLE, scanner: 'trivy', version, cause: message },
+    });
+  }
+}
@@ -1,9 +1,18 @@
 import type { Logger } from 'pino';
 import { Result, Success, Failure } from '@/types';
 import { extractErrorMessage } from '@/lib/errors';
-import { scanImageWithTrivy, checkTrivyAvailability } from './trivy-scanner';
+import { parseBoolEnv } from '@/config/env-utils';
+import { scanImageWithTrivy } from './trivy-scanner';
+import { scanImageWithGrype, checkGrypeAvailability } from './grype-scanner';
+import {
+  AUTO_INSTALL_SCANNER_ENV,
+  SCANNER_UNAVAILABLE,
+  preflightTrivy,
+  type ScannerPreflight,
+  type TrivyPreflightOptions,
+} from './scanner-preflight';
 
-interface SecurityScanner {
+export interface SecurityScanner {
   scanImage: (imageId: string) => Promise<Result<BasicScanResult>>;
   ping: () => Promise<Result<boolean>>;
 }
@@ -26,21 +35,63 @@ export interface BasicScanResult {
   negligibleCount: number;
   unknownCount: number;
   scanDate: Date;
+  /** Scanner that produced the findings */
+  scanner?: string;
+  /** Problems with the scanner itself, such as an outdated version */
+  warnings?: string[];
+}
+
+export interface SecurityScannerOptions {
+  /** Install a pinned Trivy into the workspace when no scanner is found */
+  autoInstall?: boolean;
+}
+
+/**
+ * Create a Trivy-based security scanner. The preflight runs once, on first use.
+ */
+function createTrivyScanner(
+  logger: Logger,
+  options: TrivyPreflightOptions = {},
+): SecurityScanner {
+  let preflight: Promise<Result<ScannerPreflight>> | undefined;
+  const check = (): Promise<Result<ScannerPreflight>> =>
+    (preflight ??= preflightTrivy(logger, options));
+
+  return {
+    async scanImage(imageId: string): Promise<Result<BasicScanResult>> {
+      const ready = await check();
+      if (!ready.ok) return ready;
+
+      const { version, binary } = ready.value;
+      const result = await scanImageWithTrivy(imageId, logger, version, binary);
+      if (!result.ok || ready.value.warnings.length === 0) return result;
+      return Success({ ...result.value, warnings: ready.value.warnings });
+    },
+
+    async ping(): Promise<Result<boolean>> {
+      const result = await check();
+      if (result.ok) {
+        logger.debug({ version: result.value.version }, 'Trivy scanner available');
+        return Success(true);
+      }
+      return Failure(result.error, result.guidance);
+    },
+  };
 }
 
 /**
- * Create a Trivy-based security scanner
+ * Create a Grype-based security scanner
  */
-function createTrivyScanner(logger: Logger): SecurityScanner {
+function createGrypeScanner(logger: Logger): SecurityScanner {
   return {
     async scanImage(imageId: string): Promise<Result<BasicScanResult>> {
-      return scanImageWithTrivy(imageId, logger);
+      return scanImageWithGrype(imageId, logger);
     },
 
     async ping(): Promise<Result<boolean>> {
-      const result = await checkTrivyAvailability(logger);
+      const result = await checkGrypeAvailability(logger);
       if (result.ok) {
-        logger.debug({ version: result.value }, 'Trivy scanner available');
+        logger.debug({ version: result.value }, 'Grype scanner available');
         return Success(true);
       }
       return Failure(result.error, result.guidance);
@@ -48,6 +99,61 @@ function createTrivyScanner(logger: Logger): SecurityScanner {
   };
 }
 
+/**
+ * Create a scanner that uses whichever of Trivy or Grype is installed,
+ * preferring Trivy. Detection runs once, on first use; Trivy is only
+ * auto-installed when neither is found.
+ */
+function createAutoScanner(logger: Logger, options: SecurityScannerOptions): SecurityScanner {
+  const trivy = createTrivyScanner(logger, { autoInstall: false });
+  const grype = createGrypeScanner(logger);
+  let selected: Promise<Result<SecurityScanner>> | undefined;
+
+  const select = (): Promise<Result<SecurityScanner>> => {
+    selected ??= (async () => {
+      const trivyPing = await trivy.ping();
+      if (trivyPing.ok) return Success(trivy);
+
+      const grypePing = await grype.ping();
+      if (grypePing.ok) {
+        logger.info('Trivy not found, using Grype for security scanning');
+        return Success(grype);
+      }
+
+      if (options.autoInstall ?? parseBoolEnv(AUTO_INSTALL_SCANNER_ENV, false)) {
+        const installed = createTrivyScanner(logger, { autoInstall: true });
+        const installedPing = await installed.ping();
+        if (!installedPing.ok) return installedPing;
+        return Success(installed);
+      }
+
+      return Failure('No supported security scanner found (Trivy or Grype)', {
+        message: 'Neither Trivy nor Grype is installed',
+        hint: 'A vulnerability scanner CLI is required for security scanning',
+        resolution:
+          'Install Trivy (https://aquasecurity.github.io/trivy/latest/getting-started/installation/) or Grype (https://github.com/anchore/grype#installation), ' +
+          `or set ${AUTO_INSTALL_SCANNER_ENV}=true to download Trivy into the workspace`,
+        details: { code: SCANNER_UNAVAILABLE },
+      });
+    })();
+    return selected;
+  };
+
+  return {
+    async scanImage(imageId: string): Promise<Result<BasicScanResult>> {
+      const scanner = await select();
+      if (!scanner.ok) return scanner;
+      return scanner.value.scanImage(imageId);
+    },
+
+    async ping(): Promise<Result<boolean>> {
+      const scanner = await select();
+      if (!scanner.ok) return scanner;
+      return Success(true);
+    },
+  };
+}
+
 /**
  * Create a stub scanner that returns empty results
  * Used when no real scanner is configured
@@ -96,19 +202,28 @@ function createStubScanner(logger: Logger): SecurityScanner {
  * Create a security scanner based on the specified type
  *
  * @param logger - Logger instance
- * @param scannerType - Type of scanner to create ('trivy', 'stub', or undefined for 'trivy')
+ * @param scannerType - Type of scanner to create ('trivy', 'grype', 'auto', 'stub', or undefined for 'trivy')
+ * @param options - Auto-install behaviour; defaults to CONTAINERIZATION_ASSIST_AUTO_INSTALL_SCANNER
  * @returns SecurityScanner instance
  */
-export const createSecurityScanner = (logger: Logger, scannerType?: string): SecurityScanner => {
+export const createSecurityScanner = (
+  logger: Logger,
+  scannerType?: string,
+  options: SecurityScannerOptions = {},
+): SecurityScanner => {
   const type = (scannerType || 'trivy').toLowerCase();
 
   switch (type) {
     case 'trivy':
-      return createTrivyScanner(logger);
+      return createTrivyScanner(logger, options);
+    case 'grype':
+      return createGrypeScanner(logger);
+    case 'auto':
+      return createAutoScanner(logger, options);
     case 'stub':
       return createStubScanner(logger);
     default:
       logger.warn({ scannerType: type }, 'Unknown scanner type, falling back to Trivy');
-      return createTrivyScanner(logger);
+      return createTrivyScanner(logger, options);
   }
 };
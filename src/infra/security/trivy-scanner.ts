@@ -8,6 +8,7 @@
  */
 
 import { exec, execFile } from 'node:child_process';
+import { join } from 'node:path';
 import { promisify } from 'node:util';
 import type { Logger } from 'pino';
 
@@ -15,6 +16,7 @@ import { extractErrorMessage } from '@/lib/errors';
 import { Result, Success, Failure } from '@/types';
 import type { BasicScanResult } from './scanner';
 import { DEFAULT_TIMEOUTS, LIMITS } from '@/config/constants';
+import { parseStringEnv } from '@/config/env-utils';
 
 const execAsync = promisify(exec);
 const execFileAsync = promisify(execFile);
@@ -39,7 +41,7 @@ interface TrivyResult {
   Vulnerabilities?: TrivyVulnerability[];
 }
 
-interface TrivyOutput {
+export interface TrivyOutput {
   SchemaVersion: number;
   ArtifactName: string;
   ArtifactType: string;
@@ -81,7 +83,7 @@ function mapTrivySeverity(
 /**
  * Parse Trivy JSON output to our BasicScanResult format
  */
-function parseTrivyOutput(trivyOutput: TrivyOutput, imageId: string): BasicScanResult {
+export function parseTrivyOutput(trivyOutput: TrivyOutput, imageId: string): BasicScanResult {
   const vulnerabilities: BasicScanResult['vulnerabilities'] = [];
   let criticalCount = 0;
   let highCount = 0;
@@ -146,6 +148,7 @@ function parseTrivyOutput(trivyOutput: TrivyOutput, imageId: string): BasicScanR
     negligibleCount,
     unknownCount,
     scanDate: new Date(),
+    scanner: 'trivy',
   };
 }
 
@@ -153,18 +156,36 @@ function parseTrivyOutput(trivyOutput: TrivyOutput, imageId: string): BasicScanR
  * Validate imageId against allowlist pattern to prevent command injection
  * Allows: alphanumeric, dots, colons, slashes, at-signs, underscores, and hyphens
  */
-function validateImageId(imageId: string): boolean {
+export function validateImageId(imageId: string): boolean {
   const allowedPattern = /^[a-zA-Z0-9._:/@-]+$/;
   return allowedPattern.test(imageId);
 }
 
+/**
+ * Directory auto-installed scanner binaries are placed in. It is never put
+ * on the PATH: an installed binary is only run by its absolute path, after
+ * its checksum was verified in this process.
+ */
+export function scannerInstallDir(): string {
+  return join(parseStringEnv('WORKSPACE_DIR', process.cwd()), '.containerization-assist', 'bin');
+}
+
+/**
+ * Quote a path for the shell unless it is a plain word like `trivy`
+ */
+function shellArg(value: string): string {
+  return /^[\w./-]+$/.test(value) ? value : `'${value.replace(/'/g, `'\\''`)}'`;
+}
+
 /**
  * Get Trivy version
  * @throws Error if Trivy is not installed or execution fails
  */
-async function getTrivyVersion(logger: Logger): Promise<string | undefined> {
+async function getTrivyVersion(logger: Logger, binary: string): Promise<string | undefined> {
   try {
-    const { stdout } = await execAsync('trivy --version', { timeout: DEFAULT_TIMEOUTS.trivyVersionCheck });
+    const { stdout } = await execAsync(`${shellArg(binary)} --version`, {
+      timeout: DEFAULT_TIMEOUTS.trivyVersionCheck,
+    });
     // Trivy version output format: "Version: X.Y.Z"
     const match = stdout.match(/Version:\s*([^\s\n]+)/);
     if (!match) {
@@ -186,10 +207,15 @@ async function getTrivyVersion(logger: Logger): Promise<string | undefined> {
 
 /**
  * Check if Trivy is installed and accessible
+ *
+ * @param binary - Trivy executable: `trivy` from the PATH, or the absolute path of an installed one
  */
-export async function checkTrivyAvailability(logger: Logger): Promise<Result<string>> {
+export async function checkTrivyAvailability(
+  logger: Logger,
+  binary = 'trivy',
+): Promise<Result<string>> {
   try {
-    const version = await getTrivyVersion(logger);
+    const version = await getTrivyVersion(logger, binary);
     if (!version) {
       return Failure('Trivy is installed but version could not be determined', {
         message: 'Trivy version check failed',
@@ -211,10 +237,15 @@ export async function checkTrivyAvailability(logger: Logger): Promise<Result<str
 
 /**
  * Scan a Docker image using Trivy
+ *
+ * @param trivyVersion - Version from an earlier availability check; checked here when unset
+ * @param binary - Trivy executable: `trivy` from the PATH, or the absolute path of an installed one
  */
 export async function scanImageWithTrivy(
   imageId: string,
   logger: Logger,
+  trivyVersion?: string,
+  binary = 'trivy',
 ): Promise<Result<BasicScanResult>> {
   // Validate imageId to prevent command injection
   if (!validateImageId(imageId)) {
@@ -226,13 +257,14 @@ export async function scanImageWithTrivy(
     });
   }
 
-  // Check if Trivy is available
-  const availabilityCheck = await checkTrivyAvailability(logger);
-  if (!availabilityCheck.ok) {
-    return Failure(availabilityCheck.error, availabilityCheck.guidance);
+  if (trivyVersion === undefined) {
+    const availabilityCheck = await checkTrivyAvailability(logger, binary);
+    if (!availabilityCheck.ok) {
+      return Failure(availabilityCheck.error, availabilityCheck.guidance);
+    }
+    trivyVersion = availabilityCheck.value;
   }
 
-  const trivyVersion = availabilityCheck.value;
   logger.info({ trivyVersion, imageId }, 'Starting Trivy scan');
 
   try {
@@ -243,7 +275,7 @@ export async function scanImageWithTrivy(
     const args = ['image', '--format', 'json', '--quiet', '--timeout', '5m', imageId];
     logger.debug({ args }, 'Executing Trivy command');
 
-    const { stdout, stderr } = await execFileAsync('trivy', args, {
+    const { stdout, stderr } = await execFileAsync(binary, args, {
       maxBuffer: LIMITS.MAX_SCAN_BUFFER, // 10MB buffer for large scan results
     });
 
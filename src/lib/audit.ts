@@ -0,0 +1,253 @@
+/**
+ * Tool Execution Audit Log
+ *
+ * Records who ran which tool, when, and with what outcome. Arguments are
+ * stored as a digest only, so secrets passed to tools never reach the audit
+ * trail. Records are buffered and handed to a pluggable sink in the
+ * background: recording never waits for the sink, and records that do not fit
+ * in the buffer (or that the sink fails to write) are dropped and counted.
+ *
+ * Sinks that can read their records back also serve per-session traces: the
+ * ordered tool calls of one session, for debugging.
+ */
+
+import { createReadStream } from 'node:fs';
+import { appendFile, mkdir } from 'node:fs/promises';
+import { dirname } from 'node:path';
+import { createInterface } from 'node:readline';
+import type { Logger } from 'pino';
+import { extractErrorMessage } from '@/lib/errors';
+import { Failure, Success, type Result } from '@/types';
+
+export type AuditOutcome = 'success' | 'failure';
+
+export interface AuditRecord {
+  /** ISO 8601 time the execution started */
+  timestamp: string;
+  tool: string;
+  sessionId?: string;
+  requestId?: string;
+  /** SHA-256 of the arguments (see digestArgs) */
+  argsDigest: string;
+  durationMs: number;
+  outcome: AuditOutcome;
+}
+
+/**
+ * Destination for audit records, e.g. a file or a log shipper
+ */
+export interface AuditSink {
+  write(records: AuditRecord[]): Promise<void>;
+  /** Read back the records of a session in the order they were written */
+  read?(sessionId: string): AsyncIterable<AuditRecord>;
+}
+
+export interface AuditTraceQuery {
+  sessionId: string;
+  /** Only executions that started at or after this ISO 8601 time */
+  since?: string;
+  /** Most recent executions to return (default 100, at most 1000) */
+  limit?: number;
+}
+
+export interface AuditTrace {
+  sessionId: string;
+  /** Executions in the order they started */
+  entries: AuditRecord[];
+  /** Executions matching the query, including ones left out by the limit */
+  total: number;
+  /** True when older executions were left out by the limit */
+  truncated: boolean;
+}
+
+export interface AuditLog {
+  /** Queue a record; never blocks and never throws */
+  record(record: AuditRecord): void;
+  /** Wait until every queued record has been handed to the sink */
+  flush(): Promise<void>;
+  /** Records lost because the buffer was full or the sink failed */
+  droppedCount(): number;
+  /** Tool executions of a session, when the sink can read records back */
+  trace(query: AuditTraceQuery): Promise<Result<AuditTrace>>;
+}
+
+export interface AuditLogOptions {
+  /** Records buffered while the sink is busy before new ones are dropped (default 1000) */
+  maxPending?: number;
+  logger?: Logger;
+}
+
+export const DEFAULT_MAX_PENDING_AUDIT_RECORDS = 1000;
+export const DEFAULT_TRACE_LIMIT = 100;
+export const MAX_TRACE_LIMIT = 1000;
+
+/**
+ * Create a buffered, best-effort audit log in front of a sink
+ */
+export function createAuditLog(sink: AuditSink, options: AuditLogOptions = {}): AuditLog {
+  const maxPending = options.maxPending ?? DEFAULT_MAX_PENDING_AUDIT_RECORDS;
+  let pending: AuditRecord[] = [];
+  let dropped = 0;
+  let draining: Promise<void> | undefined;
+
+  const drain = async (): Promise<void> => {
+    while (pending.length > 0) {
+      const batch = pending;
+      pending = [];
+      try {
+        await sink.write(batch);
+      } catch (error) {
+        dropped += batch.length;
+        options.logger?.warn(
+          { error: extractErrorMessage(error), dropped: batch.length },
+          'Failed to write audit records',
+        );
+      }
+    }
+  };
+
+  const startDrain = (): void => {
+    if (draining) return;
+    // Start on a later tick so recording returns before the sink is called
+    draining = new Promise<void>((resolve) => setImmediate(resolve))
+      .then(drain)
+      .finally(() => {
+        draining = undefined;
+      });
+  };
+
+  const flush = async (): Promise<void> => {
+    while (draining) await draining;
+  };
+
+  return {
+    record(record) {
+      if (pending.length >= maxPending) {
+        dropped++;
+        return;
+      }
+      pending.push(record);
+      startDrain();
+    },
+
+    flush,
+
+    droppedCount() {
+      return dropped;
+    },
+
+    async trace({ sessionId, since, limit = DEFAULT_TRACE_LIMIT }) {
+      if (!sink.read) {
+        return Failure('The audit sink cannot be read back', {
+          message: 'Session traces are not available',
+          hint: 'Traces are read from the audit log, and this sink only writes',
+          resolution: 'Use createJsonlAuditSink or createMemoryAuditSink for the audit log',
+        });
+      }
+
+      const sinceMs = since === undefined ? undefined : Date.parse(since);
+      if (sinceMs !== undefined && Number.isNaN(sinceMs)) {
+        return Failure(`Invalid since time: ${since}`, {
+          message: 'since must be an ISO 8601 time',
+          resolution: 'Pass a time such as 2025-01-01T12:00:00Z',
+        });
+      }
+      const cap = Math.min(Math.max(1, Math.floor(limit)), MAX_TRACE_LIMIT);
+
+      try {
+        // Include executions still waiting for the sink
+        await flush();
+
+        const entries: AuditRecord[] = [];
+        let total = 0;
+        for await (const entry of sink.read(sessionId)) {
+          if (entry.sessionId !== sessionId) continue;
+          if (sinceMs !== undefined && Date.parse(entry.timestamp) < sinceMs) continue;
+          total++;
+          entries.push(entry);
+          if (entries.length > cap) entries.shift();
+        }
+        // Records are written as executions finish; report them in start order
+        entries.sort((a, b) => Date.parse(a.timestamp) - Date.parse(b.timestamp));
+
+        return Success({ sessionId, entries, total, truncated: total > entries.length });
+      } catch (error) {
+        return Failure(`Failed to read audit log: ${extractErrorMessage(error)}`, {
+          message: 'Session trace could not be read',
+          hint: extractErrorMessage(error),
+          resolution: 'Check that the audit log file is readable',
+        });
+      }
+    },
+  };
+}
+
+/**
+ * Sink that keeps the most recent records in memory; lost on restart, for
+ * tests and embedding
+ */
+export function createMemoryAuditSink(maxRecords = 10000): AuditSink {
+  let records: AuditRecord[] = [];
+
+  return {
+    async write(batch) {
+      records.push(...batch);
+      if (records.length > maxRecords) records = records.slice(-maxRecords);
+    },
+
+    async *read(sessionId) {
+      for (const record of records) {
+        if (record.sessionId === sessionId) yield record;
+      }
+    },
+  };
+}
+
+/**
+ * Sink that appends one JSON object per line to a file
+ *
+ * Reading streams the file line by line, so traces of large logs do not load
+ * the whole file; lines that are not valid records are skipped.
+ */
+export function createJsonlAuditSink(filePath: string): AuditSink {
+  let ready: Promise<unknown> | undefined;
+
+  return {
+    async write(records) {
+      ready ??= mkdir(dirname(filePath), { recursive: true });
+      await ready;
+      await appendFile(filePath, records.map((record) => `${JSON.stringify(record)}\n`).join(''));
+    },
+
+    async *read(sessionId) {
+      const stream = createReadStream(filePath, { encoding: 'utf-8' });
+      const opened = new Promise<boolean>((resolve, reject) => {
+        stream.once('open', () => resolve(true));
+        stream.once('error', (error: NodeJS.ErrnoException) =>
+          error.code === 'ENOENT' ? resolve(false) : reject(error),
+        );
+      });
+      // Nothing has been recorded yet
+      if (!(await opened)) return;
+
+      const lines = createInterface({ input: stream, crlfDelay: Infinity });
+      const encodedId = JSON.stringify(sessionId);
+      try {
+        for await (const line of lines) {
+          // Cheap check before parsing; most lines belong to other sessions
+          if (!line.includes(encodedId)) continue;
+          let record: AuditRecord;
+          try {
+            record = JSON.parse(line) as AuditRecord;
+          } catch {
+            continue;
+          }
+          if (record.sessionId === sessionId) yield record;
+        }
+      } finally {
+        lines.close();
+        stream.destroy();
+      }
+    },
+  };
+}
@@ -0,0 +1,162 @@
+/**
+ * Circuit Breaker
+ *
+ * Per-tool breaker that stops calling a tool which keeps failing, e.g. every
+ * build while the Docker daemon is down. After a number of consecutive
+ * failures the circuit opens and calls are rejected immediately with a
+ * CIRCUIT_OPEN failure. Once the cool-down has elapsed a single half-open
+ * probe is let through: success closes the circuit, failure opens it again.
+ */
+
+import { Success, Failure, type Result } from '@/types';
+
+export const CIRCUIT_OPEN = 'CIRCUIT_OPEN';
+
+export type CircuitState = 'closed' | 'open' | 'half-open';
+
+export interface CircuitBreakerOptions {
+  /** Consecutive failures that open the circuit (default 5) */
+  failureThreshold?: number;
+  /** Time the circuit stays open before a probe is allowed (ms, default 30s) */
+  cooldownMs?: number;
+  /** Clock, replaceable in tests */
+  now?: () => number;
+}
+
+export interface CircuitBreaker {
+  /**
+   * Ask to run the tool. Returns a CIRCUIT_OPEN failure while the circuit is
+   * open or a half-open probe is already in flight. Every granted call must
+   * be followed by exactly one recordSuccess, recordFailure or release.
+   */
+  acquire(tool: string): Result<void>;
+  recordSuccess(tool: string): void;
+  recordFailure(tool: string): void;
+  /**
+   * End a granted call that says nothing about the tool's health, such as one
+   * cancelled by the caller. The circuit is unchanged; a half-open probe may
+   * be tried again.
+   */
+  release(tool: string): void;
+  state(tool: string): CircuitState;
+  /** Close every circuit */
+  reset(): void;
+}
+
+interface ToolCircuit {
+  state: CircuitState;
+  consecutiveFailures: number;
+  openedAt: number;
+  probeInFlight: boolean;
+}
+
+export const DEFAULT_FAILURE_THRESHOLD = 5;
+export const DEFAULT_COOLDOWN_MS = 30_000;
+
+/**
+ * Create a circuit breaker that tracks each tool separately
+ */
+export function createCircuitBreaker(options: CircuitBreakerOptions = {}): CircuitBreaker {
+  const failureThreshold = options.failureThreshold ?? DEFAULT_FAILURE_THRESHOLD;
+  const cooldownMs = options.cooldownMs ?? DEFAULT_COOLDOWN_MS;
+  const now = options.now ?? Date.now;
+
+  if (!Number.isInteger(failureThreshold) || failureThreshold < 1) {
+    throw new Error(
+      `Circuit breaker failureThreshold must be a positive integer, got ${failureThreshold}`,
+    );
+  }
+
+  const circuits = new Map<string, ToolCircuit>();
+
+  const circuitFor = (tool: string): ToolCircuit => {
+    let circuit = circuits.get(tool);
+    if (!circuit) {
+      circuit = { state: 'closed', consecutiveFailures: 0, openedAt: 0, probeInFlight: false };
+      circuits.set(tool, circuit);
+    }
+    return circuit;
+  };
+
+  // An open circuit becomes half-open once its cool-down has elapsed
+  const currentState = (circuit: ToolCircuit): CircuitState => {
+    if (circuit.state === 'open' && now() - circuit.openedAt >= cooldownMs) {
+      circuit.state = 'half-open';
+    }
+    return circuit.state;
+  };
+
+  const open = (circuit: ToolCircuit): void => {
+    circuit.state = 'open';
+    circuit.openedAt = now();
+  };
+
+  return {
+    acquire(tool) {
+      const circuit = circuitFor(tool);
+      const state = currentState(circuit);
+
+      if (state === 'closed') return Success(undefined);
+      if (state === 'half-open' && !circuit.probeInFlight) {
+        circuit.probeInFlight = true;
+        return Success(undefined);
+      }
+
+      const failures = circuit.consecutiveFailures;
+      const retryAfterMs =
+        state === 'open' ? Math.max(0, circuit.openedAt + cooldownMs - now()) : 0;
+      const retryAfterSeconds = Math.ceil(retryAfterMs / 1000);
+      return Failure(`Circuit open for ${tool}: too many consecutive failures`, {
+        message: `${tool} is temporarily disabled after ${failures} consecutive failures`,
+        hint:
+          state === 'open'
+            ? `Calls are rejected for another ${retryAfterSeconds}s before a trial call is allowed`
+            : 'A trial call is already checking whether the tool has recovered',
+        resolution:
+          'Fix the underlying problem (e.g. start the Docker daemon) and retry after the cool-down',
+        details: {
+          code: CIRCUIT_OPEN,
+          tool,
+          state,
+          consecutiveFailures: failures,
+          retryAfterMs,
+        },
+        // Retrying before the cool-down is rejected the same way
+        retryable: false,
+      });
+    },
+
+    recordSuccess(tool) {
+      const circuit = circuitFor(tool);
+      circuit.state = 'closed';
+      circuit.consecutiveFailures = 0;
+      circuit.probeInFlight = false;
+    },
+
+    recordFailure(tool) {
+      const circuit = circuitFor(tool);
+      circuit.consecutiveFailures++;
+
+      if (circuit.probeInFlight || currentState(circuit) === 'half-open') {
+        // The probe failed: back to open for another cool-down
+        circuit.probeInFlight = false;
+        open(circuit);
+      } else if (circuit.state === 'closed' && circuit.consecutiveFailures >= failureThreshold) {
+        open(circuit);
+      }
+    },
+
+    release(tool) {
+      circuitFor(tool).probeInFlight = false;
+    },
+
+    state(tool) {
+      const circuit = circuits.get(tool);
+      return circuit ? currentState(circuit) : 'closed';
+    },
+
+    reset() {
+      circuits.clear();
+    },
+  };
+}
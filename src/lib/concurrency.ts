@@ -0,0 +1,124 @@
+/**
+ * Concurrency Utilities
+ *
+ * Counting semaphore used to cap how many operations run at once.
+ * Waiters are served in FIFO order and can be cancelled via AbortSignal
+ * while still queued. The wait queue is unbounded unless maxQueue is set,
+ * in which case callers beyond it are rejected with a QUEUE_FULL failure
+ * instead of piling up.
+ */
+
+import { Success, Failure, type Result } from '@/types';
+
+export const QUEUE_FULL = 'QUEUE_FULL';
+
+/**
+ * Releases a previously acquired slot. Safe to call more than once.
+ */
+export type ReleaseFn = () => void;
+
+export interface Semaphore {
+  /**
+   * Wait for a free slot. Resolves with a release function, or a Failure
+   * if the signal is aborted before a slot becomes available or the wait
+   * queue is full.
+   */
+  acquire(signal?: AbortSignal): Promise<Result<ReleaseFn>>;
+  /** Number of callers currently holding a slot */
+  activeCount(): number;
+  /** Number of callers waiting for a slot */
+  queueDepth(): number;
+  /** Maximum number of concurrent holders */
+  readonly limit: number;
+}
+
+export interface SemaphoreOptions {
+  /** Callers allowed to wait for a slot; further callers fail at once (unset = unbounded) */
+  maxQueue?: number;
+}
+
+interface Waiter {
+  grant: (release: ReleaseFn) => void;
+}
+
+/**
+ * Create a counting semaphore with the given limit
+ *
+ * @param limit - Maximum concurrent holders (must be a positive integer)
+ */
+export function createSemaphore(limit: number, options: SemaphoreOptions = {}): Semaphore {
+  if (!Number.isInteger(limit) || limit < 1) {
+    throw new Error(`Semaphore limit must be a positive integer, got ${limit}`);
+  }
+  const { maxQueue } = options;
+  if (maxQueue !== undefined && (!Number.isInteger(maxQueue) || maxQueue < 0)) {
+    throw new Error(`Semaphore maxQueue must be zero or a positive integer, got ${maxQueue}`);
+  }
+
+  let active = 0;
+  const waiters: Waiter[] = [];
+
+  const makeRelease = (): ReleaseFn => {
+    let released = false;
+    return () => {
+      if (released) return;
+      released = true;
+
+      const next = waiters.shift();
+      if (next) {
+        // Hand the slot straight to the next waiter; active count is unchanged
+        next.grant(makeRelease());
+      } else {
+        active--;
+      }
+    };
+  };
+
+  return {
+    limit,
+
+    acquire(signal?: AbortSignal): Promise<Result<ReleaseFn>> {
+      if (signal?.aborted) {
+        return Promise.resolve(Failure('Operation cancelled while waiting for a free slot'));
+      }
+
+      if (active < limit) {
+        active++;
+        return Promise.resolve(Success(makeRelease()));
+      }
+
+      if (maxQueue !== undefined && waiters.length >= maxQueue) {
+        const message = `Queue is full: ${waiters.length} callers already waiting for a free slot`;
+        return Promise.resolve(
+          Failure(message, {
+            message,
+            details: { code: QUEUE_FULL, queueDepth: waiters.length, maxQueue, limit },
+            retryable: true,
+          }),
+        );
+      }
+
+      return new Promise((resolve) => {
+        const onAbort = (): void => {
+          const index = waiters.indexOf(waiter);
+          if (index !== -1) waiters.splice(index, 1);
+          resolve(Failure('Operation cancelled while waiting for a free slot'));
+        };
+
+        const waiter: Waiter = {
+          grant: (release) => {
+            signal?.removeEventListener('abort', onAbort);
+            resolve(Success(release));
+          },
+        };
+
+        waiters.push(waiter);
+        signal?.addEventListener('abort', onAbort, { once: true });
+      });
+    },
+
+    activeCount: () => active,
+
+    queueDepth: () => waiters.length,
+  };
+}
@@ -0,0 +1,215 @@
+/**
+ * Image reference parsing
+ *
+ * Single parser for `[registry[:port]/]repository[:tag][@digest]` references so
+ * every tool agrees on where the registry ends, what a colon means and which
+ * defaults apply. Follows the grammar of the distribution reference package.
+ */
+
+import { Failure, Success, type Result } from '@/types';
+
+/** Registry assumed when a reference names none */
+export const DOCKER_HUB_REGISTRY = 'docker.io';
+
+/**
+ * Components of an image reference, as written
+ *
+ * @example
+ * parseImageRef('localhost:5000/team/app:dev')
+ * // => { registry: 'localhost:5000', repository: 'team/app', tag: 'dev' }
+ *
+ * parseImageRef('ubuntu')
+ * // => { repository: 'ubuntu', tag: 'latest' }
+ */
+export interface ImageRef {
+  /** Registry host with optional port; undefined for Docker Hub short names */
+  registry?: string;
+  /** Repository path without registry, e.g. `ubuntu` or `team/app` */
+  repository: string;
+  /** Tag; `latest` when the reference has neither tag nor digest */
+  tag?: string;
+  /** Content digest, e.g. `sha256:4b8e...` */
+  digest?: string;
+}
+
+const MAX_NAME_LENGTH = 255;
+const MAX_TAG_LENGTH = 128;
+const TAG_PATTERN = /^[\w][\w.-]*$/;
+const DOMAIN_COMPONENT = '[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?';
+const REGISTRY_PATTERN = new RegExp(`^${DOMAIN_COMPONENT}(?:\\.${DOMAIN_COMPONENT})*(?::\\d+)?$`);
+const PATH_COMPONENT_PATTERN = /^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*$/;
+const DIGEST_PATTERN = /^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$/;
+const SHA256_HEX = /^[a-f0-9]{64}$/;
+const IMAGE_ID_PATTERN = /^(?:sha256:[a-f0-9]{64}|[a-f0-9]{12}|[a-f0-9]{64})$/;
+
+/**
+ * A leading path component is a registry host when it has a dot or port, or is localhost
+ */
+const isRegistryHost = (component: string): boolean =>
+  component.includes('.') || component.includes(':') || component === 'localhost';
+
+const invalidReference = (
+  reference: string,
+  message: string,
+  hint: string,
+): Result<ImageRef> =>
+  Failure(`Invalid image reference: ${message}`, {
+    message: `Invalid image reference: ${message}`,
+    hint,
+    resolution:
+      'Use the form [registry[:port]/]repository[:tag][@digest], e.g. "localhost:5000/app:1.0" or "ubuntu@sha256:<64 hex chars>"',
+    details: { reference },
+  });
+
+/**
+ * Whether a value is a local image ID (full or 12-character short form) rather than a reference
+ */
+export const isImageId = (value: string): boolean => IMAGE_ID_PATTERN.test(value.trim());
+
+/**
+ * Parse an image reference into registry, repository, tag and digest
+ *
+ * The first path component is the registry only when it contains a dot or a
+ * port or is `localhost`, so `localhost:5000/foo` names a registry while
+ * `myorg/app` is a Docker Hub repository. A colon after the last slash
+ * separates the tag. When neither tag nor digest is given, the tag defaults to
+ * `latest`.
+ *
+ * @param reference - Image reference such as `node:20`, `ghcr.io/org/app@sha256:...`
+ * @returns Parsed components or error
+ */
+export function parseImageRef(reference: string): Result<ImageRef> {
+  const ref = reference?.trim();
+  if (!ref) {
+    return Failure('Image name cannot be empty', {
+      message: 'Image name is required',
+      hint: 'Docker image names must contain at least a repository name',
+      resolution: 'Provide a valid image name, e.g., "myapp:latest" or "docker.io/library/node:20"',
+    });
+  }
+
+  let name = ref;
+  let digest: string | undefined;
+  const at = name.indexOf('@');
+  if (at >= 0) {
+    digest = name.slice(at + 1);
+    name = name.slice(0, at);
+    const algorithm = digest.split(':')[0];
+    if (
+      !DIGEST_PATTERN.test(digest) ||
+      (algorithm === 'sha256' && !SHA256_HEX.test(digest.slice(algorithm.length + 1)))
+    ) {
+      return invalidReference(
+        ref,
+        `malformed digest "${digest}"`,
+        'Digests have the form algorithm:hex, and sha256 digests have 64 lowercase hex characters',
+      );
+    }
+  }
+
+  let registry: string | undefined;
+  const slash = name.indexOf('/');
+  const first = slash >= 0 ? name.slice(0, slash) : '';
+  let path = name;
+  if (slash >= 0 && isRegistryHost(first)) {
+    if (!REGISTRY_PATTERN.test(first)) {
+      return invalidReference(
+        ref,
+        `malformed registry host "${first}"`,
+        'Registry hosts are DNS names or IP addresses with an optional numeric port',
+      );
+    }
+    registry = first;
+    path = name.slice(slash + 1);
+  }
+
+  // With the registry removed, any colon separates the tag
+  let tag: string | undefined;
+  const colon = path.lastIndexOf(':');
+  if (colon >= 0) {
+    tag = path.slice(colon + 1);
+    path = path.slice(0, colon);
+    if (tag.length > MAX_TAG_LENGTH) {
+      return invalidReference(
+        ref,
+        `tag too long (max ${MAX_TAG_LENGTH} characters)`,
+        'Docker tags cannot exceed 128 characters',
+      );
+    }
+    if (!TAG_PATTERN.test(tag)) {
+      return invalidReference(
+        ref,
+        `invalid tag "${tag}"`,
+        'Tags use letters, digits, "_", "." and "-", and cannot start with "." or "-"',
+      );
+    }
+  }
+
+  if (!path) {
+    return Failure('Invalid image name: repository is required', {
+      message: 'Could not parse repository from image name',
+      hint: 'Image name format should be [registry/][namespace/]repository[:tag]',
+      resolution: 'Provide a valid image name with at least a repository component',
+      details: { providedName: ref },
+    });
+  }
+
+  const badComponent = path.split('/').find((c) => !PATH_COMPONENT_PATTERN.test(c));
+  if (badComponent !== undefined) {
+    return invalidReference(
+      ref,
+      `repository component "${badComponent}" is not valid`,
+      badComponent !== badComponent.toLowerCase()
+        ? 'Repository names must be lowercase'
+        : 'Repository components use lowercase letters and digits, separated by ".", "_", "__" or "-"',
+    );
+  }
+
+  const fullName = registry ? `${registry}/${path}` : path;
+  if (fullName.length > MAX_NAME_LENGTH) {
+    return invalidReference(
+      ref,
+      `name exceeds ${MAX_NAME_LENGTH} characters`,
+      'Registry and repository together must be at most 255 characters',
+    );
+  }
+
+  return Success({
+    ...(registry && { registry }),
+    repository: path,
+    ...(tag !== undefined ? { tag } : !digest && { tag: 'latest' }),
+    ...(digest && { digest }),
+  });
+}
+
+/**
+ * Image name without tag or digest, as written, e.g. `localhost:5000/app`
+ *
+ * This is the repository argument Docker expects when tagging or pushing.
+ */
+export function imageRefName(ref: ImageRef): string {
+  return ref.registry ? `${ref.registry}/${ref.repository}` : ref.repository;
+}
+
+/**
+ * Format a reference as written, e.g. `ubuntu:latest`
+ */
+export function formatImageRef(ref: ImageRef): string {
+  return `${imageRefName(ref)}${ref.tag ? `:${ref.tag}` : ''}${ref.digest ? `@${ref.digest}` : ''}`;
+}
+
+/**
+ * Fully qualified form of a reference, e.g. `ubuntu` -> `docker.io/library/ubuntu:latest`
+ *
+ * Fills in the Docker Hub registry and `library/` namespace for official
+ * images, so references that name the same image compare equal.
+ */
+export function normalizeImageRef(ref: ImageRef): string {
+  const registry =
+    !ref.registry || ref.registry === 'index.docker.io' ? DOCKER_HUB_REGISTRY : ref.registry;
+  const repository =
+    registry === DOCKER_HUB_REGISTRY && !ref.repository.includes('/')
+      ? `library/${ref.repository}`
+      : ref.repository;
+  return formatImageRef({ ...ref, registry, repository });
+}
@@ -13,7 +13,7 @@ export const LATEST_TAG = /:latest(?:\s|$)/;
 
 // Package manager patterns
 export const SUDO_INSTALL = /install.*sudo|apt-get.*sudo|yum.*sudo|apk.*sudo/;
-export const PACKAGE_FILES = /package.*\.json|requirements\.txt|go\.mod|pom\.xml/;
+export const PACKAGE_FILES = /package.*\.json|requirements\.txt|go\.mod|pom\.xml|deno\.jsonc?/;
 
 // Secret detection patterns (for validation, not extraction)
 export const PASSWORD_PATTERN = /\w*password\w*\s*=\s*["']?.+["']?/i;
@@ -0,0 +1,117 @@
+/**
+ * Tool Result Cache
+ *
+ * In-memory cache for results of idempotent tools, keyed by tool name and a
+ * digest of the validated arguments. Tools opt in through
+ * `metadata.cacheTtlMs`; the TTL can be overridden (or set to 0 to disable
+ * caching) per tool when the cache is created. Only successful results are
+ * stored.
+ */
+
+import { createHash } from 'node:crypto';
+
+export interface ResultCacheOptions {
+  /** Per-tool TTL overrides in ms; 0 disables caching for that tool */
+  ttlMs?: Record<string, number>;
+  /** Entries kept before the oldest is evicted (default 100) */
+  maxEntries?: number;
+  /** Clock, replaceable in tests */
+  now?: () => number;
+}
+
+export interface ResultCache {
+  /** TTL to use for a tool, or undefined when the tool is not cached */
+  ttlFor(tool: string, defaultTtlMs?: number): number | undefined;
+  /** Cached value for identical arguments, if present and not expired */
+  get(tool: string, args: unknown): { value: unknown } | undefined;
+  set(tool: string, args: unknown, value: unknown, ttlMs: number): void;
+  /** Drop cached results for one tool, or for all tools */
+  invalidate(tool?: string): void;
+  size(): number;
+}
+
+interface CacheEntry {
+  tool: string;
+  value: unknown;
+  expiresAt: number;
+}
+
+export const DEFAULT_MAX_CACHE_ENTRIES = 100;
+
+/**
+ * JSON with object keys sorted, so argument order does not change the digest
+ */
+function canonicalJson(value: unknown): string {
+  if (Array.isArray(value)) return `[${value.map(canonicalJson).join(',')}]`;
+  if (value && typeof value === 'object') {
+    const entries = Object.entries(value as Record<string, unknown>)
+      .filter(([, entry]) => entry !== undefined)
+      .sort(([a], [b]) => (a < b ? -1 : a > b ? 1 : 0));
+    const fields = entries.map(([key, entry]) => `${JSON.stringify(key)}:${canonicalJson(entry)}`);
+    return `{${fields.join(',')}}`;
+  }
+  return JSON.stringify(value) ?? 'null';
+}
+
+/**
+ * SHA-256 digest of tool arguments, independent of key order
+ */
+export function digestArgs(args: unknown): string {
+  return createHash('sha256').update(canonicalJson(args)).digest('hex');
+}
+
+/**
+ * Create an in-memory tool result cache
+ */
+export function createResultCache(options: ResultCacheOptions = {}): ResultCache {
+  const maxEntries = options.maxEntries ?? DEFAULT_MAX_CACHE_ENTRIES;
+  const now = options.now ?? Date.now;
+  const entries = new Map<string, CacheEntry>();
+
+  const keyFor = (tool: string, args: unknown): string => `${tool}:${digestArgs(args)}`;
+
+  return {
+    ttlFor(tool, defaultTtlMs) {
+      const ttl = options.ttlMs?.[tool] ?? defaultTtlMs;
+      return ttl !== undefined && ttl > 0 ? ttl : undefined;
+    },
+
+    get(tool, args) {
+      const key = keyFor(tool, args);
+      const entry = entries.get(key);
+      if (!entry) return undefined;
+      if (entry.expiresAt <= now()) {
+        entries.delete(key);
+        return undefined;
+      }
+      return { value: entry.value };
+    },
+
+    set(tool, args, value, ttlMs) {
+      const key = keyFor(tool, args);
+      // Re-insert so the newest entry is evicted last
+      entries.delete(key);
+      entries.set(key, { tool, value, expiresAt: now() + ttlMs });
+
+      while (entries.size > maxEntries) {
+        const oldest = entries.keys().next().value;
+        if (oldest === undefined) break;
+        entries.delete(oldest);
+      }
+    },
+
+    invalidate(tool) {
+      if (tool === undefined) {
+        entries.clear();
+        return;
+      }
+      for (const [key, entry] of entries) {
+        if (entry.tool === tool) entries.delete(key);
+      }
+    },
+
+    size() {
+      return entries.size;
+    },
+  };
+}
@@ -0,0 +1,139 @@
+/**
+ * Retry Utilities
+ *
+ * Re-runs an operation that returns a Result with exponential backoff.
+ * Only failures classified as transient (network errors, registry 5xx and
+ * rate limits) are retried by default; everything else is returned on the
+ * first attempt. Waiting between attempts stops as soon as the AbortSignal
+ * fires.
+ */
+
+import { RETRY } from '@/config/constants';
+import type { Result } from '@/types';
+
+export type FailedResult = Extract<Result<unknown>, { ok: false }>;
+
+export interface RetryPolicy {
+  /** Total attempts including the first one */
+  maxAttempts: number;
+  /** Delay before the first retry (ms) */
+  baseDelayMs: number;
+  /** Factor applied to the delay after each retry */
+  multiplier: number;
+  /** Upper bound for a single delay (ms) */
+  maxDelayMs?: number;
+  /** Decides whether a failure is worth retrying (defaults to isTransientFailure) */
+  retryable?: (failure: FailedResult) => boolean;
+}
+
+export const DEFAULT_RETRY_POLICY: RetryPolicy = {
+  maxAttempts: RETRY.MAX_ATTEMPTS,
+  baseDelayMs: RETRY.INITIAL_DELAY,
+  multiplier: RETRY.MULTIPLIER,
+  maxDelayMs: RETRY.MAX_DELAY,
+};
+
+/** Node.js network error codes that usually clear up on their own */
+const TRANSIENT_CODES = new Set([
+  'ECONNRESET',
+  'ECONNREFUSED',
+  'ETIMEDOUT',
+  'EAI_AGAIN',
+  'EPIPE',
+  'ENETUNREACH',
+  'EHOSTUNREACH',
+]);
+
+const TRANSIENT_MESSAGE =
+  /\b(ECONNRESET|ETIMEDOUT|EAI_AGAIN|socket hang up|timed? ?out|503|502|504|429|service unavailable|bad gateway|too many requests|temporarily unavailable)\b/i;
+
+/**
+ * Whether a failure is worth retrying. An explicit guidance.retryable flag
+ * wins; otherwise a network error code or an HTTP 5xx/429 in the guidance
+ * details, or a matching error message, marks the failure as transient.
+ */
+export function isTransientFailure(failure: FailedResult): boolean {
+  if (failure.guidance?.retryable !== undefined) return failure.guidance.retryable;
+
+  const details = failure.guidance?.details;
+  const code = details?.code;
+  const statusCode = details?.statusCode;
+
+  if (typeof code === 'string' && TRANSIENT_CODES.has(code)) return true;
+  if (typeof statusCode === 'number') {
+    return statusCode === 429 || (statusCode >= 500 && statusCode <= 599);
+  }
+  return TRANSIENT_MESSAGE.test(failure.error);
+}
+
+/**
+ * Delay before retry number `retry` (1 for the first retry)
+ */
+export function backoffDelay(policy: RetryPolicy, retry: number): number {
+  const delay = policy.baseDelayMs * policy.multiplier ** (retry - 1);
+  return policy.maxDelayMs !== undefined ? Math.min(delay, policy.maxDelayMs) : delay;
+}
+
+/**
+ * Wait for the given time; resolves false early if the signal aborts
+ */
+function sleep(ms: number, signal?: AbortSignal): Promise<boolean> {
+  if (signal?.aborted) return Promise.resolve(false);
+
+  return new Promise((resolve) => {
+    const onAbort = (): void => {
+      clearTimeout(timer);
+      resolve(false);
+    };
+    const timer = setTimeout(() => {
+      signal?.removeEventListener('abort', onAbort);
+      resolve(true);
+    }, ms);
+    signal?.addEventListener('abort', onAbort, { once: true });
+  });
+}
+
+export interface WithRetryOptions {
+  signal?: AbortSignal;
+  /** Called before waiting for the next attempt */
+  onRetry?: (failure: FailedResult, attempt: number, delayMs: number) => void;
+}
+
+/**
+ * Run an operation, retrying retryable failures with exponential backoff
+ *
+ * @returns The first success, the first non-retryable failure, or the last
+ *   failure once attempts run out or the signal aborts. Failures after more
+ *   than one attempt carry `attempts` in their guidance details.
+ */
+export async function withRetry<T>(
+  operation: (attempt: number) => Promise<Result<T>>,
+  policy: RetryPolicy,
+  options: WithRetryOptions = {},
+): Promise<Result<T>> {
+  const retryable = policy.retryable ?? isTransientFailure;
+  const maxAttempts = Math.max(1, policy.maxAttempts);
+
+  for (let attempt = 1; ; attempt++) {
+    const result = await operation(attempt);
+    if (result.ok) return result;
+
+    const done = attempt >= maxAttempts || !retryable(result) || options.signal?.aborted;
+    if (!done) {
+      const delayMs = backoffDelay(policy, attempt);
+      options.onRetry?.(result, attempt, delayMs);
+      if (await sleep(delayMs, options.signal)) continue;
+    }
+
+    return attempt === 1
+      ? result
+      : {
+          ...result,
+          guidance: {
+            message: result.guidance?.message ?? result.error,
+            ...result.guidance,
+            details: { ...result.guidance?.details, attempts: attempt },
+          },
+        };
+  }
+}
@@ -0,0 +1,124 @@
+/**
+ * Tool Execution Metrics
+ *
+ * In-process latency histograms and outcome counters for tool executions,
+ * rendered in the Prometheus text exposition format so any scrape endpoint
+ * can serve them as-is.
+ */
+
+/**
+ * Histogram bucket upper bounds in seconds.
+ * Covers sub-second analysis tools through multi-minute image builds.
+ */
+export const TOOL_DURATION_BUCKETS = [
+  0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600,
+] as const;
+
+export const TOOL_DURATION_METRIC = 'containerization_assist_tool_duration_seconds';
+export const TOOL_EXECUTIONS_METRIC = 'containerization_assist_tool_executions_total';
+
+/**
+ * cache_hit: served from the result cache without running the tool;
+ * cancelled: stopped by the caller (or its batch) before it finished
+ */
+export type ToolExecutionStatus = 'success' | 'failure' | 'cache_hit' | 'cancelled';
+
+export interface HistogramSnapshot {
+  /** Cumulative counts, aligned with TOOL_DURATION_BUCKETS */
+  buckets: number[];
+  count: number;
+  sum: number;
+}
+
+export interface ToolMetricSample {
+  tool: string;
+  status: ToolExecutionStatus;
+  duration: HistogramSnapshot;
+}
+
+export interface ToolMetrics {
+  /** Record a single tool execution */
+  record(tool: string, status: ToolExecutionStatus, durationMs: number): void;
+  /** Snapshot of all recorded series */
+  snapshot(): ToolMetricSample[];
+  /** Render all series in Prometheus text exposition format */
+  render(): string;
+  /** Drop all recorded samples */
+  reset(): void;
+}
+
+const seriesKey = (tool: string, status: ToolExecutionStatus): string => `${tool}\u0000${status}`;
+
+const escapeLabel = (value: string): string =>
+  value.replace(/\\/g, '\\\\').replace(/\n/g, '\\n').replace(/"/g, '\\"');
+
+/**
+ * Create an in-memory tool metrics recorder
+ */
+export function createToolMetrics(): ToolMetrics {
+  const series = new Map<string, ToolMetricSample>();
+
+  const snapshot = (): ToolMetricSample[] =>
+    Array.from(series.values()).map((sample) => ({
+      tool: sample.tool,
+      status: sample.status,
+      duration: { ...sample.duration, buckets: [...sample.duration.buckets] },
+    }));
+
+  return {
+    record(tool, status, durationMs) {
+      const key = seriesKey(tool, status);
+      const sample = series.get(key) ?? {
+        tool,
+        status,
+        duration: { buckets: TOOL_DURATION_BUCKETS.map(() => 0), count: 0, sum: 0 },
+      };
+      series.set(key, sample);
+
+      const seconds = Math.max(0, durationMs) / 1000;
+      TOOL_DURATION_BUCKETS.forEach((bound, i) => {
+        if (seconds <= bound) sample.duration.buckets[i] = (sample.duration.buckets[i] ?? 0) + 1;
+      });
+      sample.duration.count++;
+      sample.duration.sum += seconds;
+    },
+
+    snapshot,
+
+    render() {
+      const samples = snapshot().sort(
+        (a, b) => a.tool.localeCompare(b.tool) || a.status.localeCompare(b.status),
+      );
+      const lines: string[] = [
+        `# HELP ${TOOL_DURATION_METRIC} Tool execution latency in seconds`,
+        `# TYPE ${TOOL_DURATION_METRIC} histogram`,
+      ];
+
+      for (const { tool, status, duration } of samples) {
+        const labels = `tool="${escapeLabel(tool)}",status="${status}"`;
+        TOOL_DURATION_BUCKETS.forEach((bound, i) => {
+          lines.push(`${TOOL_DURATION_METRIC}_bucket{${labels},le="${bound}"} ${duration.buckets[i]}`);
+        });
+        lines.push(`${TOOL_DURATION_METRIC}_bucket{${labels},le="+Inf"} ${duration.count}`);
+        lines.push(`${TOOL_DURATION_METRIC}_sum{${labels}} ${duration.sum}`);
+        lines.push(`${TOOL_DURATION_METRIC}_count{${labels}} ${duration.count}`);
+      }
+
+      lines.push(
+        `# HELP ${TOOL_EXECUTIONS_METRIC} Tool executions by outcome`,
+        `# TYPE ${TOOL_EXECUTIONS_METRIC} counter`,
+      );
+      for (const { tool, status, duration } of samples) {
+        lines.push(
+          `${TOOL_EXECUTIONS_METRIC}{tool="${escapeLabel(tool)}",status="${status}"} ${duration.count}`,
+        );
+      }
+
+      return `${lines.join('\n')}\n`;
+    },
+
+    reset() {
+      series.clear();
+    },
+  };
+}
@@ -6,7 +6,8 @@
  * validation functions from @/lib/validation.
  */
 
-import { Failure, Success, type Result } from '@/types';
+import { Success, type Result } from '@/types';
+import { parseImageRef } from './image-ref';
 import { validatePath, validateDockerTag, type PathValidationOptions } from './validation';
 
 /**
@@ -39,6 +40,8 @@ export interface ParsedImageName {
  * - `org/image:tag` - Docker Hub organization image
  * - `registry.io/org/image:tag` - Private registry image
  *
+ * Delegates to {@link parseImageRef}; use that directly when digests matter.
+ *
  * @param imageName - Full Docker image name
  * @returns Parsed components or error
  *
@@ -52,93 +55,16 @@ export interface ParsedImageName {
  * ```
  */
 export function parseImageName(imageName: string): Result<ParsedImageName> {
-  if (!imageName?.trim()) {
-    return Failure('Image name cannot be empty', {
-      message: 'Image name is required',
-      hint: 'Docker image names must contain at least a repository name',
-      resolution: 'Provide a valid image name, e.g., "myapp:latest" or "docker.io/library/node:20"',
-    });
-  }
-
-  // Split by colon to separate image path from tag
-  const colonIndex = imageName.lastIndexOf(':');
-  let imagePath: string;
-  let tag: string;
-
-  if (colonIndex > 0) {
-    // Check if the part after colon looks like a port number (registry with port)
-    // Port pattern: :5000/... or :8080/...
-    const afterColon = imageName.substring(colonIndex + 1);
-    const hasPathAfterColon = afterColon.includes('/');
-    // Checks for 'registry:port/path' format: digits followed by a slash
-    const hasPortAndPath = /^\d+\//.test(afterColon);
-
-    if (hasPathAfterColon && hasPortAndPath) {
-      // This is a port number, not a tag (e.g., registry.io:5000/image)
-      imagePath = imageName;
-      tag = 'latest';
-    } else {
-      // This is a tag
-      imagePath = imageName.substring(0, colonIndex);
-      tag = afterColon || 'latest';
-    }
-  } else {
-    // No colon, default to 'latest' tag
-    imagePath = imageName;
-    tag = 'latest';
-  }
-
-  // Split path by slashes to identify registry and repository
-  const parts = imagePath.split('/').filter((p) => p.length > 0);
-  let registry: string | undefined;
-  let repository: string;
-
-  if (parts.length === 0) {
-    return Failure('Invalid image name: repository is required', {
-      message: 'Could not parse repository from image name',
-      hint: 'Image name format should be [registry/][namespace/]repository[:tag]',
-      resolution: 'Provide a valid image name with at least a repository component',
-      details: { providedName: imageName },
-    });
-  } else if (parts.length === 1) {
-    // Format: image (Docker Hub library)
-    repository = parts[0] || '';
-  } else if (parts.length === 2) {
-    // Format: org/image (Docker Hub org) or registry.io/image
-    // Check if first part contains a dot or colon (indicates registry)
-    if (parts[0] && (parts[0].includes('.') || parts[0].includes(':'))) {
-      registry = parts[0];
-      repository = parts[1] || '';
-    } else {
-      // Docker Hub organization
-      repository = imagePath;
-    }
-  } else {
-    // Format: registry.io/org/image (3+ parts)
-    registry = parts[0];
-    repository = parts.slice(1).join('/');
-  }
-
-  // Validate extracted components
-  if (!repository?.trim()) {
-    return Failure('Invalid image name: repository is required', {
-      message: 'Could not parse repository from image name',
-      hint: 'Image name format should be [registry/][namespace/]repository[:tag]',
-      resolution: 'Provide a valid image name with at least a repository component',
-      details: { providedName: imageName },
-    });
-  }
-
-  // Validate tag format using existing validator
-  const tagValidation = validateDockerTag(tag);
-  if (!tagValidation.ok) {
-    return tagValidation;
+  const parsed = parseImageRef(imageName);
+  if (!parsed.ok) {
+    return parsed;
   }
 
+  const { registry, repository, tag } = parsed.value;
   return Success({
     ...(registry && { registry }),
     repository,
-    tag,
+    tag: tag ?? 'latest',
     fullName: imageName,
   });
 }
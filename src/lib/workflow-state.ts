@@ -0,0 +1,705 @@
+/**
+ * Workflow State
+ *
+ * Persists the stages a workflow has completed, with their outputs, so a
+ * long containerization flow (analyze → generate → build → scan → deploy) can
+ * continue after a server restart instead of starting over. Each stage keeps
+ * a digest of what it consumed (tool arguments, or a workflow step's
+ * dependency outputs); a stage is only reused while that digest still
+ * matches, so changed inputs are never answered with stale outputs.
+ *
+ * The file store can cap the disk used per workflow and in total: saves over
+ * the per-workflow limit fail with DISK_QUOTA_EXCEEDED, and the least
+ * recently used idle workflows are evicted to stay under the total limit.
+ * Workflows left unused for longer than a maximum idle age are removed, and
+ * cleanup removes a given workflow on demand; active workflows are never
+ * removed either way.
+ */
+
+import { mkdir, readdir, readFile, rename, rm, stat, writeFile } from 'node:fs/promises';
+import { join } from 'node:path';
+import type { Logger } from 'pino';
+import { extractErrorMessage } from '@/lib/errors';
+import type { ErrorGuidance } from '@/types';
+
+export interface CompletedStage {
+  /** SHA-256 of what the stage consumed (see digestArgs) */
+  argsDigest: string;
+  /** Stages whose outputs this stage consumed */
+  dependsOn?: string[];
+  output: unknown;
+  /** ISO 8601 completion time */
+  completedAt: string;
+}
+
+export interface WorkflowState {
+  id: string;
+  /** Digest of the workflow input; stages recorded for other input are not reused */
+  inputDigest?: string;
+  /** ISO 8601 creation time */
+  createdAt: string;
+  /** ISO 8601 time of the last recorded stage */
+  updatedAt: string;
+  stages: Record<string, CompletedStage>;
+}
+
+/**
+ * Disk used by saved workflow state
+ */
+export interface WorkflowStateUsage {
+  workflows: number;
+  totalBytes: number;
+  /** Largest state a workflow may save (unset = unlimited) */
+  maxBytesPerWorkflow?: number;
+  /** Size the store evicts idle workflows to stay under (unset = unlimited) */
+  maxTotalBytes?: number;
+}
+
+/**
+ * What a saved workflow has done, without its stage outputs
+ */
+export interface WorkflowSummary {
+  id: string;
+  /** ISO 8601 creation time */
+  createdAt: string;
+  /** ISO 8601 time of the last recorded stage */
+  updatedAt: string;
+  completedStages: number;
+  lastCompletedStage?: PipelineStage;
+  nextStage?: PipelineStage;
+  complete: boolean;
+}
+
+/**
+ * Which saved workflows to list, and in what order
+ */
+export interface WorkflowQuery {
+  /** Only complete workflows, or only ones with stages left to run */
+  status?: 'complete' | 'in-progress';
+  /** Only workflows created at least this long ago */
+  minAgeMs?: number;
+  /** Only workflows created at most this long ago */
+  maxAgeMs?: number;
+  /** Field to sort by (default: updatedAt) */
+  sortBy?: 'createdAt' | 'updatedAt';
+  /** Sort direction (default: desc, newest first) */
+  order?: 'asc' | 'desc';
+  /** Page size (default: 20) */
+  limit?: number;
+  /** nextCursor of the previous page */
+  cursor?: string;
+}
+
+export interface WorkflowPage {
+  workflows: WorkflowSummary[];
+  /** Pass as cursor to get the next page; unset on the last page */
+  nextCursor?: string;
+}
+
+/**
+ * Which saved workflows to clean up
+ */
+export interface WorkflowCleanupOptions {
+  /** Only these workflows; unset applies the store's idle age and size limits */
+  ids?: string[];
+  /** Report what would be removed without removing anything */
+  dryRun?: boolean;
+}
+
+export interface RemovedWorkflow {
+  id: string;
+  bytes: number;
+  /** Time since the workflow was last used */
+  idleMs: number;
+  /** Asked for by id, idle past the maximum age, or evicted for the total size limit */
+  reason: 'requested' | 'idle' | 'size';
+}
+
+export interface WorkflowCleanupReport {
+  removed: RemovedWorkflow[];
+  /** Requested workflows left in place */
+  skipped: Array<{ id: string; reason: 'active' | 'not-found' }>;
+  reclaimedBytes: number;
+  dryRun: boolean;
+}
+
+/**
+ * Where workflow state is kept, e.g. a directory or a database
+ */
+export interface WorkflowStateStore {
+  load(id: string): Promise<WorkflowState | undefined>;
+  save(state: WorkflowState): Promise<void>;
+  delete(id: string): Promise<void>;
+  /** Report the space used by saved state, when the store can measure it */
+  usage?(): Promise<WorkflowStateUsage>;
+  /** List saved workflows one page at a time, when the store can enumerate them */
+  list?(query?: WorkflowQuery): Promise<WorkflowPage>;
+  /** Remove idle workflows, or the given ones, when the store tracks their use */
+  cleanup?(options?: WorkflowCleanupOptions): Promise<WorkflowCleanupReport>;
+}
+
+/** Error code of saves rejected for exceeding the per-workflow size limit */
+export const DISK_QUOTA_EXCEEDED = 'DISK_QUOTA_EXCEEDED';
+
+/**
+ * Thrown by save when a workflow's state is larger than its quota; the state
+ * saved before is left in place
+ */
+export class DiskQuotaExceededError extends Error {
+  readonly code = DISK_QUOTA_EXCEEDED;
+
+  constructor(
+    message: string,
+    readonly guidance: ErrorGuidance,
+  ) {
+    super(message);
+    this.name = 'DiskQuotaExceededError';
+  }
+}
+
+/** Workflows used this recently are never evicted or cleaned up */
+export const ACTIVE_WORKFLOW_WINDOW_MS = 15 * 60 * 1000;
+
+/** Workflow id used when the caller has no session */
+export const DEFAULT_WORKFLOW_ID = 'default';
+
+/**
+ * Containerization pipeline stages in the order they run. Tools run outside
+ * this list (e.g. tag-image) are not recorded as stages.
+ */
+export const PIPELINE_STAGES = [
+  'analyze-repo',
+  'generate-dockerfile',
+  'build-image',
+  'scan-image',
+  'push-image',
+  'generate-k8s-manifests',
+  'prepare-cluster',
+  'verify-deploy',
+] as const;
+
+export type PipelineStage = (typeof PIPELINE_STAGES)[number];
+
+export function isPipelineStage(name: string): name is PipelineStage {
+  return (PIPELINE_STAGES as readonly string[]).includes(name);
+}
+
+const WORKFLOW_ID = /^[\w.-]{1,128}$/;
+
+/**
+ * Empty state for a workflow that has not completed any stage yet
+ */
+export function createWorkflowState(id: string, inputDigest?: string): WorkflowState {
+  const now = new Date().toISOString();
+  return { id, ...(inputDigest && { inputDigest }), createdAt: now, updatedAt: now, stages: {} };
+}
+
+/**
+ * Record a completed pipeline stage
+ *
+ * Running a stage again means everything after it was built from outputs
+ * that no longer apply, so later stages are dropped from the state.
+ */
+export function recordPipelineStage(
+  state: WorkflowState,
+  stage: PipelineStage,
+  completed: CompletedStage,
+): WorkflowState {
+  const position = PIPELINE_STAGES.indexOf(stage);
+  const stages = Object.fromEntries(
+    Object.entries(state.stages).filter(
+      ([name]) => !isPipelineStage(name) || PIPELINE_STAGES.indexOf(name) < position,
+    ),
+  );
+  stages[stage] = completed;
+  return { ...state, updatedAt: completed.completedAt, stages };
+}
+
+/**
+ * Completed pipeline stages in pipeline order, and the stage to run next
+ */
+export function pipelineProgress(state: WorkflowState | undefined): {
+  completed: PipelineStage[];
+  next?: PipelineStage;
+} {
+  const completed = PIPELINE_STAGES.filter((stage) => state?.stages[stage] !== undefined);
+  const last = completed[completed.length - 1];
+  const next = PIPELINE_STAGES[last ? PIPELINE_STAGES.indexOf(last) + 1 : 0];
+  return { completed, ...(next && { next }) };
+}
+
+/**
+ * Summarize a workflow's progress for listing
+ */
+export function summarizeWorkflow(state: WorkflowState): WorkflowSummary {
+  const { completed, next } = pipelineProgress(state);
+  const last = completed[completed.length - 1];
+  return {
+    id: state.id,
+    createdAt: state.createdAt,
+    updatedAt: state.updatedAt,
+    completedStages: completed.length,
+    ...(last && { lastCompletedStage: last }),
+    ...(next && { nextStage: next }),
+    complete: next === undefined,
+  };
+}
+
+/** Page size used when a query sets no limit */
+export const DEFAULT_WORKFLOW_PAGE_SIZE = 20;
+
+type SortKey = [value: string, id: string];
+
+function compareStrings(a: string, b: string): number {
+  return a < b ? -1 : a > b ? 1 : 0;
+}
+
+function encodeCursor(key: SortKey): string {
+  return Buffer.from(JSON.stringify(key)).toString('base64url');
+}
+
+function decodeCursor(cursor: string): SortKey {
+  try {
+    const key: unknown = JSON.parse(Buffer.from(cursor, 'base64url').toString('utf-8'));
+    if (Array.isArray(key) && key.length === 2 && key.every((part) => typeof part === 'string')) {
+      return key as SortKey;
+    }
+  } catch {
+    // Reported below
+  }
+  throw new Error(`Invalid workflow list cursor: ${cursor}`);
+}
+
+/**
+ * Filter, sort and page workflow summaries
+ *
+ * The cursor holds the sort value and id of the last workflow returned, so
+ * each workflow appears on exactly one page even when workflows are saved or
+ * deleted between requests (a workflow whose sort value changes may move to
+ * a page not yet read, or to one already read).
+ */
+export function queryWorkflows(
+  summaries: Iterable<WorkflowSummary>,
+  query: WorkflowQuery = {},
+  now: number = Date.now(),
+): WorkflowPage {
+  const {
+    status,
+    minAgeMs,
+    maxAgeMs,
+    sortBy = 'updatedAt',
+    order = 'desc',
+    limit = DEFAULT_WORKFLOW_PAGE_SIZE,
+    cursor,
+  } = query;
+  const after = cursor === undefined ? undefined : decodeCursor(cursor);
+  const direction = order === 'asc' ? 1 : -1;
+  const keyOf = (summary: WorkflowSummary): SortKey => [summary[sortBy], summary.id];
+  const compare = (a: SortKey, b: SortKey): number =>
+    direction * (compareStrings(a[0], b[0]) || compareStrings(a[1], b[1]));
+
+  const matches: WorkflowSummary[] = [];
+  for (const summary of summaries) {
+    if (status === 'complete' && !summary.complete) continue;
+    if (status === 'in-progress' && summary.complete) continue;
+    const age = now - Date.parse(summary.createdAt);
+    if (minAgeMs !== undefined && age < minAgeMs) continue;
+    if (maxAgeMs !== undefined && age > maxAgeMs) continue;
+    if (after && compare(keyOf(summary), after) <= 0) continue;
+    matches.push(summary);
+  }
+  matches.sort((a, b) => compare(keyOf(a), keyOf(b)));
+
+  const workflows = matches.slice(0, Math.max(1, limit));
+  const last = workflows[workflows.length - 1];
+  return {
+    workflows,
+    ...(last && matches.length > workflows.length && { nextCursor: encodeCursor(keyOf(last)) }),
+  };
+}
+
+export interface PipelineRecorder {
+  /** Record a completed stage; never throws, failures are logged */
+  record(workflowId: string, stage: PipelineStage, completed: CompletedStage): Promise<void>;
+}
+
+/**
+ * Record pipeline stages into a store, one update at a time per workflow so
+ * concurrent tool calls in a session do not overwrite each other's stages
+ */
+export function createPipelineRecorder(
+  store: WorkflowStateStore,
+  logger?: Logger,
+): PipelineRecorder {
+  const updates = new Map<string, Promise<void>>();
+
+  const update = async (
+    id: string,
+    stage: PipelineStage,
+    completed: CompletedStage,
+  ): Promise<void> => {
+    try {
+      const state = (await store.load(id)) ?? createWorkflowState(id);
+      await store.save(recordPipelineStage(state, stage, completed));
+    } catch (error) {
+      logger?.warn(
+        {
+          workflowId: id,
+          stage,
+          error: extractErrorMessage(error),
+          ...(error instanceof DiskQuotaExceededError && { code: error.code }),
+        },
+        'Failed to record workflow stage',
+      );
+    }
+  };
+
+  return {
+    record(workflowId, stage, completed) {
+      const previous = updates.get(workflowId) ?? Promise.resolve();
+      const next = previous.then(() => update(workflowId, stage, completed));
+      updates.set(workflowId, next);
+      // Forget finished chains so idle workflows do not accumulate
+      void next.then(() => {
+        if (updates.get(workflowId) === next) updates.delete(workflowId);
+      });
+      return next;
+    },
+  };
+}
+
+/**
+ * Store that keeps state in memory; lost on restart, for tests and embedding
+ */
+export function createMemoryWorkflowStateStore(): WorkflowStateStore {
+  const states = new Map<string, string>();
+
+  return {
+    async load(id) {
+      const json = states.get(id);
+      return json === undefined ? undefined : (JSON.parse(json) as WorkflowState);
+    },
+
+    async save(state) {
+      // Stored as JSON so callers see the same copies a file store would return
+      states.set(state.id, JSON.stringify(state));
+    },
+
+    async delete(id) {
+      states.delete(id);
+    },
+
+    async usage() {
+      let totalBytes = 0;
+      for (const json of states.values()) totalBytes += Buffer.byteLength(json);
+      return { workflows: states.size, totalBytes };
+    },
+
+    async list(query) {
+      const summaries = [...states.values()].map((json) =>
+        summarizeWorkflow(JSON.parse(json) as WorkflowState),
+      );
+      return queryWorkflows(summaries, query);
+    },
+  };
+}
+
+export interface FileWorkflowStateStoreOptions {
+  /** Largest state file a workflow may write; larger saves are rejected */
+  maxBytesPerWorkflow?: number;
+  /** Total size of the state files; idle workflows are evicted to stay under it */
+  maxTotalBytes?: number;
+  /** Workflows unused for longer than this are removed (unset = kept) */
+  maxIdleMs?: number;
+  /** Workflows loaded or saved within this window are not evicted or removed */
+  activeWindowMs?: number;
+  /** Receives a line for each removed workflow */
+  logger?: Logger;
+}
+
+interface CleanupPlan {
+  chosen: Array<{ id: string; entry: StoredWorkflow; reason: RemovedWorkflow['reason'] }>;
+  skipped: WorkflowCleanupReport['skipped'];
+}
+
+interface StoredWorkflow {
+  bytes: number;
+  /** Last modification time of the file, in epoch milliseconds */
+  modifiedAt: number;
+  /** Filled in when the workflow is saved or first listed */
+  summary?: WorkflowSummary;
+}
+
+/**
+ * Store that writes one JSON file per workflow to a directory
+ *
+ * Files are replaced atomically (write, then rename), so a crash while saving
+ * leaves the previous state intact. Ids are restricted to letters, digits,
+ * `_`, `.` and `-` so they cannot name files outside the directory.
+ *
+ * With `maxTotalBytes`, a save that takes the directory over the limit evicts
+ * other workflows, least recently used first. A workflow counts as used when
+ * this store loads or saves it, or otherwise when its file was last written;
+ * workflows used within `activeWindowMs` are kept even if that leaves the
+ * directory over the limit. Sizes are indexed on first use, so files written
+ * by other processes afterwards are not counted until the next restart.
+ *
+ * With `maxIdleMs`, each save also removes other workflows unused for longer
+ * than that (but never within `activeWindowMs`). `cleanup` applies the same
+ * limits on demand, or removes specific workflows unless they are active.
+ *
+ * Listing keeps only summaries in memory: each file is read once, the first
+ * time it is listed, and summaries are updated as workflows are saved.
+ */
+export function createFileWorkflowStateStore(
+  dirPath: string,
+  options: FileWorkflowStateStoreOptions = {},
+): WorkflowStateStore {
+  const { activeWindowMs = ACTIVE_WORKFLOW_WINDOW_MS, logger } = options;
+  // Zero or negative limits (e.g. an unset environment variable) mean unlimited
+  const limit = (bytes?: number): number | undefined =>
+    bytes !== undefined && bytes > 0 ? bytes : undefined;
+  const maxBytesPerWorkflow = limit(options.maxBytesPerWorkflow);
+  const maxTotalBytes = limit(options.maxTotalBytes);
+  const maxIdleMs = limit(options.maxIdleMs);
+  let ready: Promise<unknown> | undefined;
+  let writes = 0;
+  let index: Promise<Map<string, StoredWorkflow>> | undefined;
+  const usedAt = new Map<string, number>();
+
+  const isValidId = (id: string): boolean => WORKFLOW_ID.test(id) && id !== '.' && id !== '..';
+
+  const fileFor = (id: string): string => {
+    if (!isValidId(id)) {
+      throw new Error(`Invalid workflow id: ${id}`);
+    }
+    return join(dirPath, `${id}.json`);
+  };
+
+  const scan = async (): Promise<Map<string, StoredWorkflow>> => {
+    const stored = new Map<string, StoredWorkflow>();
+    let names: string[];
+    try {
+      names = await readdir(dirPath);
+    } catch (error) {
+      if ((error as NodeJS.ErrnoException).code === 'ENOENT') return stored;
+      throw error;
+    }
+    for (const name of names) {
+      const id = name.endsWith('.json') ? name.slice(0, -'.json'.length) : '';
+      if (!isValidId(id)) continue;
+      try {
+        const info = await stat(join(dirPath, name));
+        stored.set(id, { bytes: info.size, modifiedAt: info.mtimeMs });
+      } catch {
+        // Deleted while scanning
+      }
+    }
+    return stored;
+  };
+
+  const indexed = (): Promise<Map<string, StoredWorkflow>> => {
+    index ??= scan().catch((error: unknown) => {
+      index = undefined;
+      throw error;
+    });
+    return index;
+  };
+
+  const lastUsed = (id: string, entry: StoredWorkflow): number =>
+    usedAt.get(id) ?? entry.modifiedAt;
+
+  const totalOf = (stored: Map<string, StoredWorkflow>): number => {
+    let total = 0;
+    for (const entry of stored.values()) total += entry.bytes;
+    return total;
+  };
+
+  /**
+   * Choose what to remove: the requested workflows, or otherwise those idle
+   * past maxIdleMs and then the least recently used until under
+   * maxTotalBytes. Workflows used within activeWindowMs are never chosen.
+   */
+  const plan = (
+    stored: Map<string, StoredWorkflow>,
+    now: number,
+    ids?: string[],
+    keepId?: string,
+  ): CleanupPlan => {
+    const isActive = (id: string, entry: StoredWorkflow): boolean =>
+      id === keepId || now - lastUsed(id, entry) < activeWindowMs;
+    const chosen: CleanupPlan['chosen'] = [];
+    const skipped: CleanupPlan['skipped'] = [];
+
+    if (ids) {
+      for (const id of new Set(ids)) {
+        fileFor(id); // Rejects ids that are not plain file names
+        const entry = stored.get(id);
+        if (!entry) skipped.push({ id, reason: 'not-found' });
+        else if (isActive(id, entry)) skipped.push({ id, reason: 'active' });
+        else chosen.push({ id, entry, reason: 'requested' });
+      }
+      return { chosen, skipped };
+    }
+
+    let total = totalOf(stored);
+    const idle = [...stored]
+      .filter(([id, entry]) => !isActive(id, entry))
+      .sort(([a, x], [b, y]) => lastUsed(a, x) - lastUsed(b, y));
+    for (const [id, entry] of idle) {
+      if (maxIdleMs !== undefined && now - lastUsed(id, entry) >= maxIdleMs) {
+        chosen.push({ id, entry, reason: 'idle' });
+      } else if (maxTotalBytes !== undefined && total > maxTotalBytes) {
+        chosen.push({ id, entry, reason: 'size' });
+      } else {
+        continue;
+      }
+      total -= entry.bytes;
+    }
+    return { chosen, skipped };
+  };
+
+  const remove = async (
+    stored: Map<string, StoredWorkflow>,
+    chosen: CleanupPlan['chosen'],
+    now: number,
+  ): Promise<RemovedWorkflow[]> => {
+    let total = totalOf(stored);
+    const removed: RemovedWorkflow[] = [];
+    for (const { id, entry, reason } of chosen) {
+      const idleMs = now - lastUsed(id, entry);
+      await rm(fileFor(id), { force: true });
+      stored.delete(id);
+      usedAt.delete(id);
+      total -= entry.bytes;
+      removed.push({ id, bytes: entry.bytes, idleMs, reason });
+      logger?.info(
+        {
+          workflowId: id,
+          reason,
+          idleMs,
+          reclaimedBytes: entry.bytes,
+          totalBytes: total,
+          ...(maxTotalBytes !== undefined && { maxTotalBytes }),
+        },
+        reason === 'size' ? 'Evicted least recently used workflow state' : 'Removed workflow state',
+      );
+    }
+    if (maxTotalBytes !== undefined && total > maxTotalBytes) {
+      logger?.warn(
+        { totalBytes: total, maxTotalBytes },
+        'Workflow state is over its total size limit; remaining workflows are active',
+      );
+    }
+    return removed;
+  };
+
+  return {
+    async load(id) {
+      const file = fileFor(id);
+      try {
+        const state = JSON.parse(await readFile(file, 'utf-8')) as WorkflowState;
+        usedAt.set(id, Date.now());
+        return state;
+      } catch (error) {
+        if ((error as NodeJS.ErrnoException).code === 'ENOENT') return undefined;
+        throw error;
+      }
+    },
+
+    async save(state) {
+      const file = fileFor(state.id);
+      const json = JSON.stringify(state);
+      const bytes = Buffer.byteLength(json);
+      if (maxBytesPerWorkflow !== undefined && bytes > maxBytesPerWorkflow) {
+        throw new DiskQuotaExceededError(
+          `Workflow ${state.id} state is ${bytes} bytes, over its ${maxBytesPerWorkflow} byte quota`,
+          {
+            message: 'Workflow state exceeds its disk quota',
+            hint: 'Stage outputs saved for this workflow are larger than the per-workflow limit',
+            resolution:
+              'Restart the workflow with resume-workflow restart, or raise CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_BYTES',
+            details: {
+              code: DISK_QUOTA_EXCEEDED,
+              workflowId: state.id,
+              bytes,
+              maxBytesPerWorkflow,
+            },
+          },
+        );
+      }
+
+      ready ??= mkdir(dirPath, { recursive: true });
+      await ready;
+      const stored =
+        maxTotalBytes !== undefined || maxIdleMs !== undefined ? await indexed() : undefined;
+      const temp = `${file}.${process.pid}.${++writes}.tmp`;
+      await writeFile(temp, json);
+      await rename(temp, file);
+
+      const now = Date.now();
+      usedAt.set(state.id, now);
+      // Keep an index built by usage() current even without a total limit
+      const current = stored ?? (index && (await index));
+      current?.set(state.id, { bytes, modifiedAt: now, summary: summarizeWorkflow(state) });
+      if (stored) await remove(stored, plan(stored, now, undefined, state.id).chosen, now);
+    },
+
+    async delete(id) {
+      await rm(fileFor(id), { force: true });
+      usedAt.delete(id);
+      if (index) (await index).delete(id);
+    },
+
+    async usage() {
+      const stored = await indexed();
+      let totalBytes = 0;
+      for (const entry of stored.values()) totalBytes += entry.bytes;
+      return {
+        workflows: stored.size,
+        totalBytes,
+        ...(maxBytesPerWorkflow !== undefined && { maxBytesPerWorkflow }),
+        ...(maxTotalBytes !== undefined && { maxTotalBytes }),
+      };
+    },
+
+    async list(query) {
+      const stored = await indexed();
+      const summaries: WorkflowSummary[] = [];
+      for (const [id, entry] of stored) {
+        if (!entry.summary) {
+          try {
+            const state = JSON.parse(await readFile(fileFor(id), 'utf-8')) as WorkflowState;
+            entry.summary = summarizeWorkflow(state);
+          } catch {
+            // Deleted or unreadable; resume-workflow reports unreadable state
+            continue;
+          }
+        }
+        summaries.push(entry.summary);
+      }
+      return queryWorkflows(summaries, query);
+    },
+
+    async cleanup(options = {}) {
+      const { ids, dryRun = false } = options;
+      const stored = await indexed();
+      const now = Date.now();
+      const { chosen, skipped } = plan(stored, now, ids);
+      const removed = dryRun
+        ? chosen.map(({ id, entry, reason }) => ({
+            id,
+            bytes: entry.bytes,
+            idleMs: now - lastUsed(id, entry),
+            reason,
+          }))
+        : await remove(stored, chosen, now);
+      return {
+        removed,
+        skipped,
+        reclaimedBytes: removed.reduce((sum, workflow) => sum + workflow.bytes, 0),
+        dryRun,
+      };
+    },
+  };
+}
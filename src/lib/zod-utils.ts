@@ -28,3 +28,135 @@ export function extractSchemaShape(schema: z.ZodTypeAny): ZodRawShape {
   // For ZodAny or other types without shape, return empty object
   return {};
 }
+
+/**
+ * Zod types that JSON tool arguments can never produce
+ */
+const NON_JSON_TYPES = new Set([
+  'ZodDate',
+  'ZodBigInt',
+  'ZodSymbol',
+  'ZodUndefined',
+  'ZodVoid',
+  'ZodFunction',
+  'ZodMap',
+  'ZodSet',
+  'ZodPromise',
+  'ZodNaN',
+]);
+
+/**
+ * Strip wrappers that don't change the JSON shape of a field
+ */
+function unwrapSchema(schema: z.ZodTypeAny): z.ZodTypeAny {
+  switch (schema._def?.typeName) {
+    case 'ZodOptional':
+    case 'ZodNullable':
+    case 'ZodDefault':
+      return unwrapSchema(schema._def.innerType);
+    case 'ZodEffects':
+      return unwrapSchema(schema._def.schema);
+    default:
+      return schema;
+  }
+}
+
+/**
+ * Find input fields that produce a poor MCP schema: fields without a
+ * description, and fields whose type cannot be sent as JSON. Fields of
+ * nested objects and arrays of objects are checked too.
+ *
+ * @returns One message per problem, prefixed with the field path
+ */
+export function findSchemaIssues(schema: z.ZodTypeAny): string[] {
+  const issues: string[] = [];
+
+  const visit = (shape: ZodRawShape, prefix: string): void => {
+    for (const [key, field] of Object.entries(shape)) {
+      const path = `${prefix}${key}`;
+      if (!field.description) {
+        issues.push(`${path}: missing description`);
+      }
+
+      let inner = unwrapSchema(field);
+      let itemPath = path;
+      while (inner._def?.typeName === 'ZodArray') {
+        inner = unwrapSchema(inner._def.type);
+        itemPath += '[]';
+      }
+
+      const typeName: string = inner._def?.typeName ?? '';
+      if (NON_JSON_TYPES.has(typeName)) {
+        issues.push(`${path}: ${typeName.slice(3).toLowerCase()} values cannot be passed as JSON`);
+      } else if (typeName === 'ZodObject') {
+        visit(extractSchemaShape(inner), `${itemPath}.`);
+      }
+    }
+  };
+
+  visit(extractSchemaShape(schema), '');
+  return issues;
+}
+
+/**
+ * Error code for tool arguments that do not match the tool's input schema
+ */
+export const VALIDATION_FAILED = 'VALIDATION_FAILED';
+
+/**
+ * One problem with a tool argument
+ */
+export interface ArgumentIssue {
+  /** Dotted path to the argument, or '(root)' for the arguments object itself */
+  path: string;
+  message: string;
+}
+
+const quote = (value: unknown): string =>
+  typeof value === 'string' ? `'${value}'` : String(value);
+
+const expectedOneOf = (options: unknown[], received: unknown): string =>
+  `expected one of ${options.map(quote).join(', ')}, received ${quote(received)}`;
+
+/**
+ * Describe a Zod issue in terms of the argument a caller sent
+ */
+function describeIssue(issue: z.ZodIssue): string {
+  switch (issue.code) {
+    case 'invalid_type':
+      return issue.received === 'undefined'
+        ? 'required'
+        : `expected ${issue.expected}, received ${issue.received}`;
+    case 'invalid_enum_value':
+      return expectedOneOf(issue.options, issue.received);
+    case 'invalid_union': {
+      // A union of enums (e.g. upper- and lowercase severities) reads as one enum
+      const nested = issue.unionErrors.flatMap((error) => error.issues);
+      const enums = nested.filter(
+        (i): i is z.ZodInvalidEnumValueIssue => i.code === 'invalid_enum_value',
+      );
+      const first = enums[0];
+      if (first && enums.length === nested.length) {
+        return expectedOneOf([...new Set(enums.flatMap((i) => i.options))], first.received);
+      }
+      return issue.message;
+    }
+    case 'invalid_string':
+      return issue.validation === 'regex' ? 'does not match the expected format' : issue.message;
+    case 'unrecognized_keys':
+      return `unknown ${issue.keys.length === 1 ? 'argument' : 'arguments'} ${issue.keys.join(', ')}`;
+    default:
+      return issue.message;
+  }
+}
+
+/**
+ * List every problem Zod found with a set of tool arguments, one per
+ * argument path, in a form that can be shown to the caller
+ */
+export function formatArgumentIssues(error: z.ZodError): ArgumentIssue[] {
+  return error.issues.map((issue) => ({
+    path: issue.path.length > 0 ? issue.path.join('.') : '(root)',
+    message: describeIssue(issue),
+  }));
+}
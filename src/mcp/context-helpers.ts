@@ -105,6 +105,79 @@ export function createProgressReporter(
   };
 }
 
+/**
+ * Progress of a multi-step operation
+ */
+export interface StepProgress {
+  /** Number of steps completed so far, including this one */
+  index: number;
+  /** Total number of steps */
+  total: number;
+  /** Name of the step that just completed */
+  name: string;
+  /** Time since the operation started (ms) */
+  elapsedMs: number;
+}
+
+/**
+ * Estimate the time left by extrapolating the average step duration so far
+ *
+ * @returns Remaining milliseconds, or undefined before any step has completed
+ */
+export function estimateRemainingMs(step: StepProgress): number | undefined {
+  if (step.index <= 0 || step.total <= 0) {
+    return undefined;
+  }
+
+  const remainingSteps = Math.max(0, step.total - step.index);
+  return Math.round((step.elapsedMs / step.index) * remainingSteps);
+}
+
+/**
+ * Report a completed step as progress `index` of `total`
+ *
+ * The step name, elapsed time and ETA travel as notification metadata so
+ * clients can render an estimate next to the progress bar.
+ */
+export async function reportStep(
+  progress: EnhancedProgressReporter | undefined,
+  step: StepProgress,
+): Promise<void> {
+  if (!progress) {
+    return;
+  }
+
+  const etaMs = estimateRemainingMs(step);
+  await progress(step.name, step.index, step.total, {
+    step: step.name,
+    elapsedMs: step.elapsedMs,
+    ...(etaMs !== undefined && { etaMs }),
+  });
+}
+
+/**
+ * Create a step reporter that numbers steps and measures elapsed time itself
+ *
+ * @example
+ * ```typescript
+ * const completeStep = createStepReporter(context.progress, 3);
+ * await completeStep('Analyzed repository');
+ * ```
+ */
+export function createStepReporter(
+  progress: EnhancedProgressReporter | undefined,
+  total: number,
+  now: () => number = Date.now,
+): (name: string) => Promise<void> {
+  const startedAt = now();
+  let index = 0;
+
+  return (name: string) => {
+    index += 1;
+    return reportStep(progress, { index, total, name, elapsedMs: now() - startedAt });
+  };
+}
+
 /**
  * Sends a progress notification through the MCP server using the proper MCP protocol.
  * Uses sendNotification callback if available (from request handler), otherwise falls back to logging.
@@ -9,6 +9,8 @@
 import type { Logger } from 'pino';
 import { extractProgressReporter } from './context-helpers.js';
 import type { RegoEvaluator } from '@/config/policy-rego';
+import type { WorkflowStateStore } from '@/lib/workflow-state';
+import type { ImageSigner } from '@/infra/security/cosign';
 
 // ===== TYPES =====
 
@@ -23,6 +25,8 @@ export type ProgressReporter = (
   progress?: number,
   /** Total progress value */
   total?: number,
+  /** Extra fields forwarded with the notification (e.g. step name and ETA) */
+  metadata?: Record<string, unknown>,
 ) => Promise<void>;
 
 /**
@@ -52,13 +56,39 @@ export interface ToolContext {
    * Tools can use this to validate generated content against organizational policies
    */
   policy?: RegoEvaluator;
+
+  /**
+   * Optional workflow state of the caller's session
+   * Set when the app persists pipeline stages (see resume-workflow)
+   */
+  workflow?: WorkflowBinding;
+
+  /**
+   * Optional image signer used by push-image
+   * Defaults to the cosign CLI; tests inject a fake
+   */
+  signer?: ImageSigner;
+}
+
+/**
+ * Workflow state store and the id of the caller's workflow
+ */
+export interface WorkflowBinding {
+  store: WorkflowStateStore;
+  id: string;
 }
 
 // ===== PROGRESS HANDLING =====
 
 // Re-export types and utilities from helpers
-export type { EnhancedProgressReporter } from './context-helpers.js';
-export { extractProgressToken, createProgressReporter } from './context-helpers.js';
+export type { EnhancedProgressReporter, StepProgress } from './context-helpers.js';
+export {
+  extractProgressToken,
+  createProgressReporter,
+  estimateRemainingMs,
+  reportStep,
+  createStepReporter,
+} from './context-helpers.js';
 
 // ===== CONTEXT CREATION =====
 
@@ -74,6 +104,8 @@ export interface ContextOptions {
   sendNotification?: (notification: unknown) => Promise<void>;
   /** Optional Rego policy evaluator to pass to tools */
   policy?: RegoEvaluator;
+  /** Optional workflow state binding to pass to tools */
+  workflow?: WorkflowBinding;
 }
 
 /**
@@ -103,5 +135,6 @@ export function createToolContext(logger: Logger, options: ContextOptions = {}):
     signal: options.signal,
     progress: progressReporter,
     ...(options.policy && { policy: options.policy }),
+    ...(options.workflow && { workflow: options.workflow }),
   };
 }
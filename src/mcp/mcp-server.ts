@@ -5,21 +5,23 @@
  * @see {@link ../../docs/adr/005-mcp-integration.md ADR-005: MCP Protocol Integration}
  */
 
-import { McpServer } from '@modelcontextprotocol/sdk/server/mcp.js';
+import { McpServer, ResourceTemplate } from '@modelcontextprotocol/sdk/server/mcp.js';
 import type { Server } from '@modelcontextprotocol/sdk/server/index.js';
 import { StdioServerTransport } from '@modelcontextprotocol/sdk/server/stdio.js';
 import {
   McpError,
   ErrorCode,
+  type ReadResourceResult,
   type ServerRequest,
   type ServerNotification,
 } from '@modelcontextprotocol/sdk/types.js';
 import type { RequestHandlerExtra } from '@modelcontextprotocol/sdk/shared/protocol.js';
+import type { AuditLog } from '@/lib/audit';
 import { extractErrorMessage } from '@/lib/errors';
 import { createLogger, type Logger } from '@/lib/logger';
 import type { Tool } from '@/types/tool';
 import type { ExecuteRequest, ExecuteMetadata } from '@/app/orchestrator-types';
-import type { Result, ErrorGuidance } from '@/types';
+import { getPartialResult, type Result, type ErrorGuidance } from '@/types';
 import type { ScanImageResult } from '@/tools/scan-image/tool';
 import type { DockerfilePlan } from '@/tools/generate-dockerfile/schema';
 import type { BuildImageResult } from '@/tools/build-image/tool';
@@ -51,6 +53,7 @@ import {
  */
 const RESOURCE_URI = {
   STATUS: 'containerization://status',
+  TRACE: 'containerization://trace/{sessionId}{?since,limit}',
 } as const;
 
 const ERROR_FORMAT = {
@@ -84,6 +87,8 @@ export interface ServerOptions {
   name?: string;
   version?: string;
   outputFormat?: OutputFormat;
+  /** Audit log serving the trace resource; without one the resource is not registered */
+  audit?: AuditLog;
 }
 
 /**
@@ -208,6 +213,20 @@ export function createMCPServer<TTool extends Tool>(
     }),
   );
 
+  if (options.audit) {
+    server.resource(
+      'trace',
+      new ResourceTemplate(RESOURCE_URI.TRACE, { list: undefined }),
+      {
+        title: 'Session Trace',
+        description:
+          'Tool executions of a session in order, with timestamps, durations, argument digests and outcomes',
+        mimeType: 'application/json',
+      },
+      createTraceResourceHandler(options.audit),
+    );
+  }
+
   return {
     async start(): Promise<void> {
       if (isRunning) {
@@ -255,6 +274,60 @@ export function createMCPServer<TTool extends Tool>(
   };
 }
 
+/**
+ * Read handler for the trace resource, e.g.
+ * `containerization://trace/<session>?since=2025-01-01T00:00:00Z&limit=50`
+ */
+export function createTraceResourceHandler(
+  audit: AuditLog,
+): (uri: URL, variables: Record<string, string | string[]>) => Promise<ReadResourceResult> {
+  const first = (value: string | string[] | undefined): string | undefined =>
+    Array.isArray(value) ? value[0] : value;
+  const decode = (value: string): string => {
+    try {
+      return decodeURIComponent(value);
+    } catch {
+      return value;
+    }
+  };
+
+  return async (uri, variables) => {
+    const sessionId = first(variables.sessionId);
+    const since = first(variables.since);
+    const limitText = first(variables.limit);
+    const limit = limitText ? Number(limitText) : undefined;
+
+    if (!sessionId) {
+      throw new McpError(ErrorCode.InvalidParams, 'Trace resource requires a session id');
+    }
+    if (limit !== undefined && (!Number.isInteger(limit) || limit < 1)) {
+      throw new McpError(ErrorCode.InvalidParams, `Invalid trace limit: ${limitText}`);
+    }
+
+    const result = await audit.trace({
+      sessionId: decode(sessionId),
+      ...(since && { since: decode(since) }),
+      ...(limit !== undefined && { limit }),
+    });
+    if (!result.ok) {
+      throw new McpError(
+        ErrorCode.InvalidRequest,
+        formatErrorWithGuidance(result.error, result.guidance),
+      );
+    }
+
+    return {
+      contents: [
+        {
+          uri: uri.href,
+          mimeType: 'application/json',
+          text: JSON.stringify(result.value, null, 2),
+        },
+      ],
+    };
+  };
+}
+
 /**
  * Register tools against an MCP server instance, delegating to the orchestrator executor.
  * Each tool is registered with its name, description, and input schema. Tool execution is
@@ -290,7 +363,13 @@ export function registerToolsWithServer<TTool extends Tool>(options: RegisterOpt
           if (!result.ok) {
             // Format error with guidance if available
             const errorMessage = formatErrorWithGuidance(result.error, result.guidance);
-            throw new McpError(ErrorCode.InternalError, errorMessage);
+            // Hand work finished before a cancellation back to the client
+            const partialResult = getPartialResult(result);
+            throw new McpError(
+              ErrorCode.InternalError,
+              errorMessage,
+              partialResult !== undefined ? { partialResult } : undefined,
+            );
           }
 
           return {
@@ -370,7 +449,12 @@ function createExecuteMetadata(
 
   return {
     progress: params,
-    loggerContext: createLoggerContext(toolName, transport, meta),
+    loggerContext: {
+      // JSON-RPC request id unless the client supplied its own in _meta
+      ...(extra.requestId !== undefined && { requestId: String(extra.requestId) }),
+      ...createLoggerContext(toolName, transport, meta),
+      ...(extra.sessionId && { sessionId: extra.sessionId }),
+    },
     ...(extra.sendNotification && {
       sendNotification: createNotificationAdapter(extra.sendNotification),
     }),
@@ -0,0 +1,75 @@
+/**
+ * Tool Schema Export
+ *
+ * Dumps the JSON Schema of every tool input exactly as MCP clients see it in
+ * tools/list. Tools are bound to a throwaway MCP server and listed over an
+ * in-memory transport, so descriptions and required fields come from the same
+ * zod conversion the SDK applies when serving them.
+ */
+
+import { McpServer } from '@modelcontextprotocol/sdk/server/mcp.js';
+import { Client } from '@modelcontextprotocol/sdk/client/index.js';
+import { InMemoryTransport } from '@modelcontextprotocol/sdk/inMemory.js';
+import { extractErrorMessage } from '@/lib/errors';
+import { Success, Failure, type Result } from '@/types';
+
+/**
+ * Published schema of a single tool
+ */
+export interface ToolSchema {
+  description?: string;
+  /** JSON Schema of the tool's input object */
+  inputSchema: Record<string, unknown>;
+}
+
+const EXPORT_IDENTITY = { name: 'schema-export', version: '1.0.0' };
+
+/**
+ * Export the input schema of every tool that `bind` registers on an MCP server
+ *
+ * @param bind - Registers tools on the server, e.g. `(server) => app.bindToMCP(server)`
+ * @returns Schema per tool name, in registration order
+ *
+ * @example
+ * ```typescript
+ * const schemas = await exportToolSchemas((server) => app.bindToMCP(server));
+ * if (schemas.ok) console.log(JSON.stringify(schemas.value, null, 2));
+ * ```
+ */
+export async function exportToolSchemas(
+  bind: (server: McpServer) => void,
+): Promise<Result<Record<string, ToolSchema>>> {
+  const server = new McpServer(EXPORT_IDENTITY);
+  const client = new Client(EXPORT_IDENTITY);
+
+  try {
+    bind(server);
+
+    const [clientTransport, serverTransport] = InMemoryTransport.createLinkedPair();
+    await server.connect(serverTransport);
+    await client.connect(clientTransport);
+
+    const { tools } = await client.listTools();
+    return Success(
+      Object.fromEntries(
+        tools.map((tool): [string, ToolSchema] => [
+          tool.name,
+          {
+            ...(tool.description && { description: tool.description }),
+            inputSchema: tool.inputSchema,
+          },
+        ]),
+      ),
+    );
+  } catch (error) {
+    const message = `Failed to export tool schemas: ${extractErrorMessage(error)}`;
+    return Failure(message, {
+      message,
+      hint: 'Tools could not be registered or listed on the export server',
+      resolution: 'Check that at least one tool is registered and its input schema is valid',
+    });
+  } finally {
+    await client.close();
+    await server.close();
+  }
+}
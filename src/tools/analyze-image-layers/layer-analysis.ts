@@ -0,0 +1,311 @@
+/**
+ * Image layer analysis
+ *
+ * Turns `docker history` output into a per-layer breakdown and explains why
+ * a rebuild misses the cache: every layer from the first COPY of application
+ * source onward is rebuilt whenever that source changes, so expensive
+ * installs placed after it run on every build.
+ *
+ * History does not record copied file names for the legacy builder, only
+ * `dir:` (a directory) or `file:`/`multi:` (individual files). Directory
+ * copies are treated as source copies and file copies as manifest copies.
+ */
+
+import type { DockerImageHistoryEntry } from '@/infra/docker/client';
+import { formatSize, truncate } from '@/lib/summary-helpers';
+
+/**
+ * What a layer does, when it matters for caching
+ */
+export type LayerKind = 'source-copy' | 'dependency-install' | 'system-packages';
+
+export interface ImageLayer {
+  /** 0-based position in build order, base image layers first */
+  index: number;
+  /** Dockerfile instruction reconstructed from the history, e.g. `RUN npm ci` */
+  instruction: string;
+  /** Raw history command */
+  createdBy: string;
+  /** Size in bytes */
+  size: number;
+  /** Share of the total image size, 0-100 */
+  percentOfImage: number;
+  /** Metadata-only instruction (ENV, CMD, ...) that adds no filesystem content */
+  empty: boolean;
+  fromBaseImage: boolean;
+  /** Rebuilt whenever application source changes */
+  cacheBusted: boolean;
+  kind?: LayerKind;
+}
+
+export interface LayerAnalysis {
+  /** Layers in build order */
+  layers: ImageLayer[];
+  totalSize: number;
+  baseImageSize: number;
+  /** Largest non-empty layers, biggest first */
+  largestLayers: ImageLayer[];
+  /** Index of the first layer that copies application source */
+  cacheBoundary?: number;
+  suggestions: string[];
+}
+
+export const DEFAULT_LARGEST_LAYER_COUNT = 3;
+
+/** Source copies above this size usually mean .dockerignore is missing entries */
+const LARGE_SOURCE_COPY_BYTES = 50 * 1024 * 1024;
+
+const METADATA_INSTRUCTIONS = new Set([
+  'ARG',
+  'CMD',
+  'ENTRYPOINT',
+  'ENV',
+  'EXPOSE',
+  'HEALTHCHECK',
+  'LABEL',
+  'MAINTAINER',
+  'ONBUILD',
+  'SHELL',
+  'STOPSIGNAL',
+  'USER',
+  'VOLUME',
+  'WORKDIR',
+]);
+
+/** Files that only change when dependencies change; `*` allows COPY globs */
+const MANIFEST_FILE = new RegExp(
+  `^(${[
+    'package[\\w*-]*\\.json',
+    'npm-shrinkwrap\\.json',
+    'yarn\\.lock',
+    'pnpm-lock\\.yaml',
+    'requirements[\\w.*-]*\\.txt',
+    'pyproject\\.toml',
+    'poetry\\.lock',
+    'Pipfile(\\.lock)?',
+    'setup\\.(py|cfg)',
+    'go\\.(mod|sum)',
+    'Gemfile(\\.lock)?',
+    'pom\\.xml',
+    '(build|settings)\\.gradle(\\.kts)?',
+    'gradle\\.properties',
+    '[\\w.*-]+\\.(csproj|fsproj|sln)',
+    'NuGet\\.config',
+    'Cargo\\.(toml|lock)',
+    'composer\\.(json|lock)',
+    'mix\\.(exs|lock)',
+  ].join('|')})$`,
+);
+
+const DEPENDENCY_STEPS = [
+  /^npm (ci|install|i)\b/,
+  /^pnpm (install|i)\b/,
+  /^yarn( install)?(\s+-\S+)*$/,
+  /^(python3? -m )?pip3? install\b/,
+  /^poetry install\b/,
+  /^pipenv (install|sync)\b/,
+  /^go mod download\b/,
+  /^bundle install\b/,
+  /^composer install\b/,
+  /^cargo fetch\b/,
+  /^dotnet restore\b/,
+  /^(mvn|\.\/mvnw)\b.*\bdependency:(go-offline|resolve)\b/,
+  /^(gradle|\.\/gradlew)\b.*\bdependencies\b/,
+];
+
+const SYSTEM_PACKAGE_STEP = /^(apt-get|apt|apk|yum|dnf|microdnf) (install|add)\b/;
+
+/**
+ * Reconstruct the Dockerfile instruction from a history command
+ *
+ * Handles legacy `/bin/sh -c #(nop) CMD [...]` and `/bin/sh -c <cmd>` entries,
+ * build-arg prefixes such as `|1 NODE_ENV=production /bin/sh -c ...`, and
+ * BuildKit `RUN /bin/sh -c <cmd> # buildkit` entries.
+ */
+export function describeInstruction(createdBy: string): string {
+  let command = createdBy
+    .trim()
+    .replace(/\s+#\s*buildkit$/, '')
+    .replace(/^\|\d+(\s+\S+=\S*)*\s+/, '');
+
+  const nop = /^\/bin\/(ba)?sh -c #\(nop\)\s*(.*)$/s.exec(command);
+  if (nop) {
+    return (nop[2] ?? '').replace(/\s+/g, ' ').trim();
+  }
+
+  const shell = /^(RUN(\s+--\S+)*\s+)?\/bin\/(ba)?sh -c\s+(.*)$/s.exec(command);
+  if (shell) {
+    command = `RUN ${shell[4] ?? ''}`;
+  }
+  return command.replace(/\s+/g, ' ').trim();
+}
+
+/** Commands chained in a RUN instruction */
+const runSteps = (instruction: string): string[] =>
+  instruction
+    .replace(/^RUN\s+/, '')
+    .split(/&&|\|\||;/)
+    .map((step) => step.trim().replace(/^(set -\w+|sudo)\s+/, ''))
+    .filter(Boolean);
+
+function classifyLayer(instruction: string): LayerKind | undefined {
+  const [keyword = ''] = instruction.split(' ', 1);
+
+  if (keyword === 'COPY' || keyword === 'ADD') {
+    const args = instruction
+      .split(/\s+/)
+      .slice(1)
+      .filter((arg) => !arg.startsWith('--'));
+    if (instruction.includes('--from=') || args.length < 2) {
+      return undefined;
+    }
+    // Legacy builder: `COPY dir:<hash> in /app`, `COPY file:<hash> in /app/`
+    if (args[1] === 'in') {
+      return args[0]?.startsWith('dir:') ? 'source-copy' : undefined;
+    }
+    const sources = args.slice(0, -1);
+    if (sources.some((source) => /^https?:\/\//.test(source))) {
+      return undefined;
+    }
+    const onlyManifests = sources.every((source) =>
+      MANIFEST_FILE.test(source.replace(/^\.\//, '').split('/').pop() ?? ''),
+    );
+    return onlyManifests ? undefined : 'source-copy';
+  }
+
+  if (keyword === 'RUN') {
+    const steps = runSteps(instruction);
+    if (steps.some((step) => DEPENDENCY_STEPS.some((pattern) => pattern.test(step)))) {
+      return 'dependency-install';
+    }
+    if (steps.some((step) => SYSTEM_PACKAGE_STEP.test(step))) {
+      return 'system-packages';
+    }
+  }
+  return undefined;
+}
+
+/**
+ * Number of layers that belong to the base image
+ *
+ * History keeps no explicit FROM marker. The base image ends at its last
+ * CMD or ENTRYPOINT that is followed by more filesystem changes, or at an
+ * entry tagged locally (the base image itself), whichever is later.
+ */
+function countBaseLayers(instructions: string[], entries: DockerImageHistoryEntry[]): number {
+  let baseCount = 0;
+  let pendingDefault = -1;
+
+  instructions.forEach((instruction, i) => {
+    const keyword = instruction.split(' ', 1)[0] ?? '';
+    if (keyword === 'CMD' || keyword === 'ENTRYPOINT') {
+      pendingDefault = i;
+    } else if (pendingDefault >= 0 && !METADATA_INSTRUCTIONS.has(keyword)) {
+      baseCount = pendingDefault + 1;
+      pendingDefault = -1;
+    }
+
+    const tags = entries[i]?.Tags;
+    const tagged = !!tags && tags.length > 0 && !tags.includes('<none>:<none>');
+    if (tagged && i < instructions.length - 1) {
+      baseCount = Math.max(baseCount, i + 1);
+    }
+  });
+  return baseCount;
+}
+
+const describeLayer = (layer: ImageLayer): string =>
+  `layer ${layer.index} (\`${truncate(layer.instruction, 60)}\`, ${formatSize(layer.size)})`;
+
+/**
+ * Analyze image history for layer sizes and cache behaviour
+ *
+ * @param history - Entries as returned by `docker history` / the Engine API, newest first
+ * @param largestCount - How many of the largest layers to report
+ */
+export function analyzeImageHistory(
+  history: DockerImageHistoryEntry[],
+  largestCount = DEFAULT_LARGEST_LAYER_COUNT,
+): LayerAnalysis {
+  const entries = [...history].reverse();
+  const instructions = entries.map((entry) => describeInstruction(entry.CreatedBy ?? ''));
+  const totalSize = entries.reduce((sum, entry) => sum + Math.max(entry.Size ?? 0, 0), 0);
+  const baseCount = countBaseLayers(instructions, entries);
+
+  let cacheBoundary: number | undefined;
+  const layers = entries.map((entry, index): ImageLayer => {
+    const instruction = instructions[index] ?? '';
+    const size = Math.max(entry.Size ?? 0, 0);
+    const keyword = instruction.split(' ', 1)[0] ?? '';
+    const fromBaseImage = index < baseCount;
+    const kind = fromBaseImage ? undefined : classifyLayer(instruction);
+    if (kind === 'source-copy' && cacheBoundary === undefined) {
+      cacheBoundary = index;
+    }
+
+    return {
+      index,
+      instruction,
+      createdBy: entry.CreatedBy ?? '',
+      size,
+      percentOfImage: totalSize > 0 ? Math.round((size / totalSize) * 1000) / 10 : 0,
+      empty: size === 0 && METADATA_INSTRUCTIONS.has(keyword),
+      fromBaseImage,
+      cacheBusted: cacheBoundary !== undefined,
+      ...(kind && { kind }),
+    };
+  });
+
+  const largestLayers = layers
+    .filter((layer) => layer.size > 0)
+    .sort((a, b) => b.size - a.size || a.index - b.index)
+    .slice(0, largestCount);
+
+  return {
+    layers,
+    totalSize,
+    baseImageSize: layers
+      .filter((layer) => layer.fromBaseImage)
+      .reduce((sum, layer) => sum + layer.size, 0),
+    largestLayers,
+    ...(cacheBoundary !== undefined && { cacheBoundary }),
+    suggestions: suggestImprovements(layers, cacheBoundary),
+  };
+}
+
+function suggestImprovements(layers: ImageLayer[], cacheBoundary: number | undefined): string[] {
+  if (cacheBoundary === undefined) {
+    return [];
+  }
+
+  const suggestions: string[] = [];
+  const sourceCopy = layers[cacheBoundary];
+  const bustedInstalls = layers.filter(
+    (layer) =>
+      layer.index > cacheBoundary &&
+      (layer.kind === 'dependency-install' || layer.kind === 'system-packages'),
+  );
+
+  for (const layer of bustedInstalls) {
+    const what =
+      layer.kind === 'system-packages'
+        ? 'It does not need the source; move it above the COPY'
+        : 'Copy only the dependency manifests, run it, then copy the rest of the source';
+    suggestions.push(
+      `Reorder: ${describeLayer(layer)} runs after \`${sourceCopy?.instruction}\` and is rebuilt on every source change. ${what}.`,
+    );
+  }
+
+  if (sourceCopy && sourceCopy.size >= LARGE_SOURCE_COPY_BYTES) {
+    suggestions.push(
+      `Shrink the build context: ${describeLayer(sourceCopy)} is unusually large. Check that .dockerignore excludes dependency folders, .git and build output.`,
+    );
+  }
+
+  if (bustedInstalls.length > 0) {
+    suggestions.push(
+      'Run optimize-dockerfile on the Dockerfile to apply the reordering automatically.',
+    );
+  }
+  return suggestions;
+}
@@ -0,0 +1,18 @@
+/**
+ * Schema definition for analyze-image-layers tool
+ */
+
+import { z } from 'zod';
+
+export const analyzeImageLayersSchema = z.object({
+  imageId: z.string().min(1).describe('Docker image ID or name to analyze, e.g. myapp:latest'),
+  top: z
+    .number()
+    .int()
+    .min(1)
+    .max(20)
+    .optional()
+    .describe('Number of largest layers to highlight (default 3)'),
+});
+
+export type AnalyzeImageLayersParams = z.infer<typeof analyzeImageLayersSchema>;
@@ -0,0 +1,101 @@
+/**
+ * Analyze Image Layers Tool
+ *
+ * Breaks a built image down by layer using its history: size, the
+ * instruction that created it, and whether it is rebuilt every time the
+ * application source changes. Highlights the largest layers and suggests
+ * reordering when expensive installs sit after the source COPY.
+ *
+ * This is a deterministic operational tool with no AI calls.
+ *
+ * @example
+ * ```typescript
+ * const result = await analyzeImageLayers({ imageId: 'myapp:latest' }, context);
+ * ```
+ */
+
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { extractErrorMessage } from '@/lib/errors';
+import { createDockerClient } from '@/infra/docker/client';
+import { formatSize, pluralize, truncate } from '@/lib/summary-helpers';
+import type { ToolContext } from '@/mcp/context';
+import { Failure, Success, type Result } from '@/types';
+import { tool } from '@/types/tool';
+import { analyzeImageHistory, type LayerAnalysis } from './layer-analysis';
+import { analyzeImageLayersSchema, type AnalyzeImageLayersParams } from './schema';
+
+export interface AnalyzeImageLayersResult extends LayerAnalysis {
+  /**
+   * Natural language summary for user display.
+   * @example "✅ Analyzed 18 layers of myapp:latest (363MB). 4 layers are rebuilt on every source change."
+   */
+  summary: string;
+  imageId: string;
+}
+
+async function handleAnalyzeImageLayers(
+  input: AnalyzeImageLayersParams,
+  ctx: ToolContext,
+): Promise<Result<AnalyzeImageLayersResult>> {
+  const { logger, timer } = setupToolContext(ctx, 'analyze-image-layers');
+
+  try {
+    const dockerClient = createDockerClient(logger);
+    const daemon = await dockerClient.ping();
+    if (!daemon.ok) return daemon;
+
+    const historyResult = await dockerClient.getImageHistory(input.imageId);
+    if (!historyResult.ok) {
+      return Failure(`Failed to read image history: ${historyResult.error}`, historyResult.guidance);
+    }
+
+    const analysis = analyzeImageHistory(historyResult.value, input.top);
+    const busted = analysis.layers.filter((layer) => layer.cacheBusted && !layer.empty).length;
+    const largest = analysis.largestLayers[0];
+
+    let summary = `✅ Analyzed ${pluralize(analysis.layers.length, 'layer')} of ${input.imageId} (${formatSize(analysis.totalSize)}).`;
+    summary +=
+      analysis.cacheBoundary === undefined
+        ? ' No layer copies the full application source.'
+        : ` ${pluralize(busted, 'layer')} ${busted === 1 ? 'is' : 'are'} rebuilt on every source change.`;
+    if (largest) {
+      summary += ` Largest: ${truncate(largest.instruction, 60)} (${formatSize(largest.size)}).`;
+    }
+    if (analysis.suggestions.length > 0) {
+      summary += ` ${pluralize(analysis.suggestions.length, 'suggestion')} to improve caching.`;
+    }
+
+    logger.info(
+      {
+        imageId: input.imageId,
+        layers: analysis.layers.length,
+        totalSize: analysis.totalSize,
+        cacheBoundary: analysis.cacheBoundary,
+      },
+      'Image layer analysis complete',
+    );
+    timer.end({ layers: analysis.layers.length, suggestions: analysis.suggestions.length });
+
+    return Success({ summary, imageId: input.imageId, ...analysis });
+  } catch (error) {
+    timer.error(error);
+    return Failure(extractErrorMessage(error), {
+      message: extractErrorMessage(error),
+      hint: 'An unexpected error occurred while analyzing the image layers',
+      resolution: 'Verify that Docker is running and the image exists locally (`docker images`)',
+    });
+  }
+}
+
+export default tool({
+  name: 'analyze-image-layers',
+  description:
+    'Break down a built image by layer (size, creating instruction) and explain which layers are rebuilt when source changes',
+  category: 'docker',
+  version: '1.0.0',
+  schema: analyzeImageLayersSchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  handler: handleAnalyzeImageLayers,
+});
@@ -0,0 +1,199 @@
+/**
+ * Lockfile-based package manager detection
+ *
+ * The lockfile committed next to a manifest is the most reliable signal of
+ * which package manager a project uses. Detection here drives both the
+ * analyze-repo result and the install commands recommended for Dockerfiles.
+ */
+
+export const PACKAGE_MANAGERS = [
+  'npm',
+  'pnpm',
+  'yarn',
+  'bun',
+  'deno',
+  'go',
+  'poetry',
+  'cargo',
+] as const;
+
+export type PackageManager = (typeof PACKAGE_MANAGERS)[number];
+
+/**
+ * Lockfile name to the package manager that writes it
+ */
+export const LOCKFILES: Readonly<Record<string, PackageManager>> = {
+  'package-lock.json': 'npm',
+  'pnpm-lock.yaml': 'pnpm',
+  'yarn.lock': 'yarn',
+  'bun.lockb': 'bun',
+  'bun.lock': 'bun',
+  'deno.lock': 'deno',
+  'go.sum': 'go',
+  'poetry.lock': 'poetry',
+  'Cargo.lock': 'cargo',
+};
+
+/**
+ * Whether a file name is a recognized lockfile
+ */
+export function isLockFile(fileName: string): boolean {
+  return Object.hasOwn(LOCKFILES, fileName);
+}
+
+/**
+ * Package managers that can serve each analyzed language
+ */
+const LANGUAGE_MANAGERS: Readonly<Record<string, readonly PackageManager[]>> = {
+  javascript: ['npm', 'pnpm', 'yarn', 'bun', 'deno'],
+  typescript: ['npm', 'pnpm', 'yarn', 'bun', 'deno'],
+  go: ['go'],
+  python: ['poetry'],
+  rust: ['cargo'],
+};
+
+/**
+ * Order used to break ties when several JavaScript lockfiles coexist and the
+ * manifest does not declare a package manager. Deno and Bun lockfiles mean
+ * the project runs on that runtime, not Node.js. A stray package-lock.json is
+ * usually the leftover of an accidental `npm install`, so it loses.
+ */
+const NODE_PRECEDENCE: readonly PackageManager[] = ['deno', 'bun', 'pnpm', 'yarn', 'npm'];
+
+/**
+ * Package managers that are also the runtime the project runs on
+ */
+export const JS_RUNTIME_MANAGERS = ['deno', 'bun'] as const;
+
+export type JsRuntimeManager = (typeof JS_RUNTIME_MANAGERS)[number];
+
+export function isJsRuntimeManager(manager: string | undefined): manager is JsRuntimeManager {
+  return JS_RUNTIME_MANAGERS.some((runtime) => runtime === manager);
+}
+
+export interface PackageManagerDetection {
+  packageManager: PackageManager;
+  /** Every recognized lockfile found in the module directory */
+  lockFiles: string[];
+  /** Set when more than one lockfile was found; explains which one won */
+  conflict?: string;
+}
+
+/**
+ * Install instructions for a package manager
+ */
+export interface InstallCommands {
+  /**
+   * Files to COPY before installing so the dependency layer caches well.
+   * Defaults for when the module's files are unknown; see {@link resolveCopyFiles}.
+   */
+  copyFiles: string[];
+  install: string;
+}
+
+export const INSTALL_COMMANDS: Readonly<Record<PackageManager, InstallCommands>> = {
+  npm: { copyFiles: ['package.json', 'package-lock.json'], install: 'npm ci' },
+  pnpm: {
+    copyFiles: ['package.json', 'pnpm-lock.yaml'],
+    install: 'corepack enable && pnpm install --frozen-lockfile',
+  },
+  yarn: {
+    copyFiles: ['package.json', 'yarn.lock'],
+    install: 'corepack enable && yarn install --frozen-lockfile',
+  },
+  bun: { copyFiles: ['package.json', 'bun.lock'], install: 'bun install --frozen-lockfile' },
+  deno: { copyFiles: ['deno.json', 'deno.lock'], install: 'deno install --frozen' },
+  go: { copyFiles: ['go.mod', 'go.sum'], install: 'go mod download' },
+  poetry: {
+    copyFiles: ['pyproject.toml', 'poetry.lock'],
+    install: 'pip install poetry && poetry install --no-root --only main',
+  },
+  cargo: { copyFiles: ['Cargo.toml', 'Cargo.lock'], install: 'cargo fetch --locked' },
+};
+
+/**
+ * File names accepted in place of a default copy file, in preference order.
+ * Bun 1.2 replaced the binary bun.lockb with a text bun.lock.
+ */
+const COPY_FILE_VARIANTS: Readonly<Record<string, readonly string[]>> = {
+  'bun.lock': ['bun.lock', 'bun.lockb'],
+  'deno.json': ['deno.json', 'deno.jsonc'],
+};
+
+/**
+ * Every file name that may stand in for one of the manager's copy files
+ */
+export function copyFileCandidates(manager: PackageManager): string[] {
+  return INSTALL_COMMANDS[manager].copyFiles.flatMap((file) => COPY_FILE_VARIANTS[file] ?? [file]);
+}
+
+/**
+ * Files to COPY before installing, taken from those present in the module
+ * directory. Falls back to the defaults when none of them are present.
+ *
+ * @param present - Names of the files present in the module directory
+ */
+export function resolveCopyFiles(manager: PackageManager, present: readonly string[]): string[] {
+  const { copyFiles } = INSTALL_COMMANDS[manager];
+  const found = copyFiles.flatMap((file) => {
+    const variant = (COPY_FILE_VARIANTS[file] ?? [file]).find((name) => present.includes(name));
+    return variant ? [variant] : [];
+  });
+  return found.length > 0 ? found : [...copyFiles];
+}
+
+/**
+ * Parse the `packageManager` field of package.json (e.g. "pnpm@8.15.0")
+ */
+function parseDeclaredManager(declared?: string): PackageManager | undefined {
+  const name = declared?.split('@')[0];
+  return PACKAGE_MANAGERS.find((manager) => manager === name);
+}
+
+/**
+ * Detect the package manager from the lockfiles present in a directory
+ *
+ * Only lockfiles relevant to the module's language are considered, so a Go
+ * service with a small Node.js toolchain alongside is not a conflict. When
+ * several lockfiles remain the manager declared in package.json wins,
+ * otherwise the first found of deno, bun, pnpm, yarn, npm.
+ *
+ * @param fileNames - File names in the module directory
+ * @param options - Module language and package.json `packageManager` value
+ * @returns Detection, or undefined when no relevant lockfile is present
+ */
+export function detectPackageManager(
+  fileNames: string[],
+  options: { language?: string | undefined; declared?: string | undefined } = {},
+): PackageManagerDetection | undefined {
+  const relevant = options.language ? LANGUAGE_MANAGERS[options.language] : undefined;
+  const lockFiles = fileNames
+    .filter((name) => {
+      const manager = LOCKFILES[name];
+      return isLockFile(name) && (!relevant || (manager && relevant.includes(manager)));
+    })
+    .sort();
+  const found = lockFiles.map((name) => LOCKFILES[name] as PackageManager);
+
+  const [first] = found;
+  if (!first) return undefined;
+  if (found.length === 1) {
+    return { packageManager: first, lockFiles };
+  }
+
+  const declared = parseDeclaredManager(options.declared);
+  const packageManager =
+    (declared && found.includes(declared) ? declared : undefined) ??
+    NODE_PRECEDENCE.find((manager) => found.includes(manager)) ??
+    first;
+  const reason =
+    packageManager === declared
+      ? 'declared in package.json'
+      : 'no packageManager field in package.json';
+
+  return {
+    packageManager,
+    lockFiles,
+    conflict: `Found ${lockFiles.join(', ')}; using ${packageManager} (${reason}). Remove the stale lockfiles to avoid ambiguity.`,
+  };
+}
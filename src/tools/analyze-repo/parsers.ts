@@ -11,6 +11,7 @@
  */
 
 import { promises as fs } from 'node:fs';
+import path from 'node:path';
 import * as toml from '@iarna/toml';
 import { parseStringPromise } from 'xml2js';
 import { FRAMEWORK_PORTS } from '@/config/constants';
@@ -23,12 +24,31 @@ export interface ParsedConfig {
   dependencies?: string[];
   ports?: number[];
   entryPoint?: string;
+  /** Package manager declared by the manifest itself (package.json `packageManager`) */
+  packageManager?: string;
+  /** Runtime constraints from package.json `engines`, e.g. `{ bun: '>=1.1' }` */
+  engines?: Record<string, string>;
   buildSystem?: {
     type: string;
     version?: string;
   };
 }
 
+/**
+ * Framework packages in detection order; the first one depended on wins
+ */
+const NODE_FRAMEWORK_PACKAGES: ReadonlyArray<[string, string]> = [
+  ['express', 'express'],
+  ['@nestjs/core', 'nestjs'],
+  ['next', 'next'],
+  ['nuxt', 'nuxt'],
+  ['react', 'react'],
+  ['vue', 'vue'],
+  ['@angular/core', 'angular'],
+  ['hono', 'hono'],
+  ['elysia', 'elysia'],
+];
+
 /**
  * Parse package.json for Node.js projects
  */
@@ -37,16 +57,9 @@ export async function parsePackageJson(filePath: string): Promise<ParsedConfig>
     const content = await fs.readFile(filePath, 'utf-8');
     const pkg = JSON.parse(content);
 
-    let framework: string | undefined;
     const deps = { ...pkg.dependencies, ...pkg.devDependencies };
-
-    if (deps['express']) framework = 'express';
-    else if (deps['@nestjs/core']) framework = 'nestjs';
-    else if (deps['next']) framework = 'next';
-    else if (deps['nuxt']) framework = 'nuxt';
-    else if (deps['react']) framework = 'react';
-    else if (deps['vue']) framework = 'vue';
-    else if (deps['@angular/core']) framework = 'angular';
+    const frameworkEntry = NODE_FRAMEWORK_PACKAGES.find(([name]) => deps[name]);
+    const framework = frameworkEntry?.[1];
 
     const ports: number[] = [];
     if (pkg.scripts) {
@@ -71,11 +84,11 @@ export async function parsePackageJson(filePath: string): Promise<ParsedConfig>
       else ports.push(FRAMEWORK_PORTS.next); // Default Node.js port
     }
 
-    let entryPoint = pkg.main || 'index.js';
+    let entryPoint = pkg.main || pkg.module || 'index.js';
     if (pkg.scripts?.start) {
       const startScript = pkg.scripts.start;
-      // Extract entry point from "node server.js" or "ts-node src/index.ts"
-      const match = startScript.match(/(?:node|ts-node)\s+(.+?)(?:\s|$)/);
+      // Extract entry point from "node server.js", "ts-node src/index.ts" or "bun run index.ts"
+      const match = startScript.match(/(?:node|ts-node|bun(?:\s+run)?)\s+(.+?)(?:\s|$)/);
       if (match) entryPoint = match[1];
     }
 
@@ -98,13 +111,90 @@ export async function parsePackageJson(filePath: string): Promise<ParsedConfig>
       buildSystem,
     };
     if (framework) result.framework = framework;
+    if (frameworkEntry && typeof deps[frameworkEntry[0]] === 'string') {
+      result.frameworkVersion = deps[frameworkEntry[0]];
+    }
     if (pkg.engines?.node) result.languageVersion = pkg.engines.node;
+    if (pkg.engines && typeof pkg.engines === 'object') result.engines = pkg.engines;
+    if (typeof pkg.packageManager === 'string') result.packageManager = pkg.packageManager;
     return result;
   } catch (error) {
     throw new Error(`Failed to parse package.json: ${error}`);
   }
 }
 
+/**
+ * Framework packages imported by Deno projects, matched on the package name
+ * of the import specifier
+ */
+const DENO_FRAMEWORK_PACKAGES: ReadonlyArray<[string, string]> = [
+  ['@hono/hono', 'hono'],
+  ['hono', 'hono'],
+  ['@oak/oak', 'oak'],
+  ['@fresh/core', 'fresh'],
+  ['express', 'express'],
+];
+
+/** Deno.serve listens on 8000 unless told otherwise */
+const DENO_DEFAULT_PORT = 8000;
+
+/**
+ * Package name and version of an import map specifier, e.g.
+ * `jsr:@hono/hono@^4.6.0` or `npm:express@4`
+ */
+function parseDenoSpecifier(specifier: string): { name: string; version?: string } {
+  const bare = specifier.replace(/^(jsr|npm):\/?/, '');
+  // Scoped names start with '@', so the version separator comes later
+  const separator = bare.indexOf('@', 1);
+  if (separator === -1) return { name: bare.replace(/\/$/, '') };
+  const version = bare.slice(separator + 1).split('/')[0];
+  return { name: bare.slice(0, separator), ...(version && { version }) };
+}
+
+/**
+ * Parse deno.json or deno.jsonc for Deno projects
+ */
+export async function parseDenoConfig(filePath: string): Promise<ParsedConfig> {
+  try {
+    const content = await fs.readFile(filePath, 'utf-8');
+    // deno.jsonc allows comments; strip whole-line and block comments before parsing
+    const config = JSON.parse(
+      content.replace(/\/\*[\s\S]*?\*\//g, '').replace(/^\s*\/\/.*$/gm, ''),
+    );
+
+    const imports: Record<string, string> = config.imports ?? {};
+    const packages = Object.values(imports).map((specifier) =>
+      parseDenoSpecifier(String(specifier)),
+    );
+    const frameworkEntry = DENO_FRAMEWORK_PACKAGES.find(([name]) =>
+      packages.some((pkg) => pkg.name === name),
+    );
+    const frameworkPackage = packages.find((pkg) => pkg.name === frameworkEntry?.[0]);
+
+    // "deno run --allow-net src/main.ts" or "deno serve --port 8080 main.ts"
+    const startTask: string =
+      typeof config.tasks?.start === 'string'
+        ? config.tasks.start
+        : (config.tasks?.start?.command ?? '');
+    const entryPoint =
+      startTask.match(/\s([\w./-]+\.(?:ts|tsx|js|jsx|mjs))(?:\s|$)/)?.[1] ?? 'main.ts';
+    const portMatch = startTask.match(/--port[=\s]+(\d+)/);
+
+    const result: ParsedConfig = {
+      language: 'typescript',
+      dependencies: packages.map((pkg) => pkg.name).slice(0, 20),
+      ports: [portMatch?.[1] ? parseInt(portMatch[1], 10) : DENO_DEFAULT_PORT],
+      entryPoint,
+      buildSystem: { type: 'deno' },
+    };
+    if (frameworkEntry) result.framework = frameworkEntry[1];
+    if (frameworkPackage?.version) result.frameworkVersion = frameworkPackage.version;
+    return result;
+  } catch (error) {
+    throw new Error(`Failed to parse ${path.basename(filePath)}: ${error}`);
+  }
+}
+
 /**
  * Parse pom.xml for Java/Maven projects
  */
@@ -118,7 +208,14 @@ export async function parsePomXml(filePath: string): Promise<ParsedConfig> {
     const version = project.version?.[0] || '';
 
     let framework: string | undefined;
+    let frameworkVersion: string | undefined;
     const dependencies = project.dependencies?.[0]?.dependency || [];
+    const properties = project.properties?.[0] || {};
+    // Versions are often `${quarkus.platform.version}` placeholders
+    const resolveProperty = (value?: string): string | undefined => {
+      const name = value?.match(/^\$\{(.+)\}$/)?.[1];
+      return name ? properties[name]?.[0] : value;
+    };
 
     for (const dep of dependencies) {
       const depGroupId = dep.groupId?.[0] || '';
@@ -126,20 +223,25 @@ export async function parsePomXml(filePath: string): Promise<ParsedConfig> {
 
       if (depGroupId.includes('springframework.boot')) {
         framework = 'spring-boot';
-        break;
       } else if (depArtifactId.includes('quarkus')) {
         framework = 'quarkus';
-        break;
       } else if (depArtifactId.includes('micronaut')) {
         framework = 'micronaut';
-        break;
       } else if (depGroupId.includes('jakarta.ee')) {
         framework = 'jakarta-ee';
-        break;
+      } else {
+        continue;
       }
+      frameworkVersion = resolveProperty(dep.version?.[0]);
+      break;
+    }
+
+    // Spring Boot projects usually inherit the version from the starter parent
+    const parent = project.parent?.[0];
+    if (!frameworkVersion && parent?.groupId?.[0]?.includes('springframework.boot')) {
+      frameworkVersion = resolveProperty(parent.version?.[0]);
     }
 
-    const properties = project.properties?.[0] || {};
     const javaVersion =
       properties['java.version']?.[0] ||
       properties['maven.compiler.source']?.[0] ||
@@ -168,6 +270,7 @@ export async function parsePomXml(filePath: string): Promise<ParsedConfig> {
       },
     };
     if (framework) result.framework = framework;
+    if (frameworkVersion) result.frameworkVersion = frameworkVersion;
     if (javaVersion) result.languageVersion = javaVersion;
     if (project.modelVersion?.[0] && result.buildSystem) {
       result.buildSystem.version = project.modelVersion[0];
@@ -178,6 +281,15 @@ export async function parsePomXml(filePath: string): Promise<ParsedConfig> {
   }
 }
 
+/**
+ * Gradle plugin ID prefixes of each framework
+ */
+const GRADLE_FRAMEWORK_PLUGINS: ReadonlyArray<[string, string]> = [
+  ['org.springframework.boot', 'spring-boot'],
+  ['io.quarkus', 'quarkus'],
+  ['io.micronaut', 'micronaut'],
+];
+
 /**
  * Parse build.gradle(.kts) for Java/Gradle projects
  */
@@ -185,10 +297,14 @@ export async function parseGradle(filePath: string): Promise<ParsedConfig> {
   try {
     const content = await fs.readFile(filePath, 'utf-8');
 
-    let framework: string | undefined;
-    if (content.includes('org.springframework.boot')) framework = 'spring-boot';
-    else if (content.includes('io.quarkus')) framework = 'quarkus';
-    else if (content.includes('io.micronaut')) framework = 'micronaut';
+    const frameworkEntry = GRADLE_FRAMEWORK_PLUGINS.find(([id]) => content.includes(id));
+    const framework = frameworkEntry?.[1];
+    // plugins { id 'org.springframework.boot' version '3.2.0' } or the Kotlin DSL form
+    const frameworkVersion = frameworkEntry
+      ? new RegExp(
+          `id\\s*\\(?\\s*['"]${frameworkEntry[0].replace(/\./g, '\\.')}[\\w.-]*['"]\\s*\\)?\\s*version\\s*['"]([^'"]+)['"]`,
+        ).exec(content)?.[1]
+      : undefined;
 
     let javaVersion: string | undefined;
 
@@ -225,6 +341,7 @@ export async function parseGradle(filePath: string): Promise<ParsedConfig> {
       },
     };
     if (framework) result.framework = framework;
+    if (frameworkVersion) result.frameworkVersion = frameworkVersion;
     if (javaVersion) result.languageVersion = javaVersion;
     return result;
   } catch (error) {
@@ -232,33 +349,77 @@ export async function parseGradle(filePath: string): Promise<ParsedConfig> {
   }
 }
 
+const PYTHON_FRAMEWORKS = ['django', 'flask', 'fastapi', 'tornado'] as const;
+
 /**
- * Parse requirements.txt / pyproject.toml for Python projects
+ * Split a PEP 508 requirement such as `Django[argon2]>=4.2,<5 ; python_version>"3.8"`
+ * into its normalized name and version specifier
+ */
+function parseRequirement(requirement: string): { name: string; specifier: string } | undefined {
+  const match = /^([A-Za-z0-9][\w.-]*)\s*(?:\[[^\]]*\])?\s*([^;#]*)/.exec(requirement.trim());
+  if (!match?.[1]) return undefined;
+  return {
+    name: match[1].toLowerCase().replace(/[_.]/g, '-'),
+    specifier: (match[2] ?? '').trim(),
+  };
+}
+
+/**
+ * `python_requires` and `install_requires` from setup.py or setup.cfg
+ */
+function parseSetupScript(content: string): { pythonRequires?: string; requirements: string[] } {
+  const match = /python_requires\s*=\s*(?:['"]([^'"]+)['"]|([^\s'"].*))/.exec(content);
+  const pythonRequires = (match?.[1] ?? match?.[2])?.trim();
+  // setup.py: install_requires=['flask>=2.0', ...]; setup.cfg: indented lines after the key
+  const list =
+    /install_requires\s*=\s*\[([^\]]*)\]/.exec(content)?.[1] ??
+    /install_requires\s*=\s*\n((?:[ \t]+\S.*(?:\n|$))+)/.exec(content)?.[1];
+  const requirements = list
+    ? (list.match(/['"][^'"]+['"]/g)?.map((item) => item.slice(1, -1)) ??
+      list.split('\n').map((line) => line.trim()))
+    : [];
+  return {
+    ...(pythonRequires && { pythonRequires }),
+    requirements: requirements.filter((line) => line && !line.startsWith('#')),
+  };
+}
+
+/**
+ * Parse requirements.txt / pyproject.toml / setup.py / setup.cfg for Python projects
  */
 export async function parsePythonConfig(filePath: string): Promise<ParsedConfig> {
   try {
     const content = await fs.readFile(filePath, 'utf-8');
-    const isPyProject = filePath.endsWith('pyproject.toml');
+    const fileName = path.basename(filePath);
+    const isPyProject = fileName === 'pyproject.toml';
 
-    let framework: string | undefined;
     let dependencies: string[] = [];
     let pythonVersion: string | undefined;
 
     if (isPyProject) {
-      // Parse TOML
+      // Parse TOML; PEP 621 metadata first, then Poetry's own tables
       const parsed = toml.parse(content) as {
-        project?: { dependencies?: string[]; requires_python?: string };
+        project?: { dependencies?: string[]; 'requires-python'?: string };
+        tool?: {
+          poetry?: { dependencies?: Record<string, string | { version?: string }> };
+        };
       };
       const project = parsed.project || {};
-
-      dependencies = project.dependencies || [];
-      pythonVersion = project.requires_python;
-
-      const depStr = dependencies.join(' ').toLowerCase();
-      if (depStr.includes('django')) framework = 'django';
-      else if (depStr.includes('flask')) framework = 'flask';
-      else if (depStr.includes('fastapi')) framework = 'fastapi';
-      else if (depStr.includes('tornado')) framework = 'tornado';
+      const { python: poetryPython, ...poetryDependencies } =
+        parsed.tool?.poetry?.dependencies ?? {};
+      const versionOf = (spec: string | { version?: string } | undefined): string | undefined =>
+        typeof spec === 'string' ? spec : spec?.version;
+
+      dependencies =
+        project.dependencies ??
+        Object.entries(poetryDependencies).map(
+          ([name, spec]) => `${name}${versionOf(spec) ?? ''}`,
+        );
+      pythonVersion = project['requires-python'] ?? versionOf(poetryPython);
+    } else if (fileName === 'setup.py' || fileName === 'setup.cfg') {
+      const setup = parseSetupScript(content);
+      dependencies = setup.requirements;
+      pythonVersion = setup.pythonRequires;
     } else {
       // Parse requirements.txt (simple line-by-line)
       dependencies = content
@@ -266,13 +427,14 @@ export async function parsePythonConfig(filePath: string): Promise<ParsedConfig>
         .map((line) => line.trim())
         .filter((line) => line && !line.startsWith('#'))
         .slice(0, 20);
-
-      const depStr = dependencies.join(' ').toLowerCase();
-      if (depStr.includes('django')) framework = 'django';
-      else if (depStr.includes('flask')) framework = 'flask';
-      else if (depStr.includes('fastapi')) framework = 'fastapi';
     }
 
+    const depStr = dependencies.join(' ').toLowerCase();
+    const framework = PYTHON_FRAMEWORKS.find((name) => depStr.includes(name));
+    const frameworkVersion = framework
+      ? dependencies.map(parseRequirement).find((req) => req?.name === framework)?.specifier
+      : undefined;
+
     const ports: number[] = [];
     if (framework === 'django') ports.push(FRAMEWORK_PORTS.django);
     else if (framework === 'flask') ports.push(FRAMEWORK_PORTS.flask);
@@ -288,6 +450,7 @@ export async function parsePythonConfig(filePath: string): Promise<ParsedConfig>
       },
     };
     if (framework) result.framework = framework;
+    if (frameworkVersion) result.frameworkVersion = frameworkVersion;
     if (pythonVersion) result.languageVersion = pythonVersion;
     return result;
   } catch (error) {
@@ -315,6 +478,12 @@ export async function parseCargoToml(filePath: string): Promise<ParsedConfig> {
     else if (dependencies.includes('warp')) framework = 'warp';
     else if (dependencies.includes('axum')) framework = 'axum';
 
+    const frameworkDependency = framework ? parsed.dependencies?.[framework] : undefined;
+    const frameworkVersion =
+      typeof frameworkDependency === 'string'
+        ? frameworkDependency
+        : (frameworkDependency as { version?: string } | undefined)?.version;
+
     const rustVersion = package_['rust-version'] || package_.edition;
     const ports = framework ? [8080] : [];
 
@@ -327,6 +496,7 @@ export async function parseCargoToml(filePath: string): Promise<ParsedConfig> {
       },
     };
     if (framework) result.framework = framework;
+    if (frameworkVersion) result.frameworkVersion = frameworkVersion;
     if (rustVersion) result.languageVersion = rustVersion;
     return result;
   } catch (error) {
@@ -361,6 +531,12 @@ export async function parseCsProj(filePath: string): Promise<ParsedConfig> {
     if (depStr.includes('Microsoft.AspNetCore')) framework = 'aspnet-core';
     else if (depStr.includes('Microsoft.EntityFrameworkCore')) framework = 'entity-framework';
 
+    // ASP.NET Core ships with the runtime, so its version is the target framework's
+    const frameworkVersion =
+      framework === 'aspnet-core'
+        ? /^net(?:coreapp)?(\d+\.\d+)/.exec(targetFramework ?? '')?.[1]
+        : undefined;
+
     const ports =
       framework === 'aspnet-core'
         ? [FRAMEWORK_PORTS['aspnet-core'], FRAMEWORK_PORTS['aspnet-core-https']]
@@ -375,6 +551,7 @@ export async function parseCsProj(filePath: string): Promise<ParsedConfig> {
       },
     };
     if (framework) result.framework = framework;
+    if (frameworkVersion) result.frameworkVersion = frameworkVersion;
     if (targetFramework) result.languageVersion = targetFramework;
     return result;
   } catch (error) {
@@ -382,6 +559,16 @@ export async function parseCsProj(filePath: string): Promise<ParsedConfig> {
   }
 }
 
+/**
+ * Module paths of each Go framework, in detection order
+ */
+const GO_FRAMEWORK_MODULES: ReadonlyArray<[string, string]> = [
+  ['gin-gonic/gin', 'gin'],
+  ['labstack/echo', 'echo'],
+  ['gofiber/fiber', 'fiber'],
+  ['gorilla/mux', 'gorilla'],
+];
+
 /**
  * Parse go.mod for Go projects
  */
@@ -393,23 +580,26 @@ export async function parseGoMod(filePath: string): Promise<ParsedConfig> {
     const goVersion = goVersionMatch?.[1];
 
     const dependencies: string[] = [];
+    const versions = new Map<string, string>();
     const requireMatches = content.match(/require\s+\(([^)]+)\)/s);
     if (requireMatches?.[1]) {
       const requireBlock = requireMatches[1];
       const lines = requireBlock.split('\n');
       for (const line of lines) {
-        const match = line.trim().match(/^([^\s]+)/);
+        const match = line.trim().match(/^([^\s]+)(?:\s+(v\S+))?/);
         if (match?.[1]) dependencies.push(match[1]);
+        if (match?.[1] && match[2]) versions.set(match[1], match[2]);
         if (dependencies.length >= 20) break;
       }
     }
 
-    let framework: string | undefined;
-    const depStr = dependencies.join(' ');
-    if (depStr.includes('gin-gonic/gin')) framework = 'gin';
-    else if (depStr.includes('labstack/echo')) framework = 'echo';
-    else if (depStr.includes('gofiber/fiber')) framework = 'fiber';
-    else if (depStr.includes('gorilla/mux')) framework = 'gorilla';
+    const frameworkEntry = GO_FRAMEWORK_MODULES.find(([module]) =>
+      dependencies.some((dep) => dep.includes(module)),
+    );
+    const framework = frameworkEntry?.[1];
+    const frameworkVersion = frameworkEntry
+      ? [...versions].find(([dep]) => dep.includes(frameworkEntry[0]))?.[1]
+      : undefined;
 
     const result: ParsedConfig = {
       language: 'go',
@@ -420,6 +610,7 @@ export async function parseGoMod(filePath: string): Promise<ParsedConfig> {
       },
     };
     if (framework) result.framework = framework;
+    if (frameworkVersion) result.frameworkVersion = frameworkVersion;
     if (goVersion) result.languageVersion = goVersion;
     return result;
   } catch (error) {
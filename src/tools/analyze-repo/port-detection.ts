@@ -0,0 +1,206 @@
+/**
+ * Listen port detection from source code
+ *
+ * Scans Dockerfiles, application sources and start commands for the port a
+ * service listens on. Each match is evidence with a confidence; evidence for
+ * the same port is combined, so a port seen in both an EXPOSE and a listen
+ * call ranks above one only implied by a server default. Ambiguous projects
+ * yield several candidates instead of a single guess.
+ */
+
+/**
+ * Files worth scanning for listen ports. Tests are skipped because they
+ * often bind throwaway ports.
+ */
+const PORT_SOURCE_FILE =
+  /(\.(js|mjs|cjs|ts|py|go|rs|java|kt|cs|properties)|^Dockerfile|^Procfile|^package\.json)$/;
+const TEST_FILE = /(\.(test|spec)\.[cm]?[jt]s|_test\.go|^test_.*\.py)$/;
+
+/**
+ * Whether a file name should be scanned for listen ports
+ */
+export function isPortSourceFile(fileName: string): boolean {
+  return PORT_SOURCE_FILE.test(fileName) && !TEST_FILE.test(fileName);
+}
+
+export interface PortCandidate {
+  port: number;
+  /** 0-1, combined from every piece of evidence for this port */
+  confidence: number;
+  /** Where the port was found, e.g. "server.js:12 (listen call)" */
+  sources: string[];
+}
+
+interface PortPattern {
+  pattern: RegExp;
+  label: string;
+  confidence: number;
+}
+
+const EXPOSE_CONFIDENCE = 0.9;
+
+const PORT_PATTERNS: readonly PortPattern[] = [
+  // app.listen(3000), server.listen(8080, ...), fastify.listen({ port: 3000 })
+  { pattern: /\.listen\(\s*(\d+)\b/g, label: 'listen call', confidence: 0.8 },
+  { pattern: /\.listen\(\s*\{[^}]*\bport:\s*(\d+)/g, label: 'listen call', confidence: 0.8 },
+  // http.ListenAndServe(":8080"), r.Run(":8080"), e.Start("0.0.0.0:1323")
+  {
+    pattern: /\b(?:ListenAndServe(?:TLS)?|Run|Start|Listen)\(\s*"[\w.-]*:(\d+)"/g,
+    label: 'listen call',
+    confidence: 0.8,
+  },
+  // app.run(port=5000), uvicorn.run(app, port=8000)
+  { pattern: /\brun\([^)]*\bport\s*=\s*(\d+)/g, label: 'run call', confidence: 0.8 },
+  { pattern: /^\s*server\.port\s*[=:]\s*(\d+)/gm, label: 'server.port', confidence: 0.8 },
+  // gunicorn --bind 0.0.0.0:8000, -b :8000
+  { pattern: /(?:--bind|\s-b)[\s=]+['"]?[\w.-]*:(\d+)/g, label: 'bind flag', confidence: 0.8 },
+  { pattern: /--port[\s=]+(\d+)/g, label: 'port flag', confidence: 0.7 },
+  { pattern: /\bPORT=(\d+)/g, label: 'PORT variable', confidence: 0.7 },
+  // process.env.PORT || 3000
+  {
+    pattern: /process\.env\.PORT\s*(?:\|\||\?\?)\s*['"]?(\d+)/g,
+    label: 'PORT environment default',
+    confidence: 0.7,
+  },
+  // os.environ.get('PORT', 5000), os.getenv("PORT", "8000")
+  {
+    pattern: /(?:environ\.get|getenv)\(\s*['"]PORT['"]\s*,\s*['"]?(\d+)/g,
+    label: 'PORT environment default',
+    confidence: 0.7,
+  },
+  // const PORT = 3000, port = "8000" (Go fallback after os.Getenv)
+  {
+    pattern: /\bport\s*:?=\s*['"]?(\d+)['"]?\s*;?\s*$/gim,
+    label: 'port assignment',
+    confidence: 0.6,
+  },
+  // ":8080", "0.0.0.0:8000"
+  {
+    pattern: /["'](?:0\.0\.0\.0|localhost|127\.0\.0\.1|\[::\])?:(\d+)["']/g,
+    label: 'bind address',
+    confidence: 0.5,
+  },
+];
+
+/**
+ * Start commands that listen on a default port unless told otherwise
+ */
+const SERVER_DEFAULTS: ReadonlyArray<{
+  name: string;
+  command: RegExp;
+  override: RegExp;
+  port: number;
+}> = [
+  { name: 'gunicorn', command: /\bgunicorn\b/, override: /(--bind|\s-b)[\s=]/, port: 8000 },
+  { name: 'uvicorn', command: /\buvicorn\b/, override: /--port/, port: 8000 },
+  { name: 'flask run', command: /\bflask\s+run\b/, override: /(--port|\s-p)[\s=]/, port: 5000 },
+];
+
+/** Files that hold start commands */
+const COMMAND_FILE = /^(Dockerfile|Procfile|package\.json)/;
+
+const SERVER_DEFAULT_CONFIDENCE = 0.4;
+
+/** Confidence assigned to ports only known from the framework */
+export const FRAMEWORK_DEFAULT_CONFIDENCE = 0.3;
+
+function isValidPort(port: number): boolean {
+  return Number.isInteger(port) && port > 0 && port < 65536;
+}
+
+function lineAt(content: string, index: number): number {
+  return content.slice(0, index).split('\n').length;
+}
+
+interface Evidence {
+  port: number;
+  confidence: number;
+  source: string;
+}
+
+function scanFile(file: string, content: string): Evidence[] {
+  const fileName = file.split('/').pop() ?? file;
+  // Strongest evidence per port and line, so one bind string is not counted twice
+  const found = new Map<string, Evidence>();
+  const add = (port: number, line: number, label: string, confidence: number): void => {
+    if (!isValidPort(port)) return;
+    const key = `${port}:${line}`;
+    const existing = found.get(key);
+    if (!existing || existing.confidence < confidence) {
+      found.set(key, { port, confidence, source: `${file}:${line} (${label})` });
+    }
+  };
+
+  for (const { pattern, label, confidence } of PORT_PATTERNS) {
+    for (const match of content.matchAll(pattern)) {
+      add(Number(match[1]), lineAt(content, match.index ?? 0), label, confidence);
+    }
+  }
+
+  const lines = content.split('\n');
+  if (fileName.startsWith('Dockerfile')) {
+    for (const [index, line] of lines.entries()) {
+      const expose = line.match(/^\s*EXPOSE\s+(.+)$/i);
+      // EXPOSE 8080 9090/tcp
+      for (const port of expose?.[1]?.match(/\d+/g) ?? []) {
+        add(Number(port), index + 1, 'EXPOSE', EXPOSE_CONFIDENCE);
+      }
+    }
+  }
+
+  if (COMMAND_FILE.test(fileName)) {
+    for (const [index, line] of lines.entries()) {
+      for (const { name, command, override, port } of SERVER_DEFAULTS) {
+        if (command.test(line) && !override.test(line)) {
+          add(port, index + 1, `${name} default`, SERVER_DEFAULT_CONFIDENCE);
+        }
+      }
+    }
+  }
+
+  return [...found.values()];
+}
+
+/**
+ * Combine independent evidence: the chance that at least one is right
+ */
+function combine(confidences: number[]): number {
+  const miss = confidences.reduce((product, confidence) => product * (1 - confidence), 1);
+  return Math.min(0.99, Math.round((1 - miss) * 100) / 100);
+}
+
+/**
+ * Detect listen port candidates from file contents
+ *
+ * @param files - File contents keyed by path (relative, for readable sources)
+ * @param frameworkPorts - Ports implied by the detected framework, used only
+ *   when the sources contain no evidence at all
+ * @returns Candidates ordered by confidence, highest first
+ */
+export function detectPorts(
+  files: Record<string, string>,
+  frameworkPorts: number[] = [],
+): PortCandidate[] {
+  const evidence = Object.entries(files).flatMap(([file, content]) => scanFile(file, content));
+
+  if (evidence.length === 0) {
+    return [...new Set(frameworkPorts)].filter(isValidPort).map((port) => ({
+      port,
+      confidence: FRAMEWORK_DEFAULT_CONFIDENCE,
+      sources: ['framework default'],
+    }));
+  }
+
+  const byPort = new Map<number, Evidence[]>();
+  for (const item of evidence) {
+    byPort.set(item.port, [...(byPort.get(item.port) ?? []), item]);
+  }
+
+  return [...byPort.entries()]
+    .map(([port, items]) => ({
+      port,
+      confidence: combine(items.map((item) => item.confidence)),
+      sources: items.sort((a, b) => b.confidence - a.confidence).map((item) => item.source),
+    }))
+    .sort((a, b) => b.confidence - a.confidence || a.port - b.port);
+}
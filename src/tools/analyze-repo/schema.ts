@@ -4,6 +4,8 @@
 
 import { z } from 'zod';
 import { repositoryPath, analysisOptions } from '../shared/schemas';
+import { PACKAGE_MANAGERS } from './package-managers';
+import { RUNTIMES } from './version-detection';
 
 const moduleInfo = z.object({
   name: z.string().describe('The name of the module'),
@@ -23,10 +25,32 @@ const moduleInfo = z.object({
         name: z
           .string()
           .describe('Frameworks used in project like Java Spring, SpringBoot, Hibernate etc.'),
-        version: z.string().optional(),
+        version: z
+          .string()
+          .optional()
+          .describe('Version line to build on, resolved from the declared constraint'),
+        declared: z.string().optional().describe('Version constraint as written in the manifest'),
+        pinned: z
+          .boolean()
+          .optional()
+          .describe('False when the version is a recommended default, not pinned by the project'),
       }),
     )
     .optional(),
+  runtime: z
+    .object({
+      name: z.enum(RUNTIMES),
+      version: z.string().describe('Runtime version for the base image tag, e.g. "20" or "3.12"'),
+      declared: z
+        .string()
+        .optional()
+        .describe('Version or constraint declared by the project, e.g. ">=18" or "1.8"'),
+      pinned: z
+        .boolean()
+        .describe('False when the project does not pin a single version and a default is recommended'),
+    })
+    .optional()
+    .describe('Runtime and version to pass to generate-dockerfile as languageVersion'),
   buildSystems: z
     .array(
       z.object({
@@ -36,17 +60,43 @@ const moduleInfo = z.object({
     )
     .optional()
     .describe('All detected build systems with their language versions'),
+  packageManager: z
+    .enum(PACKAGE_MANAGERS)
+    .optional()
+    .describe('Package manager detected from the lockfile in the module directory'),
+  lockFiles: z.array(z.string()).optional().describe('Lockfiles found in the module directory'),
   dependencies: z
     .array(z.string())
     .optional()
     .describe('List of module dependencies including database drivers and system libraries'),
-  ports: z.array(z.number()).optional(),
+  ports: z
+    .array(z.number())
+    .optional()
+    .describe('Listen ports detected for the module, most likely first'),
+  portCandidates: z
+    .array(
+      z.object({
+        port: z.number(),
+        confidence: z.number().describe('0-1 confidence combined from all evidence'),
+        sources: z.array(z.string()).describe('Where the port was found, e.g. "server.js:12"'),
+      }),
+    )
+    .optional()
+    .describe(
+      'Port candidates with confidence and evidence. Several candidates mean the port is ambiguous; confirm with the user before relying on one.',
+    ),
   entryPoint: z.string().optional(),
 });
 export type ModuleInfo = z.infer<typeof moduleInfo>;
 
 export const analyzeRepoSchema = z.object({
   repositoryPath,
+  subpath: z
+    .string()
+    .optional()
+    .describe(
+      'Relative path of a single application root within the repository (e.g. services/api). When omitted, the whole repository is scanned and every detected application root is returned.',
+    ),
   ...analysisOptions,
   modules: z
     .array(moduleInfo)
@@ -64,6 +114,8 @@ export interface RepositoryAnalysis {
   modules?: ModuleInfo[];
   isMonorepo?: boolean;
   analyzedPath?: string;
+  /** Non-fatal findings such as conflicting lockfiles */
+  warnings?: string[];
   // Fields from AI response (for parsing)
   language?: string;
   languageVersion?: string;
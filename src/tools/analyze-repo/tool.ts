@@ -1,4 +1,5 @@
 import path from 'node:path';
+import { createHash } from 'node:crypto';
 import { promises as fs } from 'node:fs';
 import type { z } from 'zod';
 import { Success, Failure, type Result } from '@/types';
@@ -16,8 +17,27 @@ import {
   parseCargoToml,
   parseCsProj,
   parseGoMod,
+  parseDenoConfig,
   type ParsedConfig,
 } from './parsers';
+import { detectPackageManager, isLockFile } from './package-managers';
+import { detectPorts, isPortSourceFile, type PortCandidate } from './port-detection';
+import {
+  detectFrameworkVersion,
+  detectRuntimeVersion,
+  readRuntimeVersionFile,
+  runtimeFor,
+} from './version-detection';
+
+/** Limits for reading sources during port detection */
+const MAX_PORT_SOURCE_FILES = 50;
+const MAX_PORT_SOURCE_LENGTH = 20000;
+
+/** Directories the scan skips: dependencies, VCS metadata, editor settings, build output */
+const IGNORED_DIRECTORY_PATTERN = /^(node_modules|\.git|\.vscode|\.idea|dist|build|target|bin|obj)$/;
+
+/** Deepest directory level the scan descends to */
+const MAX_SCAN_DEPTH = 3;
 
 /**
  * Scan repository directory and gather file information
@@ -26,16 +46,20 @@ async function gatherRepositoryInfo(repoPath: string): Promise<{
   configFiles: Record<string, string>;
   fileList: string[];
   directoryTree: string[];
+  lockFiles: string[];
+  portSources: Record<string, string>;
 }> {
   // Get file list (top 100 files)
   const files: string[] = [];
   const configFileContents: Record<string, string> = {};
   const dirTree: string[] = [];
+  const lockFiles: string[] = [];
+  const portSources: Record<string, string> = {};
 
   async function scanDirectory(
     dir: string,
     depth: number = 0,
-    maxDepth: number = 3,
+    maxDepth: number = MAX_SCAN_DEPTH,
   ): Promise<void> {
     if (depth > maxDepth) return;
 
@@ -47,7 +71,7 @@ async function gatherRepositoryInfo(repoPath: string): Promise<{
         const relativePath = path.relative(repoPath, fullPath);
 
         // Skip node_modules, .git, and other common ignored directories
-        if (entry.name.match(/^(node_modules|\.git|\.vscode|\.idea|dist|build|target|bin|obj)$/)) {
+        if (IGNORED_DIRECTORY_PATTERN.test(entry.name)) {
           continue;
         }
 
@@ -57,10 +81,26 @@ async function gatherRepositoryInfo(repoPath: string): Promise<{
         } else {
           files.push(relativePath);
 
+          if (isLockFile(entry.name)) {
+            lockFiles.push(relativePath);
+          }
+
+          if (
+            isPortSourceFile(entry.name) &&
+            Object.keys(portSources).length < MAX_PORT_SOURCE_FILES
+          ) {
+            try {
+              const content = await fs.readFile(fullPath, 'utf-8');
+              portSources[relativePath] = content.substring(0, MAX_PORT_SOURCE_LENGTH);
+            } catch {
+              // Skip files that can't be read
+            }
+          }
+
           // Read config files
           const configFilePattern = new RegExp(
-            '^(package\\.json|pom\\.xml|build\\.gradle|build\\.gradle\\.kts|' +
-              'requirements\\.txt|pyproject\\.toml|Cargo\\.toml|go\\.mod|' +
+            '^(package\\.json|deno\\.jsonc?|pom\\.xml|build\\.gradle|build\\.gradle\\.kts|' +
+              'requirements\\.txt|pyproject\\.toml|setup\\.py|setup\\.cfg|Cargo\\.toml|go\\.mod|' +
               'composer\\.json|Gemfile|.*\\.csproj|.*\\.fsproj|.*\\.vbproj|' +
               'Dockerfile|docker-compose\\.yml|application\\.properties|application\\.yml)$',
           );
@@ -90,17 +130,78 @@ async function gatherRepositoryInfo(repoPath: string): Promise<{
     configFiles: configFileContents,
     fileList: files.slice(0, 50),
     directoryTree: dirTree.slice(0, 30),
+    lockFiles,
+    portSources,
   };
 }
 
+/**
+ * Fingerprint of every file the scan can read: its path, size and
+ * modification time. Editing, adding or removing a manifest, lockfile or
+ * source changes the fingerprint, so a cached analysis is not reused.
+ *
+ * @returns undefined when the repository cannot be read
+ */
+async function fingerprintRepository(
+  input: z.infer<typeof analyzeRepoSchema>,
+): Promise<string | undefined> {
+  const repoPath = path.resolve(input.repositoryPath, input.subpath ?? '');
+  const hash = createHash('sha256');
+
+  async function visit(dir: string, depth: number): Promise<void> {
+    const entries = await fs.readdir(dir, { withFileTypes: true });
+    entries.sort((a, b) => (a.name < b.name ? -1 : a.name > b.name ? 1 : 0));
+    for (const entry of entries) {
+      if (IGNORED_DIRECTORY_PATTERN.test(entry.name)) continue;
+      const fullPath = path.join(dir, entry.name);
+      if (entry.isDirectory()) {
+        hash.update(`${path.relative(repoPath, fullPath)}/\n`);
+        if (depth < MAX_SCAN_DEPTH) await visit(fullPath, depth + 1);
+      } else {
+        const { size, mtimeMs } = await fs.stat(fullPath);
+        hash.update(`${path.relative(repoPath, fullPath)}\0${size}\0${mtimeMs}\n`);
+      }
+    }
+  }
+
+  try {
+    await visit(repoPath, 0);
+    return hash.digest('hex');
+  } catch {
+    return undefined;
+  }
+}
+
+/**
+ * Port source files that belong to a module directory, excluding those of
+ * modules nested inside it
+ */
+function moduleSources(
+  repoPath: string,
+  portSources: Record<string, string>,
+  moduleDir: string,
+  moduleDirs: string[],
+): Record<string, string> {
+  const owner = (fileDir: string): string | undefined =>
+    moduleDirs
+      .filter((dir) => fileDir === dir || fileDir.startsWith(`${dir}${path.sep}`))
+      .sort((a, b) => b.length - a.length)[0];
+
+  return Object.fromEntries(
+    Object.entries(portSources).filter(
+      ([file]) => owner(path.dirname(path.join(repoPath, file))) === moduleDir,
+    ),
+  );
+}
+
 /**
  * Analyze repository deterministically by parsing config files
  */
 async function analyzeRepositoryDeterministically(
   repoPath: string,
-  repoInfo: { configFiles: Record<string, string>; fileList: string[]; directoryTree: string[] },
+  repoInfo: Awaited<ReturnType<typeof gatherRepositoryInfo>>,
   ctx: ToolContext,
-): Promise<ModuleInfo[]> {
+): Promise<{ modules: ModuleInfo[]; warnings: string[] }> {
   const logger = getToolLogger(ctx, 'analyze-repo');
   const configFilePaths = Object.keys(repoInfo.configFiles);
 
@@ -122,6 +223,10 @@ async function analyzeRepositoryDeterministically(
       if (fileName === 'package.json') {
         parsedConfig = await parsePackageJson(fullPath);
       }
+      // Deno
+      else if (/^deno\.jsonc?$/.test(fileName)) {
+        parsedConfig = await parseDenoConfig(fullPath);
+      }
       // Java - Maven
       else if (fileName === 'pom.xml') {
         parsedConfig = await parsePomXml(fullPath);
@@ -131,7 +236,7 @@ async function analyzeRepositoryDeterministically(
         parsedConfig = await parseGradle(fullPath);
       }
       // Python
-      else if (fileName === 'requirements.txt' || fileName === 'pyproject.toml') {
+      else if (/^(requirements\.txt|pyproject\.toml|setup\.py|setup\.cfg)$/.test(fileName)) {
         parsedConfig = await parsePythonConfig(fullPath);
       }
       // Rust
@@ -167,6 +272,8 @@ async function analyzeRepositoryDeterministically(
   }
 
   const modules: ModuleInfo[] = [];
+  const warnings: string[] = [];
+  const moduleDirs = [...configsByDirectory.keys()];
   for (const [dirName, configs] of configsByDirectory.entries()) {
     if (configs.length === 0) continue;
 
@@ -183,21 +290,140 @@ async function analyzeRepositoryDeterministically(
         languageVersion: c.languageVersion,
       }));
 
+    const lockFileNames = repoInfo.lockFiles
+      .filter((lockFile) => path.dirname(path.join(repoPath, lockFile)) === dirName)
+      .map((lockFile) => path.basename(lockFile));
+    const detection = detectPackageManager(lockFileNames, {
+      language: primaryConfig.language,
+      declared: configs.find((c) => c.packageManager)?.packageManager,
+    });
+
+    if (detection) {
+      // package.json parsing can only assume npm; the lockfile is authoritative
+      for (const buildSystem of buildSystems) {
+        if (buildSystem.type === 'npm') buildSystem.type = detection.packageManager;
+      }
+      if (detection.conflict) {
+        logger.warn({ modulePath: dirName, lockFiles: detection.lockFiles }, detection.conflict);
+        warnings.push(`${path.basename(dirName)}: ${detection.conflict}`);
+      }
+    }
+
+    // deno.json is a manifest of its own, so Deno is recognized without a lockfile
+    const packageManager =
+      detection?.packageManager ??
+      (buildSystems.some((buildSystem) => buildSystem.type === 'deno') ? 'deno' : undefined);
+
+    // Versions may be declared in any manifest of the module's language, e.g.
+    // python_requires in setup.py next to a requirements.txt
+    const sameLanguage = configs.filter((c) => c.language === primaryConfig.language);
+    const runtimeName = runtimeFor(primaryConfig.language, packageManager);
+    const declaredVersion =
+      runtimeName === 'deno' || runtimeName === 'bun'
+        ? configs.find((c) => c.engines?.[runtimeName])?.engines?.[runtimeName]
+        : sameLanguage.find((c) => c.languageVersion)?.languageVersion;
+    const runtime = detectRuntimeVersion(
+      primaryConfig.language,
+      (await readRuntimeVersionFile(dirName, primaryConfig.language, packageManager)) ??
+        declaredVersion,
+      packageManager,
+    );
+    const frameworkVersion = primaryConfig.framework
+      ? detectFrameworkVersion(
+          primaryConfig.framework,
+          sameLanguage.find((c) => c.framework === primaryConfig.framework && c.frameworkVersion)
+            ?.frameworkVersion,
+        )
+      : undefined;
+
+    const portCandidates = detectPorts(
+      moduleSources(repoPath, repoInfo.portSources, dirName, moduleDirs),
+      primaryConfig.ports,
+    );
+
     modules.push({
       name: path.basename(dirName),
       modulePath: dirName,
       language: primaryConfig.language || 'other',
       frameworks: primaryConfig.framework
-        ? [{ name: primaryConfig.framework, version: primaryConfig.frameworkVersion }]
+        ? [{ name: primaryConfig.framework, ...frameworkVersion }]
         : undefined,
+      ...(runtime && { runtime }),
       buildSystems: buildSystems.length > 0 ? buildSystems : undefined,
+      ...(packageManager && { packageManager }),
+      ...(detection && { lockFiles: detection.lockFiles }),
       dependencies: primaryConfig.dependencies,
-      ports: primaryConfig.ports,
+      ...(portCandidates.length > 0 && {
+        ports: portCandidates.map((candidate) => candidate.port),
+        portCandidates,
+      }),
       entryPoint: primaryConfig.entryPoint,
     });
   }
 
-  return modules;
+  return { modules, warnings };
+}
+
+/**
+ * Resolve an optional subpath against the repository root
+ *
+ * The subpath must stay inside the repository and point to a directory.
+ */
+async function resolveSubpath(rootPath: string, subpath?: string): Promise<Result<string>> {
+  if (!subpath) return Success(rootPath);
+
+  const target = path.resolve(rootPath, subpath);
+  const relative = path.relative(rootPath, target);
+  if (relative.startsWith('..') || path.isAbsolute(relative)) {
+    return Failure(`Subpath ${subpath} is outside the repository`, {
+      message: 'Invalid subpath',
+      hint: `The subpath must be relative to ${rootPath}`,
+      resolution: 'Pass a path inside the repository, e.g. services/api',
+      details: { repositoryPath: rootPath, subpath },
+    });
+  }
+
+  return validatePathOrFail(target, { mustExist: true, mustBeDirectory: true });
+}
+
+/**
+ * Short "name: language/framework" label for monorepo summaries
+ */
+function describeModule(module: ModuleInfo): string {
+  const framework = module.frameworks?.[0]?.name;
+  return `${module.name}: ${module.language || 'unknown'}${framework ? `/${framework}` : ''}`;
+}
+
+/**
+ * Runtime and framework versions of a single module, e.g. " (node 20, express 4)",
+ * noting when the runtime version is a recommendation rather than pinned
+ */
+function describeVersions(module: ModuleInfo | undefined): string {
+  const { runtime, frameworks } = module ?? {};
+  const framework = frameworks?.[0];
+  const versions = [
+    runtime && `${runtime.name} ${runtime.version}`,
+    framework?.version && `${framework.name} ${framework.version}`,
+  ].filter(Boolean);
+  if (versions.length === 0) return '';
+
+  const unpinned =
+    runtime && !runtime.pinned
+      ? `; no single ${runtime.name} version is pinned, so ${runtime.name} ${runtime.version} is recommended`
+      : '';
+  return ` (${versions.join(', ')}${unpinned})`;
+}
+
+/**
+ * Port summary for a single module, listing every candidate when ambiguous
+ */
+function describePorts(candidates: PortCandidate[] = []): string {
+  const [best] = candidates;
+  if (!best) return '';
+  if (candidates.length === 1) return ` Likely listen port: ${best.port}.`;
+  return ` Port candidates: ${candidates
+    .map((c) => `${c.port} (${Math.round(c.confidence * 100)}%)`)
+    .join(', ')}.`;
 }
 
 /**
@@ -216,7 +442,12 @@ async function handleAnalyzeRepo(
   });
   if (!pathResult.ok) return pathResult;
 
-  const repoPath = pathResult.value;
+  const rootPath = pathResult.value;
+
+  const subpathResult = await resolveSubpath(rootPath, input.subpath);
+  if (!subpathResult.ok) return subpathResult;
+
+  const repoPath = subpathResult.value;
 
   try {
     // If modules are provided by user, use them
@@ -240,7 +471,11 @@ async function handleAnalyzeRepo(
     const repoInfo = await gatherRepositoryInfo(repoPath);
 
     // Analyze deterministically by parsing config files
-    const modules = await analyzeRepositoryDeterministically(repoPath, repoInfo, ctx);
+    const { modules, warnings } = await analyzeRepositoryDeterministically(
+      repoPath,
+      repoInfo,
+      ctx,
+    );
 
     if (modules.length === 0) {
       return Failure('No modules detected in repository', {
@@ -258,16 +493,21 @@ async function handleAnalyzeRepo(
     // Generate summary
     const modulesText =
       modules.length === 1
-        ? `${modules[0]?.language || 'unknown'} project`
-        : `${pluralize(modules.length, 'module')} (${modules.map((m) => m.language).join(', ')})`;
+        ? `${modules[0]?.language || 'unknown'} project${describeVersions(modules[0])}`
+        : `${pluralize(modules.length, 'module')} (${modules.map(describeModule).join(', ')})`;
 
-    const summary = `✅ Analyzed repository at ${repoPath}. Detected ${modulesText}.${isMonorepo ? ' Monorepo structure identified.' : ''} Ready for Dockerfile generation.`;
+    const monorepoText = isMonorepo
+      ? ' Monorepo structure identified; pass subpath to analyze a single service.'
+      : '';
+    const portsText = isMonorepo ? '' : describePorts(modules[0]?.portCandidates);
+    const summary = `✅ Analyzed repository at ${repoPath}. Detected ${modulesText}.${portsText}${monorepoText} Ready for Dockerfile generation.`;
 
     return Success({
       summary,
       modules,
       isMonorepo,
       analyzedPath: repoPath,
+      ...(warnings.length > 0 && { warnings }),
     });
   } catch (e) {
     const error = e as Error;
@@ -288,7 +528,10 @@ export default tool({
   schema: analyzeRepoSchema,
   metadata: {
     knowledgeEnhanced: false,
+    // Read-only, so results for the same path are reused while fingerprintRepository is unchanged
+    cacheTtlMs: 5 * 60_000,
   },
+  cacheFingerprint: fingerprintRepository,
   chainHints: {
     success:
       'Repository analysis completed successfully. Continue by calling the generate-dockerfile or fix-dockerfile tools to create or fix your Dockerfile.',
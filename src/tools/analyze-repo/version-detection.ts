@@ -0,0 +1,387 @@
+/**
+ * Runtime and framework version resolution
+ *
+ * Manifests declare versions as constraints (`>=18`, `^4.18.0`, `~=3.10`,
+ * `1.21`). Base images are tagged by a single version line (`node:20`,
+ * `python:3.12`), so each constraint is resolved to the tag it should build
+ * on. A constraint that allows exactly one tag line is pinned; anything
+ * wider, or nothing at all, gets the recommended default when the default
+ * satisfies it, and the newest allowed line otherwise.
+ *
+ * Version files used by local tooling (.nvmrc, .python-version) name the
+ * exact version developers run, so they take precedence over manifests.
+ */
+
+import { promises as fs } from 'node:fs';
+import path from 'node:path';
+
+export const RUNTIMES = ['node', 'deno', 'bun', 'python', 'go', 'java', 'dotnet', 'rust'] as const;
+
+export type Runtime = (typeof RUNTIMES)[number];
+
+/**
+ * Recommended runtime versions for projects that do not pin one. The number
+ * of components is the precision used in base image tags.
+ */
+export const DEFAULT_RUNTIME_VERSIONS: Readonly<Record<Runtime, string>> = {
+  node: '20',
+  deno: '2.1',
+  bun: '1.1',
+  python: '3.12',
+  go: '1.22',
+  java: '21',
+  dotnet: '8.0',
+  rust: '1.79',
+};
+
+/**
+ * Recommended versions of detected frameworks, used the same way
+ */
+export const DEFAULT_FRAMEWORK_VERSIONS: Readonly<Record<string, string>> = {
+  express: '4',
+  nestjs: '10',
+  next: '14',
+  nuxt: '3',
+  react: '18',
+  vue: '3',
+  angular: '17',
+  hono: '4',
+  elysia: '1',
+  oak: '17',
+  django: '5.0',
+  flask: '3.0',
+  fastapi: '0.110',
+  tornado: '6.4',
+  'spring-boot': '3.2',
+  quarkus: '3',
+  micronaut: '4',
+  gin: '1.9',
+  echo: '4',
+  fiber: '2',
+  gorilla: '1.8',
+  'actix-web': '4',
+  rocket: '0.5',
+  warp: '0.3',
+  axum: '0.7',
+  'aspnet-core': '8.0',
+};
+
+const LANGUAGE_RUNTIMES: Readonly<Record<string, Runtime>> = {
+  javascript: 'node',
+  typescript: 'node',
+  python: 'python',
+  go: 'go',
+  java: 'java',
+  dotnet: 'dotnet',
+  rust: 'rust',
+};
+
+/**
+ * JavaScript projects locked by Deno or Bun run on that runtime, not Node.js
+ *
+ * @param packageManager - Package manager detected for the module
+ */
+export function runtimeFor(
+  language: string | undefined,
+  packageManager?: string,
+): Runtime | undefined {
+  const runtime = language ? LANGUAGE_RUNTIMES[language] : undefined;
+  if (runtime === 'node' && (packageManager === 'deno' || packageManager === 'bun')) {
+    return packageManager;
+  }
+  return runtime;
+}
+
+/**
+ * Version files read by nvm, pyenv and Heroku-style buildpacks, in precedence order
+ */
+const RUNTIME_VERSION_FILES: Readonly<Partial<Record<Runtime, readonly string[]>>> = {
+  node: ['.nvmrc', '.node-version'],
+  deno: ['.dvmrc'],
+  bun: ['.bun-version'],
+  python: ['.python-version', 'runtime.txt'],
+};
+
+export interface ResolvedVersion {
+  /** Version line to build on, e.g. "20" or "3.12" */
+  version: string;
+  /** Constraint as written in the manifest */
+  declared?: string;
+  /** True when the declared constraint allows only this version line */
+  pinned: boolean;
+}
+
+export interface RuntimeVersion extends ResolvedVersion {
+  name: Runtime;
+}
+
+type Version = number[];
+
+interface Bound {
+  version: Version;
+  inclusive: boolean;
+}
+
+/** A set of comparators that must all hold, e.g. `>=3.8,<4` */
+interface Range {
+  lower?: Bound;
+  upper?: Bound;
+  excluded: Version[];
+}
+
+function compare(a: Version, b: Version): number {
+  for (let i = 0; i < Math.max(a.length, b.length); i++) {
+    const diff = (a[i] ?? 0) - (b[i] ?? 0);
+    if (diff !== 0) return diff;
+  }
+  return 0;
+}
+
+/** Version with the component at `index` incremented and the rest dropped */
+const bump = (version: Version, index: number): Version => [
+  ...version.slice(0, index),
+  (version[index] ?? 0) + 1,
+];
+
+function satisfies(version: Version, range: Range): boolean {
+  const { lower, upper, excluded } = range;
+  if (lower && compare(version, lower.version) < (lower.inclusive ? 0 : 1)) return false;
+  if (upper && compare(version, upper.version) > (upper.inclusive ? 0 : -1)) return false;
+  return !excluded.some((excludedVersion) => compare(version, excludedVersion) === 0);
+}
+
+function intersect(range: Range, lower?: Bound, upper?: Bound): void {
+  if (lower && (!range.lower || compare(lower.version, range.lower.version) > 0)) {
+    range.lower = lower;
+  }
+  if (upper && (!range.upper || compare(upper.version, range.upper.version) < 0)) {
+    range.upper = upper;
+  }
+}
+
+/**
+ * Parse one `||`-free constraint such as `>=3.8, <4` or `^18.2.0`
+ *
+ * @returns undefined when a comparator is not a recognizable version
+ */
+function parseRange(text: string): Range | undefined {
+  const range: Range = { excluded: [] };
+  const comparators = text.match(/(>=|<=|==|!=|~=|~>|[<>=^~])?\s*v?[\w.*+-]+/g) ?? [];
+  for (const comparator of comparators) {
+    const match = /^(>=|<=|==|!=|~=|~>|[<>=^~])?\s*v?(.+)$/.exec(comparator.trim());
+    const [, operator = '', raw = ''] = match ?? [];
+    if (raw === '*' || raw.toLowerCase() === 'x') continue;
+
+    // `18.x`, `3.11.*` and bare prefixes match every version under the prefix
+    const parts = raw.replace(/[-+].*$/, '').split('.');
+    const wildcard = parts.findIndex((part) => /^[*xX]$/.test(part));
+    const numeric = wildcard === -1 ? parts : parts.slice(0, wildcard);
+    if (numeric.length === 0 || numeric.some((part) => !/^\d+$/.test(part))) return undefined;
+    const digits = numeric.map(Number);
+    const prefix = wildcard !== -1 || parts.length < 3;
+    const last = digits.length - 1;
+
+    switch (operator) {
+      case '>=':
+        intersect(range, { version: digits, inclusive: true });
+        break;
+      case '>':
+        intersect(range, { version: digits, inclusive: false });
+        break;
+      case '<=':
+        intersect(range, undefined, { version: digits, inclusive: true });
+        break;
+      case '<':
+        intersect(range, undefined, { version: digits, inclusive: false });
+        break;
+      case '!=':
+        range.excluded.push(digits);
+        break;
+      case '^': {
+        // Compatible with the first non-zero component
+        const significant = digits.findIndex((part) => part !== 0);
+        const index = significant === -1 ? last : significant;
+        intersect(
+          range,
+          { version: digits, inclusive: true },
+          { version: bump(digits, index), inclusive: false },
+        );
+        break;
+      }
+      case '~':
+        intersect(
+          range,
+          { version: digits, inclusive: true },
+          { version: bump(digits, Math.min(1, last)), inclusive: false },
+        );
+        break;
+      case '~=':
+      case '~>':
+        // Compatible release: the last component may increase
+        intersect(
+          range,
+          { version: digits, inclusive: true },
+          { version: bump(digits, Math.max(0, last - 1)), inclusive: false },
+        );
+        break;
+      default:
+        // `18`, `==3.11.*`, `1.21.5`: exact, or every version under a prefix
+        intersect(
+          range,
+          { version: digits, inclusive: true },
+          prefix
+            ? { version: bump(digits, last), inclusive: false }
+            : { version: digits, inclusive: true },
+        );
+    }
+  }
+  return range;
+}
+
+const truncate = (version: Version, precision: number): Version =>
+  Array.from({ length: precision }, (_, index) => version[index] ?? 0);
+
+/**
+ * Newest version line below the range's upper bound, or the lowest line it
+ * allows when it has no upper bound
+ */
+function versionLine(range: Range, precision: number): Version | undefined {
+  const lowest = range.lower && truncate(range.lower.version, precision);
+  if (!range.upper) return lowest;
+
+  let line: Version | undefined = truncate(range.upper.version, precision);
+  if (!range.upper.inclusive && compare(line, range.upper.version) === 0) {
+    // `<3.12` excludes the whole 3.12 line
+    const last = precision - 1;
+    line = (line[last] ?? 0) > 0 ? [...line.slice(0, last), (line[last] ?? 0) - 1] : undefined;
+  }
+  return line && (!lowest || compare(line, lowest) >= 0) ? line : lowest;
+}
+
+/**
+ * Resolve a version constraint to the version line to build on
+ *
+ * @param declared - Constraint from the manifest; `||` separates alternatives
+ * @param recommended - Default version; its component count sets the precision
+ */
+export function resolveVersion(
+  declared: string | undefined,
+  recommended: string,
+): ResolvedVersion {
+  const fallback: ResolvedVersion = {
+    version: recommended,
+    ...(declared && { declared }),
+    pinned: false,
+  };
+  const precision = recommended.split('.').length;
+  const preferred = recommended.split('.').map(Number);
+
+  const ranges = (declared ?? '').split('||').map((alternative) => alternative.trim());
+  if (ranges.every((alternative) => alternative === '' || alternative === '*')) return fallback;
+
+  const resolved: Array<{ version: Version; pinned: boolean }> = [];
+  for (const alternative of ranges) {
+    const range = parseRange(alternative);
+    if (!range) return fallback;
+    const line = satisfies(preferred, range) ? preferred : versionLine(range, precision);
+    if (!line) continue;
+    // Pinned when no other line is allowed: none below (lower bound) and none above
+    const lowest = range.lower && truncate(range.lower.version, precision);
+    const pinned =
+      lowest !== undefined &&
+      compare(line, lowest) === 0 &&
+      !satisfies(bump(line, precision - 1), range);
+    resolved.push({ version: line, pinned });
+  }
+
+  // Prefer an alternative that admits the default, then the newest line
+  const best =
+    resolved.find((candidate) => candidate.version === preferred) ??
+    resolved.sort((a, b) => compare(b.version, a.version))[0];
+  if (!best) return fallback;
+  return {
+    version: best.version.join('.'),
+    ...(declared && { declared }),
+    pinned: best.pinned && resolved.length === 1,
+  };
+}
+
+/**
+ * Declared runtime version as a constraint; strips manifest-specific prefixes
+ * and ignores values that are not runtime versions
+ */
+function normalizeRuntimeConstraint(runtime: Runtime, declared: string): string | undefined {
+  switch (runtime) {
+    case 'java':
+      // Maven and Gradle still accept the legacy 1.8 spelling of Java 8
+      return declared.replace(/^1\.(\d+)$/, '$1');
+    case 'dotnet': {
+      // net8.0, netcoreapp3.1; net48 is .NET Framework and has no Linux image
+      const match = /^net(?:coreapp)?(\d+\.\d+)/i.exec(declared);
+      return match?.[1];
+    }
+    case 'rust':
+      // Cargo editions (2018, 2021) are not toolchain versions
+      return /^20\d\d$/.test(declared) ? undefined : declared;
+    default:
+      return declared;
+  }
+}
+
+/**
+ * Runtime version pinned by a version file in a module directory
+ *
+ * @returns The first line of the first version file found, without prefixes
+ *   such as `v18.17.0` or `python-3.11.5`
+ */
+export async function readRuntimeVersionFile(
+  directory: string,
+  language: string | undefined,
+  packageManager?: string,
+): Promise<string | undefined> {
+  const runtime = runtimeFor(language, packageManager);
+  for (const file of (runtime && RUNTIME_VERSION_FILES[runtime]) ?? []) {
+    try {
+      const content = await fs.readFile(path.join(directory, file), 'utf-8');
+      const version = content.split('\n')[0]?.trim().replace(/^(python-|v)/, '');
+      if (version) return version;
+    } catch {
+      // Not present
+    }
+  }
+  return undefined;
+}
+
+/**
+ * Runtime version for a module from its language and declared version
+ *
+ * @returns undefined for languages without a known runtime image
+ */
+export function detectRuntimeVersion(
+  language: string | undefined,
+  declared: string | undefined,
+  packageManager?: string,
+): RuntimeVersion | undefined {
+  const runtime = runtimeFor(language, packageManager);
+  if (!runtime) return undefined;
+
+  const constraint = declared && normalizeRuntimeConstraint(runtime, declared.trim());
+  const resolved = resolveVersion(constraint || undefined, DEFAULT_RUNTIME_VERSIONS[runtime]);
+  return { name: runtime, ...resolved, ...(declared && { declared }) };
+}
+
+/**
+ * Framework version from the dependency constraint in the manifest
+ *
+ * @returns undefined when the constraint is missing and no default is known
+ */
+export function detectFrameworkVersion(
+  framework: string,
+  declared: string | undefined,
+): ResolvedVersion | undefined {
+  const recommended = DEFAULT_FRAMEWORK_VERSIONS[framework];
+  if (!recommended) {
+    return declared ? { version: declared, declared, pinned: false } : undefined;
+  }
+  return resolveVersion(declared, recommended);
+}
@@ -4,6 +4,26 @@
 
 import { z } from 'zod';
 import { imageName, tags, buildArgs, platform } from '../shared/schemas';
+import { DOCKER_PLATFORMS } from '../generate-dockerfile/schema';
+import { SBOM_FORMATS } from '@/infra/security/sbom';
+
+/**
+ * BuildKit cache source/target, e.g. `type=registry,ref=myregistry.io/app:cache` or `type=gha`
+ */
+const cacheSpec = z
+  .string()
+  .regex(/^type=(registry|gha)(,.*)?$/, 'Cache spec must start with type=registry or type=gha');
+
+/**
+ * Cache export target; `type=inline` embeds the cache in the pushed image and
+ * is the only export the default docker driver supports
+ */
+const cacheToSpec = z
+  .string()
+  .regex(
+    /^type=(registry|gha|inline)(,.*)?$/,
+    'Cache export must start with type=registry, type=gha, or type=inline',
+  );
 
 export const buildImageSchema = z.object({
   path: z
@@ -19,6 +39,38 @@ export const buildImageSchema = z.object({
   tags: tags.optional(),
   buildArgs: buildArgs.optional(),
   platform,
+  platforms: z
+    .array(z.enum(DOCKER_PLATFORMS))
+    .optional()
+    .describe(
+      'Target platforms for a multi-architecture build (e.g., ["linux/amd64", "linux/arm64"]). Uses docker buildx and pushes a manifest list, so tags must include a registry. Omit for a single-platform build.',
+    ),
+  cacheFrom: z
+    .array(cacheSpec)
+    .optional()
+    .describe(
+      'BuildKit cache sources passed as --cache-from (e.g., ["type=registry,ref=myregistry.io/app:cache"] or ["type=gha"])',
+    ),
+  cacheTo: z
+    .array(cacheToSpec)
+    .optional()
+    .describe(
+      'BuildKit cache export targets passed as --cache-to (e.g., ["type=registry,ref=myregistry.io/app:cache,mode=max"], ["type=gha,mode=max"], or ["type=inline"], which works on the default docker driver)',
+    ),
+  generateSbom: z
+    .boolean()
+    .optional()
+    .describe('Generate an SBOM for the built image (uses syft when installed)'),
+  sbomFormat: z
+    .enum(SBOM_FORMATS)
+    .optional()
+    .describe('SBOM format when generateSbom is set (default: cyclonedx)'),
+  dryRun: z
+    .boolean()
+    .optional()
+    .describe(
+      'Validate the build context, Dockerfile and tags and return the build plan without contacting the Docker daemon; nothing is built',
+    ),
 });
 
 export type BuildImageParams = z.infer<typeof buildImageSchema>;
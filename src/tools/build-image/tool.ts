@@ -15,9 +15,24 @@
 import path from 'path';
 import { normalizePath } from '@/lib/platform';
 import { setupToolContext } from '@/lib/tool-context-helpers';
-import type { ToolContext } from '@/mcp/context';
+import { createStepReporter, type ToolContext } from '@/mcp/context';
 import { createDockerClient, type DockerBuildOptions } from '@/infra/docker/client';
-import { validatePathOrFail, parseImageName } from '@/lib/validation-helpers';
+import {
+  checkBuildxAvailability,
+  isMultiPlatform,
+  runBuildxBuild,
+  unsupportedCacheExports,
+  type BuildCacheSummary,
+} from '@/infra/docker/buildx';
+import {
+  createInventoryGenerator,
+  createSyftGenerator,
+  generateSbom as generateImageSbom,
+  type SbomArtifact,
+  type SbomFormat,
+} from '@/infra/security/sbom';
+import { validatePathOrFail } from '@/lib/validation-helpers';
+import { imageRefName, parseImageRef } from '@/lib/image-ref';
 import { readDockerfile } from '@/lib/file-utils';
 
 import { type Result, Success, Failure } from '@/types';
@@ -49,6 +64,41 @@ export interface BuildImageResult {
   /** Security-related warnings discovered during build */
   securityWarnings?: string[];
   failedTags?: string[];
+  /** Platforms built (multi-architecture builds only) */
+  platforms?: string[];
+  /** Manifest list digest (multi-architecture builds only) */
+  digest?: string;
+  /** Whether the image was pushed as part of the build (multi-architecture builds only) */
+  pushed?: boolean;
+  /** Layer cache hits and misses (BuildKit builds only) */
+  cache?: BuildCacheSummary;
+  /** Non-fatal build issues, such as cache options that could not be honoured */
+  warnings?: string[];
+  /** Generated SBOM artifact (when generateSbom is set) */
+  sbom?: SbomArtifact;
+  /** True when this was a dry run; no image was built, tagged or pushed */
+  dryRun?: boolean;
+  /** Build that would run (dry runs only) */
+  plan?: BuildPlan;
+}
+
+/**
+ * Build that a dry run validated without running it
+ */
+export interface BuildPlan {
+  /** Absolute build context path */
+  context: string;
+  /** Dockerfile path relative to the context */
+  dockerfile: string;
+  tags: string[];
+  /** Build argument names; values are left out so secrets are not echoed back */
+  buildArgs: string[];
+  /** Target platforms; empty for the daemon's default platform */
+  platforms: string[];
+  /** docker for a classic daemon build, buildx for BuildKit builds */
+  builder: 'docker' | 'buildx';
+  cacheFrom?: string[];
+  cacheTo?: string[];
 }
 
 /**
@@ -78,15 +128,16 @@ async function applyAdditionalTags(
 ): Promise<string[]> {
   const failedTags: string[] = [];
   for (const tag of tags) {
-    const parsedTag = parseImageName(tag);
-    if (!parsedTag.ok) {
-      logger.warn({ imageId, tag, error: parsedTag.error }, 'Failed to parse tag - skipping');
+    const parsedTag = parseImageRef(tag);
+    if (!parsedTag.ok || parsedTag.value.digest) {
+      const error = parsedTag.ok ? 'Tags cannot include a digest' : parsedTag.error;
+      logger.warn({ imageId, tag, error }, 'Failed to parse tag - skipping');
       failedTags.push(tag);
       continue;
     }
 
-    const { repository, tag: tagName, registry } = parsedTag.value;
-    const fullRepository = registry ? `${registry}/${repository}` : repository;
+    const fullRepository = imageRefName(parsedTag.value);
+    const tagName = parsedTag.value.tag ?? 'latest';
 
     const tagResult = await dockerClient.tagImage(imageId, fullRepository, tagName);
     if (!tagResult.ok) {
@@ -97,6 +148,36 @@ async function applyAdditionalTags(
   return failedTags;
 }
 
+/**
+ * Generate an SBOM for a locally available image, preferring syft and
+ * falling back to a layer inventory from the Docker daemon
+ */
+async function createImageSbom(
+  imageRef: string,
+  format: SbomFormat,
+  dockerClient: ReturnType<typeof createDockerClient>,
+  logger: ReturnType<typeof setupToolContext>['logger'],
+): Promise<{ sbom?: SbomArtifact; warnings: string[] }> {
+  const { artifact, warnings } = await generateImageSbom(imageRef, format, {
+    generators: [
+      createSyftGenerator(logger),
+      createInventoryGenerator((ref) => dockerClient.inspectImage(ref)),
+    ],
+    logger,
+  });
+  return { ...(artifact && { sbom: artifact }), warnings };
+}
+
+/**
+ * Return the tags that cannot be applied to an image
+ */
+function findInvalidTags(tags: string[]): string[] {
+  return tags.filter((tag) => {
+    const parsed = parseImageRef(tag);
+    return !parsed.ok || parsed.value.digest !== undefined;
+  });
+}
+
 /**
  * Analyze build for security issues
  */
@@ -154,6 +235,11 @@ async function handleBuildImage(
     tags = [],
     buildArgs = {},
     platform,
+    platforms,
+    cacheFrom = [],
+    cacheTo = [],
+    generateSbom = false,
+    sbomFormat = 'cyclonedx',
   } = params;
 
   try {
@@ -194,12 +280,176 @@ async function handleBuildImage(
 
     const finalTags = tags.length > 0 ? tags : imageName ? [imageName] : [];
 
+    const multiPlatform = isMultiPlatform(platforms);
+    const useCache = cacheFrom.length > 0 || cacheTo.length > 0;
+    // A single entry in platforms is an ordinary single-platform build
+    const targetPlatform = platform ?? (platforms?.length === 1 ? platforms[0] : undefined);
+    const buildWarnings: string[] = [];
+
+    if (params.dryRun) {
+      const invalidTags = findInvalidTags(finalTags);
+      if (invalidTags.length > 0) {
+        return Failure(`Invalid image tags: ${invalidTags.join(', ')}`, {
+          message: 'The build plan has tags that cannot be applied to an image',
+          hint: 'Tags must be image references such as myregistry.io/app:1.0, without a digest',
+          resolution: 'Fix the listed tags and run the dry run again',
+          details: { invalidTags },
+        });
+      }
+
+      const plan: BuildPlan = {
+        context: buildContext,
+        dockerfile: path.relative(buildContext, finalDockerfilePath),
+        tags: finalTags,
+        buildArgs: Object.keys(finalBuildArgs),
+        platforms: multiPlatform ? (platforms ?? []) : targetPlatform ? [targetPlatform] : [],
+        builder: multiPlatform || useCache ? 'buildx' : 'docker',
+        ...(cacheFrom.length > 0 && { cacheFrom }),
+        ...(cacheTo.length > 0 && { cacheTo }),
+      };
+      if (plan.builder === 'buildx') {
+        buildWarnings.push('This build needs docker buildx; its availability was not checked');
+      }
+      if (cacheTo.length > 0) {
+        buildWarnings.push(
+          'Cache export needs a buildx builder with the docker-container driver; the default docker driver only exports type=inline',
+        );
+      }
+      if (multiPlatform) {
+        buildWarnings.push('A multi-platform build pushes the image to its registry');
+      }
+
+      logger.info({ plan }, 'Build plan validated (dry run)');
+      timer.end({ dryRun: true });
+      return Success({
+        summary: `✅ Dry run: build plan for ${finalTags[0] ?? 'image'} is valid (${plan.builder} build of ${plan.dockerfile}). Nothing was built.`,
+        success: true,
+        imageId: '',
+        tags: finalTags,
+        size: 0,
+        buildTime: 0,
+        logs: [],
+        dryRun: true,
+        plan,
+        ...(securityWarnings.length > 0 && { securityWarnings }),
+        ...(buildWarnings.length > 0 && { warnings: buildWarnings }),
+      });
+    }
+
+    // Daemon check, build, then tagging and SBOM
+    const completeStep = createStepReporter(context.progress, 3);
+
+    // Fail with recovery steps up front instead of a connection error mid-build
+    const daemon = await dockerClient.ping();
+    if (!daemon.ok) return daemon;
+    await completeStep('Docker daemon reachable');
+
+    // Multi-platform builds and cache import/export need BuildKit via buildx
+    if (multiPlatform || useCache) {
+      const buildxCheck = await checkBuildxAvailability(logger);
+
+      if (!buildxCheck.ok && multiPlatform) return buildxCheck;
+
+      if (!buildxCheck.ok) {
+        const warning =
+          'BuildKit is not available (docker buildx not found); building without cache import/export';
+        logger.warn({ cacheFrom, cacheTo }, warning);
+        buildWarnings.push(warning);
+      } else {
+        const buildPlatforms = multiPlatform
+          ? (platforms ?? [])
+          : targetPlatform
+            ? [targetPlatform]
+            : [];
+
+        const unsupported = buildxCheck.value.platforms.length
+          ? buildPlatforms.filter((p) => !buildxCheck.value.platforms.includes(p))
+          : [];
+        if (unsupported.length > 0) {
+          return Failure(
+            `Builder '${buildxCheck.value.builder}' does not support: ${unsupported.join(', ')}`,
+            {
+              message: 'Requested platforms are not supported by the active buildx builder',
+              hint: `Supported platforms: ${buildxCheck.value.platforms.join(', ')}`,
+              resolution:
+                'Create a builder with the docker-container driver and QEMU emulation: docker run --privileged --rm tonistiigi/binfmt --install all && docker buildx create --driver docker-container --use',
+              details: { builder: buildxCheck.value.builder, unsupported },
+            },
+          );
+        }
+
+        // The default docker driver would reject these and fail the whole build
+        const skippedCacheTo = unsupportedCacheExports(buildxCheck.value.driver, cacheTo);
+        const exportTargets = cacheTo.filter((target) => !skippedCacheTo.includes(target));
+        if (skippedCacheTo.length > 0) {
+          const warning = `Builder '${buildxCheck.value.builder}' uses the docker driver, which cannot export cache; skipped --cache-to ${skippedCacheTo.join(', ')}. To export cache, use a docker-container builder: docker buildx create --driver docker-container --use`;
+          logger.warn({ builder: buildxCheck.value.builder, skippedCacheTo }, warning);
+          buildWarnings.push(warning);
+        }
+
+        const buildxResult = await runBuildxBuild(
+          {
+            context: buildContext,
+            dockerfile: path.relative(buildContext, finalDockerfilePath),
+            tags: finalTags,
+            platforms: buildPlatforms,
+            buildArgs: finalBuildArgs,
+            ...(cacheFrom.length > 0 && { cacheFrom }),
+            ...(exportTargets.length > 0 && { cacheTo: exportTargets }),
+          },
+          logger,
+        );
+        if (!buildxResult.ok) return buildxResult;
+
+        const { digest, imageId, buildTime, logs, pushed, cache } = buildxResult.value;
+        const imageTag = finalTags[0] || imageId;
+        await completeStep(`Built ${imageTag}`);
+
+        let sbom: SbomArtifact | undefined;
+        if (generateSbom && multiPlatform) {
+          buildWarnings.push(
+            'SBOM generation is skipped for multi-platform builds because the image is not loaded locally',
+          );
+        } else if (generateSbom) {
+          const sbomResult = await createImageSbom(imageTag, sbomFormat, dockerClient, logger);
+          sbom = sbomResult.sbom;
+          buildWarnings.push(...sbomResult.warnings);
+        }
+
+        const timeText = buildTime
+          ? ` Build completed in ${formatDuration(Math.round(buildTime / 1000))}.`
+          : '';
+        const cacheText = cache ? ` Cache: ${cache.hits} hits, ${cache.misses} misses.` : '';
+        const summary = multiPlatform
+          ? `✅ Built and pushed multi-platform image ${imageTag} for ${buildPlatforms.join(', ')}.${timeText}${cacheText}`
+          : `✅ Built image successfully. Image: ${imageTag}.${timeText}${cacheText}${sbom ? ` SBOM: ${sbom.path}.` : ''}`;
+
+        await completeStep('Build complete');
+        timer.end({ imageId, digest, platforms: buildPlatforms, buildTime });
+        return Success({
+          summary,
+          success: true,
+          imageId: multiPlatform ? digest : imageId,
+          tags: finalTags,
+          size: 0,
+          buildTime,
+          logs,
+          ...(multiPlatform && { platforms: buildPlatforms, pushed }),
+          ...(digest && { digest }),
+          ...(cache && { cache }),
+          ...(sbom && { sbom }),
+          ...(securityWarnings.length > 0 && { securityWarnings }),
+          ...(buildWarnings.length > 0 && { warnings: buildWarnings }),
+        });
+      }
+    }
+
     // Prepare Docker build options
     const buildOptions: DockerBuildOptions = {
       context: buildContext,
       dockerfile: path.relative(buildContext, finalDockerfilePath),
       buildargs: finalBuildArgs,
-      ...(platform !== undefined && { platform }),
+      ...(targetPlatform !== undefined && { platform: targetPlatform }),
       ...(finalTags.length > 0 && finalTags[0] && { t: finalTags[0] }),
     };
 
@@ -221,6 +471,7 @@ async function handleBuildImage(
 
       return Failure(detailedError, guidance);
     }
+    await completeStep(`Built ${finalTags[0] || buildResult.value.imageId}`);
 
     // Apply additional tags to the built image
     let failedTags: string[] = [];
@@ -243,12 +494,22 @@ async function handleBuildImage(
 
     // Generate summary
     const imageTag = finalTags[0] || buildResult.value.imageId;
+
+    let sbom: SbomArtifact | undefined;
+    if (generateSbom) {
+      const sbomResult = await createImageSbom(imageTag, sbomFormat, dockerClient, logger);
+      sbom = sbomResult.sbom;
+      buildWarnings.push(...sbomResult.warnings);
+    }
+
     const sizeText = buildResult.value.size ? ` (${formatSize(buildResult.value.size)})` : '';
     const timeText = buildResult.value.buildTime
       ? ` Build completed in ${formatDuration(Math.round(buildResult.value.buildTime / 1000))}.`
       : '';
 
-    const summary = `✅ Built image successfully. Image: ${imageTag}${sizeText}.${timeText}`;
+    const sbomText = sbom ? ` SBOM: ${sbom.path}.` : '';
+
+    const summary = `✅ Built image successfully. Image: ${imageTag}${sizeText}.${timeText}${sbomText}`;
 
     const result: BuildImageResult = {
       summary,
@@ -261,8 +522,11 @@ async function handleBuildImage(
       logs: buildResult.value.logs,
       ...(securityWarnings.length > 0 && { securityWarnings }),
       ...(failedTags.length > 0 && { failedTags }),
+      ...(buildWarnings.length > 0 && { warnings: buildWarnings }),
+      ...(sbom && { sbom }),
     };
 
+    await completeStep('Build complete');
     timer.end({ imageId: buildResult.value.imageId, buildTime: buildResult.value.buildTime });
     return Success(result);
   } catch (error) {
@@ -0,0 +1,19 @@
+/**
+ * Schema definition for cleanup-workspace tool
+ */
+
+import { z } from 'zod';
+
+export const cleanupWorkspaceSchema = z.object({
+  workflowId: z
+    .string()
+    .regex(/^[\w.-]{1,128}$/)
+    .optional()
+    .describe("Workflow (session) whose saved state to remove (default: the caller's session)"),
+  dryRun: z
+    .boolean()
+    .optional()
+    .describe('Report what would be removed without removing anything'),
+});
+
+export type CleanupWorkspaceParams = z.infer<typeof cleanupWorkspaceSchema>;
@@ -0,0 +1,107 @@
+/**
+ * Cleanup Workspace Tool
+ *
+ * Removes the saved state of one workflow session on demand, to reclaim
+ * disk before the store's idle age or size limits would. A session used
+ * within the active window (see ACTIVE_WORKFLOW_WINDOW_MS) is left alone,
+ * so a workflow that is still running never loses its stages.
+ *
+ * @example
+ * ```typescript
+ * const result = await app.execute('cleanup-workspace', { workflowId: 'session-42' });
+ * // result.value.reclaimedBytes === 18432
+ * ```
+ */
+
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { extractErrorMessage } from '@/lib/errors';
+import { formatSize } from '@/lib/summary-helpers';
+import type { ToolContext } from '@/mcp/context';
+import { Failure, Success, type Result } from '@/types';
+import { tool } from '@/types/tool';
+import { cleanupWorkspaceSchema, type CleanupWorkspaceParams } from './schema';
+
+export interface CleanupWorkspaceResult {
+  /**
+   * Natural language summary for user display.
+   * @example "✅ Removed saved state of workflow session-42 (18KB reclaimed)."
+   */
+  summary: string;
+  workflowId: string;
+  /** Whether the state was removed (or, in a dry run, would be) */
+  removed: boolean;
+  reclaimedBytes: number;
+  /** Why nothing was removed */
+  skippedReason?: 'active' | 'not-found';
+  dryRun: boolean;
+}
+
+async function handleCleanupWorkspace(
+  input: CleanupWorkspaceParams,
+  context: ToolContext,
+): Promise<Result<CleanupWorkspaceResult>> {
+  const { logger, timer } = setupToolContext(context, 'cleanup-workspace');
+
+  const binding = context.workflow;
+  if (!binding?.store.cleanup) {
+    return Failure('Workspace cleanup is not available', {
+      message: 'No workflow state store that can clean up workflows',
+      hint: 'Workflow state is only cleaned up when the server saves it to a directory',
+      resolution:
+        'Set CONTAINERIZATION_ASSIST_WORKFLOW_STATE_DIR, or pass a workflowState store with cleanup() to createApp',
+    });
+  }
+
+  const workflowId = input.workflowId ?? binding.id;
+  const dryRun = input.dryRun ?? false;
+  try {
+    const report = await binding.store.cleanup({ ids: [workflowId], dryRun });
+    const skippedReason = report.skipped.find((entry) => entry.id === workflowId)?.reason;
+    const removed = report.removed.some((entry) => entry.id === workflowId);
+
+    let summary: string;
+    if (skippedReason === 'active') {
+      summary = `✅ Workflow ${workflowId} is active, so its state was kept. Try again once it has been idle.`;
+    } else if (skippedReason === 'not-found') {
+      summary = `✅ No saved state for workflow ${workflowId}; nothing to remove.`;
+    } else if (dryRun) {
+      summary = `✅ Dry run: would remove saved state of workflow ${workflowId} (${formatSize(report.reclaimedBytes)}).`;
+    } else {
+      summary = `✅ Removed saved state of workflow ${workflowId} (${formatSize(report.reclaimedBytes)} reclaimed).`;
+    }
+
+    logger.info(
+      { workflowId, removed, reclaimedBytes: report.reclaimedBytes, skippedReason, dryRun },
+      'Workspace cleanup finished',
+    );
+    timer.end({ workflowId, removed, reclaimedBytes: report.reclaimedBytes });
+    return Success({
+      summary,
+      workflowId,
+      removed,
+      reclaimedBytes: report.reclaimedBytes,
+      ...(skippedReason && { skippedReason }),
+      dryRun,
+    });
+  } catch (error) {
+    timer.error(error);
+    return Failure(`Failed to clean up workflow state: ${extractErrorMessage(error)}`, {
+      message: 'Workflow state could not be cleaned up',
+      hint: extractErrorMessage(error),
+      resolution: 'Check that the workflow state directory is writable',
+    });
+  }
+}
+
+export default tool({
+  name: 'cleanup-workspace',
+  description:
+    'Remove the saved workflow state of an idle session and report the disk space reclaimed',
+  category: 'utility',
+  version: '1.0.0',
+  schema: cleanupWorkspaceSchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  handler: handleCleanupWorkspace,
+});
@@ -0,0 +1,20 @@
+import { z } from 'zod';
+import { namespaceOptional } from '../shared/schemas';
+
+export const deploySchema = z.object({
+  manifestPaths: z
+    .array(z.string().min(1))
+    .min(1)
+    .describe('Kubernetes YAML manifest files to apply (e.g. from generate-k8s-manifests)'),
+  namespace: namespaceOptional.describe(
+    'Namespace for resources that do not set one (default: default)',
+  ),
+  dryRun: z
+    .boolean()
+    .optional()
+    .describe(
+      'Send every request as a server-side dry run: the API server validates and admits the resources, but nothing is changed (default: false)',
+    ),
+});
+
+export type DeployParams = z.infer<typeof deploySchema>;
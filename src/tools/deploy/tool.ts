@@ -0,0 +1,166 @@
+/**
+ * Deploy Tool
+ *
+ * Applies Kubernetes manifests to the cluster with a create-or-patch
+ * strategy (the equivalent of `kubectl apply -f`). With `dryRun`, every
+ * request is a server-side dry run, so the API server validates and admits
+ * the resources without persisting anything.
+ *
+ * This is a deterministic operational tool with no AI calls.
+ *
+ * @example
+ * ```typescript
+ * const result = await deploy({
+ *   manifestPaths: ['./k8s/deployment.yaml', './k8s/service.yaml'],
+ *   namespace: 'staging',
+ *   dryRun: true,
+ * }, context);
+ * ```
+ */
+
+import { readFile } from 'node:fs/promises';
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { validatePathOrFail } from '@/lib/validation-helpers';
+import { extractErrorMessage } from '@/lib/errors';
+import type { ToolContext } from '@/mcp/context';
+import {
+  createIdempotentApply,
+  parseManifests,
+  type K8sResource,
+} from '@/infra/kubernetes/idempotent-apply';
+import { Success, Failure, type Result } from '@/types';
+import { deploySchema, type DeployParams } from './schema';
+
+export interface DeployResult extends Record<string, unknown> {
+  /**
+   * Natural language summary for user display.
+   * @example "✅ Applied 2 resources to namespace staging: Deployment/web, Service/web."
+   */
+  summary: string;
+  success: boolean;
+  namespace: string;
+  /** True when nothing was persisted (server-side dry run) */
+  dryRun: boolean;
+  /** Resources applied, or admitted by the dry run, in manifest order */
+  resources: Array<{ kind: string; name: string; namespace: string }>;
+  workflowHints?: {
+    nextStep: string;
+    message: string;
+  };
+}
+
+/**
+ * Read and parse every manifest file, in order
+ */
+async function loadManifests(manifestPaths: string[]): Promise<Result<K8sResource[]>> {
+  const resources: K8sResource[] = [];
+  for (const manifestPath of manifestPaths) {
+    const validPath = await validatePathOrFail(manifestPath, { mustExist: true, mustBeFile: true });
+    if (!validPath.ok) return validPath;
+
+    resources.push(...parseManifests(await readFile(validPath.value, 'utf-8')));
+  }
+  return Success(resources);
+}
+
+async function handleDeploy(
+  params: DeployParams,
+  context: ToolContext,
+): Promise<Result<DeployResult>> {
+  const { logger, timer } = setupToolContext(context, 'deploy');
+
+  const { manifestPaths, dryRun = false } = params;
+  const namespace = params.namespace ?? 'default';
+
+  try {
+    const loaded = await loadManifests(manifestPaths);
+    if (!loaded.ok) return loaded;
+
+    if (loaded.value.length === 0) {
+      return Failure('No Kubernetes resources found in the given manifests', {
+        message: 'Manifests contain no resources',
+        hint: 'Each YAML document needs apiVersion and kind to be applied',
+        resolution: 'Check the manifest paths, or generate manifests with generate-k8s-manifests',
+        details: { manifestPaths },
+      });
+    }
+
+    const applyResource = createIdempotentApply(logger);
+    const resources: DeployResult['resources'] = [];
+
+    for (const manifest of loaded.value) {
+      // Cluster-scoped kinds ignore the namespace; the API server clears it
+      const resource: K8sResource = {
+        ...manifest,
+        metadata: { ...manifest.metadata, namespace: manifest.metadata.namespace ?? namespace },
+      };
+      const target = `${resource.kind}/${resource.metadata.name}`;
+
+      const result = await applyResource(resource, { dryRun });
+      if (!result.ok) {
+        timer.error(new Error(result.error));
+        return Failure(`Failed to apply ${target}: ${result.error}`, {
+          message: result.guidance?.message ?? `Failed to apply ${target}`,
+          ...(result.guidance?.hint && { hint: result.guidance.hint }),
+          resolution: result.guidance?.resolution ?? 'Fix the manifest and run deploy again',
+          // Resources applied before the failure are not rolled back
+          details: { ...result.guidance?.details, failed: target, applied: resources, dryRun },
+        });
+      }
+
+      resources.push({
+        kind: resource.kind,
+        name: resource.metadata.name,
+        namespace: resource.metadata.namespace ?? namespace,
+      });
+    }
+
+    const names = resources.map((r) => `${r.kind}/${r.name}`).join(', ');
+    const summary = dryRun
+      ? `✅ Dry run: ${resources.length} resource(s) passed server-side validation for namespace ${namespace}: ${names}. Nothing was changed.`
+      : `✅ Applied ${resources.length} resource(s) to namespace ${namespace}: ${names}.`;
+
+    logger.info({ namespace, dryRun, count: resources.length }, 'Deploy complete');
+    timer.end({ namespace, dryRun, resources: resources.length });
+
+    return Success({
+      summary,
+      success: true,
+      namespace,
+      dryRun,
+      resources,
+      workflowHints: dryRun
+        ? {
+            nextStep: 'deploy',
+            message: 'Dry run passed. Run deploy again without dryRun to apply the manifests.',
+          }
+        : {
+            nextStep: 'verify-deploy',
+            message: 'Manifests applied. Use verify-deploy to wait for the rollout and check health.',
+          },
+    });
+  } catch (error) {
+    timer.error(error);
+
+    return Failure(extractErrorMessage(error), {
+      message: extractErrorMessage(error),
+      hint: 'An unexpected error occurred while applying manifests',
+      resolution:
+        'Verify that a kubeconfig is available, the cluster is reachable, and you have permission to create the resources',
+    });
+  }
+}
+
+import { tool } from '@/types/tool';
+
+export default tool({
+  name: 'deploy',
+  description: 'Apply Kubernetes manifests to the cluster, optionally as a server-side dry run',
+  category: 'kubernetes',
+  version: '1.0.0',
+  schema: deploySchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  handler: handleDeploy,
+});
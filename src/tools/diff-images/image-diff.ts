@@ -0,0 +1,247 @@
+/**
+ * Image diffing
+ *
+ * Compares the final file trees of two images and, when SBOMs are available,
+ * their package inventories. Only metadata is compared: changed files are
+ * reported by size and content digest, never by content, so large binaries
+ * show up as a single entry with a size delta. Directories are rolled up so
+ * that growth such as a new `node_modules` tree reads as one line instead of
+ * thousands of files.
+ */
+
+import type {
+  ImageFileEntry,
+  ImageFileType,
+  ImageFilesystem,
+} from '@/infra/docker/image-filesystem';
+import type { SbomPackage } from '@/infra/security/sbom';
+import { formatSize } from '@/lib/summary-helpers';
+
+/** Why a file present in both images differs */
+export type FileChangeReason = 'content' | 'type' | 'mode' | 'link';
+
+export interface FileChange {
+  path: string;
+  type: ImageFileType;
+  /** Size in bytes in the target image; 0 when removed */
+  size: number;
+  /** Size in bytes in the base image, for changed and removed files */
+  previousSize?: number;
+  sizeDelta: number;
+  /** What differs, for changed files */
+  reasons?: FileChangeReason[];
+}
+
+export interface DirectoryChange {
+  /** Directory rolled up to at most DIRECTORY_DEPTH levels, e.g. `/app/node_modules` */
+  path: string;
+  added: number;
+  removed: number;
+  changed: number;
+  sizeDelta: number;
+}
+
+export interface FilesystemDiff {
+  /** Largest changes first, at most `maxEntries` per list */
+  added: FileChange[];
+  removed: FileChange[];
+  changed: FileChange[];
+  /** Totals before truncation */
+  counts: { added: number; removed: number; changed: number; unchanged: number };
+  /** Net change in file bytes */
+  sizeDelta: number;
+  /** Directories with changes, largest size delta first */
+  directories: DirectoryChange[];
+  /** Whether any list was cut to `maxEntries` */
+  truncated: boolean;
+}
+
+export interface DiffFilesystemsOptions {
+  /** Maximum entries per list (default DEFAULT_MAX_ENTRIES) */
+  maxEntries?: number;
+}
+
+export interface PackageVersionChange {
+  name: string;
+  type?: string;
+  previousVersions: string[];
+  versions: string[];
+}
+
+export interface PackageDiff {
+  added: SbomPackage[];
+  removed: SbomPackage[];
+  changed: PackageVersionChange[];
+}
+
+export const DEFAULT_MAX_ENTRIES = 50;
+
+const DIRECTORY_DEPTH = 2;
+
+/** `-42MB` / `+3KB` */
+export const formatSizeDelta = (bytes: number): string =>
+  `${bytes < 0 ? '-' : '+'}${formatSize(Math.abs(bytes))}`;
+
+const directoryOf = (path: string): string => {
+  const parts = path.split('/').filter(Boolean).slice(0, -1);
+  return `/${parts.slice(0, DIRECTORY_DEPTH).join('/')}`;
+};
+
+const bySizeDelta = (a: { sizeDelta: number; path: string }, b: typeof a): number =>
+  Math.abs(b.sizeDelta) - Math.abs(a.sizeDelta) || a.path.localeCompare(b.path);
+
+function changeReasons(before: ImageFileEntry, after: ImageFileEntry): FileChangeReason[] {
+  const reasons: FileChangeReason[] = [];
+  if (before.type !== after.type) {
+    reasons.push('type');
+  } else if (
+    before.size !== after.size ||
+    (before.digest !== undefined && after.digest !== undefined && before.digest !== after.digest)
+  ) {
+    reasons.push('content');
+  }
+  if ((before.mode & 0o7777) !== (after.mode & 0o7777)) {
+    reasons.push('mode');
+  }
+  if (before.type === after.type && before.linkTarget !== after.linkTarget) {
+    reasons.push('link');
+  }
+  return reasons;
+}
+
+/**
+ * Classify every non-directory path as added, removed, changed or unchanged
+ *
+ * @param base - File tree of the older image
+ * @param target - File tree of the newer image
+ */
+export function diffFilesystems(
+  base: ImageFilesystem,
+  target: ImageFilesystem,
+  options: DiffFilesystemsOptions = {},
+): FilesystemDiff {
+  const { maxEntries = DEFAULT_MAX_ENTRIES } = options;
+  const added: FileChange[] = [];
+  const removed: FileChange[] = [];
+  const changed: FileChange[] = [];
+  let unchanged = 0;
+
+  for (const [path, after] of target) {
+    if (after.type === 'directory') continue;
+
+    const before = base.get(path);
+    if (!before || before.type === 'directory') {
+      added.push({ path, type: after.type, size: after.size, sizeDelta: after.size });
+      continue;
+    }
+    const reasons = changeReasons(before, after);
+    if (reasons.length === 0) {
+      unchanged++;
+      continue;
+    }
+    changed.push({
+      path,
+      type: after.type,
+      size: after.size,
+      previousSize: before.size,
+      sizeDelta: after.size - before.size,
+      reasons,
+    });
+  }
+
+  for (const [path, before] of base) {
+    if (before.type === 'directory') continue;
+
+    const after = target.get(path);
+    if (!after || after.type === 'directory') {
+      removed.push({
+        path,
+        type: before.type,
+        size: 0,
+        previousSize: before.size,
+        sizeDelta: -before.size,
+      });
+    }
+  }
+
+  const directories = new Map<string, DirectoryChange>();
+  const rollUp = (list: FileChange[], kind: 'added' | 'removed' | 'changed'): void => {
+    for (const change of list) {
+      const path = directoryOf(change.path);
+      const directory = directories.get(path) ?? {
+        path,
+        added: 0,
+        removed: 0,
+        changed: 0,
+        sizeDelta: 0,
+      };
+      directory[kind]++;
+      directory.sizeDelta += change.sizeDelta;
+      directories.set(path, directory);
+    }
+  };
+  rollUp(added, 'added');
+  rollUp(removed, 'removed');
+  rollUp(changed, 'changed');
+
+  const sizeDelta = [...added, ...removed, ...changed].reduce(
+    (sum, change) => sum + change.sizeDelta,
+    0,
+  );
+
+  return {
+    added: added.sort(bySizeDelta).slice(0, maxEntries),
+    removed: removed.sort(bySizeDelta).slice(0, maxEntries),
+    changed: changed.sort(bySizeDelta).slice(0, maxEntries),
+    counts: { added: added.length, removed: removed.length, changed: changed.length, unchanged },
+    sizeDelta,
+    directories: [...directories.values()].sort(bySizeDelta).slice(0, maxEntries),
+    truncated: Math.max(added.length, removed.length, changed.length) > maxEntries,
+  };
+}
+
+const uniqueVersions = (packages: SbomPackage[]): string[] =>
+  [...new Set(packages.map((pkg) => pkg.version))].sort();
+
+/**
+ * Compare two package inventories from parseSbomPackages
+ *
+ * A package installed in several versions (e.g. nested npm dependencies)
+ * counts as changed when its set of versions differs.
+ */
+export function diffPackages(
+  base: Map<string, SbomPackage[]>,
+  target: Map<string, SbomPackage[]>,
+): PackageDiff {
+  const diff: PackageDiff = { added: [], removed: [], changed: [] };
+
+  for (const [key, after] of target) {
+    const before = base.get(key);
+    if (!before) {
+      diff.added.push(...after);
+      continue;
+    }
+    const previousVersions = uniqueVersions(before);
+    const versions = uniqueVersions(after);
+    if (previousVersions.join('\n') !== versions.join('\n')) {
+      const type = after[0]?.type;
+      diff.changed.push({
+        name: after[0]?.name ?? key,
+        ...(type && { type }),
+        previousVersions,
+        versions,
+      });
+    }
+  }
+  for (const [key, before] of base) {
+    if (!target.has(key)) {
+      diff.removed.push(...before);
+    }
+  }
+
+  const byName = (a: { name: string }, b: { name: string }): number => a.name.localeCompare(b.name);
+  diff.added.sort(byName);
+  diff.removed.sort(byName);
+  diff.changed.sort(byName);
+  return diff;
+}
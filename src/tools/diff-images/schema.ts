@@ -0,0 +1,27 @@
+/**
+ * Schema definition for diff-images tool
+ */
+
+import { z } from 'zod';
+
+export const diffImagesSchema = z.object({
+  baseImage: z.string().min(1).describe('Older image to compare from, e.g. myapp:1.0'),
+  targetImage: z.string().min(1).describe('Newer image to compare to, e.g. myapp:1.1'),
+  maxEntries: z
+    .number()
+    .int()
+    .min(1)
+    .max(500)
+    .optional()
+    .describe('Maximum files listed per change type, largest first (default 50)'),
+  baseSbomPath: z
+    .string()
+    .optional()
+    .describe('CycloneDX or SPDX JSON SBOM of the base image; generated with syft if omitted'),
+  targetSbomPath: z
+    .string()
+    .optional()
+    .describe('CycloneDX or SPDX JSON SBOM of the target image; generated with syft if omitted'),
+});
+
+export type DiffImagesParams = z.infer<typeof diffImagesSchema>;
@@ -0,0 +1,220 @@
+/**
+ * Diff Images Tool
+ *
+ * Explains why an image grew (or shrank) between two versions. Both images
+ * are exported with `docker save` and their layers replayed to get the final
+ * file trees, which are compared path by path. When SBOMs are available
+ * (given as files, or generated with syft) package additions, removals and
+ * version changes are reported too.
+ *
+ * This is a deterministic operational tool with no AI calls.
+ *
+ * @example
+ * ```typescript
+ * const result = await diffImages({ baseImage: 'myapp:1.0', targetImage: 'myapp:1.1' }, context);
+ * ```
+ */
+
+import { readFile } from 'node:fs/promises';
+import type { Logger } from 'pino';
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { extractErrorMessage } from '@/lib/errors';
+import { createDockerClient, type DockerClient } from '@/infra/docker/client';
+import { readImageFilesystem, type ImageFilesystem } from '@/infra/docker/image-filesystem';
+import {
+  createSyftGenerator,
+  parseSbomPackages,
+  type SbomGenerator,
+  type SbomPackage,
+} from '@/infra/security/sbom';
+import { formatSize, pluralize } from '@/lib/summary-helpers';
+import type { ToolContext } from '@/mcp/context';
+import { Failure, Success, type Result } from '@/types';
+import { tool } from '@/types/tool';
+import {
+  diffFilesystems,
+  diffPackages,
+  formatSizeDelta,
+  type FilesystemDiff,
+  type PackageDiff,
+} from './image-diff';
+import { diffImagesSchema, type DiffImagesParams } from './schema';
+
+export interface DiffImagesResult extends FilesystemDiff {
+  /**
+   * Natural language summary for user display.
+   * @example "✅ Compared myapp:1.0 → myapp:1.1: +42MB (120MB → 162MB). 1204 files added, 3 removed, 17 changed."
+   */
+  summary: string;
+  baseImage: string;
+  targetImage: string;
+  /** Image sizes in bytes as reported by Docker */
+  baseSize: number;
+  targetSize: number;
+  imageSizeDelta: number;
+  /** Package changes, when SBOMs were available for both images */
+  packages?: PackageDiff;
+  warnings?: string[];
+}
+
+interface LoadedImage {
+  size: number;
+  filesystem: ImageFilesystem;
+}
+
+async function loadImage(
+  dockerClient: DockerClient,
+  imageRef: string,
+): Promise<Result<LoadedImage>> {
+  const info = await dockerClient.inspectImage(imageRef);
+  if (!info.ok) {
+    return Failure(`Failed to inspect ${imageRef}: ${info.error}`, info.guidance);
+  }
+  const archive = await dockerClient.saveImage(imageRef);
+  if (!archive.ok) {
+    return Failure(`Failed to export ${imageRef}: ${archive.error}`, archive.guidance);
+  }
+  const filesystem = await readImageFilesystem(archive.value);
+  if (!filesystem.ok) {
+    return Failure(`Failed to read ${imageRef}: ${filesystem.error}`, filesystem.guidance);
+  }
+  return Success({ size: info.value.Size ?? 0, filesystem: filesystem.value });
+}
+
+/**
+ * Packages of an image from the given SBOM file, or from syft when installed
+ *
+ * Returns undefined when no SBOM is available; generation problems are
+ * recorded as warnings since the file diff is still useful on its own.
+ */
+async function loadPackages(
+  imageRef: string,
+  sbomPath: string | undefined,
+  generator: SbomGenerator,
+  warnings: string[],
+  logger: Logger,
+): Promise<Result<Map<string, SbomPackage[]> | undefined>> {
+  if (sbomPath) {
+    try {
+      return Success(parseSbomPackages(await readFile(sbomPath, 'utf-8')));
+    } catch (error) {
+      return Failure(`Failed to read SBOM ${sbomPath}: ${extractErrorMessage(error)}`, {
+        message: 'SBOM could not be read',
+        hint: 'The SBOM must be a CycloneDX or SPDX JSON document',
+        resolution: 'Check the path, or omit it to generate the SBOM with syft',
+        details: { sbomPath },
+      });
+    }
+  }
+
+  if (!(await generator.isAvailable())) {
+    return Success(undefined);
+  }
+  const document = await generator.generate(imageRef, 'cyclonedx');
+  if (!document.ok) {
+    logger.warn({ imageRef, error: document.error }, 'SBOM generation failed');
+    warnings.push(`Could not generate an SBOM for ${imageRef}: ${document.error}`);
+    return Success(undefined);
+  }
+  return Success(parseSbomPackages(document.value));
+}
+
+async function handleDiffImages(
+  input: DiffImagesParams,
+  ctx: ToolContext,
+): Promise<Result<DiffImagesResult>> {
+  const { logger, timer } = setupToolContext(ctx, 'diff-images');
+  const { baseImage, targetImage } = input;
+
+  try {
+    const dockerClient = createDockerClient(logger);
+    const daemon = await dockerClient.ping();
+    if (!daemon.ok) return daemon;
+
+    const base = await loadImage(dockerClient, baseImage);
+    if (!base.ok) return base;
+    const target = await loadImage(dockerClient, targetImage);
+    if (!target.ok) return target;
+
+    const diff = diffFilesystems(base.value.filesystem, target.value.filesystem, {
+      ...(input.maxEntries && { maxEntries: input.maxEntries }),
+    });
+
+    const warnings: string[] = [];
+    const generator = createSyftGenerator(logger);
+    const basePackages = await loadPackages(
+      baseImage,
+      input.baseSbomPath,
+      generator,
+      warnings,
+      logger,
+    );
+    if (!basePackages.ok) return basePackages;
+    const targetPackages = await loadPackages(
+      targetImage,
+      input.targetSbomPath,
+      generator,
+      warnings,
+      logger,
+    );
+    if (!targetPackages.ok) return targetPackages;
+
+    const packages =
+      basePackages.value && targetPackages.value
+        ? diffPackages(basePackages.value, targetPackages.value)
+        : undefined;
+    if (!packages) {
+      warnings.push('Package diff skipped: install syft or provide SBOMs for both images');
+    }
+
+    const imageSizeDelta = target.value.size - base.value.size;
+    const { counts } = diff;
+    let summary = `✅ Compared ${baseImage} → ${targetImage}: ${formatSizeDelta(imageSizeDelta)} (${formatSize(base.value.size)} → ${formatSize(target.value.size)}).`;
+    summary += ` ${pluralize(counts.added, 'file')} added, ${counts.removed} removed, ${counts.changed} changed.`;
+    const largest = diff.directories[0];
+    if (largest && largest.sizeDelta !== 0) {
+      summary += ` Largest change: ${largest.path} (${formatSizeDelta(largest.sizeDelta)}).`;
+    }
+    if (packages) {
+      summary += ` Packages: ${packages.added.length} added, ${packages.removed.length} removed, ${packages.changed.length} changed.`;
+    }
+
+    logger.info(
+      { baseImage, targetImage, imageSizeDelta, ...counts, packageDiff: !!packages },
+      'Image diff complete',
+    );
+    timer.end({ ...counts, imageSizeDelta });
+
+    return Success({
+      summary,
+      baseImage,
+      targetImage,
+      baseSize: base.value.size,
+      targetSize: target.value.size,
+      imageSizeDelta,
+      ...diff,
+      ...(packages && { packages }),
+      ...(warnings.length > 0 && { warnings }),
+    });
+  } catch (error) {
+    timer.error(error);
+    return Failure(extractErrorMessage(error), {
+      message: extractErrorMessage(error),
+      hint: 'An unexpected error occurred while comparing the images',
+      resolution: 'Verify that Docker is running and both images exist locally (`docker images`)',
+    });
+  }
+}
+
+export default tool({
+  name: 'diff-images',
+  description:
+    'Compare two images: files added, removed and changed, package changes from SBOMs, and the size delta',
+  category: 'docker',
+  version: '1.0.0',
+  schema: diffImagesSchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  handler: handleDiffImages,
+});
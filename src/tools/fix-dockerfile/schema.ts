@@ -18,6 +18,12 @@ export const fixDockerfileSchema = z
       .describe(
         'Optional path to specific policy file to use for organizational policy validation (defaults to all policies in policies/)',
       ),
+    secretRulesPath: z
+      .string()
+      .optional()
+      .describe(
+        'Optional path to a gitleaks-style TOML file with secret detection rules and allowlist for the no-secrets check (defaults to the built-in rules)',
+      ),
   })
   .refine((data) => data.dockerfile || data.path, {
     message: "Either 'dockerfile' content or 'path' must be provided",
@@ -22,6 +22,7 @@ import {
 import { CATEGORY } from '@/knowledge/types';
 import { createKnowledgeTool, createSimpleCategorizer } from '../shared/knowledge-tool-pattern';
 import { validateDockerfileContent } from '@/validation/dockerfile-validator';
+import { loadSecretRules, type SecretRuleset } from '@/validation/secret-rules';
 import { ValidationCategory, ValidationSeverity } from '@/validation/core-types';
 import type { z } from 'zod';
 import { readDockerfile } from '@/lib/file-utils';
@@ -316,10 +317,21 @@ async function handleFixDockerfile(
 
   const content = contentResult.value;
 
+  let secretRules: SecretRuleset | undefined;
+  if (input.secretRulesPath) {
+    const rulesResult = await loadSecretRules(input.secretRulesPath);
+    if (!rulesResult.ok) {
+      return rulesResult;
+    }
+    secretRules = rulesResult.value;
+  }
+
   logger.info({ preview: content.substring(0, 100) }, 'Validating Dockerfile for issues');
 
   const validationReport = await validateDockerfileContent(content, {
     enableExternalLinter: true,
+    ...(secretRules && { secretRules }),
+    ...(input.path !== undefined && { path: input.path }),
   });
 
   const validationIssues: ValidationIssue[] = validationReport.results
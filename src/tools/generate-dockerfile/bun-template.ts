@@ -0,0 +1,110 @@
+/**
+ * Bun Dockerfile Template
+ *
+ * Renders a multi-stage Dockerfile on the official oven/bun image:
+ * - a deps stage copies package.json and the Bun lockfile and runs
+ *   `bun install --frozen-lockfile --production`, so dependencies are cached
+ *   in their own layer and the lockfile is enforced
+ * - the runtime stage receives node_modules and the sources and runs the
+ *   entry point directly with bun as the image's bun user
+ *
+ * The entry point is read from the package.json start script, module or
+ * main field.
+ */
+
+import { Success, Failure, type Result } from '@/types';
+
+/** Bun release used when the project does not pin one */
+export const DEFAULT_BUN_VERSION = '1.1';
+
+/** Lockfiles written by Bun: binary before 1.2, text since */
+export const BUN_LOCKFILES: readonly string[] = ['bun.lockb', 'bun.lock'];
+
+const DEFAULT_PORT = 3000;
+
+const ENTRY_POINT = /^[\w./-]+\.(?:ts|tsx|js|jsx|mjs|cjs)$/;
+
+interface PackageManifest {
+  main?: string;
+  module?: string;
+  scripts?: Record<string, string>;
+}
+
+export interface BunBuildTarget {
+  /** File run with `bun`, or undefined to run the start script */
+  entryPoint?: string;
+  lockFile: string;
+  bunVersion?: string;
+}
+
+/**
+ * Decide what to run from package.json and the lockfiles present
+ *
+ * @param packageJson - Content of package.json
+ * @param files - Names of the files present in the module directory
+ */
+export function resolveBunBuildTarget(
+  packageJson: string,
+  files: string[],
+  bunVersion?: string,
+): Result<BunBuildTarget> {
+  const lockFile = BUN_LOCKFILES.find((file) => files.includes(file));
+  if (!lockFile) {
+    return Failure('No Bun lockfile found', {
+      message: 'Missing Bun lockfile',
+      hint: 'The template installs dependencies with --frozen-lockfile',
+      resolution: 'Run `bun install` and commit the generated lockfile',
+    });
+  }
+
+  const manifest = JSON.parse(packageJson) as PackageManifest;
+  const start = manifest.scripts?.start;
+  // "bun run src/index.ts" or "bun src/index.ts"; other start scripts run as-is
+  const fromScript = start?.split(/\s+/).find((arg) => ENTRY_POINT.test(arg));
+  const entryPoint = start ? fromScript : (manifest.module ?? manifest.main);
+  if (!entryPoint && !start) {
+    return Failure('Could not determine the Bun entry point', {
+      message: 'No Bun entry point',
+      hint: 'package.json has no start script, module or main field',
+      resolution: 'Add a "start" script such as "bun run src/index.ts" to package.json',
+    });
+  }
+
+  return Success({
+    ...(entryPoint && { entryPoint }),
+    lockFile,
+    ...(bunVersion && { bunVersion }),
+  });
+}
+
+/**
+ * Render the multi-stage Dockerfile for a resolved Bun build target
+ */
+export function renderBunDockerfile(
+  target: BunBuildTarget,
+  options: { port?: number | undefined } = {},
+): string {
+  const version = /^\d+(\.\d+){0,2}$/.test(target.bunVersion ?? '')
+    ? target.bunVersion
+    : DEFAULT_BUN_VERSION;
+  const command = target.entryPoint
+    ? `["bun", "run", "${target.entryPoint}"]`
+    : '["bun", "run", "start"]';
+
+  return `# syntax=docker/dockerfile:1
+FROM oven/bun:${version}-slim AS deps
+WORKDIR /app
+# Dependencies only; this layer is reused until package.json/${target.lockFile} change
+COPY package.json ${target.lockFile} ./
+RUN bun install --frozen-lockfile --production
+
+FROM oven/bun:${version}-slim AS runtime
+WORKDIR /app
+ENV NODE_ENV=production
+COPY --from=deps /app/node_modules ./node_modules
+COPY . .
+USER bun
+EXPOSE ${options.port ?? DEFAULT_PORT}
+CMD ${command}
+`;
+}
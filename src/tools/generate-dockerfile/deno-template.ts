@@ -0,0 +1,159 @@
+/**
+ * Deno Dockerfile Template
+ *
+ * Renders a Dockerfile on the official denoland/deno image:
+ * - deno.json and deno.lock are copied first and `deno install --frozen`
+ *   populates the module cache in its own layer, so dependencies are only
+ *   fetched again when the lockfile changes
+ * - the entry point is cached at build time and run with `--cached-only`, so
+ *   the container never downloads code at startup
+ * - the app runs as the image's deno user with explicit permission flags
+ *   instead of `--allow-all`
+ *
+ * The entry point and permissions are read from the `start` task in deno.json.
+ */
+
+import { Success, Failure, type Result } from '@/types';
+
+/** Deno release used when the project does not pin one */
+export const DEFAULT_DENO_VERSION = '2.1.4';
+
+/** Permissions granted when the start task does not list any */
+export const DEFAULT_DENO_PERMISSIONS: readonly string[] = [
+  '--allow-net',
+  '--allow-env',
+  '--allow-read=.',
+];
+
+/** Entry points tried, in order, when deno.json has no start task */
+export const DENO_ENTRY_POINTS: readonly string[] = [
+  'main.ts',
+  'src/main.ts',
+  'server.ts',
+  'mod.ts',
+];
+
+const DEFAULT_PORT = 8000;
+
+const ENTRY_POINT = /^[\w./-]+\.(?:ts|tsx|js|jsx|mjs)$/;
+
+/**
+ * What the template needs to know about a deno.json start task
+ */
+export interface DenoStartTask {
+  entryPoint?: string;
+  /** `--allow-*` and `--deny-*` flags, without `--allow-all` */
+  permissions: string[];
+}
+
+/**
+ * Parse the `start` task of deno.json or deno.jsonc, e.g.
+ * `deno run --allow-net --allow-env=PORT main.ts`
+ */
+export function parseDenoStartTask(content: string): DenoStartTask {
+  const config = JSON.parse(
+    content.replace(/\/\*[\s\S]*?\*\//g, '').replace(/^\s*\/\/.*$/gm, ''),
+  ) as { tasks?: Record<string, string | { command?: string }> };
+  const start = config.tasks?.start;
+  const command = typeof start === 'string' ? start : (start?.command ?? '');
+  const args = command.split(/\s+/).filter(Boolean);
+
+  const entryPoint = args.find((arg) => ENTRY_POINT.test(arg));
+  return {
+    ...(entryPoint && { entryPoint }),
+    // -A and --allow-all are dropped so the template falls back to explicit defaults
+    permissions: args.filter(
+      (arg) => /^--(allow|deny)-[\w-]+/.test(arg) && !arg.startsWith('--allow-all'),
+    ),
+  };
+}
+
+export interface DenoBuildTarget {
+  entryPoint: string;
+  permissions: string[];
+  /** Files copied before `deno install`, e.g. deno.json and deno.lock */
+  manifestFiles: string[];
+  /** Whether deno.lock exists, so installs and runs can be frozen */
+  locked: boolean;
+  denoVersion?: string;
+}
+
+/**
+ * Decide what to run from the start task and the files present in the module
+ *
+ * @param task - Parsed start task, when deno.json has one
+ * @param files - Names of the Deno files present in the module directory
+ */
+export function resolveDenoBuildTarget(
+  task: DenoStartTask | undefined,
+  files: string[],
+  denoVersion?: string,
+): Result<DenoBuildTarget> {
+  const entryPoint = task?.entryPoint ?? DENO_ENTRY_POINTS.find((file) => files.includes(file));
+  if (!entryPoint) {
+    return Failure('Could not determine the Deno entry point', {
+      message: 'No Deno entry point',
+      hint: `deno.json has no start task and none of ${DENO_ENTRY_POINTS.join(', ')} exist`,
+      resolution: 'Add a "start" task such as "deno run --allow-net main.ts" to deno.json',
+    });
+  }
+
+  const configFile = ['deno.json', 'deno.jsonc'].find((file) => files.includes(file));
+  const locked = files.includes('deno.lock');
+  const permissions =
+    task && task.permissions.length > 0 ? task.permissions : [...DEFAULT_DENO_PERMISSIONS];
+
+  return Success({
+    entryPoint,
+    permissions,
+    manifestFiles: [...(configFile ? [configFile] : []), ...(locked ? ['deno.lock'] : [])],
+    locked,
+    ...(denoVersion && { denoVersion }),
+  });
+}
+
+/**
+ * denoland/deno publishes full version tags only, so "2.1" becomes "2.1.0"
+ */
+function denoImageTag(version: string | undefined): string {
+  const match = /^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?$/.exec(version ?? '');
+  if (!match) return DEFAULT_DENO_VERSION;
+  const [, major, minor = '0', patch = '0'] = match;
+  return `${major}.${minor}.${patch}`;
+}
+
+/**
+ * Render the Dockerfile for a resolved Deno build target
+ */
+export function renderDenoDockerfile(
+  target: DenoBuildTarget,
+  options: { port?: number | undefined } = {},
+): string {
+  const frozen = target.locked ? ' --frozen' : '';
+  const runArgs = [
+    'run',
+    ...target.permissions,
+    ...(target.locked ? ['--frozen'] : []),
+    '--cached-only',
+    target.entryPoint,
+  ];
+  const dependencies =
+    target.manifestFiles.length > 0
+      ? `# Dependencies only; this layer is reused until ${target.manifestFiles.join('/')} change
+COPY ${target.manifestFiles.join(' ')} ./
+RUN deno install${frozen}
+`
+      : '';
+
+  return `# syntax=docker/dockerfile:1
+FROM denoland/deno:${denoImageTag(target.denoVersion)}
+WORKDIR /app
+# Deno may write node_modules next to deno.json; sources stay owned by root
+RUN chown deno:deno /app
+USER deno
+${dependencies}COPY . .
+RUN deno install${frozen} --entrypoint ${target.entryPoint}
+EXPOSE ${options.port ?? DEFAULT_PORT}
+CMD ["${runArgs.join('", "')}"]
+`;
+}
@@ -0,0 +1,174 @@
+/**
+ * Rust Dockerfile Template
+ *
+ * Renders a multi-stage Dockerfile for Cargo projects:
+ * - chef/planner/builder stages use cargo-chef so dependencies are compiled
+ *   in their own cached layer and only rebuilt when Cargo manifests change
+ * - a slim Debian runtime stage receives just the release binary and runs it
+ *   as a non-root user
+ *
+ * The binary name is read from Cargo.toml. Workspaces need a target crate
+ * so the template knows which member to build.
+ */
+
+import * as toml from '@iarna/toml';
+import { Success, Failure, type Result } from '@/types';
+
+/** Rust toolchain used when the project does not pin one */
+export const DEFAULT_RUST_VERSION = '1.83';
+
+const DEFAULT_PORT = 8080;
+
+interface CargoManifest {
+  package?: { name?: string; 'rust-version'?: string };
+  bin?: Array<{ name?: string; path?: string }>;
+  workspace?: { members?: string[] };
+}
+
+/**
+ * What the template needs to know about a Cargo manifest
+ */
+export interface CargoProject {
+  /** Package name, absent for virtual workspace manifests */
+  packageName?: string;
+  /** Binary targets declared with [[bin]], in declaration order */
+  binaries: string[];
+  /** Workspace member paths or globs (e.g. "crates/*") */
+  workspaceMembers: string[];
+  /** Minimum supported Rust version from `package.rust-version` */
+  rustVersion?: string;
+}
+
+/**
+ * Parse the parts of a Cargo.toml relevant for containerizing
+ */
+export function parseCargoProject(content: string): CargoProject {
+  const manifest = toml.parse(content) as CargoManifest;
+
+  return {
+    ...(manifest.package?.name && { packageName: manifest.package.name }),
+    binaries: (manifest.bin ?? [])
+      .map((bin) => bin.name)
+      .filter((name): name is string => Boolean(name)),
+    workspaceMembers: manifest.workspace?.members ?? [],
+    ...(manifest.package?.['rust-version'] && {
+      rustVersion: manifest.package['rust-version'],
+    }),
+  };
+}
+
+/**
+ * Candidate manifest paths for a crate in a workspace, relative to the root
+ *
+ * Explicit members match on their last path segment; single-level globs
+ * such as "crates/*" expand to "crates/<crate>".
+ */
+export function workspaceMemberPaths(members: string[], crate: string): string[] {
+  return members.flatMap((member) => {
+    if (member.endsWith('/*')) return [`${member.slice(0, -1)}${crate}`];
+    return member.split('/').pop() === crate ? [member] : [];
+  });
+}
+
+export interface RustBuildTarget {
+  /** Binary produced by `cargo build --release` */
+  binaryName: string;
+  /** Workspace member to build with `--package`, when building a workspace */
+  crate?: string;
+  rustVersion?: string;
+}
+
+/**
+ * Decide what to build from the root manifest and, for workspaces, the
+ * manifest of the requested member crate
+ *
+ * @param root - Parsed root Cargo.toml
+ * @param member - Parsed Cargo.toml of the target crate, for workspaces
+ * @param targetCrate - Crate requested by the user
+ */
+export function resolveRustBuildTarget(
+  root: CargoProject,
+  member?: CargoProject,
+  targetCrate?: string,
+): Result<RustBuildTarget> {
+  const isWorkspace = root.workspaceMembers.length > 0;
+
+  if (isWorkspace && !targetCrate && !root.packageName) {
+    return Failure('Cargo workspace detected; the crate to build must be specified', {
+      message: 'Ambiguous Rust build target',
+      hint: `Workspace members: ${root.workspaceMembers.join(', ')}`,
+      resolution: 'Call generate-dockerfile again with targetCrate set to the binary crate to containerize',
+      details: { workspaceMembers: root.workspaceMembers },
+    });
+  }
+
+  const project = isWorkspace && targetCrate ? member : root;
+  if (!project) {
+    return Failure(`Crate ${targetCrate} not found in the Cargo workspace`, {
+      message: 'Unknown Rust crate',
+      hint: `Workspace members: ${root.workspaceMembers.join(', ')}`,
+      resolution: 'Set targetCrate to the package name of a workspace member',
+      details: { targetCrate, workspaceMembers: root.workspaceMembers },
+    });
+  }
+
+  const binaryName = project.binaries[0] ?? project.packageName;
+  if (!binaryName) {
+    return Failure('Could not determine the Rust binary name from Cargo.toml', {
+      message: 'No binary target',
+      hint: 'Cargo.toml has neither a package name nor a [[bin]] target',
+      resolution: 'Add a [package] name or a [[bin]] section to Cargo.toml',
+    });
+  }
+
+  const rustVersion = project.rustVersion ?? root.rustVersion;
+  return Success({
+    binaryName,
+    ...(isWorkspace && targetCrate && { crate: project.packageName ?? targetCrate }),
+    ...(rustVersion && { rustVersion }),
+  });
+}
+
+/**
+ * Render the multi-stage Dockerfile for a resolved Rust build target
+ */
+export function renderRustDockerfile(
+  target: RustBuildTarget,
+  options: { port?: number | undefined } = {},
+): string {
+  // Cargo editions ("2021") are not toolchain versions
+  const rustVersion = /^\d+\.\d+/.test(target.rustVersion ?? '')
+    ? target.rustVersion
+    : DEFAULT_RUST_VERSION;
+  const packageFlag = target.crate ? ` --package ${target.crate}` : '';
+  const binary = target.binaryName;
+
+  return `# syntax=docker/dockerfile:1
+FROM rust:${rustVersion}-slim-bookworm AS chef
+RUN cargo install cargo-chef --locked
+WORKDIR /app
+
+FROM chef AS planner
+COPY . .
+RUN cargo chef prepare --recipe-path recipe.json
+
+FROM chef AS builder
+COPY --from=planner /app/recipe.json recipe.json
+# Dependencies only; this layer is reused until Cargo.toml/Cargo.lock change
+RUN cargo chef cook --release --recipe-path recipe.json${packageFlag}
+COPY . .
+RUN cargo build --release${packageFlag} --bin ${binary}
+
+FROM debian:bookworm-slim AS runtime
+RUN apt-get update \\
+    && apt-get install -y --no-install-recommends ca-certificates \\
+    && rm -rf /var/lib/apt/lists/* \\
+    && groupadd --system app \\
+    && useradd --system --gid app --no-create-home app
+WORKDIR /app
+COPY --from=builder /app/target/release/${binary} /usr/local/bin/${binary}
+USER app
+EXPOSE ${options.port ?? DEFAULT_PORT}
+ENTRYPOINT ["/usr/local/bin/${binary}"]
+`;
+}
@@ -5,6 +5,7 @@
 import { z } from 'zod';
 import { environment, repositoryPath, type ToolNextAction } from '../shared/schemas';
 import { ModuleInfo } from '../analyze-repo/schema';
+import { PACKAGE_MANAGERS, type PackageManager } from '../analyze-repo/package-managers';
 import type { PolicyValidationResult } from '@/lib/policy-helpers';
 
 /**
@@ -39,8 +40,26 @@ export const generateDockerfileSchema = z.object({
   languageVersion: z
     .string()
     .optional()
-    .describe('Language version (e.g., "17", "3.11", "20"). For Java, this is the java.version from pom.xml or sourceCompatibility from build.gradle.'),
+    .describe('Language version (e.g., "17", "3.11", "20"). Use runtime.version from analyze-repo, which resolves manifest constraints such as engines.node ">=18" to a base image version.'),
   framework: z.string().optional().describe('Framework used (e.g., "spring", "django")'),
+  targetCrate: z
+    .string()
+    .optional()
+    .describe(
+      'Rust only: workspace member crate to build when the repository is a Cargo workspace (e.g., "api-server").',
+    ),
+  packageManager: z
+    .enum(PACKAGE_MANAGERS)
+    .optional()
+    .describe(
+      'Package manager detected by analyze-repo from the lockfile (e.g., "pnpm"). Determines the dependency install commands recommended for the Dockerfile; "deno" and "bun" also select a Deno or Bun template instead of a Node.js one.',
+    ),
+  ports: z
+    .array(z.number())
+    .optional()
+    .describe(
+      'Listen ports detected by analyze-repo, most likely first. The first port is used for EXPOSE.',
+    ),
   environment: environment.describe('Target environment (production, development, etc.)'),
   detectedDependencies: z
     .array(z.string())
@@ -122,6 +141,32 @@ export interface EnhancementGuidance {
   strategy: 'minor-tweaks' | 'moderate-refactor' | 'major-overhaul';
 }
 
+/**
+ * Dependency install step matching the project's package manager
+ */
+export interface DependencyInstallRecommendation {
+  packageManager: PackageManager;
+  /** Files to COPY before running the install command */
+  copyFiles: string[];
+  /** Command for the RUN instruction */
+  command: string;
+}
+
+/**
+ * Ready-to-use Dockerfile rendered from a language template
+ */
+export interface DockerfileTemplate {
+  /** Template that was rendered: rust, deno or bun */
+  language: string;
+  content: string;
+  /** Binary the Dockerfile builds and runs, for compiled languages */
+  binaryName?: string;
+  /** Workspace member the Dockerfile builds, when applicable */
+  crate?: string;
+  /** Script the runtime starts, for Deno and Bun; absent when Bun runs the start script */
+  entryPoint?: string;
+}
+
 export interface DockerfilePlan {
   /** Next action directive - provides explicit guidance on what files to create/update */
   nextAction: ToolNextAction;
@@ -131,6 +176,8 @@ export interface DockerfilePlan {
       multistage: boolean;
       reason: string;
     };
+    /** Install step for dependencies, present when the package manager is known */
+    dependencyInstall?: DependencyInstallRecommendation;
     /** Platform to use for building images (e.g., "linux/amd64", "linux/arm64") */
     platform?: DockerPlatform;
     /** Default tag to apply to built images */
@@ -149,6 +196,8 @@ export interface DockerfilePlan {
     guidance: EnhancementGuidance;
   };
   policyValidation?: PolicyValidationResult;
+  /** Rendered starting point for projects with a built-in template (Rust, Deno and Bun) */
+  dockerfileTemplate?: DockerfileTemplate;
   /**
    * @deprecated Use recommendations.baseImages, securityConsiderations, optimizations, and bestPractices instead
    */
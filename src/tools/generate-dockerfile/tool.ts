@@ -9,7 +9,8 @@
  */
 
 import { validatePathOrFail } from '@/lib/validation-helpers';
-import { Failure, type Result, TOPICS } from '@/types';
+import { Success, Failure, type Result, TOPICS } from '@/types';
+import { extractErrorMessage } from '@/lib/errors';
 import type { ToolContext } from '@/mcp/context';
 import {
   generateDockerfileSchema,
@@ -20,9 +21,37 @@ import {
   type DockerfileAnalysis,
   type EnhancementGuidance,
   type DockerPlatform,
+  type DockerfileTemplate,
   DOCKER_PLATFORMS,
 } from './schema';
+import {
+  parseCargoProject,
+  renderRustDockerfile,
+  resolveRustBuildTarget,
+  workspaceMemberPaths,
+  type CargoProject,
+} from './rust-template';
+import {
+  DENO_ENTRY_POINTS,
+  parseDenoStartTask,
+  renderDenoDockerfile,
+  resolveDenoBuildTarget,
+  type DenoStartTask,
+} from './deno-template';
+import {
+  BUN_LOCKFILES,
+  renderBunDockerfile,
+  resolveBunBuildTarget,
+  type BunBuildTarget,
+} from './bun-template';
 import type { ToolNextAction } from '../shared/schemas';
+import {
+  INSTALL_COMMANDS,
+  copyFileCandidates,
+  isJsRuntimeManager,
+  resolveCopyFiles,
+  type JsRuntimeManager,
+} from '../analyze-repo/package-managers';
 import { CATEGORY } from '@/knowledge/types';
 import { createKnowledgeTool, createSimpleCategorizer } from '../shared/knowledge-tool-pattern';
 import type { z } from 'zod';
@@ -86,6 +115,11 @@ interface ExtendedDockerfileParams extends GenerateDockerfileParams {
     analysis: DockerfileAnalysis;
     guidance: EnhancementGuidance;
   };
+  dockerfileTemplate?: DockerfileTemplate;
+  /** Why a language template could not be rendered, surfaced in the summary */
+  templateIssue?: string;
+  /** Dependency manifest and lockfile present in the module, copied before installing */
+  dependencyFiles?: string[];
 }
 
 /**
@@ -511,6 +545,22 @@ const runPattern = createKnowledgeTool<
       const modulePath = input.modulePath || path;
       const language = input.language || 'auto-detect';
       const framework = input.framework;
+      const dependencyInstall = input.packageManager && {
+        packageManager: input.packageManager,
+        copyFiles: input.dependencyFiles ?? INSTALL_COMMANDS[input.packageManager].copyFiles,
+        command: INSTALL_COMMANDS[input.packageManager].install,
+      };
+      const installInstruction = dependencyInstall
+        ? ` Install dependencies with \`${dependencyInstall.command}\` after copying ${dependencyInstall.copyFiles.join(' and ')}, as described in recommendations.dependencyInstall.`
+        : '';
+      const [port, ...otherPorts] = input.ports ?? [];
+      const portInstruction = port
+        ? ` Expose port ${port}${otherPorts.length > 0 ? ` (other detected candidates: ${otherPorts.join(', ')}; confirm which one the application listens on)` : ''}.`
+        : '';
+      const { dockerfileTemplate, templateIssue } = input;
+      const templateInstruction = dockerfileTemplate
+        ? ` Start from dockerfileTemplate.content, which already ${describeTemplate(dockerfileTemplate)}, and adjust it only where the recommendations require.`
+        : '';
 
       // Access existing Dockerfile info from extended input (added in run function)
       // Type is already ExtendedDockerfileParams, so no assertion needed
@@ -584,7 +634,7 @@ const runPattern = createKnowledgeTool<
       const nextAction: ToolNextAction = existingDockerfile
         ? {
             action: 'update-files',
-            instruction: `Update the existing Dockerfile at ${relativeDockerfilePath} by applying the enhancement recommendations. Preserve the items listed in existingDockerfile.guidance.preserve, make improvements from existingDockerfile.guidance.improve, and add missing features from existingDockerfile.guidance.addMissing. Use the base images, security considerations, optimizations, and best practices from recommendations.`,
+            instruction: `Update the existing Dockerfile at ${relativeDockerfilePath} by applying the enhancement recommendations. Preserve the items listed in existingDockerfile.guidance.preserve, make improvements from existingDockerfile.guidance.improve, and add missing features from existingDockerfile.guidance.addMissing. Use the base images, security considerations, optimizations, and best practices from recommendations.${installInstruction}${portInstruction}`,
             files: [
               {
                 path: relativeDockerfilePath,
@@ -594,7 +644,7 @@ const runPattern = createKnowledgeTool<
           }
         : {
             action: 'create-files',
-            instruction: `Create a new Dockerfile at ${relativeDockerfilePath} using the base images, security considerations, optimizations, and best practices from recommendations. Follow the ${rules.buildStrategy.multistage ? 'multi-stage' : 'single-stage'} build strategy described in recommendations.buildStrategy.`,
+            instruction: `Create a new Dockerfile at ${relativeDockerfilePath} using the base images, security considerations, optimizations, and best practices from recommendations. Follow the ${rules.buildStrategy.multistage ? 'multi-stage' : 'single-stage'} build strategy described in recommendations.buildStrategy.${installInstruction}${portInstruction}${templateInstruction}`,
             files: [
               {
                 path: relativeDockerfilePath,
@@ -612,6 +662,18 @@ const runPattern = createKnowledgeTool<
         optimizationMatches.length +
         bestPracticeMatches.length;
 
+      const packageManagerLine = dependencyInstall
+        ? `Package Manager: ${dependencyInstall.packageManager} (${dependencyInstall.command})\n`
+        : '';
+      const portLine = port
+        ? `Port: ${port}${otherPorts.length > 0 ? ` (candidates: ${input.ports?.join(', ')})` : ''}\n`
+        : '';
+      const templateLine = dockerfileTemplate
+        ? `Template: ${dockerfileTemplate.language} (${templateTarget(dockerfileTemplate)})\n`
+        : templateIssue
+          ? `Template: not rendered - ${templateIssue}\n`
+          : '';
+
       let summary: string;
       if (existingDockerfile) {
         const { analysis, guidance } = existingDockerfile;
@@ -622,6 +684,8 @@ const runPattern = createKnowledgeTool<
           `Environment: ${input.environment || 'production'}\n` +
           `Current State: ${analysis.complexity}, ${analysis.securityPosture} security, ${analysis.instructionCount} instructions\n` +
           `Strategy: ${rules.buildStrategy.multistage ? 'Multi-stage' : 'Single-stage'} build\n` +
+          packageManagerLine +
+          portLine +
           `Enhancement: ${guidance.strategy}\n` +
           `Changes: Preserve ${guidance.preserve.length} items, Improve ${guidance.improve.length} items, Add ${guidance.addMissing.length} missing items\n` +
           `Recommendations: ${totalRecommendations} total (${baseImageMatches.length} base images, ${securityMatches.length} security, ${optimizationMatches.length} optimizations, ${bestPracticeMatches.length} best practices)\n\n` +
@@ -633,6 +697,9 @@ const runPattern = createKnowledgeTool<
           `Language: ${language}${languageVersionStr}${frameworkStr}\n` +
           `Environment: ${input.environment || 'production'}\n` +
           `Strategy: ${rules.buildStrategy.multistage ? 'Multi-stage' : 'Single-stage'} build\n` +
+          packageManagerLine +
+          portLine +
+          templateLine +
           `Recommendations: ${totalRecommendations} total (${baseImageMatches.length} base images, ${securityMatches.length} security, ${optimizationMatches.length} optimizations, ${bestPracticeMatches.length} best practices)\n\n` +
           `✅ Ready to create Dockerfile based on recommendations.`;
       }
@@ -647,6 +714,7 @@ const runPattern = createKnowledgeTool<
               language: language === 'java' || language === 'dotnet' ? language : 'other',
             }),
           ...(input.languageVersion && { languageVersion: input.languageVersion }),
+          ...(input.packageManager && { packageManager: input.packageManager }),
           ...(framework &&
             framework !== 'auto-detect' && {
               frameworks: [{ name: framework }],
@@ -654,6 +722,7 @@ const runPattern = createKnowledgeTool<
         },
         recommendations: {
           buildStrategy: rules.buildStrategy,
+          ...(dependencyInstall && { dependencyInstall }),
           baseImages: baseImageMatches,
           securityConsiderations: securityMatches,
           optimizations: optimizationMatches,
@@ -661,6 +730,7 @@ const runPattern = createKnowledgeTool<
         },
         confidence,
         summary,
+        ...(dockerfileTemplate && { dockerfileTemplate }),
         ...(existingDockerfile && {
           existingDockerfile: {
             path: existingDockerfile.path,
@@ -674,6 +744,174 @@ const runPattern = createKnowledgeTool<
   },
 });
 
+/**
+ * What a rendered template builds and runs, for the next action instruction
+ */
+function describeTemplate(template: DockerfileTemplate): string {
+  if (template.binaryName) return `builds and runs the ${template.binaryName} binary`;
+  return template.entryPoint
+    ? `runs ${template.entryPoint} with ${template.language}`
+    : `runs the start script with ${template.language}`;
+}
+
+/**
+ * Short description of a rendered template's target for the summary
+ */
+function templateTarget(template: DockerfileTemplate): string {
+  if (template.binaryName) {
+    return `binary: ${template.binaryName}${template.crate ? `, crate: ${template.crate}` : ''}`;
+  }
+  return `entry point: ${template.entryPoint ?? 'start script'}`;
+}
+
+/**
+ * Deno or Bun when the project runs on that runtime rather than Node.js
+ */
+function jsRuntimeOf(input: GenerateDockerfileParams): JsRuntimeManager | undefined {
+  if (isJsRuntimeManager(input.packageManager)) return input.packageManager;
+  return isJsRuntimeManager(input.language) ? input.language : undefined;
+}
+
+/**
+ * Names of the files present in a module directory, among those given
+ */
+async function presentFiles(modulePath: string, fileNames: readonly string[]): Promise<string[]> {
+  const present: string[] = [];
+  for (const fileName of fileNames) {
+    try {
+      await fs.access(nodePath.join(modulePath, fileName));
+      present.push(fileName);
+    } catch {
+      // Not present
+    }
+  }
+  return present;
+}
+
+/**
+ * Read deno.json under the module path and render the Deno template
+ */
+async function loadDenoTemplate(
+  modulePath: string,
+  denoVersion?: string,
+  port?: number,
+): Promise<Result<DockerfileTemplate>> {
+  const files = await presentFiles(modulePath, [
+    'deno.json',
+    'deno.jsonc',
+    'deno.lock',
+    ...DENO_ENTRY_POINTS,
+  ]);
+  const configFile = files.find((file) => file.startsWith('deno.json'));
+
+  let task: DenoStartTask | undefined;
+  if (configFile) {
+    try {
+      const content = await fs.readFile(nodePath.join(modulePath, configFile), 'utf-8');
+      task = parseDenoStartTask(content);
+    } catch (error) {
+      return Failure(`Could not read ${configFile}: ${extractErrorMessage(error)}`, {
+        message: `${configFile} not readable`,
+        hint: `Expected valid JSON at ${nodePath.join(modulePath, configFile)}`,
+        resolution: `Fix the syntax of ${configFile}`,
+      });
+    }
+  }
+
+  const target = resolveDenoBuildTarget(task, files, denoVersion);
+  if (!target.ok) return target;
+
+  return Success({
+    language: 'deno',
+    content: renderDenoDockerfile(target.value, { port }),
+    entryPoint: target.value.entryPoint,
+  });
+}
+
+/**
+ * Read package.json under the module path and render the Bun template
+ */
+async function loadBunTemplate(
+  modulePath: string,
+  bunVersion?: string,
+  port?: number,
+): Promise<Result<DockerfileTemplate>> {
+  let packageJson: string;
+  try {
+    packageJson = await fs.readFile(nodePath.join(modulePath, 'package.json'), 'utf-8');
+  } catch (error) {
+    return Failure(`Could not read package.json: ${extractErrorMessage(error)}`, {
+      message: 'package.json not readable',
+      hint: `Expected a package manifest at ${nodePath.join(modulePath, 'package.json')}`,
+      resolution: 'Point modulePath at the directory containing package.json',
+    });
+  }
+
+  let target: Result<BunBuildTarget>;
+  try {
+    target = resolveBunBuildTarget(
+      packageJson,
+      await presentFiles(modulePath, BUN_LOCKFILES),
+      bunVersion,
+    );
+  } catch (error) {
+    return Failure(`Could not parse package.json: ${extractErrorMessage(error)}`, {
+      message: 'package.json is not valid JSON',
+      resolution: 'Fix the syntax of package.json',
+    });
+  }
+  if (!target.ok) return target;
+
+  return Success({
+    language: 'bun',
+    content: renderBunDockerfile(target.value, { port }),
+    ...(target.value.entryPoint && { entryPoint: target.value.entryPoint }),
+  });
+}
+
+/**
+ * Read Cargo manifests under the module path and render the Rust template
+ */
+async function loadRustTemplate(
+  modulePath: string,
+  targetCrate?: string,
+  port?: number,
+): Promise<Result<DockerfileTemplate>> {
+  let root: CargoProject;
+  try {
+    root = parseCargoProject(await fs.readFile(nodePath.join(modulePath, 'Cargo.toml'), 'utf-8'));
+  } catch (error) {
+    return Failure(`Could not read Cargo.toml: ${extractErrorMessage(error)}`, {
+      message: 'Cargo.toml not readable',
+      hint: `Expected a Cargo manifest at ${nodePath.join(modulePath, 'Cargo.toml')}`,
+      resolution: 'Point modulePath at the directory containing Cargo.toml',
+    });
+  }
+
+  let member: CargoProject | undefined;
+  if (targetCrate && root.workspaceMembers.length > 0) {
+    for (const memberPath of workspaceMemberPaths(root.workspaceMembers, targetCrate)) {
+      try {
+        const manifestPath = nodePath.join(modulePath, memberPath, 'Cargo.toml');
+        member = parseCargoProject(await fs.readFile(manifestPath, 'utf-8'));
+        break;
+      } catch {
+        // Try the next candidate member path
+      }
+    }
+  }
+
+  const target = resolveRustBuildTarget(root, member, targetCrate);
+  if (!target.ok) return target;
+
+  return Success({
+    language: 'rust',
+    content: renderRustDockerfile(target.value, { port }),
+    binaryName: target.value.binaryName,
+    ...(target.value.crate && { crate: target.value.crate }),
+  });
+}
+
 /**
  * Convert DockerfilePlan to pseudo-Dockerfile text for policy validation
  * This allows policy rules to match against the planned Dockerfile structure
@@ -916,10 +1154,39 @@ async function handleGenerateDockerfile(
     );
   }
 
+  // New Rust, Deno and Bun Dockerfiles get a rendered template as a starting point
+  let template: Result<DockerfileTemplate> | undefined;
+  const jsRuntime = jsRuntimeOf(input);
+  if (!existingDockerfile && input.language === 'rust') {
+    template = await loadRustTemplate(targetPath, input.targetCrate, input.ports?.[0]);
+  } else if (!existingDockerfile && jsRuntime === 'deno') {
+    template = await loadDenoTemplate(targetPath, input.languageVersion, input.ports?.[0]);
+  } else if (!existingDockerfile && jsRuntime === 'bun') {
+    template = await loadBunTemplate(targetPath, input.languageVersion, input.ports?.[0]);
+  }
+  if (template && !template.ok) {
+    ctx.logger.warn({ error: template.error }, 'Dockerfile template not rendered');
+  }
+
+  const dependencyFiles =
+    input.packageManager &&
+    resolveCopyFiles(
+      input.packageManager,
+      await presentFiles(targetPath, copyFileCandidates(input.packageManager)),
+    );
+
   // Add existing Dockerfile to input if found
   const extendedInput = {
     ...input,
+    ...(dependencyFiles && { dependencyFiles }),
     ...(existingDockerfile && { existingDockerfile }),
+    ...(template?.ok && { dockerfileTemplate: template.value }),
+    ...(template &&
+      !template.ok && {
+        templateIssue: template.guidance?.resolution
+          ? `${template.error}. ${template.guidance.resolution}`
+          : template.error,
+      }),
   };
 
   // Run the pattern to generate the plan
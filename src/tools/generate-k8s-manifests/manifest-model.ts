@@ -0,0 +1,479 @@
+/**
+ * Manifest Model and Renderers
+ *
+ * A single application model drives every concrete output format so raw
+ * manifests, Helm charts and Kustomize bases cannot drift apart: raw YAML is
+ * rendered from the model directly, the chart's values.yaml is the same model
+ * expressed as Helm values for fixed templates, and the Kustomize base reuses
+ * the raw resources.
+ */
+
+import yaml from 'js-yaml';
+
+/**
+ * CPU/memory requests and limits for the application container
+ */
+export interface ResourceSpec {
+  requests: { cpu: string; memory: string };
+  limits: { cpu: string; memory: string };
+}
+
+/**
+ * Format-independent description of the application to deploy
+ */
+export interface ManifestModel {
+  name: string;
+  image: { repository: string; tag: string };
+  replicas: number;
+  /** Container ports, exposed by the Service; the first is the one the Ingress routes to */
+  ports: number[];
+  resources: ResourceSpec;
+  env: Array<{ name: string; value: string }>;
+  /** Ingress host; no Ingress is emitted when omitted */
+  ingressHost?: string;
+}
+
+interface ResourceQuantitiesInput {
+  cpu?: string | undefined;
+  memory?: string | undefined;
+}
+
+export interface ManifestModelInput {
+  name: string;
+  image?: string | undefined;
+  replicas?: number | undefined;
+  ports?: number[] | undefined;
+  resources?:
+    | {
+        requests?: ResourceQuantitiesInput | undefined;
+        limits?: ResourceQuantitiesInput | undefined;
+      }
+    | undefined;
+  env?: Record<string, string> | undefined;
+  ingressHost?: string | undefined;
+}
+
+/**
+ * Per-environment adjustments applied on top of a Kustomize base
+ */
+export interface KustomizeOverlayInput {
+  replicas?: number | undefined;
+  resources?:
+    | {
+        requests?: ResourceQuantitiesInput | undefined;
+        limits?: ResourceQuantitiesInput | undefined;
+      }
+    | undefined;
+}
+
+/**
+ * A generated file, relative to the output root
+ */
+export interface GeneratedManifestFile {
+  path: string;
+  content: string;
+}
+
+const DEFAULT_PORT = 8080;
+const DEFAULT_RESOURCES: ResourceSpec = {
+  requests: { cpu: '100m', memory: '128Mi' },
+  limits: { cpu: '500m', memory: '512Mi' },
+};
+
+/**
+ * Split an image reference into repository and tag, defaulting the tag to latest
+ */
+function splitImage(image: string): { repository: string; tag: string } {
+  const lastColon = image.lastIndexOf(':');
+  if (lastColon > image.lastIndexOf('/')) {
+    return { repository: image.slice(0, lastColon), tag: image.slice(lastColon + 1) };
+  }
+  return { repository: image, tag: 'latest' };
+}
+
+/**
+ * Build the manifest model from tool input, filling in defaults
+ */
+export function buildManifestModel(input: ManifestModelInput): ManifestModel {
+  return {
+    name: input.name,
+    image: splitImage(input.image ?? `${input.name}:latest`),
+    replicas: input.replicas ?? 1,
+    ports: input.ports && input.ports.length > 0 ? [...new Set(input.ports)] : [DEFAULT_PORT],
+    resources: {
+      requests: {
+        cpu: input.resources?.requests?.cpu ?? DEFAULT_RESOURCES.requests.cpu,
+        memory: input.resources?.requests?.memory ?? DEFAULT_RESOURCES.requests.memory,
+      },
+      limits: {
+        cpu: input.resources?.limits?.cpu ?? DEFAULT_RESOURCES.limits.cpu,
+        memory: input.resources?.limits?.memory ?? DEFAULT_RESOURCES.limits.memory,
+      },
+    },
+    env: Object.entries(input.env ?? {}).map(([name, value]) => ({ name, value })),
+    ...(input.ingressHost && { ingressHost: input.ingressHost }),
+  };
+}
+
+function labels(model: ManifestModel): Record<string, string> {
+  return { 'app.kubernetes.io/name': model.name };
+}
+
+/**
+ * Port name, required by Kubernetes once a Service exposes more than one port
+ */
+function portName(model: ManifestModel, port: number): { name?: string } {
+  return model.ports.length > 1 ? { name: `port-${port}` } : {};
+}
+
+function containerPorts(model: ManifestModel): Array<Record<string, unknown>> {
+  return model.ports.map((port) => ({ ...portName(model, port), containerPort: port }));
+}
+
+function servicePorts(model: ManifestModel): Array<Record<string, unknown>> {
+  return model.ports.map((port) => ({ ...portName(model, port), port, targetPort: port }));
+}
+
+/** Port the Ingress routes to */
+function primaryPort(model: ManifestModel): number {
+  return model.ports[0] ?? DEFAULT_PORT;
+}
+
+/**
+ * Build the Kubernetes resources described by the model, in apply order
+ */
+export function buildManifestResources(model: ManifestModel): Record<string, unknown>[] {
+  const container: Record<string, unknown> = {
+    name: model.name,
+    image: `${model.image.repository}:${model.image.tag}`,
+    ports: containerPorts(model),
+    resources: model.resources,
+  };
+  if (model.env.length > 0) {
+    container.env = model.env;
+  }
+
+  const resources: Record<string, unknown>[] = [
+    {
+      apiVersion: 'apps/v1',
+      kind: 'Deployment',
+      metadata: { name: model.name, labels: labels(model) },
+      spec: {
+        replicas: model.replicas,
+        selector: { matchLabels: labels(model) },
+        template: {
+          metadata: { labels: labels(model) },
+          spec: { containers: [container] },
+        },
+      },
+    },
+    {
+      apiVersion: 'v1',
+      kind: 'Service',
+      metadata: { name: model.name, labels: labels(model) },
+      spec: {
+        selector: labels(model),
+        ports: servicePorts(model),
+      },
+    },
+  ];
+
+  if (model.ingressHost) {
+    resources.push({
+      apiVersion: 'networking.k8s.io/v1',
+      kind: 'Ingress',
+      metadata: { name: model.name, labels: labels(model) },
+      spec: {
+        rules: [
+          {
+            host: model.ingressHost,
+            http: {
+              paths: [
+                {
+                  path: '/',
+                  pathType: 'Prefix',
+                  backend: { service: { name: model.name, port: { number: primaryPort(model) } } },
+                },
+              ],
+            },
+          },
+        ],
+      },
+    });
+  }
+
+  return resources;
+}
+
+/**
+ * Render the model as raw Kubernetes manifests, one file per resource
+ */
+export function renderRawManifests(model: ManifestModel): GeneratedManifestFile[] {
+  return buildManifestResources(model).map((resource) => ({
+    path: `${String(resource.kind).toLowerCase()}.yaml`,
+    content: yaml.dump(resource, { noRefs: true }),
+  }));
+}
+
+const KUSTOMIZATION_HEADER = {
+  apiVersion: 'kustomize.config.k8s.io/v1beta1',
+  kind: 'Kustomization',
+};
+
+/**
+ * Drop undefined quantities so patches only touch what the overlay sets
+ */
+function definedQuantities(
+  quantities: ResourceQuantitiesInput | undefined,
+): Record<string, string> | undefined {
+  const defined = Object.fromEntries(
+    Object.entries(quantities ?? {}).filter(([, value]) => value !== undefined),
+  ) as Record<string, string>;
+  return Object.keys(defined).length > 0 ? defined : undefined;
+}
+
+/**
+ * Build a strategic-merge patch for the Deployment from an overlay, or
+ * undefined when the overlay changes nothing
+ */
+function buildOverlayPatch(
+  model: ManifestModel,
+  overlay: KustomizeOverlayInput,
+): Record<string, unknown> | undefined {
+  const requests = definedQuantities(overlay.resources?.requests);
+  const limits = definedQuantities(overlay.resources?.limits);
+  const hasResources = Boolean(requests || limits);
+
+  if (overlay.replicas === undefined && !hasResources) {
+    return undefined;
+  }
+
+  return {
+    apiVersion: 'apps/v1',
+    kind: 'Deployment',
+    metadata: { name: model.name },
+    spec: {
+      ...(overlay.replicas !== undefined && { replicas: overlay.replicas }),
+      ...(hasResources && {
+        template: {
+          spec: {
+            containers: [
+              {
+                name: model.name,
+                resources: { ...(requests && { requests }), ...(limits && { limits }) },
+              },
+            ],
+          },
+        },
+      }),
+    },
+  };
+}
+
+/**
+ * Render the model as a Kustomize base plus optional environment overlays
+ *
+ * @param overlays - Overlay patches keyed by environment (e.g. dev, staging, prod)
+ */
+export function renderKustomize(
+  model: ManifestModel,
+  overlays: Record<string, KustomizeOverlayInput> = {},
+): GeneratedManifestFile[] {
+  const resources = renderRawManifests(model);
+  const files: GeneratedManifestFile[] = resources.map((file) => ({
+    path: `base/${file.path}`,
+    content: file.content,
+  }));
+
+  files.push({
+    path: 'base/kustomization.yaml',
+    content: yaml.dump({ ...KUSTOMIZATION_HEADER, resources: resources.map((f) => f.path) }),
+  });
+
+  for (const [environment, overlay] of Object.entries(overlays)) {
+    const patch = buildOverlayPatch(model, overlay);
+    files.push({
+      path: `overlays/${environment}/kustomization.yaml`,
+      content: yaml.dump({
+        ...KUSTOMIZATION_HEADER,
+        resources: ['../../base'],
+        ...(patch && { patches: [{ path: 'deployment-patch.yaml' }] }),
+      }),
+    });
+    if (patch) {
+      files.push({
+        path: `overlays/${environment}/deployment-patch.yaml`,
+        content: yaml.dump(patch, { noRefs: true }),
+      });
+    }
+  }
+
+  return files;
+}
+
+/**
+ * Express the model as Helm chart values
+ */
+export function buildHelmValues(model: ManifestModel): Record<string, unknown> {
+  return {
+    replicaCount: model.replicas,
+    image: model.image,
+    containerPorts: containerPorts(model),
+    service: { port: primaryPort(model), ports: servicePorts(model) },
+    resources: model.resources,
+    env: model.env,
+    ingress: { enabled: Boolean(model.ingressHost), host: model.ingressHost ?? '' },
+  };
+}
+
+const HELM_LABELS = `app.kubernetes.io/name: {{ .Chart.Name }}`;
+
+const DEPLOYMENT_TEMPLATE = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Chart.Name }}
+  labels:
+    ${HELM_LABELS}
+spec:
+  replicas: {{ .Values.replicaCount }}
+  selector:
+    matchLabels:
+      ${HELM_LABELS}
+  template:
+    metadata:
+      labels:
+        ${HELM_LABELS}
+    spec:
+      containers:
+        - name: {{ .Chart.Name }}
+          image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+          ports: {{- toYaml .Values.containerPorts | nindent 12 }}
+          resources: {{- toYaml .Values.resources | nindent 12 }}
+          {{- if .Values.env }}
+          env: {{- toYaml .Values.env | nindent 12 }}
+          {{- end }}
+`;
+
+const SERVICE_TEMPLATE = `apiVersion: v1
+kind: Service
+metadata:
+  name: {{ .Chart.Name }}
+  labels:
+    ${HELM_LABELS}
+spec:
+  selector:
+    ${HELM_LABELS}
+  ports: {{- toYaml .Values.service.ports | nindent 4 }}
+`;
+
+const INGRESS_TEMPLATE = `{{- if .Values.ingress.enabled }}
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: {{ .Chart.Name }}
+  labels:
+    ${HELM_LABELS}
+spec:
+  rules:
+    - host: {{ .Values.ingress.host | quote }}
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: {{ .Chart.Name }}
+                port:
+                  number: {{ .Values.service.port }}
+{{- end }}
+`;
+
+/**
+ * Render the model as a minimal Helm chart (Chart.yaml, values.yaml, templates)
+ */
+export function renderHelmChart(model: ManifestModel): GeneratedManifestFile[] {
+  const chart = {
+    apiVersion: 'v2',
+    name: model.name,
+    description: `A Helm chart for ${model.name}`,
+    type: 'application',
+    version: '0.1.0',
+    appVersion: model.image.tag,
+  };
+
+  return [
+    { path: 'Chart.yaml', content: yaml.dump(chart) },
+    { path: 'values.yaml', content: yaml.dump(buildHelmValues(model), { noRefs: true }) },
+    { path: 'templates/deployment.yaml', content: DEPLOYMENT_TEMPLATE },
+    { path: 'templates/service.yaml', content: SERVICE_TEMPLATE },
+    { path: 'templates/ingress.yaml', content: INGRESS_TEMPLATE },
+  ];
+}
+
+/**
+ * Context available to chart templates
+ */
+export interface HelmRenderContext {
+  Values: Record<string, unknown>;
+  Chart: { Name: string };
+}
+
+function lookup(context: HelmRenderContext, path: string): unknown {
+  return path
+    .split('.')
+    .filter(Boolean)
+    .reduce<unknown>(
+      (value, key) =>
+        value && typeof value === 'object' ? (value as Record<string, unknown>)[key] : undefined,
+      context,
+    );
+}
+
+function isTruthy(value: unknown): boolean {
+  if (Array.isArray(value)) return value.length > 0;
+  if (value && typeof value === 'object') return Object.keys(value).length > 0;
+  return Boolean(value);
+}
+
+function evaluatePipeline(expression: string, context: HelmRenderContext): string {
+  const [head = '', ...filters] = expression.split('|').map((part) => part.trim());
+  const toYaml = head.startsWith('toYaml ');
+  const value = lookup(context, toYaml ? head.slice('toYaml '.length).trim() : head);
+  let output = toYaml ? yaml.dump(value, { noRefs: true }).trimEnd() : String(value ?? '');
+
+  for (const filter of filters) {
+    const [fn, arg] = filter.split(/\s+/);
+    if (fn === 'quote') {
+      output = JSON.stringify(output);
+    } else if (fn === 'nindent') {
+      const pad = ' '.repeat(Number(arg));
+      output = `\n${output
+        .split('\n')
+        .map((line) => pad + line)
+        .join('\n')}`;
+    } else {
+      throw new Error(`Unsupported template function: ${fn}`);
+    }
+  }
+  return output;
+}
+
+/**
+ * Render a chart template with the subset of Go template syntax used by
+ * renderHelmChart: value lookups, `quote`, `toYaml | nindent`, and
+ * non-nested `if` blocks. Used to preview charts without the helm binary.
+ */
+export function renderHelmTemplate(template: string, context: HelmRenderContext): string {
+  const withConditionals = template.replace(
+    /\s*\{\{-?\s*if\s+(\S+)\s*-?\}\}([\s\S]*?)\s*\{\{-\s*end\s*-?\}\}/g,
+    (_match, path: string, body: string) => (isTruthy(lookup(context, path)) ? body : ''),
+  );
+
+  return withConditionals
+    .replace(
+      /(\s*)\{\{(-?)\s*(.*?)\s*\}\}/g,
+      (_match, space: string, trim: string, expr: string) =>
+        (trim ? '' : space) + evaluatePipeline(expr, context),
+    )
+    .trimStart();
+}
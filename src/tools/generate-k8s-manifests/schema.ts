@@ -3,8 +3,21 @@
  */
 
 import { z } from 'zod';
-import { environment, type ToolNextAction } from '../shared/schemas';
+import { environment, replicas, type ToolNextAction } from '../shared/schemas';
 import type { PolicyValidationResult } from '@/lib/policy-helpers';
+import type { GeneratedManifestFile } from './manifest-model';
+
+const resourceQuantities = z
+  .object({
+    cpu: z.string().optional().describe('CPU quantity (e.g., 250m)'),
+    memory: z.string().optional().describe('Memory quantity (e.g., 256Mi)'),
+  })
+  .optional();
+
+const resourceSpec = z
+  .object({ requests: resourceQuantities, limits: resourceQuantities })
+  .optional()
+  .describe('Container resource requests and limits');
 
 export const generateK8sManifestsSchema = z
   .object({
@@ -44,7 +57,10 @@ export const generateK8sManifestsSchema = z
       .array(z.string())
       .optional()
       .describe('List of module dependencies including database drivers and system libraries'),
-    ports: z.array(z.number()).optional(),
+    ports: z
+      .array(z.number())
+      .optional()
+      .describe('Container ports from analyze-repo, most likely first. The first is the default.'),
     entryPoint: z.string().optional(),
 
     // ACA conversion field
@@ -72,6 +88,34 @@ export const generateK8sManifestsSchema = z
       .default(true)
       .describe('Add helpful comments in the output (primarily for ACA conversions)'),
     namespace: z.string().optional().describe('Target Kubernetes namespace'),
+
+    // Rendering inputs for generated kubernetes/helm output (repository mode)
+    image: z
+      .string()
+      .optional()
+      .describe('Container image reference (defaults to <name>:latest)'),
+    replicas,
+    resources: resourceSpec,
+    env: z
+      .record(z.string(), z.string())
+      .optional()
+      .describe('Environment variables for the application container'),
+    ingressHost: z
+      .string()
+      .optional()
+      .describe('Hostname to expose through an Ingress; no Ingress is generated when omitted'),
+    overlays: z
+      .record(
+        z.string().regex(/^[a-z0-9][a-z0-9-]*$/, 'Overlay names must be lowercase directory names'),
+        z.object({
+          replicas,
+          resources: resourceSpec,
+        }),
+      )
+      .optional()
+      .describe(
+        'Kustomize overlays keyed by environment (e.g., dev, staging, prod) with replica/resource patches. Only used when manifestType is kustomize.',
+      ),
   })
   .superRefine((data, ctx) => {
     const hasAcaManifest = !!data.acaManifest;
@@ -181,4 +225,6 @@ export interface ManifestPlan {
   confidence: number;
   summary: string;
   policyValidation?: PolicyValidationResult;
+  /** Rendered manifests or chart files, paths relative to the module root */
+  generatedFiles?: GeneratedManifestFile[];
 }
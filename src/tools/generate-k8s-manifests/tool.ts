@@ -33,6 +33,13 @@ import { pluralize } from '@/lib/summary-helpers';
 import type { RegoEvaluator } from '@/config/policy-rego';
 import type { Logger } from 'pino';
 import { getToolLogger } from '@/lib/tool-helpers';
+import {
+  buildManifestModel,
+  renderHelmChart,
+  renderKustomize,
+  renderRawManifests,
+  type GeneratedManifestFile,
+} from './manifest-model';
 import {
   validateContentAgainstPolicy,
   type PolicyViolation,
@@ -68,6 +75,75 @@ const MANIFEST_TYPE_TO_TOPIC = {
 const DEFAULT_POLICY_VALIDATION_CPU_LIMIT = '500m';
 const DEFAULT_POLICY_VALIDATION_MEMORY_LIMIT = '512Mi';
 
+const HELM_FILE_PURPOSES: Record<string, string> = {
+  'Chart.yaml': 'Chart metadata',
+  'values.yaml': 'Default values (image, replicas, resources, env)',
+  'templates/deployment.yaml': 'Deployment template',
+  'templates/service.yaml': 'Service template',
+  'templates/ingress.yaml': 'Ingress template (enabled via values)',
+};
+
+const RAW_FILE_PURPOSES: Record<string, string> = {
+  'deployment.yaml': 'Application deployment',
+  'service.yaml': 'Service exposure',
+  'ingress.yaml': 'Ingress routing for the configured host',
+};
+
+const PACKAGED_OUTPUT_LABELS: Record<string, string> = {
+  helm: 'Helm chart',
+  kustomize: 'Kustomize base and overlays',
+};
+
+/**
+ * Describe a generated file for the next-action file list
+ */
+function describeGeneratedFile(path: string, chartRoot: string): string {
+  const overlay = path.match(/\/overlays\/([^/]+)\//)?.[1];
+  if (overlay) return `Kustomize ${overlay} overlay`;
+  if (path.includes('/base/')) return 'Kustomize base';
+  return HELM_FILE_PURPOSES[path.slice(chartRoot.length)] ?? 'Chart file';
+}
+
+/**
+ * Render concrete output for manifest types backed by the shared manifest model.
+ * Raw manifests, Helm charts and Kustomize bases are all derived from the same
+ * model so the outputs stay equivalent.
+ */
+function renderModelFiles(
+  input: GenerateK8sManifestsParams,
+): GeneratedManifestFile[] | undefined {
+  if (!input.name || input.manifestType === 'aca') {
+    return undefined;
+  }
+
+  const model = buildManifestModel({
+    name: input.name,
+    image: input.image,
+    replicas: input.replicas,
+    ports: input.ports,
+    resources: input.resources,
+    env: input.env,
+    ingressHost: input.ingressHost,
+  });
+
+  if (input.manifestType === 'helm') {
+    return renderHelmChart(model).map((file) => ({
+      path: `./helm/${input.name}/${file.path}`,
+      content: file.content,
+    }));
+  }
+  if (input.manifestType === 'kustomize') {
+    return renderKustomize(model, input.overlays).map((file) => ({
+      path: `./k8s/${file.path}`,
+      content: file.content,
+    }));
+  }
+  return renderRawManifests(model).map((file) => ({
+    path: `./k8s/${file.path}`,
+    content: file.content,
+  }));
+}
+
 /**
  * Parse ACA manifest from YAML or JSON string
  */
@@ -420,20 +496,27 @@ const runPattern = createKnowledgeTool<
           matchScore: snippet.weight,
         }));
 
+      const generatedFiles = renderModelFiles(input);
+
       // Determine manifest files for repository mode
-      const manifestFiles: Array<{ path: string; purpose: string }> = [
-        { path: './k8s/deployment.yaml', purpose: 'Application deployment' },
-        { path: './k8s/service.yaml', purpose: 'Service exposure' },
-      ];
-
-      // Add configmap if there are ports or environment variables
-      if (input.ports && input.ports.length > 0) {
-        manifestFiles.push({ path: './k8s/configmap.yaml', purpose: 'Configuration management' });
-      }
+      const packaged = input.manifestType === 'helm' || input.manifestType === 'kustomize';
+      const manifestFiles: Array<{ path: string; purpose: string }> = generatedFiles
+        ? generatedFiles.map((file) => ({
+            path: file.path,
+            purpose: packaged
+              ? describeGeneratedFile(file.path, `./helm/${input.name}/`)
+              : (RAW_FILE_PURPOSES[file.path.replace('./k8s/', '')] ?? 'Kubernetes manifest'),
+          }))
+        : [
+            { path: './k8s/deployment.yaml', purpose: 'Application deployment' },
+            { path: './k8s/service.yaml', purpose: 'Service exposure' },
+          ];
 
       const nextAction: ToolNextAction = {
         action: 'create-files',
-        instruction: `Create ${input.manifestType} manifests in ./k8s directory for ${input.name}. Use security considerations from recommendations.securityConsiderations, resource management from recommendations.resourceManagement, and best practices from recommendations.bestPractices. Reference repositoryInfo for application details like language, ports, and dependencies.`,
+        instruction: packaged
+          ? `Write the ${PACKAGED_OUTPUT_LABELS[input.manifestType]} in generatedFiles for ${input.name}, then refine them using security considerations from recommendations.securityConsiderations, resource management from recommendations.resourceManagement, and best practices from recommendations.bestPractices.`
+          : `Create ${input.manifestType} manifests in ./k8s directory for ${input.name}. Use security considerations from recommendations.securityConsiderations, resource management from recommendations.resourceManagement, and best practices from recommendations.bestPractices. Reference repositoryInfo for application details like language, ports, and dependencies.`,
         files: manifestFiles,
       };
 
@@ -442,12 +525,21 @@ const runPattern = createKnowledgeTool<
           ? ` (${input.frameworks.map((f) => f.name).join(', ')})`
           : '';
 
+      // Packaged outputs repeat file names across directories, so list their paths
+      const manifestList = manifestFiles
+        .map((f) => (packaged ? f.path.replace(/^\.\//, '') : f.path.split('/').pop()))
+        .join(', ');
+
       const summary =
         `🔨 ACTION REQUIRED: Create ${input.manifestType} manifests\n` +
         `Application: ${input.name || input.language || 'application'}${frameworksStr}\n` +
-        `Manifests: ${manifestFiles.map((f) => f.path.split('/').pop()).join(', ')}\n` +
+        `Manifests: ${manifestList}\n` +
         `Recommendations: ${knowledgeMatches.length} total (${securityMatches.length} security, ${resourceMatches.length} resources, ${bestPracticeMatches.length} best practices)\n\n` +
-        `✅ Ready to create manifests in ./k8s directory.`;
+        (input.manifestType === 'helm'
+          ? `✅ Ready to write the Helm chart to ./helm/${input.name}.`
+          : input.manifestType === 'kustomize'
+            ? `✅ Ready to write the Kustomize base and overlays to ./k8s.`
+            : `✅ Ready to create manifests in ./k8s directory.`);
 
       return {
         nextAction,
@@ -471,6 +563,7 @@ const runPattern = createKnowledgeTool<
         knowledgeMatches,
         confidence,
         summary,
+        ...(generatedFiles && { generatedFiles }),
       };
     },
   },
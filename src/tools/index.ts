@@ -1,25 +1,45 @@
+import analyzeImageLayersTool from './analyze-image-layers/tool';
 import analyzeRepoTool from './analyze-repo/tool';
 import buildImageTool from './build-image/tool';
+import cleanupWorkspaceTool from './cleanup-workspace/tool';
+import deployTool from './deploy/tool';
+import diffImagesTool from './diff-images/tool';
 import fixDockerfileTool from './fix-dockerfile/tool';
 import generateDockerfileTool from './generate-dockerfile/tool';
 import generateK8sManifestsTool from './generate-k8s-manifests/tool';
+import listWorkflowsTool from './list-workflows/tool';
 import opsTool from './ops/tool';
+import optimizeDockerfileTool from './optimize-dockerfile/tool';
 import prepareClusterTool from './prepare-cluster/tool';
 import pushImageTool from './push-image/tool';
+import resumeWorkflowTool from './resume-workflow/tool';
+import rollbackDeployTool from './rollback-deploy/tool';
 import scanImageTool from './scan-image/tool';
+import scanImagesBatchTool from './scan-images-batch/tool';
+import scanLicensesTool from './scan-licenses/tool';
 import tagImageTool from './tag-image/tool';
 import verifyDeployTool from './verify-deploy/tool';
 
 const TOOL_NAME = {
+  ANALYZE_IMAGE_LAYERS: 'analyze-image-layers',
   ANALYZE_REPO: 'analyze-repo',
   BUILD_IMAGE: 'build-image',
+  CLEANUP_WORKSPACE: 'cleanup-workspace',
+  DEPLOY: 'deploy',
+  DIFF_IMAGES: 'diff-images',
   FIX_DOCKERFILE: 'fix-dockerfile',
   GENERATE_DOCKERFILE: 'generate-dockerfile',
   GENERATE_K8S_MANIFESTS: 'generate-k8s-manifests',
+  LIST_WORKFLOWS: 'list-workflows',
   OPS: 'ops',
+  OPTIMIZE_DOCKERFILE: 'optimize-dockerfile',
   PREPARE_CLUSTER: 'prepare-cluster',
   PUSH_IMAGE: 'push-image',
+  RESUME_WORKFLOW: 'resume-workflow',
+  ROLLBACK_DEPLOY: 'rollback-deploy',
   SCAN_IMAGE: 'scan-image',
+  SCAN_IMAGES_BATCH: 'scan-images-batch',
+  SCAN_LICENSES: 'scan-licenses',
   TAG_IMAGE: 'tag-image',
   VERIFY_DEPLOY: 'verify-deploy',
 } as const;
@@ -27,29 +47,49 @@ const TOOL_NAME = {
 export type ToolName = (typeof TOOL_NAME)[keyof typeof TOOL_NAME];
 
 // Ensure proper names on all tools
+analyzeImageLayersTool.name = TOOL_NAME.ANALYZE_IMAGE_LAYERS;
 analyzeRepoTool.name = TOOL_NAME.ANALYZE_REPO;
 buildImageTool.name = TOOL_NAME.BUILD_IMAGE;
+cleanupWorkspaceTool.name = TOOL_NAME.CLEANUP_WORKSPACE;
+deployTool.name = TOOL_NAME.DEPLOY;
+diffImagesTool.name = TOOL_NAME.DIFF_IMAGES;
 fixDockerfileTool.name = TOOL_NAME.FIX_DOCKERFILE;
 generateDockerfileTool.name = TOOL_NAME.GENERATE_DOCKERFILE;
 generateK8sManifestsTool.name = TOOL_NAME.GENERATE_K8S_MANIFESTS;
+listWorkflowsTool.name = TOOL_NAME.LIST_WORKFLOWS;
 opsTool.name = TOOL_NAME.OPS;
+optimizeDockerfileTool.name = TOOL_NAME.OPTIMIZE_DOCKERFILE;
 prepareClusterTool.name = TOOL_NAME.PREPARE_CLUSTER;
 pushImageTool.name = TOOL_NAME.PUSH_IMAGE;
+resumeWorkflowTool.name = TOOL_NAME.RESUME_WORKFLOW;
+rollbackDeployTool.name = TOOL_NAME.ROLLBACK_DEPLOY;
 scanImageTool.name = TOOL_NAME.SCAN_IMAGE;
+scanImagesBatchTool.name = TOOL_NAME.SCAN_IMAGES_BATCH;
+scanLicensesTool.name = TOOL_NAME.SCAN_LICENSES;
 tagImageTool.name = TOOL_NAME.TAG_IMAGE;
 verifyDeployTool.name = TOOL_NAME.VERIFY_DEPLOY;
 
 // Create a union type of all tool types for better type safety
 export type Tool = (
+  | typeof analyzeImageLayersTool
   | typeof analyzeRepoTool
   | typeof buildImageTool
+  | typeof cleanupWorkspaceTool
+  | typeof deployTool
+  | typeof diffImagesTool
   | typeof fixDockerfileTool
   | typeof generateDockerfileTool
   | typeof generateK8sManifestsTool
+  | typeof listWorkflowsTool
   | typeof opsTool
+  | typeof optimizeDockerfileTool
   | typeof prepareClusterTool
   | typeof pushImageTool
+  | typeof resumeWorkflowTool
+  | typeof rollbackDeployTool
   | typeof scanImageTool
+  | typeof scanImagesBatchTool
+  | typeof scanLicensesTool
   | typeof tagImageTool
   | typeof verifyDeployTool
 ) & { name: string };
@@ -64,26 +104,46 @@ export const ALL_TOOLS: readonly Tool[] = [
   generateK8sManifestsTool,
 
   // Operational/deterministic tools
+  analyzeImageLayersTool,
   buildImageTool,
+  cleanupWorkspaceTool,
+  deployTool,
+  diffImagesTool,
+  listWorkflowsTool,
   opsTool,
+  optimizeDockerfileTool,
   prepareClusterTool,
   pushImageTool,
+  resumeWorkflowTool,
+  rollbackDeployTool,
   scanImageTool,
+  scanImagesBatchTool,
+  scanLicensesTool,
   tagImageTool,
   verifyDeployTool,
 ] as const;
 
 export {
   TOOL_NAME,
+  analyzeImageLayersTool,
   analyzeRepoTool,
   buildImageTool,
+  cleanupWorkspaceTool,
+  deployTool,
+  diffImagesTool,
   fixDockerfileTool,
   generateDockerfileTool,
   generateK8sManifestsTool,
+  listWorkflowsTool,
   opsTool,
+  optimizeDockerfileTool,
   prepareClusterTool,
   pushImageTool,
+  resumeWorkflowTool,
+  rollbackDeployTool,
   scanImageTool,
+  scanImagesBatchTool,
+  scanLicensesTool,
   tagImageTool,
   verifyDeployTool,
 };
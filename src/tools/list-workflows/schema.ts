@@ -0,0 +1,45 @@
+/**
+ * Schema definition for list-workflows tool
+ */
+
+import { z } from 'zod';
+
+export const listWorkflowsSchema = z.object({
+  status: z
+    .enum(['complete', 'in-progress'])
+    .optional()
+    .describe('Only list complete workflows, or only ones with stages left to run'),
+  minAgeMinutes: z
+    .number()
+    .nonnegative()
+    .optional()
+    .describe('Only list workflows created at least this many minutes ago'),
+  maxAgeMinutes: z
+    .number()
+    .nonnegative()
+    .optional()
+    .describe('Only list workflows created at most this many minutes ago'),
+  sortBy: z
+    .enum(['created', 'updated'])
+    .optional()
+    .describe(
+      'Sort by creation time or by the time of the last completed stage (default: updated)',
+    ),
+  order: z
+    .enum(['asc', 'desc'])
+    .optional()
+    .describe('Sort direction (default: desc, newest first)'),
+  limit: z
+    .number()
+    .int()
+    .min(1)
+    .max(100)
+    .optional()
+    .describe('Workflows per page (default: 20)'),
+  cursor: z
+    .string()
+    .optional()
+    .describe('nextCursor from the previous page, to continue listing'),
+});
+
+export type ListWorkflowsParams = z.infer<typeof listWorkflowsSchema>;
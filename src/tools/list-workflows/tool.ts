@@ -0,0 +1,110 @@
+/**
+ * List Workflows Tool
+ *
+ * Lists the workflows saved in the app's workflow state store with how far
+ * each has progressed, so a caller can pick one to continue with
+ * resume-workflow. Results can be filtered by status and age, sorted by
+ * creation or update time, and read a page at a time with a cursor.
+ *
+ * @example
+ * ```typescript
+ * const page = await app.execute('list-workflows', { status: 'in-progress', limit: 10 });
+ * const next = await app.execute('list-workflows', {
+ *   status: 'in-progress',
+ *   limit: 10,
+ *   cursor: page.value.nextCursor,
+ * });
+ * ```
+ */
+
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { extractErrorMessage } from '@/lib/errors';
+import { pluralize } from '@/lib/summary-helpers';
+import type { WorkflowQuery, WorkflowSummary } from '@/lib/workflow-state';
+import type { ToolContext } from '@/mcp/context';
+import { Failure, Success, type Result } from '@/types';
+import { tool } from '@/types/tool';
+import { listWorkflowsSchema, type ListWorkflowsParams } from './schema';
+
+const MINUTE_MS = 60 * 1000;
+
+const SORT_FIELDS = { created: 'createdAt', updated: 'updatedAt' } as const;
+
+export interface ListWorkflowsResult {
+  /**
+   * Natural language summary for user display.
+   * @example "✅ Listed 10 workflows (in-progress, newest update first). More are available."
+   */
+  summary: string;
+  workflows: WorkflowSummary[];
+  /** Pass as cursor to list the next page; unset on the last page */
+  nextCursor?: string;
+}
+
+function toQuery(input: ListWorkflowsParams): WorkflowQuery {
+  return {
+    ...(input.status && { status: input.status }),
+    ...(input.minAgeMinutes !== undefined && { minAgeMs: input.minAgeMinutes * MINUTE_MS }),
+    ...(input.maxAgeMinutes !== undefined && { maxAgeMs: input.maxAgeMinutes * MINUTE_MS }),
+    ...(input.sortBy && { sortBy: SORT_FIELDS[input.sortBy] }),
+    ...(input.order && { order: input.order }),
+    ...(input.limit !== undefined && { limit: input.limit }),
+    ...(input.cursor && { cursor: input.cursor }),
+  };
+}
+
+async function handleListWorkflows(
+  input: ListWorkflowsParams,
+  context: ToolContext,
+): Promise<Result<ListWorkflowsResult>> {
+  const { logger, timer } = setupToolContext(context, 'list-workflows');
+
+  const store = context.workflow?.store;
+  if (!store?.list) {
+    return Failure('Workflow listing is not available', {
+      message: 'No workflow state store that can list workflows',
+      hint: 'Workflows are only listed when the server saves workflow state',
+      resolution:
+        'Set CONTAINERIZATION_ASSIST_WORKFLOW_STATE_DIR, or pass a workflowState store with list() to createApp',
+    });
+  }
+
+  try {
+    const page = await store.list(toQuery(input));
+
+    const filter = input.status ? `${input.status}, ` : '';
+    const order = input.order === 'asc' ? 'oldest' : 'newest';
+    const field = input.sortBy === 'created' ? 'creation' : 'update';
+    const more = page.nextCursor ? ' More are available.' : '';
+    const summary = `✅ Listed ${pluralize(page.workflows.length, 'workflow')} (${filter}${order} ${field} first).${more}`;
+
+    logger.info(
+      { count: page.workflows.length, hasMore: page.nextCursor !== undefined },
+      'Workflows listed',
+    );
+    timer.end({ count: page.workflows.length });
+    return Success({ summary, ...page });
+  } catch (error) {
+    timer.error(error);
+    return Failure(`Failed to list workflows: ${extractErrorMessage(error)}`, {
+      message: 'Workflow state could not be listed',
+      hint: extractErrorMessage(error),
+      resolution: input.cursor
+        ? 'Pass the nextCursor from the previous page unchanged, or list again without a cursor'
+        : 'Check that the workflow state directory is readable',
+    });
+  }
+}
+
+export default tool({
+  name: 'list-workflows',
+  description:
+    'List saved containerization workflows with their progress, filtered by status and age, sorted and paginated',
+  category: 'utility',
+  version: '1.0.0',
+  schema: listWorkflowsSchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  handler: handleListWorkflows,
+});
@@ -25,7 +25,8 @@ import { Success, Failure, type Result } from '@/types';
 import type { ToolContext } from '@/mcp/context';
 import { opsToolSchema } from './schema';
 import type { z } from 'zod';
-import { formatDuration, formatTimestamp } from '@/lib/summary-helpers';
+import { formatDuration, formatSize, formatTimestamp } from '@/lib/summary-helpers';
+import type { WorkflowStateUsage } from '@/lib/workflow-state';
 
 interface PingConfig {
   message?: string;
@@ -133,6 +134,8 @@ export interface ServerStatusResult {
     migrated: number;
   };
   sessions?: number;
+  /** Disk used by saved workflow state (when workflow state is enabled) */
+  workflowState?: WorkflowStateUsage;
 }
 
 /**
@@ -162,9 +165,19 @@ export async function serverStatus(
 
     const migratedToolCount = 12;
 
+    let workflowState: WorkflowStateUsage | undefined;
+    try {
+      workflowState = await context.workflow?.store.usage?.();
+    } catch (error) {
+      logger.warn({ error: extractErrorMessage(error) }, 'Could not measure workflow state');
+    }
+
     // Generate summary
     const uptimeStr = formatDuration(uptime);
-    const summary = `✅ Server healthy. Running for ${uptimeStr}. Memory: ${memPercentage}% used, CPU: ${cpus.length} cores.`;
+    const stateText = workflowState
+      ? ` Workflow state: ${workflowState.workflows} saved (${formatSize(workflowState.totalBytes)}).`
+      : '';
+    const summary = `✅ Server healthy. Running for ${uptimeStr}. Memory: ${memPercentage}% used, CPU: ${cpus.length} cores.${stateText}`;
 
     const status: ServerStatusResult = {
       summary,
@@ -191,6 +204,7 @@ export async function serverStatus(
         count: 14,
         migrated: migratedToolCount,
       },
+      ...(workflowState && { sessions: workflowState.workflows, workflowState }),
     };
 
     logger.info(
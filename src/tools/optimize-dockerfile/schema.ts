@@ -0,0 +1,16 @@
+/**
+ * Schema definition for optimize-dockerfile tool
+ */
+
+import { z } from 'zod';
+
+export const optimizeDockerfileSchema = z
+  .object({
+    dockerfile: z.string().optional().describe('Dockerfile content to optimize'),
+    path: z.string().optional().describe('Path to Dockerfile file to optimize'),
+  })
+  .refine((data) => data.dockerfile || data.path, {
+    message: "Either 'dockerfile' content or 'path' must be provided",
+  });
+
+export type OptimizeDockerfileParams = z.infer<typeof optimizeDockerfileSchema>;
@@ -0,0 +1,106 @@
+/**
+ * Optimize Dockerfile Tool
+ *
+ * Analyzes a Dockerfile for layer-cache and image-size improvements and
+ * returns a prioritized list of suggestions. Changes that cannot alter the
+ * build result (reordering and merging instructions) are also applied and
+ * returned as an optimized Dockerfile; nothing is ever dropped.
+ *
+ * This is a deterministic tool with no AI calls.
+ *
+ * @example
+ * ```typescript
+ * const result = await optimizeDockerfile({ path: './Dockerfile' }, context);
+ * ```
+ */
+
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { readDockerfile } from '@/lib/file-utils';
+import { pluralize } from '@/lib/summary-helpers';
+import type { ToolContext } from '@/mcp/context';
+import { Success, type Result } from '@/types';
+import type { ToolNextAction } from '../shared/schemas';
+import {
+  optimizeDockerfile,
+  type OptimizationSuggestion,
+} from '@/validation/dockerfile-optimizer';
+import { optimizeDockerfileSchema, type OptimizeDockerfileParams } from './schema';
+
+export interface OptimizeDockerfileResult extends Record<string, unknown> {
+  /**
+   * Natural language summary for user display.
+   * @example "✅ Found 3 optimizations for Dockerfile (2 applied automatically)."
+   */
+  summary: string;
+  /** Suggestions ordered by impact */
+  suggestions: OptimizationSuggestion[];
+  /** Rewritten Dockerfile, present when at least one suggestion was applied */
+  optimizedDockerfile?: string;
+  appliedCount: number;
+  nextAction?: ToolNextAction;
+}
+
+async function handleOptimizeDockerfile(
+  input: OptimizeDockerfileParams,
+  context: ToolContext,
+): Promise<Result<OptimizeDockerfileResult>> {
+  const { logger, timer } = setupToolContext(context, 'optimize-dockerfile');
+
+  const contentResult = await readDockerfile({
+    ...(input.path !== undefined && { path: input.path }),
+    ...(input.dockerfile !== undefined && { content: input.dockerfile }),
+  });
+  if (!contentResult.ok) {
+    return contentResult;
+  }
+
+  const { suggestions, optimized } = optimizeDockerfile(contentResult.value);
+  const appliedCount = suggestions.filter((s) => s.autoApplied).length;
+  const target = input.path ?? './Dockerfile';
+
+  logger.info(
+    { suggestions: suggestions.map((s) => s.id), appliedCount },
+    'Dockerfile optimization analysis complete',
+  );
+  timer.end({ suggestionCount: suggestions.length, appliedCount });
+
+  if (suggestions.length === 0) {
+    return Success({
+      summary: '✅ No optimizations found. Dockerfile layers are already cache-friendly.',
+      suggestions,
+      appliedCount: 0,
+    });
+  }
+
+  const top = suggestions[0];
+  return Success({
+    summary:
+      `✅ Found ${pluralize(suggestions.length, 'optimization')} for ${target} (${appliedCount} applied automatically).` +
+      (top ? ` Highest impact: ${top.title.toLowerCase()}.` : ''),
+    suggestions,
+    ...(optimized && { optimizedDockerfile: optimized }),
+    appliedCount,
+    ...(optimized && {
+      nextAction: {
+        action: 'update-files',
+        instruction: `Replace ${target} with optimizedDockerfile, then review the suggestions that were not applied automatically.`,
+        files: [{ path: target, purpose: 'Container build configuration (optimized)' }],
+      },
+    }),
+  });
+}
+
+import { tool } from '@/types/tool';
+
+export default tool({
+  name: 'optimize-dockerfile',
+  description:
+    'Suggest prioritized layer-cache and image-size optimizations for a Dockerfile and return a safely optimized version',
+  category: 'docker',
+  version: '1.0.0',
+  schema: optimizeDockerfileSchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  handler: handleOptimizeDockerfile,
+});
@@ -665,7 +665,7 @@ export default tool({
     knowledgeEnhanced: false,
   },
   chainHints: {
-    success: 'Cluster preparation successful. Next: Use `kubectl apply -f <manifest-folder>` to deploy your manifests to the cluster, then call verify-deploy to check deployment status.',
+    success: 'Cluster preparation successful. Next: call deploy with your manifests (dryRun: true to validate them first), then call verify-deploy to check deployment status.',
     failure:
       'Cluster preparation found issues. Check connectivity, permissions, and namespace configuration.',
   },
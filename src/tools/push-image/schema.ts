@@ -14,5 +14,12 @@ export const pushImageSchema = z.object({
       password: z.string(),
     })
     .optional()
-    .describe('Registry credentials. If not provided, will attempt to use Docker credential helpers'),
+    .describe(
+      'Registry credentials. If not provided, uses CONTAINERIZATION_ASSIST_REGISTRY_AUTH, then Docker config and credential helpers',
+    ),
+  sign: z.boolean().optional().describe('Sign the pushed image with cosign'),
+  cosignKeyRef: z
+    .string()
+    .optional()
+    .describe('Cosign key reference (file path, KMS URI or k8s://namespace/secret). Omit for keyless OIDC signing'),
 });
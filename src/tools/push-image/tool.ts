@@ -8,9 +8,15 @@
  */
 
 import { createDockerClient, type DockerClient } from '@/infra/docker/client';
-import { getRegistryCredentials } from '@/infra/docker/credential-helpers';
+import { resolveRegistryCredentials } from '@/infra/docker/credential-helpers';
+import {
+  createCosignSigner,
+  type ImageSigner,
+  type ImageSignature,
+  type SignOptions,
+} from '@/infra/security/cosign';
 import { getToolLogger } from '@/lib/tool-helpers';
-import { parseImageName } from '@/lib/validation-helpers';
+import { DOCKER_HUB_REGISTRY, imageRefName, parseImageRef } from '@/lib/image-ref';
 import { Success, Failure, type Result } from '@/types';
 import type { ToolContext } from '@/mcp/context';
 import { tool } from '@/types/tool';
@@ -29,6 +35,42 @@ export interface PushImageResult {
   registry: string;
   digest: string;
   pushedTag: string;
+  /** Whether the pushed image was signed (only set when signing was requested) */
+  signed?: boolean;
+  /** Signature details when signing succeeded */
+  signature?: ImageSignature;
+  /** Why signing failed; the image was still pushed */
+  signingError?: string;
+}
+
+type SigningOutcome = Pick<PushImageResult, 'signed' | 'signature' | 'signingError'>;
+
+/**
+ * Sign a pushed image. Failures are reported in the outcome rather than
+ * failing the tool, since the push itself already succeeded.
+ */
+async function signPushedImage(
+  signer: ImageSigner,
+  imageRef: string,
+  options: SignOptions,
+  logger: ReturnType<typeof getToolLogger>,
+): Promise<SigningOutcome> {
+  if (!(await signer.isAvailable())) {
+    logger.warn({ imageRef }, 'Cosign not installed - image pushed unsigned');
+    return {
+      signed: false,
+      signingError: 'cosign is not installed (https://docs.sigstore.dev/cosign/system_config/installation/)',
+    };
+  }
+
+  const signResult = await signer.sign(imageRef, options);
+  if (!signResult.ok) {
+    logger.warn({ imageRef, error: signResult.error }, 'Image signing failed - image pushed unsigned');
+    return { signed: false, signingError: signResult.error };
+  }
+
+  logger.info({ imageRef, signature: signResult.value }, 'Image signed');
+  return { signed: true, signature: signResult.value };
 }
 
 /**
@@ -55,10 +97,20 @@ async function handlePushImage(
     }
 
     // Parse and validate image name
-    const parsedImage = parseImageName(input.imageId);
+    const parsedImage = parseImageRef(input.imageId);
     if (!parsedImage.ok) {
       return parsedImage;
     }
+    if (!parsedImage.value.tag) {
+      return Failure(
+        'Cannot push a digest reference without a tag',
+        createErrorGuidance(
+          'Image reference has a digest but no tag',
+          'Pushing needs a tag to publish the image under',
+          'Reference the image by tag, e.g. myapp:v1.0, or tag it first with tag-image.',
+        ),
+      );
+    }
 
     // Use docker from context if provided (for testing), otherwise create new client
     // Type guard for test context with docker property
@@ -66,107 +118,64 @@ async function handlePushImage(
       (ctx && 'docker' in ctx && ((ctx as Record<string, unknown>).docker as DockerClient)) ||
       createDockerClient(logger);
 
+    const daemon = await dockerClient.ping();
+    if (!daemon.ok) return daemon;
+
     // Determine the final repository and tag based on registry input
     let repository: string;
     const tag = parsedImage.value.tag;
+    const imageName = imageRefName(parsedImage.value);
 
     if (input.registry) {
       // Registry provided - clean it and determine if we need to prefix
       const registryHost = input.registry.replace(/^https?:\/\//, '').replace(/\/$/, '');
 
-      // Check if the image already contains the registry (avoid double prefixing)
-      // Reconstruct the full image path (without tag) to compare with target registry
-      const fullImagePath = parsedImage.value.registry ?
-        `${parsedImage.value.registry}/${parsedImage.value.repository}` :
-        parsedImage.value.repository;
-
-      // Check if the image already starts with the target registry
-      if (fullImagePath === registryHost || fullImagePath.startsWith(`${registryHost}/`)) {
+      if (imageName === registryHost || imageName.startsWith(`${registryHost}/`)) {
         // Image already contains the target registry - use the full path as-is
-        repository = fullImagePath;
-      } else if (parsedImage.value.repository.includes('/') && !parsedImage.value.repository.startsWith('docker.io/')) {
-        // Image has namespace or registry prefix that doesn't match input registry
-        // Only strip first part if it looks like a registry hostname (contains '.' or ':')
-        const imageParts = parsedImage.value.repository.split('/');
-        const firstPart = imageParts[0];
-
-        if (firstPart && (firstPart.includes('.') || firstPart.includes(':'))) {
-          // Looks like a registry hostname, replace it
-          const pathAfterRegistry = imageParts.slice(1).join('/');
-          repository = `${registryHost}/${pathAfterRegistry}`;
-        } else {
-          // Looks like a namespace/organization, keep the full path
-          repository = `${registryHost}/${parsedImage.value.repository}`;
-        }
+        repository = imageName;
       } else {
-        // No registry in image or it's docker.io - prefix with input registry
+        // Keep the repository path; any registry host in the image is replaced
         repository = `${registryHost}/${parsedImage.value.repository}`;
       }
     } else {
       // No registry provided - use image as-is (defaults to Docker Hub)
-      repository = parsedImage.value.repository;
+      repository = imageName;
     }
 
-    // Build auth config - try credential helpers first, then manual credentials
-    let authConfig: { username: string; password: string; serveraddress: string } | undefined;
-
-    // Try Docker credential helpers first (only if registry is provided)
-    if (input.registry) {
-      const credResult = await getRegistryCredentials(input.registry, logger);
-      if (credResult.ok && credResult.value) {
-        authConfig = credResult.value;
-        logger.info({
-          registry: input.registry,
-          username: authConfig.username,
-          serveraddress: authConfig.serveraddress,
-          passwordProvided: !!authConfig.password,
-        }, 'Using credentials from Docker credential helper');
-      } else if (credResult.ok) {
-        logger.debug({ registry: input.registry }, 'No credentials found in Docker credential helpers');
-      } else {
-        logger.debug({ registry: input.registry, error: credResult.error }, 'Credential helper lookup failed');
-      }
+    // Validate that both username and password are present when credentials are given
+    if (input.credentials && (!input.credentials.username || !input.credentials.password)) {
+      return Failure(
+        'Missing registry credentials',
+        createErrorGuidance(
+          'Both username and password are required for registry authentication',
+          'Registry credentials are incomplete',
+          'Provide both username and password in the credentials parameter',
+        ),
+      );
     }
 
-    // Fall back to manual credentials if provided and no credentials found via helpers
-    if (!authConfig && input.credentials) {
-      // Validate that both username and password are present
-      if (!input.credentials.username || !input.credentials.password) {
-        return Failure(
-          'Missing registry credentials',
-          createErrorGuidance(
-            'Both username and password are required for registry authentication',
-            'Registry credentials are incomplete',
-            'Provide both username and password in the credentials parameter',
-          ),
-        );
-      }
-
-      logger.info({ registry: input.registry }, 'Using provided credentials');
-
-      // Simple serveraddress: use the registry host for most cases, special case Docker Hub
-      let serverAddress: string;
-      if (input.registry) {
-        const registryHost = input.registry.replace(/^https?:\/\//, '').replace(/\/$/, '');
-        if (
-          registryHost === 'docker.io' ||
-          registryHost === 'index.docker.io' ||
-          registryHost === 'registry-1.docker.io'
-        ) {
-          serverAddress = 'https://index.docker.io/v1/';
-        } else {
-          serverAddress = registryHost;
-        }
-      } else {
-        // Default to Docker Hub if no registry provided
-        serverAddress = 'https://index.docker.io/v1/';
-      }
-
-      authConfig = {
-        username: input.credentials.username,
-        password: input.credentials.password,
-        serveraddress: serverAddress,
-      };
+    // Resolve credentials: tool arguments, then environment, then Docker config and helpers
+    const targetRegistry = input.registry || parsedImage.value.registry || DOCKER_HUB_REGISTRY;
+    const credResult = await resolveRegistryCredentials(targetRegistry, input.credentials, logger);
+    if (!credResult.ok) {
+      return credResult;
+    }
+    const authConfig = credResult.value?.auth;
+    if (credResult.value) {
+      logger.info(
+        {
+          registry: targetRegistry,
+          source: credResult.value.source,
+          username: credResult.value.auth.username,
+          serveraddress: credResult.value.auth.serveraddress,
+        },
+        'Using registry credentials',
+      );
+    } else {
+      logger.debug(
+        { registry: targetRegistry },
+        'No registry credentials found, pushing anonymously',
+      );
     }
 
     // Tag image with target registry
@@ -199,18 +208,13 @@ async function handlePushImage(
     }
 
     const pushTime = Date.now() - startTime;
-    // Build pushed tag for the response - use original image format if no registry, otherwise use the resolved repository
-    const pushedTag = input.registry ? `${repository}:${tag}` : `${parsedImage.value.repository}:${tag}`;
+    const pushedTag = `${repository}:${tag}`;
 
-    // Build display tag for summary based on the actual registry used
-    let displayTag: string;
-    if (input.registry) {
-      // Custom registry provided - use the resolved repository format
-      displayTag = `${repository}:${tag}`;
-    } else {
-      // No custom registry - always use docker.io prefix
-      displayTag = `docker.io/${repository}:${tag}`;
-    }
+    // Build display tag for summary; Docker Hub short names get the docker.io prefix
+    const displayTag =
+      input.registry || parsedImage.value.registry
+        ? pushedTag
+        : `${DOCKER_HUB_REGISTRY}/${pushedTag}`;
 
     logger.info(
       { pushedTag, pushTime, digest: pushResult.value.digest },
@@ -224,15 +228,36 @@ async function handlePushImage(
     const digestShort = colonIndex >= 0 && digest.length > colonIndex + 7
       ? `${digest.substring(0, colonIndex + 7)}...`
       : digest;
-    const summary = `✅ Pushed image to registry. Image: ${displayTag}. Digest: ${digestShort}`;
+
+    // Sign by digest so the signature stays bound to the pushed content
+    let signing: SigningOutcome = {};
+    if (input.sign) {
+      const signer = ctx.signer ?? createCosignSigner(logger);
+      const signRef = digest ? `${repository}@${digest}` : `${repository}:${tag}`;
+      // cosign reads Docker config credentials itself; others must be handed over
+      const signAuth = credResult.value?.source === 'docker-config' ? undefined : authConfig;
+      const signOptions: SignOptions = {
+        ...(input.cosignKeyRef && { keyRef: input.cosignKeyRef }),
+        ...(signAuth && { auth: signAuth }),
+      };
+      signing = await signPushedImage(signer, signRef, signOptions, logger);
+    }
+
+    let summary = `✅ Pushed image to registry. Image: ${displayTag}. Digest: ${digestShort}`;
+    if (signing.signed) {
+      summary += ` Signed with cosign (${signing.signature?.keyless ? 'keyless' : 'key'}).`;
+    } else if (signing.signed === false) {
+      summary = `⚠️ Pushed image but it is unsigned: ${signing.signingError}. Image: ${displayTag}. Digest: ${digestShort}`;
+    }
 
     // Return success response
     const result: PushImageResult = {
       summary,
       success: true,
-      registry: input.registry || 'docker.io',
+      registry: targetRegistry,
       digest: pushResult.value.digest,
       pushedTag,
+      ...signing,
     };
 
     return Success(result);
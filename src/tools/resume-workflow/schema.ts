@@ -0,0 +1,19 @@
+/**
+ * Schema definition for resume-workflow tool
+ */
+
+import { z } from 'zod';
+
+export const resumeWorkflowSchema = z.object({
+  workflowId: z
+    .string()
+    .regex(/^[\w.-]{1,128}$/)
+    .optional()
+    .describe("Workflow to resume (default: the caller's session)"),
+  restart: z
+    .boolean()
+    .optional()
+    .describe('Discard the saved stages so the workflow starts over from analyze-repo'),
+});
+
+export type ResumeWorkflowParams = z.infer<typeof resumeWorkflowSchema>;
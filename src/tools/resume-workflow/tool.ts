@@ -0,0 +1,156 @@
+/**
+ * Resume Workflow Tool
+ *
+ * Continues a containerization workflow from its last completed stage after
+ * the server restarted or the conversation was interrupted. The stages that
+ * already ran are read from the app's workflow state store together with
+ * their outputs, so the caller can pass those outputs to the next stage
+ * instead of analyzing, generating and building again.
+ *
+ * Stages are recorded by the orchestrator as pipeline tools succeed; running
+ * a stage again drops the stages after it, so the saved outputs always belong
+ * to one consistent run.
+ *
+ * @example
+ * ```typescript
+ * const result = await app.execute('resume-workflow', {}, { sessionId });
+ * // result.value.nextStage === 'scan-image' once build-image has completed
+ * ```
+ */
+
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { extractErrorMessage } from '@/lib/errors';
+import { pluralize } from '@/lib/summary-helpers';
+import { pipelineProgress, type PipelineStage, type WorkflowState } from '@/lib/workflow-state';
+import type { ToolContext } from '@/mcp/context';
+import { Failure, Success, type Result } from '@/types';
+import { tool } from '@/types/tool';
+import { resumeWorkflowSchema, type ResumeWorkflowParams } from './schema';
+
+/** How to continue with each stage, given the outputs of the ones before it */
+const NEXT_ACTIONS: Record<PipelineStage, string> = {
+  'analyze-repo': 'Call analyze-repo on the repository to start the workflow',
+  'generate-dockerfile': 'Call generate-dockerfile with the analyze-repo output',
+  'build-image': 'Call build-image with the Dockerfile from generate-dockerfile',
+  'scan-image': 'Call scan-image on the image from build-image',
+  'push-image': 'Call push-image to push the scanned image to a registry',
+  'generate-k8s-manifests': 'Call generate-k8s-manifests for the pushed image',
+  'prepare-cluster': 'Call prepare-cluster to get the target cluster ready',
+  'verify-deploy': 'Apply the manifests, then call verify-deploy to check the deployment',
+};
+
+export interface CompletedStageSummary {
+  stage: PipelineStage;
+  /** ISO 8601 completion time */
+  completedAt: string;
+  /** Result the stage returned, to pass on to later stages */
+  output: unknown;
+}
+
+export interface ResumeWorkflowResult {
+  /**
+   * Natural language summary for user display.
+   * @example "✅ Resuming workflow abc: 3 stages completed (last: build-image). Next: scan-image."
+   */
+  summary: string;
+  workflowId: string;
+  /** Completed stages in pipeline order */
+  completedStages: CompletedStageSummary[];
+  lastCompletedStage?: PipelineStage;
+  /** First stage that has not completed; unset once the workflow is complete */
+  nextStage?: PipelineStage;
+  nextAction?: string;
+  complete: boolean;
+  /** True when the saved stages were discarded */
+  restarted: boolean;
+}
+
+function describeProgress(
+  workflowId: string,
+  state: WorkflowState | undefined,
+  restarted: boolean,
+): ResumeWorkflowResult {
+  const { completed, next } = pipelineProgress(state);
+  const completedStages = completed.map((stage) => {
+    const saved = state?.stages[stage];
+    return { stage, completedAt: saved?.completedAt ?? '', output: saved?.output };
+  });
+  const last = completed[completed.length - 1];
+
+  let summary: string;
+  if (restarted) {
+    summary = `✅ Workflow ${workflowId} restarted; saved stages were discarded. Next: analyze-repo.`;
+  } else if (!last) {
+    summary = `✅ No completed stages saved for workflow ${workflowId}. Next: analyze-repo.`;
+  } else if (!next) {
+    summary = `✅ Workflow ${workflowId} is complete: all ${completed.length} stages have run.`;
+  } else {
+    summary = `✅ Resuming workflow ${workflowId}: ${pluralize(completed.length, 'stage')} completed (last: ${last}). Next: ${next}.`;
+  }
+
+  return {
+    summary,
+    workflowId,
+    completedStages,
+    ...(last && { lastCompletedStage: last }),
+    ...(next && { nextStage: next, nextAction: NEXT_ACTIONS[next] }),
+    complete: next === undefined,
+    restarted,
+  };
+}
+
+async function handleResumeWorkflow(
+  input: ResumeWorkflowParams,
+  context: ToolContext,
+): Promise<Result<ResumeWorkflowResult>> {
+  const { logger, timer } = setupToolContext(context, 'resume-workflow');
+
+  const binding = context.workflow;
+  if (!binding) {
+    return Failure('Workflow state persistence is not enabled', {
+      message: 'No workflow state store',
+      hint: 'Completed stages are only saved when the server has a workflow state directory',
+      resolution:
+        'Set CONTAINERIZATION_ASSIST_WORKFLOW_STATE_DIR, or pass workflowState to createApp',
+    });
+  }
+
+  const workflowId = input.workflowId ?? binding.id;
+  try {
+    if (input.restart) {
+      await binding.store.delete(workflowId);
+      logger.info({ workflowId }, 'Workflow state discarded');
+      timer.end({ workflowId, restarted: true });
+      return Success(describeProgress(workflowId, undefined, true));
+    }
+
+    const state = await binding.store.load(workflowId);
+    const result = describeProgress(workflowId, state, false);
+    logger.info(
+      { workflowId, completed: result.completedStages.length, nextStage: result.nextStage },
+      'Workflow state loaded',
+    );
+    timer.end({ workflowId, completed: result.completedStages.length });
+    return Success(result);
+  } catch (error) {
+    timer.error(error);
+    return Failure(`Failed to read workflow state: ${extractErrorMessage(error)}`, {
+      message: 'Workflow state unavailable',
+      hint: extractErrorMessage(error),
+      resolution: 'Check that the workflow state directory is readable, or resume with restart',
+    });
+  }
+}
+
+export default tool({
+  name: 'resume-workflow',
+  description:
+    'Continue a containerization workflow from its last completed stage, returning saved stage outputs and the next stage to run',
+  category: 'utility',
+  version: '1.0.0',
+  schema: resumeWorkflowSchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  handler: handleResumeWorkflow,
+});
@@ -0,0 +1,21 @@
+import { z } from 'zod';
+import { namespaceOptional } from '../shared/schemas';
+
+export const rollbackDeploySchema = z.object({
+  deploymentName: z.string().min(1).describe('Deployment name to roll back (required)'),
+  namespace: namespaceOptional,
+  toRevision: z
+    .number()
+    .int()
+    .positive()
+    .optional()
+    .describe('Revision to roll back to (default: the revision before the current one)'),
+  timeoutSeconds: z
+    .number()
+    .int()
+    .positive()
+    .optional()
+    .describe('How long to wait for the rolled-back Deployment to become ready (default: 180)'),
+});
+
+export type RollbackDeployParams = z.infer<typeof rollbackDeploySchema>;
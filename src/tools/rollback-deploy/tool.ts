@@ -0,0 +1,176 @@
+/**
+ * Rollback Deployment Tool
+ *
+ * Rolls a Kubernetes Deployment back to an earlier revision (the equivalent
+ * of `kubectl rollout undo`) and waits for the rollout to stabilize.
+ *
+ * This is a deterministic operational tool with no AI calls.
+ *
+ * @example
+ * ```typescript
+ * const result = await rollbackDeploy({
+ *   deploymentName: 'my-app',
+ *   namespace: 'production',
+ *   toRevision: 3,
+ * }, context);
+ * ```
+ */
+
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { extractErrorMessage } from '@/lib/errors';
+import type { ToolContext } from '@/mcp/context';
+import { createKubernetesClient, type PodStatus } from '@/infra/kubernetes/client';
+import { DEFAULT_TIMEOUTS } from '@/config/constants';
+import { Success, Failure, type Result } from '@/types';
+import { rollbackDeploySchema, type RollbackDeployParams } from './schema';
+
+export interface RollbackDeployResult extends Record<string, unknown> {
+  /**
+   * Natural language summary for user display.
+   * @example "✅ Rolled back my-app from revision 5 to revision 4 (now revision 6). 3/3 pods ready."
+   */
+  summary: string;
+  success: boolean;
+  namespace: string;
+  deploymentName: string;
+  /** Revision that was current before the rollback */
+  fromRevision: number;
+  /** Revision whose pod template was restored */
+  restoredRevision: number;
+  /** Revision number the Deployment is at after the rollback */
+  revision: number;
+  ready: boolean;
+  readyReplicas: number;
+  totalReplicas: number;
+  pods: PodStatus[];
+}
+
+async function handleRollbackDeploy(
+  params: RollbackDeployParams,
+  context: ToolContext,
+): Promise<Result<RollbackDeployResult>> {
+  const { logger, timer } = setupToolContext(context, 'rollback-deploy');
+
+  const { deploymentName, toRevision } = params;
+  const namespace = params.namespace ?? 'default';
+  const timeoutSeconds = params.timeoutSeconds ?? Math.floor(DEFAULT_TIMEOUTS.deployment / 1000);
+
+  try {
+    const k8sClient = createKubernetesClient(logger);
+
+    const history = await k8sClient.listDeploymentRevisions(namespace, deploymentName);
+    if (!history.ok) {
+      return Failure(history.error, history.guidance);
+    }
+
+    const current = history.value.find((revision) => revision.current);
+    const previous = history.value.filter((revision) => !revision.current);
+    const historyCommand = `kubectl rollout history deployment/${deploymentName} -n ${namespace}`;
+
+    if (previous.length === 0) {
+      return Failure(`Deployment ${deploymentName} has no previous revision to roll back to`, {
+        message: 'No rollout history',
+        hint: 'The deployment has only been rolled out once, or its revision history was pruned by revisionHistoryLimit',
+        resolution: `Redeploy a known-good image instead. Inspect history with: ${historyCommand}`,
+        details: { namespace, deploymentName, currentRevision: current?.revision },
+      });
+    }
+
+    if (toRevision !== undefined && !previous.some((r) => r.revision === toRevision)) {
+      const available = previous.map((r) => r.revision);
+      return Failure(`Revision ${toRevision} not found in the history of ${deploymentName}`, {
+        message: 'Unknown target revision',
+        hint: `Available previous revisions: ${available.join(', ')}`,
+        resolution: `Choose one of the listed revisions. Inspect history with: ${historyCommand}`,
+        details: { namespace, deploymentName, availableRevisions: available },
+      });
+    }
+
+    logger.info(
+      { namespace, deploymentName, fromRevision: current?.revision, toRevision },
+      'Rolling back deployment',
+    );
+
+    const rollback = await k8sClient.rollbackDeployment(namespace, deploymentName, toRevision);
+    if (!rollback.ok) {
+      return Failure(rollback.error, rollback.guidance);
+    }
+
+    const waitResult = await k8sClient.waitForDeploymentReady(
+      namespace,
+      deploymentName,
+      timeoutSeconds,
+      DEFAULT_TIMEOUTS.deploymentPoll,
+    );
+    const pods = await k8sClient.listDeploymentPods(namespace, deploymentName);
+    const podStatuses = pods.ok ? pods.value : [];
+
+    if (!waitResult.ok) {
+      const status = await k8sClient.getDeploymentStatus(namespace, deploymentName);
+      const unhealthy = podStatuses.filter((pod) => !pod.ready);
+
+      timer.error(new Error(waitResult.error));
+      return Failure(
+        `Rolled back ${deploymentName} to revision ${rollback.value.toRevision}, but the rollout did not stabilize within ${timeoutSeconds}s`,
+        {
+          message: 'Rollout did not become ready',
+          hint:
+            unhealthy.length > 0
+              ? `Unready pods: ${unhealthy.map((p) => `${p.name} (${p.reason ?? p.phase})`).join(', ')}`
+              : 'Pods have not reported ready yet',
+          resolution: `Check pod events with: kubectl describe pods -n ${namespace}, or retry with a longer timeoutSeconds`,
+          details: {
+            ...rollback.value,
+            ...(status.ok && { status: status.value }),
+            pods: podStatuses,
+          },
+        },
+      );
+    }
+
+    const after = await k8sClient.listDeploymentRevisions(namespace, deploymentName);
+    const revision =
+      (after.ok ? after.value.find((r) => r.current)?.revision : undefined) ??
+      rollback.value.toRevision;
+    const { readyReplicas, totalReplicas } = waitResult.value;
+
+    timer.end({ deploymentName, revision });
+
+    return Success({
+      summary: `✅ Rolled back ${deploymentName} from revision ${rollback.value.fromRevision} to revision ${rollback.value.toRevision} (now revision ${revision}). ${readyReplicas}/${totalReplicas} pods ready.`,
+      success: true,
+      namespace,
+      deploymentName,
+      fromRevision: rollback.value.fromRevision,
+      restoredRevision: rollback.value.toRevision,
+      revision,
+      ready: true,
+      readyReplicas,
+      totalReplicas,
+      pods: podStatuses,
+    });
+  } catch (error) {
+    timer.error(error);
+
+    return Failure(extractErrorMessage(error), {
+      message: extractErrorMessage(error),
+      hint: 'An unexpected error occurred during deployment rollback',
+      resolution:
+        'Verify the deployment exists, the cluster is accessible, and you have permission to update deployments',
+    });
+  }
+}
+
+import { tool } from '@/types/tool';
+
+export default tool({
+  name: 'rollback-deploy',
+  description: 'Roll a Kubernetes deployment back to a previous revision and wait for it to be ready',
+  category: 'kubernetes',
+  version: '1.0.0',
+  schema: rollbackDeploySchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  handler: handleRollbackDeploy,
+});
@@ -13,14 +13,41 @@ export const scanImageSchema = z.object({
     ])
     .optional()
     .describe('Minimum severity to report'),
+  failOnSeverity: z
+    .union([
+      z.enum(['LOW', 'MEDIUM', 'HIGH', 'CRITICAL']),
+      z.enum(['low', 'medium', 'high', 'critical']),
+    ])
+    .optional()
+    .describe(
+      'Return an error result when any finding is at or above this severity (e.g., "critical" fails on CRITICAL only)',
+    ),
+  ignoreUnfixed: z
+    .boolean()
+    .optional()
+    .describe('Exclude vulnerabilities that have no fixed version available'),
   scanType: z
     .enum(['vulnerability', 'config', 'all'])
     .default('vulnerability') // Added default
     .describe('Type of scan to perform'),
   scanner: z
-    .enum(['trivy', 'snyk', 'grype'])
-    .default('trivy') // Added default
-    .describe('Scanner to use for vulnerability detection'),
+    .enum(['trivy', 'snyk', 'grype', 'auto'])
+    .optional()
+    .describe(
+      'Scanner to use for vulnerability detection. Defaults to auto: Trivy, or Grype when only Grype is installed',
+    ),
+  outputFormat: z
+    .enum(['json', 'sarif'])
+    .optional()
+    .describe(
+      'Result format. "sarif" additionally writes a SARIF 2.1.0 report for GitHub code scanning',
+    ),
+  dockerfilePath: z
+    .string()
+    .optional()
+    .describe(
+      'Dockerfile the image was built from, relative to the repository root. SARIF results point at it (default: Dockerfile)',
+    ),
   enableAISuggestions: z
     .boolean()
     .default(true)
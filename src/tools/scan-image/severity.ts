@@ -0,0 +1,48 @@
+/**
+ * Severity thresholds shared by the image scanning tools
+ */
+
+import type { BasicScanResult } from '@/infra/security/scanner';
+
+export type SeverityThreshold = 'low' | 'medium' | 'high' | 'critical';
+
+export const SEVERITIES_AT_OR_ABOVE: Record<SeverityThreshold, SeverityThreshold[]> = {
+  critical: ['critical'],
+  high: ['critical', 'high'],
+  medium: ['critical', 'high', 'medium'],
+  low: ['critical', 'high', 'medium', 'low'],
+};
+
+/**
+ * Count findings at or above a severity threshold
+ */
+export function countAtOrAbove(scanResult: BasicScanResult, threshold: SeverityThreshold): number {
+  const counts: Record<SeverityThreshold, number> = {
+    critical: scanResult.criticalCount,
+    high: scanResult.highCount,
+    medium: scanResult.mediumCount,
+    low: scanResult.lowCount,
+  };
+  return SEVERITIES_AT_OR_ABOVE[threshold].reduce((sum, severity) => sum + counts[severity], 0);
+}
+
+/**
+ * Drop findings without an available fix and recount by severity
+ */
+export function excludeUnfixed(scanResult: BasicScanResult): BasicScanResult {
+  const vulnerabilities = scanResult.vulnerabilities.filter((v) => v.fixedVersion);
+  const count = (severity: BasicScanResult['vulnerabilities'][number]['severity']): number =>
+    vulnerabilities.filter((v) => v.severity === severity).length;
+
+  return {
+    ...scanResult,
+    vulnerabilities,
+    totalVulnerabilities: vulnerabilities.length,
+    criticalCount: count('CRITICAL'),
+    highCount: count('HIGH'),
+    mediumCount: count('MEDIUM'),
+    lowCount: count('LOW'),
+    negligibleCount: count('NEGLIGIBLE'),
+    unknownCount: count('UNKNOWN'),
+  };
+}
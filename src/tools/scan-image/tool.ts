@@ -9,10 +9,18 @@ import { setupToolContext } from '@/lib/tool-context-helpers';
 import type { ToolContext } from '@/mcp/context';
 
 import { createSecurityScanner } from '@/infra/security/scanner';
+import { toSarif, writeSarifReport } from '@/infra/security/sarif';
 import { Success, Failure, type Result } from '@/types';
+import { isImageId, normalizeImageRef, parseImageRef } from '@/lib/image-ref';
 import { getKnowledgeForCategory } from '@/knowledge/index';
 import type { KnowledgeMatch } from '@/knowledge/types';
 import { scanImageSchema, type ScanImageParams } from './schema';
+import {
+  SEVERITIES_AT_OR_ABOVE,
+  countAtOrAbove,
+  excludeUnfixed,
+  type SeverityThreshold,
+} from './severity';
 import { formatVulnerabilities, buildStatusSummary, pluralize } from '@/lib/summary-helpers';
 
 interface DockerScanResult {
@@ -64,6 +72,12 @@ export interface ScanImageResult {
   };
   scanTime: string;
   passed: boolean;
+  /** Scanner that produced the findings */
+  scanner?: string;
+  /** Problems with the scanner itself, such as an outdated version */
+  warnings?: string[];
+  /** Path of the SARIF 2.1.0 report (when outputFormat is sarif) */
+  sarifPath?: string;
 }
 
 /**
@@ -82,12 +96,18 @@ async function handleScanImage(
   }
   const { logger, timer } = setupToolContext(context, 'scan-image');
 
-  const { scanner = 'trivy', severity } = params;
+  const {
+    scanner = 'auto',
+    severity,
+    failOnSeverity,
+    ignoreUnfixed = false,
+    outputFormat = 'json',
+    dockerfilePath,
+  } = params;
 
   // Map severity parameter to threshold
-  const finalSeverityThreshold = severity
-    ? (severity.toLowerCase() as 'low' | 'medium' | 'high' | 'critical')
-    : 'high';
+  const finalSeverityThreshold = severity ? (severity.toLowerCase() as SeverityThreshold) : 'high';
+  const failOnThreshold = failOnSeverity?.toLowerCase() as SeverityThreshold | undefined;
 
   try {
     logger.info(
@@ -106,7 +126,16 @@ async function handleScanImage(
         resolution: 'Add imageId parameter with the Docker image ID or name to scan',
       });
     }
-    logger.info({ imageId, scanner }, 'Scanning image for vulnerabilities');
+
+    // Image IDs go to the scanner as-is; references are checked before scanning
+    const imageRef = isImageId(imageId) ? undefined : parseImageRef(imageId);
+    if (imageRef && !imageRef.ok) {
+      return imageRef;
+    }
+    logger.info(
+      { imageId, scanner, ...(imageRef && { image: normalizeImageRef(imageRef.value) }) },
+      'Scanning image for vulnerabilities',
+    );
 
     // Scan image using security scanner
     const scanResultWrapper = await securityScanner.scanImage(imageId);
@@ -118,7 +147,13 @@ async function handleScanImage(
       );
     }
 
-    const scanResult = scanResultWrapper.value;
+    const scanResult = ignoreUnfixed
+      ? excludeUnfixed(scanResultWrapper.value)
+      : scanResultWrapper.value;
+    if (ignoreUnfixed) {
+      const excluded = scanResultWrapper.value.totalVulnerabilities - scanResult.totalVulnerabilities;
+      logger.info({ excluded }, 'Excluded vulnerabilities without an available fix');
+    }
 
     // Convert BasicScanResult to DockerScanResult
     const dockerScanResult: DockerScanResult = {
@@ -146,27 +181,10 @@ async function handleScanImage(
     };
 
     // Determine if scan passed based on threshold
-    const thresholdMap = {
-      critical: ['critical'],
-      high: ['critical', 'high'],
-      medium: ['critical', 'high', 'medium'],
-      low: ['critical', 'high', 'medium', 'low'],
-    };
-
-    const failingSeverities = thresholdMap[finalSeverityThreshold] || thresholdMap['high'];
-    let vulnerabilityCount = 0;
-
-    for (const severity of failingSeverities) {
-      if (severity === 'critical') {
-        vulnerabilityCount += scanResult.criticalCount;
-      } else if (severity === 'high') {
-        vulnerabilityCount += scanResult.highCount;
-      } else if (severity === 'medium') {
-        vulnerabilityCount += scanResult.mediumCount;
-      } else if (severity === 'low') {
-        vulnerabilityCount += scanResult.lowCount;
-      }
-    }
+    const vulnerabilityCount = countAtOrAbove(
+      scanResult,
+      SEVERITIES_AT_OR_ABOVE[finalSeverityThreshold] ? finalSeverityThreshold : 'high',
+    );
 
     const passed = vulnerabilityCount === 0;
 
@@ -229,6 +247,21 @@ async function handleScanImage(
       `🔒 Security scan failed. ${vulnSummary}.${remediationText}`,
     );
 
+    // SARIF is written alongside the JSON result, never instead of it
+    const warnings = [...(scanResult.warnings ?? [])];
+    let sarifPath: string | undefined;
+    if (outputFormat === 'sarif') {
+      const sarif = toSarif(scanResult, imageId, dockerfilePath);
+      const sarifResult = await writeSarifReport(sarif, imageId);
+      if (sarifResult.ok) {
+        sarifPath = sarifResult.value;
+        logger.info({ sarifPath }, 'SARIF report written');
+      } else {
+        logger.warn({ error: sarifResult.error }, 'Failed to write SARIF report');
+        warnings.push(`SARIF report was requested but not written: ${sarifResult.error}`);
+      }
+    }
+
     // Prepare the result
     const result: ScanImageResult = {
       summary,
@@ -245,6 +278,9 @@ async function handleScanImage(
       },
       scanTime: dockerScanResult.scanTime ?? new Date().toISOString(),
       passed,
+      ...(scanResult.scanner && { scanner: scanResult.scanner }),
+      ...(warnings.length > 0 && { warnings }),
+      ...(sarifPath && { sarifPath }),
     };
 
     timer.end({
@@ -263,6 +299,30 @@ async function handleScanImage(
       'Image scan completed',
     );
 
+    // Severity gate: fail the tool, but keep the findings available to the caller
+    if (failOnThreshold) {
+      const gatedCount = countAtOrAbove(scanResult, failOnThreshold);
+      if (gatedCount > 0) {
+        const thresholdLabel = failOnThreshold.toUpperCase();
+        return Failure(
+          `Security gate failed: ${pluralize(gatedCount, 'vulnerability', 'vulnerabilities')} at or above ${thresholdLabel} severity`,
+          {
+            message: `Image ${imageId} has findings at or above the ${thresholdLabel} gate`,
+            hint: summary,
+            resolution:
+              'Update the base image or affected packages to fixed versions, then rebuild and rescan. Use fix-dockerfile for guidance.',
+            details: {
+              failOnSeverity: thresholdLabel,
+              ignoreUnfixed,
+              vulnerabilities: result.vulnerabilities,
+              findings: dockerScanResult.vulnerabilities ?? [],
+              ...(sarifPath && { sarifPath }),
+            },
+          },
+        );
+      }
+    }
+
     return Success(result);
   } catch (error) {
     timer.error(error);
@@ -272,7 +332,7 @@ async function handleScanImage(
     return Failure(errorMessage, {
       message: errorMessage,
       hint: 'An unexpected error occurred during the security scan',
-      resolution: 'Verify that the scanner (Trivy) is installed and accessible, the image exists, and you have proper permissions',
+      resolution: 'Verify that the scanner (Trivy or Grype) is installed and accessible, the image exists, and you have proper permissions',
     });
   }
 }
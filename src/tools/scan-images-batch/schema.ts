@@ -0,0 +1,43 @@
+/**
+ * Schema definition for scan-images-batch tool
+ */
+
+import { z } from 'zod';
+
+export const MAX_BATCH_IMAGES = 50;
+
+export const scanImagesBatchSchema = z.object({
+  images: z
+    .array(z.string().min(1))
+    .min(1)
+    .max(MAX_BATCH_IMAGES)
+    .describe('Docker image IDs or names to scan'),
+  severity: z
+    .union([
+      z.enum(['LOW', 'MEDIUM', 'HIGH', 'CRITICAL']),
+      z.enum(['low', 'medium', 'high', 'critical']),
+    ])
+    .optional()
+    .describe(
+      'An image passes when it has no findings at or above this severity (default: high)',
+    ),
+  ignoreUnfixed: z
+    .boolean()
+    .optional()
+    .describe('Exclude vulnerabilities that have no fixed version available'),
+  scanner: z
+    .enum(['trivy', 'grype', 'auto'])
+    .optional()
+    .describe(
+      'Scanner to use for every image. Defaults to auto: Trivy, or Grype when only Grype is installed',
+    ),
+  concurrency: z
+    .number()
+    .int()
+    .min(1)
+    .max(8)
+    .optional()
+    .describe('Images scanned at the same time (default: 3)'),
+});
+
+export type ScanImagesBatchParams = z.infer<typeof scanImagesBatchSchema>;
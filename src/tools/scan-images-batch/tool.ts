@@ -0,0 +1,225 @@
+/**
+ * Scan Images Batch Tool
+ *
+ * Scans several images for vulnerabilities at once, a bounded number at a
+ * time, and reports each image alongside a combined pass/fail against one
+ * severity threshold. An image that cannot be scanned is reported with its
+ * error and does not stop the others; it does fail the batch.
+ *
+ * @example
+ * ```typescript
+ * const result = await app.execute('scan-images-batch', {
+ *   images: ['api:1.4.0', 'worker:1.4.0', 'web:1.4.0'],
+ *   severity: 'critical',
+ *   concurrency: 2,
+ * });
+ * ```
+ */
+
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { createSemaphore } from '@/lib/concurrency';
+import { extractErrorMessage } from '@/lib/errors';
+import { isImageId, parseImageRef } from '@/lib/image-ref';
+import { buildStatusSummary, pluralize } from '@/lib/summary-helpers';
+import { createSecurityScanner, type SecurityScanner } from '@/infra/security/scanner';
+import type { ToolContext } from '@/mcp/context';
+import { Failure, Success, type ErrorGuidance, type Result } from '@/types';
+import { tool } from '@/types/tool';
+import { countAtOrAbove, excludeUnfixed, type SeverityThreshold } from '../scan-image/severity';
+import { scanImagesBatchSchema, type ScanImagesBatchParams } from './schema';
+
+const DEFAULT_CONCURRENCY = 3;
+
+export interface BatchImageResult {
+  image: string;
+  /** passed/failed against the threshold, or error when the image could not be scanned */
+  status: 'passed' | 'failed' | 'error';
+  vulnerabilities?: {
+    critical: number;
+    high: number;
+    medium: number;
+    low: number;
+    negligible: number;
+    unknown: number;
+    total: number;
+  };
+  /** Findings at or above the severity threshold */
+  blockingCount?: number;
+  /** Scanner that produced the findings */
+  scanner?: string;
+  /** Problems with the scanner itself, such as an outdated version */
+  warnings?: string[];
+  error?: string;
+  guidance?: ErrorGuidance;
+}
+
+export interface ScanImagesBatchResult {
+  /**
+   * Natural language summary for user display.
+   * @example "❌ 🔒 Batch scan failed. 2 of 4 images passed, 1 failed, 1 could not be scanned (threshold HIGH)."
+   */
+  summary: string;
+  /** Whether every image was scanned and passed */
+  passed: boolean;
+  severityThreshold: string;
+  /** Per-image results, in the order the images were given */
+  images: BatchImageResult[];
+  totals: { images: number; passed: number; failed: number; errored: number };
+}
+
+function erroredImage(image: string, error: string, guidance?: ErrorGuidance): BatchImageResult {
+  return { image, status: 'error', error, ...(guidance && { guidance }) };
+}
+
+/**
+ * Scan one image, turning every failure into an error entry
+ */
+async function scanOne(
+  image: string,
+  scanner: SecurityScanner,
+  threshold: SeverityThreshold,
+  ignoreUnfixed: boolean,
+): Promise<BatchImageResult> {
+  // Image IDs go to the scanner as-is; references are checked before scanning
+  const imageRef = isImageId(image) ? undefined : parseImageRef(image);
+  if (imageRef && !imageRef.ok) {
+    return erroredImage(image, imageRef.error, imageRef.guidance);
+  }
+
+  try {
+    const scanned = await scanner.scanImage(image);
+    if (!scanned.ok) {
+      return erroredImage(image, `Failed to scan image: ${scanned.error}`, scanned.guidance);
+    }
+
+    const scanResult = ignoreUnfixed ? excludeUnfixed(scanned.value) : scanned.value;
+    const blockingCount = countAtOrAbove(scanResult, threshold);
+    return {
+      image,
+      status: blockingCount === 0 ? 'passed' : 'failed',
+      vulnerabilities: {
+        critical: scanResult.criticalCount,
+        high: scanResult.highCount,
+        medium: scanResult.mediumCount,
+        low: scanResult.lowCount,
+        negligible: scanResult.negligibleCount,
+        unknown: scanResult.unknownCount,
+        total: scanResult.totalVulnerabilities,
+      },
+      blockingCount,
+      ...(scanResult.scanner && { scanner: scanResult.scanner }),
+      ...(scanResult.warnings &&
+        scanResult.warnings.length > 0 && { warnings: scanResult.warnings }),
+    };
+  } catch (error) {
+    return erroredImage(image, `Failed to scan image: ${extractErrorMessage(error)}`);
+  }
+}
+
+async function handleScanImagesBatch(
+  params: ScanImagesBatchParams,
+  context: ToolContext,
+): Promise<Result<ScanImagesBatchResult>> {
+  const { logger, timer } = setupToolContext(context, 'scan-images-batch');
+
+  const {
+    scanner = 'auto',
+    ignoreUnfixed = false,
+    concurrency = DEFAULT_CONCURRENCY,
+  } = params;
+  const threshold = (params.severity?.toLowerCase() ?? 'high') as SeverityThreshold;
+  const thresholdLabel = threshold.toUpperCase();
+  // The same image listed twice is scanned once
+  const images = [...new Set(params.images)];
+
+  logger.info(
+    { images: images.length, concurrency, scanner, severityThreshold: threshold },
+    'Starting batch image scan',
+  );
+
+  // One scanner for the batch, so scanner detection and preflight run once
+  const securityScanner = createSecurityScanner(logger, scanner);
+  const semaphore = createSemaphore(concurrency);
+
+  const results = await Promise.all(
+    images.map(async (image): Promise<BatchImageResult> => {
+      const slot = await semaphore.acquire(context.signal);
+      if (!slot.ok) return erroredImage(image, slot.error, slot.guidance);
+
+      try {
+        const result = await scanOne(image, securityScanner, threshold, ignoreUnfixed);
+        if (result.status === 'error') {
+          logger.warn({ image, error: result.error }, 'Image scan failed');
+        } else {
+          logger.info(
+            { image, status: result.status, blocking: result.blockingCount },
+            'Image scan completed',
+          );
+        }
+        return result;
+      } finally {
+        slot.value();
+      }
+    }),
+  );
+
+  const totals = {
+    images: results.length,
+    passed: results.filter((r) => r.status === 'passed').length,
+    failed: results.filter((r) => r.status === 'failed').length,
+    errored: results.filter((r) => r.status === 'error').length,
+  };
+  const passed = totals.passed === totals.images;
+
+  timer.end({ ...totals, passed });
+
+  const firstError = results.find((r) => r.status === 'error');
+  if (firstError && totals.errored === totals.images) {
+    return Failure(
+      `Failed to scan any of ${pluralize(totals.images, 'image')}: ${firstError.error}`,
+      {
+        message: firstError.guidance?.message ?? 'No image could be scanned',
+        ...(firstError.guidance?.hint && { hint: firstError.guidance.hint }),
+        resolution:
+          firstError.guidance?.resolution ??
+          'Verify that the scanner (Trivy or Grype) is installed and that the images exist',
+        details: { ...firstError.guidance?.details, images: results },
+      },
+    );
+  }
+
+  const counts = [
+    `${totals.passed} of ${pluralize(totals.images, 'image')} passed`,
+    ...(totals.failed > 0 ? [`${totals.failed} failed`] : []),
+    ...(totals.errored > 0 ? [`${totals.errored} could not be scanned`] : []),
+  ].join(', ');
+  const summary = buildStatusSummary(
+    passed,
+    `🔒 Batch scan passed. ${counts} (threshold ${thresholdLabel}).`,
+    `🔒 Batch scan failed. ${counts} (threshold ${thresholdLabel}).`,
+  );
+
+  logger.info({ ...totals, passed }, 'Batch image scan completed');
+
+  return Success({ summary, passed, severityThreshold: thresholdLabel, images: results, totals });
+}
+
+export const scanImagesBatch = handleScanImagesBatch;
+
+export default tool({
+  name: 'scan-images-batch',
+  description:
+    'Scan several Docker images for vulnerabilities concurrently, with per-image results and a combined pass/fail',
+  category: 'security',
+  version: '1.0.0',
+  schema: scanImagesBatchSchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  chainHints: {
+    success: 'All images passed the security scan. Proceed with push-image for each image.',
+    failure:
+      'Some images failed or could not be scanned. Run scan-image on a failing image for remediation guidance, or fix-dockerfile to address its base image and dependencies.',
+  },
+  handler: handleScanImagesBatch,
+});
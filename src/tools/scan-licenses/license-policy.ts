@@ -0,0 +1,206 @@
+/**
+ * License classification and policy evaluation
+ *
+ * Licenses are classified into broad categories and checked against an
+ * allowed/denied policy. Policy entries are SPDX IDs (`MIT`), prefix
+ * patterns (`GPL-*`) or category names (`copyleft`, `unknown`), matched
+ * case-insensitively; denied entries win over allowed ones.
+ *
+ * Without an allow list, permissive licenses are allowed and everything else
+ * needs review. With an allow list, anything not on it needs review.
+ *
+ * SPDX expressions are evaluated by their semantics: `A OR B` takes the more
+ * acceptable choice and `A AND B` must satisfy both.
+ */
+
+export const LICENSE_CATEGORIES = ['permissive', 'weak-copyleft', 'copyleft', 'unknown'] as const;
+
+export type LicenseCategory = (typeof LICENSE_CATEGORIES)[number];
+
+export type LicenseStatus = 'allowed' | 'review' | 'denied';
+
+export interface LicensePolicy {
+  allowed: string[];
+  denied: string[];
+}
+
+export interface LicenseVerdict {
+  /** Normalized license or expression; `UNKNOWN` when none was declared */
+  license: string;
+  category: LicenseCategory;
+  status: LicenseStatus;
+}
+
+export const UNKNOWN_LICENSE = 'UNKNOWN';
+
+const CATEGORY_PATTERNS: Array<[RegExp, LicenseCategory]> = [
+  [/^A?GPL-[\d.]+(-only|-or-later|\+)?$/i, 'copyleft'],
+  [/^(SSPL-1\.0|EUPL-1\.[12]|OSL-3\.0|CC-BY-SA-4\.0)$/i, 'copyleft'],
+  [/^LGPL-[\d.]+(-only|-or-later|\+)?$/i, 'weak-copyleft'],
+  [/^(MPL-(1\.1|2\.0)|EPL-[12]\.0|CDDL-1\.[01]|CPL-1\.0)$/i, 'weak-copyleft'],
+  [
+    new RegExp(
+      `^(${[
+        'MIT(-0)?',
+        'ISC',
+        '0BSD',
+        'BSD-[234]-Clause',
+        'Apache-(1\\.1|2\\.0)',
+        'Unlicense',
+        'CC0-1\\.0',
+        'CC-BY-[34]\\.0',
+        'Zlib',
+        'BSL-1\\.0',
+        'Python-2\\.0',
+        'PSF-2\\.0',
+        'BlueOak-1\\.0\\.0',
+        'Artistic-2\\.0',
+        'X11',
+        'PostgreSQL',
+        'OpenSSL',
+        'WTFPL',
+      ].join('|')})$`,
+      'i',
+    ),
+    'permissive',
+  ],
+];
+
+/** Common non-SPDX spellings found in package metadata */
+const LICENSE_ALIASES: Readonly<Record<string, string>> = {
+  'apache 2.0': 'Apache-2.0',
+  'apache-2': 'Apache-2.0',
+  'apache license 2.0': 'Apache-2.0',
+  'apache license, version 2.0': 'Apache-2.0',
+  'apache software license': 'Apache-2.0',
+  'mit license': 'MIT',
+  'the mit license': 'MIT',
+  expat: 'MIT',
+  'isc license': 'ISC',
+  'new bsd license': 'BSD-3-Clause',
+  'simplified bsd license': 'BSD-2-Clause',
+  'mozilla public license 2.0': 'MPL-2.0',
+  gplv2: 'GPL-2.0-only',
+  gplv3: 'GPL-3.0-only',
+  lgplv3: 'LGPL-3.0-only',
+};
+
+const STATUS_RANK: Readonly<Record<LicenseStatus, number>> = { allowed: 0, review: 1, denied: 2 };
+
+const CATEGORY_RANK: Readonly<Record<LicenseCategory, number>> = {
+  permissive: 0,
+  'weak-copyleft': 1,
+  copyleft: 2,
+  unknown: 3,
+};
+
+const worse = (a: LicenseVerdict, b: LicenseVerdict): number =>
+  STATUS_RANK[a.status] - STATUS_RANK[b.status] ||
+  CATEGORY_RANK[a.category] - CATEGORY_RANK[b.category];
+
+export function licenseCategory(license: string): LicenseCategory {
+  return CATEGORY_PATTERNS.find(([pattern]) => pattern.test(license))?.[1] ?? 'unknown';
+}
+
+function matchesPolicy(entries: string[], license: string, category: LicenseCategory): boolean {
+  const id = license.toLowerCase();
+  return entries.some((entry) => {
+    const pattern = entry.trim().toLowerCase();
+    if (pattern === category) return true;
+    return pattern.endsWith('*') ? id.startsWith(pattern.slice(0, -1)) : id === pattern;
+  });
+}
+
+/**
+ * Verdict for a single license identifier
+ */
+export function evaluateLicense(license: string, policy: LicensePolicy): LicenseVerdict {
+  const category = licenseCategory(license);
+  let status: LicenseStatus;
+  if (matchesPolicy(policy.denied, license, category)) {
+    status = 'denied';
+  } else if (matchesPolicy(policy.allowed, license, category)) {
+    status = 'allowed';
+  } else {
+    status = policy.allowed.length === 0 && category === 'permissive' ? 'allowed' : 'review';
+  }
+  return { license, category, status };
+}
+
+/**
+ * Recursive-descent evaluation of an SPDX expression; AND binds tighter than OR
+ *
+ * @returns undefined when the text is not a well-formed expression
+ */
+function evaluateExpression(tokens: string[], policy: LicensePolicy): LicenseVerdict | undefined {
+  let position = 0;
+  const peek = (): string | undefined => tokens[position]?.toUpperCase();
+
+  const primary = (): LicenseVerdict | undefined => {
+    const token = tokens[position++];
+    if (token === '(') {
+      const inner = orExpression();
+      const closed = tokens[position++] === ')';
+      return closed && inner ? { ...inner, license: `(${inner.license})` } : undefined;
+    }
+    if (!token || token === ')' || ['AND', 'OR', 'WITH'].includes(token.toUpperCase())) {
+      return undefined;
+    }
+    // Exceptions such as `WITH Classpath-exception-2.0` only relax the license
+    if (peek() === 'WITH') {
+      position += 2;
+    }
+    return evaluateLicense(token, policy);
+  };
+
+  const combine = (
+    operator: 'AND' | 'OR',
+    operand: () => LicenseVerdict | undefined,
+  ): LicenseVerdict | undefined => {
+    let result = operand();
+    while (result && peek() === operator) {
+      position++;
+      const next = operand();
+      if (!next) return undefined;
+      // AND is as bad as its worst operand, OR as good as its best
+      const keep = operator === 'AND' ? worse(result, next) >= 0 : worse(result, next) <= 0;
+      const chosen = keep ? result : next;
+      result = { ...chosen, license: `${result.license} ${operator} ${next.license}` };
+    }
+    return result;
+  };
+
+  const andExpression = (): LicenseVerdict | undefined => combine('AND', primary);
+  const orExpression = (): LicenseVerdict | undefined => combine('OR', andExpression);
+
+  const verdict = orExpression();
+  return position === tokens.length ? verdict : undefined;
+}
+
+/**
+ * Verdict for a package's declared licenses
+ *
+ * Several declared licenses are all required (treated as AND), which is the
+ * conservative reading when metadata does not say otherwise. Text that is not
+ * an SPDX expression is matched as a single license name.
+ */
+export function evaluateLicenses(
+  licenses: string[] | undefined,
+  policy: LicensePolicy,
+): LicenseVerdict {
+  const verdicts = (licenses ?? [])
+    .map((license) => license.trim())
+    .filter(Boolean)
+    .map((license) => {
+      const alias = LICENSE_ALIASES[license.toLowerCase()];
+      if (alias) return evaluateLicense(alias, policy);
+      const tokens = license.match(/\(|\)|[^\s()]+/g) ?? [];
+      return evaluateExpression(tokens, policy) ?? evaluateLicense(license, policy);
+    });
+
+  if (verdicts.length === 0) {
+    return evaluateLicense(UNKNOWN_LICENSE, policy);
+  }
+  const chosen = verdicts.reduce((a, b) => (worse(a, b) >= 0 ? a : b));
+  return { ...chosen, license: verdicts.map((verdict) => verdict.license).join(' AND ') };
+}
@@ -0,0 +1,136 @@
+/**
+ * Dependency licenses from repository lockfiles
+ *
+ * npm records each package's license in package-lock.json (lockfile v2+).
+ * Older lockfiles do not, so the installed package.json under node_modules
+ * is read when present. Other lockfiles (yarn, pnpm, bun, deno, poetry,
+ * Cargo, go.sum) record no license data; they are reported so the caller can
+ * supply an SBOM.
+ */
+
+import { promises as fs } from 'node:fs';
+import path from 'node:path';
+import type { SbomPackage } from '@/infra/security/sbom';
+import { LOCKFILES } from '../analyze-repo/package-managers';
+
+export const NPM_LOCKFILE = 'package-lock.json';
+
+const NODE_MODULES = 'node_modules/';
+
+export interface LockfileInventory {
+  packages: SbomPackage[];
+  /** Lockfiles found in the directory, npm's included */
+  lockFiles: string[];
+  /** Lockfiles found that carry no license data */
+  unsupportedLockFiles: string[];
+}
+
+interface NpmManifest {
+  license?: string | { type?: string };
+  licenses?: Array<string | { type?: string }>;
+}
+
+interface NpmLockEntry extends NpmManifest {
+  version?: string;
+  dev?: boolean;
+  link?: boolean;
+  dependencies?: Record<string, NpmLockEntry>;
+}
+
+interface NpmLockfile {
+  lockfileVersion?: number;
+  packages?: Record<string, NpmLockEntry>;
+  dependencies?: Record<string, NpmLockEntry>;
+}
+
+/**
+ * Licenses declared in a package.json or lockfile entry; handles the
+ * deprecated `{ type }` object and `licenses` array forms
+ */
+export function declaredLicenses(manifest: NpmManifest): string[] {
+  const entries = manifest.license !== undefined ? [manifest.license] : (manifest.licenses ?? []);
+  return entries
+    .map((entry) => (typeof entry === 'string' ? entry : (entry.type ?? '')))
+    .filter(Boolean);
+}
+
+async function installedLicenses(directory: string, packagePath: string): Promise<string[]> {
+  try {
+    const manifest = JSON.parse(
+      await fs.readFile(path.join(directory, packagePath, 'package.json'), 'utf-8'),
+    ) as NpmManifest;
+    return declaredLicenses(manifest);
+  } catch {
+    return [];
+  }
+}
+
+/**
+ * Flatten lockfile v1 `dependencies` into install paths, e.g.
+ * `node_modules/a/node_modules/b`
+ */
+function flattenV1(
+  dependencies: Record<string, NpmLockEntry>,
+  parent = '',
+): Array<[string, NpmLockEntry]> {
+  return Object.entries(dependencies).flatMap(([name, entry]) => {
+    const installPath = `${parent}${NODE_MODULES}${name}`;
+    return [
+      [installPath, entry] as [string, NpmLockEntry],
+      ...flattenV1(entry.dependencies ?? {}, `${installPath}/`),
+    ];
+  });
+}
+
+/**
+ * Read the production dependencies and their licenses from a directory's lockfiles
+ *
+ * @param directory - Directory containing the lockfile
+ * @param includeDev - Include devDependencies, which normally do not ship
+ */
+export async function readLockfileLicenses(
+  directory: string,
+  includeDev = false,
+): Promise<LockfileInventory> {
+  const lockFiles: string[] = [];
+  for (const file of Object.keys(LOCKFILES)) {
+    try {
+      await fs.access(path.join(directory, file));
+      lockFiles.push(file);
+    } catch {
+      // Not present
+    }
+  }
+  const unsupportedLockFiles = lockFiles.filter((file) => file !== NPM_LOCKFILE);
+  if (!lockFiles.includes(NPM_LOCKFILE)) {
+    return { packages: [], lockFiles, unsupportedLockFiles };
+  }
+
+  const lockfile = JSON.parse(
+    await fs.readFile(path.join(directory, NPM_LOCKFILE), 'utf-8'),
+  ) as NpmLockfile;
+  // v2+ also lists the root ('') and workspace sources, which are not dependencies
+  const entries = lockfile.packages
+    ? Object.entries(lockfile.packages).filter(([installPath]) =>
+        installPath.includes(NODE_MODULES),
+      )
+    : flattenV1(lockfile.dependencies ?? {});
+
+  const packages: SbomPackage[] = [];
+  for (const [installPath, entry] of entries) {
+    if (entry.link || (entry.dev && !includeDev)) continue;
+
+    const name = installPath.slice(installPath.lastIndexOf(NODE_MODULES) + NODE_MODULES.length);
+    let licenses = declaredLicenses(entry);
+    if (licenses.length === 0) {
+      licenses = await installedLicenses(directory, installPath);
+    }
+    packages.push({
+      name,
+      version: entry.version ?? '',
+      type: 'npm',
+      ...(licenses.length > 0 && { licenses }),
+    });
+  }
+  return { packages, lockFiles, unsupportedLockFiles };
+}
@@ -0,0 +1,38 @@
+/**
+ * Schema definition for scan-licenses tool
+ */
+
+import { z } from 'zod';
+
+export const scanLicensesSchema = z.object({
+  imageId: z
+    .string()
+    .optional()
+    .describe('Image to inventory with syft, e.g. myapp:1.0'),
+  path: z
+    .string()
+    .optional()
+    .describe('Repository directory whose lockfile lists the dependencies'),
+  sbomPath: z
+    .string()
+    .optional()
+    .describe('Existing CycloneDX or SPDX JSON SBOM to read instead of generating one'),
+  allowedLicenses: z
+    .array(z.string())
+    .optional()
+    .describe(
+      'Licenses that need no review: SPDX IDs, prefixes such as "BSD-*", or categories ("permissive", "weak-copyleft", "copyleft", "unknown")',
+    ),
+  deniedLicenses: z
+    .array(z.string())
+    .optional()
+    .describe(
+      'Licenses that fail the scan, in the same forms as allowedLicenses, e.g. ["GPL-*", "AGPL-*"]',
+    ),
+  includeDev: z
+    .boolean()
+    .optional()
+    .describe('Include development dependencies when reading a lockfile (default false)'),
+});
+
+export type ScanLicensesParams = z.infer<typeof scanLicensesSchema>;
@@ -0,0 +1,284 @@
+/**
+ * Scan Licenses Tool
+ *
+ * Builds a license inventory of an image's or repository's dependencies and
+ * checks it against an allowed/denied license policy. Packages come from an
+ * existing SBOM, from syft for images, or from the repository lockfile.
+ * Denied licenses fail the tool; the full inventory is still returned in the
+ * failure details so it can be attached to a legal review.
+ *
+ * The policy is read from the tool arguments, falling back to the
+ * CONTAINERIZATION_ASSIST_ALLOWED_LICENSES and
+ * CONTAINERIZATION_ASSIST_DENIED_LICENSES environment variables.
+ *
+ * This is a deterministic operational tool with no AI calls.
+ *
+ * @example
+ * ```typescript
+ * const result = await scanLicenses({ imageId: 'myapp:1.0', deniedLicenses: ['GPL-*'] }, context);
+ * ```
+ */
+
+import { readFile } from 'node:fs/promises';
+import type { Logger } from 'pino';
+import { parseListEnv } from '@/config/env-utils';
+import { setupToolContext } from '@/lib/tool-context-helpers';
+import { extractErrorMessage } from '@/lib/errors';
+import { validatePathOrFail } from '@/lib/validation-helpers';
+import { createSyftGenerator, parseSbomPackages, type SbomPackage } from '@/infra/security/sbom';
+import { pluralize, summarizeList } from '@/lib/summary-helpers';
+import type { ToolContext } from '@/mcp/context';
+import { Failure, Success, type Result } from '@/types';
+import { tool } from '@/types/tool';
+import {
+  evaluateLicenses,
+  LICENSE_CATEGORIES,
+  type LicenseCategory,
+  type LicensePolicy,
+  type LicenseStatus,
+  type LicenseVerdict,
+} from './license-policy';
+import { NPM_LOCKFILE, readLockfileLicenses } from './lockfile-licenses';
+import { scanLicensesSchema, type ScanLicensesParams } from './schema';
+
+export const ALLOWED_LICENSES_ENV = 'CONTAINERIZATION_ASSIST_ALLOWED_LICENSES';
+export const DENIED_LICENSES_ENV = 'CONTAINERIZATION_ASSIST_DENIED_LICENSES';
+
+export type LicenseInventorySource = 'sbom' | 'image' | 'lockfile';
+
+export interface LicensedPackage extends LicenseVerdict {
+  name: string;
+  version: string;
+  type?: string;
+}
+
+export interface ScanLicensesResult {
+  /**
+   * Natural language summary for user display.
+   * @example "✅ Scanned licenses of 212 packages in myapp:1.0: 208 permissive, 1 copyleft, 3 unknown. 4 packages need review: ..."
+   */
+  summary: string;
+  source: LicenseInventorySource;
+  /** Image, directory or SBOM file that was scanned */
+  target: string;
+  /** Whether no package uses a denied license */
+  passed: boolean;
+  policy: LicensePolicy;
+  /** Every package, denied first, then those needing review */
+  packages: LicensedPackage[];
+  /** Package count per license */
+  licenses: Record<string, number>;
+  categories: Record<LicenseCategory, number>;
+  statuses: Record<LicenseStatus, number>;
+  warnings?: string[];
+}
+
+interface Inventory {
+  source: LicenseInventorySource;
+  target: string;
+  packages: SbomPackage[];
+  warnings: string[];
+}
+
+const STATUS_ORDER: readonly LicenseStatus[] = ['denied', 'review', 'allowed'];
+
+/**
+ * Read packages from the first source given: sbomPath, imageId, then path
+ */
+async function loadInventory(
+  input: ScanLicensesParams,
+  logger: Logger,
+): Promise<Result<Inventory>> {
+  if (input.sbomPath) {
+    try {
+      const document = await readFile(input.sbomPath, 'utf-8');
+      const packages = [...parseSbomPackages(document).values()].flat();
+      return Success({ source: 'sbom', target: input.sbomPath, packages, warnings: [] });
+    } catch (error) {
+      return Failure(`Failed to read SBOM ${input.sbomPath}: ${extractErrorMessage(error)}`, {
+        message: 'SBOM could not be read',
+        hint: 'The SBOM must be a CycloneDX or SPDX JSON document',
+        resolution: 'Check the sbomPath, or pass imageId or path to build the inventory instead',
+      });
+    }
+  }
+
+  if (input.imageId) {
+    const generator = createSyftGenerator(logger);
+    if (!(await generator.isAvailable())) {
+      return Failure('Syft is required to list the licenses in an image', {
+        message: 'Syft is not installed',
+        hint: 'Image license inventories are read from a syft SBOM',
+        resolution:
+          'Install syft (https://github.com/anchore/syft#installation), or pass an existing SBOM as sbomPath',
+      });
+    }
+    const document = await generator.generate(input.imageId, 'cyclonedx');
+    if (!document.ok) return document;
+    const packages = [...parseSbomPackages(document.value).values()].flat();
+    return Success({ source: 'image', target: input.imageId, packages, warnings: [] });
+  }
+
+  if (input.path) {
+    const pathResult = await validatePathOrFail(input.path, {
+      mustExist: true,
+      mustBeDirectory: true,
+    });
+    if (!pathResult.ok) return pathResult;
+
+    const lockfile = await readLockfileLicenses(pathResult.value, input.includeDev);
+    const sbomHint = `generate an SBOM with \`syft dir:${input.path} -o cyclonedx-json\` and pass it as sbomPath`;
+    if (!lockfile.lockFiles.includes(NPM_LOCKFILE)) {
+      return Failure(`No lockfile with license information in ${input.path}`, {
+        message: 'No supported lockfile found',
+        hint:
+          lockfile.lockFiles.length > 0
+            ? `${summarizeList(lockfile.lockFiles)} ${lockfile.lockFiles.length === 1 ? 'does' : 'do'} not record licenses`
+            : 'Licenses are read from package-lock.json',
+        resolution: `Commit a package-lock.json, or ${sbomHint}`,
+      });
+    }
+    const warnings = lockfile.unsupportedLockFiles.map(
+      (file) => `${file} does not record licenses and was skipped; ${sbomHint}`,
+    );
+    return Success({
+      source: 'lockfile',
+      target: input.path,
+      packages: lockfile.packages,
+      warnings,
+    });
+  }
+
+  return Failure('Nothing to scan: provide imageId, path or sbomPath', {
+    message: 'No scan target given',
+    resolution: 'Pass imageId for an image, path for a repository, or sbomPath for an existing SBOM',
+  });
+}
+
+/**
+ * Evaluate every package, dropping duplicate installs of the same version
+ */
+function evaluatePackages(packages: SbomPackage[], policy: LicensePolicy): LicensedPackage[] {
+  const seen = new Set<string>();
+  const licensed: LicensedPackage[] = [];
+  for (const pkg of packages) {
+    const key = `${pkg.type ?? ''}:${pkg.name}@${pkg.version}`;
+    if (seen.has(key)) continue;
+    seen.add(key);
+    licensed.push({
+      name: pkg.name,
+      version: pkg.version,
+      ...(pkg.type && { type: pkg.type }),
+      ...evaluateLicenses(pkg.licenses, policy),
+    });
+  }
+  return licensed.sort(
+    (a, b) =>
+      STATUS_ORDER.indexOf(a.status) - STATUS_ORDER.indexOf(b.status) ||
+      a.name.localeCompare(b.name),
+  );
+}
+
+const describePackages = (packages: LicensedPackage[]): string =>
+  summarizeList(packages.map((pkg) => `${pkg.name} (${pkg.license})`));
+
+async function handleScanLicenses(
+  input: ScanLicensesParams,
+  ctx: ToolContext,
+): Promise<Result<ScanLicensesResult>> {
+  const { logger, timer } = setupToolContext(ctx, 'scan-licenses');
+
+  try {
+    const inventory = await loadInventory(input, logger);
+    if (!inventory.ok) return inventory;
+    const { source, target, warnings } = inventory.value;
+
+    const policy: LicensePolicy = {
+      allowed: input.allowedLicenses ?? parseListEnv(ALLOWED_LICENSES_ENV),
+      denied: input.deniedLicenses ?? parseListEnv(DENIED_LICENSES_ENV),
+    };
+    const packages = evaluatePackages(inventory.value.packages, policy);
+
+    const licenses: Record<string, number> = {};
+    const categories: Record<LicenseCategory, number> = {
+      permissive: 0,
+      'weak-copyleft': 0,
+      copyleft: 0,
+      unknown: 0,
+    };
+    const statuses: Record<LicenseStatus, number> = { allowed: 0, review: 0, denied: 0 };
+    for (const pkg of packages) {
+      licenses[pkg.license] = (licenses[pkg.license] ?? 0) + 1;
+      categories[pkg.category]++;
+      statuses[pkg.status]++;
+    }
+
+    const breakdown = LICENSE_CATEGORIES.filter((category) => categories[category] > 0)
+      .map((category) => `${categories[category]} ${category}`)
+      .join(', ');
+    const denied = packages.filter((pkg) => pkg.status === 'denied');
+    const review = packages.filter((pkg) => pkg.status === 'review');
+
+    let scanned = `Scanned licenses of ${pluralize(packages.length, 'package')} in ${target}${breakdown ? `: ${breakdown}` : ''}.`;
+    if (review.length > 0) {
+      scanned += ` ${pluralize(review.length, 'package')} ${review.length === 1 ? 'needs' : 'need'} review: ${describePackages(review)}.`;
+    }
+    const summary =
+      denied.length > 0
+        ? `❌ ${pluralize(denied.length, 'package')} ${denied.length === 1 ? 'uses' : 'use'} denied licenses: ${describePackages(denied)}. ${scanned}`
+        : `✅ ${scanned}`;
+
+    const result: ScanLicensesResult = {
+      summary,
+      source,
+      target,
+      passed: denied.length === 0,
+      policy,
+      packages,
+      licenses,
+      categories,
+      statuses,
+      ...(warnings.length > 0 && { warnings }),
+    };
+
+    logger.info({ source, target, ...statuses }, 'License scan completed');
+    timer.end({ packages: packages.length, ...statuses });
+
+    // Policy gate: fail the tool, but keep the inventory available to the caller
+    if (denied.length > 0) {
+      const deniedLicenses = [...new Set(denied.map((pkg) => pkg.license))];
+      return Failure(
+        `License policy failed: ${pluralize(denied.length, 'package')} ${denied.length === 1 ? 'uses' : 'use'} denied licenses (${summarizeList(deniedLicenses)})`,
+        {
+          message: `${target} has dependencies under denied licenses`,
+          hint: summary,
+          resolution:
+            'Replace or remove the listed packages, or get a legal exception and add the license to allowedLicenses.',
+          details: { report: result },
+        },
+      );
+    }
+
+    return Success(result);
+  } catch (error) {
+    timer.error(error);
+    return Failure(extractErrorMessage(error), {
+      message: extractErrorMessage(error),
+      hint: 'An unexpected error occurred while building the license inventory',
+      resolution: 'Check that the lockfile or SBOM is valid JSON and the image exists locally',
+    });
+  }
+}
+
+export default tool({
+  name: 'scan-licenses',
+  description:
+    'List dependency licenses of an image or repository and check them against an allowed/denied license policy',
+  category: 'docker',
+  version: '1.0.0',
+  schema: scanLicensesSchema,
+  metadata: {
+    knowledgeEnhanced: false,
+  },
+  handler: handleScanLicenses,
+});
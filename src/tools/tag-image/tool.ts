@@ -10,7 +10,7 @@
 import { setupToolContext } from '@/lib/tool-context-helpers';
 import { extractErrorMessage } from '@/lib/errors';
 import { createDockerClient } from '@/infra/docker/client';
-import { parseImageName } from '@/lib/validation-helpers';
+import { imageRefName, parseImageRef } from '@/lib/image-ref';
 import { Success, Failure, type Result } from '@/types';
 import type { ToolContext } from '@/mcp/context';
 import { tool } from '@/types/tool';
@@ -50,10 +50,18 @@ async function handleTagImage(
   }
 
   // Parse and validate image name
-  const parsedImage = parseImageName(tag);
+  const parsedImage = parseImageRef(tag);
   if (!parsedImage.ok) {
     return parsedImage;
   }
+  if (parsedImage.value.digest) {
+    return Failure('Cannot tag an image with a digest reference', {
+      message: 'Tag must not include a digest',
+      hint: 'Digests identify image content and are assigned by the registry, not by tagging',
+      resolution: 'Remove the @digest part from the tag, e.g. myapp:v1.0',
+      details: { tag },
+    });
+  }
 
   try {
     const dockerClient = createDockerClient(logger);
@@ -68,12 +76,12 @@ async function handleTagImage(
       });
     }
 
-    // Extract repository and tag from parsed image
-    const { repository, tag: tagName } = parsedImage.value;
+    const daemon = await dockerClient.ping();
+    if (!daemon.ok) return daemon;
+
     // For Docker tag operation, repository includes registry if present
-    const fullRepository = parsedImage.value.registry
-      ? `${parsedImage.value.registry}/${repository}`
-      : repository;
+    const fullRepository = imageRefName(parsedImage.value);
+    const tagName = parsedImage.value.tag ?? 'latest';
 
     const tagResult = await dockerClient.tagImage(source, fullRepository, tagName);
     if (!tagResult.ok) {
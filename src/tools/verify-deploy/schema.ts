@@ -7,6 +7,18 @@ export const verifyDeploySchema = z.object({
     .array(z.enum(['pods', 'services', 'ingress', 'health']))
     .optional()
     .describe('Checks to perform'),
+  waitForReady: z
+    .boolean()
+    .optional()
+    .describe(
+      'Wait for the rollout to finish, reporting ready pod counts as progress (default: true). When false, the current status is checked once.',
+    ),
+  timeoutSeconds: z
+    .number()
+    .int()
+    .positive()
+    .optional()
+    .describe('How long to wait for the deployment to become ready (default: 60)'),
 });
 
 export type VerifyDeployParams = z.infer<typeof verifyDeploySchema>;
@@ -25,11 +25,16 @@
 
 import { setupToolContext } from '@/lib/tool-context-helpers';
 import { extractErrorMessage } from '@/lib/errors';
-import type { ToolContext } from '@/mcp/context';
-import { createKubernetesClient, type KubernetesClient } from '@/infra/kubernetes/client';
+import { reportStep, type ToolContext } from '@/mcp/context';
+import {
+  createKubernetesClient,
+  type KubernetesClient,
+  type PodEvent,
+} from '@/infra/kubernetes/client';
+import { formatRolloutProgress, type RolloutProgressCallback } from '@/infra/kubernetes/rollout';
 
 import { DEFAULT_TIMEOUTS } from '@/config/constants';
-import { Success, Failure, type Result } from '@/types';
+import { Success, Failure, Cancelled, type Result } from '@/types';
 import { verifyDeploySchema, type VerifyDeployParams } from './schema';
 import { buildStatusSummary } from '@/lib/summary-helpers';
 
@@ -79,6 +84,8 @@ export interface VerifyDeploymentResult extends Record<string, unknown> {
       message?: string;
     }>;
   };
+  /** Pod events explaining why the deployment is not ready (only when not ready) */
+  events?: PodEvent[];
   workflowHints?: {
     nextStep: string;
     message: string;
@@ -93,6 +100,9 @@ async function checkDeploymentHealth(
   namespace: string,
   deploymentName: string,
   timeout: number,
+  waitForReady: boolean,
+  onProgress?: RolloutProgressCallback,
+  signal?: AbortSignal,
 ): Promise<{
   ready: boolean;
   readyReplicas: number;
@@ -100,8 +110,17 @@ async function checkDeploymentHealth(
   status: 'healthy' | 'unhealthy' | 'unknown';
   message: string;
 }> {
-  // Use shared waitForDeploymentReady from client
-  const waitResult = await k8sClient.waitForDeploymentReady(namespace, deploymentName, timeout);
+  // Use shared waitForDeploymentReady from client, or a single status read
+  const waitResult = waitForReady
+    ? await k8sClient.waitForDeploymentReady(
+        namespace,
+        deploymentName,
+        timeout,
+        DEFAULT_TIMEOUTS.deploymentPoll,
+        onProgress,
+        signal,
+      )
+    : await k8sClient.getDeploymentStatus(namespace, deploymentName);
 
   if (waitResult.ok && waitResult.value?.ready) {
     return {
@@ -125,6 +144,39 @@ async function checkDeploymentHealth(
   };
 }
 
+/**
+ * Collect events for the deployment's unready pods.
+ * Best-effort: diagnostics must not turn a "not ready" result into a failure.
+ */
+async function collectUnreadyPodEvents(
+  k8sClient: KubernetesClient,
+  namespace: string,
+  deploymentName: string,
+): Promise<PodEvent[]> {
+  try {
+    const pods = await k8sClient.listDeploymentPods(namespace, deploymentName);
+    if (!pods.ok) return [];
+
+    const unready = pods.value.filter((pod) => !pod.ready).map((pod) => pod.name);
+    const events = await k8sClient.listPodEvents(namespace, unready);
+    return events.ok ? events.value : [];
+  } catch {
+    return [];
+  }
+}
+
+/**
+ * Summarize warning events for the summary line
+ */
+function describeEvents(events: PodEvent[]): string {
+  const warnings = [
+    ...new Set(events.filter((e) => e.type === 'Warning').map((e) => `${e.reason}: ${e.message}`)),
+  ];
+  return warnings.length > 0
+    ? `Recent pod events: ${warnings.slice(-3).join('; ')}`
+    : 'Check pod logs for details.';
+}
+
 /**
  * Check endpoint health
  */
@@ -183,9 +235,11 @@ async function handleVerifyDeployment(
     deploymentName: configDeploymentName,
     namespace: configNamespace,
     checks = ['pods', 'services', 'health'],
+    waitForReady = true,
   } = params;
 
-  const timeout = Math.floor(DEFAULT_TIMEOUTS.verification / 1000); // Convert ms to seconds
+  // Default verification timeout is configured in ms
+  const timeout = params.timeoutSeconds ?? Math.floor(DEFAULT_TIMEOUTS.verification / 1000);
 
   try {
     logger.info({ checks }, 'Starting Kubernetes deployment verification');
@@ -207,8 +261,54 @@ async function handleVerifyDeployment(
 
     logger.info({ namespace, deploymentName }, 'Checking deployment health');
 
+    // Report ready pods as completed steps so clients can show an ETA for the rollout.
+    // MCP progress must increase, so a drop in ready pods (e.g. a restart) is not reported.
+    const { progress } = context;
+    const waitStartedAt = Date.now();
+    let reportedReady = -1;
+    const onProgress: RolloutProgressCallback | undefined = progress
+      ? async (status) => {
+          if (status.readyReplicas <= reportedReady) return;
+          reportedReady = status.readyReplicas;
+          await reportStep(progress, {
+            index: status.readyReplicas,
+            total: Math.max(status.totalReplicas, status.readyReplicas),
+            name: formatRolloutProgress(status),
+            elapsedMs: Date.now() - waitStartedAt,
+          });
+        }
+      : undefined;
+
     // Check deployment health
-    const health = await checkDeploymentHealth(k8sClient, namespace, deploymentName, timeout);
+    const health = await checkDeploymentHealth(
+      k8sClient,
+      namespace,
+      deploymentName,
+      timeout,
+      waitForReady,
+      onProgress,
+      context.signal,
+    );
+
+    // Stop after the rollout wait, keeping the status read so far
+    if (context.signal?.aborted) {
+      logger.info({ deploymentName, ready: health.ready }, 'Verification cancelled');
+      timer.end({ deploymentName, cancelled: true });
+      return Cancelled(
+        {
+          namespace,
+          deploymentName,
+          ready: health.ready,
+          readyReplicas: health.readyReplicas,
+          totalReplicas: health.totalReplicas,
+        },
+        `Verification of deployment ${deploymentName} was cancelled`,
+      );
+    }
+
+    const events = health.ready
+      ? []
+      : await collectUnreadyPodEvents(k8sClient, namespace, deploymentName);
 
     // Initialize health checks
     const healthChecks: Array<{ name: string; status: 'pass' | 'fail'; message?: string }> = [];
@@ -244,7 +344,7 @@ async function handleVerifyDeployment(
     const summary = buildStatusSummary(
       isSuccessful,
       `Deployment ${deploymentName} is healthy. ${health.readyReplicas}/${health.totalReplicas} pods ready. All health checks passing.`,
-      `Deployment ${deploymentName} has issues. ${health.readyReplicas}/${health.totalReplicas} pods ready. Check pod logs for details.`,
+      `Deployment ${deploymentName} has issues. ${health.readyReplicas}/${health.totalReplicas} pods ready. ${describeEvents(events)}`,
     );
 
     // Prepare the result
@@ -278,6 +378,7 @@ async function handleVerifyDeployment(
         message: health.message,
         ...(healthChecks.length > 0 && { checks: healthChecks }),
       },
+      ...(events.length > 0 && { events }),
       workflowHints: {
         nextStep: isSuccessful ? 'ops' : 'fix-deployment-issues',
         message: isSuccessful
@@ -17,6 +17,11 @@ export interface ErrorGuidance {
   resolution?: string;
   /** Additional context or details */
   details?: Record<string, unknown>;
+  /**
+   * Whether the same call may succeed if repeated (network blips, timeouts).
+   * Unset when unknown; the retry policy then falls back to its own checks.
+   */
+  retryable?: boolean;
 }
 
 // ===== WORKFLOW GUIDANCE SYSTEM =====
@@ -63,3 +68,33 @@ export const Failure = <T>(error: string, guidance?: ErrorGuidance): Result<T> =
   const resultGuidance = guidance ? { ...guidance, message: guidance.message || error } : undefined;
   return resultGuidance ? { ok: false, error, guidance: resultGuidance } : { ok: false, error };
 };
+
+/**
+ * Create a failure for an operation cancelled part-way through
+ *
+ * The work completed before cancellation is kept in guidance details as
+ * `partialResult`, so callers can recover it with getPartialResult.
+ * @param partial - Work completed before the cancellation
+ * @param reason - Error message
+ */
+export const Cancelled = <T>(partial: unknown, reason = 'Operation cancelled'): Result<T> =>
+  Failure(reason, {
+    message: reason,
+    details: { cancelled: true, partialResult: partial },
+    // Repeating a cancelled call would only be cancelled again
+    retryable: false,
+  });
+
+/**
+ * Whether a result is a Cancelled failure
+ */
+export const isCancelled = (result: Result<unknown>): boolean =>
+  !result.ok && result.guidance?.details?.cancelled === true;
+
+/**
+ * Work completed before cancellation, or undefined if the result is not a Cancelled failure
+ */
+export const getPartialResult = (result: Result<unknown>): unknown => {
+  const details = result.ok ? undefined : result.guidance?.details;
+  return details?.cancelled === true ? details.partialResult : undefined;
+};
@@ -12,6 +12,15 @@ import type { Result } from './core';
 import type { TransportConfig } from '@/app';
 import type { MCPServer, OutputFormat } from '@/mcp/mcp-server';
 import type { Tool, ToolName } from '@/tools';
+import type { ToolCategory } from './categories';
+import type { ToolCapability } from './tool';
+import type { ToolMetadata } from './tool-metadata';
+import type { ToolMetrics } from '@/lib/tool-metrics';
+import type { RetryPolicy } from '@/lib/retry';
+import type { CircuitBreaker } from '@/lib/circuit-breaker';
+import type { ResultCache } from '@/lib/result-cache';
+import type { AuditLog } from '@/lib/audit';
+import type { WorkflowStateStore } from '@/lib/workflow-state';
 
 // Extract input/output types from tool registry
 type ExtractToolInput<T extends { schema: ZodTypeAny }> = T['schema'] extends ZodTypeAny
@@ -40,6 +49,9 @@ export interface ExecutionMetadata {
   /** Request ID for tracing */
   requestId?: string;
 
+  /** Session of the caller, recorded in the audit log */
+  sessionId?: string;
+
   /** Optional abort signal for cancellation support */
   signal?: AbortSignal;
 
@@ -49,10 +61,25 @@ export interface ExecutionMetadata {
   /** MCP notification callback for progress updates */
   sendNotification?: (notification: unknown) => Promise<void>;
 
+  /** Retry transient failures with exponential backoff (see DEFAULT_RETRY_POLICY) */
+  retry?: Partial<RetryPolicy>;
+
   /** Additional metadata */
   [key: string]: unknown;
 }
 
+/**
+ * Registered tool as described by AppRuntime.listToolMetadata
+ */
+export interface ToolDescriptor {
+  name: ToolName;
+  description: string;
+  version?: string;
+  category?: ToolCategory;
+  metadata: ToolMetadata;
+  capabilities: ToolCapability[];
+}
+
 /**
  * Strongly typed AppRuntime interface with dependency injection support
  */
@@ -76,6 +103,21 @@ export interface AppRuntime {
     category?: string;
   }>;
 
+  /**
+   * List all available tools with their metadata and capabilities
+   */
+  listToolMetadata(): ToolDescriptor[];
+
+  /**
+   * Names of the tools in a category
+   */
+  filterByCategory(category: ToolCategory): ToolName[];
+
+  /**
+   * Whether a registered tool supports a capability (false for unknown tools)
+   */
+  hasCapability(toolName: string, capability: ToolCapability): boolean;
+
   /**
    * Start MCP server with specified transport
    */
@@ -98,6 +140,8 @@ export interface AppRuntime {
         available: boolean;
         version?: string;
         error?: string;
+        /** Socket that was probed */
+        socketPath?: string;
       };
       kubernetes?: {
         available: boolean;
@@ -140,6 +184,27 @@ export interface AppRuntimeConfig {
 
   /** Output format for tool responses */
   outputFormat?: OutputFormat;
+
+  /** Maximum tools executing at once; excess requests queue (unset or 0 = unlimited) */
+  maxConcurrentToolExecutions?: number;
+
+  /** Requests allowed to wait for a slot; more fail with QUEUE_FULL (unset or 0 = unbounded) */
+  maxQueuedToolExecutions?: number;
+
+  /** Recorder for per-tool latency histograms and outcome counters */
+  metrics?: ToolMetrics;
+
+  /** Short-circuits tools after repeated consecutive failures (see createCircuitBreaker) */
+  circuitBreaker?: CircuitBreaker;
+
+  /** Reuses results of idempotent tools such as analyze-repo (see createResultCache) */
+  resultCache?: ResultCache;
+
+  /** Audit trail of tool executions (see createAuditLog); also served as the trace resource */
+  audit?: AuditLog;
+
+  /** Completed pipeline stages per session, read by resume-workflow */
+  workflowState?: WorkflowStateStore;
 }
 
 /**
@@ -6,6 +6,7 @@
 
 import { z } from 'zod';
 import { Result, Success, Failure } from './core';
+import { findSchemaIssues } from '@/lib/zod-utils';
 
 /**
  * Tool Metadata Schema
@@ -16,6 +17,11 @@ import { Result, Success, Failure } from './core';
 const ToolMetadataSchema = z.object({
   /** Whether this tool uses knowledge enhancement (required) */
   knowledgeEnhanced: z.boolean(),
+  /**
+   * How long a successful result may be reused for identical arguments (ms).
+   * Only for idempotent tools; unset means results are never cached.
+   */
+  cacheTtlMs: z.number().int().positive().optional(),
 });
 
 export type ToolMetadata = z.infer<typeof ToolMetadataSchema>;
@@ -48,6 +54,8 @@ function validateToolMetadata(metadata: unknown): Result<ToolMetadata> {
 export interface ValidatableTool {
   name: string;
   metadata: ToolMetadata;
+  /** Input schema; when given, its fields are checked for descriptions and JSON-compatible types */
+  schema?: z.ZodTypeAny;
 }
 
 /**
@@ -77,6 +85,8 @@ export interface ValidationReport {
  */
 const VALIDATION_SUGGESTIONS: Record<string, string> = {
   'Invalid metadata schema': 'Fix metadata schema validation errors',
+  'Incomplete input schema':
+    'Describe every input field with .describe() and use JSON-compatible types only',
 };
 
 /**
@@ -92,6 +102,9 @@ export async function validateAllToolMetadata(
     const metadataErrors: Array<{ name: string; error: string }> = [];
 
     for (const tool of tools) {
+      const issues: string[] = [];
+      const suggestions: string[] = [];
+
       // Validate metadata schema compliance
       const metadataValidation = validateToolMetadata(tool.metadata);
       if (!metadataValidation.ok) {
@@ -99,14 +112,24 @@ export async function validateAllToolMetadata(
           name: tool.name,
           error: metadataValidation.error,
         });
-        invalidTools.push({
-          name: tool.name,
-          issues: ['Invalid metadata schema'],
-          suggestions: [
-            VALIDATION_SUGGESTIONS['Invalid metadata schema'] ??
-              'Fix metadata schema validation errors',
-          ],
-        });
+        issues.push('Invalid metadata schema');
+        suggestions.push(
+          VALIDATION_SUGGESTIONS['Invalid metadata schema'] ??
+            'Fix metadata schema validation errors',
+        );
+      }
+
+      // Undescribed or non-JSON inputs end up as unusable fields in the published MCP schema
+      const schemaIssues = tool.schema ? findSchemaIssues(tool.schema) : [];
+      if (schemaIssues.length > 0) {
+        issues.push(...schemaIssues);
+        suggestions.push(
+          VALIDATION_SUGGESTIONS['Incomplete input schema'] ?? 'Describe every input field',
+        );
+      }
+
+      if (issues.length > 0) {
+        invalidTools.push({ name: tool.name, issues, suggestions });
       } else {
         validTools.push(tool.name);
       }
@@ -45,6 +45,13 @@ export interface Tool<TSchema extends z.ZodTypeAny = z.ZodTypeAny, TOut = unknow
   /** Optional workflow guidance hints for tool chaining */
   chainHints?: ChainHints;
 
+  /**
+   * Fingerprint of what a cached result depends on besides the arguments,
+   * such as file modification times. A cached result is only reused while
+   * the fingerprint is unchanged; undefined skips the cache for that call.
+   */
+  cacheFingerprint?: (input: z.infer<TSchema>) => Promise<string | undefined>;
+
   /** Parse and validate untyped arguments to strongly-typed input (matches Zod API) */
   parse: (args: unknown) => z.infer<TSchema>;
 
@@ -65,6 +72,7 @@ export function tool<TSchema extends z.ZodTypeAny, TOut>(config: {
   category?: ToolCategory;
   version?: string;
   chainHints?: ChainHints;
+  cacheFingerprint?: (input: z.infer<TSchema>) => Promise<string | undefined>;
 }): Tool<TSchema, TOut> {
   return {
     ...config,
@@ -72,3 +80,22 @@ export function tool<TSchema extends z.ZodTypeAny, TOut>(config: {
     parse: (args: unknown) => config.schema.parse(args), // Uses Zod's parse, throws on invalid
   };
 }
+
+/**
+ * Optional behaviour a tool supports, derived from its definition
+ */
+export type ToolCapability =
+  | 'knowledge-enhanced' // Uses the knowledge base (metadata.knowledgeEnhanced)
+  | 'cacheable' // Results may be reused for identical arguments (metadata.cacheTtlMs)
+  | 'chain-hints'; // Suggests follow-up tools after it runs
+
+/**
+ * List the capabilities a tool supports
+ */
+export function toolCapabilities(tool: Pick<Tool, 'metadata' | 'chainHints'>): ToolCapability[] {
+  const capabilities: ToolCapability[] = [];
+  if (tool.metadata.knowledgeEnhanced) capabilities.push('knowledge-enhanced');
+  if (tool.metadata.cacheTtlMs !== undefined) capabilities.push('cacheable');
+  if (tool.chainHints) capabilities.push('chain-hints');
+  return capabilities;
+}
@@ -0,0 +1,312 @@
+/**
+ * Docker Compose validation using YAML parser (Functional)
+ *
+ * Understands compose v2/v3 files and the version-less Compose Specification.
+ * Rules run per service; file-level problems (unparseable YAML, no services,
+ * unsupported version) are reported as a single error result.
+ */
+
+import { parse as parseYaml } from 'yaml';
+import { extractErrorMessage } from '@/lib/errors';
+import {
+  ComposeService,
+  ComposeValidationRule,
+  ValidationResult,
+  ValidationReport,
+  ValidationSeverity,
+  ValidationCategory,
+  ValidationGrade,
+} from './core-types';
+
+export interface ComposeValidatorInstance {
+  validate(yamlContent: string): ValidationReport;
+  getRules(): ComposeValidationRule[];
+  getCategory(category: ValidationCategory): ComposeValidationRule[];
+}
+
+/**
+ * Host paths that give a container control over the host when bind-mounted
+ */
+const SENSITIVE_HOST_PATHS = [
+  '/etc',
+  '/proc',
+  '/sys',
+  '/dev',
+  '/boot',
+  '/root',
+  '/var/run/docker.sock',
+  '/run/docker.sock',
+  '/var/lib/docker',
+  '~/.ssh',
+  '~/.aws',
+  '~/.kube',
+];
+
+/**
+ * Get host source paths of bind mounts declared on a service
+ */
+const getBindSources = (service: ComposeService): string[] => {
+  return (service.volumes || []).flatMap((volume) => {
+    if (typeof volume === 'string') {
+      // Short syntax: SOURCE:TARGET[:MODE]; named volumes have no path prefix
+      const [source] = volume.split(':');
+      return source && /^[/.~]/.test(source) ? [source] : [];
+    }
+    return volume?.type === 'bind' && volume.source ? [volume.source] : [];
+  });
+};
+
+const isSensitiveHostPath = (source: string): boolean => {
+  const path = source.length > 1 ? source.replace(/\/+$/, '') : source;
+  if (path === '/') return true;
+  return SENSITIVE_HOST_PATHS.some(
+    (sensitive) => path === sensitive || path.startsWith(`${sensitive}/`),
+  );
+};
+
+/**
+ * Get the tag of an image reference, or undefined when it has none
+ */
+const getImageTag = (image: string): string | undefined => {
+  const name = image.split('@')[0] ?? image;
+  const lastSegment = name.slice(name.lastIndexOf('/') + 1);
+  const separator = lastSegment.indexOf(':');
+  return separator === -1 ? undefined : lastSegment.slice(separator + 1);
+};
+
+/**
+ * Compose validation rules
+ */
+const COMPOSE_RULES: ComposeValidationRule[] = [
+  {
+    id: 'has-image-or-build',
+    name: 'Image or build defined',
+    description: 'Services must define an image to run or a build context',
+    check: (service: ComposeService) => !!(service.image || service.build),
+    message: 'Define image or build for the service',
+    severity: ValidationSeverity.ERROR,
+    fix: 'Add image: <name>:<tag> or build: <context>',
+    category: ValidationCategory.BEST_PRACTICE,
+  },
+
+  {
+    id: 'has-resource-limits',
+    name: 'Resource limits defined',
+    description: 'Services should limit CPU and memory',
+    check: (service: ComposeService) => {
+      const limits = service.deploy?.resources?.limits;
+      // v2 files set limits on the service itself, v3 under deploy.resources
+      const cpus = limits?.cpus ?? service.cpus;
+      const memory = limits?.memory ?? service.mem_limit;
+      return cpus !== undefined && memory !== undefined;
+    },
+    message: 'Define CPU and memory limits for the service',
+    severity: ValidationSeverity.WARNING,
+    fix: 'Add deploy.resources.limits.cpus and deploy.resources.limits.memory',
+    category: ValidationCategory.BEST_PRACTICE,
+  },
+
+  {
+    id: 'no-latest-tag',
+    name: 'Pinned image tag',
+    description: 'Images should use a specific tag rather than latest',
+    check: (service: ComposeService) => {
+      if (!service.image) return true;
+      // Digests are immutable regardless of tag
+      if (service.image.includes('@')) return true;
+
+      const tag = getImageTag(service.image);
+      return tag !== undefined && tag !== 'latest';
+    },
+    message: 'Pin the image to a specific version tag',
+    severity: ValidationSeverity.WARNING,
+    fix: 'Replace latest or a missing tag with a version, e.g. nginx:1.27',
+    category: ValidationCategory.BEST_PRACTICE,
+  },
+
+  {
+    id: 'no-host-network',
+    name: 'Avoid host networking',
+    description: 'Services should not share the host network stack',
+    check: (service: ComposeService) => service.network_mode !== 'host',
+    message: 'Avoid network_mode: host unless absolutely necessary',
+    severity: ValidationSeverity.WARNING,
+    fix: 'Remove network_mode: host and publish ports instead',
+    category: ValidationCategory.SECURITY,
+  },
+
+  {
+    id: 'no-sensitive-bind-mounts',
+    name: 'No sensitive host mounts',
+    description: 'Services should not bind-mount sensitive host paths',
+    check: (service: ComposeService) => !getBindSources(service).some(isSensitiveHostPath),
+    message: 'Do not bind-mount sensitive host paths such as /etc or the Docker socket',
+    severity: ValidationSeverity.ERROR,
+    fix: 'Use named volumes, configs, or secrets instead of host paths',
+    category: ValidationCategory.SECURITY,
+  },
+];
+
+/**
+ * Calculate validation grade from score
+ */
+const calculateGrade = (score: number): ValidationGrade => {
+  if (score >= 90) return 'A';
+  if (score >= 80) return 'B';
+  if (score >= 70) return 'C';
+  if (score >= 60) return 'D';
+  return 'F';
+};
+
+/**
+ * Create validation report from results
+ */
+const createReport = (results: ValidationResult[]): ValidationReport => {
+  const errors = results.filter(
+    (r) => !r.passed && r.metadata?.severity === ValidationSeverity.ERROR,
+  ).length;
+  const warnings = results.filter(
+    (r) => !r.passed && r.metadata?.severity === ValidationSeverity.WARNING,
+  ).length;
+  const info = results.filter(
+    (r) => !r.passed && r.metadata?.severity === ValidationSeverity.INFO,
+  ).length;
+  const passed = results.filter((r) => r.passed).length;
+  const total = results.length;
+
+  // Same weighting as the Kubernetes validator so scores are comparable
+  const score = Math.max(0, 100 - errors * 15 - warnings * 5 - info * 2);
+
+  return {
+    results,
+    score,
+    grade: calculateGrade(score),
+    passed,
+    failed: total - passed,
+    errors,
+    warnings,
+    info,
+    timestamp: new Date().toISOString(),
+  };
+};
+
+/**
+ * Report for a file that cannot be validated at all
+ */
+const createFailureReport = (ruleId: string, message: string): ValidationReport => {
+  return {
+    results: [
+      {
+        ruleId,
+        isValid: false,
+        passed: false,
+        errors: [message],
+        warnings: [],
+        message,
+        metadata: {
+          severity: ValidationSeverity.ERROR,
+        },
+      },
+    ],
+    score: 0,
+    grade: 'F',
+    passed: 0,
+    failed: 1,
+    errors: 1,
+    warnings: 0,
+    info: 0,
+    timestamp: new Date().toISOString(),
+  };
+};
+
+const isObject = (value: unknown): value is Record<string, unknown> =>
+  !!value && typeof value === 'object' && !Array.isArray(value);
+
+/**
+ * Validate docker-compose YAML content
+ */
+const validateComposeContent = (yamlContent: string): ValidationReport => {
+  let document: unknown;
+  try {
+    // Compose files commonly share settings through anchors and `<<` merge keys
+    document = parseYaml(yamlContent, { merge: true });
+  } catch (parseError) {
+    return createFailureReport(
+      'parse-error',
+      `Failed to parse YAML: ${extractErrorMessage(parseError)}`,
+    );
+  }
+
+  if (!isObject(document)) {
+    return createFailureReport('no-services', 'No services found in compose file');
+  }
+
+  // version is obsolete in the Compose Specification but still common; only 2.x and 3.x exist
+  const version = document.version;
+  if (version !== undefined && !/^[23](\.\d+)?$/.test(String(version))) {
+    return createFailureReport(
+      'unsupported-version',
+      `Unsupported compose file version: ${String(version)}`,
+    );
+  }
+
+  const services = document.services;
+  if (!isObject(services) || Object.keys(services).length === 0) {
+    return createFailureReport('no-services', 'No services found in compose file');
+  }
+
+  const allResults: ValidationResult[] = [];
+
+  for (const [serviceName, definition] of Object.entries(services)) {
+    // A service declared with no body (`web:`) has no fields to satisfy the rules
+    const service: ComposeService = isObject(definition) ? definition : {};
+
+    for (const rule of COMPOSE_RULES) {
+      const passed = rule.check(service);
+
+      allResults.push({
+        ruleId: `${serviceName}-${rule.id}`,
+        isValid: passed,
+        passed,
+        errors: passed ? [] : [`[${serviceName}] ${rule.name}: ${rule.message}`],
+        warnings: [],
+        message: passed
+          ? `✓ [${serviceName}] ${rule.name}`
+          : `✗ [${serviceName}] ${rule.name}: ${rule.message}`,
+        suggestions: !passed && rule.fix ? [rule.fix] : [],
+        metadata: {
+          severity: rule.severity,
+          location: `services.${serviceName}`,
+          category: rule.category,
+        },
+      });
+    }
+  }
+
+  return createReport(allResults);
+};
+
+/**
+ * Get all compose validation rules
+ */
+const getComposeRules = (): ComposeValidationRule[] => {
+  return [...COMPOSE_RULES];
+};
+
+/**
+ * Get compose rules by category
+ */
+const getComposeRulesByCategory = (category: ValidationCategory): ComposeValidationRule[] => {
+  return COMPOSE_RULES.filter((rule) => rule.category === category);
+};
+
+/**
+ * Create a docker-compose validator factory
+ */
+export const createComposeValidator = (): ComposeValidatorInstance => {
+  return {
+    validate: validateComposeContent,
+    getRules: getComposeRules,
+    getCategory: getComposeRulesByCategory,
+  };
+};
@@ -51,6 +51,8 @@ export interface DockerfileValidationRule {
   name: string;
   description: string;
   check: (commands: CommandEntry[]) => boolean;
+  /** Instructions the check reads; omitted when any change can affect it */
+  instructions?: string[];
   message: string;
   severity: ValidationSeverity;
   fix?: string;
@@ -71,11 +73,66 @@ export interface KubernetesManifest {
   [key: string]: unknown;
 }
 
+// Request-scoped settings passed to a single Kubernetes validate() call
+export interface KubernetesValidationContext {
+  /** Registries (optionally with a path prefix) images may come from; unset = any */
+  allowedRegistries?: string[];
+  /** Cluster version to check API versions against, e.g. `1.29`; unset = skip */
+  targetVersion?: string;
+}
+
+// Label or annotation key a resource must or should carry, optionally with a value pattern
+export interface MetadataKeyRequirement {
+  key: string;
+  /** Value must match; strings are compiled with `new RegExp` */
+  pattern?: RegExp | string;
+}
+
+// Label and annotation conventions checked on every resource's metadata
+export interface LabelPolicy {
+  requiredLabels?: Array<string | MetadataKeyRequirement>;
+  recommendedLabels?: Array<string | MetadataKeyRequirement>;
+  requiredAnnotations?: Array<string | MetadataKeyRequirement>;
+  recommendedAnnotations?: Array<string | MetadataKeyRequirement>;
+}
+
 export interface KubernetesValidationRule {
   id: string;
   name: string;
   description: string;
-  check: (manifest: KubernetesManifest) => boolean;
+  check: (manifest: KubernetesManifest, context: KubernetesValidationContext) => boolean;
+  message: string;
+  severity: ValidationSeverity;
+  fix?: string;
+  /** Manifest-specific fix for a failed check; takes precedence over `fix` */
+  suggest?: (manifest: KubernetesManifest, context: KubernetesValidationContext) => string;
+  category: ValidationCategory;
+}
+
+// Compose service entry; covers the v2 and v3 fields the rules inspect
+export interface ComposeService {
+  image?: string;
+  build?: string | Record<string, unknown>;
+  network_mode?: string;
+  volumes?: Array<string | { type?: string; source?: string; target?: string }>;
+  mem_limit?: string | number;
+  cpus?: string | number;
+  deploy?: {
+    resources?: {
+      limits?: {
+        cpus?: string | number;
+        memory?: string;
+      };
+    };
+  };
+  [key: string]: unknown;
+}
+
+export interface ComposeValidationRule {
+  id: string;
+  name: string;
+  description: string;
+  check: (service: ComposeService) => boolean;
   message: string;
   severity: ValidationSeverity;
   fix?: string;
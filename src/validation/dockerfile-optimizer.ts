@@ -0,0 +1,590 @@
+/**
+ * Dockerfile Optimizer
+ *
+ * Finds cache and size improvements in a Dockerfile and, where the rewrite
+ * cannot change what the build does, applies them:
+ * - system-packages-before-source: OS package installs moved ahead of the
+ *   source COPY so code edits don't re-run them
+ * - dependencies-before-source: dependency manifests copied and installed
+ *   before the rest of the source
+ * - combine-run: consecutive RUNs of the same tool merged into one layer
+ * - slim-base-image: lighter runtime base (suggested only, never applied)
+ *
+ * Rewrites only move or merge instructions. The optimized output is
+ * re-parsed and discarded if any original instruction would be lost.
+ */
+
+export type OptimizationImpact = 'high' | 'medium' | 'low';
+
+export type OptimizationId =
+  | 'system-packages-before-source'
+  | 'dependencies-before-source'
+  | 'combine-run'
+  | 'slim-base-image';
+
+export interface OptimizationSuggestion {
+  id: OptimizationId;
+  title: string;
+  description: string;
+  impact: OptimizationImpact;
+  /** Expected effect of the change on build time or image size */
+  estimatedImpact: string;
+  /** 1-based lines of the affected instructions in the original Dockerfile */
+  lines: number[];
+  /** Whether the change is reflected in the optimized Dockerfile */
+  autoApplied: boolean;
+  example?: string;
+}
+
+export interface DockerfileOptimization {
+  /** Suggestions ordered by impact, then by position in the Dockerfile */
+  suggestions: OptimizationSuggestion[];
+  /** Rewritten Dockerfile; present when at least one suggestion was applied */
+  optimized?: string;
+}
+
+interface Instruction {
+  keyword: string;
+  /** Arguments with line continuations joined and whitespace collapsed */
+  args: string;
+  /** Original text of the instruction, continuation lines included */
+  text: string;
+  /** Comment and blank lines directly above the instruction */
+  trivia: string[];
+  /** 1-based start line in the original Dockerfile, 0 for synthesized lines */
+  line: number;
+}
+
+interface Finding {
+  id: OptimizationId;
+  line: number;
+  applied: boolean;
+  detail?: string;
+}
+
+const IMPACT_ORDER: Record<OptimizationImpact, number> = { high: 0, medium: 1, low: 2 };
+
+const SUGGESTION_TEXT: Record<
+  OptimizationId,
+  Pick<OptimizationSuggestion, 'title' | 'description' | 'impact' | 'estimatedImpact' | 'example'>
+> = {
+  'dependencies-before-source': {
+    title: 'Install dependencies before copying source',
+    description:
+      'Copy only the dependency manifests, install, then copy the rest of the source so the install layer is reused when only code changes. Make sure .dockerignore excludes local dependency folders such as node_modules.',
+    impact: 'high',
+    estimatedImpact: 'Rebuilds after code-only changes skip dependency installation',
+    example: 'COPY package*.json ./\nRUN npm ci\nCOPY . .',
+  },
+  'system-packages-before-source': {
+    title: 'Install system packages before copying source',
+    description:
+      'OS package installation does not depend on application code; running it before the source COPY keeps it cached across code changes.',
+    impact: 'high',
+    estimatedImpact: 'Rebuilds after code-only changes skip OS package installation',
+  },
+  'combine-run': {
+    title: 'Combine consecutive RUN instructions',
+    description:
+      'Consecutive RUN instructions for the same tool create extra layers and, for package managers, can install from a stale index cached by an earlier layer.',
+    impact: 'medium',
+    estimatedImpact: 'Fewer layers and no stale package index between update and install',
+    example: 'RUN apt-get update \\\n    && apt-get install -y curl',
+  },
+  'slim-base-image': {
+    title: 'Use a slimmer runtime base image',
+    description:
+      'The final stage uses a full distribution image. The slim variant ships the same runtime without compilers and documentation. Not applied automatically because system packages differ; verify the application still runs.',
+    impact: 'medium',
+    estimatedImpact: 'Typically several hundred MB smaller final image',
+  },
+};
+
+/**
+ * Dependency installs and the manifests they need. Only whole-project
+ * installs match: `npm install express` edits package.json and must stay put.
+ */
+const DEPENDENCY_INSTALLS: Array<{
+  pattern: RegExp;
+  manifests: (match: RegExpMatchArray) => string[];
+}> = [
+  { pattern: /^npm (ci|install|i)(\s+-\S+)*$/, manifests: () => ['package*.json'] },
+  {
+    pattern: /^pnpm (install|i)(\s+-\S+)*$/,
+    manifests: () => ['package.json', 'pnpm-lock.yaml*'],
+  },
+  { pattern: /^yarn( install)?(\s+-\S+)*$/, manifests: () => ['package.json', 'yarn.lock*'] },
+  { pattern: /^pip3? install\b.*\s-r\s+(\S+)/, manifests: (m) => [m[1] ?? 'requirements.txt'] },
+  { pattern: /^poetry install(\s+-\S+)*$/, manifests: () => ['pyproject.toml', 'poetry.lock*'] },
+  { pattern: /^go mod download$/, manifests: () => ['go.mod', 'go.sum*'] },
+  { pattern: /^bundle install(\s+-\S+)*$/, manifests: () => ['Gemfile', 'Gemfile.lock*'] },
+];
+
+/** Cleanup steps that belong with the install they follow */
+const INSTALL_CLEANUP = /^(npm cache clean|yarn cache clean|pip cache purge|rm -rf )/;
+
+const SYSTEM_PACKAGE_STEP =
+  /^(apt-get|apt|apk|yum|dnf|microdnf) |^rm -rf \/var\/(lib\/apt\/lists|cache\/(apk|yum|dnf))/;
+
+/**
+ * Package installs from a file (`apt-get install ./pkg.deb`, `apk add /tmp/x.apk`)
+ * that may come from the build context
+ */
+const LOCAL_PACKAGE = /(^|\s)(\.{1,2}|~)?\/\S*|\.(deb|apk|rpm)(\s|$)/;
+
+/** Directories whose contents change what OS package managers install */
+const PACKAGE_MANAGER_PATHS = [
+  '/etc',
+  '/usr/share/keyrings',
+  '/var/lib/apt',
+  '/var/lib/dpkg',
+  '/var/lib/rpm',
+  '/var/cache',
+  '/lib/apk',
+];
+
+/** Instructions a RUN may be moved across without changing its environment */
+const MOVABLE_ACROSS = new Set(['COPY', 'ADD', 'EXPOSE', 'LABEL']);
+
+/**
+ * Shell constructs whose effects would leak into commands chained after them
+ * (cwd, variables, options) or that break when joined onto one logical line
+ */
+const UNSAFE_TO_CHAIN =
+  /(^|[\s;&|(])(cd|export|source|set|unset|exit|exec|umask|ulimit|shopt|alias)(\s|$)|(^|[\s;&|])\.\s|(^|[;&|]\s*)[A-Za-z_]\w*=|<<|\s#|&\s*$/;
+
+const FINAL_STAGE_SLIM: Array<{ pattern: RegExp; slim: (match: RegExpMatchArray) => string }> = [
+  { pattern: /^node:(\d[\d.]*)$/, slim: (m) => `node:${m[1]}-slim` },
+  { pattern: /^python:(\d[\d.]*)$/, slim: (m) => `python:${m[1]}-slim` },
+  { pattern: /^ruby:(\d[\d.]*)$/, slim: (m) => `ruby:${m[1]}-slim` },
+  { pattern: /^openjdk:(\d+)(-jdk)?$/, slim: (m) => `eclipse-temurin:${m[1]}-jre` },
+];
+
+function isComment(line: string): boolean {
+  return line.trim().startsWith('#');
+}
+
+function continues(line: string): boolean {
+  return /\\\s*$/.test(line);
+}
+
+/**
+ * Split Dockerfile content into logical instructions, keeping comments and
+ * blank lines attached to the instruction that follows them
+ */
+function parseInstructions(content: string): { instructions: Instruction[]; trailing: string[] } {
+  const lines = content.split(/\r?\n/);
+  const instructions: Instruction[] = [];
+  let trivia: string[] = [];
+
+  for (let i = 0; i < lines.length; i++) {
+    const first = lines[i] ?? '';
+    if (first.trim() === '' || isComment(first)) {
+      trivia.push(first);
+      continue;
+    }
+
+    let end = i;
+    while (
+      end + 1 < lines.length &&
+      (continues(lines[end] ?? '') || (end > i && isComment(lines[end] ?? '')))
+    ) {
+      end++;
+    }
+
+    const textLines = lines.slice(i, end + 1);
+    const logical = textLines
+      .filter((line, index) => index === 0 || !isComment(line))
+      .map((line) => line.replace(/\\\s*$/, ''))
+      .join(' ')
+      .trim();
+    const [keyword = '', ...rest] = logical.split(/\s+/);
+
+    instructions.push({
+      keyword: keyword.toUpperCase(),
+      args: rest.join(' '),
+      text: textLines.join('\n'),
+      trivia,
+      line: i + 1,
+    });
+    trivia = [];
+    i = end;
+  }
+
+  return { instructions, trailing: trivia };
+}
+
+function render(instructions: Instruction[], trailing: string[]): string {
+  return [...instructions.flatMap((i) => [...i.trivia, i.text]), ...trailing].join('\n');
+}
+
+function synthesize(keyword: string, args: string): Instruction {
+  return { keyword, args, text: `${keyword} ${args}`, trivia: [], line: 0 };
+}
+
+/**
+ * Shell-form RUN commands split on `&&`; undefined for exec form or RUN flags
+ */
+function runSegments(instruction: Instruction): string[] | undefined {
+  if (instruction.keyword !== 'RUN') return undefined;
+  if (instruction.args.startsWith('[') || instruction.args.startsWith('--')) return undefined;
+  return instruction.args.split('&&').map((segment) => segment.trim());
+}
+
+/**
+ * Index ranges [start, end) of each build stage
+ */
+function stageRanges(instructions: Instruction[]): Array<[number, number]> {
+  const starts = instructions
+    .map((instruction, index) => (instruction.keyword === 'FROM' ? index : -1))
+    .filter((index) => index >= 0);
+  return starts.map((start, n) => [start, starts[n + 1] ?? instructions.length]);
+}
+
+function copyParts(instruction: Instruction): { flags: string[]; sources: string[]; dest: string } {
+  const tokens = instruction.args.split(' ');
+  const flags = tokens.filter((token) => token.startsWith('--'));
+  const paths = tokens.filter((token) => !token.startsWith('--'));
+  return { flags, sources: paths.slice(0, -1), dest: paths[paths.length - 1] ?? '.' };
+}
+
+/**
+ * Index of the first COPY/ADD of the whole build context within a stage
+ */
+function sourceCopyIndex(instructions: Instruction[], [start, end]: [number, number]): number {
+  for (let i = start; i < end; i++) {
+    const instruction = instructions[i];
+    if (!instruction || (instruction.keyword !== 'COPY' && instruction.keyword !== 'ADD')) {
+      continue;
+    }
+    const { flags, sources } = copyParts(instruction);
+    if (flags.some((flag) => flag.startsWith('--from'))) continue;
+    if (sources.some((source) => source === '.' || source === './')) return i;
+  }
+  return -1;
+}
+
+/**
+ * Absolute destination of a COPY/ADD, resolved against the stage's WORKDIR;
+ * undefined when it depends on a variable
+ */
+function copyDestination(
+  instructions: Instruction[],
+  index: number,
+  stageStart: number,
+): string | undefined {
+  const instruction = instructions[index];
+  if (!instruction) return undefined;
+  let dest = copyParts(instruction).dest;
+
+  for (let i = index - 1; i >= stageStart && !dest.startsWith('/'); i--) {
+    const workdir = instructions[i];
+    if (workdir?.keyword === 'WORKDIR') dest = `${workdir.args.replace(/\/+$/, '')}/${dest}`;
+  }
+  if (!dest.startsWith('/')) dest = `/${dest}`;
+  if (dest.includes('$')) return undefined;
+
+  return `/${dest.split('/').filter((part) => part !== '' && part !== '.').join('/')}`;
+}
+
+/**
+ * COPY/ADD instructions in [from, to) that could write files an OS package
+ * manager reads, such as apt sources, keyrings, or repository definitions
+ */
+function packageManagerCopies(
+  instructions: Instruction[],
+  from: number,
+  to: number,
+  stageStart: number,
+): string[] {
+  const copies: string[] = [];
+  for (let i = from; i < to; i++) {
+    const instruction = instructions[i];
+    if (!instruction || (instruction.keyword !== 'COPY' && instruction.keyword !== 'ADD')) {
+      continue;
+    }
+    const dest = copyDestination(instructions, i, stageStart);
+    const affects =
+      dest === undefined ||
+      dest === '/' ||
+      PACKAGE_MANAGER_PATHS.some(
+        (path) => path === dest || path.startsWith(`${dest}/`) || dest.startsWith(`${path}/`),
+      );
+    if (affects) copies.push(`${instruction.keyword} into ${dest ?? instruction.args}`);
+  }
+  return copies;
+}
+
+/**
+ * Keywords between two positions that a RUN cannot safely be moved across
+ */
+function blockersBetween(instructions: Instruction[], from: number, to: number): string[] {
+  const keywords = instructions.slice(from, to).map((i) => i.keyword);
+  return [...new Set(keywords.filter((keyword) => !MOVABLE_ACROSS.has(keyword)))];
+}
+
+function blockedDetail(blockers: string[]): string {
+  return `Not applied automatically: ${blockers.join(', ')} between the source COPY and this step may change how it runs`;
+}
+
+/**
+ * Move pure OS package RUNs ahead of the source COPY
+ */
+function hoistSystemPackages(instructions: Instruction[], findings: Finding[]): Instruction[] {
+  const result = [...instructions];
+
+  for (const range of stageRanges(result).reverse()) {
+    let source = sourceCopyIndex(result, range);
+    if (source < 0) continue;
+
+    for (let i = source + 1; i < range[1]; i++) {
+      const instruction = result[i];
+      const segments = instruction && runSegments(instruction);
+      if (!instruction || !segments?.every((segment) => SYSTEM_PACKAGE_STEP.test(segment))) {
+        continue;
+      }
+      // Installing a package file may depend on the source COPY providing it
+      if (segments.some((s) => !s.startsWith('rm ') && LOCAL_PACKAGE.test(s))) continue;
+
+      const blockers = blockersBetween(result, source + 1, i);
+      const copies = packageManagerCopies(result, source, i, range[0]);
+      const applied = blockers.length === 0 && copies.length === 0;
+      const detail =
+        blockers.length > 0
+          ? blockedDetail(blockers)
+          : `Not applied automatically: ${copies.join(', ')} may add package sources or keys this step uses`;
+      findings.push({
+        id: 'system-packages-before-source',
+        line: instruction.line,
+        applied,
+        ...(!applied && { detail }),
+      });
+      if (applied) {
+        result.splice(i, 1);
+        result.splice(source, 0, instruction);
+        source++;
+      }
+    }
+  }
+
+  return result;
+}
+
+/**
+ * Copy manifests and run the dependency install before the source COPY
+ */
+function installDependenciesFirst(instructions: Instruction[], findings: Finding[]): Instruction[] {
+  const result = [...instructions];
+
+  // Later stages first so insertions don't shift ranges still to be visited
+  for (const range of stageRanges(result).reverse()) {
+    const source = sourceCopyIndex(result, range);
+    if (source < 0) continue;
+
+    for (let i = source + 1; i < range[1]; i++) {
+      const instruction = result[i];
+      const segments = instruction && runSegments(instruction);
+      const [first] = segments ?? [];
+      const install = first
+        ? DEPENDENCY_INSTALLS.map((d) => ({ d, match: first.match(d.pattern) })).find(
+            (candidate) => candidate.match,
+          )
+        : undefined;
+      if (!instruction || !segments || !install?.match) continue;
+
+      // The install plus any cache cleanup directly after it moves; anything else stays
+      let split = 1;
+      while (split < segments.length && INSTALL_CLEANUP.test(segments[split] ?? '')) split++;
+      const moved = segments.slice(0, split);
+      const remaining = segments.slice(split);
+
+      const blockers = blockersBetween(result, source + 1, i);
+      const splittable =
+        remaining.length === 0 ||
+        (!/["'`]/.test(instruction.args) && moved.every((s) => !UNSAFE_TO_CHAIN.test(s)));
+      const applied = blockers.length === 0 && splittable;
+      const detail =
+        blockers.length > 0
+          ? blockedDetail(blockers)
+          : 'Not applied automatically: the install shares its RUN with steps that cannot be split off safely';
+      findings.push({
+        id: 'dependencies-before-source',
+        line: instruction.line,
+        applied,
+        ...(!applied && { detail }),
+      });
+      if (!applied) break;
+
+      const sourceCopy = result[source] as Instruction;
+      const { flags, dest } = copyParts(sourceCopy);
+      const manifests = install.d.manifests(install.match);
+      const alreadyCopied = result
+        .slice(range[0], source)
+        .some(
+          (existing) =>
+            existing.keyword === 'COPY' &&
+            manifests.some((manifest) => copyParts(existing).sources.includes(manifest)),
+        );
+      const manifestDest = dest === '.' ? './' : dest.endsWith('/') ? dest : `${dest}/`;
+      const manifestCopy = synthesize('COPY', [...flags, ...manifests, manifestDest].join(' '));
+      const installRun =
+        remaining.length === 0 ? instruction : synthesize('RUN', moved.join(' && '));
+      const leftover = remaining.length > 0 ? [synthesize('RUN', remaining.join(' && '))] : [];
+
+      result.splice(i, 1, ...leftover);
+      result.splice(source, 0, ...(alreadyCopied ? [] : [manifestCopy]), installRun);
+      break;
+    }
+  }
+
+  return result;
+}
+
+function programOf(segment: string): string {
+  const program = segment.split(' ')[0] ?? '';
+  return program === 'apt' ? 'apt-get' : program;
+}
+
+/**
+ * Merge consecutive RUNs that start with the same program
+ */
+function combineRuns(instructions: Instruction[], findings: Finding[]): Instruction[] {
+  const result: Instruction[] = [];
+
+  for (const instruction of instructions) {
+    const previous = result[result.length - 1];
+    const previousSegments = previous && runSegments(previous);
+    const segments = runSegments(instruction);
+
+    const sameTool =
+      previousSegments &&
+      segments &&
+      instruction.trivia.every((line) => line.trim() === '') &&
+      programOf(previousSegments[0] ?? '') === programOf(segments[0] ?? '');
+    if (!previous || !previousSegments || !sameTool) {
+      result.push(instruction);
+      continue;
+    }
+
+    const applied = !UNSAFE_TO_CHAIN.test(previous.args);
+    findings.push({
+      id: 'combine-run',
+      line: instruction.line || previous.line,
+      applied,
+      ...(!applied && {
+        detail:
+          'Not applied automatically: the earlier RUN changes shell state (directory, variables or options) that would carry over',
+      }),
+    });
+    if (!applied) {
+      result.push(instruction);
+      continue;
+    }
+
+    const body = instruction.text.replace(/^\s*RUN\s+/i, '');
+    result[result.length - 1] = {
+      ...previous,
+      args: `${previous.args} && ${instruction.args}`,
+      text: `${previous.text} \\\n    && ${body}`,
+    };
+  }
+
+  return result;
+}
+
+function suggestSlimBase(instructions: Instruction[], findings: Finding[]): void {
+  const from = [...instructions].reverse().find((i) => i.keyword === 'FROM');
+  if (!from) return;
+
+  const image = from.args.split(/\s+/).find((token) => !token.startsWith('--')) ?? '';
+  for (const { pattern, slim } of FINAL_STAGE_SLIM) {
+    const match = image.match(pattern);
+    if (match) {
+      findings.push({
+        id: 'slim-base-image',
+        line: from.line,
+        applied: false,
+        detail: `Replace ${image} with ${slim(match)}`,
+      });
+      return;
+    }
+  }
+}
+
+/**
+ * Every original instruction, RUN commands split per `&&` step
+ */
+function instructionUnits(instructions: Instruction[]): string[] {
+  return instructions.flatMap(
+    (instruction) =>
+      runSegments(instruction)?.map((segment) => `RUN ${segment}`) ?? [
+        `${instruction.keyword} ${instruction.args}`,
+      ],
+  );
+}
+
+function preservesInstructions(original: Instruction[], optimized: Instruction[]): boolean {
+  const remaining = instructionUnits(optimized);
+  return instructionUnits(original).every((unit) => {
+    const index = remaining.indexOf(unit);
+    if (index < 0) return false;
+    remaining.splice(index, 1);
+    return true;
+  });
+}
+
+function toSuggestions(findings: Finding[]): OptimizationSuggestion[] {
+  const grouped = new Map<string, Finding[]>();
+  for (const finding of findings) {
+    const key = `${finding.id}:${finding.applied}`;
+    grouped.set(key, [...(grouped.get(key) ?? []), finding]);
+  }
+
+  return [...grouped.values()]
+    .map((group) => {
+      const [first] = group as [Finding, ...Finding[]];
+      const text = SUGGESTION_TEXT[first.id];
+      const lines = [...new Set(group.map((f) => f.line).filter((line) => line > 0))].sort(
+        (a, b) => a - b,
+      );
+      return {
+        id: first.id,
+        ...text,
+        description: first.detail ? `${first.detail}. ${text.description}` : text.description,
+        lines,
+        autoApplied: first.applied,
+      };
+    })
+    .sort(
+      (a, b) =>
+        IMPACT_ORDER[a.impact] - IMPACT_ORDER[b.impact] || (a.lines[0] ?? 0) - (b.lines[0] ?? 0),
+    );
+}
+
+/**
+ * Analyze a Dockerfile and produce prioritized optimization suggestions
+ * plus, when any were safe to apply, the rewritten Dockerfile
+ */
+export function optimizeDockerfile(content: string): DockerfileOptimization {
+  const { instructions, trailing } = parseInstructions(content);
+  const findings: Finding[] = [];
+
+  let optimized = hoistSystemPackages(instructions, findings);
+  optimized = installDependenciesFirst(optimized, findings);
+  optimized = combineRuns(optimized, findings);
+  suggestSlimBase(instructions, findings);
+
+  if (!findings.some((finding) => finding.applied)) {
+    return { suggestions: toSuggestions(findings) };
+  }
+
+  const rendered = render(optimized, trailing);
+  if (!preservesInstructions(instructions, parseInstructions(rendered).instructions)) {
+    // Safety net: never return a rewrite that loses an instruction
+    return {
+      suggestions: toSuggestions(findings.map((finding) => ({ ...finding, applied: false }))),
+    };
+  }
+
+  return { suggestions: toSuggestions(findings), optimized: rendered };
+}
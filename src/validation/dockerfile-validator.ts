@@ -21,6 +21,14 @@ import {
 } from './core-types';
 import { lintWithDockerfilelint } from './dockerfilelint-adapter';
 import { mergeReports } from './merge-reports';
+import {
+  DEFAULT_SECRET_RULESET,
+  isAllowlisted,
+  redactSecret,
+  scanForSecrets,
+  type SecretFinding,
+  type SecretRuleset,
+} from './secret-rules';
 import {
   SUDO_INSTALL,
   AS_CLAUSE,
@@ -53,6 +61,59 @@ const getArgValue = (command: DockerCommand): string => {
   return '';
 };
 
+const KEYWORD_PATTERNS = [PASSWORD_PATTERN, API_KEY_PATTERN, SECRET_PATTERN, TOKEN_PATTERN];
+
+/**
+ * Where secrets are looked for and which rules and allowlist apply
+ */
+interface SecretScanOptions {
+  ruleset: SecretRuleset;
+  file?: string;
+}
+
+/**
+ * Hardcoded secrets in ENV/ARG keyword assignments and, for every
+ * instruction, matches of the secret ruleset
+ */
+const findHardcodedSecrets = (
+  commands: DockerCommand[],
+  { ruleset, file }: SecretScanOptions = { ruleset: DEFAULT_SECRET_RULESET },
+): SecretFinding[] =>
+  commands.flatMap((cmd: DockerCommand) => {
+    const value = getArgValue(cmd);
+    const findings = scanForSecrets(value, cmd.lineno, ruleset, file);
+
+    if (
+      (cmd.name === 'ENV' || cmd.name === 'ARG') &&
+      KEYWORD_PATTERNS.some((pattern) => pattern.test(value))
+    ) {
+      const assignment = value.match(/^([A-Z_][A-Z0-9_]*)\s*=\s*(.*)$/i);
+      if (isAllowlisted(assignment?.[2] ?? value, ruleset.allowlist)) return findings;
+      findings.unshift({
+        ruleId: 'generic-secret-assignment',
+        description: assignment?.[1] ?? 'secret',
+        ...(file && { file }),
+        line: cmd.lineno,
+        match: redactSecret(assignment?.[2] ?? value),
+      });
+    }
+    return findings;
+  });
+
+/**
+ * Name reported for a finding: the variable for keyword assignments, else the rule
+ */
+const findingName = (finding: SecretFinding): string =>
+  finding.ruleId === 'generic-secret-assignment' ? finding.description : finding.ruleId;
+
+/**
+ * One error line per finding, e.g. `Dockerfile:4: Stripe access token (sk_l****)`
+ */
+const describeFinding = (finding: SecretFinding): string => {
+  const location = finding.file ? `${finding.file}:${finding.line}` : `Line ${finding.line}`;
+  return `${location}: ${finding.description} (${finding.match})`;
+};
+
 /**
  * Validation rules for Dockerfile analysis
  */
@@ -70,6 +131,7 @@ const DOCKERFILE_RULES: DockerfileValidationRule[] = [
       const userArg = getArgValue(lastUser);
       return userArg !== 'root' && userArg !== '0';
     },
+    instructions: ['USER'],
     message: 'Container should run as non-root user',
     severity: ValidationSeverity.ERROR,
     fix: 'Add USER directive with non-root user (e.g., USER node)',
@@ -87,6 +149,7 @@ const DOCKERFILE_RULES: DockerfileValidationRule[] = [
         return SUDO_INSTALL.test(args);
       });
     },
+    instructions: ['RUN'],
     message: 'Avoid installing sudo in containers',
     severity: ValidationSeverity.WARNING,
     fix: 'Remove sudo installation, use specific user permissions instead',
@@ -99,13 +162,19 @@ const DOCKERFILE_RULES: DockerfileValidationRule[] = [
     description: 'Base images should use specific versions for reproducibility',
     check: (commands: CommandEntry[]) => {
       const fromCommands = commands.filter((cmd: DockerCommand) => cmd.name === 'FROM');
+      const stages = new Set<string>();
       return fromCommands.every((cmd: DockerCommand) => {
         const image = getArgValue(cmd);
         // Multi-stage builds use 'FROM image AS stage' syntax
-        const cleanImage = image?.split(AS_CLAUSE)?.[0];
+        const [cleanImage, stage] = image?.split(AS_CLAUSE) ?? [];
+        // 'FROM <earlier stage>' reuses a pinned image rather than pulling one
+        const isStageReference = cleanImage !== undefined && stages.has(cleanImage.toLowerCase());
+        if (stage) stages.add(stage.trim().toLowerCase());
+        if (isStageReference) return true;
         return cleanImage && cleanImage.includes(':') && !LATEST_TAG.test(cleanImage);
       });
     },
+    instructions: ['FROM'],
     message: 'Use specific version tags instead of latest',
     severity: ValidationSeverity.WARNING,
     fix: 'Replace :latest with specific version (e.g., node:18-alpine)',
@@ -119,6 +188,7 @@ const DOCKERFILE_RULES: DockerfileValidationRule[] = [
     check: (commands: CommandEntry[]) => {
       return commands.some((cmd: CommandEntry) => cmd.name === 'HEALTHCHECK');
     },
+    instructions: ['HEALTHCHECK'],
     message: 'Add HEALTHCHECK for container monitoring',
     severity: ValidationSeverity.INFO,
     fix: 'Add HEALTHCHECK CMD curl -f http://localhost/health || exit 1',
@@ -151,6 +221,7 @@ const DOCKERFILE_RULES: DockerfileValidationRule[] = [
       if (packageCopyIndex === -1) return false;
       return packageCopyIndex < sourceCopyIndex;
     },
+    instructions: ['COPY'],
     message: 'Copy dependency files before source code for better caching',
     severity: ValidationSeverity.INFO,
     fix: 'COPY package*.json ./ before COPY . .',
@@ -161,17 +232,8 @@ const DOCKERFILE_RULES: DockerfileValidationRule[] = [
     id: 'no-secrets',
     name: 'No hardcoded secrets',
     description: 'Secrets should not be hardcoded in Dockerfile',
-    check: (commands: CommandEntry[]) => {
-      const suspicious = [PASSWORD_PATTERN, API_KEY_PATTERN, SECRET_PATTERN, TOKEN_PATTERN];
-
-      return !commands.some((cmd: DockerCommand) => {
-        if (cmd.name === 'ENV' || cmd.name === 'ARG') {
-          const value = getArgValue(cmd);
-          return suspicious.some((pattern) => pattern.test(value));
-        }
-        return false;
-      });
-    },
+    check: (commands: CommandEntry[]) => findHardcodedSecrets(commands).length === 0,
+    instructions: ['ENV', 'ARG', 'RUN', 'LABEL', 'ADD', 'CMD', 'ENTRYPOINT', 'HEALTHCHECK'],
     message: 'Do not hardcode secrets in Dockerfile',
     severity: ValidationSeverity.ERROR,
     fix: 'Use build arguments or runtime environment variables',
@@ -212,6 +274,7 @@ const DOCKERFILE_RULES: DockerfileValidationRule[] = [
 
       return hasExpose;
     },
+    instructions: ['CMD', 'ENTRYPOINT', 'EXPOSE'],
     message: 'Document exposed ports with EXPOSE instruction',
     severity: ValidationSeverity.INFO,
     fix: 'Add EXPOSE <port> for application ports',
@@ -225,6 +288,7 @@ const DOCKERFILE_RULES: DockerfileValidationRule[] = [
     check: (commands: DockerCommand[]) => {
       return commands.some((cmd: DockerCommand) => cmd.name === 'WORKDIR');
     },
+    instructions: ['WORKDIR'],
     message: 'Set WORKDIR for better file organization',
     severity: ValidationSeverity.INFO,
     fix: 'Add WORKDIR /app or appropriate directory',
@@ -375,6 +439,51 @@ const createReport = (results: ValidationResult[]): ValidationReport => {
   };
 };
 
+/**
+ * Run a single rule against parsed commands
+ */
+const evaluateRule = (
+  rule: DockerfileValidationRule,
+  commands: DockerCommand[],
+  secrets?: SecretScanOptions,
+): ValidationResult => {
+  // The no-secrets rule reports each finding with the secret name and location
+  if (rule.id === 'no-secrets') {
+    const findings = findHardcodedSecrets(commands, secrets);
+    const passed = findings.length === 0;
+    const names = [...new Set(findings.map(findingName))].join(', ');
+
+    return {
+      ruleId: rule.id,
+      isValid: passed,
+      passed,
+      errors: findings.map(describeFinding),
+      warnings: [],
+      message: passed ? `✓ ${rule.name}` : `✗ ${rule.name}: ${rule.message} (found: ${names})`,
+      suggestions: !passed && rule.fix ? [rule.fix] : [],
+      metadata: {
+        severity: rule.severity,
+        ...(findings[0] && { location: `line ${findings[0].line}` }),
+      },
+    };
+  }
+
+  const passed = rule.check(commands);
+
+  return {
+    ruleId: rule.id,
+    isValid: passed,
+    passed,
+    errors: passed ? [] : [`${rule.name}: ${rule.message}`],
+    warnings: [],
+    message: passed ? `✓ ${rule.name}` : `✗ ${rule.name}: ${rule.message}`,
+    suggestions: !passed && rule.fix ? [rule.fix] : [],
+    metadata: {
+      severity: rule.severity,
+    },
+  };
+};
+
 /**
  * Detect BuildKit features in Dockerfile content
  */
@@ -400,7 +509,10 @@ function detectBuildKitFeatures(content: string): {
  * Validate BuildKit Dockerfile
  * Used when standard parser fails due to BuildKit syntax
  */
-function validateBuildKitDockerfile(content: string): ValidationReport {
+function validateBuildKitDockerfile(
+  content: string,
+  secrets: SecretScanOptions = { ruleset: DEFAULT_SECRET_RULESET },
+): ValidationReport {
   const results: ValidationResult[] = [];
   const lines = content.split('\n');
 
@@ -431,21 +543,32 @@ function validateBuildKitDockerfile(content: string): ValidationReport {
       });
     }
 
-    // Check for potential secrets
-    if (/password|api_key|secret|token/i.test(line) && !line.startsWith('#')) {
-      // Skip if it's in a comment or mount directive (secrets are OK in BuildKit mounts)
-      if (!line.includes('--mount=type=secret')) {
+    // Check for potential secrets (secrets are OK in BuildKit mounts)
+    if (!line.includes('--mount=type=secret')) {
+      const findings = scanForSecrets(line, lineNumber, secrets.ruleset, secrets.file);
+      if (/password|api_key|secret|token/i.test(line)) {
         // Extract the variable name from ENV/ARG statements
-        const envMatch = line.match(/^\s*(?:ENV|ARG)\s+([A-Z_][A-Z0-9_]*)\s*=/i);
-        const variableName = envMatch ? envMatch[1] : 'secret';
+        const envMatch = line.match(/^\s*(?:ENV|ARG)\s+([A-Z_][A-Z0-9_]*)\s*=\s*(.*)$/i);
+        if (!isAllowlisted(envMatch?.[2] ?? line, secrets.ruleset.allowlist)) {
+          findings.unshift({
+            ruleId: 'generic-secret-assignment',
+            description: envMatch?.[1] ?? 'secret',
+            ...(secrets.file && { file: secrets.file }),
+            line: lineNumber,
+            match: redactSecret(envMatch?.[2] ?? ''),
+          });
+        }
+      }
 
+      if (findings.length > 0) {
+        const names = [...new Set(findings.map(findingName))].join(', ');
         results.push({
           ruleId: 'no-secrets',
           isValid: false,
           passed: false,
-          errors: [`Line ${lineNumber}: Potential secret exposed`],
+          errors: findings.map(describeFinding),
           warnings: [],
-          message: `✗ No hardcoded secrets: Do not hardcode secrets in Dockerfile (found: ${variableName})`,
+          message: `✗ No hardcoded secrets: Do not hardcode secrets in Dockerfile (found: ${names})`,
           suggestions: ['Use build arguments or runtime environment variables'],
           metadata: {
             severity: ValidationSeverity.ERROR,
@@ -536,8 +659,19 @@ function validateBuildKitDockerfile(content: string): ValidationReport {
  */
 export const validateDockerfileContent = async (
   dockerfileContent: string,
-  options?: { enableExternalLinter?: boolean },
+  options?: {
+    enableExternalLinter?: boolean;
+    /** Secret rules and allowlist for the no-secrets rule (default: built-in rules) */
+    secretRules?: SecretRuleset;
+    /** Dockerfile path reported with secret findings */
+    path?: string;
+  },
 ): Promise<ValidationReport> => {
+  const secrets: SecretScanOptions = {
+    ruleset: options?.secretRules ?? DEFAULT_SECRET_RULESET,
+    ...(options?.path && { file: options.path }),
+  };
+
   // Detect BuildKit features first
   const buildKit = detectBuildKitFeatures(dockerfileContent);
 
@@ -553,45 +687,7 @@ export const validateDockerfileContent = async (
       const commands = parseResult.value;
 
       // Run all validation rules directly
-      const results: ValidationResult[] = [];
-
-      for (const rule of DOCKERFILE_RULES) {
-        const passed = rule.check(commands);
-
-        // Special handling for no-secrets rule to include the specific secret name
-        let message = passed ? `✓ ${rule.name}` : `✗ ${rule.name}: ${rule.message}`;
-        if (!passed && rule.id === 'no-secrets') {
-          // Extract the secret variable name from the ENV/ARG commands
-          const secretVariable = commands.find((cmd: DockerCommand) => {
-            if (cmd.name === 'ENV' || cmd.name === 'ARG') {
-              const value = getArgValue(cmd);
-              const suspicious = [PASSWORD_PATTERN, API_KEY_PATTERN, SECRET_PATTERN, TOKEN_PATTERN];
-              return suspicious.some((pattern) => pattern.test(value));
-            }
-            return false;
-          });
-
-          if (secretVariable) {
-            const value = getArgValue(secretVariable);
-            const variableMatch = value.match(/^([A-Z_][A-Z0-9_]*)\s*=/i);
-            const variableName = variableMatch ? variableMatch[1] : 'secret';
-            message = `✗ ${rule.name}: ${rule.message} (found: ${variableName})`;
-          }
-        }
-
-        results.push({
-          ruleId: rule.id,
-          isValid: passed,
-          passed,
-          errors: passed ? [] : [`${rule.name}: ${rule.message}`],
-          warnings: [],
-          message,
-          suggestions: !passed && rule.fix ? [rule.fix] : [],
-          metadata: {
-            severity: rule.severity,
-          },
-        });
-      }
+      const results = DOCKERFILE_RULES.map((rule) => evaluateRule(rule, commands, secrets));
 
       const internalReport = createReport(results);
 
@@ -610,7 +706,7 @@ export const validateDockerfileContent = async (
     }
 
     // Fall back to BuildKit-specific validation
-    const buildKitReport = validateBuildKitDockerfile(dockerfileContent);
+    const buildKitReport = validateBuildKitDockerfile(dockerfileContent, secrets);
 
     // Add external linter if enabled (it might handle BuildKit better)
     if (options?.enableExternalLinter !== false) {
@@ -689,45 +785,7 @@ export const validateDockerfileContent = async (
   const commands = parseResult.value;
 
   // Run all validation rules directly
-  const results: ValidationResult[] = [];
-
-  for (const rule of DOCKERFILE_RULES) {
-    const passed = rule.check(commands);
-
-    // Special handling for no-secrets rule to include the specific secret name
-    let message = passed ? `✓ ${rule.name}` : `✗ ${rule.name}: ${rule.message}`;
-    if (!passed && rule.id === 'no-secrets') {
-      // Extract the secret variable name from the ENV/ARG commands
-      const secretVariable = commands.find((cmd: DockerCommand) => {
-        if (cmd.name === 'ENV' || cmd.name === 'ARG') {
-          const value = getArgValue(cmd);
-          const suspicious = [PASSWORD_PATTERN, API_KEY_PATTERN, SECRET_PATTERN, TOKEN_PATTERN];
-          return suspicious.some((pattern) => pattern.test(value));
-        }
-        return false;
-      });
-
-      if (secretVariable) {
-        const value = getArgValue(secretVariable);
-        const variableMatch = value.match(/^([A-Z_][A-Z0-9_]*)\s*=/i);
-        const variableName = variableMatch ? variableMatch[1] : 'secret';
-        message = `✗ ${rule.name}: ${rule.message} (found: ${variableName})`;
-      }
-    }
-
-    results.push({
-      ruleId: rule.id,
-      isValid: passed,
-      passed,
-      errors: passed ? [] : [`${rule.name}: ${rule.message}`],
-      warnings: [],
-      message,
-      suggestions: !passed && rule.fix ? [rule.fix] : [],
-      metadata: {
-        severity: rule.severity,
-      },
-    });
-  }
+  const results = DOCKERFILE_RULES.map((rule) => evaluateRule(rule, commands, secrets));
 
   const internalReport = createReport(results);
 
@@ -745,3 +803,91 @@ export const validateDockerfileContent = async (
 
   return internalReport;
 };
+
+/**
+ * Result of an incremental Dockerfile validation, passed back in on the next edit
+ */
+export interface DockerfileValidationState {
+  /** Parsed instructions the report was computed from */
+  commands: CommandEntry[];
+  report: ValidationReport;
+  /** Rules evaluated to produce the report; the others were carried over */
+  evaluatedRules: string[];
+}
+
+/**
+ * Instruction names whose sequence of arguments differs between two parses
+ */
+const changedInstructions = (before: DockerCommand[], after: DockerCommand[]): Set<string> => {
+  const argsByName = (commands: DockerCommand[]): Map<string, string[]> => {
+    const byName = new Map<string, string[]>();
+    for (const cmd of commands) {
+      byName.set(cmd.name, [...(byName.get(cmd.name) ?? []), getArgValue(cmd)]);
+    }
+    return byName;
+  };
+
+  const previous = argsByName(before);
+  const current = argsByName(after);
+  const changed = new Set<string>();
+  for (const name of new Set([...previous.keys(), ...current.keys()])) {
+    const a = previous.get(name) ?? [];
+    const b = current.get(name) ?? [];
+    if (a.length !== b.length || a.some((args, i) => args !== b[i])) {
+      changed.add(name);
+    }
+  }
+  return changed;
+};
+
+/**
+ * Revalidate a Dockerfile after an edit, re-running only the affected rules
+ *
+ * The content is re-parsed, which is cheap; the saving is in the rules. A rule
+ * is re-run when an instruction it reads was added, removed, edited or
+ * reordered since `previous`, and its earlier result is kept otherwise. Rules
+ * that depend on the whole file always run. Without `previous` every rule runs.
+ *
+ * Intended for editor integrations that validate on each keystroke, so the
+ * external linter is not run. A parse failure is returned as a Failure and
+ * the caller should keep its previous state.
+ *
+ * @example
+ * ```typescript
+ * let state = revalidateDockerfile(content);
+ * // ...after each edit
+ * const next = revalidateDockerfile(editedContent, state.ok ? state.value : undefined);
+ * ```
+ */
+export const revalidateDockerfile = (
+  dockerfileContent: string,
+  previous?: DockerfileValidationState,
+): Result<DockerfileValidationState> => {
+  const buildKit = detectBuildKitFeatures(dockerfileContent);
+  if (!buildKit.syntax && !buildKit.hasHeredocs && !buildKit.hasMounts) {
+    const syntaxResult = validateSyntax(dockerfileContent);
+    if (!syntaxResult.ok) return syntaxResult;
+  }
+
+  const parseResult = parseDockerfile(dockerfileContent);
+  if (!parseResult.ok) return parseResult;
+  const commands = parseResult.value;
+
+  const changed = previous ? changedInstructions(previous.commands, commands) : undefined;
+  const previousResults = new Map(
+    (previous?.report.results ?? []).map((result) => [result.ruleId, result]),
+  );
+
+  const evaluatedRules: string[] = [];
+  const results = DOCKERFILE_RULES.map((rule) => {
+    const carried = previousResults.get(rule.id);
+    const affected =
+      !changed || !rule.instructions || rule.instructions.some((name) => changed.has(name));
+    if (carried && !affected) return carried;
+
+    evaluatedRules.push(rule.id);
+    return evaluateRule(rule, commands);
+  });
+
+  return Success({ commands, report: createReport(results), evaluatedRules });
+};
@@ -0,0 +1,81 @@
+/**
+ * Conversion between validation results and error guidance
+ *
+ * Validators report problems as ValidationResult while tools fail with
+ * Failure(message, guidance). These helpers translate a failed validation
+ * result into ErrorGuidance (suggestions become the resolution, the fix
+ * suggestion becomes the hint) and back, so a tool can surface a validator
+ * finding as its own failure without rebuilding it field by field.
+ */
+
+import type { ErrorGuidance } from '@/types';
+import { ValidationCategory, ValidationSeverity, type ValidationResult } from './core-types';
+
+const SEVERITIES = new Set<string>(Object.values(ValidationSeverity));
+const CATEGORIES = new Set<string>(Object.values(ValidationCategory));
+
+const stringList = (value: unknown): string[] | undefined =>
+  Array.isArray(value) && value.every((item) => typeof item === 'string') ? value : undefined;
+
+const text = (value: unknown): string | undefined =>
+  typeof value === 'string' && value.length > 0 ? value : undefined;
+
+/**
+ * Describe a failed validation result as error guidance
+ */
+export function validationResultToGuidance(result: ValidationResult): ErrorGuidance {
+  const message = result.message || result.errors[0] || result.ruleId || 'Validation failed';
+  const { severity, category, location, fixSuggestion } = result.metadata ?? {};
+
+  return {
+    message,
+    ...(fixSuggestion && { hint: fixSuggestion }),
+    ...(result.suggestions && result.suggestions.length > 0 && {
+      resolution: result.suggestions.join('\n'),
+    }),
+    details: {
+      ...(result.ruleId && { ruleId: result.ruleId }),
+      errors: result.errors,
+      warnings: result.warnings,
+      ...(severity && { severity }),
+      ...(category && { category }),
+      ...(location && { location }),
+    },
+    // Re-running the same validation on the same input gives the same result
+    retryable: false,
+  };
+}
+
+/**
+ * Rebuild a failed validation result from error guidance
+ *
+ * Fields written by validationResultToGuidance are restored exactly; other
+ * guidance maps onto the closest fields (message as the only error, each
+ * resolution line as a suggestion).
+ */
+export function guidanceToValidationResult(guidance: ErrorGuidance): ValidationResult {
+  const details = guidance.details ?? {};
+  const ruleId = text(details.ruleId);
+  const severity = text(details.severity);
+  const category = text(details.category);
+  const location = text(details.location);
+  const suggestions = guidance.resolution?.split('\n').filter((line) => line.trim().length > 0);
+
+  const metadata: NonNullable<ValidationResult['metadata']> = {
+    ...(severity && SEVERITIES.has(severity) && { severity: severity as ValidationSeverity }),
+    ...(category && CATEGORIES.has(category) && { category: category as ValidationCategory }),
+    ...(location && { location }),
+    ...(guidance.hint && { fixSuggestion: guidance.hint }),
+  };
+
+  return {
+    isValid: false,
+    passed: false,
+    errors: stringList(details.errors) ?? [guidance.message],
+    warnings: stringList(details.warnings) ?? [],
+    message: guidance.message,
+    ...(ruleId && { ruleId }),
+    ...(suggestions && suggestions.length > 0 && { suggestions }),
+    ...(Object.keys(metadata).length > 0 && { metadata }),
+  };
+}
@@ -1,13 +1,55 @@
 /**
  * Validation module exports - Functional API with Result<T> pattern
+ *
+ * Published as `containerization-assist-mcp/validation`.
  */
 
 export * from './core-types';
 
+export { validationResultToGuidance, guidanceToValidationResult } from './error-guidance';
+export {
+  runValidationPhases,
+  type ValidationPhase,
+  type ValidationPhaseOptions,
+  type ValidationPhaseOutcome,
+  type ValidationPhaseStatus,
+  type PhaseValidator,
+  type PhasedValidationReport,
+} from './phases';
+export {
+  parsePhaseConfig,
+  buildValidationPhases,
+  PhaseConfigSchema,
+  type PhaseConfig,
+  type ValidatorFactory,
+  type ValidatorRegistry,
+  type ValidationPipeline,
+} from './phase-config';
+export { applySeverityPolicy, type SeverityPolicy } from './severity-policy';
+export { summarizeReport, type ValidationSummary, type PhaseSummary } from './report-summary';
 export {
   createKubernetesValidator,
+  createK8sApiVersionValidator,
+  createK8sLabelConventionValidator,
   type KubernetesValidatorInstance,
 } from './kubernetes-validator';
+export {
+  K8S_API_REMOVALS,
+  getApiVersionStatus,
+  type ApiVersionRemoval,
+  type ApiVersionStatus,
+} from './k8s-api-versions';
+export { createComposeValidator, type ComposeValidatorInstance } from './compose-validator';
+export {
+  DEFAULT_SECRET_RULES,
+  loadSecretRules,
+  parseSecretRules,
+  scanForSecrets,
+  type SecretRule,
+  type SecretRuleset,
+  type SecretAllowlist,
+  type SecretFinding,
+} from './secret-rules';
 export type {
   ValidationResult,
   ValidationReport,
@@ -16,4 +58,8 @@ export type {
   ValidationGrade,
   DockerfileValidationRule,
   KubernetesValidationRule,
+  KubernetesValidationContext,
+  LabelPolicy,
+  MetadataKeyRequirement,
+  ComposeValidationRule,
 } from './core-types';
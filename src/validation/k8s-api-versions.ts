@@ -0,0 +1,230 @@
+/**
+ * Kubernetes API version removals
+ *
+ * Served API versions that later Kubernetes releases removed, keyed by the
+ * release that removed them, following the upstream deprecated API migration
+ * guide. Used to flag manifests that will stop applying after a cluster
+ * upgrade.
+ */
+
+import type { KubernetesManifest } from './core-types';
+
+export interface ApiVersionRemoval {
+  /** Group/version that is no longer served, e.g. `extensions/v1beta1` */
+  apiVersion: string;
+  kinds: string[];
+  /** Group/version to migrate to, or a note when there is no direct replacement */
+  replacement: string;
+  /** Release that started warning about the version, when known */
+  deprecatedIn?: string;
+}
+
+export const K8S_API_REMOVALS: Readonly<Record<string, readonly ApiVersionRemoval[]>> = {
+  '1.16': [
+    {
+      apiVersion: 'extensions/v1beta1',
+      kinds: ['Deployment', 'DaemonSet', 'ReplicaSet'],
+      replacement: 'apps/v1',
+    },
+    {
+      apiVersion: 'apps/v1beta1',
+      kinds: ['Deployment', 'StatefulSet', 'ReplicaSet'],
+      replacement: 'apps/v1',
+    },
+    {
+      apiVersion: 'apps/v1beta2',
+      kinds: ['Deployment', 'StatefulSet', 'DaemonSet', 'ReplicaSet'],
+      replacement: 'apps/v1',
+    },
+    {
+      apiVersion: 'extensions/v1beta1',
+      kinds: ['NetworkPolicy'],
+      replacement: 'networking.k8s.io/v1',
+    },
+    {
+      apiVersion: 'extensions/v1beta1',
+      kinds: ['PodSecurityPolicy'],
+      replacement: 'policy/v1beta1',
+    },
+  ],
+  '1.22': [
+    {
+      apiVersion: 'extensions/v1beta1',
+      kinds: ['Ingress'],
+      replacement: 'networking.k8s.io/v1',
+      deprecatedIn: '1.14',
+    },
+    {
+      apiVersion: 'networking.k8s.io/v1beta1',
+      kinds: ['Ingress', 'IngressClass'],
+      replacement: 'networking.k8s.io/v1',
+      deprecatedIn: '1.19',
+    },
+    {
+      apiVersion: 'apiextensions.k8s.io/v1beta1',
+      kinds: ['CustomResourceDefinition'],
+      replacement: 'apiextensions.k8s.io/v1',
+      deprecatedIn: '1.16',
+    },
+    {
+      apiVersion: 'admissionregistration.k8s.io/v1beta1',
+      kinds: ['MutatingWebhookConfiguration', 'ValidatingWebhookConfiguration'],
+      replacement: 'admissionregistration.k8s.io/v1',
+      deprecatedIn: '1.16',
+    },
+    {
+      apiVersion: 'rbac.authorization.k8s.io/v1beta1',
+      kinds: ['ClusterRole', 'ClusterRoleBinding', 'Role', 'RoleBinding'],
+      replacement: 'rbac.authorization.k8s.io/v1',
+      deprecatedIn: '1.17',
+    },
+    {
+      apiVersion: 'scheduling.k8s.io/v1beta1',
+      kinds: ['PriorityClass'],
+      replacement: 'scheduling.k8s.io/v1',
+      deprecatedIn: '1.14',
+    },
+    {
+      apiVersion: 'certificates.k8s.io/v1beta1',
+      kinds: ['CertificateSigningRequest'],
+      replacement: 'certificates.k8s.io/v1',
+      deprecatedIn: '1.19',
+    },
+    {
+      apiVersion: 'coordination.k8s.io/v1beta1',
+      kinds: ['Lease'],
+      replacement: 'coordination.k8s.io/v1',
+    },
+    {
+      apiVersion: 'storage.k8s.io/v1beta1',
+      kinds: ['CSIDriver', 'CSINode', 'StorageClass', 'VolumeAttachment'],
+      replacement: 'storage.k8s.io/v1',
+    },
+  ],
+  '1.25': [
+    {
+      apiVersion: 'batch/v1beta1',
+      kinds: ['CronJob'],
+      replacement: 'batch/v1',
+      deprecatedIn: '1.21',
+    },
+    {
+      apiVersion: 'policy/v1beta1',
+      kinds: ['PodDisruptionBudget'],
+      replacement: 'policy/v1',
+      deprecatedIn: '1.21',
+    },
+    {
+      apiVersion: 'policy/v1beta1',
+      kinds: ['PodSecurityPolicy'],
+      replacement: 'Pod Security Admission (no direct replacement)',
+      deprecatedIn: '1.21',
+    },
+    {
+      apiVersion: 'autoscaling/v2beta1',
+      kinds: ['HorizontalPodAutoscaler'],
+      replacement: 'autoscaling/v2',
+      deprecatedIn: '1.22',
+    },
+    {
+      apiVersion: 'discovery.k8s.io/v1beta1',
+      kinds: ['EndpointSlice'],
+      replacement: 'discovery.k8s.io/v1',
+      deprecatedIn: '1.21',
+    },
+    {
+      apiVersion: 'events.k8s.io/v1beta1',
+      kinds: ['Event'],
+      replacement: 'events.k8s.io/v1',
+    },
+    {
+      apiVersion: 'node.k8s.io/v1beta1',
+      kinds: ['RuntimeClass'],
+      replacement: 'node.k8s.io/v1',
+    },
+  ],
+  '1.26': [
+    {
+      apiVersion: 'autoscaling/v2beta2',
+      kinds: ['HorizontalPodAutoscaler'],
+      replacement: 'autoscaling/v2',
+      deprecatedIn: '1.23',
+    },
+    {
+      apiVersion: 'flowcontrol.apiserver.k8s.io/v1beta1',
+      kinds: ['FlowSchema', 'PriorityLevelConfiguration'],
+      replacement: 'flowcontrol.apiserver.k8s.io/v1',
+      deprecatedIn: '1.23',
+    },
+  ],
+  '1.27': [
+    {
+      apiVersion: 'storage.k8s.io/v1beta1',
+      kinds: ['CSIStorageCapacity'],
+      replacement: 'storage.k8s.io/v1',
+      deprecatedIn: '1.24',
+    },
+  ],
+  '1.29': [
+    {
+      apiVersion: 'flowcontrol.apiserver.k8s.io/v1beta2',
+      kinds: ['FlowSchema', 'PriorityLevelConfiguration'],
+      replacement: 'flowcontrol.apiserver.k8s.io/v1',
+      deprecatedIn: '1.26',
+    },
+  ],
+  '1.32': [
+    {
+      apiVersion: 'flowcontrol.apiserver.k8s.io/v1beta3',
+      kinds: ['FlowSchema', 'PriorityLevelConfiguration'],
+      replacement: 'flowcontrol.apiserver.k8s.io/v1',
+      deprecatedIn: '1.29',
+    },
+  ],
+};
+
+export interface ApiVersionStatus {
+  status: 'removed' | 'deprecated';
+  removedIn: string;
+  replacement: string;
+}
+
+/**
+ * Parse `1.29`, `v1.29` or `v1.29.3` into a comparable minor version number
+ */
+const parseMinorVersion = (version: string): number | undefined => {
+  const match = /^v?1\.(\d+)(?:\.\d+)?$/.exec(version.trim());
+  return match ? Number(match[1]) : undefined;
+};
+
+/**
+ * Removal or deprecation of the manifest's API version as of the target release
+ *
+ * @returns undefined when the API version is still served without deprecation,
+ *   or when the target version cannot be parsed
+ */
+export const getApiVersionStatus = (
+  manifest: KubernetesManifest,
+  targetVersion: string,
+): ApiVersionStatus | undefined => {
+  const target = parseMinorVersion(targetVersion);
+  if (target === undefined || !manifest.apiVersion || !manifest.kind) return undefined;
+
+  for (const [removedIn, removals] of Object.entries(K8S_API_REMOVALS)) {
+    const removal = removals.find(
+      (entry) =>
+        entry.apiVersion === manifest.apiVersion && entry.kinds.includes(manifest.kind as string),
+    );
+    if (!removal) continue;
+
+    const removedAt = parseMinorVersion(removedIn) ?? Infinity;
+    const deprecatedAt = removal.deprecatedIn ? parseMinorVersion(removal.deprecatedIn) : undefined;
+    if (target >= removedAt) {
+      return { status: 'removed', removedIn, replacement: removal.replacement };
+    }
+    if (deprecatedAt !== undefined && target >= deprecatedAt) {
+      return { status: 'deprecated', removedIn, replacement: removal.replacement };
+    }
+  }
+  return undefined;
+};
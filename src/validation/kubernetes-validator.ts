@@ -9,13 +9,17 @@ import { parse as parseYaml } from 'yaml';
 import { extractErrorMessage } from '@/lib/errors';
 import {
   KubernetesValidationRule,
+  KubernetesValidationContext,
   KubernetesManifest,
+  LabelPolicy,
+  MetadataKeyRequirement,
   ValidationResult,
   ValidationReport,
   ValidationSeverity,
   ValidationCategory,
   ValidationGrade,
 } from './core-types';
+import { getApiVersionStatus } from './k8s-api-versions';
 
 // Type definitions for Kubernetes resources
 interface PodSpec {
@@ -78,7 +82,8 @@ interface WorkloadSpec {
 }
 
 export interface KubernetesValidatorInstance {
-  validate(yamlContent: string): ValidationReport;
+  /** Validate manifests; `context` carries settings for this call only */
+  validate(yamlContent: string, context?: KubernetesValidationContext): ValidationReport;
   getRules(): KubernetesValidationRule[];
   getCategory(category: ValidationCategory): KubernetesValidationRule[];
 }
@@ -113,6 +118,64 @@ const getContainers = (manifest: KubernetesManifest): Container[] => {
   return [...(podSpec?.containers || []), ...(podSpec?.initContainers || [])];
 };
 
+/**
+ * Fully qualified image reference, e.g. `nginx:1.27` -> `docker.io/nginx:1.27`
+ */
+const qualifyImage = (image: string): string => {
+  const [first, ...rest] = image.split('/');
+  // Only registry hosts contain a dot or port, or are localhost
+  const hasRegistry = rest.length > 0 && !!first && /[.:]|^localhost$/.test(first);
+  return hasRegistry ? image : `docker.io/${image}`;
+};
+
+/**
+ * Replacement suggestion for an API version that is removed or deprecated in the target
+ */
+const suggestApiVersion = (
+  manifest: KubernetesManifest,
+  context: KubernetesValidationContext,
+): string => {
+  const status = context.targetVersion
+    ? getApiVersionStatus(manifest, context.targetVersion)
+    : undefined;
+  if (!status) return 'Use an API version served by the target cluster';
+  return (
+    `Migrate ${manifest.kind} from ${manifest.apiVersion} to ${status.replacement} ` +
+    `(removed in ${status.removedIn})`
+  );
+};
+
+/**
+ * API version rules; they pass unless the context names a target cluster version
+ */
+const API_VERSION_RULES: KubernetesValidationRule[] = [
+  {
+    id: 'api-version-removed',
+    name: 'API version served',
+    description: 'Resources must not use API versions removed in the target cluster version',
+    check: (manifest: KubernetesManifest, context: KubernetesValidationContext) =>
+      !context.targetVersion ||
+      getApiVersionStatus(manifest, context.targetVersion)?.status !== 'removed',
+    message: 'API version is no longer served by the target cluster version',
+    severity: ValidationSeverity.ERROR,
+    suggest: suggestApiVersion,
+    category: ValidationCategory.COMPLIANCE,
+  },
+
+  {
+    id: 'api-version-deprecated',
+    name: 'API version not deprecated',
+    description: 'Resources should not use API versions deprecated in the target cluster version',
+    check: (manifest: KubernetesManifest, context: KubernetesValidationContext) =>
+      !context.targetVersion ||
+      getApiVersionStatus(manifest, context.targetVersion)?.status !== 'deprecated',
+    message: 'API version is deprecated and will be removed in a later release',
+    severity: ValidationSeverity.WARNING,
+    suggest: suggestApiVersion,
+    category: ValidationCategory.COMPLIANCE,
+  },
+];
+
 /**
  * Kubernetes validation rules
  */
@@ -268,6 +331,26 @@ const KUBERNETES_RULES: KubernetesValidationRule[] = [
     category: ValidationCategory.BEST_PRACTICE,
   },
 
+  {
+    id: 'trusted-registry',
+    name: 'Images from allowed registries',
+    description: 'Images must come from the registries allowed for this validation',
+    check: (manifest: KubernetesManifest, context: KubernetesValidationContext) => {
+      const allowed = context.allowedRegistries;
+      if (!allowed || !isWorkload(manifest)) return true;
+
+      return getContainers(manifest).every((container) => {
+        if (!container.image) return true;
+        const image = qualifyImage(container.image);
+        return allowed.some((prefix) => image.startsWith(`${prefix.replace(/\/+$/, '')}/`));
+      });
+    },
+    message: 'Pull images only from allowed registries',
+    severity: ValidationSeverity.ERROR,
+    fix: 'Push the image to an allowed registry and update the image reference',
+    category: ValidationCategory.SECURITY,
+  },
+
   {
     id: 'no-host-network',
     name: 'Avoid host networking',
@@ -331,17 +414,22 @@ const KUBERNETES_RULES: KubernetesValidationRule[] = [
     fix: 'Add strategy.type (RollingUpdate or Recreate)',
     category: ValidationCategory.BEST_PRACTICE,
   },
+
+  ...API_VERSION_RULES,
 ];
 
+/**
+ * Split content into YAML documents; multiple K8s resources are separated by ---
+ */
+const splitDocuments = (yamlContent: string): string[] => yamlContent.split(/^---\s*$/m);
+
 /**
  * Parse YAML documents from content
  */
 const parseDocuments = (yamlContent: string): KubernetesManifest[] => {
-  // YAML allows multiple K8s resources separated by ---
-  const parts = yamlContent.split(/^---\s*$/m);
   const documents: KubernetesManifest[] = [];
 
-  for (const part of parts) {
+  for (const part of splitDocuments(yamlContent)) {
     const trimmed = part.trim();
     if (trimmed) {
       try {
@@ -405,10 +493,15 @@ const createReport = (results: ValidationResult[]): ValidationReport => {
 /**
  * Validate Kubernetes YAML content
  */
-const validateKubernetesContent = (yamlContent: string): ValidationReport => {
+const validateKubernetesContent = (
+  yamlContent: string,
+  context: KubernetesValidationContext = {},
+  rules: KubernetesValidationRule[] = KUBERNETES_RULES,
+): ValidationReport => {
   try {
     try {
-      parseYaml(yamlContent);
+      // Parse per document: the parser rejects multi-document content as a whole
+      splitDocuments(yamlContent).forEach((part) => parseYaml(part));
     } catch (parseError) {
       return {
         results: [
@@ -472,9 +565,10 @@ const validateKubernetesContent = (yamlContent: string): ValidationReport => {
 
       validDocumentCount++;
 
-      for (const rule of KUBERNETES_RULES) {
-        const passed = rule.check(doc);
+      for (const rule of rules) {
+        const passed = rule.check(doc, context);
         const resourceName = doc.metadata?.name || doc.kind;
+        const fix = passed ? undefined : (rule.suggest?.(doc, context) ?? rule.fix);
 
         allResults.push({
           ruleId: `${resourceName}-${rule.id}`,
@@ -485,7 +579,7 @@ const validateKubernetesContent = (yamlContent: string): ValidationReport => {
           message: passed
             ? `✓ [${resourceName}] ${rule.name}`
             : `✗ [${resourceName}] ${rule.name}: ${rule.message}`,
-          suggestions: !passed && rule.fix ? [rule.fix] : [],
+          suggestions: fix ? [fix] : [],
           metadata: {
             severity: rule.severity,
             location: `${doc.kind}/${resourceName}`,
@@ -574,6 +668,95 @@ export const createKubernetesValidator = (): KubernetesValidatorInstance => {
   };
 };
 
+/**
+ * Create a validator that only checks API versions against a target cluster version
+ *
+ * Resources using an API version removed as of `targetVersion` (e.g. `1.29`)
+ * fail with an error, deprecated ones with a warning; both suggest the
+ * replacement group/version.
+ */
+export const createK8sApiVersionValidator = (
+  targetVersion: string,
+): KubernetesValidatorInstance => {
+  return {
+    validate: (yamlContent, context = {}) =>
+      validateKubernetesContent(yamlContent, { ...context, targetVersion }, API_VERSION_RULES),
+    getRules: () => [...API_VERSION_RULES],
+    getCategory: (category) => API_VERSION_RULES.filter((rule) => rule.category === category),
+  };
+};
+
+/**
+ * Build one rule per label or annotation key in a convention policy
+ */
+const metadataKeyRules = (
+  field: 'labels' | 'annotations',
+  requirements: Array<string | MetadataKeyRequirement>,
+  severity: ValidationSeverity,
+): KubernetesValidationRule[] =>
+  requirements.map((requirement) => {
+    const { key, pattern }: MetadataKeyRequirement =
+      typeof requirement === 'string' ? { key: requirement } : requirement;
+    const regex = pattern === undefined ? undefined : new RegExp(pattern);
+    const kind = field === 'labels' ? 'label' : 'annotation';
+    const path = `metadata.${field}["${key}"]`;
+    const expectation = severity === ValidationSeverity.ERROR ? 'must' : 'should';
+    const valueOf = (manifest: KubernetesManifest): string | undefined => {
+      const value = manifest.metadata?.[field]?.[key];
+      return value === undefined || value === null ? undefined : String(value);
+    };
+
+    return {
+      id: `${kind}-${key}`,
+      name: `${kind === 'label' ? 'Label' : 'Annotation'} ${key}`,
+      description: `Resources ${expectation} set ${path}`,
+      check: (manifest: KubernetesManifest) => {
+        const value = valueOf(manifest);
+        return value !== undefined && (!regex || regex.test(value));
+      },
+      message: regex
+        ? `${path} ${expectation} be set and match ${regex}`
+        : `${path} ${expectation} be set`,
+      severity,
+      suggest: (manifest: KubernetesManifest) => {
+        const value = valueOf(manifest);
+        return value === undefined
+          ? `Add ${path}`
+          : `Change ${path} from "${value}" to a value matching ${regex}`;
+      },
+      category: ValidationCategory.COMPLIANCE,
+    };
+  });
+
+/**
+ * Create a validator that checks label and annotation conventions on every resource
+ *
+ * Missing or non-matching required keys are errors, recommended keys are
+ * warnings. Each failed result names the resource and the metadata field path.
+ *
+ * @throws SyntaxError when a string pattern is not a valid regular expression
+ */
+export const createK8sLabelConventionValidator = (
+  policy: LabelPolicy,
+): KubernetesValidatorInstance => {
+  const rules = [
+    ...metadataKeyRules('labels', policy.requiredLabels ?? [], ValidationSeverity.ERROR),
+    ...metadataKeyRules('labels', policy.recommendedLabels ?? [], ValidationSeverity.WARNING),
+    ...metadataKeyRules('annotations', policy.requiredAnnotations ?? [], ValidationSeverity.ERROR),
+    ...metadataKeyRules(
+      'annotations',
+      policy.recommendedAnnotations ?? [],
+      ValidationSeverity.WARNING,
+    ),
+  ];
+
+  return {
+    validate: (yamlContent, context = {}) => validateKubernetesContent(yamlContent, context, rules),
+    getRules: () => [...rules],
+    getCategory: (category) => rules.filter((rule) => rule.category === category),
+  };
+};
+
 /**
  * Standalone validation function for simple use cases
  */
@@ -0,0 +1,156 @@
+/**
+ * Declarative validation phases
+ *
+ * Builds the phases for runValidationPhases from a YAML or JSON config instead
+ * of code. Validators are referenced by name and resolved through a registry of
+ * factories, so a typo in the config fails when the phases are built rather
+ * than silently validating less.
+ *
+ * @example
+ * ```yaml
+ * failFast: true
+ * severity:
+ *   warningsAsErrors: true
+ *   downgrade: [no-root-user]
+ * phases:
+ *   - name: syntax
+ *     validators: [parse]
+ *   - name: security
+ *     validators:
+ *       - secrets
+ *       - name: base-image
+ *         options: { allowLatest: false }
+ * ```
+ */
+
+import { z } from 'zod';
+import { parse as parseYaml } from 'yaml';
+import { Success, Failure, type Result } from '@/types';
+import { extractErrorMessage } from '@/lib/errors';
+import type { PhaseValidator, ValidationPhase, ValidationPhaseOptions } from './phases';
+
+/**
+ * Creates a validator from the options given in the config
+ */
+export type ValidatorFactory<T> = (options: Record<string, unknown>) => PhaseValidator<T>;
+
+/**
+ * Validator factories by the name used in configs
+ */
+export type ValidatorRegistry<T> = Readonly<Record<string, ValidatorFactory<T>>>;
+
+const validatorRefSchema = z.union([
+  z.string().min(1),
+  z.object({
+    name: z.string().min(1),
+    options: z.record(z.unknown()).optional(),
+  }),
+]);
+
+export const PhaseConfigSchema = z.object({
+  failFast: z.boolean().optional(),
+  timing: z.boolean().optional(),
+  severity: z
+    .object({
+      warningsAsErrors: z.boolean().optional(),
+      downgrade: z.array(z.string().min(1)).optional(),
+    })
+    .optional(),
+  phases: z
+    .array(
+      z.object({
+        name: z.string().min(1),
+        validators: z.array(validatorRefSchema).min(1),
+      }),
+    )
+    .min(1),
+});
+
+export type PhaseConfig = z.infer<typeof PhaseConfigSchema>;
+
+/**
+ * Phases and run options ready for runValidationPhases
+ */
+export interface ValidationPipeline<T> {
+  phases: ValidationPhase<T>[];
+  options: ValidationPhaseOptions;
+}
+
+/**
+ * Parse and validate phase config content (YAML or JSON)
+ */
+export function parsePhaseConfig(content: string): Result<PhaseConfig> {
+  let parsed: unknown;
+  try {
+    parsed = parseYaml(content);
+  } catch (error) {
+    return Failure(`Invalid YAML in validation config: ${extractErrorMessage(error)}`, {
+      message: 'Validation config is not valid YAML or JSON',
+      hint: extractErrorMessage(error),
+      resolution: 'Fix the syntax in the validation config',
+    });
+  }
+
+  const result = PhaseConfigSchema.safeParse(parsed);
+  if (!result.success) {
+    const issues = result.error.issues.map((i) => `${i.path.join('.')}: ${i.message}`);
+    return Failure(`Invalid validation config: ${issues.join(', ')}`, {
+      message: 'Validation config does not match the expected shape',
+      details: { issues },
+      resolution: 'Define phases as a list of { name, validators } entries',
+    });
+  }
+  return Success(result.data);
+}
+
+/**
+ * Resolve a phase config against a validator registry
+ *
+ * Every unknown validator name is reported, not just the first.
+ */
+export function buildValidationPhases<T>(
+  config: PhaseConfig,
+  registry: ValidatorRegistry<T>,
+): Result<ValidationPipeline<T>> {
+  const unknown: string[] = [];
+
+  const phases = config.phases.map((phase): ValidationPhase<T> => {
+    const validators: PhaseValidator<T>[] = [];
+    for (const ref of phase.validators) {
+      const name = typeof ref === 'string' ? ref : ref.name;
+      const options = typeof ref === 'string' ? {} : (ref.options ?? {});
+      const factory = Object.hasOwn(registry, name) ? registry[name] : undefined;
+      if (!factory) {
+        unknown.push(`${phase.name}/${name}`);
+        continue;
+      }
+      validators.push(factory(options));
+    }
+    return { name: phase.name, validators };
+  });
+
+  if (unknown.length > 0) {
+    const message = `Unknown validators in validation config: ${unknown.join(', ')}`;
+    return Failure(message, {
+      message,
+      hint: `Registered validators: ${Object.keys(registry).join(', ') || 'none'}`,
+      resolution: 'Register the validators or fix their names in the config',
+    });
+  }
+
+  return Success({
+    phases,
+    options: {
+      ...(config.failFast !== undefined && { failFast: config.failFast }),
+      ...(config.timing !== undefined && { timing: config.timing }),
+      ...(config.severity && {
+        severity: {
+          ...(config.severity.warningsAsErrors !== undefined && {
+            warningsAsErrors: config.severity.warningsAsErrors,
+          }),
+          ...(config.severity.downgrade && { downgrade: config.severity.downgrade }),
+        },
+      }),
+    },
+  });
+}
@@ -0,0 +1,120 @@
+/**
+ * Phased validation
+ *
+ * Runs groups of validators in order, e.g. syntax before security, and merges
+ * their reports. With failFast, a phase that reports errors stops the later
+ * phases; they are listed as skipped so callers can tell "not checked" apart
+ * from "passed".
+ */
+
+import type { ValidationReport } from './core-types';
+import { mergeMultipleReports } from './merge-reports';
+import { applySeverityPolicy, type SeverityPolicy } from './severity-policy';
+
+export type ValidationPhaseStatus = 'passed' | 'failed' | 'skipped';
+
+/**
+ * Validator run as part of a phase
+ */
+export type PhaseValidator<T> = (input: T) => ValidationReport | Promise<ValidationReport>;
+
+/**
+ * Named group of validators that run together
+ */
+export interface ValidationPhase<T> {
+  name: string;
+  validators: PhaseValidator<T>[];
+}
+
+export interface ValidationPhaseOutcome {
+  name: string;
+  status: ValidationPhaseStatus;
+  /** Error-severity results reported by the phase (0 when skipped) */
+  errors: number;
+  /** Wall time of the phase; set when timing is enabled and the phase ran */
+  durationMs?: number;
+  /** Time taken by each validator, in the order given; set with durationMs */
+  validatorDurationsMs?: number[];
+}
+
+export interface PhasedValidationReport extends ValidationReport {
+  /** One entry per phase, in the order given */
+  phases: ValidationPhaseOutcome[];
+  /** Total wall time of all phases; set when timing is enabled */
+  durationMs?: number;
+}
+
+export interface ValidationPhaseOptions {
+  /** Skip the remaining phases once a phase reports errors */
+  failFast?: boolean;
+  /** Record phase and validator durations in the report */
+  timing?: boolean;
+  /** Adjust severities before deciding whether a phase failed */
+  severity?: SeverityPolicy;
+}
+
+/**
+ * Run validation phases in order and merge their reports
+ *
+ * Validators within a phase run concurrently, so a phase takes as long as its
+ * slowest validator.
+ *
+ * @example
+ * ```typescript
+ * const report = await runValidationPhases(
+ *   content,
+ *   [
+ *     { name: 'syntax', validators: [checkSyntax] },
+ *     { name: 'security', validators: [checkSecrets, checkUser] },
+ *   ],
+ *   { failFast: true },
+ * );
+ * ```
+ */
+export async function runValidationPhases<T>(
+  input: T,
+  phases: ValidationPhase<T>[],
+  options: ValidationPhaseOptions = {},
+): Promise<PhasedValidationReport> {
+  const reports: ValidationReport[] = [];
+  const outcomes: ValidationPhaseOutcome[] = [];
+  const startTime = Date.now();
+  let stopped = false;
+
+  for (const phase of phases) {
+    if (stopped) {
+      outcomes.push({ name: phase.name, status: 'skipped', errors: 0 });
+      continue;
+    }
+
+    const phaseStart = Date.now();
+    const timed = await Promise.all(
+      phase.validators.map(async (validate) => {
+        const validatorStart = Date.now();
+        const report = await validate(input);
+        return { report, durationMs: Date.now() - validatorStart };
+      }),
+    );
+    const merged = mergeMultipleReports(timed.map(({ report }) => report));
+    const phaseReport = options.severity ? applySeverityPolicy(merged, options.severity) : merged;
+    reports.push(phaseReport);
+
+    const failed = phaseReport.errors > 0;
+    outcomes.push({
+      name: phase.name,
+      status: failed ? 'failed' : 'passed',
+      errors: phaseReport.errors,
+      ...(options.timing && {
+        durationMs: Date.now() - phaseStart,
+        validatorDurationsMs: timed.map(({ durationMs }) => durationMs),
+      }),
+    });
+    stopped = failed && options.failFast === true;
+  }
+
+  return {
+    ...mergeMultipleReports(reports),
+    phases: outcomes,
+    ...(options.timing && { durationMs: Date.now() - startTime }),
+  };
+}
@@ -0,0 +1,83 @@
+/**
+ * Validation report summary
+ *
+ * Aggregate counts for dashboards and CI output, so consumers do not have to
+ * walk the results themselves.
+ */
+
+import { ValidationSeverity, type ValidationReport } from './core-types';
+import type { PhasedValidationReport, ValidationPhaseStatus } from './phases';
+
+export interface PhaseSummary {
+  status: ValidationPhaseStatus;
+  errors: number;
+  durationMs?: number;
+}
+
+export interface ValidationSummary {
+  total: number;
+  passed: number;
+  failed: number;
+  /** Failed results per severity */
+  bySeverity: Record<ValidationSeverity, number>;
+  /** Failed results per rule ID */
+  byRule: Record<string, number>;
+  /** Outcome per phase; empty for reports that were not produced in phases */
+  byPhase: Record<string, PhaseSummary>;
+  /** Total validation time, when the report was produced with timing enabled */
+  durationMs?: number;
+}
+
+const isPhased = (report: ValidationReport): report is PhasedValidationReport =>
+  Array.isArray((report as Partial<PhasedValidationReport>).phases);
+
+/**
+ * Summarize a validation report
+ *
+ * Counts are computed from the results rather than copied from the report
+ * totals, so they always agree with what is listed.
+ */
+export function summarizeReport(report: ValidationReport): ValidationSummary {
+  const bySeverity: Record<ValidationSeverity, number> = {
+    [ValidationSeverity.ERROR]: 0,
+    [ValidationSeverity.WARNING]: 0,
+    [ValidationSeverity.INFO]: 0,
+  };
+  const byRule: Record<string, number> = {};
+  let passed = 0;
+
+  for (const result of report.results) {
+    if (result.passed) {
+      passed++;
+      continue;
+    }
+
+    const severity = result.metadata?.severity;
+    if (severity) bySeverity[severity]++;
+
+    const ruleId = result.ruleId || 'unknown';
+    byRule[ruleId] = (byRule[ruleId] ?? 0) + 1;
+  }
+
+  const byPhase: Record<string, PhaseSummary> = {};
+  const phased = isPhased(report);
+  if (phased) {
+    for (const phase of report.phases) {
+      byPhase[phase.name] = {
+        status: phase.status,
+        errors: phase.errors,
+        ...(phase.durationMs !== undefined && { durationMs: phase.durationMs }),
+      };
+    }
+  }
+
+  return {
+    total: report.results.length,
+    passed,
+    failed: report.results.length - passed,
+    bySeverity,
+    byRule,
+    byPhase,
+    ...(phased && report.durationMs !== undefined && { durationMs: report.durationMs }),
+  };
+}
@@ -0,0 +1,274 @@
+/**
+ * Secret detection rules for the Dockerfile no-secrets check
+ *
+ * The built-in rules follow the gitleaks default ruleset for the secret types
+ * most often pasted into Dockerfiles. Each rule is a regex with an optional
+ * capture group holding the secret and a minimum Shannon entropy, so that
+ * placeholders such as `sk_test_xxxxxxxxxx` are not reported.
+ *
+ * Custom rules are read from a gitleaks-style TOML file:
+ *
+ * ```toml
+ * [extend]
+ * useDefault = true   # keep the built-in rules (default: replace them)
+ *
+ * [[rules]]
+ * id = "internal-api-token"
+ * description = "Internal API token"
+ * regex = '''itk_[a-z0-9]{32}'''
+ * entropy = 3.0
+ *
+ * [allowlist]
+ * regexes = ['''EXAMPLE''']
+ * stopwords = ["dummy"]
+ * ```
+ */
+
+import { readFile } from 'node:fs/promises';
+import * as toml from '@iarna/toml';
+import { extractErrorMessage } from '@/lib/errors';
+import { Success, Failure, type Result } from '@/types';
+
+export interface SecretRule {
+  id: string;
+  description: string;
+  regex: RegExp;
+  /** Capture group holding the secret; the whole match when omitted */
+  secretGroup?: number;
+  /** Minimum Shannon entropy of the secret, in bits per character */
+  entropy?: number;
+}
+
+/** Known false positives, matched against the secret */
+export interface SecretAllowlist {
+  regexes?: RegExp[];
+  /** Secrets containing any of these words (case-insensitive) are ignored */
+  stopwords?: string[];
+}
+
+export interface SecretRuleset {
+  rules: SecretRule[];
+  allowlist?: SecretAllowlist;
+}
+
+export interface SecretFinding {
+  ruleId: string;
+  description: string;
+  /** Dockerfile path, when the content came from a file */
+  file?: string;
+  /** 1-based line the instruction starts on */
+  line: number;
+  /** The secret with all but its first characters masked */
+  match: string;
+}
+
+export const DEFAULT_SECRET_RULES: readonly SecretRule[] = [
+  {
+    id: 'slack-bot-token',
+    description: 'Slack bot token',
+    regex: /xoxb-[0-9]{10,13}-[0-9]{10,13}[a-zA-Z0-9-]*/,
+  },
+  {
+    id: 'slack-user-token',
+    description: 'Slack user token',
+    regex: /xox[pe](?:-[0-9]{10,13}){3}-[a-zA-Z0-9-]{28,34}/,
+  },
+  {
+    id: 'slack-webhook-url',
+    description: 'Slack webhook URL',
+    regex: /(?:https?:\/\/)?hooks\.slack\.com\/(?:services|workflows)\/[A-Za-z0-9+/]{43,46}/,
+  },
+  {
+    id: 'gcp-service-account',
+    description: 'GCP service account key',
+    regex: /"type"\s*:\s*"service_account"/,
+  },
+  {
+    id: 'gcp-api-key',
+    description: 'GCP API key',
+    regex: /\b(AIza[\w-]{35})(?:[^\w-]|$)/,
+    secretGroup: 1,
+    entropy: 3,
+  },
+  {
+    id: 'stripe-access-token',
+    description: 'Stripe access token',
+    regex: /\b((?:sk|rk)_(?:test|live|prod)_[a-zA-Z0-9]{10,99})\b/,
+    secretGroup: 1,
+    entropy: 2,
+  },
+  {
+    id: 'private-key',
+    description: 'Private key',
+    regex: /-----BEGIN[ A-Z0-9_-]{0,100}PRIVATE KEY(?: BLOCK)?-----/,
+  },
+  {
+    id: 'jwt',
+    description: 'JSON Web Token',
+    regex: /\b(ey[a-zA-Z0-9]{17,}\.ey[a-zA-Z0-9/\\_-]{17,}\.(?:[a-zA-Z0-9/\\_-]{10,}={0,2})?)/,
+    secretGroup: 1,
+    entropy: 3,
+  },
+];
+
+export const DEFAULT_SECRET_RULESET: SecretRuleset = { rules: [...DEFAULT_SECRET_RULES] };
+
+/**
+ * Shannon entropy of a string in bits per character
+ */
+export function shannonEntropy(value: string): number {
+  if (value.length === 0) return 0;
+
+  const counts = new Map<string, number>();
+  for (const char of value) {
+    counts.set(char, (counts.get(char) ?? 0) + 1);
+  }
+  let entropy = 0;
+  for (const count of counts.values()) {
+    const p = count / value.length;
+    entropy -= p * Math.log2(p);
+  }
+  return entropy;
+}
+
+/**
+ * Mask a secret for reporting, keeping a short prefix to help locate it
+ */
+export function redactSecret(secret: string): string {
+  return secret.length < 12 ? '****' : `${secret.slice(0, 4)}****`;
+}
+
+/**
+ * Whether a secret is a known false positive
+ */
+export function isAllowlisted(secret: string, allowlist?: SecretAllowlist): boolean {
+  if (!allowlist) return false;
+
+  const lower = secret.toLowerCase();
+  return (
+    (allowlist.regexes ?? []).some((regex) => regex.test(secret)) ||
+    (allowlist.stopwords ?? []).some((word) => lower.includes(word.toLowerCase()))
+  );
+}
+
+/**
+ * Run a ruleset over the text of one instruction
+ */
+export function scanForSecrets(
+  text: string,
+  line: number,
+  ruleset: SecretRuleset = DEFAULT_SECRET_RULESET,
+  file?: string,
+): SecretFinding[] {
+  const findings: SecretFinding[] = [];
+
+  for (const rule of ruleset.rules) {
+    const match = rule.regex.exec(text);
+    const secret = match?.[rule.secretGroup ?? 0];
+    if (!secret) continue;
+    if (rule.entropy !== undefined && shannonEntropy(secret) < rule.entropy) continue;
+    if (isAllowlisted(secret, ruleset.allowlist)) continue;
+
+    findings.push({
+      ruleId: rule.id,
+      description: rule.description,
+      ...(file && { file }),
+      line,
+      match: redactSecret(secret),
+    });
+  }
+
+  return findings;
+}
+
+interface RulesFile {
+  extend?: { useDefault?: boolean };
+  rules?: Array<{
+    id?: string;
+    description?: string;
+    regex?: string;
+    secretGroup?: number;
+    entropy?: number;
+  }>;
+  allowlist?: { regexes?: string[]; stopwords?: string[] };
+}
+
+/**
+ * Compile a gitleaks (RE2) pattern; a leading `(?i)` becomes the `i` flag
+ */
+const compilePattern = (pattern: string): RegExp => {
+  const insensitive = pattern.startsWith('(?i)');
+  return new RegExp(insensitive ? pattern.slice(4) : pattern, insensitive ? 'i' : '');
+};
+
+/**
+ * Parse a gitleaks-style TOML rules file
+ */
+export function parseSecretRules(content: string): Result<SecretRuleset> {
+  let file: RulesFile;
+  try {
+    file = toml.parse(content) as RulesFile;
+  } catch (error) {
+    return Failure(`Invalid secret rules file: ${extractErrorMessage(error)}`, {
+      message: 'The secret rules file is not valid TOML',
+      hint: 'Rules files use the gitleaks configuration format',
+      resolution: 'Fix the TOML syntax and try again',
+    });
+  }
+
+  const rules: SecretRule[] = file.extend?.useDefault ? [...DEFAULT_SECRET_RULES] : [];
+  for (const [index, rule] of (file.rules ?? []).entries()) {
+    const id = rule.id ?? `rule-${index + 1}`;
+    if (!rule.regex) {
+      return Failure(`Secret rule ${id} has no regex`, {
+        message: 'Every secret rule needs a regex',
+        resolution: `Add a regex to rule ${id} in the rules file`,
+      });
+    }
+
+    try {
+      rules.push({
+        id,
+        description: rule.description ?? id,
+        regex: compilePattern(rule.regex),
+        ...(rule.secretGroup !== undefined && { secretGroup: rule.secretGroup }),
+        ...(rule.entropy !== undefined && { entropy: rule.entropy }),
+      });
+    } catch (error) {
+      return Failure(`Secret rule ${id} has an invalid regex: ${extractErrorMessage(error)}`, {
+        message: 'Secret rule regexes must be valid JavaScript regular expressions',
+        hint: 'Inline flags other than a leading (?i) are not supported',
+        resolution: `Fix the regex of rule ${id} in the rules file`,
+      });
+    }
+  }
+
+  let allowlist: SecretAllowlist | undefined;
+  try {
+    allowlist = file.allowlist && {
+      ...(file.allowlist.regexes && { regexes: file.allowlist.regexes.map(compilePattern) }),
+      ...(file.allowlist.stopwords && { stopwords: file.allowlist.stopwords }),
+    };
+  } catch (error) {
+    return Failure(`Secret allowlist has an invalid regex: ${extractErrorMessage(error)}`, {
+      message: 'Allowlist regexes must be valid JavaScript regular expressions',
+      resolution: 'Fix the allowlist regexes in the rules file',
+    });
+  }
+
+  return Success({ rules, ...(allowlist && { allowlist }) });
+}
+
+/**
+ * Load a gitleaks-style TOML rules file
+ */
+export async function loadSecretRules(rulesPath: string): Promise<Result<SecretRuleset>> {
+  try {
+    return parseSecretRules(await readFile(rulesPath, 'utf-8'));
+  } catch (error) {
+    return Failure(`Failed to read secret rules file: ${extractErrorMessage(error)}`, {
+      message: `Could not read ${rulesPath}`,
+      resolution: 'Check that the rules file exists and is readable',
+    });
+  }
+}
@@ -0,0 +1,101 @@
+/**
+ * Severity policy
+ *
+ * Adjusts finding severities after validation: CI can treat every warning as
+ * an error, while newly introduced rules can be demoted to warnings until
+ * existing Dockerfiles and manifests have caught up.
+ */
+
+import { ValidationSeverity, type ValidationReport, type ValidationResult } from './core-types';
+
+export interface SeverityPolicy {
+  /** Promote every warning to an error */
+  warningsAsErrors?: boolean;
+  /**
+   * Rule IDs to demote from error to warning; takes precedence over
+   * warningsAsErrors. Also matches resource-scoped IDs such as
+   * `web-no-latest-tag` for `no-latest-tag` when `web` is the finding's
+   * Kubernetes resource or Compose service.
+   */
+  downgrade?: string[];
+}
+
+/**
+ * Resource or service name a scoped finding's rule ID is prefixed with,
+ * read back from the location the Kubernetes (`Kind/name`) and Compose
+ * (`services.name`) validators record
+ */
+const scopeOf = (location: string | undefined): string | undefined => {
+  if (!location) return undefined;
+  if (location.startsWith('services.')) return location.slice('services.'.length);
+  const slash = location.indexOf('/');
+  return slash > 0 ? location.slice(slash + 1) : undefined;
+};
+
+const matchesRule = (result: ValidationResult, codes: string[]): boolean => {
+  const { ruleId } = result;
+  if (!ruleId) return false;
+  if (codes.includes(ruleId)) return true;
+
+  const scope = scopeOf(result.metadata?.location);
+  return (
+    !!scope && ruleId.startsWith(`${scope}-`) && codes.includes(ruleId.slice(scope.length + 1))
+  );
+};
+
+/**
+ * Severity a failed result should have under the policy
+ */
+const targetSeverity = (
+  result: ValidationResult,
+  policy: SeverityPolicy,
+): ValidationSeverity | undefined => {
+  const severity = result.metadata?.severity;
+  if (matchesRule(result, policy.downgrade ?? [])) {
+    return severity === ValidationSeverity.ERROR ? ValidationSeverity.WARNING : severity;
+  }
+  if (policy.warningsAsErrors && severity === ValidationSeverity.WARNING) {
+    return ValidationSeverity.ERROR;
+  }
+  return severity;
+};
+
+/**
+ * Apply a severity policy to a validation report
+ *
+ * Only failed results change. Their messages move between `errors` and
+ * `warnings` with the severity, and the report's error, warning and info
+ * counts are recomputed. Score and grade are left as the validator computed
+ * them, since each validator weighs severities differently.
+ */
+export function applySeverityPolicy(
+  report: ValidationReport,
+  policy: SeverityPolicy,
+): ValidationReport {
+  const results = report.results.map((result): ValidationResult => {
+    if (result.passed) return result;
+
+    const from = result.metadata?.severity;
+    const to = targetSeverity(result, policy);
+    if (!from || !to || from === to) return result;
+
+    const messages = [...result.errors, ...result.warnings];
+    return {
+      ...result,
+      errors: to === ValidationSeverity.ERROR ? messages : [],
+      warnings: to === ValidationSeverity.WARNING ? messages : [],
+      metadata: { ...result.metadata, severity: to },
+    };
+  });
+
+  const failedWith = (severity: ValidationSeverity): number =>
+    results.filter((r) => !r.passed && r.metadata?.severity === severity).length;
+
+  return {
+    ...report,
+    results,
+    errors: failedWith(ValidationSeverity.ERROR),
+    warnings: failedWith(ValidationSeverity.WARNING),
+    info: failedWith(ValidationSeverity.INFO),
+  };
+}
@@ -0,0 +1,39 @@
+/**
+ * Bun Basic Repository Fixture
+ * Elysia web application running on Bun with a binary lockfile
+ */
+
+export const bunBasicRepository = {
+  'package.json': `{
+  "name": "bun-basic",
+  "version": "1.0.0",
+  "module": "src/index.ts",
+  "type": "module",
+  "scripts": {
+    "start": "bun run src/index.ts",
+    "dev": "bun --watch src/index.ts",
+    "test": "bun test"
+  },
+  "engines": {
+    "bun": ">=1.1.0"
+  },
+  "dependencies": {
+    "elysia": "^1.1.25"
+  },
+  "devDependencies": {
+    "@types/bun": "^1.1.13",
+    "typescript": "^5.6.3"
+  }
+}`,
+  // bun.lockb is binary; only its presence matters to detection
+  'bun.lockb': '\u0000bun-lockfile-format-v0\n',
+  'src/index.ts': `import { Elysia } from 'elysia';
+
+const app = new Elysia()
+  .get('/', () => ({ message: 'Hello World!' }))
+  .get('/health', () => ({ status: 'ok' }))
+  .listen(Number(process.env.PORT ?? 3000));
+
+console.log(\`Listening on \${app.server?.port}\`);
+`,
+};
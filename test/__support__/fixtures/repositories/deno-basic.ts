@@ -0,0 +1,41 @@
+/**
+ * Deno Basic Repository Fixture
+ * Hono web application running on Deno with a lockfile
+ */
+
+export const denoBasicRepository = {
+  'deno.json': `{
+  "tasks": {
+    "start": "deno run --allow-net --allow-env=PORT main.ts",
+    "dev": "deno run --watch --allow-net --allow-env=PORT main.ts"
+  },
+  "imports": {
+    "@std/assert": "jsr:@std/assert@^1.0.8",
+    "hono": "jsr:@hono/hono@^4.6.12"
+  }
+}`,
+  'deno.lock': `{
+  "version": "4",
+  "specifiers": {
+    "jsr:@hono/hono@^4.6.12": "4.6.12",
+    "jsr:@std/assert@^1.0.8": "1.0.8"
+  },
+  "jsr": {
+    "@hono/hono@4.6.12": { "integrity": "sha256-0000000000000000000000000000000000000000000000000000000000000000" },
+    "@std/assert@1.0.8": { "integrity": "sha256-1111111111111111111111111111111111111111111111111111111111111111" }
+  }
+}`,
+  'main.ts': `import { Hono } from 'hono';
+
+const app = new Hono();
+
+app.get('/', (c) => c.json({ message: 'Hello World!' }));
+app.get('/health', (c) => c.json({ status: 'ok' }));
+
+Deno.serve({ port: Number(Deno.env.get('PORT') ?? 8000) }, app.fetch);
+`,
+  'main_test.ts': `import { assertEquals } from '@std/assert';
+
+Deno.test('adds', () => assertEquals(1 + 1, 2));
+`,
+};
@@ -33,6 +33,10 @@ import {
   expectedRustBasicDockerfile 
 } from './rust-basic';
 
+import { monorepoGoNodeRepository, expectedMonorepoGoNodeAnalysis } from './monorepo-go-node';
+import { denoBasicRepository } from './deno-basic';
+import { bunBasicRepository } from './bun-basic';
+
 // Re-export all fixtures
 export { 
   nodeExpressBasicRepository, 
@@ -49,7 +53,11 @@ export {
   expectedGoBasicDockerfile,
   rustBasicRepository, 
   expectedRustBasicAnalysis, 
-  expectedRustBasicDockerfile
+  expectedRustBasicDockerfile,
+  monorepoGoNodeRepository,
+  expectedMonorepoGoNodeAnalysis,
+  denoBasicRepository,
+  bunBasicRepository
 };
 
 /**
@@ -0,0 +1,66 @@
+/**
+ * Monorepo Repository Fixture
+ * A Go service and a Node.js service living side by side under services/
+ */
+
+export const monorepoGoNodeRepository = {
+  'README.md': `# Platform monorepo
+
+Services live under services/.
+`,
+  'services/inventory/go.mod': `module example.com/platform/inventory
+
+go 1.22
+
+require (
+    github.com/gin-gonic/gin v1.9.1
+)`,
+  'services/inventory/go.sum': `github.com/gin-gonic/gin v1.9.1 h1:4idEAncQnU5cB7BeOkPtxjfCSye0AAm1R0RVIqJ+Jmg=
+`,
+  'services/inventory/main.go': `package main
+
+import "github.com/gin-gonic/gin"
+
+func main() {
+    r := gin.Default()
+    r.GET("/health", func(c *gin.Context) { c.JSON(200, gin.H{"status": "ok"}) })
+    r.Run(":8080")
+}`,
+  'services/storefront/package.json': `{
+  "name": "storefront",
+  "version": "1.0.0",
+  "main": "server.js",
+  "scripts": {
+    "start": "node server.js"
+  },
+  "dependencies": {
+    "express": "^4.19.2"
+  }
+}`,
+  'services/storefront/package-lock.json': `{
+  "name": "storefront",
+  "lockfileVersion": 3,
+  "requires": true,
+  "packages": {}
+}`,
+  'services/storefront/server.js': `const express = require('express');
+const app = express();
+
+app.get('/health', (req, res) => res.json({ status: 'ok' }));
+
+app.listen(process.env.PORT || 3000);
+`,
+};
+
+export const expectedMonorepoGoNodeAnalysis = {
+  isMonorepo: true,
+  services: [
+    { name: 'inventory', path: 'services/inventory', language: 'go', framework: 'gin' },
+    {
+      name: 'storefront',
+      path: 'services/storefront',
+      language: 'javascript',
+      framework: 'express',
+    },
+  ],
+};
@@ -0,0 +1,62 @@
+/**
+ * Dockerfile fixture with one hardcoded secret per built-in secret rule
+ *
+ * The secrets are fake and assembled from parts so that the fixture itself
+ * does not trip secret scanning on the repository.
+ */
+
+export const FAKE_SECRETS = {
+  'slack-bot-token': ['xoxb', '1234567890123', '1234567890123', 'AbCdEfGhIjKlMnOpQrStUvWx'].join(
+    '-',
+  ),
+  'slack-user-token': [
+    'xoxp',
+    '1234567890',
+    '1234567890',
+    '1234567890',
+    'a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6',
+  ].join('-'),
+  'slack-webhook-url': [
+    'https://hooks.slack.com/services',
+    'T00000000',
+    'B00000000',
+    'XXXXXXXXXXXXXXXXXXXXXXXX',
+  ].join('/'),
+  'gcp-service-account': '"type": "service_account"',
+  'gcp-api-key': ['AIza', 'SyD3mo-Fak3Key_0123456789abcdefghij'].join(''),
+  'stripe-access-token': ['sk', 'live', '4eC39HqLyjWDarjtT1zdp7dc'].join('_'),
+  'private-key': `-----BEGIN ${'RSA PRIVATE'} KEY-----`,
+  jwt: [
+    'eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9',
+    'eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiaWF0IjoxNTE2MjM5MDIyfQ',
+    'SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c',
+  ].join('.'),
+} as const;
+
+export type SecretRuleId = keyof typeof FAKE_SECRETS;
+
+/** Line each secret is on in {@link SECRETS_DOCKERFILE} */
+export const SECRET_LINES: Record<SecretRuleId, number> = {
+  'slack-bot-token': 3,
+  'slack-user-token': 4,
+  'slack-webhook-url': 5,
+  'gcp-service-account': 6,
+  'gcp-api-key': 7,
+  'stripe-access-token': 8,
+  'private-key': 9,
+  jwt: 10,
+};
+
+export const SECRETS_DOCKERFILE = `FROM node:20-alpine
+WORKDIR /app
+ENV SLACK_BOT=${FAKE_SECRETS['slack-bot-token']}
+ENV SLACK_USER=${FAKE_SECRETS['slack-user-token']}
+RUN curl -X POST ${FAKE_SECRETS['slack-webhook-url']} -d '{"text":"built"}'
+RUN echo '{${FAKE_SECRETS['gcp-service-account']}, "project_id": "demo"}' > /app/gcp.json
+ENV MAPS_KEY=${FAKE_SECRETS['gcp-api-key']}
+ENV STRIPE=${FAKE_SECRETS['stripe-access-token']}
+RUN printf '${FAKE_SECRETS['private-key']}\\nMIIEowIBAAKCAQEA' > /root/id_rsa
+ENV SESSION=${FAKE_SECRETS.jwt}
+USER node
+EXPOSE 3000
+CMD ["node", "server.js"]`;
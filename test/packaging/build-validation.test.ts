@@ -80,8 +80,10 @@ describe('Build Validation', () => {
         'generate-dockerfile',
         'generate-k8s-manifests',
         'ops',
+        'optimize-dockerfile',
         'prepare-cluster',
         'push-image',
+        'rollback-deploy',
         'scan-image',
         'tag-image',
         'fix-dockerfile',
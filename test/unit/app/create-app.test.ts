@@ -309,3 +309,64 @@ describe('createApp tool aliases', () => {
     expect(tools[0].name).toBe('analyze-repo');
   });
 });
+
+describe('createApp tool introspection', () => {
+  function createCategorizedTool(
+    name: string,
+    category: string,
+    extra: Record<string, unknown> = {},
+  ): Tool<ReturnType<typeof z.object>, unknown> {
+    return {
+      ...createTool(name),
+      category,
+      metadata: { knowledgeEnhanced: false },
+      ...extra,
+    } as Tool<ReturnType<typeof z.object>, unknown>;
+  }
+
+  const tools = () => [
+    createCategorizedTool('analyze-repo', 'analysis', {
+      metadata: { knowledgeEnhanced: true, cacheTtlMs: 60_000 },
+      chainHints: { success: 'Next: generate-dockerfile', failure: 'Check the path' },
+    }),
+    createCategorizedTool('build-image', 'docker'),
+    createCategorizedTool('tag-image', 'docker'),
+    createCategorizedTool('prepare-cluster', 'kubernetes'),
+  ];
+
+  it('should list metadata and capabilities for every tool', () => {
+    const app = createApp({ tools: tools(), logger: createLoggerStub() });
+
+    const [analyze, build] = app.listToolMetadata();
+    expect(analyze).toEqual({
+      name: 'analyze-repo',
+      description: 'analyze-repo description',
+      version: '1.0.0',
+      category: 'analysis',
+      metadata: { knowledgeEnhanced: true, cacheTtlMs: 60_000 },
+      capabilities: ['knowledge-enhanced', 'cacheable', 'chain-hints'],
+    });
+    expect(build?.capabilities).toEqual([]);
+  });
+
+  it('should filter tool names by category', () => {
+    const app = createApp({ tools: tools(), logger: createLoggerStub() });
+
+    expect(app.filterByCategory('docker')).toEqual(['build-image', 'tag-image']);
+    expect(app.filterByCategory('kubernetes')).toEqual(['prepare-cluster']);
+    expect(app.filterByCategory('security')).toEqual([]);
+  });
+
+  it('should look up capabilities by tool name, including aliases', () => {
+    const app = createApp({
+      tools: tools(),
+      toolAliases: { 'analyze-repo': 'project_analyze' },
+      logger: createLoggerStub(),
+    });
+
+    expect(app.hasCapability('project_analyze', 'cacheable')).toBe(true);
+    expect(app.hasCapability('build-image', 'cacheable')).toBe(false);
+    expect(app.hasCapability('analyze-repo', 'cacheable')).toBe(false);
+    expect(app.hasCapability('missing-tool', 'chain-hints')).toBe(false);
+  });
+});
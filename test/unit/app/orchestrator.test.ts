@@ -7,7 +7,16 @@ import { describe, it, expect, beforeEach } from '@jest/globals';
 import { z } from 'zod';
 import { createOrchestrator } from '@/app/orchestrator';
 import type { ToolOrchestrator } from '@/app/orchestrator-types';
-import { Success, Failure, type Tool } from '@/types';
+import { Success, Failure, Cancelled, getPartialResult, type Tool } from '@/types';
+import { createToolMetrics } from '@/lib/tool-metrics';
+import { createCircuitBreaker, CIRCUIT_OPEN } from '@/lib/circuit-breaker';
+import { QUEUE_FULL } from '@/lib/concurrency';
+import { createResultCache, digestArgs } from '@/lib/result-cache';
+import { createAuditLog, type AuditRecord } from '@/lib/audit';
+import { createMemoryWorkflowStateStore } from '@/lib/workflow-state';
+import { VALIDATION_FAILED } from '@/lib/zod-utils';
+import scanImagesBatchTool from '@/tools/scan-images-batch/tool';
+import cleanupWorkspaceTool from '@/tools/cleanup-workspace/tool';
 import type { Server } from '@modelcontextprotocol/sdk/server/index.js';
 
 describe('Tool Orchestrator', () => {
@@ -99,6 +108,102 @@ describe('Tool Orchestrator', () => {
     });
   });
 
+  describe('Argument Validation', () => {
+    let scanBatchHandler: jest.Mock;
+    let cleanupHandler: jest.Mock;
+    let validating: ToolOrchestrator;
+
+    beforeEach(() => {
+      scanBatchHandler = jest.fn().mockResolvedValue(Success({ passed: true }));
+      cleanupHandler = jest.fn().mockResolvedValue(Success({ removed: true }));
+      validating = createOrchestrator({
+        registry: new Map<string, Tool>([
+          ['scan-images-batch', { ...scanImagesBatchTool, handler: scanBatchHandler } as any],
+          ['cleanup-workspace', { ...cleanupWorkspaceTool, handler: cleanupHandler } as any],
+        ]),
+        server: mockServer,
+      });
+    });
+
+    it('should list every problem before the tool runs', async () => {
+      const result = await validating.execute({
+        toolName: 'scan-images-batch',
+        params: { severity: 'extreme', concurrency: '4' },
+      });
+
+      expect(result.ok).toBe(false);
+      if (result.ok) return;
+      expect(result.error).toBe(
+        "Validation failed: images: required; severity: expected one of 'LOW', 'MEDIUM', " +
+          "'HIGH', 'CRITICAL', 'low', 'medium', 'high', 'critical', received 'extreme'; " +
+          'concurrency: expected number, received string',
+      );
+      expect(result.guidance).toMatchObject({
+        message: 'Invalid arguments for scan-images-batch',
+        hint: '3 argument problems found before the tool ran',
+        retryable: false,
+        details: {
+          code: VALIDATION_FAILED,
+          tool: 'scan-images-batch',
+          issues: [
+            { path: 'images', message: 'required' },
+            { path: 'severity', message: expect.stringContaining("received 'extreme'") },
+            { path: 'concurrency', message: 'expected number, received string' },
+          ],
+        },
+      });
+      expect(scanBatchHandler).not.toHaveBeenCalled();
+    });
+
+    it('should report nested paths and pattern constraints', async () => {
+      const batch = await validating.execute({
+        toolName: 'scan-images-batch',
+        params: { images: ['api:1.0', 42], ignoreUnfixed: 'yes' },
+      });
+      const cleanup = await validating.execute({
+        toolName: 'cleanup-workspace',
+        params: { workflowId: '../other-session', dryRun: 1 },
+      });
+
+      expect(!batch.ok && batch.guidance?.details?.issues).toEqual([
+        { path: 'images.1', message: 'expected string, received number' },
+        { path: 'ignoreUnfixed', message: 'expected boolean, received string' },
+      ]);
+      expect(!cleanup.ok && cleanup.guidance?.details).toEqual({
+        code: VALIDATION_FAILED,
+        tool: 'cleanup-workspace',
+        issues: [
+          { path: 'workflowId', message: 'does not match the expected format' },
+          { path: 'dryRun', message: 'expected boolean, received number' },
+        ],
+      });
+      expect(scanBatchHandler).not.toHaveBeenCalled();
+      expect(cleanupHandler).not.toHaveBeenCalled();
+    });
+
+    it('should report arguments that are not an object', async () => {
+      const result = await validating.execute({ toolName: 'cleanup-workspace', params: 'all' });
+
+      expect(!result.ok && result.guidance?.details?.issues).toEqual([
+        { path: '(root)', message: 'expected object, received string' },
+      ]);
+      expect(cleanupHandler).not.toHaveBeenCalled();
+    });
+
+    it('should run the tool when the arguments are valid', async () => {
+      const result = await validating.execute({
+        toolName: 'scan-images-batch',
+        params: { images: ['api:1.0'], severity: 'critical' },
+      });
+
+      expect(result.ok).toBe(true);
+      expect(scanBatchHandler).toHaveBeenCalledWith(
+        { images: ['api:1.0'], severity: 'critical' },
+        expect.anything(),
+      );
+    });
+  });
+
   describe('Policy Application', () => {
     it('should apply blocking policies', async () => {
       // Create orchestrator with policy
@@ -115,4 +220,843 @@ describe('Tool Orchestrator', () => {
       expect(orchestratorWithPolicy).toBeDefined();
     });
   });
-});
\ No newline at end of file
+
+  describe('Metrics', () => {
+    it('should record duration and outcome for every execution', async () => {
+      const metrics = createToolMetrics();
+      const failingTool: Tool = {
+        name: 'failing-tool',
+        description: 'Always fails',
+        schema: z.object({}),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler: jest.fn().mockResolvedValue(Failure('boom')),
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+      mockTools.set('failing-tool', failingTool);
+
+      const instrumented = createOrchestrator({
+        registry: mockTools,
+        config: { chainHintsMode: 'disabled', metrics },
+      });
+
+      await instrumented.execute({ toolName: 'tool-a', params: { input: 'x' } });
+      await instrumented.execute({ toolName: 'tool-a', params: { input: 'y' } });
+      await instrumented.execute({ toolName: 'failing-tool', params: {} });
+
+      const samples = metrics.snapshot();
+      const success = samples.find((s) => s.tool === 'tool-a' && s.status === 'success');
+      const failure = samples.find((s) => s.tool === 'failing-tool' && s.status === 'failure');
+
+      expect(success?.duration.count).toBe(2);
+      expect(failure?.duration.count).toBe(1);
+      expect(metrics.render()).toContain('tool="failing-tool",status="failure"');
+    });
+  });
+
+  describe('Concurrency Limit', () => {
+    it('should never run more tools at once than the configured limit', async () => {
+      const limit = 2;
+      let running = 0;
+      let maxObserved = 0;
+
+      const slowTool: Tool = {
+        name: 'slow-tool',
+        description: 'Slow test tool',
+        schema: z.object({}),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler: jest.fn(async () => {
+          running++;
+          maxObserved = Math.max(maxObserved, running);
+          await new Promise((resolve) => setTimeout(resolve, 10));
+          running--;
+          return Success({ done: true });
+        }),
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+
+      const limited = createOrchestrator({
+        registry: new Map([['slow-tool', slowTool]]),
+        config: { chainHintsMode: 'disabled', maxConcurrentToolExecutions: limit },
+      });
+
+      const pending = Array.from({ length: 6 }, () =>
+        limited.execute({ toolName: 'slow-tool', params: {} }),
+      );
+      const results = await Promise.all(pending);
+
+      expect(results.every((r) => r.ok)).toBe(true);
+      expect(maxObserved).toBeLessThanOrEqual(limit);
+      expect(maxObserved).toBe(limit);
+      expect(limited.getQueueDepth()).toBe(0);
+    });
+
+    it('should fail queued executions whose signal is aborted', async () => {
+      let releaseFirst: () => void = () => {};
+      const blockingTool: Tool = {
+        name: 'blocking-tool',
+        description: 'Blocks until released',
+        schema: z.object({}),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler: jest.fn(
+          () =>
+            new Promise((resolve) => {
+              releaseFirst = () => resolve(Success({ done: true }));
+            }),
+        ),
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+
+      const limited = createOrchestrator({
+        registry: new Map([['blocking-tool', blockingTool]]),
+        config: { chainHintsMode: 'disabled', maxConcurrentToolExecutions: 1 },
+      });
+
+      const waitFor = async (condition: () => boolean): Promise<void> => {
+        while (!condition()) {
+          await new Promise((resolve) => setTimeout(resolve, 1));
+        }
+      };
+
+      const first = limited.execute({ toolName: 'blocking-tool', params: {} });
+      // Let the first execution acquire its slot before queuing the second
+      await waitFor(() => (blockingTool.handler as jest.Mock).mock.calls.length === 1);
+
+      const controller = new AbortController();
+      const second = limited.execute({
+        toolName: 'blocking-tool',
+        params: {},
+        metadata: { signal: controller.signal },
+      });
+      await waitFor(() => limited.getQueueDepth() === 1);
+      expect(limited.getQueueDepth()).toBe(1);
+
+      controller.abort();
+      const secondResult = await second;
+      expect(secondResult.ok).toBe(false);
+      expect(limited.getQueueDepth()).toBe(0);
+
+      releaseFirst();
+      const firstResult = await first;
+      expect(firstResult.ok).toBe(true);
+      expect(blockingTool.handler).toHaveBeenCalledTimes(1);
+    });
+
+    it('should reject executions with QUEUE_FULL once the queue is at capacity', async () => {
+      const releases: Array<() => void> = [];
+      const blockingTool: Tool = {
+        name: 'blocking-tool',
+        description: 'Blocks until released',
+        schema: z.object({}),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler: jest.fn(
+          () => new Promise((resolve) => releases.push(() => resolve(Success({ done: true })))),
+        ),
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+
+      const bounded = createOrchestrator({
+        registry: new Map([['blocking-tool', blockingTool]]),
+        config: {
+          chainHintsMode: 'disabled',
+          maxConcurrentToolExecutions: 1,
+          maxQueuedToolExecutions: 2,
+        },
+      });
+
+      const run = () => bounded.execute({ toolName: 'blocking-tool', params: {} });
+      const running = run();
+      await new Promise((resolve) => setTimeout(resolve, 5));
+      const queued = [run(), run()];
+      await new Promise((resolve) => setTimeout(resolve, 5));
+      expect(bounded.getQueueDepth()).toBe(2);
+
+      const rejected = await run();
+      expect(rejected.ok).toBe(false);
+      if (!rejected.ok) {
+        expect(rejected.guidance?.details?.code).toBe(QUEUE_FULL);
+        expect(rejected.guidance?.retryable).toBe(true);
+        expect(rejected.guidance?.resolution).toContain('MAX_QUEUED_TOOL_EXECUTIONS');
+      }
+
+      // Queued executions drain normally once slots free up
+      for (let released = 0; released < 3; ) {
+        const release = releases.shift();
+        if (release) {
+          release();
+          released++;
+        }
+        await new Promise((resolve) => setTimeout(resolve, 1));
+      }
+      const results = await Promise.all([running, ...queued]);
+      expect(results.every((result) => result.ok)).toBe(true);
+      expect(blockingTool.handler).toHaveBeenCalledTimes(3);
+    });
+  });
+
+  describe('Retry', () => {
+    // Tool that returns the given failures first, then succeeds
+    const flakyTool = (failures: unknown[]): Tool => {
+      const handler = jest.fn().mockResolvedValue(Success({ pushed: true }));
+      for (const failure of failures) handler.mockResolvedValueOnce(failure);
+      return {
+        name: 'flaky-tool',
+        description: 'Fails before succeeding',
+        schema: z.object({}),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler,
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+    };
+
+    it('should retry a tool that fails with a transient error until it succeeds', async () => {
+      const metrics = createToolMetrics();
+      const tool = flakyTool([
+        Failure('connect ECONNRESET', {
+          message: 'Network error',
+          details: { code: 'ECONNRESET' },
+        }),
+        Failure('registry returned 503 Service Unavailable'),
+      ]);
+      const retrying = createOrchestrator({
+        registry: new Map([['flaky-tool', tool]]),
+        config: { chainHintsMode: 'disabled', metrics },
+      });
+
+      const result = await retrying.execute({
+        toolName: 'flaky-tool',
+        params: {},
+        retry: { maxAttempts: 3, baseDelayMs: 1 },
+      });
+
+      expect(result).toEqual(Success({ pushed: true }));
+      expect(tool.handler).toHaveBeenCalledTimes(3);
+      // One execution is recorded, not one per attempt
+      const samples = metrics.snapshot();
+      expect(samples).toHaveLength(1);
+      expect(samples[0]?.status).toBe('success');
+    });
+
+    it('should not retry a non-retryable failure', async () => {
+      const tool = flakyTool([Failure('Dockerfile not found')]);
+      const retrying = createOrchestrator({
+        registry: new Map([['flaky-tool', tool]]),
+        config: { chainHintsMode: 'disabled' },
+      });
+
+      const result = await retrying.execute({
+        toolName: 'flaky-tool',
+        params: {},
+        retry: { maxAttempts: 3, baseDelayMs: 1 },
+      });
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) expect(result.error).toBe('Dockerfile not found');
+      expect(tool.handler).toHaveBeenCalledTimes(1);
+    });
+
+    it('should retry thrown transient errors', async () => {
+      const tool = flakyTool([]);
+      (tool.handler as jest.Mock).mockRejectedValueOnce(new Error('socket hang up'));
+      const retrying = createOrchestrator({
+        registry: new Map([['flaky-tool', tool]]),
+        config: { chainHintsMode: 'disabled' },
+      });
+
+      const result = await retrying.execute({
+        toolName: 'flaky-tool',
+        params: {},
+        retry: { baseDelayMs: 1 },
+      });
+
+      expect(result.ok).toBe(true);
+      expect(tool.handler).toHaveBeenCalledTimes(2);
+    });
+
+    it('should not retry failures flagged as not retryable', async () => {
+      const tool = flakyTool([
+        Failure('registry returned 503', { message: 'Registry error', retryable: false }),
+      ]);
+      const retrying = createOrchestrator({
+        registry: new Map([['flaky-tool', tool]]),
+        config: { chainHintsMode: 'disabled' },
+      });
+
+      const result = await retrying.execute({
+        toolName: 'flaky-tool',
+        params: {},
+        retry: { baseDelayMs: 1 },
+      });
+
+      expect(result.ok).toBe(false);
+      expect(tool.handler).toHaveBeenCalledTimes(1);
+    });
+
+    it('should run once without a retry policy', async () => {
+      const tool = flakyTool([Failure('connect ECONNRESET')]);
+      const once = createOrchestrator({
+        registry: new Map([['flaky-tool', tool]]),
+        config: { chainHintsMode: 'disabled' },
+      });
+
+      const result = await once.execute({ toolName: 'flaky-tool', params: {} });
+
+      expect(result.ok).toBe(false);
+      expect(tool.handler).toHaveBeenCalledTimes(1);
+    });
+  });
+
+  describe('Circuit Breaker', () => {
+    it('should reject a failing tool until a probe after the cool-down succeeds', async () => {
+      let time = 0;
+      const circuitBreaker = createCircuitBreaker({
+        failureThreshold: 2,
+        cooldownMs: 1000,
+        now: () => time,
+      });
+      const handler = jest
+        .fn()
+        .mockResolvedValueOnce(Failure('Cannot connect to the Docker daemon'))
+        .mockResolvedValueOnce(Failure('Cannot connect to the Docker daemon'))
+        .mockResolvedValue(Success({ imageId: 'sha256:abc' }));
+      const buildTool: Tool = {
+        name: 'build-image',
+        description: 'Builds an image',
+        schema: z.object({}),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler,
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+      const guarded = createOrchestrator({
+        registry: new Map([['build-image', buildTool]]),
+        config: { chainHintsMode: 'disabled', circuitBreaker },
+      });
+      const build = () => guarded.execute({ toolName: 'build-image', params: {} });
+
+      await build();
+      await build();
+      const rejected = await build();
+
+      expect(rejected.ok).toBe(false);
+      if (!rejected.ok) expect(rejected.guidance?.details?.code).toBe(CIRCUIT_OPEN);
+      expect(handler).toHaveBeenCalledTimes(2);
+
+      time += 1000;
+      const probe = await build();
+
+      expect(probe.ok).toBe(true);
+      expect(handler).toHaveBeenCalledTimes(3);
+      expect(circuitBreaker.state('build-image')).toBe('closed');
+    });
+
+    it('should not count validation errors as failures', async () => {
+      const circuitBreaker = createCircuitBreaker({ failureThreshold: 1 });
+      const guarded = createOrchestrator({
+        registry: mockTools,
+        config: { chainHintsMode: 'disabled', circuitBreaker },
+      });
+
+      await guarded.execute({ toolName: 'tool-b', params: { value: 'not-a-number' } });
+
+      expect(circuitBreaker.state('tool-b')).toBe('closed');
+    });
+
+    const breakerTool = (name: string, handler: jest.Mock): Tool =>
+      ({
+        name,
+        description: `Tool ${name}`,
+        schema: z.object({}),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler,
+        metadata: { knowledgeEnhanced: false },
+      }) as any;
+
+    it('should not count cancelled runs as failures', async () => {
+      const circuitBreaker = createCircuitBreaker({ failureThreshold: 1 });
+      const metrics = createToolMetrics();
+      const controller = new AbortController();
+      const handler = jest
+        .fn()
+        // The client cancels mid-run and the tool fails as a result
+        .mockImplementationOnce(async () => {
+          controller.abort();
+          return Failure('Build aborted');
+        })
+        // The tool notices a cancellation itself and returns what it finished
+        .mockResolvedValueOnce(Cancelled({ completed: ['pull'] }));
+      const guarded = createOrchestrator({
+        registry: new Map([['build-image', breakerTool('build-image', handler)]]),
+        config: { chainHintsMode: 'disabled', circuitBreaker, metrics },
+      });
+
+      await guarded.execute({
+        toolName: 'build-image',
+        params: {},
+        metadata: { signal: controller.signal },
+      });
+      await guarded.execute({ toolName: 'build-image', params: {} });
+
+      expect(circuitBreaker.state('build-image')).toBe('closed');
+      expect(metrics.snapshot().map((s) => [s.status, s.duration.count])).toEqual([
+        ['cancelled', 2],
+      ]);
+    });
+
+    it('should not trip the breaker for batch siblings aborted by stopOnFailure', async () => {
+      const circuitBreaker = createCircuitBreaker({ failureThreshold: 1 });
+      const sibling = jest.fn(
+        (_params: unknown, context: any) =>
+          new Promise((resolve) =>
+            context.signal.addEventListener('abort', () => resolve(Failure('Scan aborted'))),
+          ),
+      );
+      const guarded = createOrchestrator({
+        registry: new Map([
+          ['broken-tool', breakerTool('broken-tool', jest.fn().mockResolvedValue(Failure('boom')))],
+          ['scan-image', breakerTool('scan-image', sibling)],
+        ]),
+        config: { chainHintsMode: 'disabled', circuitBreaker },
+      });
+
+      const results = await guarded.executeParallel(
+        [
+          { toolName: 'scan-image', params: {} },
+          { toolName: 'broken-tool', params: {} },
+        ],
+        { stopOnFailure: true },
+      );
+
+      expect(results[0]).toMatchObject({ ok: false, error: 'Scan aborted' });
+      expect(circuitBreaker.state('broken-tool')).toBe('open');
+      expect(circuitBreaker.state('scan-image')).toBe('closed');
+    });
+  });
+
+  describe('Parallel Execution', () => {
+    // Tool that resolves after `ms`, fails for `fail: true`, and honors cancellation
+    const createSlowTool = () => {
+      let running = 0;
+      let maxRunning = 0;
+      const handler = jest.fn(
+        ({ id, ms, fail }: { id: string; ms: number; fail?: boolean }, context: any) =>
+          new Promise((resolve) => {
+            running++;
+            maxRunning = Math.max(maxRunning, running);
+            const finish = (result: unknown): void => {
+              running--;
+              resolve(result);
+            };
+            const timer = setTimeout(
+              () => finish(fail ? Failure(`${id} failed`) : Success({ id })),
+              ms,
+            );
+            context.signal?.addEventListener('abort', () => {
+              clearTimeout(timer);
+              finish(Failure(`${id} aborted`));
+            });
+          }),
+      );
+      const tool: Tool = {
+        name: 'slow-tool',
+        description: 'Resolves after a delay',
+        schema: z.object({ id: z.string(), ms: z.number(), fail: z.boolean().optional() }),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler,
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+      return { tool, handler, maxRunning: () => maxRunning };
+    };
+
+    const call = (id: string, ms: number, fail = false) => ({
+      toolName: 'slow-tool',
+      params: { id, ms, fail },
+    });
+
+    it('should return every result in input order, including failures', async () => {
+      const { tool } = createSlowTool();
+      const parallel = createOrchestrator({
+        registry: new Map([['slow-tool', tool]]),
+        config: { chainHintsMode: 'disabled' },
+      });
+
+      const results = await parallel.executeParallel([
+        call('a', 30),
+        call('b', 5, true),
+        call('c', 10),
+        { toolName: 'missing-tool', params: {} },
+      ]);
+
+      expect(results).toHaveLength(4);
+      expect(results[0]).toEqual(Success({ id: 'a' }));
+      expect(results[1]).toMatchObject({ ok: false, error: 'b failed' });
+      expect(results[2]).toEqual(Success({ id: 'c' }));
+      expect(results[3]).toMatchObject({ ok: false });
+    });
+
+    it('should run at most maxConcurrency calls at once', async () => {
+      const { tool, maxRunning } = createSlowTool();
+      const parallel = createOrchestrator({
+        registry: new Map([['slow-tool', tool]]),
+        config: { chainHintsMode: 'disabled' },
+      });
+
+      const results = await parallel.executeParallel(
+        ['a', 'b', 'c', 'd', 'e'].map((id) => call(id, 5)),
+        { maxConcurrency: 2 },
+      );
+
+      expect(results.every((result) => result.ok)).toBe(true);
+      expect(maxRunning()).toBe(2);
+    });
+
+    it('should cancel running calls and skip unstarted ones when the signal aborts', async () => {
+      const { tool, handler } = createSlowTool();
+      const parallel = createOrchestrator({
+        registry: new Map([['slow-tool', tool]]),
+        config: { chainHintsMode: 'disabled' },
+      });
+      const controller = new AbortController();
+      setTimeout(() => controller.abort(), 20);
+
+      const results = await parallel.executeParallel(
+        [call('fast', 5), call('slow', 10_000), call('next', 1000), call('later', 5)],
+        { maxConcurrency: 2, signal: controller.signal },
+      );
+
+      expect(results[0]).toEqual(Success({ id: 'fast' }));
+      expect(results[1]).toMatchObject({ ok: false, error: 'slow aborted' });
+      expect(results[2]).toMatchObject({ ok: false, error: 'next aborted' });
+      expect(results[3]).toMatchObject({ ok: false, error: 'Cancelled before slow-tool started' });
+      expect(handler).toHaveBeenCalledTimes(3);
+    });
+
+    it('should stop the batch at the first failure when stopOnFailure is set', async () => {
+      const { tool, handler } = createSlowTool();
+      const parallel = createOrchestrator({
+        registry: new Map([['slow-tool', tool]]),
+        config: { chainHintsMode: 'disabled' },
+      });
+
+      const results = await parallel.executeParallel(
+        [call('done', 2), call('broken', 10, true), call('slow', 10_000), call('later', 5)],
+        { maxConcurrency: 2, stopOnFailure: true },
+      );
+
+      expect(results[0]).toEqual(Success({ id: 'done' }));
+      expect(results[1]).toMatchObject({ ok: false, error: 'broken failed' });
+      expect(results[2]).toMatchObject({ ok: false, error: 'slow aborted' });
+      expect(results[3]).toMatchObject({
+        ok: false,
+        error: 'Cancelled before slow-tool started',
+        guidance: { details: { failedIndex: 1 } },
+      });
+      expect(handler).toHaveBeenCalledTimes(3);
+      expect(handler).not.toHaveBeenCalledWith(
+        expect.objectContaining({ id: 'later' }),
+        expect.anything(),
+      );
+    });
+  });
+
+  describe('Result Cache', () => {
+    const createCachedOrchestrator = () => {
+      let time = 0;
+      let runs = 0;
+      const metrics = createToolMetrics();
+      const analyzeTool: Tool = {
+        name: 'analyze-tool',
+        description: 'Idempotent analysis',
+        schema: z.object({ path: z.string() }),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler: jest.fn(async ({ path }: { path: string }) => Success({ path, run: ++runs })),
+        metadata: { knowledgeEnhanced: false, cacheTtlMs: 1000 },
+      } as any;
+      const deployTool: Tool = {
+        name: 'deploy-tool',
+        description: 'Changes cluster state',
+        schema: z.object({ path: z.string() }),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler: jest.fn(async () => Success({ deployed: true })),
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+      const cached = createOrchestrator({
+        registry: new Map([
+          ['analyze-tool', analyzeTool],
+          ['deploy-tool', deployTool],
+        ]),
+        config: {
+          chainHintsMode: 'disabled',
+          metrics,
+          resultCache: createResultCache({ now: () => time }),
+        },
+      });
+      return {
+        cached,
+        metrics,
+        analyzeTool,
+        deployTool,
+        advance: (ms: number) => (time += ms),
+      };
+    };
+
+    it('should return the cached result for identical arguments', async () => {
+      const { cached, metrics, analyzeTool } = createCachedOrchestrator();
+
+      const first = await cached.execute({ toolName: 'analyze-tool', params: { path: '/repo' } });
+      const second = await cached.execute({ toolName: 'analyze-tool', params: { path: '/repo' } });
+
+      expect(first).toEqual(Success({ path: '/repo', run: 1 }));
+      expect(second).toEqual(Success({ path: '/repo', run: 1 }));
+      expect(analyzeTool.handler).toHaveBeenCalledTimes(1);
+      const hits = metrics.snapshot().find((s) => s.status === 'cache_hit');
+      expect(hits).toMatchObject({ tool: 'analyze-tool', duration: { count: 1 } });
+    });
+
+    it('should re-execute when the arguments change', async () => {
+      const { cached, analyzeTool } = createCachedOrchestrator();
+
+      await cached.execute({ toolName: 'analyze-tool', params: { path: '/repo' } });
+      const other = await cached.execute({ toolName: 'analyze-tool', params: { path: '/other' } });
+
+      expect(other).toEqual(Success({ path: '/other', run: 2 }));
+      expect(analyzeTool.handler).toHaveBeenCalledTimes(2);
+    });
+
+    it('should re-execute once the TTL expires', async () => {
+      const { cached, analyzeTool, advance } = createCachedOrchestrator();
+
+      await cached.execute({ toolName: 'analyze-tool', params: { path: '/repo' } });
+      advance(1000);
+      const expired = await cached.execute({ toolName: 'analyze-tool', params: { path: '/repo' } });
+
+      expect(expired).toEqual(Success({ path: '/repo', run: 2 }));
+      expect(analyzeTool.handler).toHaveBeenCalledTimes(2);
+    });
+
+    it('should never cache tools without a cache TTL', async () => {
+      const { cached, deployTool } = createCachedOrchestrator();
+
+      await cached.execute({ toolName: 'deploy-tool', params: { path: '/repo' } });
+      await cached.execute({ toolName: 'deploy-tool', params: { path: '/repo' } });
+
+      expect(deployTool.handler).toHaveBeenCalledTimes(2);
+    });
+
+    it('should re-execute when the fingerprint of what the tool read changes', async () => {
+      const { cached, analyzeTool } = createCachedOrchestrator();
+      let fingerprint: string | undefined = 'mtime-1';
+      analyzeTool.cacheFingerprint = jest.fn(async () => fingerprint);
+      const run = () => cached.execute({ toolName: 'analyze-tool', params: { path: '/repo' } });
+
+      await run();
+      const unchanged = await run();
+      fingerprint = 'mtime-2';
+      const changed = await run();
+      fingerprint = undefined;
+      const unreadable = await run();
+
+      expect(unchanged).toEqual(Success({ path: '/repo', run: 1 }));
+      expect(changed).toEqual(Success({ path: '/repo', run: 2 }));
+      expect(unreadable).toEqual(Success({ path: '/repo', run: 3 }));
+      expect(analyzeTool.cacheFingerprint).toHaveBeenCalledWith({ path: '/repo' });
+    });
+  });
+
+  describe('Audit Log', () => {
+    it('should record one audit entry per execution without raw arguments', async () => {
+      const written: AuditRecord[] = [];
+      const audit = createAuditLog({
+        write: async (records) => {
+          written.push(...records);
+        },
+      });
+      const failingTool: Tool = {
+        name: 'failing-tool',
+        description: 'Always fails',
+        schema: z.object({ token: z.string() }),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler: jest.fn().mockResolvedValue(Failure('boom')),
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+      mockTools.set('failing-tool', failingTool);
+      const audited = createOrchestrator({
+        registry: mockTools,
+        config: { chainHintsMode: 'disabled', audit },
+      });
+
+      await audited.execute({
+        toolName: 'tool-a',
+        params: { input: 'x' },
+        metadata: { loggerContext: { sessionId: 'session-1', requestId: 'req-1' } },
+      });
+      await audited.execute({ toolName: 'failing-tool', params: { token: 'secret-value' } });
+      await audit.flush();
+
+      expect(written).toHaveLength(2);
+      expect(written[0]).toEqual({
+        timestamp: expect.stringMatching(/^\d{4}-\d{2}-\d{2}T/),
+        tool: 'tool-a',
+        sessionId: 'session-1',
+        requestId: 'req-1',
+        argsDigest: digestArgs({ input: 'x' }),
+        durationMs: expect.any(Number),
+        outcome: 'success',
+      });
+      expect(written[1]).toMatchObject({ tool: 'failing-tool', outcome: 'failure' });
+      expect(written[1]).not.toHaveProperty('sessionId');
+      expect(JSON.stringify(written)).not.toContain('secret-value');
+    });
+  });
+
+  describe('Workflow State', () => {
+    const stageTool = (name: string, handler: jest.Mock): Tool =>
+      ({
+        name,
+        description: `Stage ${name}`,
+        schema: z.object({ path: z.string() }),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler,
+        metadata: { knowledgeEnhanced: false },
+      }) as any;
+
+    it('should save pipeline stages per session and pass the store to tools', async () => {
+      const store = createMemoryWorkflowStateStore();
+      const buildHandler = jest.fn().mockResolvedValue(Success({ imageId: 'sha256:abc' }));
+      const analyzeHandler = jest.fn().mockResolvedValue(Success({ language: 'go' }));
+      mockTools.set('analyze-repo', stageTool('analyze-repo', analyzeHandler));
+      mockTools.set('build-image', stageTool('build-image', buildHandler));
+      const recording = createOrchestrator({
+        registry: mockTools,
+        config: { chainHintsMode: 'disabled', workflowState: store },
+      });
+      const metadata = { loggerContext: { sessionId: 'session-1' } };
+
+      await recording.execute({ toolName: 'analyze-repo', params: { path: '/repo' }, metadata });
+      await recording.execute({ toolName: 'build-image', params: { path: '/repo' }, metadata });
+      await recording.execute({ toolName: 'tool-a', params: { input: 'x' }, metadata });
+
+      const state = await store.load('session-1');
+      expect(Object.keys(state?.stages ?? {})).toEqual(['analyze-repo', 'build-image']);
+      expect(state?.stages['build-image']).toMatchObject({
+        argsDigest: digestArgs({ path: '/repo' }),
+        output: { imageId: 'sha256:abc' },
+      });
+      expect(buildHandler.mock.calls[0]?.[1]).toMatchObject({
+        workflow: { store, id: 'session-1' },
+      });
+    });
+
+    it('should not record failed stages and drop later stages when one runs again', async () => {
+      const store = createMemoryWorkflowStateStore();
+      const succeed = jest.fn().mockResolvedValue(Success({}));
+      const fail = jest.fn().mockResolvedValue(Failure('boom'));
+      mockTools.set('analyze-repo', stageTool('analyze-repo', succeed));
+      mockTools.set('build-image', stageTool('build-image', succeed));
+      mockTools.set('scan-image', stageTool('scan-image', fail));
+      const recording = createOrchestrator({
+        registry: mockTools,
+        config: { chainHintsMode: 'disabled', workflowState: store },
+      });
+
+      await recording.execute({ toolName: 'analyze-repo', params: { path: '/repo' } });
+      await recording.execute({ toolName: 'build-image', params: { path: '/repo' } });
+      await recording.execute({ toolName: 'scan-image', params: { path: '/repo' } });
+      expect(Object.keys((await store.load('default'))?.stages ?? {})).toEqual([
+        'analyze-repo',
+        'build-image',
+      ]);
+
+      await recording.execute({ toolName: 'analyze-repo', params: { path: '/other' } });
+      expect(Object.keys((await store.load('default'))?.stages ?? {})).toEqual(['analyze-repo']);
+    });
+
+    it('should record a stage served from the result cache', async () => {
+      const store = createMemoryWorkflowStateStore();
+      const analyzeHandler = jest.fn().mockResolvedValue(Success({ language: 'go' }));
+      mockTools.set('analyze-repo', {
+        ...stageTool('analyze-repo', analyzeHandler),
+        metadata: { knowledgeEnhanced: false, cacheTtlMs: 60_000 },
+      } as Tool);
+      const recording = createOrchestrator({
+        registry: mockTools,
+        config: {
+          chainHintsMode: 'disabled',
+          workflowState: store,
+          resultCache: createResultCache(),
+        },
+      });
+
+      await recording.execute({
+        toolName: 'analyze-repo',
+        params: { path: '/repo' },
+        metadata: { loggerContext: { sessionId: 'session-1' } },
+      });
+      await recording.execute({
+        toolName: 'analyze-repo',
+        params: { path: '/repo' },
+        metadata: { loggerContext: { sessionId: 'session-2' } },
+      });
+
+      expect(analyzeHandler).toHaveBeenCalledTimes(1);
+      expect((await store.load('session-2'))?.stages['analyze-repo']).toMatchObject({
+        argsDigest: digestArgs({ path: '/repo' }),
+        output: { language: 'go' },
+      });
+    });
+  });
+
+  describe('Cancellation', () => {
+    it('should return the steps a cancelled tool completed', async () => {
+      const controller = new AbortController();
+      const steppingTool: Tool = {
+        name: 'stepping-tool',
+        description: 'Runs steps until cancelled',
+        schema: z.object({ steps: z.number() }),
+        inputSchema: {},
+        parse: jest.fn((args: any) => args),
+        handler: jest.fn(async ({ steps }: { steps: number }, context: any) => {
+          const completed: string[] = [];
+          for (let step = 1; step <= steps; step++) {
+            if (context.signal?.aborted) return Cancelled({ completed });
+            completed.push(`step-${step}`);
+            // Cancel deterministically once the second step is done
+            if (step === 2) controller.abort();
+          }
+          return Success({ completed });
+        }),
+        metadata: { knowledgeEnhanced: false },
+      } as any;
+      mockTools.set('stepping-tool', steppingTool);
+
+      const result = await orchestrator.execute({
+        toolName: 'stepping-tool',
+        params: { steps: 5 },
+        metadata: { signal: controller.signal },
+        retry: { maxAttempts: 3, baseDelayMs: 1 },
+      });
+
+      expect(result.ok).toBe(false);
+      expect(getPartialResult(result)).toEqual({ completed: ['step-1', 'step-2'] });
+      // A cancellation is never retried
+      expect(steppingTool.handler).toHaveBeenCalledTimes(1);
+    });
+
+    it('should not report a partial result for ordinary failures', () => {
+      const failure = Failure('boom', { message: 'boom', details: { code: 'ECONNRESET' } });
+
+      expect(getPartialResult(failure)).toBeUndefined();
+      expect(getPartialResult(Success({ completed: [] }))).toBeUndefined();
+    });
+  });
+});
@@ -0,0 +1,436 @@
+/**
+ * Workflow Tests
+ * Tests DAG validation and parallel execution of workflow steps
+ */
+
+import { describe, it, expect, jest, beforeEach, afterEach } from '@jest/globals';
+import { promises as fs } from 'node:fs';
+import * as os from 'node:os';
+import * as path from 'node:path';
+import { createWorkflow, runWorkflow, toolStep, type WorkflowStep } from '@/app/workflow';
+import { createFileWorkflowStateStore } from '@/lib/workflow-state';
+import { Success, Failure, type Result } from '@/types';
+
+const delay = (ms: number): Promise<void> => new Promise((resolve) => setTimeout(resolve, ms));
+
+/**
+ * Tracks how many steps run at the same time
+ */
+function createTracker() {
+  let running = 0;
+  let maxRunning = 0;
+  const order: string[] = [];
+
+  const step = (
+    id: string,
+    dependsOn: string[],
+    run: (dependencies: Record<string, unknown>) => Result<unknown>,
+    ms = 20,
+  ): WorkflowStep<number> => ({
+    id,
+    dependsOn,
+    run: async ({ dependencies }) => {
+      running++;
+      maxRunning = Math.max(maxRunning, running);
+      order.push(id);
+      await delay(ms);
+      running--;
+      return run(dependencies);
+    },
+  });
+
+  return { step, order, maxRunning: () => maxRunning };
+}
+
+describe('Workflow', () => {
+  describe('createWorkflow', () => {
+    it('should order steps after their dependencies', () => {
+      const noop = async () => Success(undefined);
+      const workflow = createWorkflow([
+        { id: 'deploy', dependsOn: ['build'], run: noop },
+        { id: 'build', dependsOn: ['analyze'], run: noop },
+        { id: 'analyze', run: noop },
+      ]);
+
+      expect(workflow.ok).toBe(true);
+      if (workflow.ok) {
+        expect(workflow.value.order).toEqual(['analyze', 'build', 'deploy']);
+      }
+    });
+
+    it('should reject dependency cycles', () => {
+      const noop = async () => Success(undefined);
+      const workflow = createWorkflow([
+        { id: 'a', dependsOn: ['c'], run: noop },
+        { id: 'b', dependsOn: ['a'], run: noop },
+        { id: 'c', dependsOn: ['b'], run: noop },
+      ]);
+
+      expect(workflow.ok).toBe(false);
+      if (!workflow.ok) {
+        expect(workflow.error).toBe('Workflow has a dependency cycle: a -> c -> b -> a');
+        expect(workflow.guidance?.details).toEqual({ cycle: ['a', 'c', 'b', 'a'] });
+      }
+    });
+
+    it('should reject a step that depends on itself', () => {
+      const workflow = createWorkflow([
+        { id: 'a', dependsOn: ['a'], run: async () => Success(undefined) },
+      ]);
+
+      expect(workflow.ok).toBe(false);
+      if (!workflow.ok) {
+        expect(workflow.error).toContain('a -> a');
+      }
+    });
+
+    it('should reject unknown dependencies and duplicate ids', () => {
+      const noop = async () => Success(undefined);
+
+      const unknown = createWorkflow([{ id: 'a', dependsOn: ['missing'], run: noop }]);
+      expect(unknown.ok).toBe(false);
+      if (!unknown.ok) expect(unknown.error).toContain('unknown step(s): missing');
+
+      const duplicate = createWorkflow([
+        { id: 'a', run: noop },
+        { id: 'a', run: noop },
+      ]);
+      expect(duplicate.ok).toBe(false);
+      if (!duplicate.ok) expect(duplicate.error).toContain('Duplicate workflow step id: a');
+    });
+  });
+
+  describe('runWorkflow', () => {
+    it('should run the legs of a diamond in parallel and pass outputs along', async () => {
+      const tracker = createTracker();
+      const workflow = createWorkflow([
+        tracker.step('source', [], () => Success(10)),
+        tracker.step('double', ['source'], (deps) => Success((deps.source as number) * 2)),
+        tracker.step('square', ['source'], (deps) => Success((deps.source as number) ** 2)),
+        tracker.step('sum', ['double', 'square'], (deps) =>
+          Success({ received: deps, total: (deps.double as number) + (deps.square as number) }),
+        ),
+      ]);
+      expect(workflow.ok).toBe(true);
+      if (!workflow.ok) return;
+
+      const run = await runWorkflow(workflow.value, 0);
+
+      expect(run.succeeded).toBe(true);
+      expect(tracker.maxRunning()).toBe(2);
+      expect(tracker.order[0]).toBe('source');
+      expect(tracker.order[3]).toBe('sum');
+      expect(run.steps.sum?.value).toEqual({ received: { double: 20, square: 100 }, total: 120 });
+    });
+
+    it('should give every step the workflow input', async () => {
+      const seen: unknown[] = [];
+      const record = async ({ input }: { input: { repo: string } }) => {
+        seen.push(input);
+        return Success(undefined);
+      };
+      const workflow = createWorkflow<{ repo: string }>([
+        { id: 'a', run: record },
+        { id: 'b', dependsOn: ['a'], run: record },
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+
+      await runWorkflow(workflow.value, { repo: './app' });
+
+      expect(seen).toEqual([{ repo: './app' }, { repo: './app' }]);
+    });
+
+    it('should stop a failed branch and let unrelated branches finish', async () => {
+      const tracker = createTracker();
+      const workflow = createWorkflow([
+        tracker.step('analyze', [], () => Success('analysis')),
+        tracker.step('build', ['analyze'], () => Failure('Docker daemon unavailable')),
+        tracker.step('push', ['build'], () => Success('pushed')),
+        tracker.step('manifests', ['analyze'], () => Success('manifests'), 40),
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+
+      const run = await runWorkflow(workflow.value, 0);
+
+      expect(run.succeeded).toBe(false);
+      expect(run.steps.build).toMatchObject({
+        status: 'failed',
+        error: 'Docker daemon unavailable',
+      });
+      expect(run.steps.push).toEqual({ status: 'skipped', blockedBy: 'build' });
+      expect(run.steps.manifests).toMatchObject({ status: 'succeeded', value: 'manifests' });
+      expect(tracker.order).not.toContain('push');
+    });
+
+    it('should treat a throwing step as failed', async () => {
+      const workflow = createWorkflow([
+        {
+          id: 'explode',
+          run: async () => {
+            throw new Error('boom');
+          },
+        },
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+
+      const run = await runWorkflow(workflow.value, undefined);
+
+      expect(run.steps.explode).toMatchObject({ status: 'failed', error: 'boom' });
+    });
+
+    it('should respect maxConcurrency', async () => {
+      const tracker = createTracker();
+      const workflow = createWorkflow([
+        tracker.step('a', [], () => Success(1)),
+        tracker.step('b', [], () => Success(2)),
+        tracker.step('c', [], () => Success(3)),
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+
+      const run = await runWorkflow(workflow.value, 0, { maxConcurrency: 1 });
+
+      expect(run.succeeded).toBe(true);
+      expect(tracker.maxRunning()).toBe(1);
+    });
+
+    it('should skip steps that have not started once cancelled', async () => {
+      const controller = new AbortController();
+      const tracker = createTracker();
+      const workflow = createWorkflow([
+        tracker.step('first', [], () => {
+          controller.abort();
+          return Success(1);
+        }),
+        tracker.step('second', ['first'], () => Success(2)),
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+
+      const run = await runWorkflow(workflow.value, 0, { signal: controller.signal });
+
+      expect(run.steps.first?.status).toBe('succeeded');
+      expect(run.steps.second).toEqual({ status: 'skipped', error: 'Workflow cancelled' });
+    });
+  });
+
+  describe('conditional steps', () => {
+    /**
+     * analyze → generate → build, where generate is skipped when the analysis
+     * found a valid Dockerfile
+     */
+    function createPipeline(analysis: { dockerfile?: { path: string; valid: boolean } }) {
+      const tracker = createTracker();
+      const generate = tracker.step('generate', ['analyze'], () => Success('Dockerfile'), 1);
+      const workflow = createWorkflow([
+        tracker.step('analyze', [], () => Success(analysis), 1),
+        {
+          ...generate,
+          skipIf: ({ dependencies }) =>
+            (dependencies.analyze as typeof analysis).dockerfile?.valid === true,
+        },
+        tracker.step('build', ['generate'], (deps) => Success({ built: deps.generate }), 1),
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+      return { workflow: workflow.value, order: tracker.order };
+    }
+
+    it('should skip a step whose predicate matches and still run its dependents', async () => {
+      const pipeline = createPipeline({ dockerfile: { path: 'Dockerfile', valid: true } });
+
+      const run = await runWorkflow(pipeline.workflow, 0);
+
+      expect(run.succeeded).toBe(true);
+      expect(pipeline.order).toEqual(['analyze', 'build']);
+      expect(run.steps.generate).toEqual({ status: 'skipped', skippedByCondition: true });
+      expect(run.steps.build).toMatchObject({ status: 'succeeded', value: { built: undefined } });
+    });
+
+    it('should run the step when the predicate does not match', async () => {
+      const pipeline = createPipeline({ dockerfile: { path: 'Dockerfile', valid: false } });
+
+      const run = await runWorkflow(pipeline.workflow, 0);
+
+      expect(run.succeeded).toBe(true);
+      expect(pipeline.order).toEqual(['analyze', 'generate', 'build']);
+      expect(run.steps.generate).toMatchObject({ status: 'succeeded', value: 'Dockerfile' });
+      expect(run.steps.build?.value).toEqual({ built: 'Dockerfile' });
+    });
+
+    it('should fail the step when the predicate throws', async () => {
+      const workflow = createWorkflow([
+        {
+          id: 'generate',
+          skipIf: () => {
+            throw new Error('bad analysis');
+          },
+          run: async () => Success('Dockerfile'),
+        },
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+
+      const run = await runWorkflow(workflow.value, 0);
+
+      expect(run.succeeded).toBe(false);
+      expect(run.steps.generate).toEqual({
+        status: 'failed',
+        error: 'skipIf failed: bad analysis',
+      });
+    });
+  });
+
+  describe('resuming from saved state', () => {
+    let dir: string;
+
+    beforeEach(async () => {
+      dir = await fs.mkdtemp(path.join(os.tmpdir(), 'workflow-state-'));
+    });
+
+    afterEach(async () => {
+      await fs.rm(dir, { recursive: true, force: true });
+    });
+
+    /**
+     * analyze → generate → build → scan, where build fails until `buildFixed`
+     */
+    function createPipeline(options: { buildFixed: boolean; analysis?: string }) {
+      const tracker = createTracker();
+      const workflow = createWorkflow([
+        tracker.step('analyze', [], () => Success(options.analysis ?? 'go'), 1),
+        tracker.step('generate', ['analyze'], (deps) => Success(`FROM ${deps.analyze}`), 1),
+        tracker.step(
+          'build',
+          ['generate'],
+          () => (options.buildFixed ? Success('sha256:abc') : Failure('Docker daemon unavailable')),
+          1,
+        ),
+        tracker.step('scan', ['build'], (deps) => Success({ image: deps.build, critical: 0 }), 1),
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+      return { workflow: workflow.value, order: tracker.order };
+    }
+
+    it('should skip stages a run completed before a restart', async () => {
+      const input = { repositoryPath: '/repo' };
+      const first = createPipeline({ buildFixed: false });
+      const firstRun = await runWorkflow(first.workflow, input, {
+        state: { store: createFileWorkflowStateStore(dir), workflowId: 'wf-1' },
+      });
+      expect(firstRun.steps.build?.status).toBe('failed');
+      expect(first.order).toEqual(['analyze', 'generate', 'build']);
+
+      // A new store on the same directory stands in for a restarted server
+      const second = createPipeline({ buildFixed: true });
+      const secondRun = await runWorkflow(second.workflow, input, {
+        state: { store: createFileWorkflowStateStore(dir), workflowId: 'wf-1' },
+      });
+
+      expect(secondRun.succeeded).toBe(true);
+      expect(second.order).toEqual(['build', 'scan']);
+      expect(secondRun.steps.analyze).toEqual({ status: 'succeeded', value: 'go', resumed: true });
+      expect(secondRun.steps.generate).toMatchObject({ value: 'FROM go', resumed: true });
+      expect(secondRun.steps.build).not.toHaveProperty('resumed');
+      expect(secondRun.steps.scan?.value).toEqual({ image: 'sha256:abc', critical: 0 });
+
+      const saved = await createFileWorkflowStateStore(dir).load('wf-1');
+      expect(Object.keys(saved?.stages ?? {}).sort()).toEqual(
+        ['analyze', 'build', 'generate', 'scan'].sort(),
+      );
+    });
+
+    it('should run everything again when the workflow input changed', async () => {
+      const state = { store: createFileWorkflowStateStore(dir), workflowId: 'wf-1' };
+      const first = createPipeline({ buildFixed: true });
+      await runWorkflow(first.workflow, { repositoryPath: '/a' }, { state });
+
+      const rerun = createPipeline({ buildFixed: true });
+      const run = await runWorkflow(rerun.workflow, { repositoryPath: '/b' }, { state });
+
+      expect(run.succeeded).toBe(true);
+      expect(rerun.order).toEqual(['analyze', 'generate', 'build', 'scan']);
+    });
+
+    it('should rerun stages whose dependency outputs changed', async () => {
+      const store = createFileWorkflowStateStore(dir);
+      const input = { repositoryPath: '/repo' };
+      await runWorkflow(createPipeline({ buildFixed: true }).workflow, input, {
+        state: { store, workflowId: 'wf-1' },
+      });
+
+      // Drop the saved analysis so it runs again and produces a different result
+      const saved = await store.load('wf-1');
+      if (!saved) throw new Error('state was not saved');
+      delete saved.stages.analyze;
+      await store.save(saved);
+
+      const rerun = createPipeline({ buildFixed: true, analysis: 'node' });
+      const run = await runWorkflow(rerun.workflow, input, {
+        state: { store, workflowId: 'wf-1' },
+      });
+
+      expect(rerun.order).toEqual(['analyze', 'generate', 'build']);
+      expect(run.steps.generate?.value).toBe('FROM node');
+      // The rebuilt image is identical, so its scan is still valid
+      expect(run.steps.scan).toMatchObject({ status: 'succeeded', resumed: true });
+    });
+
+    it('should start over when the saved state cannot be read', async () => {
+      await fs.writeFile(path.join(dir, 'wf-1.json'), '{not json');
+
+      const pipeline = createPipeline({ buildFixed: true });
+      const run = await runWorkflow(pipeline.workflow, 0, {
+        state: { store: createFileWorkflowStateStore(dir), workflowId: 'wf-1' },
+      });
+
+      expect(run.succeeded).toBe(true);
+      expect(pipeline.order).toHaveLength(4);
+    });
+  });
+
+  describe('toolStep', () => {
+    it('should execute the tool with params built from dependency outputs', async () => {
+      const runtime = { execute: jest.fn<any>().mockResolvedValue(Success({ ok: true })) };
+      const workflow = createWorkflow<{ repositoryPath: string }>([
+        { id: 'analyze', run: async () => Success({ language: 'go' }) },
+        toolStep(runtime, {
+          id: 'dockerfile',
+          toolName: 'generate-dockerfile',
+          dependsOn: ['analyze'],
+          params: ({ input, dependencies }) => ({
+            repositoryPath: input.repositoryPath,
+            language: (dependencies.analyze as { language: string }).language,
+          }),
+        }),
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+
+      const run = await runWorkflow(workflow.value, { repositoryPath: '/repo' });
+
+      expect(run.succeeded).toBe(true);
+      expect(runtime.execute).toHaveBeenCalledWith(
+        'generate-dockerfile',
+        { repositoryPath: '/repo', language: 'go' },
+        undefined,
+      );
+    });
+
+    it('should not execute the tool when skipIf matches', async () => {
+      const runtime = { execute: jest.fn<any>().mockResolvedValue(Success({ ok: true })) };
+      const workflow = createWorkflow<{ repositoryPath: string }>([
+        { id: 'analyze', run: async () => Success({ dockerfile: { valid: true } }) },
+        toolStep(runtime, {
+          id: 'dockerfile',
+          toolName: 'generate-dockerfile',
+          dependsOn: ['analyze'],
+          skipIf: ({ dependencies }) =>
+            (dependencies.analyze as { dockerfile: { valid: boolean } }).dockerfile.valid,
+          params: ({ input }) => ({ repositoryPath: input.repositoryPath }),
+        }),
+      ]);
+      if (!workflow.ok) throw new Error(workflow.error);
+
+      const run = await runWorkflow(workflow.value, { repositoryPath: '/repo' });
+
+      expect(run.steps.dockerfile?.skippedByCondition).toBe(true);
+      expect(runtime.execute).not.toHaveBeenCalled();
+    });
+  });
+});
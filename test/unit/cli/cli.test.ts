@@ -41,6 +41,7 @@ describe('CLI Interface', () => {
       expect(content).toContain('--dev');
       expect(content).toContain('--validate');
       expect(content).toContain('--list-tools');
+      expect(content).toContain('--export-schemas');
       expect(content).toContain('--health-check');
       expect(content).toContain('--docker-socket');
       expect(content).toContain('--k8s-namespace');
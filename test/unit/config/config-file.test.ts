@@ -0,0 +1,174 @@
+/**
+ * Unit tests for YAML configuration file support
+ */
+import { writeFileSync } from 'node:fs';
+import { join } from 'node:path';
+import {
+  applyConfigFileEnv,
+  isYamlConfigPath,
+  loadConfigFile,
+  parseConfigFile,
+} from '@/config/config-file';
+import { config, reloadConfig } from '@/config/index';
+import { createTestTempDir } from '../../__support__/utilities/tmp-helpers';
+
+const SAMPLE_CONFIG = `
+server:
+  logLevel: warn
+  port: 8080
+docker:
+  timeout: 120000
+orchestrator:
+  maxConcurrentToolExecutions: 4
+policyPath: ./policies/custom.rego
+`;
+
+describe('config-file', () => {
+  const originalEnv = process.env;
+
+  beforeEach(() => {
+    process.env = { ...originalEnv };
+  });
+
+  afterAll(() => {
+    process.env = originalEnv;
+    reloadConfig();
+  });
+
+  describe('isYamlConfigPath', () => {
+    it('should recognize yaml extensions', () => {
+      expect(isYamlConfigPath('server.yaml')).toBe(true);
+      expect(isYamlConfigPath('server.YML')).toBe(true);
+      expect(isYamlConfigPath('policy.rego')).toBe(false);
+      expect(isYamlConfigPath('.env')).toBe(false);
+    });
+  });
+
+  describe('parseConfigFile', () => {
+    it('should map nested keys to environment variables', () => {
+      const result = parseConfigFile(SAMPLE_CONFIG);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.env).toEqual({
+          LOG_LEVEL: 'warn',
+          PORT: '8080',
+          DOCKER_TIMEOUT: '120000',
+          MAX_CONCURRENT_TOOL_EXECUTIONS: '4',
+          CONTAINERIZATION_ASSIST_POLICY_PATH: './policies/custom.rego',
+        });
+        expect(result.value.warnings).toEqual([]);
+      }
+    });
+
+    it('should warn about unknown keys instead of failing', () => {
+      const result = parseConfigFile('server:\n  port: 9000\n  color: blue\nextra: true\n');
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.env).toEqual({ PORT: '9000' });
+        expect(result.value.warnings).toEqual([
+          'Unknown config key ignored: server.color',
+          'Unknown config key ignored: extra',
+        ]);
+      }
+    });
+
+    it('should join list values into comma-separated variables', () => {
+      const result = parseConfigFile('licenses:\n  allowed:\n    - MIT\n    - Apache-2.0\n');
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.env).toEqual({
+          CONTAINERIZATION_ASSIST_ALLOWED_LICENSES: 'MIT,Apache-2.0',
+        });
+        expect(result.value.warnings).toEqual([]);
+      }
+    });
+
+    it('should still reject lists for scalar settings', () => {
+      const result = parseConfigFile('server:\n  port:\n    - 8080\n');
+
+      expect(result.ok && result.value.warnings).toEqual([
+        'Config key server.port must be a scalar value; ignored',
+      ]);
+    });
+
+    it('should treat an empty file as no settings', () => {
+      const result = parseConfigFile('');
+      expect(result.ok && result.value.env).toEqual({});
+    });
+
+    it('should fail on invalid YAML', () => {
+      const result = parseConfigFile('server: [unclosed');
+      expect(result.ok).toBe(false);
+    });
+
+    it('should fail when the top level is not a mapping', () => {
+      const result = parseConfigFile('- a\n- b\n');
+      expect(result.ok).toBe(false);
+    });
+  });
+
+  describe('loadConfigFile', () => {
+    it('should read a config file from disk', async () => {
+      const { dir, cleanup } = createTestTempDir('config-file-test-');
+      try {
+        const filePath = join(dir.name, 'server.yaml');
+        writeFileSync(filePath, SAMPLE_CONFIG);
+
+        const result = loadConfigFile(filePath);
+
+        expect(result.ok).toBe(true);
+        if (result.ok) expect(result.value.env.PORT).toBe('8080');
+      } finally {
+        await cleanup();
+      }
+    });
+
+    it('should resolve a relative policyPath against the config file directory', async () => {
+      const { dir, cleanup } = createTestTempDir('config-file-test-');
+      try {
+        const filePath = join(dir.name, 'server.yaml');
+        writeFileSync(filePath, SAMPLE_CONFIG);
+
+        const result = loadConfigFile(filePath);
+
+        expect(result.ok && result.value.env.CONTAINERIZATION_ASSIST_POLICY_PATH).toBe(
+          join(dir.name, 'policies', 'custom.rego'),
+        );
+      } finally {
+        await cleanup();
+      }
+    });
+
+    it('should fail for a missing file', () => {
+      const result = loadConfigFile('/nonexistent/server.yaml');
+      expect(result.ok).toBe(false);
+    });
+  });
+
+  describe('precedence', () => {
+    it('should apply yaml < env < flag', () => {
+      const parsed = parseConfigFile(SAMPLE_CONFIG);
+      if (!parsed.ok) throw new Error(parsed.error);
+
+      // Environment already sets the port; the file must not override it
+      process.env.PORT = '7000';
+      delete process.env.LOG_LEVEL;
+      delete process.env.DOCKER_TIMEOUT;
+
+      const applied = applyConfigFileEnv(parsed.value.env);
+      expect(applied).not.toContain('PORT');
+      expect(applied).toContain('LOG_LEVEL');
+
+      // CLI flags are written to the environment last
+      process.env.LOG_LEVEL = 'debug';
+      reloadConfig();
+
+      expect(config.server.port).toBe(7000); // env beats yaml
+      expect(config.server.logLevel).toBe('debug'); // flag beats yaml
+      expect(config.docker.timeout).toBe(120000); // yaml fills the gap
+    });
+  });
+});
@@ -0,0 +1,111 @@
+/**
+ * Unit tests for SIGHUP-triggered configuration reload
+ */
+import { writeFileSync } from 'node:fs';
+import { join } from 'node:path';
+import pino from 'pino';
+import { applyConfigFileEnv, loadConfigFile } from '@/config/config-file';
+import { config, reloadConfig } from '@/config/index';
+import { installReloadHandler, reloadRuntimeConfig } from '@/config/reload';
+import { createDockerClient } from '@/infra/docker/client';
+import { createTestTempDir } from '../../__support__/utilities/tmp-helpers';
+
+jest.mock('dockerode');
+
+describe('config reload', () => {
+  const originalEnv = process.env;
+  let configPath: string;
+  let cleanup: () => Promise<void>;
+  let fileManagedKeys: string[];
+
+  beforeEach(() => {
+    process.env = { ...originalEnv };
+    delete process.env.LOG_LEVEL;
+    delete process.env.DOCKER_TIMEOUT;
+    delete process.env.PORT;
+
+    const tmp = createTestTempDir('config-reload-test-');
+    cleanup = tmp.cleanup;
+    configPath = join(tmp.dir.name, 'server.yaml');
+    writeFileSync(configPath, 'server:\n  logLevel: info\n  port: 3000\ndocker:\n  timeout: 60000\n');
+
+    const loaded = loadConfigFile(configPath);
+    if (!loaded.ok) throw new Error(loaded.error);
+    fileManagedKeys = applyConfigFileEnv(loaded.value.env);
+    reloadConfig();
+  });
+
+  afterEach(async () => {
+    await cleanup();
+    process.env = originalEnv;
+    reloadConfig();
+  });
+
+  it('should change the log level when SIGHUP is received', () => {
+    const logger = pino({ level: 'info', enabled: true }, { write: () => {} });
+    const uninstall = installReloadHandler({ configPath, fileManagedKeys, logger });
+
+    try {
+      writeFileSync(configPath, 'server:\n  logLevel: debug\n  port: 3000\ndocker:\n  timeout: 60000\n');
+      process.emit('SIGHUP', 'SIGHUP');
+
+      expect(logger.level).toBe('debug');
+      expect(config.server.logLevel).toBe('debug');
+    } finally {
+      uninstall();
+    }
+  });
+
+  it('should apply timeouts but leave fixed settings untouched', () => {
+    const logger = pino({ level: 'info' }, { write: () => {} });
+    writeFileSync(configPath, 'server:\n  logLevel: info\n  port: 9999\ndocker:\n  timeout: 90000\n');
+
+    const result = reloadRuntimeConfig({ configPath, fileManagedKeys, logger });
+
+    expect(result.ok).toBe(true);
+    if (result.ok) {
+      expect(Object.keys(result.value.changes)).toEqual(['DOCKER_TIMEOUT']);
+    }
+    expect(config.docker.timeout).toBe(90000);
+    expect(config.server.port).toBe(3000);
+  });
+
+  it('should give Docker clients created after a reload the new timeout', () => {
+    const logger = pino({ level: 'info' }, { write: () => {} });
+    const DockerMock = require('dockerode') as jest.Mock;
+    DockerMock.mockClear();
+    writeFileSync(configPath, 'server:\n  logLevel: info\n  port: 3000\ndocker:\n  timeout: 90000\n');
+
+    createDockerClient(logger, { socketPath: '/var/run/docker.sock' });
+    expect(reloadRuntimeConfig({ configPath, fileManagedKeys, logger }).ok).toBe(true);
+    createDockerClient(logger, { socketPath: '/var/run/docker.sock' });
+
+    expect(DockerMock.mock.calls.map(([options]) => options.timeout)).toEqual([60000, 90000]);
+  });
+
+  it('should not override settings supplied by env or flags', () => {
+    const logger = pino({ level: 'warn' }, { write: () => {} });
+    writeFileSync(configPath, 'server:\n  logLevel: debug\n');
+
+    const result = reloadRuntimeConfig({
+      configPath,
+      fileManagedKeys: fileManagedKeys.filter((key) => key !== 'LOG_LEVEL'),
+      logger,
+    });
+
+    expect(result.ok && result.value.changes).toEqual({});
+    expect(logger.level).toBe('warn');
+  });
+
+  it('should reject an invalid reload atomically', () => {
+    const logger = pino({ level: 'info' }, { write: () => {} });
+    writeFileSync(configPath, 'server:\n  logLevel: debug\ndocker:\n  timeout: -1\n');
+
+    const result = reloadRuntimeConfig({ configPath, fileManagedKeys, logger });
+
+    expect(result.ok).toBe(false);
+    expect(logger.level).toBe('info');
+    expect(config.server.logLevel).toBe('info');
+    expect(config.docker.timeout).toBe(60000);
+  });
+});
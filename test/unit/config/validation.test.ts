@@ -0,0 +1,132 @@
+/**
+ * Unit tests for startup configuration validation
+ */
+import {
+  collectConfigProblems,
+  validateConfig,
+  type ValidatableConfig,
+} from '@/config/validation';
+
+const validConfig: ValidatableConfig = {
+  server: { logLevel: 'info', port: 3000 },
+  workspace: { workspaceDir: '/workspace', maxFileSize: 10485760 },
+  docker: { socketPath: '/var/run/docker.sock', timeout: 60000 },
+  orchestrator: { maxConcurrentToolExecutions: 0 },
+};
+
+describe('config validation', () => {
+  it('should accept a valid configuration', () => {
+    expect(collectConfigProblems(validConfig, {})).toEqual([]);
+    expect(validateConfig(validConfig, {}).ok).toBe(true);
+  });
+
+  it('should reject an unknown log level', () => {
+    const problems = collectConfigProblems({
+      ...validConfig,
+      server: { ...validConfig.server, logLevel: 'verbose' },
+    });
+
+    expect(problems).toHaveLength(1);
+    expect(problems[0]?.field).toBe('server.logLevel');
+    expect(problems[0]?.suggestion).toContain('LOG_LEVEL');
+  });
+
+  it('should reject out-of-range ports', () => {
+    const problems = collectConfigProblems({
+      ...validConfig,
+      server: { ...validConfig.server, port: 70000 },
+    });
+
+    expect(problems.map((p) => p.field)).toEqual(['server.port']);
+  });
+
+  it('should reject non-positive sizes and timeouts', () => {
+    const problems = collectConfigProblems({
+      ...validConfig,
+      workspace: { ...validConfig.workspace, maxFileSize: 0 },
+      docker: { ...validConfig.docker, timeout: -1 },
+    });
+
+    expect(problems.map((p) => p.field)).toEqual(['workspace.maxFileSize', 'docker.timeout']);
+  });
+
+  it('should reject a negative concurrency limit', () => {
+    const problems = collectConfigProblems({
+      ...validConfig,
+      orchestrator: { maxConcurrentToolExecutions: -2 },
+    });
+
+    expect(problems[0]?.field).toBe('orchestrator.maxConcurrentToolExecutions');
+  });
+
+  it('should reject a negative queue limit', () => {
+    const problems = collectConfigProblems({
+      ...validConfig,
+      orchestrator: { maxConcurrentToolExecutions: 2, maxQueuedToolExecutions: -1 },
+    });
+
+    expect(problems.map((p) => p.field)).toEqual(['orchestrator.maxQueuedToolExecutions']);
+  });
+
+  it('should reject integer settings that are not whole numbers', () => {
+    const problems = collectConfigProblems(validConfig, {
+      DOCKER_TIMEOUT: 'abc',
+      MAX_CONCURRENT_TOOL_EXECUTIONS: 'x',
+      MAX_FILE_SIZE: '10mb',
+      PORT: '3000',
+    });
+
+    expect(problems.map((p) => p.field)).toEqual([
+      'workspace.maxFileSize',
+      'docker.timeout',
+      'orchestrator.maxConcurrentToolExecutions',
+    ]);
+    expect(problems[1]?.message).toBe('DOCKER_TIMEOUT is not an integer: "abc"');
+    expect(problems[1]?.suggestion).toContain('DOCKER_TIMEOUT');
+  });
+
+  it('should report a malformed setting once rather than also range-checking its default', () => {
+    const problems = collectConfigProblems(
+      { ...validConfig, server: { ...validConfig.server, port: Number.NaN } },
+      { PORT: 'http' },
+    );
+
+    expect(problems).toEqual([
+      expect.objectContaining({ field: 'server.port', message: 'PORT is not an integer: "http"' }),
+    ]);
+  });
+
+  it('should reject malformed workflow state limits instead of disabling them', () => {
+    const problems = collectConfigProblems(validConfig, {
+      CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_BYTES: '5MB',
+      CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_TOTAL_BYTES: '1e9',
+      CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_IDLE_MINUTES: 'one day',
+    });
+
+    expect(problems.map((p) => p.field)).toEqual([
+      'workflowState.maxBytesPerWorkflow',
+      'workflowState.maxTotalBytes',
+      'workflowState.maxIdleMinutes',
+    ]);
+    expect(problems[0]?.message).toBe(
+      'CONTAINERIZATION_ASSIST_WORKFLOW_STATE_MAX_BYTES is not an integer: "5MB"',
+    );
+  });
+
+  it('should report every problem at once', () => {
+    const result = validateConfig({
+      server: { logLevel: 'loud', port: 0 },
+      workspace: { workspaceDir: '  ', maxFileSize: -5 },
+      docker: { socketPath: '', timeout: 0 },
+      orchestrator: { maxConcurrentToolExecutions: 1.5 },
+    });
+
+    expect(result.ok).toBe(false);
+    if (!result.ok) {
+      expect(result.error).toContain('6 problem(s)');
+      const problems = result.guidance?.details?.problems as unknown[];
+      expect(problems).toHaveLength(6);
+      expect(result.guidance?.resolution?.split('\n')).toHaveLength(6);
+    }
+  });
+});
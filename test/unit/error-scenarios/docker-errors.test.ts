@@ -44,7 +44,9 @@ const mockDockerClient = {
   pushImage: jest.fn(),
   pullImage: jest.fn(),
   inspectImage: jest.fn(),
-  ping: jest.fn(),
+  ping: jest.fn(async () =>
+    createSuccessResult({ socketPath: '/var/run/docker.sock', version: '27.0.0' }),
+  ),
 };
 
 jest.mock('../../../src/infra/docker/client', () => ({
@@ -0,0 +1,340 @@
+/**
+ * Unit tests for docker buildx integration
+ */
+
+import { describe, it, expect, jest } from '@jest/globals';
+import type { Logger } from 'pino';
+import {
+  buildBuildxArgs,
+  checkBuildxAvailability,
+  isMultiPlatform,
+  parseCacheSummary,
+  runBuildxBuild,
+  unsupportedCacheExports,
+  type CommandRunner,
+} from '../../../../src/infra/docker/buildx';
+
+function createMockLogger(): Logger {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+  } as unknown as Logger;
+}
+
+const INSPECT_OUTPUT = `Name:          multiarch
+Driver:        docker-container
+
+Nodes:
+Name:      multiarch0
+Endpoint:  unix:///var/run/docker.sock
+Status:    running
+Platforms: linux/amd64*, linux/arm64, linux/arm/v7
+`;
+
+describe('Docker Buildx', () => {
+  describe('isMultiPlatform', () => {
+    it('should require more than one platform', () => {
+      expect(isMultiPlatform(undefined)).toBe(false);
+      expect(isMultiPlatform(['linux/amd64'])).toBe(false);
+      expect(isMultiPlatform(['linux/amd64', 'linux/arm64'])).toBe(true);
+    });
+  });
+
+  describe('buildBuildxArgs', () => {
+    it('should push a manifest list for multi-platform builds', () => {
+      const args = buildBuildxArgs({
+        context: '/app',
+        dockerfile: 'Dockerfile',
+        tags: ['registry.example.com/app:1.0', 'registry.example.com/app:latest'],
+        platforms: ['linux/amd64', 'linux/arm64'],
+        buildArgs: { NODE_ENV: 'production' },
+      });
+
+      expect(args).toEqual([
+        'buildx',
+        'build',
+        '--platform',
+        'linux/amd64,linux/arm64',
+        '--file',
+        'Dockerfile',
+        '--tag',
+        'registry.example.com/app:1.0',
+        '--tag',
+        'registry.example.com/app:latest',
+        '--build-arg',
+        'NODE_ENV',
+        '--push',
+        '--progress',
+        'plain',
+        '/app',
+      ]);
+    });
+
+    it('should load single-platform builds into the local image store', () => {
+      const args = buildBuildxArgs({
+        context: '/app',
+        tags: ['app:latest'],
+        platforms: ['linux/arm64'],
+      });
+
+      expect(args).toEqual([
+        'buildx',
+        'build',
+        '--platform',
+        'linux/arm64',
+        '--tag',
+        'app:latest',
+        '--load',
+        '--progress',
+        'plain',
+        '/app',
+      ]);
+      expect(args).not.toContain('--push');
+    });
+
+    it('should omit --platform when no platform is requested', () => {
+      const args = buildBuildxArgs({ context: '/app', tags: ['app:latest'], platforms: [] });
+
+      expect(args).not.toContain('--platform');
+      expect(args).toContain('--load');
+    });
+
+    it('should translate cache sources and targets', () => {
+      const args = buildBuildxArgs({
+        context: '/app',
+        tags: ['app:latest'],
+        platforms: [],
+        cacheFrom: ['type=registry,ref=registry.example.com/app:cache', 'type=gha'],
+        cacheTo: ['type=gha,mode=max'],
+      });
+
+      expect(args).toEqual([
+        'buildx',
+        'build',
+        '--tag',
+        'app:latest',
+        '--cache-from',
+        'type=registry,ref=registry.example.com/app:cache',
+        '--cache-from',
+        'type=gha',
+        '--cache-to',
+        'type=gha,mode=max',
+        '--load',
+        '--progress',
+        'plain',
+        '/app',
+      ]);
+    });
+
+    it('should pass the metadata file before the context', () => {
+      const args = buildBuildxArgs({
+        context: '/app',
+        tags: [],
+        platforms: ['linux/amd64', 'linux/arm64'],
+        metadataFile: '/tmp/meta.json',
+      });
+
+      expect(args.slice(-6)).toEqual([
+        '--metadata-file',
+        '/tmp/meta.json',
+        '--push',
+        '--progress',
+        'plain',
+        '/app',
+      ]);
+    });
+  });
+
+  describe('parseCacheSummary', () => {
+    it('should count cached and executed Dockerfile steps', () => {
+      const logs = [
+        '#1 [internal] load build definition from Dockerfile',
+        '#1 DONE 0.0s',
+        '#5 [1/4] FROM docker.io/library/node:20-alpine@sha256:abc',
+        '#5 CACHED',
+        '#6 [2/4] WORKDIR /app',
+        '#6 CACHED',
+        '#7 [builder 3/4] RUN npm ci',
+        '#7 DONE 12.3s',
+        '#8 [4/4] COPY . .',
+        '#8 DONE 0.2s',
+      ];
+
+      expect(parseCacheSummary(logs)).toEqual({ hits: 2, misses: 2 });
+    });
+
+    it('should return undefined when no build steps are present', () => {
+      expect(parseCacheSummary(['#1 [internal] load .dockerignore', '#1 DONE 0.0s'])).toBeUndefined();
+    });
+  });
+
+  describe('unsupportedCacheExports', () => {
+    const targets = ['type=registry,ref=r.io/app:cache,mode=max', 'type=inline', 'type=gha'];
+
+    it('should reject all but inline cache exports on the docker driver', () => {
+      expect(unsupportedCacheExports('docker', targets)).toEqual([
+        'type=registry,ref=r.io/app:cache,mode=max',
+        'type=gha',
+      ]);
+    });
+
+    it('should accept every export on other or unknown drivers', () => {
+      expect(unsupportedCacheExports('docker-container', targets)).toEqual([]);
+      expect(unsupportedCacheExports(undefined, targets)).toEqual([]);
+    });
+  });
+
+  describe('checkBuildxAvailability', () => {
+    it('should report the active builder and its platforms', async () => {
+      const runner = jest.fn<CommandRunner>(async (_cmd, args) => ({
+        stdout:
+          args[1] === 'version'
+            ? 'github.com/docker/buildx v0.12.1 30feaa1\n'
+            : INSPECT_OUTPUT,
+        stderr: '',
+      }));
+
+      const result = await checkBuildxAvailability(createMockLogger(), runner);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value).toEqual({
+          version: 'v0.12.1',
+          builder: 'multiarch',
+          driver: 'docker-container',
+          platforms: ['linux/amd64', 'linux/arm64', 'linux/arm/v7'],
+        });
+      }
+    });
+
+    it('should return setup guidance when buildx is not installed', async () => {
+      const runner = jest.fn<CommandRunner>(async () => {
+        throw new Error("docker: 'buildx' is not a docker command.");
+      });
+
+      const result = await checkBuildxAvailability(createMockLogger(), runner);
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('buildx is not available');
+        expect(result.guidance?.resolution).toContain('docker buildx create');
+      }
+      expect(runner).toHaveBeenCalledTimes(1);
+    });
+
+    it('should fail when no builder instance exists', async () => {
+      const runner = jest.fn<CommandRunner>(async (_cmd, args) => {
+        if (args[1] === 'inspect') throw new Error('no builder instance found');
+        return { stdout: 'github.com/docker/buildx v0.12.1 30feaa1\n', stderr: '' };
+      });
+
+      const result = await checkBuildxAvailability(createMockLogger(), runner);
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('builder');
+        expect(result.guidance?.resolution).toContain('docker buildx create');
+      }
+    });
+  });
+
+  describe('runBuildxBuild', () => {
+    it('should invoke docker with the buildx command line', async () => {
+      const runner = jest.fn<CommandRunner>(async () => ({
+        stdout: '',
+        stderr: '#1 [internal] load build definition\n#1 DONE 0.1s\n',
+      }));
+
+      const result = await runBuildxBuild(
+        { context: '/app', tags: ['app:1.0'], platforms: ['linux/amd64', 'linux/arm64'] },
+        createMockLogger(),
+        runner,
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.pushed).toBe(true);
+        expect(result.value.platforms).toEqual(['linux/amd64', 'linux/arm64']);
+        expect(result.value.logs).toContain('#1 DONE 0.1s');
+      }
+
+      const [command, args] = runner.mock.calls[0] ?? [];
+      expect(command).toBe('docker');
+      expect(args).toEqual(expect.arrayContaining(['--platform', 'linux/amd64,linux/arm64']));
+      expect(args).toContain('--metadata-file');
+    });
+
+    it('should enable BuildKit and report cache usage', async () => {
+      const runner = jest.fn<CommandRunner>(async () => ({
+        stdout: '',
+        stderr: '#5 [1/2] FROM docker.io/library/alpine\n#5 CACHED\n#6 [2/2] RUN apk add curl\n#6 DONE 2.0s\n',
+      }));
+
+      const result = await runBuildxBuild(
+        { context: '/app', tags: ['app:1.0'], platforms: [], cacheFrom: ['type=gha'] },
+        createMockLogger(),
+        runner,
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.pushed).toBe(false);
+        expect(result.value.cache).toEqual({ hits: 1, misses: 1 });
+      }
+      expect(runner.mock.calls[0]?.[2]?.env?.DOCKER_BUILDKIT).toBe('1');
+      expect(runner.mock.calls[0]?.[1]).toEqual(expect.arrayContaining(['--cache-from', 'type=gha']));
+    });
+
+    it('should keep build arg values out of the args, logs, and failure details', async () => {
+      const runner = jest.fn<CommandRunner>(async () => {
+        throw new Error('exit status 1');
+      });
+      const logger = createMockLogger();
+
+      const result = await runBuildxBuild(
+        {
+          context: '/app',
+          tags: ['app:1.0'],
+          platforms: [],
+          buildArgs: { NPM_TOKEN: 's3cr3t-value' },
+        },
+        logger,
+        runner,
+      );
+
+      const [, args, options] = runner.mock.calls[0] ?? [];
+      expect(args).toEqual(expect.arrayContaining(['--build-arg', 'NPM_TOKEN']));
+      expect(JSON.stringify(args)).not.toContain('s3cr3t-value');
+      expect(options?.env?.NPM_TOKEN).toBe('s3cr3t-value');
+      expect(JSON.stringify((logger.debug as jest.Mock).mock.calls)).not.toContain('s3cr3t-value');
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(JSON.stringify(result.guidance?.details)).not.toContain('s3cr3t-value');
+      }
+    });
+
+    it('should surface build logs on failure', async () => {
+      const runner = jest.fn<CommandRunner>(async () => {
+        throw Object.assign(new Error('exit status 1'), {
+          stderr: '#5 ERROR: failed to solve: process "/bin/sh -c npm ci" did not complete',
+        });
+      });
+
+      const result = await runBuildxBuild(
+        { context: '/app', tags: ['app:1.0'], platforms: ['linux/amd64', 'linux/arm64'] },
+        createMockLogger(),
+        runner,
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('Buildx build failed');
+        expect(result.guidance?.details?.buildLogs).toEqual([
+          '#5 ERROR: failed to solve: process "/bin/sh -c npm ci" did not complete',
+        ]);
+      }
+    });
+  });
+});
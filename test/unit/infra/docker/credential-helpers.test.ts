@@ -4,8 +4,15 @@
  * SECURITY FOCUS: Tests for host confusion and credential leakage vulnerabilities
  */
 
-import { describe, it, expect, beforeEach, jest } from '@jest/globals';
-import { getRegistryCredentials } from '../../../../src/infra/docker/credential-helpers';
+import { describe, it, expect, beforeEach, afterEach, jest } from '@jest/globals';
+import { chmodSync, mkdtempSync, rmSync, writeFileSync } from 'fs';
+import { tmpdir } from 'os';
+import { delimiter, join } from 'path';
+import {
+  getRegistryCredentials,
+  REGISTRY_AUTH_ENV,
+  resolveRegistryCredentials,
+} from '../../../../src/infra/docker/credential-helpers';
 import type { Logger } from 'pino';
 
 describe('Docker Credential Helpers Security', () => {
@@ -376,4 +383,165 @@ describe('Docker Credential Helpers Security', () => {
       expect(result.ok).toBe(true);
     });
   });
+
+  describe('resolveRegistryCredentials', () => {
+    const REGISTRY = 'registry.example.com';
+    const HELPER_RESPONSE = {
+      ServerURL: 'helper.example.com',
+      Username: 'helper-user',
+      Secret: 'helper-secret',
+    };
+    const savedEnv = {
+      DOCKER_CONFIG: process.env.DOCKER_CONFIG,
+      PATH: process.env.PATH,
+      [REGISTRY_AUTH_ENV]: process.env[REGISTRY_AUTH_ENV],
+    };
+    let configDir: string;
+
+    const writeDockerConfig = (config: object): void => {
+      writeFileSync(join(configDir, 'config.json'), JSON.stringify(config));
+    };
+
+    const loggedText = (): string =>
+      JSON.stringify(
+        Object.values(mockLogger).flatMap((fn) => (fn as jest.Mock).mock.calls),
+      );
+
+    beforeEach(() => {
+      configDir = mkdtempSync(join(tmpdir(), 'docker-config-'));
+      process.env.DOCKER_CONFIG = configDir;
+      delete process.env[REGISTRY_AUTH_ENV];
+
+      // Fake helper answering for helper.example.com, found via PATH like the real ones
+      const helperPath = join(configDir, 'docker-credential-fake');
+      writeFileSync(
+        helperPath,
+        ['#!/bin/sh', 'cat >/dev/null', `echo '${JSON.stringify(HELPER_RESPONSE)}'`, ''].join('\n'),
+      );
+      chmodSync(helperPath, 0o755);
+      process.env.PATH = `${configDir}${delimiter}${savedEnv.PATH ?? ''}`;
+    });
+
+    afterEach(() => {
+      rmSync(configDir, { recursive: true, force: true });
+      for (const [name, value] of Object.entries(savedEnv)) {
+        if (value === undefined) {
+          delete process.env[name];
+        } else {
+          process.env[name] = value;
+        }
+      }
+    });
+
+    it('should prefer explicit arguments over environment and Docker config', async () => {
+      process.env[REGISTRY_AUTH_ENV] = JSON.stringify({
+        'registry.example.com': { username: 'env-user', password: 'env-secret' },
+      });
+      writeDockerConfig({
+        auths: { 'registry.example.com': { username: 'config-user', password: 'config-secret' } },
+      });
+
+      const result = await resolveRegistryCredentials(
+        REGISTRY,
+        { username: 'arg-user', password: 'arg-secret' },
+        mockLogger,
+      );
+
+      expect(result.ok && result.value).toEqual({
+        source: 'argument',
+        auth: { username: 'arg-user', password: 'arg-secret', serveraddress: REGISTRY },
+      });
+    });
+
+    it('should prefer the environment over Docker config', async () => {
+      process.env[REGISTRY_AUTH_ENV] = JSON.stringify({
+        'https://registry.example.com': {
+          auth: Buffer.from('env-user:env:secret').toString('base64'),
+        },
+      });
+      writeDockerConfig({
+        auths: { 'registry.example.com': { username: 'config-user', password: 'config-secret' } },
+      });
+
+      const result = await resolveRegistryCredentials(REGISTRY, undefined, mockLogger);
+
+      expect(result.ok && result.value).toEqual({
+        source: 'environment',
+        auth: { username: 'env-user', password: 'env:secret', serveraddress: REGISTRY },
+      });
+    });
+
+    it('should fall back to Docker config auths, matching Docker Hub URL keys', async () => {
+      process.env[REGISTRY_AUTH_ENV] = JSON.stringify({
+        'other.example.com': { username: 'env-user', password: 'env-secret' },
+      });
+      writeDockerConfig({
+        auths: {
+          'https://index.docker.io/v1/': {
+            auth: Buffer.from('hub-user:hub-secret').toString('base64'),
+          },
+        },
+      });
+
+      const result = await resolveRegistryCredentials('docker.io', undefined, mockLogger);
+
+      expect(result.ok && result.value).toEqual({
+        source: 'docker-config',
+        auth: {
+          username: 'hub-user',
+          password: 'hub-secret',
+          serveraddress: 'https://index.docker.io/v1/',
+        },
+      });
+    });
+
+    it('should use the credential helper configured for the registry', async () => {
+      writeDockerConfig({
+        auths: { 'other.example.com': { username: 'config-user', password: 'config-secret' } },
+        credHelpers: { 'helper.example.com': 'fake' },
+      });
+
+      const result = await resolveRegistryCredentials('helper.example.com', undefined, mockLogger);
+
+      expect(result.ok && result.value).toEqual({
+        source: 'docker-config',
+        auth: {
+          username: 'helper-user',
+          password: 'helper-secret',
+          serveraddress: 'helper.example.com',
+        },
+      });
+    });
+
+    it('should return null when no source has credentials', async () => {
+      writeDockerConfig({ credHelpers: { 'helper.example.com': 'fake' } });
+
+      const result = await resolveRegistryCredentials(REGISTRY, undefined, mockLogger);
+
+      expect(result).toEqual({ ok: true, value: null });
+    });
+
+    it('should reject malformed environment credentials without echoing them', async () => {
+      process.env[REGISTRY_AUTH_ENV] = '{"registry.example.com": {"password": "env-secret"';
+
+      const result = await resolveRegistryCredentials(REGISTRY, undefined, mockLogger);
+
+      expect(result.ok).toBe(false);
+      expect(JSON.stringify(result)).not.toContain('env-secret');
+    });
+
+    it('should never log passwords', async () => {
+      writeDockerConfig({ credsStore: 'fake' });
+
+      await resolveRegistryCredentials(
+        REGISTRY,
+        { username: 'arg-user', password: 'arg-secret' },
+        mockLogger,
+      );
+      await resolveRegistryCredentials('helper.example.com', undefined, mockLogger);
+
+      expect(loggedText()).toContain('helper.example.com');
+      expect(loggedText()).not.toMatch(/arg-secret|helper-secret/);
+    });
+  });
 });
@@ -0,0 +1,136 @@
+/**
+ * Unit tests for Docker daemon reachability checks
+ */
+
+import { describe, it, expect, beforeEach, jest } from '@jest/globals';
+import Docker from 'dockerode';
+import * as os from 'node:os';
+import * as path from 'node:path';
+import {
+  DOCKER_UNAVAILABLE,
+  isDaemonConnectionError,
+  probeDockerDaemon,
+} from '../../../../src/infra/docker/daemon';
+import type { DockerClient } from '../../../../src/infra/docker/client';
+import { createLogger } from '../../../../src/lib/logger';
+
+const SOCKET = '/var/run/docker.sock';
+
+/** Error shaped like the one Node raises when a socket connect fails */
+function connectError(code: string, address = SOCKET): Error {
+  return Object.assign(new Error(`connect ${code} ${address}`), {
+    code,
+    syscall: 'connect',
+    address,
+  });
+}
+
+const failingVersion = (error: Error) => ({
+  version: jest.fn(async (): Promise<{ Version?: string }> => {
+    throw error;
+  }),
+});
+
+describe('Docker daemon reachability', () => {
+  describe('probeDockerDaemon', () => {
+    it('should report the daemon version when it answers', async () => {
+      const docker = { version: async () => ({ Version: '27.1.1', ApiVersion: '1.46' }) };
+
+      const result = await probeDockerDaemon(docker, SOCKET);
+
+      expect(result).toEqual({
+        ok: true,
+        value: { socketPath: SOCKET, version: '27.1.1', apiVersion: '1.46' },
+      });
+    });
+
+    it('should name the missing socket and how to recover', async () => {
+      const result = await probeDockerDaemon(failingVersion(connectError('ENOENT')), SOCKET);
+
+      expect(result.ok).toBe(false);
+      if (result.ok) return;
+      expect(result.error).toBe(`Docker is unavailable: No Docker socket exists at ${SOCKET}`);
+      expect(result.guidance?.details).toEqual({
+        code: DOCKER_UNAVAILABLE,
+        socketPath: SOCKET,
+        cause: 'ENOENT',
+      });
+      expect(result.guidance?.resolution).toContain('systemctl start docker');
+      expect(result.guidance?.resolution).toContain('docker group');
+      expect(result.guidance?.resolution).toContain('CONTAINER_RUNTIME=podman');
+      expect(result.guidance?.retryable).toBe(false);
+    });
+
+    it('should explain a socket the user cannot open', async () => {
+      const result = await probeDockerDaemon(failingVersion(connectError('EACCES')), SOCKET);
+
+      expect(!result.ok && result.guidance?.hint).toBe(
+        `Permission denied opening the Docker socket at ${SOCKET}`,
+      );
+    });
+
+    it('should give up on a daemon that does not answer', async () => {
+      const hanging = { version: () => new Promise<{ Version?: string }>(() => {}) };
+
+      const result = await probeDockerDaemon(hanging, SOCKET, 20);
+
+      expect(result.ok).toBe(false);
+      if (result.ok) return;
+      expect(result.guidance?.hint).toContain('did not respond within 20ms');
+      expect(result.guidance?.details).toMatchObject({ cause: 'ETIMEDOUT' });
+      expect(result.guidance?.retryable).toBe(true);
+    });
+
+    it('should fail with DOCKER_UNAVAILABLE for a socket that does not exist', async () => {
+      const socketPath = path.join(os.tmpdir(), `missing-docker-${process.pid}.sock`);
+
+      const result = await probeDockerDaemon(new Docker({ socketPath }), socketPath);
+
+      expect(result.ok).toBe(false);
+      if (result.ok) return;
+      expect(result.guidance?.details).toMatchObject({ code: DOCKER_UNAVAILABLE, socketPath });
+      expect(result.guidance?.hint).toContain(socketPath);
+    });
+  });
+
+  describe('isDaemonConnectionError', () => {
+    it('should only match failures to connect to the socket', () => {
+      expect(isDaemonConnectionError(connectError('ECONNREFUSED'))).toBe(true);
+      expect(isDaemonConnectionError(connectError('EPERM'))).toBe(true);
+      // A missing Dockerfile is ENOENT too, but not from connecting
+      const missingFile = Object.assign(new Error('ENOENT'), { code: 'ENOENT' });
+      const forbidden = Object.assign(new Error('denied'), { statusCode: 403 });
+
+      expect(isDaemonConnectionError(missingFile)).toBe(false);
+      expect(isDaemonConnectionError(forbidden)).toBe(false);
+    });
+  });
+
+  describe('Docker client', () => {
+    const { createDockerClient } = jest.requireActual<
+      typeof import('../../../../src/infra/docker/client')
+    >('../../../../src/infra/docker/client');
+    const logger = createLogger({ level: 'silent' });
+    const socketPath = path.join(os.tmpdir(), `missing-docker-${process.pid}.sock`);
+    let client: DockerClient;
+
+    beforeEach(() => {
+      client = createDockerClient(logger, { socketPath });
+    });
+
+    it('should fail ping with the socket that was tried', async () => {
+      const result = await client.ping();
+
+      expect(!result.ok && result.guidance?.details?.socketPath).toBe(socketPath);
+    });
+
+    it('should report DOCKER_UNAVAILABLE when an operation cannot connect', async () => {
+      const result = await client.getImage('app:latest');
+
+      expect(result.ok).toBe(false);
+      if (result.ok) return;
+      expect(result.error).toBe('Failed to get image: Docker daemon is not reachable');
+      expect(result.guidance?.details).toMatchObject({ code: DOCKER_UNAVAILABLE, socketPath });
+    });
+  });
+});
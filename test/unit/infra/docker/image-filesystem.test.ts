@@ -0,0 +1,193 @@
+/**
+ * Unit tests for image filesystem reconstruction from `docker save` archives
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import { createHash } from 'crypto';
+import { gzipSync } from 'zlib';
+import {
+  applyLayer,
+  readImageFilesystem,
+  type ImageFilesystem,
+} from '../../../../src/infra/docker/image-filesystem';
+
+interface TarFile {
+  name: string;
+  content?: string | Buffer;
+  /** Tar type flag: '0' file, '1' hard link, '2' symlink, '5' directory, 'x' PAX header */
+  type?: string;
+  linkname?: string;
+  mode?: number;
+}
+
+function tarHeader(file: TarFile, size: number): Buffer {
+  const block = Buffer.alloc(512);
+  const octal = (value: number, width: number): string => value.toString(8).padStart(width, '0');
+  block.write(file.name.slice(0, 100), 0);
+  block.write(octal(file.mode ?? (file.type === '5' ? 0o755 : 0o644), 7), 100);
+  block.write(octal(0, 7), 108);
+  block.write(octal(0, 7), 116);
+  block.write(octal(size, 11), 124);
+  block.write(octal(0, 11), 136);
+  block.fill(' ', 148, 156);
+  block.write(file.type ?? '0', 156);
+  block.write(file.linkname ?? '', 157);
+  block.write('ustar\u000000', 257);
+  const checksum = block.reduce((sum, byte) => sum + byte, 0);
+  block.write(`${octal(checksum, 6)}\u0000 `, 148);
+  return block;
+}
+
+function tar(files: TarFile[]): Buffer {
+  const parts: Buffer[] = [];
+  for (const file of files) {
+    const data = Buffer.from(file.content ?? '');
+    parts.push(tarHeader(file, data.length), data, Buffer.alloc((512 - (data.length % 512)) % 512));
+  }
+  parts.push(Buffer.alloc(1024));
+  return Buffer.concat(parts);
+}
+
+/** Stream a buffer in small chunks so headers straddle chunk boundaries */
+async function* chunked(data: Buffer, size = 100): AsyncGenerator<Buffer> {
+  for (let offset = 0; offset < data.length; offset += size) {
+    yield data.subarray(offset, offset + size);
+  }
+}
+
+function paxHeader(path: string): TarFile {
+  let record = ` path=${path}\n`;
+  let length = record.length;
+  while (`${length}${record}`.length !== length) {
+    length = `${length}${record}`.length;
+  }
+  record = `${length}${record}`;
+  return { name: 'PaxHeader', type: 'x', content: record };
+}
+
+const sha256 = (content: string): string =>
+  `sha256:${createHash('sha256').update(content).digest('hex')}`;
+
+const manifest = (layers: string[]): TarFile => ({
+  name: 'manifest.json',
+  content: JSON.stringify([{ Config: 'config.json', RepoTags: ['app:1'], Layers: layers }]),
+});
+
+const summarize = (fs: ImageFilesystem): Record<string, string> =>
+  Object.fromEntries([...fs.values()].map((entry) => [entry.path, entry.type]));
+
+describe('readImageFilesystem', () => {
+  it('should replay legacy layers in manifest order and honour whiteouts', async () => {
+    const lower = tar([
+      { name: 'app/', type: '5' },
+      { name: 'app/index.js', content: 'console.log(1)' },
+      { name: 'app/debug.log', content: 'noise' },
+      { name: 'etc/conf.d/', type: '5' },
+      { name: 'etc/conf.d/a.conf', content: 'a' },
+      { name: 'etc/conf.d/b.conf', content: 'b' },
+    ]);
+    const upper = tar([
+      { name: 'app/.wh.debug.log' },
+      { name: 'app/index.js', content: 'console.log(2)' },
+      { name: 'app/main.js', type: '1', linkname: 'app/index.js' },
+      { name: 'bin/', type: '5' },
+      { name: 'bin/sh', type: '2', linkname: 'busybox', mode: 0o777 },
+      { name: 'etc/conf.d/.wh..wh..opq' },
+      { name: 'etc/conf.d/c.conf', content: 'c' },
+    ]);
+    // Layers appear in the archive before the manifest that orders them
+    const archive = tar([
+      { name: 'config.json', content: '{"architecture":"amd64"}' },
+      { name: 'upper/layer.tar', content: upper },
+      { name: 'lower/layer.tar', content: lower },
+      manifest(['lower/layer.tar', 'upper/layer.tar']),
+    ]);
+
+    const result = await readImageFilesystem(chunked(archive));
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(summarize(result.value)).toEqual({
+      '/app': 'directory',
+      '/app/index.js': 'file',
+      '/app/main.js': 'hardlink',
+      '/bin': 'directory',
+      '/bin/sh': 'symlink',
+      '/etc/conf.d': 'directory',
+      '/etc/conf.d/c.conf': 'file',
+    });
+    expect(result.value.get('/app/index.js')).toEqual({
+      path: '/app/index.js',
+      type: 'file',
+      size: 14,
+      mode: 0o644,
+      digest: sha256('console.log(2)'),
+    });
+    expect(result.value.get('/app/main.js')).toMatchObject({
+      linkTarget: '/app/index.js',
+      size: 14,
+      digest: sha256('console.log(2)'),
+    });
+    expect(result.value.get('/bin/sh')).toMatchObject({ linkTarget: 'busybox', mode: 0o777 });
+  });
+
+  it('should read gzip-compressed OCI layout layers and PAX long names', async () => {
+    const longPath = `srv/${'nested/'.repeat(20)}file.txt`;
+    const layer = gzipSync(tar([paxHeader(longPath), { name: 'ignored', content: 'deep' }]));
+    const archive = tar([
+      { name: 'oci-layout', content: '{"imageLayoutVersion":"1.0.0"}' },
+      { name: 'blobs/sha256/cfg', content: '{"os":"linux"}' },
+      { name: 'blobs/sha256/abc', content: layer },
+      manifest(['blobs/sha256/abc']),
+    ]);
+
+    const result = await readImageFilesystem(chunked(archive, 4096));
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect([...result.value.keys()]).toEqual([`/${longPath}`]);
+    expect(result.value.get(`/${longPath}`)?.digest).toBe(sha256('deep'));
+  });
+
+  it('should fail when the manifest is missing', async () => {
+    const result = await readImageFilesystem(chunked(tar([{ name: 'a/layer.tar' }])));
+
+    expect(result.ok).toBe(false);
+    if (!result.ok) {
+      expect(result.error).toContain('manifest.json');
+    }
+  });
+
+  it('should fail when a manifest layer is not in the archive', async () => {
+    const result = await readImageFilesystem(chunked(tar([manifest(['gone/layer.tar'])])));
+
+    expect(result.ok).toBe(false);
+    if (!result.ok) {
+      expect(result.error).toContain('gone/layer.tar');
+      expect(result.guidance?.details).toEqual({ layer: 'gone/layer.tar' });
+    }
+  });
+
+  it('should fail on data that is not a tar archive', async () => {
+    const result = await readImageFilesystem(chunked(Buffer.alloc(2048, 'x')));
+
+    expect(result.ok).toBe(false);
+    if (!result.ok) {
+      expect(result.guidance?.message).toBe('Image archive could not be read');
+    }
+  });
+});
+
+describe('applyLayer', () => {
+  it('should replace a directory tree with a file of the same name', () => {
+    const fs: ImageFilesystem = new Map([
+      ['/data', { path: '/data', type: 'directory', size: 0, mode: 0o755 }],
+      ['/data/a', { path: '/data/a', type: 'file', size: 1, mode: 0o644 }],
+      ['/database', { path: '/database', type: 'file', size: 2, mode: 0o644 }],
+    ]);
+
+    applyLayer(fs, [{ path: '/data', type: 'file', size: 3, mode: 0o644 }]);
+
+    expect(summarize(fs)).toEqual({ '/data': 'file', '/database': 'file' });
+  });
+});
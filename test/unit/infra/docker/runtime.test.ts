@@ -0,0 +1,120 @@
+/**
+ * Unit tests for container runtime selection
+ */
+
+import { describe, it, expect, beforeEach, afterEach, jest } from '@jest/globals';
+import {
+  getPodmanSockets,
+  parseRuntimePreference,
+  resolveContainerRuntime,
+} from '../../../../src/infra/docker/runtime';
+
+/**
+ * Fake socket probe that reports the given sockets as live
+ */
+function fakeProbe(live: string[]): jest.Mock<(paths: string[]) => string | null> {
+  return jest.fn((paths: string[]) => paths.find((p) => live.includes(p)) ?? null);
+}
+
+describe('Container Runtime Selection', () => {
+  let originalPlatform: string;
+  let originalEnv: NodeJS.ProcessEnv;
+
+  beforeEach(() => {
+    originalPlatform = process.platform;
+    originalEnv = { ...process.env };
+    Object.defineProperty(process, 'platform', { value: 'linux', configurable: true });
+    process.env.XDG_RUNTIME_DIR = '/run/user/1000';
+  });
+
+  afterEach(() => {
+    Object.defineProperty(process, 'platform', { value: originalPlatform, configurable: true });
+    process.env = originalEnv;
+  });
+
+  describe('parseRuntimePreference', () => {
+    it('should accept known runtimes case-insensitively', () => {
+      expect(parseRuntimePreference('Podman')).toBe('podman');
+      expect(parseRuntimePreference('docker')).toBe('docker');
+      expect(parseRuntimePreference(' auto ')).toBe('auto');
+    });
+
+    it('should default to auto for missing or unknown values', () => {
+      expect(parseRuntimePreference(undefined)).toBe('auto');
+      expect(parseRuntimePreference('containerd')).toBe('auto');
+    });
+  });
+
+  describe('getPodmanSockets', () => {
+    it('should prefer the rootless XDG runtime socket', () => {
+      expect(getPodmanSockets()[0]).toBe('/run/user/1000/podman/podman.sock');
+    });
+  });
+
+  describe('resolveContainerRuntime', () => {
+    it('should use Docker when its socket is present in auto mode', () => {
+      const probe = fakeProbe(['/var/run/docker.sock', '/run/user/1000/podman/podman.sock']);
+
+      const selection = resolveContainerRuntime('auto', probe);
+
+      expect(selection).toEqual({
+        runtime: 'docker',
+        socketPath: '/var/run/docker.sock',
+        detected: true,
+      });
+    });
+
+    it('should fall back to Podman when the Docker socket is absent', () => {
+      const probe = fakeProbe(['/run/user/1000/podman/podman.sock']);
+
+      const selection = resolveContainerRuntime('auto', probe);
+
+      expect(selection.runtime).toBe('podman');
+      expect(selection.socketPath).toBe('/run/user/1000/podman/podman.sock');
+      expect(selection.detected).toBe(true);
+    });
+
+    it('should dispatch to Podman when explicitly selected even if Docker is available', () => {
+      const probe = fakeProbe(['/var/run/docker.sock', '/run/podman/podman.sock']);
+
+      const selection = resolveContainerRuntime('podman', probe);
+
+      expect(selection.runtime).toBe('podman');
+      expect(selection.socketPath).toBe('/run/podman/podman.sock');
+    });
+
+    it('should not fall back to Podman when Docker is explicitly selected', () => {
+      const probe = fakeProbe(['/run/user/1000/podman/podman.sock']);
+
+      const selection = resolveContainerRuntime('docker', probe);
+
+      expect(selection.runtime).toBe('docker');
+      expect(selection.detected).toBe(false);
+    });
+
+    it('should report an undetected Docker default when nothing is available', () => {
+      const selection = resolveContainerRuntime('auto', fakeProbe([]));
+
+      expect(selection).toEqual({
+        runtime: 'docker',
+        socketPath: '/var/run/docker.sock',
+        detected: false,
+      });
+    });
+
+    it('should read the preference from CONTAINER_RUNTIME by default', () => {
+      process.env.CONTAINER_RUNTIME = 'podman';
+      const probe = fakeProbe(['/var/run/docker.sock', '/run/user/1000/podman/podman.sock']);
+
+      expect(resolveContainerRuntime(undefined, probe).runtime).toBe('podman');
+    });
+
+    it('should use the Docker named pipe on Windows', () => {
+      Object.defineProperty(process, 'platform', { value: 'win32', configurable: true });
+
+      const selection = resolveContainerRuntime('auto', fakeProbe([]));
+
+      expect(selection.socketPath).toBe('npipe://./pipe/docker_engine');
+    });
+  });
+});
@@ -8,6 +8,7 @@ import { describe, it, expect } from '@jest/globals';
 import { extractDockerErrorGuidance } from '@/infra/docker/errors';
 import { extractK8sErrorGuidance } from '@/infra/kubernetes/errors';
 import { Failure } from '@/types';
+import { isTransientFailure } from '@/lib/retry';
 
 describe('Error Guidance System', () => {
   describe('Docker Error Guidance', () => {
@@ -285,4 +286,44 @@ describe('Error Guidance System', () => {
       }
     });
   });
-});
\ No newline at end of file
+  describe('Retryable Classification', () => {
+    it('should mark Docker network and server errors as retryable', () => {
+      for (const props of [{ code: 'ETIMEDOUT' }, { code: 'ECONNRESET' }, { statusCode: 503 }]) {
+        const error = Object.assign(new Error('request failed'), props);
+        expect(extractDockerErrorGuidance(error).retryable).toBe(true);
+      }
+    });
+
+    it('should mark Docker errors the same call cannot fix as not retryable', () => {
+      for (const props of [{ code: 'ENOTFOUND' }, { statusCode: 401 }, { statusCode: 404 }]) {
+        const error = Object.assign(new Error('request failed'), props);
+        expect(extractDockerErrorGuidance(error).retryable).toBe(false);
+      }
+      expect(extractDockerErrorGuidance(new Error('unknown instruction: FORM')).retryable).toBe(
+        false,
+      );
+    });
+
+    it('should classify Kubernetes errors', () => {
+      expect(extractK8sErrorGuidance(new Error('connect ETIMEDOUT')).retryable).toBe(true);
+      expect(extractK8sErrorGuidance(new Error('forbidden: cannot list pods')).retryable).toBe(
+        false,
+      );
+    });
+
+    it('should leave unknown errors unclassified', () => {
+      expect(extractDockerErrorGuidance(new Error('something odd')).retryable).toBeUndefined();
+      expect(extractK8sErrorGuidance(new Error('something odd')).retryable).toBeUndefined();
+    });
+
+    it('should keep the flag when guidance is passed on in a Failure', () => {
+      const error = Object.assign(new Error('connect ECONNRESET'), { code: 'ECONNRESET' });
+      const guidance = extractDockerErrorGuidance(error);
+
+      const result = Failure(`Failed to push image: ${guidance.message}`, guidance);
+
+      expect(!result.ok && result.guidance?.retryable).toBe(true);
+      expect(!result.ok && isTransientFailure(result)).toBe(true);
+    });
+  });
+});
@@ -0,0 +1,98 @@
+/**
+ * Deployment Rollout Polling Tests
+ */
+
+import { describe, it, expect, jest } from '@jest/globals';
+import { formatRolloutProgress, waitForRollout } from '../../../../src/infra/kubernetes/rollout';
+import type { DeploymentResult } from '../../../../src/infra/kubernetes/client';
+import {
+  Success,
+  Failure,
+  getPartialResult,
+  isCancelled,
+  type Result,
+} from '../../../../src/types';
+
+/**
+ * Fake status source that walks through the given statuses, then repeats the last one
+ */
+function statusSequence(
+  ...statuses: Array<Result<DeploymentResult>>
+): () => Promise<Result<DeploymentResult>> {
+  let call = 0;
+  return async () => statuses[Math.min(call++, statuses.length - 1)] as Result<DeploymentResult>;
+}
+
+const status = (readyReplicas: number, totalReplicas = 3): Result<DeploymentResult> =>
+  Success({ ready: readyReplicas === totalReplicas, readyReplicas, totalReplicas });
+
+describe('waitForRollout', () => {
+  const target = { namespace: 'production', name: 'web', pollIntervalMs: 1 };
+
+  it('should report progress as pods become ready', async () => {
+    const onProgress = jest.fn<(s: DeploymentResult) => void>();
+
+    const result = await waitForRollout(
+      statusSequence(status(0), status(0), status(1), status(3)),
+      { ...target, timeoutSeconds: 5, onProgress },
+    );
+
+    expect(result).toEqual(Success({ ready: true, readyReplicas: 3, totalReplicas: 3 }));
+    // Unchanged polls are not reported again
+    expect(onProgress.mock.calls.map(([s]) => formatRolloutProgress(s))).toEqual([
+      '0/3 pods ready',
+      '1/3 pods ready',
+      '3/3 pods ready',
+    ]);
+  });
+
+  it('should keep polling through transient status errors', async () => {
+    const result = await waitForRollout(
+      statusSequence(Failure('connection reset'), status(2, 2)),
+      { ...target, timeoutSeconds: 5 },
+    );
+
+    expect(result.ok).toBe(true);
+  });
+
+  it('should return the last seen status when the rollout never becomes ready', async () => {
+    const onProgress = jest.fn();
+
+    const result = await waitForRollout(statusSequence(status(0), status(1)), {
+      ...target,
+      timeoutSeconds: 0.05,
+      onProgress,
+    });
+
+    expect(result.ok).toBe(false);
+    if (!result.ok) {
+      expect(result.error).toContain('did not become ready within 0.05 seconds');
+      expect(result.guidance?.hint).toBe('Last seen: 1/3 pods ready');
+      expect(result.guidance?.details).toMatchObject({
+        lastStatus: { ready: false, readyReplicas: 1, totalReplicas: 3 },
+      });
+    }
+    expect(onProgress).toHaveBeenCalledTimes(2);
+  });
+
+  it('should stop polling and keep the last seen status when cancelled', async () => {
+    const controller = new AbortController();
+    const getStatus = jest.fn(statusSequence(status(1)));
+
+    const pending = waitForRollout(getStatus, {
+      ...target,
+      pollIntervalMs: 60_000,
+      timeoutSeconds: 120,
+      signal: controller.signal,
+    });
+    await new Promise((resolve) => setImmediate(resolve));
+    controller.abort();
+    const result = await pending;
+
+    expect(isCancelled(result)).toBe(true);
+    expect(getPartialResult(result)).toEqual({
+      lastStatus: { ready: false, readyReplicas: 1, totalReplicas: 3 },
+    });
+    expect(getStatus).toHaveBeenCalledTimes(1);
+  });
+});
@@ -0,0 +1,40 @@
+/**
+ * Cosign Signer Tests
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  buildCosignSignArgs,
+  buildSignerDockerConfig,
+} from '../../../../src/infra/security/cosign';
+
+describe('Cosign Signer', () => {
+  describe('buildCosignSignArgs', () => {
+    it('should pass the key reference when provided', () => {
+      expect(
+        buildCosignSignArgs('myregistry.io/app@sha256:abc', { keyRef: 'cosign.key' }),
+      ).toEqual(['sign', '--yes', '--key', 'cosign.key', 'myregistry.io/app@sha256:abc']);
+    });
+
+    it('should sign keyless without a key reference', () => {
+      expect(buildCosignSignArgs('myregistry.io/app@sha256:abc', {})).toEqual([
+        'sign',
+        '--yes',
+        'myregistry.io/app@sha256:abc',
+      ]);
+    });
+  });
+
+  describe('buildSignerDockerConfig', () => {
+    it('should grant basic auth to the push registry only', () => {
+      const config = buildSignerDockerConfig({
+        username: 'ci-bot',
+        password: 'registry-token',
+        serveraddress: 'myregistry.io',
+      });
+
+      const auth = Buffer.from('ci-bot:registry-token').toString('base64');
+      expect(config).toEqual({ auths: { 'myregistry.io': { auth } } });
+    });
+  });
+});
@@ -0,0 +1,152 @@
+/**
+ * SARIF Export Tests
+ */
+
+import { describe, it, expect, afterEach } from '@jest/globals';
+import { readFileSync } from 'node:fs';
+import { join } from 'node:path';
+import Ajv, { type ValidateFunction } from 'ajv';
+import addFormats from 'ajv-formats';
+import { toSarif, writeSarifReport } from '@/infra/security/sarif';
+import { parseTrivyOutput, type TrivyOutput } from '@/infra/security/trivy-scanner';
+import { createTestTempDir } from '../../../__support__/utilities/tmp-helpers';
+
+const FIXTURES = join(__dirname, '../../../__support__/fixtures/scanner-outputs');
+const SARIF_SCHEMA = join(
+  __dirname,
+  '../../../__support__/fixtures/sarif/sarif-schema-2.1.0.json',
+);
+
+/**
+ * Compiles the official OASIS SARIF 2.1.0 schema. It is written for JSON Schema
+ * draft-04, so the draft-04 `$schema`/`id` headers are dropped for Ajv's default draft.
+ */
+function compileSarifSchema(): ValidateFunction {
+  const {
+    $schema: _draft,
+    id: _id,
+    ...schema
+  } = JSON.parse(readFileSync(SARIF_SCHEMA, 'utf-8')) as Record<string, unknown>;
+  const ajv = new Ajv({ allErrors: true });
+  addFormats(ajv);
+  return ajv.compile(schema);
+}
+
+function loadScanResult(): ReturnType<typeof parseTrivyOutput> {
+  const output = JSON.parse(
+    readFileSync(join(FIXTURES, 'trivy-alpine.json'), 'utf-8'),
+  ) as TrivyOutput;
+  return parseTrivyOutput(output, 'myapp:1.0');
+}
+
+describe('SARIF Export', () => {
+  let cleanup: (() => Promise<void>) | undefined;
+
+  afterEach(async () => {
+    await cleanup?.();
+    cleanup = undefined;
+  });
+
+  it('should produce a document that conforms to the SARIF 2.1.0 schema', () => {
+    const sarif = toSarif(loadScanResult(), 'myapp:1.0');
+
+    const validate = compileSarifSchema();
+
+    expect(validate(sarif)).toBe(true);
+    expect(validate.errors ?? []).toEqual([]);
+  });
+
+  it('should reject a document the SARIF 2.1.0 schema does not allow', () => {
+    const sarif = toSarif(loadScanResult(), 'myapp:1.0');
+    const invalid = {
+      ...sarif,
+      runs: sarif.runs.map((run) => ({
+        ...run,
+        results: run.results.map((result) => ({ ...result, level: 'critical' })),
+      })),
+    };
+
+    expect(compileSarifSchema()(invalid)).toBe(false);
+  });
+
+  it('should map each CVE ID to a rule ID', () => {
+    const scanResult = loadScanResult();
+    const sarif = toSarif(scanResult, 'myapp:1.0');
+    const run = sarif.runs[0];
+
+    expect(run?.tool.driver.rules.map((rule) => rule.id).sort()).toEqual(
+      scanResult.vulnerabilities.map((v) => v.id).sort(),
+    );
+    for (const result of run?.results ?? []) {
+      expect(run?.tool.driver.rules[result.ruleIndex]?.id).toBe(result.ruleId);
+    }
+  });
+
+  it('should point results at the affected package', () => {
+    const sarif = toSarif(loadScanResult(), 'myapp:1.0');
+    const result = sarif.runs[0]?.results.find((r) => r.ruleId === 'CVE-2024-4068');
+
+    expect(result?.level).toBe('error');
+    expect(result?.locations[0]?.logicalLocations[0]).toEqual({
+      name: 'braces',
+      fullyQualifiedName: 'braces@3.0.2',
+      kind: 'package',
+    });
+    expect(result?.message.text).toContain('Fixed in 3.0.3');
+  });
+
+  it('should locate results in the Dockerfile and record the image in properties', () => {
+    const [first] = toSarif(loadScanResult(), 'myapp:1.0', './services/api/Dockerfile').runs[0]
+      ?.results ?? [];
+
+    expect(first?.locations[0]?.physicalLocation.artifactLocation.uri).toBe(
+      'services/api/Dockerfile',
+    );
+    expect(first?.properties).toEqual({ imageRef: 'myapp:1.0' });
+  });
+
+  it('should default the artifact location to the root Dockerfile', () => {
+    const [first] = toSarif(loadScanResult(), 'registry.io/team/myapp:1.0').runs[0]?.results ?? [];
+
+    expect(first?.locations[0]?.physicalLocation.artifactLocation.uri).toBe('Dockerfile');
+    expect(first?.properties.imageRef).toBe('registry.io/team/myapp:1.0');
+  });
+
+  it('should emit one rule per CVE when several packages share it', () => {
+    const scanResult = loadScanResult();
+    const [first] = scanResult.vulnerabilities;
+    const duplicate = { ...(first as NonNullable<typeof first>), package: 'libcrypto3' };
+    const sarif = toSarif(
+      { ...scanResult, vulnerabilities: [...scanResult.vulnerabilities, duplicate] },
+      'myapp:1.0',
+    );
+
+    expect(sarif.runs[0]?.tool.driver.rules).toHaveLength(scanResult.vulnerabilities.length);
+    expect(sarif.runs[0]?.results).toHaveLength(scanResult.vulnerabilities.length + 1);
+  });
+
+  it('should map severities to SARIF levels and GitHub security-severity', () => {
+    const rules = toSarif(loadScanResult(), 'myapp:1.0').runs[0]?.tool.driver.rules ?? [];
+    const byId = Object.fromEntries(rules.map((rule) => [rule.id, rule]));
+
+    expect(byId['CVE-2024-4068']?.properties['security-severity']).toBe('9.5');
+    expect(byId['CVE-2023-5678']?.defaultConfiguration.level).toBe('warning');
+  });
+
+  it('should write the report to disk', async () => {
+    const tmp = createTestTempDir('sarif-test-');
+    cleanup = tmp.cleanup;
+
+    const result = await writeSarifReport(
+      toSarif(loadScanResult(), 'myapp:1.0'),
+      'myapp:1.0',
+      tmp.dir.name,
+    );
+
+    expect(result.ok).toBe(true);
+    if (result.ok) {
+      expect(result.value).toMatch(/myapp_1\.0\.sarif$/);
+      expect(JSON.parse(readFileSync(result.value, 'utf-8')).version).toBe('2.1.0');
+    }
+  });
+});
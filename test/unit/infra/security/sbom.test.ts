@@ -0,0 +1,148 @@
+/**
+ * SBOM Generation Tests
+ */
+
+import { describe, it, expect, jest, beforeEach, afterEach } from '@jest/globals';
+import { readFileSync } from 'node:fs';
+import type { Logger } from 'pino';
+import {
+  buildInventoryDocument,
+  createInventoryGenerator,
+  generateSbom,
+  type SbomGenerator,
+} from '../../../../src/infra/security/sbom';
+import { Success, Failure } from '../../../../src/types';
+import { createTestTempDir } from '../../../__support__/utilities/tmp-helpers';
+
+function createMockLogger(): Logger {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+  } as unknown as Logger;
+}
+
+function fakeGenerator(
+  name: string,
+  available: boolean,
+  document = '{"bomFormat":"CycloneDX"}',
+): SbomGenerator {
+  return {
+    name,
+    isAvailable: jest.fn(async () => available),
+    generate: jest.fn(async () => Success(document)),
+  };
+}
+
+const IMAGE_INFO = {
+  Id: 'sha256:image',
+  RepoTags: ['myapp:1.0'],
+  Layers: ['sha256:aaa', 'sha256:bbb'],
+};
+
+describe('SBOM Generation', () => {
+  let outputDir: string;
+  let cleanup: () => Promise<void>;
+
+  beforeEach(() => {
+    const tmp = createTestTempDir('sbom-test-');
+    outputDir = tmp.dir.name;
+    cleanup = tmp.cleanup;
+  });
+
+  afterEach(async () => {
+    await cleanup();
+  });
+
+  describe('generateSbom', () => {
+    it('should use the first available generator and write the artifact', async () => {
+      const syft = fakeGenerator('syft', true);
+      const inventory = fakeGenerator('inventory', true);
+
+      const result = await generateSbom('myapp:1.0', 'cyclonedx', {
+        generators: [syft, inventory],
+        logger: createMockLogger(),
+        outputDir,
+      });
+
+      expect(result.warnings).toEqual([]);
+      expect(result.artifact).toMatchObject({
+        format: 'cyclonedx',
+        generator: 'syft',
+        mediaType: 'application/vnd.cyclonedx+json',
+      });
+      expect(readFileSync(result.artifact?.path ?? '', 'utf-8')).toBe('{"bomFormat":"CycloneDX"}');
+      expect(syft.generate).toHaveBeenCalledWith('myapp:1.0', 'cyclonedx');
+      expect(inventory.generate).not.toHaveBeenCalled();
+    });
+
+    it('should fall back with a warning when syft is not installed', async () => {
+      const result = await generateSbom('myapp:1.0', 'spdx', {
+        generators: [fakeGenerator('syft', false), fakeGenerator('inventory', true, '{}')],
+        logger: createMockLogger(),
+        outputDir,
+      });
+
+      expect(result.artifact?.generator).toBe('inventory');
+      expect(result.artifact?.path).toMatch(/myapp_1\.0\.spdx\.json$/);
+      expect(result.warnings).toEqual([
+        "SBOM generator 'syft' is not installed",
+        expect.stringContaining('install syft'),
+      ]);
+    });
+
+    it('should degrade to a warning when no generator succeeds', async () => {
+      const failing: SbomGenerator = {
+        name: 'inventory',
+        isAvailable: async () => true,
+        generate: async () => Failure('image not found'),
+      };
+
+      const result = await generateSbom('myapp:1.0', 'cyclonedx', {
+        generators: [fakeGenerator('syft', false), failing],
+        logger: createMockLogger(),
+        outputDir,
+      });
+
+      expect(result.artifact).toBeUndefined();
+      expect(result.warnings).toContain('No SBOM generator is available; SBOM was not generated');
+    });
+  });
+
+  describe('inventory generator', () => {
+    it('should list image layers as CycloneDX components', () => {
+      const doc = buildInventoryDocument('myapp:1.0', IMAGE_INFO, 'cyclonedx');
+
+      expect(doc).toMatchObject({
+        bomFormat: 'CycloneDX',
+        specVersion: '1.5',
+        metadata: { component: { type: 'container', name: 'myapp:1.0', version: 'sha256:image' } },
+        components: [
+          { type: 'file', name: 'layer-0', hashes: [{ alg: 'SHA-256', content: 'aaa' }] },
+          { type: 'file', name: 'layer-1', hashes: [{ alg: 'SHA-256', content: 'bbb' }] },
+        ],
+      });
+    });
+
+    it('should describe the image and its layers in SPDX', () => {
+      const doc = buildInventoryDocument('myapp:1.0', IMAGE_INFO, 'spdx');
+
+      expect(doc).toMatchObject({ spdxVersion: 'SPDX-2.3', name: 'myapp:1.0' });
+      expect(doc.packages).toHaveLength(3);
+      expect(doc.relationships).toContainEqual({
+        spdxElementId: 'SPDXRef-Image',
+        relationshipType: 'CONTAINS',
+        relatedSpdxElement: 'SPDXRef-Layer-1',
+      });
+    });
+
+    it('should propagate inspect failures', async () => {
+      const generator = createInventoryGenerator(async () => Failure('No such image'));
+
+      const result = await generator.generate('missing:1.0', 'cyclonedx');
+
+      expect(result.ok).toBe(false);
+    });
+  });
+});
@@ -0,0 +1,127 @@
+/**
+ * Scanner Preflight Tests
+ *
+ * Trivy presence and version checks, and auto-install of a missing Trivy
+ */
+
+import { describe, it, expect, jest, beforeEach } from '@jest/globals';
+import type { Logger } from 'pino';
+
+import {
+  MIN_TRIVY_VERSION,
+  PINNED_TRIVY_VERSION,
+  SCANNER_UNAVAILABLE,
+  compareVersions,
+  preflightTrivy,
+} from '@/infra/security/scanner-preflight';
+import { Success, Failure, type Result } from '@/types';
+
+function createMockLogger(): Logger {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+  } as unknown as Logger;
+}
+
+const notInstalled = Failure('Trivy not installed or not in PATH');
+const INSTALLED_TRIVY = '/workspace/.containerization-assist/bin/trivy';
+
+describe('Scanner preflight', () => {
+  let probe: jest.Mock<(logger: Logger, binary?: string) => Promise<Result<string>>>;
+  let install: jest.Mock<(version: string, logger: Logger) => Promise<Result<string>>>;
+
+  beforeEach(() => {
+    probe = jest.fn<(logger: Logger, binary?: string) => Promise<Result<string>>>();
+    install = jest.fn<(version: string, logger: Logger) => Promise<Result<string>>>();
+  });
+
+  it('should pass without warnings for an acceptable version', async () => {
+    probe.mockResolvedValue(Success('0.58.1'));
+
+    const result = await preflightTrivy(createMockLogger(), { probe, install });
+
+    expect(result).toEqual(
+      Success({ version: '0.58.1', binary: 'trivy', installed: false, warnings: [] }),
+    );
+    expect(install).not.toHaveBeenCalled();
+  });
+
+  it('should warn, but not fail, for a version below the minimum', async () => {
+    probe.mockResolvedValue(Success('0.45.2'));
+    const logger = createMockLogger();
+
+    const result = await preflightTrivy(logger, { probe, install });
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(result.value.warnings).toHaveLength(1);
+    expect(result.value.warnings[0]).toContain('0.45.2');
+    expect(result.value.warnings[0]).toContain(MIN_TRIVY_VERSION);
+    expect(logger.warn).toHaveBeenCalled();
+  });
+
+  it('should fail with install instructions when Trivy is missing', async () => {
+    probe.mockResolvedValue(notInstalled);
+
+    const result = await preflightTrivy(createMockLogger(), {
+      autoInstall: false,
+      probe,
+      install,
+    });
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.error).toBe('Trivy not installed or not in PATH');
+    expect(result.guidance?.details).toMatchObject({ code: SCANNER_UNAVAILABLE, scanner: 'trivy' });
+    expect(result.guidance?.resolution).toContain('brew install trivy');
+    expect(result.guidance?.resolution).toContain('--auto-install-scanner');
+    expect(install).not.toHaveBeenCalled();
+  });
+
+  it('should install the pinned version when Trivy is missing and auto-install is on', async () => {
+    probe.mockResolvedValueOnce(notInstalled).mockResolvedValueOnce(Success(PINNED_TRIVY_VERSION));
+    install.mockResolvedValue(Success(INSTALLED_TRIVY));
+
+    const result = await preflightTrivy(createMockLogger(), { autoInstall: true, probe, install });
+
+    expect(install).toHaveBeenCalledWith(PINNED_TRIVY_VERSION, expect.anything());
+    // The installed binary is probed and run by its absolute path, not found on the PATH
+    expect(probe).toHaveBeenLastCalledWith(expect.anything(), INSTALLED_TRIVY);
+    expect(result).toEqual(
+      Success({
+        version: PINNED_TRIVY_VERSION,
+        binary: INSTALLED_TRIVY,
+        installed: true,
+        warnings: [],
+      }),
+    );
+  });
+
+  it('should report a failed auto-install', async () => {
+    probe.mockResolvedValue(notInstalled);
+    install.mockResolvedValue(
+      Failure('Failed to install Trivy 0.58.1: GET ... returned 404 Not Found', {
+        message: 'Trivy auto-install failed',
+        details: { code: SCANNER_UNAVAILABLE },
+      }),
+    );
+
+    const result = await preflightTrivy(createMockLogger(), { autoInstall: true, probe, install });
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.error).toContain('Failed to install Trivy');
+    expect(probe).toHaveBeenCalledTimes(1);
+  });
+
+  describe('compareVersions', () => {
+    it('should compare numerically, ignoring prefixes and pre-release tags', () => {
+      expect(compareVersions('0.9.0', '0.50.0')).toBeLessThan(0);
+      expect(compareVersions('v0.50.0', '0.50.0')).toBe(0);
+      expect(compareVersions('0.50.1-rc1', '0.50.0')).toBeGreaterThan(0);
+      expect(compareVersions('1.0', '0.58.1')).toBeGreaterThan(0);
+    });
+  });
+});
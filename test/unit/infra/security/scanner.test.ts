@@ -0,0 +1,187 @@
+/**
+ * Security Scanner Tests
+ *
+ * Normalization of Trivy and Grype output, and scanner selection
+ */
+
+import { describe, it, expect, jest, beforeEach } from '@jest/globals';
+import { readFileSync } from 'node:fs';
+import { join } from 'node:path';
+import type { Logger } from 'pino';
+
+const mockCheckTrivyAvailability = jest.fn<(...args: unknown[]) => Promise<unknown>>();
+const mockScanImageWithTrivy = jest.fn<(...args: unknown[]) => Promise<unknown>>();
+const mockCheckGrypeAvailability = jest.fn<(...args: unknown[]) => Promise<unknown>>();
+const mockScanImageWithGrype = jest.fn<(...args: unknown[]) => Promise<unknown>>();
+
+jest.mock('@/infra/security/trivy-scanner', () => ({
+  ...jest.requireActual<object>('@/infra/security/trivy-scanner'),
+  checkTrivyAvailability: (...args: unknown[]) => mockCheckTrivyAvailability(...args),
+  scanImageWithTrivy: (...args: unknown[]) => mockScanImageWithTrivy(...args),
+}));
+
+jest.mock('@/infra/security/grype-scanner', () => ({
+  ...jest.requireActual<object>('@/infra/security/grype-scanner'),
+  checkGrypeAvailability: (...args: unknown[]) => mockCheckGrypeAvailability(...args),
+  scanImageWithGrype: (...args: unknown[]) => mockScanImageWithGrype(...args),
+}));
+
+import { parseTrivyOutput, type TrivyOutput } from '@/infra/security/trivy-scanner';
+import { parseGrypeOutput, type GrypeOutput } from '@/infra/security/grype-scanner';
+import { createSecurityScanner, type BasicScanResult } from '@/infra/security/scanner';
+import { Success, Failure } from '@/types';
+
+const FIXTURES = join(__dirname, '../../../__support__/fixtures/scanner-outputs');
+
+function loadFixture<T>(name: string): T {
+  return JSON.parse(readFileSync(join(FIXTURES, name), 'utf-8')) as T;
+}
+
+function createMockLogger(): Logger {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+  } as unknown as Logger;
+}
+
+/**
+ * Strip scanner-specific metadata and order findings by ID for comparison
+ */
+function comparable(result: BasicScanResult): Omit<BasicScanResult, 'scanDate' | 'scanner'> {
+  const { scanDate: _scanDate, scanner: _scanner, ...rest } = result;
+  return {
+    ...rest,
+    vulnerabilities: [...rest.vulnerabilities].sort((a, b) => a.id.localeCompare(b.id)),
+  };
+}
+
+describe('Security Scanners', () => {
+  describe('output normalization', () => {
+    const trivy = parseTrivyOutput(loadFixture<TrivyOutput>('trivy-alpine.json'), 'myapp:1.0');
+    const grype = parseGrypeOutput(loadFixture<GrypeOutput>('grype-alpine.json'), 'myapp:1.0');
+
+    it('should produce equivalent results from Trivy and Grype', () => {
+      expect(comparable(grype)).toEqual(comparable(trivy));
+    });
+
+    it('should tag results with the producing scanner', () => {
+      expect(trivy.scanner).toBe('trivy');
+      expect(grype.scanner).toBe('grype');
+    });
+
+    it('should map Grype findings onto the shared vulnerability shape', () => {
+      expect(grype.vulnerabilities).toContainEqual({
+        id: 'CVE-2023-5678',
+        severity: 'MEDIUM',
+        package: 'libssl3',
+        version: '3.1.3-r0',
+        fixedVersion: '3.1.4-r1',
+        description: expect.stringContaining('X9.42 DH keys'),
+      });
+      expect(grype).toMatchObject({
+        totalVulnerabilities: 4,
+        criticalCount: 1,
+        highCount: 1,
+        mediumCount: 2,
+        lowCount: 0,
+      });
+    });
+
+    it('should omit fixedVersion for unfixed Grype findings', () => {
+      const busybox = grype.vulnerabilities.find((v) => v.package === 'busybox');
+      expect(busybox?.fixedVersion).toBeUndefined();
+    });
+
+    it('should report the CVE alias and its description for GHSA matches', () => {
+      const crossSpawn = grype.vulnerabilities.find((v) => v.package === 'cross-spawn');
+      expect(crossSpawn?.id).toBe('CVE-2024-21538');
+      expect(crossSpawn?.description).toBe('cross-spawn: regular expression denial of service');
+    });
+
+    it('should handle Grype output without matches', () => {
+      const empty = parseGrypeOutput({ matches: [] }, 'scratch:1.0');
+      expect(empty.totalVulnerabilities).toBe(0);
+      expect(empty.unknownCount).toBe(0);
+    });
+  });
+
+  describe('scanner selection', () => {
+    const scanResult = { imageId: 'myapp:1.0', vulnerabilities: [] } as unknown as BasicScanResult;
+
+    beforeEach(() => {
+      jest.clearAllMocks();
+      mockScanImageWithTrivy.mockResolvedValue(Success({ ...scanResult, scanner: 'trivy' }));
+      mockScanImageWithGrype.mockResolvedValue(Success({ ...scanResult, scanner: 'grype' }));
+    });
+
+    it('should use Grype when explicitly selected', async () => {
+      const scanner = createSecurityScanner(createMockLogger(), 'grype');
+
+      const result = await scanner.scanImage('myapp:1.0');
+
+      expect(result.ok && result.value.scanner).toBe('grype');
+      expect(mockScanImageWithTrivy).not.toHaveBeenCalled();
+    });
+
+    it('should prefer Trivy in auto mode when both are installed', async () => {
+      mockCheckTrivyAvailability.mockResolvedValue(Success('0.48.0'));
+      mockCheckGrypeAvailability.mockResolvedValue(Success('0.74.0'));
+
+      const result = await createSecurityScanner(createMockLogger(), 'auto').scanImage('myapp:1.0');
+
+      expect(result.ok && result.value.scanner).toBe('trivy');
+    });
+
+    it('should fall back to Grype in auto mode when Trivy is missing', async () => {
+      mockCheckTrivyAvailability.mockResolvedValue(Failure('Trivy not installed or not in PATH'));
+      mockCheckGrypeAvailability.mockResolvedValue(Success('0.74.0'));
+
+      const result = await createSecurityScanner(createMockLogger(), 'auto').scanImage('myapp:1.0');
+
+      expect(result.ok && result.value.scanner).toBe('grype');
+      expect(mockScanImageWithTrivy).not.toHaveBeenCalled();
+    });
+
+    it('should fail with install guidance in auto mode when neither is installed', async () => {
+      mockCheckTrivyAvailability.mockResolvedValue(Failure('Trivy not installed or not in PATH'));
+      mockCheckGrypeAvailability.mockResolvedValue(Failure('Grype not installed or not in PATH'));
+
+      const result = await createSecurityScanner(createMockLogger(), 'auto').scanImage('myapp:1.0');
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('Trivy or Grype');
+        expect(result.guidance?.resolution).toContain('grype');
+      }
+    });
+
+    it('should check Trivy once and attach version warnings to results', async () => {
+      mockCheckTrivyAvailability.mockResolvedValue(Success('0.45.0'));
+      const scanner = createSecurityScanner(createMockLogger(), 'trivy', { autoInstall: false });
+
+      await scanner.scanImage('myapp:1.0');
+      const result = await scanner.scanImage('myapp:1.0');
+
+      expect(mockCheckTrivyAvailability).toHaveBeenCalledTimes(1);
+      expect(mockScanImageWithTrivy).toHaveBeenCalledWith(
+        'myapp:1.0',
+        expect.anything(),
+        '0.45.0',
+        'trivy',
+      );
+      expect(result.ok && result.value.warnings?.[0]).toContain('older than the minimum');
+    });
+
+    it('should fail with SCANNER_UNAVAILABLE when Trivy is missing', async () => {
+      mockCheckTrivyAvailability.mockResolvedValue(Failure('Trivy not installed or not in PATH'));
+      const scanner = createSecurityScanner(createMockLogger(), 'trivy', { autoInstall: false });
+
+      const result = await scanner.scanImage('myapp:1.0');
+
+      expect(!result.ok && result.guidance?.details?.code).toBe('SCANNER_UNAVAILABLE');
+      expect(mockScanImageWithTrivy).not.toHaveBeenCalled();
+    });
+  });
+});
@@ -512,6 +512,48 @@ describe('Trivy Scanner', () => {
       }
     });
 
+    it('should run trivy from the unmodified PATH when auto-install is disabled', async () => {
+      const { createSecurityScanner } = await import('@/infra/security/scanner');
+
+      mockExecAsync.mockResolvedValue({ stdout: 'Version: 0.58.1\n', stderr: '' });
+      mockExecFileAsync.mockResolvedValueOnce({
+        stdout: JSON.stringify({ SchemaVersion: 2, ArtifactName: 'test:latest', Results: [] }),
+        stderr: '',
+      });
+
+      const scanner = createSecurityScanner(mockLogger, 'trivy', { autoInstall: false });
+      const result = await scanner.scanImage('test:latest');
+
+      expect(result.ok).toBe(true);
+      expect(mockExecAsync).toHaveBeenCalledWith('trivy --version', expect.anything());
+      expect(mockExecFileAsync).toHaveBeenCalledWith(
+        'trivy',
+        expect.arrayContaining(['image', 'test:latest']),
+        expect.anything(),
+      );
+      // No PATH override, so nothing in the workspace can shadow the real trivy
+      const options = [
+        ...mockExecAsync.mock.calls.map(([, opts]) => opts),
+        ...mockExecFileAsync.mock.calls.map(([, , opts]) => opts),
+      ] as Array<{ env?: NodeJS.ProcessEnv }>;
+      expect(options.every((opts) => opts.env === undefined)).toBe(true);
+    });
+
+    it('should run an installed trivy by its absolute path', async () => {
+      const binary = '/work space/.containerization-assist/bin/trivy';
+      mockExecAsync.mockResolvedValue({ stdout: 'Version: 0.58.1\n', stderr: '' });
+      mockExecFileAsync.mockResolvedValueOnce({
+        stdout: JSON.stringify({ SchemaVersion: 2, ArtifactName: 'test:latest', Results: [] }),
+        stderr: '',
+      });
+
+      const result = await scanImageWithTrivy('test:latest', mockLogger, undefined, binary);
+
+      expect(result.ok).toBe(true);
+      expect(mockExecAsync).toHaveBeenCalledWith(`'${binary}' --version`, expect.anything());
+      expect(mockExecFileAsync).toHaveBeenCalledWith(binary, expect.any(Array), expect.anything());
+    });
+
     it('should fail ping when Trivy is not available', async () => {
       const { createSecurityScanner } = await import('@/infra/security/scanner');
 
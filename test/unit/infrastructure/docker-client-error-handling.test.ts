@@ -11,7 +11,9 @@ jest.mock('dockerode');
 
 // Mock socket validation
 jest.mock('../../../src/infra/docker/socket-validation', () => ({
-  autoDetectDockerSocket: jest.fn(() => '/var/run/docker.sock')
+  autoDetectDockerSocket: jest.fn(() => '/var/run/docker.sock'),
+  getDefaultDockerSockets: jest.fn(() => ['/var/run/docker.sock']),
+  findAvailableSocket: jest.fn(() => '/var/run/docker.sock'),
 }));
 
 describe('Docker Client Enhanced Error Handling', () => {
@@ -0,0 +1,191 @@
+/**
+ * Tests for the tool execution audit log
+ */
+
+import { promises as fs } from 'node:fs';
+import * as os from 'node:os';
+import * as path from 'node:path';
+import {
+  createAuditLog,
+  createJsonlAuditSink,
+  createMemoryAuditSink,
+  type AuditRecord,
+  type AuditSink,
+} from '@/lib/audit';
+
+const record = (tool: string): AuditRecord => ({
+  timestamp: '2025-01-01T00:00:00.000Z',
+  tool,
+  argsDigest: 'abc123',
+  durationMs: 5,
+  outcome: 'success',
+});
+
+/** Record for a session, started the given number of minutes after midnight */
+const call = (sessionId: string, tool: string, minute: number): AuditRecord => ({
+  ...record(tool),
+  sessionId,
+  timestamp: `2025-01-01T00:${String(minute).padStart(2, '0')}:00.000Z`,
+});
+
+/**
+ * Sink that keeps records in memory and can be paused
+ */
+function createFakeSink() {
+  const written: AuditRecord[] = [];
+  let release: () => void = () => {};
+  let paused: Promise<void> | undefined;
+
+  const sink: AuditSink = {
+    async write(records) {
+      if (paused) await paused;
+      written.push(...records);
+    },
+  };
+  return {
+    sink,
+    written,
+    pause: () => {
+      paused = new Promise((resolve) => (release = resolve));
+    },
+    resume: () => {
+      paused = undefined;
+      release();
+    },
+  };
+}
+
+describe('audit log', () => {
+  it('should hand records to the sink in the background', async () => {
+    const { sink, written } = createFakeSink();
+    const audit = createAuditLog(sink);
+
+    audit.record(record('analyze-repo'));
+    audit.record(record('build-image'));
+    expect(written).toEqual([]);
+
+    await audit.flush();
+    expect(written.map((r) => r.tool)).toEqual(['analyze-repo', 'build-image']);
+    expect(audit.droppedCount()).toBe(0);
+  });
+
+  it('should drop and count records while the buffer is full', async () => {
+    const fake = createFakeSink();
+    const audit = createAuditLog(fake.sink, { maxPending: 2 });
+
+    fake.pause();
+    audit.record(record('a'));
+    // Let the first batch reach the paused sink
+    await new Promise((resolve) => setImmediate(resolve));
+    audit.record(record('b'));
+    audit.record(record('c'));
+    audit.record(record('d'));
+    expect(audit.droppedCount()).toBe(1);
+
+    fake.resume();
+    await audit.flush();
+    expect(fake.written.map((r) => r.tool)).toEqual(['a', 'b', 'c']);
+  });
+
+  it('should count records the sink fails to write without throwing', async () => {
+    const audit = createAuditLog({ write: jest.fn().mockRejectedValue(new Error('disk full')) });
+
+    expect(() => audit.record(record('a'))).not.toThrow();
+    await audit.flush();
+
+    expect(audit.droppedCount()).toBe(1);
+  });
+
+  describe('trace', () => {
+    it('should return executions in start order, including unflushed ones', async () => {
+      const audit = createAuditLog(createMemoryAuditSink());
+      audit.record(call('s1', 'build-image', 2));
+      audit.record(call('s2', 'ops', 1));
+      // Finished after build-image but started before it
+      audit.record(call('s1', 'analyze-repo', 1));
+
+      const result = await audit.trace({ sessionId: 's1' });
+
+      expect(result.ok).toBe(true);
+      if (!result.ok) return;
+      expect(result.value.entries.map((r) => r.tool)).toEqual(['analyze-repo', 'build-image']);
+      expect(result.value).toMatchObject({ sessionId: 's1', total: 2, truncated: false });
+    });
+
+    it('should keep the most recent entries up to the limit and apply since', async () => {
+      const audit = createAuditLog(createMemoryAuditSink());
+      for (let minute = 0; minute < 10; minute++) audit.record(call('s1', `t${minute}`, minute));
+
+      const limited = await audit.trace({ sessionId: 's1', limit: 3 });
+      const since = await audit.trace({ sessionId: 's1', since: '2025-01-01T00:08:00Z' });
+
+      expect(limited.ok && limited.value.entries.map((r) => r.tool)).toEqual(['t7', 't8', 't9']);
+      expect(limited.ok && limited.value).toMatchObject({ total: 10, truncated: true });
+      expect(since.ok && since.value.entries.map((r) => r.tool)).toEqual(['t8', 't9']);
+    });
+
+    it('should fail for an invalid since time or a write-only sink', async () => {
+      const readable = createAuditLog(createMemoryAuditSink());
+      const writeOnly = createAuditLog({ write: async () => {} });
+
+      const badSince = await readable.trace({ sessionId: 's1', since: 'yesterday' });
+      const unreadable = await writeOnly.trace({ sessionId: 's1' });
+
+      expect(!badSince.ok && badSince.error).toBe('Invalid since time: yesterday');
+      expect(!unreadable.ok && unreadable.error).toBe('The audit sink cannot be read back');
+    });
+  });
+
+  describe('createJsonlAuditSink', () => {
+    let tempDir: string;
+
+    beforeEach(async () => {
+      tempDir = await fs.mkdtemp(path.join(os.tmpdir(), 'audit-test-'));
+    });
+
+    afterEach(async () => {
+      await fs.rm(tempDir, { recursive: true, force: true });
+    });
+
+    it('should append one JSON object per line', async () => {
+      const file = path.join(tempDir, 'logs', 'audit.jsonl');
+      const sink = createJsonlAuditSink(file);
+
+      await sink.write([record('a')]);
+      await sink.write([record('b'), record('c')]);
+
+      const lines = (await fs.readFile(file, 'utf-8')).trim().split('\n');
+      expect(lines.map((line) => JSON.parse(line).tool)).toEqual(['a', 'b', 'c']);
+    });
+
+    it('should read back one session\'s records and skip malformed lines', async () => {
+      const file = path.join(tempDir, 'audit.jsonl');
+      const audit = createAuditLog(createJsonlAuditSink(file));
+      audit.record(call('s1', 'analyze-repo', 0));
+      audit.record(call('s2', 'analyze-repo', 1));
+      await audit.flush();
+      await fs.appendFile(file, '{"sessionId":"s1", truncated\n');
+      audit.record(call('s1', 'build-image', 2));
+
+      const result = await audit.trace({ sessionId: 's1' });
+
+      expect(result.ok && result.value.entries.map((r) => r.tool)).toEqual([
+        'analyze-repo',
+        'build-image',
+      ]);
+    });
+
+    it('should return an empty trace before anything was written', async () => {
+      const audit = createAuditLog(createJsonlAuditSink(path.join(tempDir, 'none.jsonl')));
+
+      const result = await audit.trace({ sessionId: 's1' });
+
+      expect(result.ok && result.value).toEqual({
+        sessionId: 's1',
+        entries: [],
+        total: 0,
+        truncated: false,
+      });
+    });
+  });
+});
@@ -0,0 +1,115 @@
+/**
+ * Tests for the per-tool circuit breaker
+ */
+
+import { createCircuitBreaker, CIRCUIT_OPEN } from '@/lib/circuit-breaker';
+
+/**
+ * Breaker with a manual clock
+ */
+function createTestBreaker(failureThreshold = 3, cooldownMs = 1000) {
+  let time = 0;
+  const breaker = createCircuitBreaker({ failureThreshold, cooldownMs, now: () => time });
+  return { breaker, advance: (ms: number) => (time += ms) };
+}
+
+describe('circuit breaker', () => {
+  it('should reject non-positive thresholds', () => {
+    expect(() => createCircuitBreaker({ failureThreshold: 0 })).toThrow('positive integer');
+  });
+
+  it('should go closed -> open -> half-open -> closed', () => {
+    const { breaker, advance } = createTestBreaker();
+
+    // Closed: failures below the threshold keep the circuit closed
+    for (let i = 0; i < 2; i++) {
+      expect(breaker.acquire('build-image').ok).toBe(true);
+      breaker.recordFailure('build-image');
+    }
+    expect(breaker.state('build-image')).toBe('closed');
+
+    // Open: the third consecutive failure trips the circuit
+    expect(breaker.acquire('build-image').ok).toBe(true);
+    breaker.recordFailure('build-image');
+    expect(breaker.state('build-image')).toBe('open');
+
+    const rejected = breaker.acquire('build-image');
+    expect(rejected.ok).toBe(false);
+    if (!rejected.ok) {
+      expect(rejected.guidance?.details).toMatchObject({
+        code: CIRCUIT_OPEN,
+        tool: 'build-image',
+        state: 'open',
+        consecutiveFailures: 3,
+        retryAfterMs: 1000,
+      });
+    }
+
+    // Half-open: one probe is allowed after the cool-down, others are rejected
+    advance(1000);
+    expect(breaker.state('build-image')).toBe('half-open');
+    expect(breaker.acquire('build-image').ok).toBe(true);
+    const concurrent = breaker.acquire('build-image');
+    expect(concurrent.ok).toBe(false);
+    if (!concurrent.ok) expect(concurrent.guidance?.details?.state).toBe('half-open');
+
+    // Closed: a successful probe closes the circuit and resets the count
+    breaker.recordSuccess('build-image');
+    expect(breaker.state('build-image')).toBe('closed');
+    breaker.recordFailure('build-image');
+    expect(breaker.state('build-image')).toBe('closed');
+  });
+
+  it('should reopen when the half-open probe fails', () => {
+    const { breaker, advance } = createTestBreaker(1);
+
+    breaker.acquire('push-image');
+    breaker.recordFailure('push-image');
+    advance(1000);
+    expect(breaker.acquire('push-image').ok).toBe(true);
+    breaker.recordFailure('push-image');
+
+    expect(breaker.state('push-image')).toBe('open');
+    advance(999);
+    expect(breaker.acquire('push-image').ok).toBe(false);
+    advance(1);
+    expect(breaker.acquire('push-image').ok).toBe(true);
+  });
+
+  it('should let another probe through after a cancelled one is released', () => {
+    const { breaker, advance } = createTestBreaker(1);
+    breaker.acquire('build-image');
+    breaker.recordFailure('build-image');
+    advance(1000);
+
+    expect(breaker.acquire('build-image').ok).toBe(true);
+    expect(breaker.acquire('build-image').ok).toBe(false);
+    breaker.release('build-image');
+
+    expect(breaker.state('build-image')).toBe('half-open');
+    expect(breaker.acquire('build-image').ok).toBe(true);
+  });
+
+  it('should reset the failure count on success', () => {
+    const { breaker } = createTestBreaker();
+
+    breaker.recordFailure('build-image');
+    breaker.recordFailure('build-image');
+    breaker.recordSuccess('build-image');
+    breaker.recordFailure('build-image');
+    breaker.recordFailure('build-image');
+
+    expect(breaker.state('build-image')).toBe('closed');
+  });
+
+  it('should track tools separately', () => {
+    const { breaker } = createTestBreaker(1);
+
+    breaker.recordFailure('build-image');
+
+    expect(breaker.acquire('build-image').ok).toBe(false);
+    expect(breaker.acquire('analyze-repo').ok).toBe(true);
+    breaker.reset();
+    expect(breaker.state('build-image')).toBe('closed');
+  });
+});
@@ -0,0 +1,156 @@
+/**
+ * Tests for concurrency utilities
+ */
+
+import { createSemaphore, QUEUE_FULL } from '@/lib/concurrency';
+
+describe('concurrency', () => {
+  describe('createSemaphore', () => {
+    it('should reject non-positive limits', () => {
+      expect(() => createSemaphore(0)).toThrow('positive integer');
+      expect(() => createSemaphore(-1)).toThrow('positive integer');
+      expect(() => createSemaphore(1.5)).toThrow('positive integer');
+    });
+
+    it('should grant slots up to the limit immediately', async () => {
+      const semaphore = createSemaphore(2);
+
+      const first = await semaphore.acquire();
+      const second = await semaphore.acquire();
+
+      expect(first.ok && second.ok).toBe(true);
+      expect(semaphore.activeCount()).toBe(2);
+      expect(semaphore.queueDepth()).toBe(0);
+    });
+
+    it('should queue callers beyond the limit and serve them in order', async () => {
+      const semaphore = createSemaphore(1);
+      const order: string[] = [];
+
+      const first = await semaphore.acquire();
+      const secondPromise = semaphore.acquire().then((r) => {
+        order.push('second');
+        return r;
+      });
+      const thirdPromise = semaphore.acquire().then((r) => {
+        order.push('third');
+        return r;
+      });
+
+      expect(semaphore.queueDepth()).toBe(2);
+
+      if (first.ok) first.value();
+      const second = await secondPromise;
+      expect(semaphore.queueDepth()).toBe(1);
+
+      if (second.ok) second.value();
+      const third = await thirdPromise;
+      if (third.ok) third.value();
+
+      expect(order).toEqual(['second', 'third']);
+      expect(semaphore.activeCount()).toBe(0);
+      expect(semaphore.queueDepth()).toBe(0);
+    });
+
+    it('should ignore repeated release calls', async () => {
+      const semaphore = createSemaphore(1);
+      const slot = await semaphore.acquire();
+
+      if (slot.ok) {
+        slot.value();
+        slot.value();
+      }
+
+      expect(semaphore.activeCount()).toBe(0);
+    });
+
+    it('should fail queued callers when their signal aborts', async () => {
+      const semaphore = createSemaphore(1);
+      const controller = new AbortController();
+
+      const held = await semaphore.acquire();
+      const queued = semaphore.acquire(controller.signal);
+      expect(semaphore.queueDepth()).toBe(1);
+
+      controller.abort();
+      const result = await queued;
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('cancelled');
+      }
+      expect(semaphore.queueDepth()).toBe(0);
+
+      if (held.ok) held.value();
+      expect(semaphore.activeCount()).toBe(0);
+    });
+
+    it('should fail immediately when the signal is already aborted', async () => {
+      const semaphore = createSemaphore(1);
+      const controller = new AbortController();
+      controller.abort();
+
+      const result = await semaphore.acquire(controller.signal);
+
+      expect(result.ok).toBe(false);
+      expect(semaphore.activeCount()).toBe(0);
+    });
+
+    it('should reject negative maxQueue values', () => {
+      expect(() => createSemaphore(1, { maxQueue: -1 })).toThrow('maxQueue');
+    });
+
+    it('should reject callers beyond maxQueue and accept them once the queue drains', async () => {
+      const semaphore = createSemaphore(1, { maxQueue: 2 });
+
+      const held = await semaphore.acquire();
+      const queued = [semaphore.acquire(), semaphore.acquire()];
+      const rejected = await semaphore.acquire();
+
+      expect(rejected.ok).toBe(false);
+      if (!rejected.ok) {
+        expect(rejected.guidance?.details).toEqual({
+          code: QUEUE_FULL,
+          queueDepth: 2,
+          maxQueue: 2,
+          limit: 1,
+        });
+        expect(rejected.guidance?.retryable).toBe(true);
+      }
+      expect(semaphore.queueDepth()).toBe(2);
+
+      // Releasing hands the slot to the first waiter and frees a queue place
+      if (held.ok) held.value();
+      const first = await queued[0];
+      expect(semaphore.queueDepth()).toBe(1);
+
+      const accepted = semaphore.acquire();
+      expect(semaphore.queueDepth()).toBe(2);
+
+      if (first?.ok) first.value();
+      const second = await queued[1];
+      if (second?.ok) second.value();
+      const third = await accepted;
+      if (third.ok) third.value();
+
+      expect(third.ok).toBe(true);
+      expect(semaphore.activeCount()).toBe(0);
+    });
+
+    it('should keep waiting without a maxQueue', async () => {
+      const semaphore = createSemaphore(1);
+
+      const held = await semaphore.acquire();
+      const waiting = Array.from({ length: 50 }, () => semaphore.acquire());
+
+      expect(semaphore.queueDepth()).toBe(50);
+
+      if (held.ok) held.value();
+      for (const pending of waiting) {
+        const slot = await pending;
+        if (slot.ok) slot.value();
+      }
+      expect(semaphore.activeCount()).toBe(0);
+    });
+  });
+});
@@ -0,0 +1,161 @@
+/**
+ * Tests for image reference parsing
+ */
+
+import {
+  formatImageRef,
+  imageRefName,
+  isImageId,
+  normalizeImageRef,
+  parseImageRef,
+  type ImageRef,
+} from '@/lib/image-ref';
+
+const DIGEST = `sha256:${'a1b2c3d4'.repeat(8)}`;
+
+describe('image-ref', () => {
+  describe('parseImageRef', () => {
+    it.each<[string, ImageRef, string]>([
+      ['ubuntu', { repository: 'ubuntu', tag: 'latest' }, 'docker.io/library/ubuntu:latest'],
+      ['ubuntu:22.04', { repository: 'ubuntu', tag: '22.04' }, 'docker.io/library/ubuntu:22.04'],
+      ['myorg/app', { repository: 'myorg/app', tag: 'latest' }, 'docker.io/myorg/app:latest'],
+      [
+        'docker.io/library/node:20',
+        { registry: 'docker.io', repository: 'library/node', tag: '20' },
+        'docker.io/library/node:20',
+      ],
+      [
+        'index.docker.io/nginx',
+        { registry: 'index.docker.io', repository: 'nginx', tag: 'latest' },
+        'docker.io/library/nginx:latest',
+      ],
+      [
+        'localhost:5000/foo',
+        { registry: 'localhost:5000', repository: 'foo', tag: 'latest' },
+        'localhost:5000/foo:latest',
+      ],
+      [
+        'localhost:5000/foo:1.2',
+        { registry: 'localhost:5000', repository: 'foo', tag: '1.2' },
+        'localhost:5000/foo:1.2',
+      ],
+      [
+        'localhost/myapp:dev',
+        { registry: 'localhost', repository: 'myapp', tag: 'dev' },
+        'localhost/myapp:dev',
+      ],
+      [
+        'registry.example.com:443/team/sub/app:1.0-rc.1',
+        { registry: 'registry.example.com:443', repository: 'team/sub/app', tag: '1.0-rc.1' },
+        'registry.example.com:443/team/sub/app:1.0-rc.1',
+      ],
+      [
+        `ubuntu@${DIGEST}`,
+        { repository: 'ubuntu', digest: DIGEST },
+        `docker.io/library/ubuntu@${DIGEST}`,
+      ],
+      [
+        `ghcr.io/acme/api:v2@${DIGEST}`,
+        { registry: 'ghcr.io', repository: 'acme/api', tag: 'v2', digest: DIGEST },
+        `ghcr.io/acme/api:v2@${DIGEST}`,
+      ],
+      [
+        `localhost:5000/foo@${DIGEST}`,
+        { registry: 'localhost:5000', repository: 'foo', digest: DIGEST },
+        `localhost:5000/foo@${DIGEST}`,
+      ],
+      [
+        'node:sha256-abc123',
+        { repository: 'node', tag: 'sha256-abc123' },
+        'docker.io/library/node:sha256-abc123',
+      ],
+      [
+        'my_app__v2/web--ui',
+        { repository: 'my_app__v2/web--ui', tag: 'latest' },
+        'docker.io/my_app__v2/web--ui:latest',
+      ],
+      ['  nginx:1.27  ', { repository: 'nginx', tag: '1.27' }, 'docker.io/library/nginx:1.27'],
+    ])('should parse %s', (reference, expected, normalized) => {
+      const result = parseImageRef(reference);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value).toEqual(expected);
+        expect(normalizeImageRef(result.value)).toBe(normalized);
+      }
+    });
+
+    it.each([
+      ['', 'empty'],
+      ['   ', 'empty'],
+      ['Ubuntu', 'lowercase'],
+      ['myorg/App:1.0', 'lowercase'],
+      ['/app', 'repository component'],
+      ['app/', 'repository component'],
+      ['-app', 'repository component'],
+      ['my...app', 'repository component'],
+      ['localhost:5000/', 'repository is required'],
+      ['node:', 'invalid tag'],
+      ['node:invalid tag!', 'invalid tag'],
+      ['node:-dash', 'invalid tag'],
+      [`node:${'a'.repeat(129)}`, '128'],
+      ['node@sha256:abc123', 'malformed digest'],
+      ['node@sha256', 'malformed digest'],
+      ['my_registry.io:5000/app', 'malformed registry host'],
+      [`registry.io/${'a'.repeat(250)}`, '255'],
+    ])('should reject %j', (reference, reason) => {
+      const result = parseImageRef(reference);
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(`${result.error} ${result.guidance?.hint ?? ''}`).toContain(reason);
+      }
+    });
+  });
+
+  describe('formatImageRef', () => {
+    it('should round-trip references as written, adding the implicit tag', () => {
+      const formatted = ['ubuntu', 'localhost:5000/foo:dev', `ghcr.io/acme/api@${DIGEST}`].map(
+        (reference) => {
+          const result = parseImageRef(reference);
+          return result.ok ? formatImageRef(result.value) : result.error;
+        },
+      );
+
+      expect(formatted).toEqual([
+        'ubuntu:latest',
+        'localhost:5000/foo:dev',
+        `ghcr.io/acme/api@${DIGEST}`,
+      ]);
+    });
+
+    it('should give the repository Docker expects for tagging', () => {
+      expect(imageRefName({ registry: 'localhost:5000', repository: 'foo', tag: 'dev' })).toBe(
+        'localhost:5000/foo',
+      );
+      expect(imageRefName({ repository: 'myorg/app', tag: 'latest' })).toBe('myorg/app');
+    });
+  });
+
+  describe('normalizeImageRef', () => {
+    it('should make references to the same image compare equal', () => {
+      const normalized = ['nginx', 'docker.io/nginx:latest', 'index.docker.io/library/nginx'].map(
+        (reference) => {
+          const result = parseImageRef(reference);
+          return result.ok ? normalizeImageRef(result.value) : result.error;
+        },
+      );
+
+      expect(new Set(normalized)).toEqual(new Set(['docker.io/library/nginx:latest']));
+    });
+  });
+
+  describe('isImageId', () => {
+    it('should recognise full and short image IDs only', () => {
+      expect(isImageId(DIGEST)).toBe(true);
+      expect(isImageId('a1b2c3d4a1b2')).toBe(true);
+      expect(isImageId('ubuntu')).toBe(false);
+      expect(isImageId('sha256:mock-image-id')).toBe(false);
+    });
+  });
+});
@@ -113,6 +113,11 @@ describe('regex-patterns', () => {
       expect('pom.xml').toMatch(PACKAGE_FILES);
     });
 
+    it('should match deno.json', () => {
+      expect('deno.json').toMatch(PACKAGE_FILES);
+      expect('deno.jsonc').toMatch(PACKAGE_FILES);
+    });
+
     it('should not match unrelated files', () => {
       expect('index.js').not.toMatch(PACKAGE_FILES);
       expect('README.md').not.toMatch(PACKAGE_FILES);
@@ -0,0 +1,67 @@
+/**
+ * Tests for the tool result cache
+ */
+
+import { createResultCache, digestArgs } from '@/lib/result-cache';
+
+describe('result cache', () => {
+  describe('digestArgs', () => {
+    it('should not depend on key order or undefined fields', () => {
+      expect(digestArgs({ path: '/repo', depth: 2 })).toBe(
+        digestArgs({ depth: 2, path: '/repo', extra: undefined }),
+      );
+      expect(digestArgs({ path: '/repo' })).not.toBe(digestArgs({ path: '/other' }));
+      expect(digestArgs({ tags: ['a', 'b'] })).not.toBe(digestArgs({ tags: ['b', 'a'] }));
+    });
+  });
+
+  it('should return values for identical arguments until the TTL expires', () => {
+    let time = 0;
+    const cache = createResultCache({ now: () => time });
+
+    cache.set('analyze-repo', { repositoryPath: '/repo' }, { language: 'go' }, 1000);
+
+    expect(cache.get('analyze-repo', { repositoryPath: '/repo' })).toEqual({
+      value: { language: 'go' },
+    });
+    expect(cache.get('analyze-repo', { repositoryPath: '/other' })).toBeUndefined();
+    expect(cache.get('generate-dockerfile', { repositoryPath: '/repo' })).toBeUndefined();
+
+    time += 1000;
+    expect(cache.get('analyze-repo', { repositoryPath: '/repo' })).toBeUndefined();
+    expect(cache.size()).toBe(0);
+  });
+
+  it('should prefer per-tool TTL overrides and treat 0 as not cacheable', () => {
+    const cache = createResultCache({ ttlMs: { 'analyze-repo': 60_000, 'verify-deploy': 0 } });
+
+    expect(cache.ttlFor('analyze-repo', 300_000)).toBe(60_000);
+    expect(cache.ttlFor('verify-deploy', 300_000)).toBeUndefined();
+    expect(cache.ttlFor('optimize-dockerfile', 1000)).toBe(1000);
+    expect(cache.ttlFor('deploy')).toBeUndefined();
+  });
+
+  it('should evict the oldest entry beyond maxEntries', () => {
+    const cache = createResultCache({ maxEntries: 2 });
+
+    cache.set('tool', { n: 1 }, 1, 1000);
+    cache.set('tool', { n: 2 }, 2, 1000);
+    cache.set('tool', { n: 3 }, 3, 1000);
+
+    expect(cache.get('tool', { n: 1 })).toBeUndefined();
+    expect(cache.get('tool', { n: 3 })).toEqual({ value: 3 });
+  });
+
+  it('should invalidate one tool or everything', () => {
+    const cache = createResultCache();
+    cache.set('a', {}, 1, 1000);
+    cache.set('b', {}, 2, 1000);
+
+    cache.invalidate('a');
+    expect(cache.get('a', {})).toBeUndefined();
+    expect(cache.get('b', {})).toEqual({ value: 2 });
+
+    cache.invalidate();
+    expect(cache.size()).toBe(0);
+  });
+});
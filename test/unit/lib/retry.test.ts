@@ -0,0 +1,145 @@
+/**
+ * Tests for retry utilities
+ */
+
+import {
+  withRetry,
+  backoffDelay,
+  isTransientFailure,
+  DEFAULT_RETRY_POLICY,
+  type RetryPolicy,
+} from '@/lib/retry';
+import { Success, Failure, type Result } from '@/types';
+
+const fastPolicy: RetryPolicy = { maxAttempts: 3, baseDelayMs: 1, multiplier: 2 };
+
+/**
+ * Operation that fails with the given results first, then succeeds
+ */
+function failingThenSucceeding(failures: Result<string>[]) {
+  const attempts: number[] = [];
+  const operation = async (attempt: number): Promise<Result<string>> => {
+    attempts.push(attempt);
+    return failures[attempt - 1] ?? Success('done');
+  };
+  return { operation, attempts };
+}
+
+describe('retry', () => {
+  describe('isTransientFailure', () => {
+    it('should classify network error codes and 5xx/429 status codes as transient', () => {
+      const transient = (details: Record<string, unknown>) =>
+        isTransientFailure(Failure('failed', { message: 'failed', details }));
+
+      expect(transient({ code: 'ECONNRESET' })).toBe(true);
+      expect(transient({ code: 'EAI_AGAIN' })).toBe(true);
+      expect(transient({ statusCode: 503 })).toBe(true);
+      expect(transient({ statusCode: 429 })).toBe(true);
+      expect(transient({ statusCode: 404 })).toBe(false);
+      expect(transient({ code: 'ENOENT' })).toBe(false);
+    });
+
+    it('should let an explicit retryable flag override the heuristics', () => {
+      expect(
+        isTransientFailure(
+          Failure('connect ETIMEDOUT', {
+            message: 'timed out',
+            details: { code: 'ETIMEDOUT' },
+            retryable: false,
+          }),
+        ),
+      ).toBe(false);
+      expect(
+        isTransientFailure(Failure('lock held', { message: 'lock held', retryable: true })),
+      ).toBe(true);
+    });
+
+    it('should fall back to the error message', () => {
+      expect(isTransientFailure(Failure('registry returned 503 Service Unavailable'))).toBe(true);
+      expect(isTransientFailure(Failure('socket hang up'))).toBe(true);
+      expect(isTransientFailure(Failure('Dockerfile not found'))).toBe(false);
+    });
+  });
+
+  describe('backoffDelay', () => {
+    it('should grow exponentially and stop at the max delay', () => {
+      expect([1, 2, 3, 4, 5].map((retry) => backoffDelay(DEFAULT_RETRY_POLICY, retry))).toEqual([
+        1000, 2000, 4000, 8000, 10_000,
+      ]);
+    });
+  });
+
+  describe('withRetry', () => {
+    it('should retry transient failures until the operation succeeds', async () => {
+      const { operation, attempts } = failingThenSucceeding([
+        Failure('connect ETIMEDOUT'),
+        Failure('connect ETIMEDOUT'),
+      ]);
+      const onRetry = jest.fn();
+
+      const result = await withRetry(operation, fastPolicy, { onRetry });
+
+      expect(result).toEqual(Success('done'));
+      expect(attempts).toEqual([1, 2, 3]);
+      expect(onRetry.mock.calls.map(([, attempt, delayMs]) => [attempt, delayMs])).toEqual([
+        [1, 1],
+        [2, 2],
+      ]);
+    });
+
+    it('should return a non-retryable failure after one attempt', async () => {
+      const { operation, attempts } = failingThenSucceeding([Failure('Invalid image name')]);
+
+      const result = await withRetry(operation, fastPolicy);
+
+      expect(result).toEqual(Failure('Invalid image name'));
+      expect(attempts).toEqual([1]);
+    });
+
+    it('should return the last failure with the attempt count once attempts run out', async () => {
+      const { operation, attempts } = failingThenSucceeding([
+        Failure('socket hang up'),
+        Failure('socket hang up'),
+        Failure('socket hang up'),
+      ]);
+
+      const result = await withRetry(operation, fastPolicy);
+
+      expect(attempts).toEqual([1, 2, 3]);
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toBe('socket hang up');
+        expect(result.guidance?.details).toEqual({ attempts: 3 });
+      }
+    });
+
+    it('should use a custom retryable predicate', async () => {
+      const { operation, attempts } = failingThenSucceeding([Failure('lock held')]);
+
+      const result = await withRetry(operation, {
+        ...fastPolicy,
+        retryable: (failure) => failure.error === 'lock held',
+      });
+
+      expect(result.ok).toBe(true);
+      expect(attempts).toEqual([1, 2]);
+    });
+
+    it('should stop waiting when the signal aborts', async () => {
+      const controller = new AbortController();
+      const { operation, attempts } = failingThenSucceeding([Failure('connect ECONNRESET')]);
+      const startTime = Date.now();
+
+      const pending = withRetry(
+        operation,
+        { ...fastPolicy, baseDelayMs: 10_000 },
+        { signal: controller.signal, onRetry: () => controller.abort() },
+      );
+      const result = await pending;
+
+      expect(result.ok).toBe(false);
+      expect(attempts).toEqual([1]);
+      expect(Date.now() - startTime).toBeLessThan(1000);
+    });
+  });
+});
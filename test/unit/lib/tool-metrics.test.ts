@@ -0,0 +1,67 @@
+/**
+ * Tests for tool execution metrics
+ */
+
+import {
+  createToolMetrics,
+  TOOL_DURATION_BUCKETS,
+  TOOL_DURATION_METRIC,
+  TOOL_EXECUTIONS_METRIC,
+} from '@/lib/tool-metrics';
+
+describe('tool-metrics', () => {
+  it('should record durations into cumulative buckets', () => {
+    const metrics = createToolMetrics();
+
+    metrics.record('build-image', 'success', 200);
+    metrics.record('build-image', 'success', 90_000);
+
+    const [sample] = metrics.snapshot();
+    expect(sample?.tool).toBe('build-image');
+    expect(sample?.status).toBe('success');
+    expect(sample?.duration.count).toBe(2);
+    expect(sample?.duration.sum).toBeCloseTo(90.2);
+
+    const idx = (bound: number): number => TOOL_DURATION_BUCKETS.indexOf(bound as never);
+    expect(sample?.duration.buckets[idx(0.1)]).toBe(0);
+    expect(sample?.duration.buckets[idx(0.25)]).toBe(1);
+    expect(sample?.duration.buckets[idx(60)]).toBe(1);
+    expect(sample?.duration.buckets[idx(120)]).toBe(2);
+  });
+
+  it('should keep separate series per tool and status', () => {
+    const metrics = createToolMetrics();
+
+    metrics.record('scan-image', 'success', 10);
+    metrics.record('scan-image', 'failure', 10);
+    metrics.record('tag-image', 'success', 10);
+
+    expect(metrics.snapshot()).toHaveLength(3);
+  });
+
+  it('should render Prometheus exposition format', () => {
+    const metrics = createToolMetrics();
+    metrics.record('tag-image', 'failure', 1500);
+
+    const output = metrics.render();
+
+    expect(output).toContain(`# TYPE ${TOOL_DURATION_METRIC} histogram`);
+    expect(output).toContain(
+      `${TOOL_DURATION_METRIC}_bucket{tool="tag-image",status="failure",le="2.5"} 1`,
+    );
+    expect(output).toContain(
+      `${TOOL_DURATION_METRIC}_bucket{tool="tag-image",status="failure",le="+Inf"} 1`,
+    );
+    expect(output).toContain(`${TOOL_DURATION_METRIC}_count{tool="tag-image",status="failure"} 1`);
+    expect(output).toContain(`${TOOL_EXECUTIONS_METRIC}{tool="tag-image",status="failure"} 1`);
+  });
+
+  it('should clear series on reset', () => {
+    const metrics = createToolMetrics();
+    metrics.record('ops', 'success', 5);
+
+    metrics.reset();
+
+    expect(metrics.snapshot()).toEqual([]);
+  });
+});
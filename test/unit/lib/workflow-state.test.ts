@@ -0,0 +1,403 @@
+/**
+ * Tests for workflow state persistence
+ */
+
+import { promises as fs } from 'node:fs';
+import * as os from 'node:os';
+import * as path from 'node:path';
+import {
+  createFileWorkflowStateStore,
+  createMemoryWorkflowStateStore,
+  createPipelineRecorder,
+  createWorkflowState,
+  DISK_QUOTA_EXCEEDED,
+  DiskQuotaExceededError,
+  pipelineProgress,
+  queryWorkflows,
+  recordPipelineStage,
+  type CompletedStage,
+  type WorkflowState,
+  type WorkflowStateStore,
+} from '@/lib/workflow-state';
+
+const completed = (output: unknown): CompletedStage => ({
+  argsDigest: 'abc123',
+  output,
+  completedAt: '2025-01-01T00:00:00.000Z',
+});
+
+describe('workflow state', () => {
+  describe('recordPipelineStage', () => {
+    it('should add stages and drop the ones after a stage that ran again', () => {
+      let state = createWorkflowState('wf');
+      state = recordPipelineStage(state, 'analyze-repo', completed('analysis'));
+      state = recordPipelineStage(state, 'generate-dockerfile', completed('dockerfile'));
+      state = recordPipelineStage(state, 'build-image', completed('image'));
+      state = recordPipelineStage(state, 'generate-dockerfile', completed('new dockerfile'));
+
+      expect(Object.keys(state.stages)).toEqual(['analyze-repo', 'generate-dockerfile']);
+      expect(state.stages['generate-dockerfile']?.output).toBe('new dockerfile');
+    });
+  });
+
+  describe('pipelineProgress', () => {
+    it('should report completed stages in pipeline order and the next stage', () => {
+      let state = createWorkflowState('wf');
+      state = recordPipelineStage(state, 'analyze-repo', completed({}));
+      state = recordPipelineStage(state, 'build-image', completed({}));
+
+      expect(pipelineProgress(state)).toEqual({
+        completed: ['analyze-repo', 'build-image'],
+        next: 'scan-image',
+      });
+      expect(pipelineProgress(undefined)).toEqual({ completed: [], next: 'analyze-repo' });
+    });
+
+    it('should have no next stage once verify-deploy completed', () => {
+      const state = recordPipelineStage(createWorkflowState('wf'), 'verify-deploy', completed({}));
+
+      expect(pipelineProgress(state).next).toBeUndefined();
+    });
+  });
+
+  describe('file store', () => {
+    let dir: string;
+
+    beforeEach(async () => {
+      dir = await fs.mkdtemp(path.join(os.tmpdir(), 'workflow-state-'));
+    });
+
+    afterEach(async () => {
+      await fs.rm(dir, { recursive: true, force: true });
+    });
+
+    it('should keep state across store instances', async () => {
+      const stateDir = path.join(dir, 'nested');
+      const state = recordPipelineStage(
+        createWorkflowState('session-1'),
+        'analyze-repo',
+        completed({ language: 'java' }),
+      );
+      await createFileWorkflowStateStore(stateDir).save(state);
+
+      const reloaded = await createFileWorkflowStateStore(stateDir).load('session-1');
+
+      expect(reloaded).toEqual(state);
+      expect(await fs.readdir(stateDir)).toEqual(['session-1.json']);
+    });
+
+    it('should return undefined for unknown workflows and delete saved ones', async () => {
+      const store = createFileWorkflowStateStore(dir);
+      expect(await store.load('missing')).toBeUndefined();
+
+      await store.save(createWorkflowState('wf'));
+      await store.delete('wf');
+      await store.delete('wf');
+
+      expect(await store.load('wf')).toBeUndefined();
+    });
+
+    it('should reject state over the per-workflow quota and keep the previous save', async () => {
+      const store = createFileWorkflowStateStore(dir, { maxBytesPerWorkflow: 400 });
+      const small = recordPipelineStage(createWorkflowState('wf'), 'analyze-repo', completed('a'));
+      await store.save(small);
+
+      const large = recordPipelineStage(small, 'generate-dockerfile', completed('x'.repeat(500)));
+      const error = await store.save(large).catch((e: unknown) => e);
+
+      expect(error).toBeInstanceOf(DiskQuotaExceededError);
+      expect((error as DiskQuotaExceededError).code).toBe(DISK_QUOTA_EXCEEDED);
+      expect((error as DiskQuotaExceededError).guidance.details).toMatchObject({
+        workflowId: 'wf',
+        maxBytesPerWorkflow: 400,
+      });
+      expect(await store.load('wf')).toEqual(small);
+    });
+
+    it('should evict the least recently used idle workflows over the total limit', async () => {
+      const state = (id: string) =>
+        recordPipelineStage(createWorkflowState(id), 'analyze-repo', completed('x'.repeat(300)));
+      const size = Buffer.byteLength(JSON.stringify(state('wf-1')));
+      const store = createFileWorkflowStateStore(dir, {
+        maxTotalBytes: size * 3,
+        activeWindowMs: 0,
+      });
+
+      await store.save(state('wf-1'));
+      await store.save(state('wf-2'));
+      await store.save(state('wf-3'));
+      // Loading wf-1 makes wf-2 the least recently used
+      await new Promise((resolve) => setTimeout(resolve, 5));
+      await store.load('wf-1');
+      await store.save(state('wf-4'));
+
+      expect((await fs.readdir(dir)).sort()).toEqual(['wf-1.json', 'wf-3.json', 'wf-4.json']);
+      expect(await store.usage?.()).toEqual({
+        workflows: 3,
+        totalBytes: size * 3,
+        maxTotalBytes: size * 3,
+      });
+    });
+
+    it('should keep active workflows even when over the total limit', async () => {
+      const warn = jest.fn();
+      const store = createFileWorkflowStateStore(dir, {
+        maxTotalBytes: 1,
+        logger: { info: jest.fn(), warn } as any,
+      });
+
+      await store.save(createWorkflowState('wf-1'));
+      await store.save(createWorkflowState('wf-2'));
+
+      expect((await fs.readdir(dir)).sort()).toEqual(['wf-1.json', 'wf-2.json']);
+      expect(warn).toHaveBeenCalledWith(
+        expect.objectContaining({ maxTotalBytes: 1 }),
+        'Workflow state is over its total size limit; remaining workflows are active',
+      );
+    });
+
+    describe('cleanup', () => {
+      const HOUR = 60 * 60 * 1000;
+
+      /** Save workflows, then date their files as last used `ageMs` ago */
+      const seed = async (ages: Record<string, number>): Promise<void> => {
+        const seeding = createFileWorkflowStateStore(dir);
+        for (const [id, ageMs] of Object.entries(ages)) {
+          await seeding.save(createWorkflowState(id));
+          const usedAt = new Date(Date.now() - ageMs);
+          await fs.utimes(path.join(dir, `${id}.json`), usedAt, usedAt);
+        }
+      };
+      const sizeOf = async (id: string) => (await fs.stat(path.join(dir, `${id}.json`))).size;
+
+      it('should remove workflows idle past the maximum age when another saves', async () => {
+        await seed({ stale: 48 * HOUR, recent: 2 * HOUR, active: 60 * 1000 });
+        const staleBytes = await sizeOf('stale');
+        const info = jest.fn();
+        const store = createFileWorkflowStateStore(dir, {
+          maxIdleMs: 24 * HOUR,
+          logger: { info, warn: jest.fn() } as any,
+        });
+
+        await store.save(createWorkflowState('new'));
+
+        expect((await fs.readdir(dir)).sort()).toEqual(['active.json', 'new.json', 'recent.json']);
+        expect(info).toHaveBeenCalledWith(
+          expect.objectContaining({
+            workflowId: 'stale',
+            reason: 'idle',
+            reclaimedBytes: staleBytes,
+          }),
+          'Removed workflow state',
+        );
+      });
+
+      it('should never remove active workflows, however short the idle age', async () => {
+        await seed({ stale: 2 * HOUR, active: 60 * 1000 });
+        const store = createFileWorkflowStateStore(dir, { maxIdleMs: 1 });
+
+        const report = await store.cleanup!();
+
+        expect(report.removed.map((w) => w.id)).toEqual(['stale']);
+        expect((await fs.readdir(dir)).sort()).toEqual(['active.json']);
+      });
+
+      it('should remove only the requested idle workflows and report what it skipped', async () => {
+        await seed({ stale: 2 * HOUR, other: 3 * HOUR, active: 60 * 1000 });
+        const staleBytes = await sizeOf('stale');
+        const store = createFileWorkflowStateStore(dir);
+
+        const report = await store.cleanup!({ ids: ['stale', 'active', 'missing'] });
+
+        expect(report.removed).toEqual([
+          { id: 'stale', bytes: staleBytes, idleMs: expect.any(Number), reason: 'requested' },
+        ]);
+        expect(report.skipped).toEqual([
+          { id: 'active', reason: 'active' },
+          { id: 'missing', reason: 'not-found' },
+        ]);
+        expect(report.reclaimedBytes).toBe(staleBytes);
+        expect((await fs.readdir(dir)).sort()).toEqual(['active.json', 'other.json']);
+        expect(await store.usage?.()).toMatchObject({ workflows: 2 });
+      });
+
+      it('should leave files in place on a dry run', async () => {
+        await seed({ stale: 2 * HOUR });
+        const store = createFileWorkflowStateStore(dir);
+
+        const report = await store.cleanup!({ ids: ['stale'], dryRun: true });
+
+        expect(report).toMatchObject({ dryRun: true, removed: [{ id: 'stale' }] });
+        expect(report.reclaimedBytes).toBeGreaterThan(0);
+        expect(await fs.readdir(dir)).toEqual(['stale.json']);
+      });
+    });
+
+    it('should count workflows saved before the store was created', async () => {
+      await createFileWorkflowStateStore(dir).save(createWorkflowState('old'));
+
+      const usage = await createFileWorkflowStateStore(dir).usage?.();
+
+      expect(usage?.workflows).toBe(1);
+      expect(usage?.totalBytes).toBeGreaterThan(0);
+    });
+
+    it('should reject ids that are not plain file names', async () => {
+      const store = createFileWorkflowStateStore(dir);
+
+      await expect(store.load('../escape')).rejects.toThrow('Invalid workflow id');
+      await expect(store.save(createWorkflowState('..'))).rejects.toThrow('Invalid workflow id');
+    });
+  });
+
+  describe('listing', () => {
+    const HOUR = 60 * 60 * 1000;
+    const now = Date.parse('2025-06-01T00:00:00.000Z');
+    const iso = (ms: number) => new Date(ms).toISOString();
+
+    /** 30 workflows created an hour apart; every third one is complete */
+    const seedStates = (): WorkflowState[] =>
+      Array.from({ length: 30 }, (_, i) => {
+        const id = `wf-${String(i).padStart(2, '0')}`;
+        let state = createWorkflowState(id);
+        state = recordPipelineStage(state, 'analyze-repo', completed({}));
+        if (i % 3 === 0) state = recordPipelineStage(state, 'verify-deploy', completed({}));
+        // Updated in the reverse order of creation
+        return { ...state, createdAt: iso(now - (30 - i) * HOUR), updatedAt: iso(now - i * 60000) };
+      });
+
+    it('should return every workflow exactly once across pages', async () => {
+      const store = createMemoryWorkflowStateStore();
+      for (const state of seedStates()) await store.save(state);
+
+      const seen: string[] = [];
+      let cursor: string | undefined;
+      let pages = 0;
+      do {
+        const page = await store.list!({ limit: 7, ...(cursor && { cursor }) });
+        seen.push(...page.workflows.map((w) => w.id));
+        cursor = page.nextCursor;
+        pages++;
+      } while (cursor);
+
+      expect(pages).toBe(5);
+      expect(new Set(seen).size).toBe(30);
+      expect(seen).toHaveLength(30);
+    });
+
+    it('should sort by update time, newest first, by default', () => {
+      const summaries = seedStates().map((state) => ({
+        id: state.id,
+        createdAt: state.createdAt,
+        updatedAt: state.updatedAt,
+        completedStages: 1,
+        complete: false,
+      }));
+
+      const byUpdate = queryWorkflows(summaries, { limit: 3 }, now);
+      const byCreation = queryWorkflows(summaries, { sortBy: 'createdAt', limit: 3 }, now);
+      const oldest = queryWorkflows(
+        summaries,
+        { sortBy: 'createdAt', order: 'asc', limit: 3 },
+        now,
+      );
+
+      expect(byUpdate.workflows.map((w) => w.id)).toEqual(['wf-00', 'wf-01', 'wf-02']);
+      expect(byCreation.workflows.map((w) => w.id)).toEqual(['wf-29', 'wf-28', 'wf-27']);
+      expect(oldest.workflows.map((w) => w.id)).toEqual(['wf-00', 'wf-01', 'wf-02']);
+    });
+
+    it('should filter by status and age', () => {
+      const summaries = seedStates().map((state) => ({
+        id: state.id,
+        createdAt: state.createdAt,
+        updatedAt: state.updatedAt,
+        completedStages: 1,
+        complete: Number(state.id.slice(3)) % 3 === 0,
+      }));
+
+      const complete = queryWorkflows(summaries, { status: 'complete', limit: 100 }, now);
+      const recent = queryWorkflows(
+        summaries,
+        { status: 'in-progress', maxAgeMs: 5 * HOUR, minAgeMs: 2 * HOUR, sortBy: 'createdAt' },
+        now,
+      );
+
+      expect(complete.workflows).toHaveLength(10);
+      expect(complete.workflows.every((w) => w.complete)).toBe(true);
+      // Created 5, 4, 3 and 2 hours ago are wf-25..wf-28; wf-27 is complete
+      expect(recent.workflows.map((w) => w.id)).toEqual(['wf-28', 'wf-26', 'wf-25']);
+      expect(recent.nextCursor).toBeUndefined();
+    });
+
+    it('should list from the file store and keep summaries current', async () => {
+      const dir = await fs.mkdtemp(path.join(os.tmpdir(), 'workflow-list-'));
+      try {
+        for (const state of seedStates().slice(0, 5)) {
+          await createFileWorkflowStateStore(dir).save(state);
+        }
+        const store = createFileWorkflowStateStore(dir);
+
+        const first = await store.list!({ status: 'complete' });
+        expect(first.workflows.map((w) => w.id)).toEqual(['wf-00', 'wf-03']);
+        expect(first.workflows[0]).toMatchObject({ completedStages: 2, complete: true });
+
+        await store.delete('wf-03');
+        await store.save(
+          recordPipelineStage(createWorkflowState('wf-01'), 'verify-deploy', completed({})),
+        );
+
+        const second = await store.list!({ status: 'complete' });
+        expect(second.workflows.map((w) => w.id)).toEqual(['wf-00', 'wf-01']);
+      } finally {
+        await fs.rm(dir, { recursive: true, force: true });
+      }
+    });
+
+    it('should reject a malformed cursor', () => {
+      expect(() => queryWorkflows([], { cursor: 'not-a-cursor' })).toThrow(
+        'Invalid workflow list cursor',
+      );
+    });
+  });
+
+  describe('pipeline recorder', () => {
+    it('should apply concurrent records for a workflow one at a time', async () => {
+      const store = createMemoryWorkflowStateStore();
+      const recorder = createPipelineRecorder(store);
+
+      await Promise.all([
+        recorder.record('wf', 'analyze-repo', completed('a')),
+        recorder.record('wf', 'generate-dockerfile', completed('g')),
+        recorder.record('wf', 'build-image', completed('b')),
+      ]);
+
+      const state = await store.load('wf');
+      expect(Object.keys(state?.stages ?? {})).toEqual([
+        'analyze-repo',
+        'generate-dockerfile',
+        'build-image',
+      ]);
+    });
+
+    it('should log and continue when the store fails', async () => {
+      const warn = jest.fn();
+      const failing: WorkflowStateStore = {
+        load: async () => undefined,
+        save: async () => {
+          throw new Error('disk full');
+        },
+        delete: async () => {},
+      };
+      const recorder = createPipelineRecorder(failing, { warn } as any);
+
+      await expect(
+        recorder.record('wf', 'analyze-repo', completed('a')),
+      ).resolves.toBeUndefined();
+      expect(warn).toHaveBeenCalledWith(
+        expect.objectContaining({ workflowId: 'wf', error: 'disk full' }),
+        'Failed to record workflow stage',
+      );
+    });
+  });
+});
@@ -9,6 +9,9 @@ import {
   extractProgressToken,
   createProgressReporter,
   extractProgressReporter,
+  estimateRemainingMs,
+  reportStep,
+  createStepReporter,
 } from '@/mcp/context-helpers';
 
 describe('MCP Context Helpers', () => {
@@ -241,6 +244,68 @@ describe('MCP Context Helpers', () => {
     });
   });
 
+  describe('step progress', () => {
+    it('should extrapolate the remaining time from the average step duration', () => {
+      expect(estimateRemainingMs({ index: 1, total: 4, name: 'a', elapsedMs: 200 })).toBe(600);
+      expect(estimateRemainingMs({ index: 4, total: 4, name: 'd', elapsedMs: 800 })).toBe(0);
+      expect(
+        estimateRemainingMs({ index: 0, total: 4, name: 'start', elapsedMs: 0 }),
+      ).toBeUndefined();
+    });
+
+    it('should forward the step name, elapsed time and ETA as a progress notification', async () => {
+      const mockSendNotification = jest.fn<(notification: unknown) => Promise<void>>().mockResolvedValue(undefined);
+      const reporter = createProgressReporter('token', mockLogger, mockSendNotification);
+
+      await reportStep(reporter, {
+        index: 1,
+        total: 3,
+        name: 'Analyzed repository',
+        elapsedMs: 150,
+      });
+
+      expect(mockSendNotification).toHaveBeenCalledWith({
+        method: 'notifications/progress',
+        params: {
+          progressToken: 'token',
+          progress: 1,
+          total: 3,
+          message: 'Analyzed repository',
+          step: 'Analyzed repository',
+          elapsedMs: 150,
+          etaMs: 300,
+        },
+      });
+    });
+
+    it('should do nothing without a progress reporter', async () => {
+      await expect(
+        reportStep(undefined, { index: 1, total: 2, name: 'step', elapsedMs: 10 }),
+      ).resolves.toBeUndefined();
+    });
+
+    it('should number steps and report a decreasing ETA while steps take equal time', async () => {
+      const reporter = jest.fn<(...args: unknown[]) => Promise<void>>().mockResolvedValue(undefined);
+      let clock = 1000;
+      const completeStep = createStepReporter(reporter, 4, () => clock);
+
+      for (const name of ['a', 'b', 'c', 'd']) {
+        clock += 100;
+        await completeStep(name);
+      }
+
+      const calls = reporter.mock.calls as [string, number, number, Record<string, number>][];
+      expect(calls.map(([name, index, total]) => [name, index, total])).toEqual([
+        ['a', 1, 4],
+        ['b', 2, 4],
+        ['c', 3, 4],
+        ['d', 4, 4],
+      ]);
+      expect(calls.map(([, , , metadata]) => metadata.elapsedMs)).toEqual([100, 200, 300, 400]);
+      expect(calls.map(([, , , metadata]) => metadata.etaMs)).toEqual([300, 200, 100, 0]);
+    });
+  });
+
   describe('Integration: Full progress notification flow', () => {
     it('should handle complete progress reporting lifecycle', async () => {
       const mockSendNotification = jest.fn<(notification: unknown) => Promise<void>>().mockResolvedValue(undefined);
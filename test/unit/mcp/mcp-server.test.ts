@@ -1,8 +1,14 @@
 import { describe, it, expect, beforeEach, jest } from '@jest/globals';
 import { z } from 'zod';
 import type { Tool } from '@/types/tool';
-import { registerToolsWithServer, formatOutput, OUTPUTFORMAT } from '@/mcp/mcp-server';
-import { Success, Failure } from '@/types';
+import {
+  registerToolsWithServer,
+  formatOutput,
+  createTraceResourceHandler,
+  OUTPUTFORMAT,
+} from '@/mcp/mcp-server';
+import { createAuditLog, createMemoryAuditSink } from '@/lib/audit';
+import { Success, Failure, Cancelled } from '@/types';
 import type { Logger } from 'pino';
 import { McpError } from '@modelcontextprotocol/sdk/types.js';
 
@@ -109,6 +115,32 @@ describe('registerToolsWithServer', () => {
     expect(executeMock).toHaveBeenCalled();
   });
 
+  it('attaches the partial result of a cancelled tool to the McpError', async () => {
+    const tool = createTool('cancel-demo');
+    (executeMock as any).mockResolvedValue(Cancelled({ completed: ['analyze'] }));
+
+    const fakeServer = {
+      tool: serverToolMock,
+    } as unknown as Parameters<typeof registerToolsWithServer>[0]['server'];
+
+    registerToolsWithServer({
+      server: fakeServer,
+      tools: [tool],
+      logger,
+      transport: 'stdio',
+      execute: executeMock,
+      outputFormat: OUTPUTFORMAT.MARKDOWN,
+    });
+
+    const handler = serverToolMock.mock.calls[0][3] as any;
+    const extra = { sendNotification: jest.fn(), signal: new AbortController().signal };
+
+    await expect(handler({ foo: 'value' }, extra)).rejects.toMatchObject({
+      message: expect.stringContaining('Operation cancelled'),
+      data: { partialResult: { completed: ['analyze'] } },
+    });
+  });
+
   it('formats output according to specified outputFormat', async () => {
     const tool = createTool('format-demo');
     const mockResult = { name: 'test', version: '1.0' };
@@ -142,6 +174,68 @@ describe('registerToolsWithServer', () => {
 });
 
 
+describe('createTraceResourceHandler', () => {
+  const call = (sessionId: string, tool: string, minute: number) => ({
+    timestamp: `2025-01-01T00:0${minute}:00.000Z`,
+    tool,
+    sessionId,
+    argsDigest: `digest-${tool}`,
+    durationMs: 100 * minute,
+    outcome: 'success' as const,
+  });
+
+  function createAudit() {
+    const audit = createAuditLog(createMemoryAuditSink());
+    audit.record(call('session-1', 'analyze-repo', 1));
+    audit.record(call('other', 'ops', 2));
+    audit.record(call('session-1', 'generate-dockerfile', 3));
+    audit.record(call('session-1', 'build-image', 4));
+    return audit;
+  }
+
+  it('returns the session trace as JSON', async () => {
+    const handler = createTraceResourceHandler(createAudit());
+    const uri = new URL('containerization://trace/session-1?limit=2');
+
+    const result = await handler(uri, { sessionId: 'session-1', limit: '2' });
+
+    expect(result.contents[0]).toMatchObject({ uri: uri.href, mimeType: 'application/json' });
+    const trace = JSON.parse(result.contents[0]?.text as string);
+    expect(trace.entries.map((e: { tool: string }) => e.tool)).toEqual([
+      'generate-dockerfile',
+      'build-image',
+    ]);
+    expect(trace.entries[1]).toEqual(call('session-1', 'build-image', 4));
+    expect(trace).toMatchObject({ sessionId: 'session-1', total: 3, truncated: true });
+  });
+
+  it('applies the since filter', async () => {
+    const handler = createTraceResourceHandler(createAudit());
+
+    const result = await handler(new URL('containerization://trace/session-1'), {
+      sessionId: 'session-1',
+      since: encodeURIComponent('2025-01-01T00:03:00Z'),
+    });
+
+    const trace = JSON.parse(result.contents[0]?.text as string);
+    expect(trace.entries.map((e: { tool: string }) => e.tool)).toEqual([
+      'generate-dockerfile',
+      'build-image',
+    ]);
+  });
+
+  it('rejects an invalid limit with McpError', async () => {
+    const handler = createTraceResourceHandler(createAudit());
+
+    await expect(
+      handler(new URL('containerization://trace/session-1?limit=0'), {
+        sessionId: 'session-1',
+        limit: '0',
+      }),
+    ).rejects.toThrow(McpError);
+  });
+});
+
 describe('formatOutput', () => {
   it('formats as JSON when format is JSON', () => {
     const input = { name: 'test', version: 1 };
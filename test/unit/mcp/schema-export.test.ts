@@ -0,0 +1,93 @@
+/**
+ * Tests for exporting tool input schemas
+ */
+
+import { describe, it, expect, jest } from '@jest/globals';
+import { z } from 'zod';
+import type { Logger } from 'pino';
+import { exportToolSchemas } from '@/mcp/schema-export';
+import { registerToolsWithServer, OUTPUTFORMAT } from '@/mcp/mcp-server';
+import { tool, Success } from '@/types';
+
+const logger = {
+  info: jest.fn(),
+  warn: jest.fn(),
+  error: jest.fn(),
+  debug: jest.fn(),
+  child: jest.fn().mockReturnThis(),
+} as unknown as Logger;
+
+const sampleTools = [
+  tool({
+    name: 'sample-build',
+    description: 'Build a sample image',
+    schema: z.object({
+      path: z.string().describe('Path to the build context'),
+      tags: z.array(z.string()).optional().describe('Tags to apply'),
+      noCache: z.boolean().default(false),
+    }),
+    metadata: { knowledgeEnhanced: false },
+    handler: async () => Success({}),
+  }),
+  tool({
+    name: 'sample-deploy',
+    description: 'Deploy a sample manifest',
+    schema: z.object({
+      namespace: z.string().describe('Target namespace'),
+      replicas: z.number().int().min(1),
+    }),
+    metadata: { knowledgeEnhanced: false },
+    handler: async () => Success({}),
+  }),
+];
+
+describe('exportToolSchemas', () => {
+  it('should export one input schema per tool with descriptions and required fields', async () => {
+    const result = await exportToolSchemas((server) =>
+      registerToolsWithServer({
+        server,
+        tools: sampleTools,
+        logger,
+        transport: 'schema-export',
+        execute: jest.fn() as never,
+        outputFormat: OUTPUTFORMAT.MARKDOWN,
+      }),
+    );
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+
+    expect(Object.keys(result.value)).toEqual(['sample-build', 'sample-deploy']);
+
+    const build = result.value['sample-build'];
+    expect(build?.description).toBe('Build a sample image');
+    expect(build?.inputSchema).toMatchObject({
+      type: 'object',
+      properties: {
+        path: { type: 'string', description: 'Path to the build context' },
+        tags: { type: 'array', items: { type: 'string' }, description: 'Tags to apply' },
+        noCache: { type: 'boolean', default: false },
+      },
+      required: ['path'],
+    });
+
+    expect(result.value['sample-deploy']?.inputSchema).toMatchObject({
+      properties: {
+        namespace: { type: 'string', description: 'Target namespace' },
+        replicas: { type: 'integer', minimum: 1 },
+      },
+      required: ['namespace', 'replicas'],
+    });
+  });
+
+  it('should return a failure when the tools cannot be registered', async () => {
+    const result = await exportToolSchemas(() => {
+      throw new Error('duplicate tool name');
+    });
+
+    expect(result.ok).toBe(false);
+    if (!result.ok) {
+      expect(result.error).toContain('duplicate tool name');
+    }
+  });
+});
@@ -198,7 +198,41 @@ describe('IdempotentApply', () => {
       expect(result.ok).toBe(true);
       expect(mockCoreApi.createNamespacedConfigMap).toHaveBeenCalledWith({
         namespace: 'default',
-        body: configMap
+        body: configMap,
+        dryRun: 'All'
+      });
+    });
+
+    test('should send the patch as a dry run when the resource exists', async () => {
+      const deployment = {
+        apiVersion: 'apps/v1',
+        kind: 'Deployment',
+        metadata: {
+          name: 'existing-app',
+          namespace: 'default'
+        },
+        spec: {
+          replicas: 2
+        }
+      };
+
+      mockAppsApi.createNamespacedDeployment.mockRejectedValue({
+        response: { statusCode: 409 },
+        message: 'already exists'
+      });
+      mockAppsApi.patchNamespacedDeployment.mockResolvedValue({ body: deployment });
+
+      const result = await applyResource(deployment, { dryRun: true });
+
+      expect(result.ok).toBe(true);
+      expect(mockAppsApi.createNamespacedDeployment).toHaveBeenCalledWith(
+        expect.objectContaining({ dryRun: 'All' })
+      );
+      expect(mockAppsApi.patchNamespacedDeployment).toHaveBeenCalledWith({
+        name: 'existing-app',
+        namespace: 'default',
+        body: deployment,
+        dryRun: 'All'
       });
     });
   });
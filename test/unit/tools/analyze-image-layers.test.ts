@@ -0,0 +1,220 @@
+/**
+ * Unit Tests: Analyze Image Layers Tool
+ * Tests layer breakdown and cache analysis against recorded `docker history` output
+ */
+
+import { jest } from '@jest/globals';
+import { readFileSync } from 'fs';
+import { join } from 'path';
+import type { DockerImageHistoryEntry } from '../../../src/infra/docker/client';
+import {
+  analyzeImageHistory,
+  describeInstruction,
+} from '../../../src/tools/analyze-image-layers/layer-analysis';
+
+function createMockLogger() {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+    trace: jest.fn(),
+    fatal: jest.fn(),
+    child: jest.fn().mockReturnThis(),
+  } as any;
+}
+
+const mockDockerClient = {
+  ping: jest.fn(async () => ({
+    ok: true,
+    value: { socketPath: '/var/run/docker.sock', version: '27.0.0' },
+  })),
+  getImageHistory: jest.fn<(imageId: string) => Promise<any>>(),
+};
+
+jest.mock('../../../src/infra/docker/client', () => ({
+  createDockerClient: jest.fn(() => mockDockerClient),
+}));
+
+jest.mock('../../../src/lib/logger', () => ({
+  createTimer: jest.fn(() => ({
+    end: jest.fn(),
+    error: jest.fn(),
+  })),
+  createLogger: jest.fn(() => createMockLogger()),
+}));
+
+import { default as analyzeImageLayersTool } from '../../../src/tools/analyze-image-layers/tool';
+
+const FIXTURES = join(__dirname, '../../__support__/fixtures/docker-history');
+const NODE_APP_HISTORY: DockerImageHistoryEntry[] = JSON.parse(
+  readFileSync(join(FIXTURES, 'node-app.json'), 'utf-8'),
+);
+
+/** History entries, newest first, from instructions in build order */
+const historyOf = (...layers: Array<[string, number]>): DockerImageHistoryEntry[] =>
+  layers
+    .map(([CreatedBy, Size]) => ({ Id: '<missing>', Created: 0, CreatedBy, Size }))
+    .reverse();
+
+describe('analyze-image-layers', () => {
+  describe('describeInstruction', () => {
+    it.each([
+      ['/bin/sh -c #(nop)  CMD ["bash"]', 'CMD ["bash"]'],
+      ['/bin/sh -c #(nop) COPY dir:4f2b9c in /app ', 'COPY dir:4f2b9c in /app'],
+      ['/bin/sh -c npm ci', 'RUN npm ci'],
+      ['|1 NODE_ENV=production /bin/sh -c npm ci', 'RUN npm ci'],
+      ['RUN /bin/sh -c npm run build # buildkit', 'RUN npm run build'],
+      ['RUN --mount=type=cache,target=/root/.npm /bin/sh -c npm ci # buildkit', 'RUN npm ci'],
+      ['COPY . . # buildkit', 'COPY . .'],
+      ['WORKDIR /app', 'WORKDIR /app'],
+    ])('should describe %s', (createdBy, expected) => {
+      expect(describeInstruction(createdBy)).toBe(expected);
+    });
+  });
+
+  describe('analyzeImageHistory', () => {
+    it('should break the recorded history down by layer in build order', () => {
+      const analysis = analyzeImageHistory(NODE_APP_HISTORY);
+
+      expect(analysis.layers).toHaveLength(18);
+      expect(analysis.totalSize).toBe(380_717_027);
+      expect(analysis.layers[0]?.instruction).toMatch(/^ADD file:\w+ in \/$/);
+      expect(analysis.layers.slice(10).map((l) => [l.instruction, l.size])).toEqual([
+        ['ENV NODE_ENV=production', 0],
+        ['WORKDIR /app', 0],
+        ['COPY . .', 3_145_728],
+        ['RUN npm ci --omit=dev', 148_234_567],
+        [
+          'RUN apt-get update && apt-get install -y --no-install-recommends curl && rm -rf /var/lib/apt/lists/*',
+          21_456_789,
+        ],
+        ['RUN npm run build', 8_912_345],
+        ['EXPOSE map[3000/tcp:{}]', 0],
+        ['CMD ["node" "dist/index.js"]', 0],
+      ]);
+    });
+
+    it('should separate base image layers at the base CMD', () => {
+      const analysis = analyzeImageHistory(NODE_APP_HISTORY);
+
+      expect(analysis.layers.filter((l) => l.fromBaseImage)).toHaveLength(10);
+      expect(analysis.layers[9]?.instruction).toBe('CMD ["node"]');
+      expect(analysis.baseImageSize).toBe(198_967_598);
+      expect(analysis.layers.filter((l) => l.empty).map((l) => l.index)).toEqual([
+        1, 3, 5, 8, 9, 10, 11, 16, 17,
+      ]);
+    });
+
+    it('should identify the largest layers', () => {
+      const analysis = analyzeImageHistory(NODE_APP_HISTORY);
+
+      expect(analysis.largestLayers.map((l) => l.index)).toEqual([13, 4, 0]);
+      expect(analysis.largestLayers[0]).toMatchObject({
+        instruction: 'RUN npm ci --omit=dev',
+        percentOfImage: 38.9,
+        kind: 'dependency-install',
+        cacheBusted: true,
+      });
+      expect(analyzeImageHistory(NODE_APP_HISTORY, 1).largestLayers).toHaveLength(1);
+    });
+
+    it('should flag installs after the source copy as cache-busted and suggest reordering', () => {
+      const analysis = analyzeImageHistory(NODE_APP_HISTORY);
+
+      expect(analysis.cacheBoundary).toBe(12);
+      expect(analysis.layers.filter((l) => l.cacheBusted).map((l) => l.index)).toEqual([
+        12, 13, 14, 15, 16, 17,
+      ]);
+      expect(analysis.layers[14]?.kind).toBe('system-packages');
+      expect(analysis.suggestions).toHaveLength(3);
+      expect(analysis.suggestions[0]).toContain('layer 13 (`RUN npm ci --omit=dev`, 141MB)');
+      expect(analysis.suggestions[0]).toContain('Copy only the dependency manifests');
+      expect(analysis.suggestions[1]).toContain('move it above the COPY');
+      expect(analysis.suggestions[2]).toContain('optimize-dockerfile');
+    });
+
+    it('should not suggest reordering when manifests are copied before the source', () => {
+      const analysis = analyzeImageHistory(
+        historyOf(
+          ['/bin/sh -c #(nop) ADD file:abc in / ', 7_000_000],
+          ['/bin/sh -c #(nop)  CMD ["/bin/sh"]', 0],
+          ['WORKDIR /app', 0],
+          ['COPY package.json package-lock.json ./ # buildkit', 400_000],
+          ['RUN /bin/sh -c npm ci # buildkit', 90_000_000],
+          ['COPY . . # buildkit', 2_000_000],
+          ['RUN /bin/sh -c npm run build # buildkit', 5_000_000],
+        ),
+      );
+
+      expect(analysis.cacheBoundary).toBe(5);
+      expect(analysis.layers[4]).toMatchObject({ kind: 'dependency-install', cacheBusted: false });
+      expect(analysis.suggestions).toEqual([]);
+    });
+
+    it('should treat legacy directory copies as source and flag a large build context', () => {
+      const analysis = analyzeImageHistory(
+        historyOf(
+          ['/bin/sh -c #(nop) COPY file:1a2b in /app/ ', 1_000],
+          ['/bin/sh -c #(nop) COPY dir:3c4d in /app ', 300_000_000],
+          ['/bin/sh -c pip install -r requirements.txt', 60_000_000],
+        ),
+      );
+
+      expect(analysis.cacheBoundary).toBe(1);
+      expect(analysis.layers[0]?.kind).toBeUndefined();
+      expect(analysis.suggestions[1]).toContain('.dockerignore');
+    });
+
+    it('should report no cache boundary when nothing copies source', () => {
+      const analysis = analyzeImageHistory(
+        historyOf(
+          ['/bin/sh -c #(nop) ADD file:abc in / ', 5_000],
+          ['COPY --from=build /out /app', 10],
+        ),
+      );
+
+      expect(analysis.cacheBoundary).toBeUndefined();
+      expect(analysis.layers.every((l) => !l.cacheBusted)).toBe(true);
+    });
+  });
+
+  describe('tool handler', () => {
+    it('should return the analysis with a summary', async () => {
+      mockDockerClient.getImageHistory.mockResolvedValue({ ok: true, value: NODE_APP_HISTORY });
+
+      const result = await analyzeImageLayersTool.handler(
+        { imageId: 'myapp:latest' },
+        { logger: createMockLogger() } as any,
+      );
+
+      expect(mockDockerClient.getImageHistory).toHaveBeenCalledWith('myapp:latest');
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.imageId).toBe('myapp:latest');
+        expect(result.value.summary).toContain('Analyzed 18 layers of myapp:latest (363MB)');
+        expect(result.value.summary).toContain('4 layers are rebuilt on every source change');
+        expect(result.value.summary).toContain('Largest: RUN npm ci --omit=dev (141MB)');
+      }
+    });
+
+    it('should fail when the image history cannot be read', async () => {
+      mockDockerClient.getImageHistory.mockResolvedValue({
+        ok: false,
+        error: 'No such image: missing:latest',
+        guidance: { message: 'Image not found' },
+      });
+
+      const result = await analyzeImageLayersTool.handler(
+        { imageId: 'missing:latest' },
+        { logger: createMockLogger() } as any,
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('No such image');
+        expect(result.guidance?.message).toBe('Image not found');
+      }
+    });
+  });
+});
@@ -35,6 +35,54 @@ jest.mock('node:fs', () => ({
 // Import the mocked fs after setting up the mock
 import { promises as fs } from 'node:fs';
 import analyzeTool from '@/tools/analyze-repo/tool';
+import {
+  monorepoGoNodeRepository,
+  expectedMonorepoGoNodeAnalysis,
+} from '../../__support__/fixtures/repositories/monorepo-go-node';
+import {
+  pythonFlaskBasicRepository,
+} from '../../__support__/fixtures/repositories/python-flask-basic';
+import {
+  nodeExpressBasicRepository,
+} from '../../__support__/fixtures/repositories/node-express-basic';
+import { goBasicRepository } from '../../__support__/fixtures/repositories/go-basic';
+import { rustBasicRepository } from '../../__support__/fixtures/repositories/rust-basic';
+import { denoBasicRepository } from '../../__support__/fixtures/repositories/deno-basic';
+import { bunBasicRepository } from '../../__support__/fixtures/repositories/bun-basic';
+import {
+  javaSpringBootBasicRepository,
+} from '../../__support__/fixtures/repositories/java-springboot-basic';
+
+/**
+ * Back the mocked fs with an in-memory tree of relative path -> content
+ */
+function mockFileTree(root: string, files: Record<string, string>) {
+  const entries = (dirPath: string) => {
+    const prefix = dirPath === root ? '' : `${dirPath.slice(root.length + 1)}/`;
+    const children = new Map<string, boolean>();
+    for (const file of Object.keys(files)) {
+      if (!file.startsWith(prefix)) continue;
+      const [name, ...rest] = file.slice(prefix.length).split('/');
+      if (name) children.set(name, rest.length > 0);
+    }
+    return [...children].map(([name, isDir]) => ({
+      name,
+      isDirectory: () => isDir,
+      isFile: () => !isDir,
+    }));
+  };
+
+  (fs.stat as jest.Mock).mockResolvedValue({ isDirectory: () => true, isFile: () => false });
+  (fs.readdir as jest.Mock).mockImplementation((dirPath: string) =>
+    Promise.resolve(entries(dirPath)),
+  );
+  (fs.readFile as jest.Mock).mockImplementation((filePath: string) => {
+    const content = files[filePath.slice(root.length + 1)];
+    return content === undefined
+      ? Promise.reject(new Error('File not found'))
+      : Promise.resolve(content);
+  });
+}
 
 describe('analyze-repo tool (v4.0.0 - deterministic)', () => {
   let mockContext: ToolContext;
@@ -226,6 +274,354 @@ describe('analyze-repo tool (v4.0.0 - deterministic)', () => {
     });
   });
 
+  describe('Monorepo detection', () => {
+    const root = '/test/monorepo';
+
+    beforeEach(() => {
+      mockFileTree(root, monorepoGoNodeRepository);
+    });
+
+    it('should return every service root with its language and framework', async () => {
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.isMonorepo).toBe(expectedMonorepoGoNodeAnalysis.isMonorepo);
+        const services = (result.value.modules ?? [])
+          .map((m) => ({
+            name: m.name,
+            path: m.modulePath.slice(root.length + 1),
+            language: m.language,
+            framework: m.frameworks?.[0]?.name,
+          }))
+          .sort((a, b) => a.name.localeCompare(b.name));
+        expect(services).toEqual(expectedMonorepoGoNodeAnalysis.services);
+        expect(result.value.summary).toContain('inventory: go/gin');
+        expect(result.value.summary).toContain('storefront: javascript/express');
+      }
+    });
+
+    it('should analyze a single service when subpath is given', async () => {
+      const result = await analyzeTool.handler(
+        { repositoryPath: root, subpath: 'services/inventory' },
+        mockContext,
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.isMonorepo).toBe(false);
+        expect(result.value.analyzedPath).toBe(`${root}/services/inventory`);
+        expect(result.value.modules).toHaveLength(1);
+        expect(result.value.modules?.[0]).toMatchObject({
+          name: 'inventory',
+          language: 'go',
+          frameworks: [{ name: 'gin' }],
+        });
+      }
+    });
+
+    it('should reject a subpath that escapes the repository', async () => {
+      const result = await analyzeTool.handler(
+        { repositoryPath: root, subpath: '../elsewhere' },
+        mockContext,
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('outside the repository');
+      }
+    });
+  });
+
+  describe('Package manager detection', () => {
+    const root = '/test/repo';
+    const packageJson = (extra: Record<string, unknown> = {}) =>
+      JSON.stringify({ name: 'web', dependencies: { express: '^4.19.0' }, ...extra });
+
+    it('should report the package manager from the lockfile', async () => {
+      mockFileTree(root, { 'package.json': packageJson(), 'pnpm-lock.yaml': '' });
+
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.modules?.[0]).toMatchObject({
+          packageManager: 'pnpm',
+          lockFiles: ['pnpm-lock.yaml'],
+          buildSystems: [{ type: 'pnpm' }],
+        });
+        expect(result.value.warnings).toBeUndefined();
+      }
+    });
+
+    it('should warn when conflicting lockfiles coexist', async () => {
+      mockFileTree(root, {
+        'package.json': packageJson({ packageManager: 'yarn@1.22.22' }),
+        'package-lock.json': '{}',
+        'yarn.lock': '',
+      });
+
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.modules?.[0]?.packageManager).toBe('yarn');
+        expect(result.value.modules?.[0]?.lockFiles).toEqual(['package-lock.json', 'yarn.lock']);
+        expect(result.value.warnings).toHaveLength(1);
+        expect(result.value.warnings?.[0]).toContain('declared in package.json');
+      }
+    });
+
+    it('should detect a Deno project from deno.json and deno.lock', async () => {
+      mockFileTree(root, denoBasicRepository);
+
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.modules?.[0]).toMatchObject({
+          language: 'typescript',
+          packageManager: 'deno',
+          lockFiles: ['deno.lock'],
+          buildSystems: [{ type: 'deno' }],
+          runtime: { name: 'deno', version: '2.1', pinned: false },
+          frameworks: [{ name: 'hono', version: '4', declared: '^4.6.12', pinned: true }],
+          ports: [8000],
+          entryPoint: 'main.ts',
+        });
+      }
+    });
+
+    it('should detect Deno from deno.json alone', async () => {
+      mockFileTree(root, { 'deno.json': '{ "tasks": { "start": "deno run main.ts" } }' });
+
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.modules?.[0]?.packageManager).toBe('deno');
+        expect(result.value.modules?.[0]?.lockFiles).toBeUndefined();
+        expect(result.value.modules?.[0]?.runtime?.name).toBe('deno');
+      }
+    });
+
+    it('should detect a Bun project from bun.lockb and read engines.bun', async () => {
+      mockFileTree(root, bunBasicRepository);
+
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.modules?.[0]).toMatchObject({
+          language: 'typescript',
+          packageManager: 'bun',
+          lockFiles: ['bun.lockb'],
+          buildSystems: [{ type: 'bun' }],
+          runtime: { name: 'bun', version: '1.1', declared: '>=1.1.0', pinned: false },
+          frameworks: [{ name: 'elysia', version: '1', declared: '^1.1.25', pinned: true }],
+          entryPoint: 'src/index.ts',
+        });
+      }
+    });
+  });
+
+  describe('Port detection', () => {
+    const root = '/test/repo';
+
+    it('should return every candidate with its confidence when ports are ambiguous', async () => {
+      mockFileTree(root, pythonFlaskBasicRepository);
+
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        const [module] = result.value.modules ?? [];
+        expect(module?.ports).toEqual([5000, 8000]);
+        expect(module?.portCandidates?.map((c) => [c.port, c.confidence])).toEqual([
+          [5000, 0.7],
+          [8000, 0.4],
+        ]);
+        expect(result.value.summary).toContain('Port candidates: 5000 (70%), 8000 (40%).');
+      }
+    });
+
+    it('should attribute source files to the service that contains them', async () => {
+      mockFileTree(root, monorepoGoNodeRepository);
+
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        const ports = Object.fromEntries(
+          (result.value.modules ?? []).map((m) => [m.name, m.ports]),
+        );
+        expect(ports).toEqual({ inventory: [8080], storefront: [3000] });
+      }
+    });
+
+    it('should fall back to framework defaults without source evidence', async () => {
+      mockFileTree(root, {
+        'package.json': JSON.stringify({ name: 'web', dependencies: { express: '^4.19.0' } }),
+      });
+
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.modules?.[0]?.portCandidates).toEqual([
+          { port: 3000, confidence: 0.3, sources: ['framework default'] },
+        ]);
+        expect(result.value.summary).toContain('Likely listen port: 3000.');
+      }
+    });
+  });
+
+  describe('Version detection', () => {
+    const root = '/test/repo';
+    const analyzeOnlyModule = async () => {
+      const result = await analyzeTool.handler({ repositoryPath: root }, mockContext);
+      expect(result.ok).toBe(true);
+      return result.ok
+        ? { module: result.value.modules?.[0], summary: result.value.summary }
+        : {};
+    };
+
+    it('should prefer .nvmrc over the engines range and resolve the framework pin', async () => {
+      const { 'package.json': packageJson, ...files } = nodeExpressBasicRepository;
+      mockFileTree(root, { ...files, 'package.json': JSON.stringify(packageJson) });
+
+      const { module, summary } = await analyzeOnlyModule();
+
+      expect(module?.runtime).toEqual({
+        name: 'node',
+        version: '18',
+        declared: '18.17.0',
+        pinned: true,
+      });
+      expect(module?.frameworks).toEqual([
+        { name: 'express', version: '4', declared: '^4.18.0', pinned: true },
+      ]);
+      expect(summary).toContain('Detected javascript project (node 18, express 4).');
+    });
+
+    it('should recommend a default when engines only sets a lower bound', async () => {
+      mockFileTree(root, {
+        'package.json': JSON.stringify({
+          name: 'web',
+          dependencies: { next: '14.1.0' },
+          engines: { node: '>=18.17.0' },
+        }),
+      });
+
+      const { module, summary } = await analyzeOnlyModule();
+
+      expect(module?.runtime).toEqual({
+        name: 'node',
+        version: '20',
+        declared: '>=18.17.0',
+        pinned: false,
+      });
+      expect(module?.buildSystems?.[0]?.languageVersion).toBe('>=18.17.0');
+      expect(summary).toContain(
+        '(node 20, next 14; no single node version is pinned, so node 20 is recommended)',
+      );
+    });
+
+    it('should read the go directive and the framework module version', async () => {
+      mockFileTree(root, goBasicRepository);
+
+      const { module } = await analyzeOnlyModule();
+
+      expect(module?.runtime).toEqual({
+        name: 'go',
+        version: '1.21',
+        declared: '1.21',
+        pinned: true,
+      });
+      expect(module?.frameworks).toEqual([
+        { name: 'gorilla', version: '1.8', declared: 'v1.8.0', pinned: true },
+      ]);
+    });
+
+    it('should read runtime.txt and framework pins from requirements.txt', async () => {
+      mockFileTree(root, pythonFlaskBasicRepository);
+
+      const { module } = await analyzeOnlyModule();
+
+      expect(module?.runtime).toMatchObject({ name: 'python', version: '3.11', pinned: true });
+      expect(module?.frameworks).toEqual([
+        { name: 'flask', version: '2.3', declared: '==2.3.3', pinned: true },
+      ]);
+    });
+
+    it('should combine python_requires from setup.py with unpinned requirements', async () => {
+      mockFileTree(root, {
+        'requirements.txt': 'django>=4.2\npsycopg2-binary',
+        'setup.py': `from setuptools import setup\n\nsetup(\n    name="shop",\n    python_requires=">=3.9, <3.12",\n)\n`,
+      });
+
+      const { module } = await analyzeOnlyModule();
+
+      expect(module?.runtime).toEqual({
+        name: 'python',
+        version: '3.11',
+        declared: '>=3.9, <3.12',
+        pinned: false,
+      });
+      expect(module?.frameworks).toEqual([
+        { name: 'django', version: '5.0', declared: '>=4.2', pinned: false },
+      ]);
+    });
+
+    it('should read requires-python and dependencies from pyproject.toml', async () => {
+      mockFileTree(root, {
+        'pyproject.toml': [
+          '[project]',
+          'name = "api"',
+          'requires-python = "~=3.11.2"',
+          'dependencies = ["fastapi[standard]==0.104.1", "uvicorn"]',
+        ].join('\n'),
+      });
+
+      const { module } = await analyzeOnlyModule();
+
+      expect(module?.runtime).toMatchObject({ version: '3.11', pinned: true });
+      expect(module?.frameworks?.[0]).toMatchObject({ name: 'fastapi', version: '0.104' });
+    });
+
+    it('should read the Spring Boot parent version and java.version', async () => {
+      mockFileTree(root, javaSpringBootBasicRepository);
+
+      const { module } = await analyzeOnlyModule();
+
+      expect(module?.runtime).toEqual({
+        name: 'java',
+        version: '17',
+        declared: '17',
+        pinned: true,
+      });
+      expect(module?.frameworks).toEqual([
+        { name: 'spring-boot', version: '3.1', declared: '3.1.5', pinned: true },
+      ]);
+    });
+
+    it('should recommend a toolchain when Cargo.toml only sets an edition', async () => {
+      mockFileTree(root, rustBasicRepository);
+
+      const { module } = await analyzeOnlyModule();
+
+      expect(module?.runtime).toEqual({
+        name: 'rust',
+        version: '1.79',
+        declared: '2021',
+        pinned: false,
+      });
+      expect(module?.frameworks).toEqual([
+        { name: 'warp', version: '0.3', declared: '0.3', pinned: true },
+      ]);
+    });
+  });
+
   describe('Legacy mode with pre-provided modules', () => {
     it('should use pre-provided modules without AI analysis', async () => {
       const statMock = jest.fn().mockResolvedValue({
@@ -512,4 +908,60 @@ describe('analyze-repo tool (v4.0.0 - deterministic)', () => {
       expect(analyzeTool.metadata.knowledgeEnhanced).toBe(false);
     });
   });
+
+  describe('Cache fingerprint', () => {
+    const root = '/test/cached';
+    let files: Record<string, string>;
+    let mtimes: Record<string, number>;
+    const fingerprint = () => analyzeTool.cacheFingerprint?.({ repositoryPath: root });
+
+    beforeEach(() => {
+      files = {
+        'package.json': '{"name":"web","dependencies":{"express":"^4.18.0"}}',
+        'package-lock.json': '{}',
+        'src/index.js': 'app.listen(3000);',
+        'node_modules/express/package.json': '{}',
+      };
+      mtimes = {};
+      mockFileTree(root, files);
+      (fs.stat as jest.Mock).mockImplementation(async (filePath: string) => {
+        const file = filePath.slice(root.length + 1);
+        return { size: files[file]?.length ?? 0, mtimeMs: mtimes[file] ?? 1000 };
+      });
+    });
+
+    it('should stay the same while the repository is unchanged', async () => {
+      const first = await fingerprint();
+
+      expect(first).toMatch(/^[0-9a-f]{64}$/);
+      expect(await fingerprint()).toBe(first);
+    });
+
+    it('should change when a manifest or lockfile is modified', async () => {
+      const first = await fingerprint();
+      mtimes['package-lock.json'] = 2000;
+      const afterLockfile = await fingerprint();
+      files['package.json'] = '{"name":"web","dependencies":{"express":"^5.0.0"}}';
+      const afterManifest = await fingerprint();
+
+      expect(afterLockfile).not.toBe(first);
+      expect(afterManifest).not.toBe(afterLockfile);
+    });
+
+    it('should change when a file is added, but not for ignored directories', async () => {
+      const first = await fingerprint();
+      mtimes['node_modules/express/package.json'] = 2000;
+      const afterIgnored = await fingerprint();
+      files['go.mod'] = 'module example.com/web';
+
+      expect(afterIgnored).toBe(first);
+      expect(await fingerprint()).not.toBe(first);
+    });
+
+    it('should skip the cache when the repository cannot be read', async () => {
+      (fs.readdir as jest.Mock).mockRejectedValue(new Error('EACCES'));
+
+      expect(await fingerprint()).toBeUndefined();
+    });
+  });
 });
@@ -0,0 +1,100 @@
+/**
+ * Unit tests for lockfile-based package manager detection
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  detectPackageManager,
+  INSTALL_COMMANDS,
+  PACKAGE_MANAGERS,
+  resolveCopyFiles,
+} from '@/tools/analyze-repo/package-managers';
+
+describe('detectPackageManager', () => {
+  it.each([
+    ['package-lock.json', 'npm'],
+    ['pnpm-lock.yaml', 'pnpm'],
+    ['yarn.lock', 'yarn'],
+    ['bun.lockb', 'bun'],
+    ['bun.lock', 'bun'],
+    ['deno.lock', 'deno'],
+    ['go.sum', 'go'],
+    ['poetry.lock', 'poetry'],
+    ['Cargo.lock', 'cargo'],
+  ])('should detect %s as %s', (lockFile, expected) => {
+    const detection = detectPackageManager(['README.md', lockFile, 'src']);
+
+    expect(detection).toEqual({ packageManager: expected, lockFiles: [lockFile] });
+  });
+
+  it('should return undefined when no lockfile is present', () => {
+    expect(detectPackageManager(['package.json', 'index.js'])).toBeUndefined();
+  });
+
+  describe('conflicting lockfiles', () => {
+    it('should prefer pnpm over a stray package-lock.json and report the conflict', () => {
+      const detection = detectPackageManager(['package-lock.json', 'pnpm-lock.yaml'], {
+        language: 'javascript',
+      });
+
+      expect(detection?.packageManager).toBe('pnpm');
+      expect(detection?.lockFiles).toEqual(['package-lock.json', 'pnpm-lock.yaml']);
+      expect(detection?.conflict).toContain('using pnpm');
+      expect(detection?.conflict).toContain('no packageManager field');
+    });
+
+    it('should prefer the Bun lockfile over a package-lock.json left by npm', () => {
+      const detection = detectPackageManager(['bun.lockb', 'package-lock.json'], {
+        language: 'typescript',
+      });
+
+      expect(detection?.packageManager).toBe('bun');
+      expect(detection?.conflict).toContain('using bun');
+    });
+
+    it('should honor the packageManager field declared in package.json', () => {
+      const detection = detectPackageManager(['yarn.lock', 'pnpm-lock.yaml'], {
+        language: 'typescript',
+        declared: 'yarn@4.1.0',
+      });
+
+      expect(detection?.packageManager).toBe('yarn');
+      expect(detection?.conflict).toContain('declared in package.json');
+    });
+
+    it('should ignore lockfiles that belong to another language', () => {
+      const detection = detectPackageManager(['go.sum', 'package-lock.json'], {
+        language: 'go',
+      });
+
+      expect(detection).toEqual({ packageManager: 'go', lockFiles: ['go.sum'] });
+    });
+  });
+});
+
+describe('INSTALL_COMMANDS', () => {
+  it('should copy the matching lockfile for every package manager', () => {
+    for (const manager of PACKAGE_MANAGERS) {
+      const lockFile = detectPackageManager(INSTALL_COMMANDS[manager].copyFiles);
+      expect(lockFile?.packageManager).toBe(manager);
+    }
+  });
+});
+
+describe('resolveCopyFiles', () => {
+  it('should copy the manifest and lockfile the module actually has', () => {
+    expect(resolveCopyFiles('bun', ['package.json', 'bun.lock'])).toEqual([
+      'package.json',
+      'bun.lock',
+    ]);
+    expect(resolveCopyFiles('bun', ['package.json', 'bun.lockb'])).toEqual([
+      'package.json',
+      'bun.lockb',
+    ]);
+    expect(resolveCopyFiles('deno', ['deno.jsonc'])).toEqual(['deno.jsonc']);
+  });
+
+  it('should fall back to the defaults when none of the files are present', () => {
+    expect(resolveCopyFiles('npm', [])).toEqual(INSTALL_COMMANDS.npm.copyFiles);
+  });
+});
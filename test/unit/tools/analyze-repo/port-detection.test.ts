@@ -0,0 +1,138 @@
+/**
+ * Tests for listen port detection from source code
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  detectPorts,
+  isPortSourceFile,
+  FRAMEWORK_DEFAULT_CONFIDENCE,
+} from '@/tools/analyze-repo/port-detection';
+import {
+  nodeExpressBasicRepository,
+  pythonFlaskBasicRepository,
+  javaSpringBootBasicRepository,
+  goBasicRepository,
+  monorepoGoNodeRepository,
+} from '../../../__support__/fixtures/repositories';
+
+/**
+ * Port source files of a fixture, as analyze-repo would read them
+ */
+function portSources(repository: Record<string, unknown>): Record<string, string> {
+  return Object.fromEntries(
+    Object.entries(repository)
+      .filter(([file]) => isPortSourceFile(file.split('/').pop() ?? file))
+      .map(([file, content]) => [
+        file,
+        typeof content === 'string' ? content : JSON.stringify(content, null, 2),
+      ]),
+  );
+}
+
+describe('detectPorts', () => {
+  describe('language fixtures', () => {
+    it('should read the PORT fallback of an Express app', () => {
+      const candidates = detectPorts(portSources(nodeExpressBasicRepository));
+
+      expect(candidates).toEqual([
+        { port: 3000, confidence: 0.7, sources: [expect.stringMatching(/^index\.js:\d+/)] },
+      ]);
+    });
+
+    it('should read the port fallback of a Go HTTP server', () => {
+      const candidates = detectPorts(portSources(goBasicRepository));
+
+      expect(candidates.map((c) => c.port)).toEqual([8000]);
+      expect(candidates[0]?.sources[0]).toMatch(/^main\.go:\d+ \(port assignment\)$/);
+    });
+
+    it('should read server.port from Spring Boot properties', () => {
+      const candidates = detectPorts(portSources(javaSpringBootBasicRepository));
+
+      expect(candidates).toEqual([
+        {
+          port: 8080,
+          confidence: 0.8,
+          sources: ['src/main/resources/application.properties:1 (server.port)'],
+        },
+      ]);
+    });
+
+    it('should return both candidates when Flask and Gunicorn disagree', () => {
+      const candidates = detectPorts(portSources(pythonFlaskBasicRepository));
+
+      expect(candidates).toEqual([
+        { port: 5000, confidence: 0.7, sources: [expect.stringMatching(/^app\.py:\d+/)] },
+        { port: 8000, confidence: 0.4, sources: ['Procfile:1 (gunicorn default)'] },
+      ]);
+    });
+
+    it('should read literal bind addresses in Go', () => {
+      const candidates = detectPorts(portSources(monorepoGoNodeRepository));
+
+      expect(candidates.map((c) => c.port)).toEqual([8080, 3000]);
+      expect(candidates[0]?.sources).toEqual([
+        expect.stringMatching(/^services\/inventory\/main\.go:\d+ \(listen call\)$/),
+      ]);
+    });
+  });
+
+  describe('evidence', () => {
+    it('should rank ports with more evidence higher', () => {
+      const candidates = detectPorts({
+        Dockerfile:
+          'FROM python:3.12-slim\nENV PORT=8080\nEXPOSE 8080 9090/tcp\nCMD gunicorn app:app',
+      });
+
+      expect(candidates.map((c) => [c.port, c.confidence])).toEqual([
+        [8080, 0.97],
+        [9090, 0.9],
+        [8000, 0.4],
+      ]);
+      expect(candidates[0]?.sources).toEqual([
+        'Dockerfile:3 (EXPOSE)',
+        'Dockerfile:2 (PORT variable)',
+      ]);
+    });
+
+    it('should use an explicit bind flag instead of the server default', () => {
+      const candidates = detectPorts({ Procfile: 'web: gunicorn --bind 0.0.0.0:7000 app:app' });
+
+      expect(candidates.map((c) => c.port)).toEqual([7000]);
+    });
+
+    it('should detect listen calls with literal ports', () => {
+      expect(detectPorts({ 'server.ts': 'app.listen(4000);' })[0]?.port).toBe(4000);
+      expect(detectPorts({ 'server.ts': 'fastify.listen({ port: 4001 })' })[0]?.port).toBe(4001);
+      expect(
+        detectPorts({ 'main.py': 'uvicorn.run(app, host="0.0.0.0", port=8001)' })[0]?.port,
+      ).toBe(8001);
+    });
+
+    it('should ignore out-of-range ports', () => {
+      expect(detectPorts({ 'server.js': 'app.listen(70000);' })).toEqual([]);
+    });
+
+    it('should fall back to framework ports only without any evidence', () => {
+      expect(detectPorts({ 'index.js': 'console.log("hi");' }, [3000])).toEqual([
+        { port: 3000, confidence: FRAMEWORK_DEFAULT_CONFIDENCE, sources: ['framework default'] },
+      ]);
+      expect(detectPorts({ 'index.js': 'app.listen(4000);' }, [3000]).map((c) => c.port)).toEqual([
+        4000,
+      ]);
+    });
+  });
+
+  describe('isPortSourceFile', () => {
+    it('should scan sources, Dockerfiles and start commands but not tests', () => {
+      expect(isPortSourceFile('server.js')).toBe(true);
+      expect(isPortSourceFile('main.go')).toBe(true);
+      expect(isPortSourceFile('Dockerfile')).toBe(true);
+      expect(isPortSourceFile('Procfile')).toBe(true);
+      expect(isPortSourceFile('server.test.js')).toBe(false);
+      expect(isPortSourceFile('main_test.go')).toBe(false);
+      expect(isPortSourceFile('README.md')).toBe(false);
+    });
+  });
+});
@@ -0,0 +1,120 @@
+/**
+ * Unit tests for runtime and framework version resolution
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  DEFAULT_RUNTIME_VERSIONS,
+  detectFrameworkVersion,
+  detectRuntimeVersion,
+  resolveVersion,
+} from '@/tools/analyze-repo/version-detection';
+
+describe('resolveVersion', () => {
+  it.each([
+    // Pinned: the constraint allows a single version line
+    ['18', '20', '18', true],
+    ['18.x', '20', '18', true],
+    ['^18.2.0', '20', '18', true],
+    ['~18.17.1', '20', '18', true],
+    ['20.11.1', '20', '20', true],
+    ['v1.21', '1.22', '1.21', true],
+    ['==3.11.*', '3.12', '3.11', true],
+    ['~=3.11.4', '3.12', '3.11', true],
+    ['==4.2.7', '5.0', '4.2', true],
+    ['^0.104.1', '0.110', '0.104', true],
+    // Ranges: the default when it fits, otherwise the newest allowed line
+    ['>=18.0.0', '20', '20', false],
+    ['^3.8', '3.12', '3.12', false],
+    ['>=3.8,<3.11', '3.12', '3.10', false],
+    ['>=16 <19', '20', '18', false],
+    ['<3.12', '3.12', '3.11', false],
+    ['>=22', '20', '22', false],
+    ['^16 || ^18', '20', '18', false],
+    ['^18 || ^20', '20', '20', false],
+  ])(
+    'should resolve %s (default %s) to %s, pinned %s',
+    (declared, recommended, version, pinned) => {
+      expect(resolveVersion(declared, recommended)).toEqual({ version, declared, pinned });
+    },
+  );
+
+  it.each([undefined, '', '*', 'latest', 'lts/*'])(
+    'should recommend the default for %p',
+    (declared) => {
+      const result = resolveVersion(declared, '20');
+
+      expect(result.version).toBe('20');
+      expect(result.pinned).toBe(false);
+    },
+  );
+});
+
+describe('detectRuntimeVersion', () => {
+  it('should map languages to runtimes and keep the declared value', () => {
+    expect(detectRuntimeVersion('typescript', '>=18')).toEqual({
+      name: 'node',
+      version: '20',
+      declared: '>=18',
+      pinned: false,
+    });
+    expect(detectRuntimeVersion('go', '1.21')).toEqual({
+      name: 'go',
+      version: '1.21',
+      declared: '1.21',
+      pinned: true,
+    });
+  });
+
+  it('should normalize manifest-specific version spellings', () => {
+    expect(detectRuntimeVersion('java', '1.8')).toMatchObject({ version: '8', pinned: true });
+    expect(detectRuntimeVersion('dotnet', 'net6.0')).toMatchObject({
+      version: '6.0',
+      declared: 'net6.0',
+      pinned: true,
+    });
+  });
+
+  it('should recommend the default for values that are not runtime versions', () => {
+    expect(detectRuntimeVersion('rust', '2021')).toEqual({
+      name: 'rust',
+      version: DEFAULT_RUNTIME_VERSIONS.rust,
+      declared: '2021',
+      pinned: false,
+    });
+    expect(detectRuntimeVersion('dotnet', 'net48')).toMatchObject({
+      version: DEFAULT_RUNTIME_VERSIONS.dotnet,
+      pinned: false,
+    });
+    expect(detectRuntimeVersion('python', undefined)).toEqual({
+      name: 'python',
+      version: DEFAULT_RUNTIME_VERSIONS.python,
+      pinned: false,
+    });
+  });
+
+  it('should return undefined for languages without a runtime image', () => {
+    expect(detectRuntimeVersion('other', '1.0')).toBeUndefined();
+    expect(detectRuntimeVersion(undefined, undefined)).toBeUndefined();
+  });
+});
+
+describe('detectFrameworkVersion', () => {
+  it('should resolve known frameworks against their default', () => {
+    expect(detectFrameworkVersion('express', '^4.18.0')).toEqual({
+      version: '4',
+      declared: '^4.18.0',
+      pinned: true,
+    });
+    expect(detectFrameworkVersion('django', undefined)).toEqual({ version: '5.0', pinned: false });
+  });
+
+  it('should pass through versions of frameworks without a default', () => {
+    expect(detectFrameworkVersion('jakarta-ee', '10.0.0')).toEqual({
+      version: '10.0.0',
+      declared: '10.0.0',
+      pinned: false,
+    });
+    expect(detectFrameworkVersion('jakarta-ee', undefined)).toBeUndefined();
+  });
+});
@@ -108,6 +108,14 @@ jest.mock('node:fs', () => ({
 
 // Mock lib modules
 const mockDockerClient = {
+  ping: jest.fn() as jest.MockedFunction<
+    () => Promise<{
+      ok: boolean;
+      value?: any;
+      error?: string;
+      guidance?: ErrorGuidance;
+    }>
+  >,
   buildImage: jest.fn() as jest.MockedFunction<
     (options: any) => Promise<{
       ok: boolean;
@@ -133,6 +141,26 @@ jest.mock('../../../src/infra/docker/client', () => ({
   createDockerClient: jest.fn(() => mockDockerClient),
 }));
 
+const mockCheckBuildxAvailability = jest.fn<(...args: any[]) => Promise<any>>();
+const mockRunBuildxBuild = jest.fn<(...args: any[]) => Promise<any>>();
+
+jest.mock('../../../src/infra/docker/buildx', () => ({
+  ...jest.requireActual<typeof import('../../../src/infra/docker/buildx')>(
+    '../../../src/infra/docker/buildx',
+  ),
+  checkBuildxAvailability: (...args: any[]) => mockCheckBuildxAvailability(...args),
+  runBuildxBuild: (...args: any[]) => mockRunBuildxBuild(...args),
+}));
+
+const mockGenerateSbom = jest.fn<(...args: any[]) => Promise<any>>();
+
+jest.mock('../../../src/infra/security/sbom', () => ({
+  ...jest.requireActual<typeof import('../../../src/infra/security/sbom')>(
+    '../../../src/infra/security/sbom',
+  ),
+  generateSbom: (...args: any[]) => mockGenerateSbom(...args),
+}));
+
 jest.mock('../../../src/lib/logger', () => ({
   createTimer: jest.fn(() => ({
     end: jest.fn(),
@@ -149,7 +177,10 @@ function createMockToolContext() {
 
 // Import these after mocks are set up
 import { buildImage } from '../../../src/tools/build-image/tool';
-import type { BuildImageParams as BuildImageConfig } from '../../../src/tools/build-image/schema';
+import {
+  buildImageSchema,
+  type BuildImageParams as BuildImageConfig,
+} from '../../../src/tools/build-image/schema';
 
 const mockFs = fs as jest.Mocked<typeof fs>;
 
@@ -185,6 +216,10 @@ CMD ["node", "index.js"]`;
     mockFs.readFile.mockResolvedValue(mockDockerfile);
     mockFs.writeFile.mockResolvedValue(undefined);
 
+    mockDockerClient.ping.mockResolvedValue(
+      createSuccessResult({ socketPath: '/var/run/docker.sock', version: '27.0.0' }),
+    );
+
     // Default successful Docker build
     mockDockerClient.buildImage.mockResolvedValue(
       createSuccessResult({
@@ -221,6 +256,21 @@ CMD ["node", "index.js"]`;
       }
     });
 
+    it('should report daemon check, build and finishing as progress steps', async () => {
+      const progress = jest.fn(async (..._args: unknown[]) => undefined);
+
+      const result = await buildImage(config, { ...createMockToolContext(), progress });
+
+      expect(result.ok).toBe(true);
+      const steps = progress.mock.calls.map(([message, index, total]) => [message, index, total]);
+      expect(steps).toEqual([
+        ['Docker daemon reachable', 1, 3],
+        ['Built myapp:latest', 2, 3],
+        ['Build complete', 3, 3],
+      ]);
+      expect(progress.mock.calls[2]?.[3]).toMatchObject({ step: 'Build complete', etaMs: 0 });
+    });
+
     it('should pass build arguments to Docker client', async () => {
       config.buildArgs = {
         NODE_ENV: 'development',
@@ -538,6 +588,27 @@ CMD ["node", "index.js"]`;
   });
 
   describe('Error Scenarios - Infrastructure', () => {
+    it('should stop before building when the Docker daemon is unreachable', async () => {
+      mockDockerClient.ping.mockResolvedValueOnce({
+        ok: false,
+        error: 'Docker is unavailable: No Docker socket exists at /var/run/docker.sock',
+        guidance: {
+          message: 'Docker daemon is not reachable',
+          hint: 'No Docker socket exists at /var/run/docker.sock',
+          resolution: 'Start Docker',
+          details: { code: 'DOCKER_UNAVAILABLE', socketPath: '/var/run/docker.sock' },
+        },
+      });
+
+      const result = await buildImage(config, createMockToolContext());
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.guidance?.details).toMatchObject({ code: 'DOCKER_UNAVAILABLE' });
+      }
+      expect(mockDockerClient.buildImage).not.toHaveBeenCalled();
+    });
+
     it('should fail gracefully when Docker daemon is not running', async () => {
       mockFs.readFile.mockResolvedValue(mockDockerfile);
       mockDockerClient.buildImage.mockResolvedValue({
@@ -838,6 +909,363 @@ CMD ["node", "index.js"]`;
     });
   });
 
+  describe('Multi-platform Builds', () => {
+    beforeEach(() => {
+      mockCheckBuildxAvailability.mockResolvedValue(
+        createSuccessResult({
+          version: 'v0.12.1',
+          builder: 'multiarch',
+          platforms: ['linux/amd64', 'linux/arm64'],
+        }),
+      );
+      mockRunBuildxBuild.mockResolvedValue(
+        createSuccessResult({
+          digest: 'sha256:manifestlist',
+          imageId: 'sha256:manifestlist',
+          platforms: ['linux/amd64', 'linux/arm64'],
+          pushed: true,
+          logs: ['#1 DONE'],
+          buildTime: 1000,
+        }),
+      );
+    });
+
+    it('should build through buildx when several platforms are requested', async () => {
+      const result = await buildImage(
+        { ...config, platforms: ['linux/amd64', 'linux/arm64'] },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.digest).toBe('sha256:manifestlist');
+        expect(result.value.pushed).toBe(true);
+        expect(result.value.platforms).toEqual(['linux/amd64', 'linux/arm64']);
+      }
+      expect(mockRunBuildxBuild).toHaveBeenCalledWith(
+        expect.objectContaining({ platforms: ['linux/amd64', 'linux/arm64'] }),
+        expect.anything(),
+      );
+      expect(mockDockerClient.buildImage).not.toHaveBeenCalled();
+    });
+
+    it('should keep the single-platform path for one platform', async () => {
+      const result = await buildImage(
+        { ...config, platforms: ['linux/arm64'] },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      expect(mockCheckBuildxAvailability).not.toHaveBeenCalled();
+      expect(mockDockerClient.buildImage).toHaveBeenCalledWith(
+        expect.objectContaining({ platform: 'linux/arm64' }),
+      );
+    });
+
+    it('should fail with setup guidance when buildx is unavailable', async () => {
+      mockCheckBuildxAvailability.mockResolvedValue({
+        ok: false,
+        error: 'Docker buildx is not available',
+        guidance: { message: 'Multi-platform builds require docker buildx' },
+      });
+
+      const result = await buildImage(
+        { ...config, platforms: ['linux/amd64', 'linux/arm64'] },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('buildx is not available');
+      }
+      expect(mockRunBuildxBuild).not.toHaveBeenCalled();
+    });
+  });
+
+  describe('Build Cache', () => {
+    it('should pass cache options to buildx and report cache usage', async () => {
+      mockCheckBuildxAvailability.mockResolvedValue(
+        createSuccessResult({ version: 'v0.12.1', builder: 'default', platforms: [] }),
+      );
+      mockRunBuildxBuild.mockResolvedValue(
+        createSuccessResult({
+          digest: 'sha256:manifest',
+          imageId: 'sha256:config',
+          platforms: [],
+          pushed: false,
+          logs: [],
+          buildTime: 1000,
+          cache: { hits: 3, misses: 1 },
+        }),
+      );
+
+      const result = await buildImage(
+        {
+          ...config,
+          cacheFrom: ['type=registry,ref=registry.example.com/app:cache'],
+          cacheTo: ['type=registry,ref=registry.example.com/app:cache,mode=max'],
+        },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.imageId).toBe('sha256:config');
+        expect(result.value.cache).toEqual({ hits: 3, misses: 1 });
+        expect(result.value.summary).toContain('Cache: 3 hits, 1 misses');
+      }
+      expect(mockRunBuildxBuild).toHaveBeenCalledWith(
+        expect.objectContaining({
+          platforms: [],
+          cacheFrom: ['type=registry,ref=registry.example.com/app:cache'],
+          cacheTo: ['type=registry,ref=registry.example.com/app:cache,mode=max'],
+        }),
+        expect.anything(),
+      );
+    });
+
+    it('should accept an inline cache export and keep it on the docker driver', async () => {
+      const params = buildImageSchema.parse({ ...config, cacheTo: ['type=inline'] });
+      mockCheckBuildxAvailability.mockResolvedValue(
+        createSuccessResult({
+          version: 'v0.12.1',
+          builder: 'default',
+          driver: 'docker',
+          platforms: [],
+        }),
+      );
+      mockRunBuildxBuild.mockResolvedValue(
+        createSuccessResult({
+          digest: 'sha256:manifest',
+          imageId: 'sha256:config',
+          platforms: [],
+          pushed: false,
+          logs: [],
+          buildTime: 1000,
+        }),
+      );
+
+      const result = await buildImage(params, createMockToolContext());
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.warnings ?? []).toEqual([]);
+      }
+      expect(mockRunBuildxBuild).toHaveBeenCalledWith(
+        expect.objectContaining({ cacheTo: ['type=inline'] }),
+        expect.anything(),
+      );
+      expect(buildImageSchema.safeParse({ ...config, cacheFrom: ['type=inline'] }).success).toBe(
+        false,
+      );
+    });
+
+    it('should skip cache export the docker driver cannot write', async () => {
+      mockCheckBuildxAvailability.mockResolvedValue(
+        createSuccessResult({
+          version: 'v0.12.1',
+          builder: 'default',
+          driver: 'docker',
+          platforms: [],
+        }),
+      );
+      mockRunBuildxBuild.mockResolvedValue(
+        createSuccessResult({
+          digest: 'sha256:manifest',
+          imageId: 'sha256:config',
+          platforms: [],
+          pushed: false,
+          logs: [],
+          buildTime: 1000,
+        }),
+      );
+
+      const result = await buildImage(
+        {
+          ...config,
+          cacheFrom: ['type=registry,ref=registry.example.com/app:cache'],
+          cacheTo: ['type=registry,ref=registry.example.com/app:cache,mode=max', 'type=inline'],
+        },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.warnings).toEqual([
+          expect.stringContaining("Builder 'default' uses the docker driver"),
+        ]);
+      }
+      expect(mockRunBuildxBuild).toHaveBeenCalledWith(
+        expect.objectContaining({
+          cacheFrom: ['type=registry,ref=registry.example.com/app:cache'],
+          cacheTo: ['type=inline'],
+        }),
+        expect.anything(),
+      );
+    });
+
+    it('should warn and build without cache when BuildKit is unavailable', async () => {
+      mockCheckBuildxAvailability.mockResolvedValue({
+        ok: false,
+        error: 'Docker buildx is not available',
+      });
+
+      const result = await buildImage(
+        { ...config, cacheFrom: ['type=gha'] },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.warnings).toEqual([
+          expect.stringContaining('BuildKit is not available'),
+        ]);
+      }
+      expect(mockRunBuildxBuild).not.toHaveBeenCalled();
+      expect(mockDockerClient.buildImage).toHaveBeenCalled();
+    });
+  });
+
+  describe('SBOM Generation', () => {
+    it('should not generate an SBOM by default', async () => {
+      const result = await buildImage(config, createMockToolContext());
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.sbom).toBeUndefined();
+      }
+      expect(mockGenerateSbom).not.toHaveBeenCalled();
+    });
+
+    it('should attach the SBOM artifact in the requested format', async () => {
+      mockGenerateSbom.mockResolvedValue({
+        artifact: {
+          format: 'spdx',
+          generator: 'syft',
+          path: '/tmp/sbom/myapp_latest.spdx.json',
+          mediaType: 'application/spdx+json',
+        },
+        warnings: [],
+      });
+
+      const result = await buildImage(
+        { ...config, generateSbom: true, sbomFormat: 'spdx' },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.sbom).toEqual({
+          format: 'spdx',
+          generator: 'syft',
+          path: '/tmp/sbom/myapp_latest.spdx.json',
+          mediaType: 'application/spdx+json',
+        });
+        expect(result.value.summary).toContain('SBOM: /tmp/sbom/myapp_latest.spdx.json');
+      }
+      expect(mockGenerateSbom).toHaveBeenCalledWith(
+        'myapp:latest',
+        'spdx',
+        expect.objectContaining({
+          generators: [
+            expect.objectContaining({ name: 'syft' }),
+            expect.objectContaining({ name: 'inventory' }),
+          ],
+        }),
+      );
+    });
+
+    it('should succeed with a warning when no SBOM generator is installed', async () => {
+      mockGenerateSbom.mockResolvedValue({
+        warnings: ['No SBOM generator is available; SBOM was not generated'],
+      });
+
+      const result = await buildImage({ ...config, generateSbom: true }, createMockToolContext());
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.sbom).toBeUndefined();
+        expect(result.value.warnings).toContain(
+          'No SBOM generator is available; SBOM was not generated',
+        );
+      }
+    });
+  });
+
+  describe('Dry Run', () => {
+    it('should return the build plan without calling the Docker daemon', async () => {
+      const result = await buildImage(
+        { ...config, buildArgs: { API_TOKEN: 'secret-value' }, dryRun: true },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      if (!result.ok) return;
+      expect(result.value.dryRun).toBe(true);
+      expect(result.value.imageId).toBe('');
+      expect(result.value.summary).toContain('Nothing was built');
+      expect(result.value.plan).toMatchObject({
+        dockerfile: 'Dockerfile',
+        tags: ['myapp:latest', 'myapp:v1.0'],
+        platforms: [],
+        builder: 'docker',
+      });
+      expect(result.value.plan?.buildArgs).toContain('API_TOKEN');
+      expect(JSON.stringify(result.value)).not.toContain('secret-value');
+      expect(result.value.securityWarnings).toContain('Potential secret in build arg: API_TOKEN');
+      expect(mockDockerClient.buildImage).not.toHaveBeenCalled();
+      expect(mockDockerClient.tagImage).not.toHaveBeenCalled();
+    });
+
+    it('should plan a buildx build without running buildx or generating an SBOM', async () => {
+      const result = await buildImage(
+        {
+          ...config,
+          tags: ['myregistry.io/app:1.0'],
+          platforms: ['linux/amd64', 'linux/arm64'],
+          cacheFrom: ['type=gha'],
+          generateSbom: true,
+          dryRun: true,
+        },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      if (!result.ok) return;
+      expect(result.value.plan).toMatchObject({
+        builder: 'buildx',
+        platforms: ['linux/amd64', 'linux/arm64'],
+        cacheFrom: ['type=gha'],
+      });
+      expect(mockCheckBuildxAvailability).not.toHaveBeenCalled();
+      expect(mockRunBuildxBuild).not.toHaveBeenCalled();
+      expect(mockGenerateSbom).not.toHaveBeenCalled();
+      expect(mockDockerClient.buildImage).not.toHaveBeenCalled();
+    });
+
+    it('should fail the plan for tags that cannot be applied', async () => {
+      const result = await buildImage(
+        { ...config, tags: ['myapp:latest', 'myapp@sha256:' + 'a'.repeat(64)], dryRun: true },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(false);
+      if (result.ok) return;
+      expect(result.error).toContain('Invalid image tags');
+      expect(result.guidance?.details?.invalidTags).toEqual(['myapp@sha256:' + 'a'.repeat(64)]);
+      expect(mockDockerClient.buildImage).not.toHaveBeenCalled();
+    });
+
+    it('should still fail when the Dockerfile is missing', async () => {
+      mockFs.readFile.mockRejectedValue(Object.assign(new Error('ENOENT'), { code: 'ENOENT' }));
+
+      const result = await buildImage({ ...config, dryRun: true }, createMockToolContext());
+
+      expect(result.ok).toBe(false);
+      expect(mockDockerClient.buildImage).not.toHaveBeenCalled();
+    });
+  });
+
   describe('Environment Variables', () => {
     beforeEach(() => {
       mockFs.access.mockResolvedValue(undefined);
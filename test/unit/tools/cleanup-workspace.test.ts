@@ -0,0 +1,106 @@
+/**
+ * Unit Tests: Cleanup Workspace Tool
+ * Tests removing an idle session's saved state while active sessions are kept
+ */
+
+import { jest } from '@jest/globals';
+import { promises as fs } from 'node:fs';
+import * as os from 'node:os';
+import * as path from 'node:path';
+import cleanupWorkspaceTool from '@/tools/cleanup-workspace/tool';
+import {
+  createFileWorkflowStateStore,
+  createMemoryWorkflowStateStore,
+  createWorkflowState,
+  recordPipelineStage,
+  type WorkflowStateStore,
+} from '@/lib/workflow-state';
+import { createMockLogger } from '../../__support__/utilities/mock-factories';
+
+const HOUR = 60 * 60 * 1000;
+
+describe('cleanupWorkspaceTool', () => {
+  let mockLogger: ReturnType<typeof createMockLogger>;
+  let dir: string;
+  let store: WorkflowStateStore;
+
+  const context = (id = 'session-current') =>
+    ({ logger: mockLogger, workflow: { store, id } }) as any;
+
+  /** Save a session's state, then date its file as last used `ageMs` ago */
+  const seed = async (id: string, ageMs: number): Promise<void> => {
+    const state = recordPipelineStage(createWorkflowState(id), 'analyze-repo', {
+      argsDigest: 'abc123',
+      output: { language: 'java' },
+      completedAt: '2025-01-01T00:00:00.000Z',
+    });
+    await createFileWorkflowStateStore(dir).save(state);
+    const usedAt = new Date(Date.now() - ageMs);
+    await fs.utimes(path.join(dir, `${id}.json`), usedAt, usedAt);
+  };
+
+  beforeEach(async () => {
+    mockLogger = createMockLogger();
+    jest.clearAllMocks();
+    dir = await fs.mkdtemp(path.join(os.tmpdir(), 'cleanup-workspace-'));
+    await seed('session-stale', 3 * HOUR);
+    await seed('session-active', 60 * 1000);
+    store = createFileWorkflowStateStore(dir);
+  });
+
+  afterEach(async () => {
+    await fs.rm(dir, { recursive: true, force: true });
+  });
+
+  it('should remove a stale session and report the bytes reclaimed', async () => {
+    const { size } = await fs.stat(path.join(dir, 'session-stale.json'));
+
+    const result = await cleanupWorkspaceTool.handler({ workflowId: 'session-stale' }, context());
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(result.value).toMatchObject({ removed: true, reclaimedBytes: size, dryRun: false });
+    expect(result.value.summary).toContain('Removed saved state of workflow session-stale');
+    expect(await fs.readdir(dir)).toEqual(['session-active.json']);
+  });
+
+  it('should keep an active session', async () => {
+    const result = await cleanupWorkspaceTool.handler({ workflowId: 'session-active' }, context());
+
+    expect(result.ok && result.value).toMatchObject({
+      removed: false,
+      reclaimedBytes: 0,
+      skippedReason: 'active',
+    });
+    expect((await fs.readdir(dir)).sort()).toEqual(['session-active.json', 'session-stale.json']);
+  });
+
+  it("should default to the caller's session and leave files alone on a dry run", async () => {
+    const result = await cleanupWorkspaceTool.handler({ dryRun: true }, context('session-stale'));
+
+    expect(result.ok && result.value).toMatchObject({
+      workflowId: 'session-stale',
+      removed: true,
+      dryRun: true,
+    });
+    expect(result.ok && result.value.summary).toContain('Dry run');
+    expect((await fs.readdir(dir)).sort()).toEqual(['session-active.json', 'session-stale.json']);
+  });
+
+  it('should report a session with no saved state', async () => {
+    const result = await cleanupWorkspaceTool.handler({ workflowId: 'session-gone' }, context());
+
+    expect(result.ok && result.value.skippedReason).toBe('not-found');
+  });
+
+  it('should fail when the store cannot clean up', async () => {
+    store = createMemoryWorkflowStateStore();
+
+    const result = await cleanupWorkspaceTool.handler({}, context());
+
+    expect(result.ok).toBe(false);
+    expect(!result.ok && result.guidance?.resolution).toContain(
+      'CONTAINERIZATION_ASSIST_WORKFLOW_STATE_DIR',
+    );
+  });
+});
@@ -0,0 +1,158 @@
+/**
+ * Unit Tests: Deploy Tool
+ * Tests manifest application and server-side dry runs against a fake apply function
+ */
+
+import { jest } from '@jest/globals';
+import { writeFileSync } from 'node:fs';
+import { join } from 'node:path';
+import { createTestTempDir } from '../../__support__/utilities/tmp-helpers';
+
+function createSuccessResult<T>(value: T) {
+  return {
+    ok: true as const,
+    value,
+  };
+}
+
+function createMockLogger() {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+    trace: jest.fn(),
+    fatal: jest.fn(),
+    child: jest.fn().mockReturnThis(),
+  } as any;
+}
+
+const mockApply = jest.fn<any>();
+
+jest.mock('../../../src/infra/kubernetes/idempotent-apply', () => ({
+  ...(jest.requireActual('../../../src/infra/kubernetes/idempotent-apply') as object),
+  createIdempotentApply: jest.fn(() => mockApply),
+}));
+
+jest.mock('../../../src/lib/logger', () => ({
+  createTimer: jest.fn(() => ({
+    end: jest.fn(),
+    error: jest.fn(),
+  })),
+  createLogger: jest.fn(() => createMockLogger()),
+}));
+
+function createMockToolContext() {
+  return {
+    logger: createMockLogger(),
+  } as any;
+}
+
+import { default as deployTool } from '../../../src/tools/deploy/tool';
+
+const DEPLOYMENT = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+`;
+
+const SERVICE = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: edge
+spec:
+  ports:
+    - port: 80
+`;
+
+describe('deploy', () => {
+  let dir: string;
+  let cleanup: () => Promise<void>;
+  let manifestPaths: string[];
+
+  beforeEach(() => {
+    jest.clearAllMocks();
+    const tmp = createTestTempDir('deploy-test-');
+    dir = tmp.dir.name;
+    cleanup = tmp.cleanup;
+
+    manifestPaths = [join(dir, 'deployment.yaml'), join(dir, 'service.yaml')];
+    writeFileSync(manifestPaths[0] as string, DEPLOYMENT);
+    writeFileSync(manifestPaths[1] as string, SERVICE);
+
+    mockApply.mockImplementation(async (resource: unknown) => createSuccessResult(resource));
+  });
+
+  afterEach(async () => {
+    await cleanup();
+  });
+
+  it('should send every resource as a server-side dry run and report that nothing changed', async () => {
+    const result = await deployTool.handler(
+      { manifestPaths, namespace: 'staging', dryRun: true },
+      createMockToolContext(),
+    );
+
+    expect(mockApply).toHaveBeenCalledTimes(2);
+    for (const [, options] of mockApply.mock.calls) {
+      expect(options).toEqual({ dryRun: true });
+    }
+    expect(result.ok).toBe(true);
+    if (result.ok) {
+      expect(result.value.dryRun).toBe(true);
+      expect(result.value.summary).toContain('Nothing was changed');
+      expect(result.value.workflowHints?.nextStep).toBe('deploy');
+    }
+  });
+
+  it('should apply resources in order, defaulting the namespace only where unset', async () => {
+    const result = await deployTool.handler(
+      { manifestPaths, namespace: 'staging' },
+      createMockToolContext(),
+    );
+
+    expect(mockApply.mock.calls.map(([resource]) => resource.metadata)).toEqual([
+      { name: 'web', namespace: 'staging' },
+      { name: 'web', namespace: 'edge' },
+    ]);
+    expect(mockApply.mock.calls[0]?.[1]).toEqual({ dryRun: false });
+    expect(result.ok && result.value.resources).toEqual([
+      { kind: 'Deployment', name: 'web', namespace: 'staging' },
+      { kind: 'Service', name: 'web', namespace: 'edge' },
+    ]);
+    expect(result.ok && result.value.workflowHints?.nextStep).toBe('verify-deploy');
+  });
+
+  it('should stop at the first rejected resource and list what was already applied', async () => {
+    mockApply.mockResolvedValueOnce(createSuccessResult({})).mockResolvedValueOnce({
+      ok: false,
+      error: 'Service "web" is invalid: spec.ports[0].port: Invalid value: 0',
+      guidance: { message: 'Invalid resource', resolution: 'Fix the port' },
+    });
+
+    const result = await deployTool.handler({ manifestPaths }, createMockToolContext());
+
+    expect(result.ok).toBe(false);
+    if (!result.ok) {
+      expect(result.error).toContain('Failed to apply Service/web');
+      expect(result.guidance?.resolution).toBe('Fix the port');
+      expect(result.guidance?.details).toMatchObject({
+        failed: 'Service/web',
+        applied: [{ kind: 'Deployment', name: 'web', namespace: 'default' }],
+      });
+    }
+  });
+
+  it('should fail without calling the cluster when the manifests hold no resources', async () => {
+    const empty = join(dir, 'empty.yaml');
+    writeFileSync(empty, '# nothing here\n');
+
+    const result = await deployTool.handler({ manifestPaths: [empty] }, createMockToolContext());
+
+    expect(result.ok).toBe(false);
+    expect(mockApply).not.toHaveBeenCalled();
+  });
+});
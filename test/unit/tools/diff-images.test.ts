@@ -0,0 +1,362 @@
+/**
+ * Unit Tests: Diff Images Tool
+ * Tests file and package classification between two synthetic image trees
+ */
+
+import { jest } from '@jest/globals';
+import type { ImageFileEntry, ImageFilesystem } from '../../../src/infra/docker/image-filesystem';
+import { parseSbomPackages } from '../../../src/infra/security/sbom';
+import { diffFilesystems, diffPackages } from '../../../src/tools/diff-images/image-diff';
+
+function createMockLogger() {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+    trace: jest.fn(),
+    fatal: jest.fn(),
+    child: jest.fn().mockReturnThis(),
+  } as any;
+}
+
+const mockDockerClient = {
+  ping: jest.fn(async () => ({
+    ok: true,
+    value: { socketPath: '/var/run/docker.sock', version: '27.0.0' },
+  })),
+  inspectImage: jest.fn<(imageId: string) => Promise<any>>(),
+  saveImage: jest.fn<(imageId: string) => Promise<any>>(),
+};
+
+const mockReadImageFilesystem = jest.fn<(archive: any) => Promise<any>>();
+
+const mockSbomGenerator = {
+  name: 'syft',
+  isAvailable: jest.fn<() => Promise<boolean>>(),
+  generate: jest.fn<(imageRef: string, format: string) => Promise<any>>(),
+};
+
+jest.mock('../../../src/infra/docker/client', () => ({
+  createDockerClient: jest.fn(() => mockDockerClient),
+}));
+
+jest.mock('../../../src/infra/docker/image-filesystem', () => ({
+  readImageFilesystem: (archive: any) => mockReadImageFilesystem(archive),
+}));
+
+jest.mock('../../../src/infra/security/sbom', () => ({
+  ...jest.requireActual<typeof import('../../../src/infra/security/sbom')>(
+    '../../../src/infra/security/sbom',
+  ),
+  createSyftGenerator: jest.fn(() => mockSbomGenerator),
+}));
+
+jest.mock('../../../src/lib/logger', () => ({
+  createTimer: jest.fn(() => ({
+    end: jest.fn(),
+    error: jest.fn(),
+  })),
+  createLogger: jest.fn(() => createMockLogger()),
+}));
+
+import { default as diffImagesTool } from '../../../src/tools/diff-images/tool';
+
+const MB = 1024 * 1024;
+
+const dir = (path: string): ImageFileEntry => ({ path, type: 'directory', size: 0, mode: 0o755 });
+
+const file = (
+  path: string,
+  size: number,
+  digest = `sha256:${path}`,
+  mode = 0o644,
+): ImageFileEntry => ({ path, type: 'file', size, mode, digest });
+
+const link = (path: string, linkTarget: string): ImageFileEntry => ({
+  path,
+  type: 'symlink',
+  size: 0,
+  mode: 0o777,
+  linkTarget,
+});
+
+const tree = (...entries: ImageFileEntry[]): ImageFilesystem =>
+  new Map(entries.map((entry) => [entry.path, entry]));
+
+/** myapp:1.0 - a small Node.js app */
+const BASE = tree(
+  dir('/app'),
+  file('/app/index.js', 2_000),
+  file('/app/package.json', 800),
+  file('/app/README.md', 4_000),
+  dir('/usr/local/bin'),
+  file('/usr/local/bin/node', 90 * MB, 'sha256:node-20.10'),
+  link('/usr/local/bin/npm', '../lib/node_modules/npm/bin/npm-cli.js'),
+  file('/usr/local/bin/entrypoint.sh', 300),
+  file('/etc/config', 100),
+);
+
+/** myapp:1.1 - dependencies copied in, node upgraded, docs dropped */
+const TARGET = tree(
+  dir('/app'),
+  file('/app/index.js', 2_000),
+  file('/app/package.json', 950, 'sha256:package-1.1'),
+  dir('/app/node_modules'),
+  file('/app/node_modules/express/index.js', 1_500),
+  file('/app/node_modules/express/lib/router.js', 30_000),
+  file('/app/node_modules/.bin/tsc', 40 * MB),
+  dir('/usr/local/bin'),
+  file('/usr/local/bin/node', 96 * MB, 'sha256:node-20.12'),
+  link('/usr/local/bin/npm', '../lib/node_modules/npm/bin/npm.js'),
+  file('/usr/local/bin/entrypoint.sh', 300, 'sha256:/usr/local/bin/entrypoint.sh', 0o755),
+  dir('/etc/config'),
+);
+
+describe('diff-images', () => {
+  describe('diffFilesystems', () => {
+    it('should classify files as added, removed, changed or unchanged', () => {
+      const diff = diffFilesystems(BASE, TARGET);
+
+      expect(diff.added.map((c) => c.path)).toEqual([
+        '/app/node_modules/.bin/tsc',
+        '/app/node_modules/express/lib/router.js',
+        '/app/node_modules/express/index.js',
+      ]);
+      expect(diff.removed.map((c) => c.path)).toEqual(['/app/README.md', '/etc/config']);
+      expect(diff.changed.map((c) => [c.path, c.reasons])).toEqual([
+        ['/usr/local/bin/node', ['content']],
+        ['/app/package.json', ['content']],
+        ['/usr/local/bin/entrypoint.sh', ['mode']],
+        ['/usr/local/bin/npm', ['link']],
+      ]);
+      expect(diff.counts).toEqual({ added: 3, removed: 2, changed: 4, unchanged: 1 });
+      expect(diff.truncated).toBe(false);
+    });
+
+    it('should report size deltas without file contents', () => {
+      const diff = diffFilesystems(BASE, TARGET);
+
+      expect(diff.changed[0]).toEqual({
+        path: '/usr/local/bin/node',
+        type: 'file',
+        size: 96 * MB,
+        previousSize: 90 * MB,
+        sizeDelta: 6 * MB,
+        reasons: ['content'],
+      });
+      expect(diff.removed[0]).toMatchObject({ size: 0, previousSize: 4_000, sizeDelta: -4_000 });
+      expect(diff.sizeDelta).toBe(40 * MB + 31_500 + 6 * MB + 150 - 4_000 - 100);
+    });
+
+    it('should detect content changes by digest when the size is unchanged', () => {
+      const diff = diffFilesystems(
+        tree(file('/bin/app', 5_000, 'sha256:aaa')),
+        tree(file('/bin/app', 5_000, 'sha256:bbb')),
+      );
+
+      expect(diff.changed).toEqual([
+        {
+          path: '/bin/app',
+          type: 'file',
+          size: 5_000,
+          previousSize: 5_000,
+          sizeDelta: 0,
+          reasons: ['content'],
+        },
+      ]);
+    });
+
+    it('should roll changes up by directory', () => {
+      const diff = diffFilesystems(BASE, TARGET);
+
+      expect(diff.directories.map((d) => [d.path, d.added, d.removed, d.changed])).toEqual([
+        ['/app/node_modules', 3, 0, 0],
+        ['/usr/local', 0, 0, 3],
+        ['/app', 0, 1, 1],
+        ['/etc', 0, 1, 0],
+      ]);
+      expect(diff.directories[0]?.sizeDelta).toBe(40 * MB + 31_500);
+    });
+
+    it('should cap each list at maxEntries, largest first', () => {
+      const diff = diffFilesystems(BASE, TARGET, { maxEntries: 1 });
+
+      expect(diff.added.map((c) => c.path)).toEqual(['/app/node_modules/.bin/tsc']);
+      expect(diff.changed).toHaveLength(1);
+      expect(diff.counts.added).toBe(3);
+      expect(diff.truncated).toBe(true);
+    });
+  });
+
+  describe('package diff', () => {
+    const cyclonedx = JSON.stringify({
+      bomFormat: 'CycloneDX',
+      components: [
+        { type: 'operating-system', name: 'debian', version: '12' },
+        { type: 'library', name: 'express', version: '4.18.2', purl: 'pkg:npm/express@4.18.2' },
+        {
+          type: 'library',
+          group: '@types',
+          name: 'node',
+          version: '20.1.0',
+          purl: 'pkg:npm/%40types/node@20.1.0',
+        },
+        { type: 'library', name: 'ms', version: '2.0.0', purl: 'pkg:npm/ms@2.0.0' },
+        { type: 'library', name: 'ms', version: '2.1.3', purl: 'pkg:npm/ms@2.1.3' },
+        {
+          type: 'library',
+          name: 'zlib1g',
+          version: '1:1.2.13',
+          purl: 'pkg:deb/debian/zlib1g@1:1.2.13',
+        },
+        { type: 'file', name: '/usr/lib/libz.so.1' },
+      ],
+    });
+
+    const spdx = JSON.stringify({
+      spdxVersion: 'SPDX-2.3',
+      packages: [
+        { SPDXID: 'SPDXRef-Image', name: 'myapp:1.1', versionInfo: 'sha256:abc' },
+        {
+          name: 'express',
+          versionInfo: '4.19.2',
+          externalRefs: [{ referenceType: 'purl', referenceLocator: 'pkg:npm/express@4.19.2' }],
+        },
+        {
+          name: 'ms',
+          versionInfo: '2.1.3',
+          externalRefs: [{ referenceType: 'purl', referenceLocator: 'pkg:npm/ms@2.1.3' }],
+        },
+        {
+          name: 'zlib1g',
+          versionInfo: '1:1.2.13',
+          externalRefs: [
+            { referenceType: 'cpe23Type', referenceLocator: 'cpe:2.3:a:zlib1g:zlib1g' },
+            { referenceType: 'purl', referenceLocator: 'pkg:deb/debian/zlib1g@1:1.2.13' },
+          ],
+        },
+        {
+          name: 'lodash',
+          versionInfo: '4.17.21',
+          externalRefs: [{ referenceType: 'purl', referenceLocator: 'pkg:npm/lodash@4.17.21' }],
+        },
+      ],
+    });
+
+    it('should list packages from CycloneDX and SPDX documents', () => {
+      const before = parseSbomPackages(cyclonedx);
+      const after = parseSbomPackages(spdx);
+
+      expect([...before.keys()]).toEqual([
+        'pkg:npm/express',
+        'pkg:npm/@types/node',
+        'pkg:npm/ms',
+        'pkg:deb/debian/zlib1g',
+      ]);
+      expect(before.get('pkg:npm/@types/node')).toEqual([
+        { name: '@types/node', version: '20.1.0', type: 'npm' },
+      ]);
+      expect(before.get('pkg:npm/ms')).toHaveLength(2);
+      expect(after.has('myapp:1.1')).toBe(false);
+    });
+
+    it('should report added, removed and changed packages', () => {
+      const diff = diffPackages(parseSbomPackages(cyclonedx), parseSbomPackages(spdx));
+
+      expect(diff.added).toEqual([{ name: 'lodash', version: '4.17.21', type: 'npm' }]);
+      expect(diff.removed).toEqual([{ name: '@types/node', version: '20.1.0', type: 'npm' }]);
+      expect(diff.changed).toEqual([
+        { name: 'express', type: 'npm', previousVersions: ['4.18.2'], versions: ['4.19.2'] },
+        { name: 'ms', type: 'npm', previousVersions: ['2.0.0', '2.1.3'], versions: ['2.1.3'] },
+      ]);
+    });
+  });
+
+  describe('tool handler', () => {
+    beforeEach(() => {
+      jest.clearAllMocks();
+      mockDockerClient.inspectImage.mockImplementation(async (imageId: string) => ({
+        ok: true,
+        value: { Id: imageId, Size: imageId === 'myapp:1.0' ? 120 * MB : 166 * MB },
+      }));
+      mockDockerClient.saveImage.mockImplementation(async (imageId: string) => ({
+        ok: true,
+        value: imageId,
+      }));
+      mockReadImageFilesystem.mockImplementation(async (archive: string) => ({
+        ok: true,
+        value: archive === 'myapp:1.0' ? BASE : TARGET,
+      }));
+    });
+
+    it('should return the diff with a size delta and summary', async () => {
+      mockSbomGenerator.isAvailable.mockResolvedValue(false);
+
+      const result = await diffImagesTool.handler(
+        { baseImage: 'myapp:1.0', targetImage: 'myapp:1.1' },
+        { logger: createMockLogger() } as any,
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.imageSizeDelta).toBe(46 * MB);
+        expect(result.value.counts).toEqual({ added: 3, removed: 2, changed: 4, unchanged: 1 });
+        expect(result.value.packages).toBeUndefined();
+        expect(result.value.warnings?.[0]).toContain('Package diff skipped');
+        expect(result.value.summary).toBe(
+          '✅ Compared myapp:1.0 → myapp:1.1: +46MB (120MB → 166MB). 3 files added, 2 removed, 4 changed. Largest change: /app/node_modules (+40MB).',
+        );
+      }
+    });
+
+    it('should include package changes when syft is available', async () => {
+      mockSbomGenerator.isAvailable.mockResolvedValue(true);
+      mockSbomGenerator.generate.mockImplementation(async (imageRef: string) => ({
+        ok: true,
+        value: JSON.stringify({
+          components: [
+            {
+              type: 'library',
+              name: 'express',
+              version: imageRef === 'myapp:1.0' ? '4.18.2' : '4.19.2',
+              purl: 'pkg:npm/express',
+            },
+          ],
+        }),
+      }));
+
+      const result = await diffImagesTool.handler(
+        { baseImage: 'myapp:1.0', targetImage: 'myapp:1.1' },
+        { logger: createMockLogger() } as any,
+      );
+
+      expect(mockSbomGenerator.generate).toHaveBeenCalledWith('myapp:1.0', 'cyclonedx');
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.packages?.changed).toHaveLength(1);
+        expect(result.value.warnings).toBeUndefined();
+        expect(result.value.summary).toContain('Packages: 0 added, 0 removed, 1 changed.');
+      }
+    });
+
+    it('should fail when an image cannot be exported', async () => {
+      mockDockerClient.saveImage.mockResolvedValue({
+        ok: false,
+        error: 'No such image: myapp:1.0',
+        guidance: { message: 'Image not found' },
+      });
+
+      const result = await diffImagesTool.handler(
+        { baseImage: 'myapp:1.0', targetImage: 'myapp:1.1' },
+        { logger: createMockLogger() } as any,
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toBe('Failed to export myapp:1.0: No such image: myapp:1.0');
+        expect(result.guidance?.message).toBe('Image not found');
+      }
+    });
+  });
+});
@@ -5,6 +5,7 @@
 
 import { jest } from '@jest/globals';
 import { promises as fs } from 'node:fs';
+import { join } from 'node:path';
 import { createMockValidatePath } from '../../__support__/utilities/mocks';
 
 // Result Type Helpers for Testing
@@ -1004,4 +1005,54 @@ CMD ["node", "index.js"]`;
       }
     });
   });
+
+  describe('Secret Rules', () => {
+    const rulesPath = join(__dirname, '../../__support__/fixtures/secrets/secret-rules.toml');
+
+    beforeEach(() => {
+      mockValidateDockerfileContent.mockResolvedValue({
+        passed: true,
+        score: 100,
+        grade: 'A',
+        results: [],
+      });
+    });
+
+    it('should validate with the rules file given by secretRulesPath', async () => {
+      const config = {
+        path: '/test/Dockerfile',
+        environment: 'production',
+        secretRulesPath: rulesPath,
+      };
+      const result = await fixDockerfileTool.handler(config, createMockToolContext());
+
+      expect(result.ok).toBe(true);
+      expect(mockValidateDockerfileContent).toHaveBeenCalledWith(
+        dockerfileWithIssues,
+        expect.objectContaining({
+          path: '/test/Dockerfile',
+          secretRules: expect.objectContaining({
+            rules: expect.arrayContaining([
+              expect.objectContaining({ id: 'internal-api-token' }),
+            ]),
+          }),
+        }),
+      );
+    });
+
+    it('should fail when the rules file cannot be read', async () => {
+      const config = {
+        dockerfile: dockerfileWithIssues,
+        environment: 'production',
+        secretRulesPath: join(__dirname, 'missing-rules.toml'),
+      };
+      const result = await fixDockerfileTool.handler(config, createMockToolContext());
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('Failed to read secret rules file');
+      }
+      expect(mockValidateDockerfileContent).not.toHaveBeenCalled();
+    });
+  });
 });
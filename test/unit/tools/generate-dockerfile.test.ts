@@ -87,6 +87,9 @@ function createMockToolContext(): ToolContext {
 // Import after mocks are set up
 import generateDockerfileTool from '@/tools/generate-dockerfile/tool';
 import type { GenerateDockerfileParams } from '@/tools/generate-dockerfile/schema';
+import { validateDockerfileContent } from '@/validation/dockerfile-validator';
+import { denoBasicRepository } from '../../__support__/fixtures/repositories/deno-basic';
+import { bunBasicRepository } from '../../__support__/fixtures/repositories/bun-basic';
 
 const mockFs = fs as jest.Mocked<typeof fs>;
 
@@ -458,6 +461,146 @@ CMD ["node", "index.js"]`;
       }
     });
 
+    it('should attach a rendered cargo-chef template for new Rust Dockerfiles', async () => {
+      config.language = 'rust';
+      mockFs.readFile.mockImplementation(async (filePath: any) => {
+        if (String(filePath).endsWith('Cargo.toml')) {
+          return '[package]\nname = "inventory"\nversion = "0.1.0"\nedition = "2021"\n';
+        }
+        throw new Error('ENOENT: no such file');
+      });
+
+      const result = await generateDockerfileTool.handler(config, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.dockerfileTemplate).toMatchObject({
+          language: 'rust',
+          binaryName: 'inventory',
+        });
+        expect(result.value.dockerfileTemplate?.content).toContain('cargo chef cook');
+        expect(result.value.nextAction.instruction).toContain('dockerfileTemplate.content');
+        expect(result.value.summary).toContain('Template: rust (binary: inventory)');
+      }
+    });
+
+    it('should explain when a Rust workspace needs a target crate', async () => {
+      config.language = 'rust';
+      mockFs.readFile.mockImplementation(async (filePath: any) => {
+        if (String(filePath).endsWith('Cargo.toml')) {
+          return '[workspace]\nmembers = ["crates/*"]\n';
+        }
+        throw new Error('ENOENT: no such file');
+      });
+
+      const result = await generateDockerfileTool.handler(config, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.dockerfileTemplate).toBeUndefined();
+        expect(result.value.summary).toContain('targetCrate');
+      }
+    });
+
+    describe('Deno and Bun templates', () => {
+      // Serve a fixture from /test/repo through the mocked filesystem
+      const mockRepository = (files: Record<string, string>) => {
+        const read = (filePath: unknown) => files[String(filePath).replace('/test/repo/', '')];
+        mockFs.readFile.mockImplementation(async (filePath: any) => {
+          const content = read(filePath);
+          if (content === undefined) throw new Error('ENOENT: no such file');
+          return content;
+        });
+        mockFs.access.mockImplementation(async (filePath: any) => {
+          if (read(filePath) === undefined) throw new Error('ENOENT: no such file');
+        });
+      };
+
+      it('should render a valid Deno Dockerfile instead of a Node.js one', async () => {
+        mockRepository(denoBasicRepository);
+        config.language = 'typescript';
+        config.packageManager = 'deno';
+        config.ports = [8000];
+
+        const result = await generateDockerfileTool.handler(config, mockContext);
+
+        expect(result.ok).toBe(true);
+        if (result.ok) {
+          const template = result.value.dockerfileTemplate;
+          expect(template).toMatchObject({ language: 'deno', entryPoint: 'main.ts' });
+          expect(template?.content).toContain('FROM denoland/deno:');
+          expect(template?.content).not.toContain('npm');
+          expect(result.value.nextAction.instruction).toContain('runs main.ts with deno');
+          expect(result.value.summary).toContain('Template: deno (entry point: main.ts)');
+
+          const report = await validateDockerfileContent(template?.content ?? '', {
+            enableExternalLinter: false,
+          });
+          expect(report.errors).toBe(0);
+        }
+      });
+
+      it('should render a valid Bun Dockerfile for projects locked with bun.lockb', async () => {
+        mockRepository(bunBasicRepository);
+        config.language = 'typescript';
+        config.packageManager = 'bun';
+        config.languageVersion = '1.1';
+
+        const result = await generateDockerfileTool.handler(config, mockContext);
+
+        expect(result.ok).toBe(true);
+        if (result.ok) {
+          const template = result.value.dockerfileTemplate;
+          expect(template).toMatchObject({ language: 'bun', entryPoint: 'src/index.ts' });
+          expect(template?.content).toContain('FROM oven/bun:1.1-slim AS deps');
+          expect(template?.content).toContain('RUN bun install --frozen-lockfile --production');
+          expect(result.value.recommendations.dependencyInstall?.command).toBe(
+            'bun install --frozen-lockfile',
+          );
+          expect(result.value.recommendations.dependencyInstall?.copyFiles).toEqual([
+            'package.json',
+            'bun.lockb',
+          ]);
+
+          const report = await validateDockerfileContent(template?.content ?? '', {
+            enableExternalLinter: false,
+          });
+          expect(report.errors).toBe(0);
+        }
+      });
+
+      it('should copy the text bun.lock written by Bun 1.2 and later', async () => {
+        const { 'bun.lockb': _binary, ...files } = bunBasicRepository;
+        mockRepository({ ...files, 'bun.lock': '{\n  "lockfileVersion": 1\n}\n' });
+        config.packageManager = 'bun';
+
+        const result = await generateDockerfileTool.handler(config, mockContext);
+
+        expect(result.ok).toBe(true);
+        if (result.ok) {
+          expect(result.value.recommendations.dependencyInstall?.copyFiles).toEqual([
+            'package.json',
+            'bun.lock',
+          ]);
+          expect(result.value.dockerfileTemplate?.content).toContain('bun.lock ');
+        }
+      });
+
+      it('should explain when a Bun project has no lockfile', async () => {
+        const { 'bun.lockb': _lockfile, ...files } = bunBasicRepository;
+        mockRepository(files);
+        config.packageManager = 'bun';
+
+        const result = await generateDockerfileTool.handler(config, mockContext);
+
+        expect(result.ok).toBe(true);
+        if (result.ok) {
+          expect(result.value.dockerfileTemplate).toBeUndefined();
+          expect(result.value.summary).toContain('Template: not rendered - No Bun lockfile found');
+        }
+      });
+    });
+
     it('should recommend multi-stage for Rust projects', async () => {
       config.language = 'rust';
 
@@ -470,6 +613,60 @@ CMD ["node", "index.js"]`;
     });
   });
 
+  describe('Dependency Install', () => {
+    it('should recommend install commands for the detected package manager', async () => {
+      config.packageManager = 'pnpm';
+
+      const result = await generateDockerfileTool.handler(config, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.recommendations.dependencyInstall).toEqual({
+          packageManager: 'pnpm',
+          copyFiles: ['package.json', 'pnpm-lock.yaml'],
+          command: 'corepack enable && pnpm install --frozen-lockfile',
+        });
+        expect(result.value.nextAction.instruction).toContain('pnpm install --frozen-lockfile');
+        expect(result.value.summary).toContain('Package Manager: pnpm');
+      }
+    });
+
+    it('should omit install commands when the package manager is unknown', async () => {
+      const result = await generateDockerfileTool.handler(config, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.recommendations.dependencyInstall).toBeUndefined();
+        expect(result.value.summary).not.toContain('Package Manager');
+      }
+    });
+  });
+
+  describe('Detected Ports', () => {
+    it('should expose the most likely port and list the other candidates', async () => {
+      config.ports = [5000, 8000];
+
+      const result = await generateDockerfileTool.handler(config, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.nextAction.instruction).toContain('Expose port 5000');
+        expect(result.value.nextAction.instruction).toContain('other detected candidates: 8000');
+        expect(result.value.summary).toContain('Port: 5000 (candidates: 5000, 8000)');
+      }
+    });
+
+    it('should not mention ports when none were detected', async () => {
+      const result = await generateDockerfileTool.handler(config, mockContext);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.nextAction.instruction).not.toContain('Expose port');
+        expect(result.value.summary).not.toContain('Port:');
+      }
+    });
+  });
+
   describe('Metadata', () => {
     it('should have correct metadata', () => {
       expect(generateDockerfileTool.version).toBe('2.0.0');
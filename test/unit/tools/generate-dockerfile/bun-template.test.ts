@@ -0,0 +1,93 @@
+/**
+ * Tests for the Bun Dockerfile template
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  DEFAULT_BUN_VERSION,
+  renderBunDockerfile,
+  resolveBunBuildTarget,
+} from '@/tools/generate-dockerfile/bun-template';
+import { validateDockerfileContent } from '@/validation/dockerfile-validator';
+import { bunBasicRepository } from '../../../__support__/fixtures/repositories/bun-basic';
+
+describe('Bun Dockerfile template', () => {
+  describe('sample Bun project', () => {
+    const target = resolveBunBuildTarget(
+      bunBasicRepository['package.json'],
+      Object.keys(bunBasicRepository),
+    );
+    const dockerfile = target.ok ? renderBunDockerfile(target.value) : '';
+
+    it('should take the entry point from the start script', () => {
+      expect(target).toEqual({
+        ok: true,
+        value: { entryPoint: 'src/index.ts', lockFile: 'bun.lockb' },
+      });
+    });
+
+    it('should install production dependencies from the lockfile in their own stage', () => {
+      expect(dockerfile).toContain(`FROM oven/bun:${DEFAULT_BUN_VERSION}-slim AS deps`);
+      expect(dockerfile).toContain(
+        'COPY package.json bun.lockb ./\nRUN bun install --frozen-lockfile --production\n',
+      );
+      expect(dockerfile).toContain('COPY --from=deps /app/node_modules ./node_modules');
+    });
+
+    it('should run the entry point with bun as the bun user', () => {
+      const runtime = dockerfile.slice(dockerfile.indexOf('AS runtime'));
+
+      expect(runtime).toContain('USER bun');
+      expect(runtime).toContain('EXPOSE 3000');
+      expect(runtime).toContain('CMD ["bun", "run", "src/index.ts"]');
+      expect(runtime).not.toContain('bun install');
+    });
+
+    it('should pass the Dockerfile validator', async () => {
+      const report = await validateDockerfileContent(dockerfile, { enableExternalLinter: false });
+
+      expect(report.errors).toBe(0);
+      const failed = report.results.filter((r) => !r.passed).map((r) => r.ruleId);
+      expect(failed).not.toContain('no-root-user');
+      expect(failed).not.toContain('specific-base-image');
+      expect(failed).not.toContain('layer-caching-optimization');
+      expect(failed).not.toContain('multi-stage-optimization');
+      expect(failed).not.toContain('has-expose');
+      expect(failed).not.toContain('workdir-set');
+    });
+  });
+
+  describe('entry point and lockfile', () => {
+    it('should run the start script when it does not name a file', () => {
+      const target = resolveBunBuildTarget(
+        JSON.stringify({ scripts: { start: 'bun run --bun next start' } }),
+        ['bun.lock'],
+        '1.2',
+      );
+
+      expect(target).toEqual({ ok: true, value: { lockFile: 'bun.lock', bunVersion: '1.2' } });
+      if (target.ok) {
+        const dockerfile = renderBunDockerfile(target.value, { port: 8080 });
+        expect(dockerfile).toContain('FROM oven/bun:1.2-slim AS runtime');
+        expect(dockerfile).toContain('COPY package.json bun.lock ./');
+        expect(dockerfile).toContain('CMD ["bun", "run", "start"]');
+        expect(dockerfile).toContain('EXPOSE 8080');
+      }
+    });
+
+    it('should fall back to the module field without a start script', () => {
+      const target = resolveBunBuildTarget(JSON.stringify({ module: 'index.ts' }), ['bun.lockb']);
+
+      expect(target.ok && target.value.entryPoint).toBe('index.ts');
+    });
+
+    it('should require a Bun lockfile', () => {
+      const target = resolveBunBuildTarget(bunBasicRepository['package.json'], ['package.json']);
+
+      expect(target.ok).toBe(false);
+      if (!target.ok) {
+        expect(target.guidance?.resolution).toContain('bun install');
+      }
+    });
+  });
+});
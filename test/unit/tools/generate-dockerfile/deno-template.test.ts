@@ -0,0 +1,124 @@
+/**
+ * Tests for the Deno Dockerfile template
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  DEFAULT_DENO_PERMISSIONS,
+  DEFAULT_DENO_VERSION,
+  parseDenoStartTask,
+  renderDenoDockerfile,
+  resolveDenoBuildTarget,
+} from '@/tools/generate-dockerfile/deno-template';
+import { validateDockerfileContent } from '@/validation/dockerfile-validator';
+import { denoBasicRepository } from '../../../__support__/fixtures/repositories/deno-basic';
+
+describe('Deno Dockerfile template', () => {
+  describe('sample Deno project', () => {
+    const task = parseDenoStartTask(denoBasicRepository['deno.json']);
+    const target = resolveDenoBuildTarget(task, Object.keys(denoBasicRepository));
+    const dockerfile = target.ok ? renderDenoDockerfile(target.value) : '';
+
+    it('should take the entry point and permissions from the start task', () => {
+      expect(task).toEqual({
+        entryPoint: 'main.ts',
+        permissions: ['--allow-net', '--allow-env=PORT'],
+      });
+      expect(target).toEqual({
+        ok: true,
+        value: {
+          entryPoint: 'main.ts',
+          permissions: ['--allow-net', '--allow-env=PORT'],
+          manifestFiles: ['deno.json', 'deno.lock'],
+          locked: true,
+        },
+      });
+    });
+
+    it('should install dependencies from the lockfile before copying sources', () => {
+      expect(dockerfile).toContain(`FROM denoland/deno:${DEFAULT_DENO_VERSION}\n`);
+      expect(dockerfile).toContain('COPY deno.json deno.lock ./\nRUN deno install --frozen\n');
+      expect(dockerfile.indexOf('RUN deno install --frozen\n')).toBeLessThan(
+        dockerfile.indexOf('COPY . .'),
+      );
+      expect(dockerfile).toContain('RUN deno install --frozen --entrypoint main.ts');
+    });
+
+    it('should run as the deno user with the declared permissions only', () => {
+      expect(dockerfile).toContain('USER deno');
+      expect(dockerfile).toContain('EXPOSE 8000');
+      expect(dockerfile).toContain(
+        'CMD ["run", "--allow-net", "--allow-env=PORT", "--frozen", "--cached-only", "main.ts"]',
+      );
+      expect(dockerfile).not.toContain('--allow-all');
+    });
+
+    it('should pass the Dockerfile validator', async () => {
+      const report = await validateDockerfileContent(dockerfile, { enableExternalLinter: false });
+
+      expect(report.errors).toBe(0);
+      const failed = report.results.filter((r) => !r.passed).map((r) => r.ruleId);
+      expect(failed).not.toContain('no-root-user');
+      expect(failed).not.toContain('specific-base-image');
+      expect(failed).not.toContain('layer-caching-optimization');
+      expect(failed).not.toContain('has-expose');
+      expect(failed).not.toContain('workdir-set');
+    });
+  });
+
+  describe('start task and entry point', () => {
+    it('should replace --allow-all with explicit default permissions', () => {
+      const task = parseDenoStartTask('{ "tasks": { "start": "deno run -A src/server.ts" } }');
+      const target = resolveDenoBuildTarget(task, ['deno.json']);
+
+      expect(task).toEqual({ entryPoint: 'src/server.ts', permissions: [] });
+      expect(target.ok && target.value.permissions).toEqual([...DEFAULT_DENO_PERMISSIONS]);
+    });
+
+    it('should read the object task form and comments of deno.jsonc', () => {
+      const task = parseDenoStartTask(`{
+  // Production entry point
+  "tasks": { "start": { "command": "deno serve --allow-read=./static server.ts" } }
+}`);
+
+      expect(task).toEqual({ entryPoint: 'server.ts', permissions: ['--allow-read=./static'] });
+    });
+
+    it('should fall back to a conventional entry point without a start task', () => {
+      const target = resolveDenoBuildTarget(undefined, ['deno.jsonc', 'src/main.ts']);
+
+      expect(target).toMatchObject({
+        ok: true,
+        value: { entryPoint: 'src/main.ts', manifestFiles: ['deno.jsonc'], locked: false },
+      });
+      if (target.ok) {
+        const dockerfile = renderDenoDockerfile(target.value, { port: 8080 });
+        expect(dockerfile).toContain('RUN deno install\n');
+        expect(dockerfile).toContain('EXPOSE 8080');
+        expect(dockerfile).not.toContain('--frozen');
+      }
+    });
+
+    it('should fail when no entry point can be found', () => {
+      const target = resolveDenoBuildTarget(undefined, ['deno.json']);
+
+      expect(target.ok).toBe(false);
+      if (!target.ok) {
+        expect(target.guidance?.resolution).toContain('"start" task');
+      }
+    });
+
+    it('should expand the runtime version line to a full image tag', () => {
+      const dockerfile = renderDenoDockerfile({
+        entryPoint: 'main.ts',
+        permissions: ['--allow-net'],
+        manifestFiles: [],
+        locked: false,
+        denoVersion: '2.0',
+      });
+
+      expect(dockerfile).toContain('FROM denoland/deno:2.0.0\n');
+      expect(dockerfile).not.toContain('RUN deno install\n');
+    });
+  });
+});
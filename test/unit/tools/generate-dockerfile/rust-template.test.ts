@@ -0,0 +1,141 @@
+/**
+ * Tests for the Rust Dockerfile template
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  DEFAULT_RUST_VERSION,
+  parseCargoProject,
+  renderRustDockerfile,
+  resolveRustBuildTarget,
+  workspaceMemberPaths,
+} from '@/tools/generate-dockerfile/rust-template';
+import { validateDockerfileContent } from '@/validation/dockerfile-validator';
+import { rustBasicRepository } from '../../../__support__/fixtures/repositories/rust-basic';
+
+const WORKSPACE_TOML = `[workspace]
+members = ["crates/*", "tools/migrate"]
+resolver = "2"
+`;
+
+const API_TOML = `[package]
+name = "api-server"
+version = "0.3.0"
+edition = "2021"
+rust-version = "1.80"
+
+[[bin]]
+name = "api"
+path = "src/main.rs"
+`;
+
+describe('Rust Dockerfile template', () => {
+  describe('sample Cargo project', () => {
+    const project = parseCargoProject(rustBasicRepository['Cargo.toml']);
+    const target = resolveRustBuildTarget(project);
+    const dockerfile = target.ok ? renderRustDockerfile(target.value, { port: 3030 }) : '';
+
+    it('should take the binary name from the package name', () => {
+      expect(target).toEqual({ ok: true, value: { binaryName: 'rust-basic' } });
+    });
+
+    it('should cache dependencies with cargo-chef in the builder stage', () => {
+      expect(dockerfile).toContain(`FROM rust:${DEFAULT_RUST_VERSION}-slim-bookworm AS chef`);
+      expect(dockerfile).toContain('RUN cargo chef prepare --recipe-path recipe.json');
+      expect(dockerfile).toContain('RUN cargo chef cook --release --recipe-path recipe.json\n');
+      expect(dockerfile.indexOf('cargo chef cook')).toBeLessThan(
+        dockerfile.indexOf('RUN cargo build --release --bin rust-basic'),
+      );
+    });
+
+    it('should copy only the release binary into a slim runtime stage', () => {
+      const runtime = dockerfile.slice(dockerfile.indexOf('FROM debian:bookworm-slim'));
+
+      expect(runtime).toContain(
+        'COPY --from=builder /app/target/release/rust-basic /usr/local/bin/rust-basic',
+      );
+      expect(runtime).toContain('USER app');
+      expect(runtime).toContain('EXPOSE 3030');
+      expect(runtime).toContain('ENTRYPOINT ["/usr/local/bin/rust-basic"]');
+      expect(runtime).not.toContain('cargo');
+    });
+
+    it('should pass the Dockerfile validator', async () => {
+      const report = await validateDockerfileContent(dockerfile, { enableExternalLinter: false });
+
+      expect(report.errors).toBe(0);
+      const failed = report.results.filter((r) => !r.passed).map((r) => r.ruleId);
+      expect(failed).not.toContain('no-root-user');
+      expect(failed).not.toContain('specific-base-image');
+      expect(failed).not.toContain('multi-stage-optimization');
+      expect(failed).not.toContain('has-expose');
+      expect(failed).not.toContain('workdir-set');
+    });
+  });
+
+  describe('binary and toolchain detection', () => {
+    it('should prefer an explicit [[bin]] target and pinned rust-version', () => {
+      const target = resolveRustBuildTarget(parseCargoProject(API_TOML));
+
+      expect(target).toEqual({ ok: true, value: { binaryName: 'api', rustVersion: '1.80' } });
+      if (target.ok) {
+        expect(renderRustDockerfile(target.value)).toContain('FROM rust:1.80-slim-bookworm');
+      }
+    });
+
+    it('should not treat a Cargo edition as a toolchain version', () => {
+      const dockerfile = renderRustDockerfile({ binaryName: 'app', rustVersion: '2021' });
+
+      expect(dockerfile).toContain(`FROM rust:${DEFAULT_RUST_VERSION}-slim-bookworm`);
+    });
+  });
+
+  describe('workspaces', () => {
+    const root = parseCargoProject(WORKSPACE_TOML);
+
+    it('should expand glob and explicit member paths for a crate', () => {
+      expect(workspaceMemberPaths(root.workspaceMembers, 'api-server')).toEqual([
+        'crates/api-server',
+      ]);
+      expect(workspaceMemberPaths(root.workspaceMembers, 'migrate')).toEqual([
+        'crates/migrate',
+        'tools/migrate',
+      ]);
+    });
+
+    it('should build the requested member crate', () => {
+      const target = resolveRustBuildTarget(root, parseCargoProject(API_TOML), 'api-server');
+
+      expect(target).toEqual({
+        ok: true,
+        value: { binaryName: 'api', crate: 'api-server', rustVersion: '1.80' },
+      });
+      if (target.ok) {
+        const dockerfile = renderRustDockerfile(target.value);
+        expect(dockerfile).toContain(
+          'cargo chef cook --release --recipe-path recipe.json --package api-server',
+        );
+        expect(dockerfile).toContain('RUN cargo build --release --package api-server --bin api');
+      }
+    });
+
+    it('should require a target crate for a virtual workspace', () => {
+      const target = resolveRustBuildTarget(root);
+
+      expect(target.ok).toBe(false);
+      if (!target.ok) {
+        expect(target.error).toContain('crate to build must be specified');
+        expect(target.guidance?.resolution).toContain('targetCrate');
+      }
+    });
+
+    it('should fail when the requested crate is not a member', () => {
+      const target = resolveRustBuildTarget(root, undefined, 'nope');
+
+      expect(target.ok).toBe(false);
+      if (!target.ok) {
+        expect(target.error).toContain('Crate nope not found');
+      }
+    });
+  });
+});
@@ -0,0 +1,350 @@
+/**
+ * Tests for the shared manifest model and its raw/Helm renderers
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from '@jest/globals';
+import { execFileSync } from 'node:child_process';
+import { mkdirSync, readFileSync, statSync, writeFileSync } from 'node:fs';
+import { dirname, join } from 'node:path';
+import yaml from 'js-yaml';
+import {
+  buildManifestModel,
+  renderHelmChart,
+  renderHelmTemplate,
+  renderKustomize,
+  renderRawManifests,
+  type GeneratedManifestFile,
+  type ManifestModel,
+} from '@/tools/generate-k8s-manifests/manifest-model';
+import { createTestTempDir } from '../../../__support__/utilities/tmp-helpers';
+
+type Resource = { kind: string; metadata: { name: string } } & Record<string, unknown>;
+
+const EXPECTED_DIR = join(__dirname, '../../../__support__/fixtures/expected-outputs/kustomize');
+
+function binaryAvailable(command: string, args: string[]): boolean {
+  try {
+    execFileSync(command, args, { stdio: 'ignore' });
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+function writeFiles(root: string, files: GeneratedManifestFile[]): void {
+  for (const file of files) {
+    const target = join(root, file.path);
+    mkdirSync(dirname(target), { recursive: true });
+    writeFileSync(target, file.content);
+  }
+}
+
+/**
+ * Strategic merge for the patch shapes renderKustomize emits: maps merge
+ * recursively and lists of named objects (containers) merge by name
+ */
+function strategicMerge(target: unknown, patch: unknown): unknown {
+  if (Array.isArray(target) && Array.isArray(patch)) {
+    const merged = [...target];
+    for (const item of patch as Array<{ name?: string }>) {
+      const index = merged.findIndex((existing) => existing.name === item.name);
+      if (item.name !== undefined && index >= 0) {
+        merged[index] = strategicMerge(merged[index], item);
+      } else {
+        merged.push(item);
+      }
+    }
+    return merged;
+  }
+  if (target && patch && typeof target === 'object' && typeof patch === 'object') {
+    const merged: Record<string, unknown> = { ...(target as Record<string, unknown>) };
+    for (const [key, value] of Object.entries(patch)) {
+      merged[key] = key in merged ? strategicMerge(merged[key], value) : value;
+    }
+    return merged;
+  }
+  return patch;
+}
+
+/**
+ * Minimal `kustomize build` covering resources (files and directories) and
+ * strategic-merge patch files
+ */
+function embeddedKustomizeBuild(dir: string): Resource[] {
+  const kustomization = yaml.load(readFileSync(join(dir, 'kustomization.yaml'), 'utf-8')) as {
+    resources?: string[];
+    patches?: Array<{ path: string }>;
+  };
+
+  let resources: Resource[] = (kustomization.resources ?? []).flatMap((entry) => {
+    const target = join(dir, entry);
+    return statSync(target).isDirectory()
+      ? embeddedKustomizeBuild(target)
+      : (yaml.loadAll(readFileSync(target, 'utf-8')) as Resource[]);
+  });
+
+  for (const { path } of kustomization.patches ?? []) {
+    const patch = yaml.load(readFileSync(join(dir, path), 'utf-8')) as Resource;
+    resources = resources.map((resource) =>
+      resource.kind === patch.kind && resource.metadata.name === patch.metadata.name
+        ? (strategicMerge(resource, patch) as Resource)
+        : resource,
+    );
+  }
+  return resources;
+}
+
+function kustomizeBuild(dir: string): Resource[] {
+  const output = binaryAvailable('kustomize', ['version'])
+    ? execFileSync('kustomize', ['build', dir], { encoding: 'utf-8' })
+    : binaryAvailable('kubectl', ['version', '--client'])
+      ? execFileSync('kubectl', ['kustomize', dir], { encoding: 'utf-8' })
+      : undefined;
+  return output === undefined
+    ? embeddedKustomizeBuild(dir)
+    : (yaml.loadAll(output).filter(Boolean) as Resource[]);
+}
+
+function byKindAndName(resources: Resource[]): Resource[] {
+  return [...resources].sort((a, b) =>
+    `${a.kind}/${a.metadata.name}`.localeCompare(`${b.kind}/${b.metadata.name}`),
+  );
+}
+
+function expectedResources(fixture: string): Resource[] {
+  return yaml.loadAll(readFileSync(join(EXPECTED_DIR, fixture), 'utf-8')) as Resource[];
+}
+
+function rawDocuments(model: ManifestModel): unknown[] {
+  return renderRawManifests(model).map((file) => yaml.load(file.content));
+}
+
+function renderChartEmbedded(model: ManifestModel): unknown[] {
+  const files = renderHelmChart(model);
+  const values = yaml.load(files.find((f) => f.path === 'values.yaml')?.content ?? '') as Record<
+    string,
+    unknown
+  >;
+
+  return files
+    .filter((file) => file.path.startsWith('templates/'))
+    .map((file) =>
+      renderHelmTemplate(file.content, { Values: values, Chart: { Name: model.name } }),
+    )
+    .flatMap((rendered) => yaml.loadAll(rendered))
+    .filter(Boolean);
+}
+
+const FULL_INPUT = {
+  name: 'orders',
+  image: 'registry.example.com:5000/shop/orders:1.4.2',
+  replicas: 3,
+  ports: [3000],
+  resources: { limits: { memory: '1Gi' } },
+  env: { NODE_ENV: 'production', FEATURE_FLAGS: 'a,b' },
+  ingressHost: 'orders.example.com',
+};
+
+describe('generate-k8s-manifests manifest model', () => {
+  describe('buildManifestModel', () => {
+    it('should fill defaults for a minimal input', () => {
+      const model = buildManifestModel({ name: 'api' });
+
+      expect(model).toEqual({
+        name: 'api',
+        image: { repository: 'api', tag: 'latest' },
+        replicas: 1,
+        ports: [8080],
+        resources: {
+          requests: { cpu: '100m', memory: '128Mi' },
+          limits: { cpu: '500m', memory: '512Mi' },
+        },
+        env: [],
+      });
+    });
+
+    it('should split registry ports from image tags', () => {
+      const model = buildManifestModel(FULL_INPUT);
+
+      expect(model.image).toEqual({
+        repository: 'registry.example.com:5000/shop/orders',
+        tag: '1.4.2',
+      });
+      expect(model.resources.limits).toEqual({ cpu: '500m', memory: '1Gi' });
+      expect(model.env).toEqual([
+        { name: 'NODE_ENV', value: 'production' },
+        { name: 'FEATURE_FLAGS', value: 'a,b' },
+      ]);
+    });
+  });
+
+  describe('renderRawManifests', () => {
+    it('should emit Deployment and Service without an ingress host', () => {
+      const files = renderRawManifests(buildManifestModel({ name: 'api' }));
+
+      expect(files.map((f) => f.path)).toEqual(['deployment.yaml', 'service.yaml']);
+    });
+
+    it('should emit an Ingress routing to the service', () => {
+      const docs = rawDocuments(buildManifestModel(FULL_INPUT));
+
+      expect(docs[2]).toMatchObject({
+        kind: 'Ingress',
+        spec: {
+          rules: [
+            {
+              host: 'orders.example.com',
+              http: {
+                paths: [{ backend: { service: { name: 'orders', port: { number: 3000 } } } }],
+              },
+            },
+          ],
+        },
+      });
+    });
+  });
+
+  describe('multiple ports', () => {
+    const model = buildManifestModel({ name: 'api', ports: [3000, 9090] });
+
+    it('should expose every port on the container and the Service', () => {
+      const [deployment, service] = rawDocuments(model) as Array<Record<string, any>>;
+
+      expect(deployment?.spec.template.spec.containers[0].ports).toEqual([
+        { name: 'port-3000', containerPort: 3000 },
+        { name: 'port-9090', containerPort: 9090 },
+      ]);
+      expect(service?.spec.ports).toEqual([
+        { name: 'port-3000', port: 3000, targetPort: 3000 },
+        { name: 'port-9090', port: 9090, targetPort: 9090 },
+      ]);
+    });
+
+    it('should render the same ports from the Helm chart', () => {
+      expect(renderChartEmbedded(model)).toEqual(rawDocuments(model));
+    });
+  });
+
+  describe('renderHelmChart', () => {
+    it('should produce Chart.yaml, values.yaml and templates', () => {
+      const files = renderHelmChart(buildManifestModel(FULL_INPUT));
+
+      expect(files.map((f) => f.path)).toEqual([
+        'Chart.yaml',
+        'values.yaml',
+        'templates/deployment.yaml',
+        'templates/service.yaml',
+        'templates/ingress.yaml',
+      ]);
+      expect(yaml.load(files[0]?.content ?? '')).toMatchObject({
+        apiVersion: 'v2',
+        name: 'orders',
+        appVersion: '1.4.2',
+      });
+      expect(yaml.load(files[1]?.content ?? '')).toMatchObject({
+        replicaCount: 3,
+        image: { repository: 'registry.example.com:5000/shop/orders', tag: '1.4.2' },
+        ingress: { enabled: true, host: 'orders.example.com' },
+      });
+    });
+
+    it.each([
+      ['minimal input', { name: 'api' }],
+      ['image, replicas, resources, env and ingress', FULL_INPUT],
+    ])('should render to the raw manifests for %s', (_label, input) => {
+      const model = buildManifestModel(input);
+
+      expect(renderChartEmbedded(model)).toEqual(rawDocuments(model));
+    });
+
+    it('should match the raw manifests when rendered by helm template', async () => {
+      if (!binaryAvailable('helm', ['version', '--short'])) {
+        return;
+      }
+
+      const model = buildManifestModel(FULL_INPUT);
+      const { dir, cleanup } = createTestTempDir('helm-chart-');
+      try {
+        writeFiles(dir.name, renderHelmChart(model));
+
+        const output = execFileSync('helm', ['template', 'release', dir.name], {
+          encoding: 'utf-8',
+        });
+        const rendered = yaml.loadAll(output).filter(Boolean);
+
+        expect(rendered).toEqual(rawDocuments(model));
+      } finally {
+        await cleanup();
+      }
+    });
+  });
+
+  describe('renderKustomize', () => {
+    const model = buildManifestModel({
+      name: 'orders',
+      image: 'registry.example.com/shop/orders:1.4.2',
+      ports: [3000],
+    });
+    const overlays = {
+      dev: {},
+      prod: { replicas: 4, resources: { limits: { cpu: '2', memory: '2Gi' } } },
+    };
+
+    let root: string;
+    let cleanup: () => Promise<void>;
+
+    beforeEach(() => {
+      const tmp = createTestTempDir('kustomize-');
+      root = tmp.dir.name;
+      cleanup = tmp.cleanup;
+      writeFiles(root, renderKustomize(model, overlays));
+    });
+
+    afterEach(async () => {
+      await cleanup();
+    });
+
+    it('should lay out a base and one directory per overlay', () => {
+      const paths = renderKustomize(model, overlays).map((f) => f.path);
+
+      expect(paths).toEqual([
+        'base/deployment.yaml',
+        'base/service.yaml',
+        'base/kustomization.yaml',
+        'overlays/dev/kustomization.yaml',
+        'overlays/prod/kustomization.yaml',
+        'overlays/prod/deployment-patch.yaml',
+      ]);
+    });
+
+    it('should build the base to the raw manifests', () => {
+      const built = byKindAndName(kustomizeBuild(join(root, 'base')));
+
+      expect(built).toEqual(byKindAndName(expectedResources('base.yaml')));
+      expect(built).toEqual(byKindAndName(rawDocuments(model) as Resource[]));
+    });
+
+    it('should apply replica and resource patches in an overlay', () => {
+      const built = byKindAndName(kustomizeBuild(join(root, 'overlays/prod')));
+
+      expect(built).toEqual(byKindAndName(expectedResources('overlay-prod.yaml')));
+    });
+
+    it('should leave the base untouched for an overlay without patches', () => {
+      expect(byKindAndName(kustomizeBuild(join(root, 'overlays/dev')))).toEqual(
+        byKindAndName(expectedResources('base.yaml')),
+      );
+    });
+  });
+
+  describe('renderHelmTemplate', () => {
+    it('should reject unsupported template functions', () => {
+      expect(() =>
+        renderHelmTemplate('name: {{ .Values.name | upper }}', {
+          Values: { name: 'x' },
+          Chart: { Name: 'x' },
+        }),
+      ).toThrow('Unsupported template function: upper');
+    });
+  });
+});
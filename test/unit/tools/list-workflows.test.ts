@@ -0,0 +1,94 @@
+/**
+ * Unit Tests: List Workflows Tool
+ * Tests listing saved workflows with filters and cursor pagination
+ */
+
+import { jest } from '@jest/globals';
+import listWorkflowsTool from '@/tools/list-workflows/tool';
+import {
+  createMemoryWorkflowStateStore,
+  createWorkflowState,
+  recordPipelineStage,
+  type WorkflowStateStore,
+} from '@/lib/workflow-state';
+import { createMockLogger } from '../../__support__/utilities/mock-factories';
+
+const stage = {
+  argsDigest: 'abc123',
+  output: {},
+  completedAt: '2025-01-01T00:00:00.000Z',
+};
+
+describe('listWorkflowsTool', () => {
+  let mockLogger: ReturnType<typeof createMockLogger>;
+  let store: WorkflowStateStore;
+
+  const context = () => ({ logger: mockLogger, workflow: { store, id: 'session-1' } }) as any;
+
+  beforeEach(async () => {
+    mockLogger = createMockLogger();
+    store = createMemoryWorkflowStateStore();
+    jest.clearAllMocks();
+
+    for (let i = 0; i < 5; i++) {
+      let state = recordPipelineStage(createWorkflowState(`wf-${i}`), 'analyze-repo', stage);
+      if (i < 2) state = recordPipelineStage(state, 'verify-deploy', stage);
+      await store.save({ ...state, updatedAt: `2025-01-0${i + 1}T00:00:00.000Z` });
+    }
+  });
+
+  it('should page through workflows with the returned cursor', async () => {
+    const first = await listWorkflowsTool.handler({ limit: 3 }, context());
+
+    expect(first.ok).toBe(true);
+    if (!first.ok) return;
+    expect(first.value.workflows.map((w) => w.id)).toEqual(['wf-4', 'wf-3', 'wf-2']);
+    expect(first.value.nextCursor).toBeDefined();
+    expect(first.value.summary).toBe(
+      '✅ Listed 3 workflows (newest update first). More are available.',
+    );
+
+    const second = await listWorkflowsTool.handler(
+      { limit: 3, cursor: first.value.nextCursor },
+      context(),
+    );
+
+    expect(second.ok && second.value.workflows.map((w) => w.id)).toEqual(['wf-1', 'wf-0']);
+    expect(second.ok && second.value.nextCursor).toBeUndefined();
+  });
+
+  it('should filter by status and sort oldest first', async () => {
+    const result = await listWorkflowsTool.handler(
+      { status: 'in-progress', order: 'asc' },
+      context(),
+    );
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(result.value.workflows.map((w) => w.id)).toEqual(['wf-2', 'wf-3', 'wf-4']);
+    expect(result.value.workflows[0]).toMatchObject({
+      completedStages: 1,
+      lastCompletedStage: 'analyze-repo',
+      nextStage: 'generate-dockerfile',
+      complete: false,
+    });
+  });
+
+  it('should fail with guidance for a cursor it did not issue', async () => {
+    const result = await listWorkflowsTool.handler({ cursor: 'bogus' }, context());
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.error).toContain('Invalid workflow list cursor');
+    expect(result.guidance?.resolution).toContain('nextCursor');
+  });
+
+  it('should fail with guidance when workflow state is not enabled', async () => {
+    const result = await listWorkflowsTool.handler({}, { logger: mockLogger } as any);
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.error).toBe('Workflow listing is not available');
+    expect(result.guidance?.resolution).toContain('CONTAINERIZATION_ASSIST_WORKFLOW_STATE_DIR');
+  });
+});
@@ -6,6 +6,7 @@
 import { jest } from '@jest/globals';
 import opsToolNew from '@/tools/ops/tool';
 import type { OpsToolParams } from '@/tools/ops/schema';
+import { createMemoryWorkflowStateStore, createWorkflowState } from '@/lib/workflow-state';
 import { createMockLogger } from '../../__support__/utilities/mock-factories';
 
 // Mock timer functionality
@@ -160,6 +161,25 @@ describe('opsTool', () => {
         'Server status compiled',
       );
     });
+
+    it('should report workflow state disk usage when enabled', async () => {
+      const store = createMemoryWorkflowStateStore();
+      await store.save(createWorkflowState('session-1'));
+      await store.save(createWorkflowState('session-2'));
+
+      const result = await opsToolNew.handler(
+        { operation: 'status' },
+        { logger: mockLogger, workflow: { store, id: 'session-1' } } as any,
+      );
+
+      expect(result.ok).toBe(true);
+      if (!result.ok) return;
+      const data = result.value as any;
+      expect(data.sessions).toBe(2);
+      expect(data.workflowState).toEqual({ workflows: 2, totalBytes: expect.any(Number) });
+      expect(data.workflowState.totalBytes).toBeGreaterThan(0);
+      expect(data.summary).toContain('Workflow state: 2 saved');
+    });
   });
 
   describe('invalid operation', () => {
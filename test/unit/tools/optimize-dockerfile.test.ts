@@ -0,0 +1,71 @@
+/**
+ * Unit Tests: Optimize Dockerfile Tool
+ * Tests the optimize-dockerfile tool handler around the deterministic optimizer
+ */
+
+import { jest } from '@jest/globals';
+
+function createMockLogger() {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+    trace: jest.fn(),
+    fatal: jest.fn(),
+    child: jest.fn().mockReturnThis(),
+  } as any;
+}
+
+jest.mock('../../../src/lib/logger', () => ({
+  createTimer: jest.fn(() => ({
+    end: jest.fn(),
+    error: jest.fn(),
+  })),
+  createLogger: jest.fn(() => createMockLogger()),
+}));
+
+function createMockToolContext() {
+  return {
+    logger: createMockLogger(),
+  } as any;
+}
+
+import { default as optimizeDockerfileTool } from '../../../src/tools/optimize-dockerfile/tool';
+
+describe('optimize-dockerfile', () => {
+  it('should return suggestions and the optimized Dockerfile', async () => {
+    const result = await optimizeDockerfileTool.handler(
+      {
+        dockerfile:
+          'FROM node:20-slim\nWORKDIR /app\nCOPY . .\nRUN npm ci\nCMD ["node", "index.js"]\n',
+      },
+      createMockToolContext(),
+    );
+
+    expect(result.ok).toBe(true);
+    if (result.ok) {
+      expect(result.value.appliedCount).toBe(1);
+      expect(result.value.suggestions.map((s) => s.id)).toEqual(['dependencies-before-source']);
+      expect(result.value.optimizedDockerfile).toContain(
+        'COPY package*.json ./\nRUN npm ci\nCOPY . .',
+      );
+      expect(result.value.summary).toContain('1 applied automatically');
+      expect(result.value.nextAction?.action).toBe('update-files');
+    }
+  });
+
+  it('should report when there is nothing to optimize', async () => {
+    const result = await optimizeDockerfileTool.handler(
+      { dockerfile: 'FROM alpine:3.19\nCMD ["sh"]\n' },
+      createMockToolContext(),
+    );
+
+    expect(result.ok).toBe(true);
+    if (result.ok) {
+      expect(result.value.suggestions).toEqual([]);
+      expect(result.value.optimizedDockerfile).toBeUndefined();
+      expect(result.value.nextAction).toBeUndefined();
+    }
+  });
+});
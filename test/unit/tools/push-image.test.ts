@@ -7,6 +7,11 @@ import type { DockerClient } from '../../../src/infra/docker/client';
 import type { Result } from '../../../src/types';
 import pushImageTool from '../../../src/tools/push-image/tool';
 import type { ToolContext } from '../../../src/types';
+import type {
+  ImageSigner,
+  ImageSignature,
+  SignOptions,
+} from '../../../src/infra/security/cosign';
 
 describe('push-image tool', () => {
   let fakeDocker: DockerClient;
@@ -20,6 +25,10 @@ describe('push-image tool', () => {
 
     // Create fake DockerClient implementation
     fakeDocker = {
+      async ping(): Promise<Result<{ socketPath: string }>> {
+        return { ok: true, value: { socketPath: '/var/run/docker.sock' } };
+      },
+
       async pushImage(repository: string, tag: string): Promise<Result<{ digest: string }>> {
         pushImageCalled = true;
         if (repository.includes('fail/repo')) {
@@ -222,6 +231,19 @@ describe('push-image tool', () => {
       }
     });
 
+    it('should keep the registry of a qualified image when no registry is given', async () => {
+      const result = await pushImageTool.handler({
+        imageId: 'localhost:5000/team/myapp:v1'
+      }, createMockContext());
+
+      expect(result.ok).toBe(true);
+
+      if (result.ok) {
+        expect(result.value.pushedTag).toBe('localhost:5000/team/myapp:v1');
+        expect(result.value.registry).toBe('localhost:5000');
+      }
+    });
+
     it('should not double-prefix registry if already in imageId', async () => {
       const result = await pushImageTool.handler({
         imageId: 'gcr.io/my-project/myapp:v1',
@@ -236,6 +258,162 @@ describe('push-image tool', () => {
     });
   });
 
+  describe('image signing', () => {
+    function createFakeSigner(
+      outcome: Result<ImageSignature> | 'not-installed',
+    ): ImageSigner & { calls: Array<{ imageRef: string; options: SignOptions }> } {
+      const calls: Array<{ imageRef: string; options: SignOptions }> = [];
+      return {
+        name: 'fake',
+        calls,
+        async isAvailable(): Promise<boolean> {
+          return outcome !== 'not-installed';
+        },
+        async sign(imageRef: string, options: SignOptions): Promise<Result<ImageSignature>> {
+          calls.push({ imageRef, options });
+          return outcome === 'not-installed' ? { ok: false, error: 'unreachable' } : outcome;
+        },
+      };
+    }
+
+    it('should not sign unless requested', async () => {
+      const signer = createFakeSigner({ ok: true, value: { signatureRef: '', keyless: true } });
+
+      const result = await pushImageTool.handler(
+        { imageId: 'myapp:v1.0.0', registry: 'myregistry.io' },
+        { ...createMockContext(), signer } as ToolContext,
+      );
+
+      expect(result.ok).toBe(true);
+      expect(signer.calls).toHaveLength(0);
+      if (result.ok) {
+        expect(result.value.signed).toBeUndefined();
+      }
+    });
+
+    it('should sign by digest with the provided key', async () => {
+      const signer = createFakeSigner({
+        ok: true,
+        value: {
+          signatureRef: 'myregistry.io/myapp:sha256-abc.sig',
+          signatureDigest: 'sha256:def',
+          keyless: false,
+        },
+      });
+
+      const result = await pushImageTool.handler(
+        {
+          imageId: 'myapp:v1.0.0',
+          registry: 'myregistry.io',
+          sign: true,
+          cosignKeyRef: 'azurekms://vault.vault.azure.net/cosign',
+        },
+        { ...createMockContext(), signer } as ToolContext,
+      );
+
+      expect(result.ok).toBe(true);
+      expect(signer.calls).toHaveLength(1);
+      expect(signer.calls[0]?.imageRef).toMatch(/^myregistry\.io\/myapp@sha256:/);
+      expect(signer.calls[0]?.options).toEqual({
+        keyRef: 'azurekms://vault.vault.azure.net/cosign',
+      });
+
+      if (result.ok) {
+        expect(result.value.signed).toBe(true);
+        expect(result.value.signature?.signatureDigest).toBe('sha256:def');
+        expect(result.value.summary).toContain('Signed with cosign (key)');
+      }
+    });
+
+    it('should sign keyless when no key is provided', async () => {
+      const signer = createFakeSigner({
+        ok: true,
+        value: { signatureRef: 'myregistry.io/myapp:sha256-abc.sig', keyless: true },
+      });
+
+      const result = await pushImageTool.handler(
+        { imageId: 'myapp:v1.0.0', registry: 'myregistry.io', sign: true },
+        { ...createMockContext(), signer } as ToolContext,
+      );
+
+      expect(result.ok).toBe(true);
+      expect(signer.calls[0]?.options).toEqual({});
+      if (result.ok) {
+        expect(result.value.signed).toBe(true);
+        expect(result.value.signature?.keyless).toBe(true);
+        expect(result.value.summary).toContain('keyless');
+      }
+    });
+
+    it('should hand credentials from the tool arguments to the signer', async () => {
+      const signer = createFakeSigner({
+        ok: true,
+        value: { signatureRef: 'myregistry.io/myapp:sha256-abc.sig', keyless: true },
+      });
+
+      const result = await pushImageTool.handler(
+        {
+          imageId: 'myapp:v1.0.0',
+          registry: 'myregistry.io',
+          sign: true,
+          credentials: { username: 'ci-bot', password: 'registry-token' },
+        },
+        { ...createMockContext(), signer },
+      );
+
+      expect(result.ok).toBe(true);
+      expect(signer.calls[0]?.options).toEqual({
+        auth: { username: 'ci-bot', password: 'registry-token', serveraddress: 'myregistry.io' },
+      });
+    });
+
+    it('should report pushed but unsigned when signing fails', async () => {
+      const signer = createFakeSigner({ ok: false, error: 'Cosign signing failed: no identity token' });
+
+      const result = await pushImageTool.handler(
+        { imageId: 'myapp:v1.0.0', registry: 'myregistry.io', sign: true },
+        { ...createMockContext(), signer } as ToolContext,
+      );
+
+      expect(result.ok).toBe(true);
+      expect(pushImageCalled).toBe(true);
+      if (result.ok) {
+        expect(result.value.signed).toBe(false);
+        expect(result.value.signingError).toContain('no identity token');
+        expect(result.value.digest).toMatch(/^sha256:/);
+        expect(result.value.summary).toMatch(/^⚠️ Pushed image but it is unsigned/);
+      }
+    });
+
+    it('should report pushed but unsigned when cosign is not installed', async () => {
+      const signer = createFakeSigner('not-installed');
+
+      const result = await pushImageTool.handler(
+        { imageId: 'myapp:v1.0.0', registry: 'myregistry.io', sign: true },
+        { ...createMockContext(), signer } as ToolContext,
+      );
+
+      expect(result.ok).toBe(true);
+      expect(signer.calls).toHaveLength(0);
+      if (result.ok) {
+        expect(result.value.signed).toBe(false);
+        expect(result.value.signingError).toContain('cosign is not installed');
+      }
+    });
+
+    it('should still fail when the push itself fails', async () => {
+      const signer = createFakeSigner({ ok: true, value: { signatureRef: '', keyless: true } });
+
+      const result = await pushImageTool.handler(
+        { imageId: 'fail/repo:v1', registry: 'my-registry.io', sign: true },
+        { ...createMockContext(), signer } as ToolContext,
+      );
+
+      expect(result.ok).toBe(false);
+      expect(signer.calls).toHaveLength(0);
+    });
+  });
+
   describe('summary generation', () => {
     it('should generate summary with truncated sha256 digest', async () => {
       // Mock pushImage to return a specific sha256 digest
@@ -0,0 +1,102 @@
+/**
+ * Unit Tests: Resume Workflow Tool
+ * Tests reading saved pipeline stages and continuing from the last completed one
+ */
+
+import { jest } from '@jest/globals';
+import resumeWorkflowTool from '@/tools/resume-workflow/tool';
+import {
+  createMemoryWorkflowStateStore,
+  createWorkflowState,
+  recordPipelineStage,
+  type WorkflowStateStore,
+} from '@/lib/workflow-state';
+import { createMockLogger } from '../../__support__/utilities/mock-factories';
+
+const stage = (output: unknown) => ({
+  argsDigest: 'abc123',
+  output,
+  completedAt: '2025-01-01T00:00:00.000Z',
+});
+
+async function seed(store: WorkflowStateStore, id: string): Promise<void> {
+  let state = createWorkflowState(id);
+  state = recordPipelineStage(state, 'analyze-repo', stage({ language: 'go' }));
+  state = recordPipelineStage(state, 'generate-dockerfile', stage({ path: 'Dockerfile' }));
+  state = recordPipelineStage(state, 'build-image', stage({ imageId: 'sha256:abc' }));
+  await store.save(state);
+}
+
+describe('resumeWorkflowTool', () => {
+  let mockLogger: ReturnType<typeof createMockLogger>;
+  let store: WorkflowStateStore;
+
+  const context = (id = 'session-1') =>
+    ({ logger: mockLogger, workflow: { store, id } }) as any;
+
+  beforeEach(() => {
+    mockLogger = createMockLogger();
+    store = createMemoryWorkflowStateStore();
+    jest.clearAllMocks();
+  });
+
+  it('should continue after the last completed stage with the saved outputs', async () => {
+    await seed(store, 'session-1');
+
+    const result = await resumeWorkflowTool.handler({}, context());
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(result.value).toMatchObject({
+      workflowId: 'session-1',
+      lastCompletedStage: 'build-image',
+      nextStage: 'scan-image',
+      nextAction: 'Call scan-image on the image from build-image',
+      complete: false,
+      restarted: false,
+    });
+    expect(result.value.completedStages.map((s) => s.stage)).toEqual([
+      'analyze-repo',
+      'generate-dockerfile',
+      'build-image',
+    ]);
+    expect(result.value.completedStages[2]?.output).toEqual({ imageId: 'sha256:abc' });
+    expect(result.value.summary).toContain('3 stages completed (last: build-image)');
+  });
+
+  it('should resume another workflow by id', async () => {
+    await seed(store, 'other');
+
+    const result = await resumeWorkflowTool.handler({ workflowId: 'other' }, context());
+
+    expect(result.ok && result.value.nextStage).toBe('scan-image');
+  });
+
+  it('should start from analyze-repo when nothing was saved', async () => {
+    const result = await resumeWorkflowTool.handler({}, context());
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(result.value.completedStages).toEqual([]);
+    expect(result.value.nextStage).toBe('analyze-repo');
+    expect(result.value).not.toHaveProperty('lastCompletedStage');
+  });
+
+  it('should discard saved stages on restart', async () => {
+    await seed(store, 'session-1');
+
+    const result = await resumeWorkflowTool.handler({ restart: true }, context());
+
+    expect(result.ok && result.value.restarted).toBe(true);
+    expect(await store.load('session-1')).toBeUndefined();
+  });
+
+  it('should fail with guidance when workflow state is not enabled', async () => {
+    const result = await resumeWorkflowTool.handler({}, { logger: mockLogger } as any);
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.error).toBe('Workflow state persistence is not enabled');
+    expect(result.guidance?.resolution).toContain('CONTAINERIZATION_ASSIST_WORKFLOW_STATE_DIR');
+  });
+});
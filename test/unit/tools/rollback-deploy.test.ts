@@ -0,0 +1,230 @@
+/**
+ * Unit Tests: Rollback Deployment Tool
+ * Tests the rollback-deploy tool against a fake Kubernetes client
+ */
+
+import { jest } from '@jest/globals';
+
+function createSuccessResult<T>(value: T) {
+  return {
+    ok: true as const,
+    value,
+  };
+}
+
+function createFailureResult(error: string) {
+  return {
+    ok: false as const,
+    error,
+  };
+}
+
+function createMockLogger() {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+    trace: jest.fn(),
+    fatal: jest.fn(),
+    child: jest.fn().mockReturnThis(),
+  } as any;
+}
+
+const mockK8sClient = {
+  listDeploymentRevisions: jest.fn<any>(),
+  rollbackDeployment: jest.fn<any>(),
+  waitForDeploymentReady: jest.fn<any>(),
+  getDeploymentStatus: jest.fn<any>(),
+  listDeploymentPods: jest.fn<any>(),
+};
+
+jest.mock('../../../src/infra/kubernetes/client', () => ({
+  createKubernetesClient: jest.fn(() => mockK8sClient),
+}));
+
+jest.mock('../../../src/lib/logger', () => ({
+  createTimer: jest.fn(() => ({
+    end: jest.fn(),
+    error: jest.fn(),
+  })),
+  createLogger: jest.fn(() => createMockLogger()),
+}));
+
+function createMockToolContext() {
+  return {
+    logger: createMockLogger(),
+  } as any;
+}
+
+import { default as rollbackDeployTool } from '../../../src/tools/rollback-deploy/tool';
+import type { RollbackDeployParams } from '../../../src/tools/rollback-deploy/schema';
+
+const revision = (n: number, current = false) => ({
+  revision: n,
+  replicaSetName: `web-rs${n}`,
+  images: [`web:v${n}`],
+  current,
+});
+
+const POD = { name: 'web-abc', phase: 'Running', ready: true, restarts: 0 };
+
+describe('rollback-deploy', () => {
+  let params: RollbackDeployParams;
+
+  beforeEach(() => {
+    jest.clearAllMocks();
+    params = { deploymentName: 'web', namespace: 'production' };
+
+    mockK8sClient.listDeploymentRevisions
+      .mockResolvedValueOnce(createSuccessResult([revision(3, true), revision(2), revision(1)]))
+      .mockResolvedValueOnce(createSuccessResult([revision(4, true), revision(3), revision(1)]));
+    mockK8sClient.rollbackDeployment.mockResolvedValue(
+      createSuccessResult({ fromRevision: 3, toRevision: 2 }),
+    );
+    mockK8sClient.waitForDeploymentReady.mockResolvedValue(
+      createSuccessResult({ ready: true, readyReplicas: 2, totalReplicas: 2 }),
+    );
+    mockK8sClient.listDeploymentPods.mockResolvedValue(createSuccessResult([POD]));
+  });
+
+  describe('successful rollback', () => {
+    it('should roll back to the previous revision and report the new revision', async () => {
+      const result = await rollbackDeployTool.handler(params, createMockToolContext());
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value).toMatchObject({
+          success: true,
+          deploymentName: 'web',
+          namespace: 'production',
+          fromRevision: 3,
+          restoredRevision: 2,
+          revision: 4,
+          ready: true,
+          readyReplicas: 2,
+          totalReplicas: 2,
+          pods: [POD],
+        });
+        expect(result.value.summary).toContain('from revision 3 to revision 2');
+      }
+      expect(mockK8sClient.rollbackDeployment).toHaveBeenCalledWith('production', 'web', undefined);
+    });
+
+    it('should pass an explicit target revision through', async () => {
+      mockK8sClient.rollbackDeployment.mockResolvedValue(
+        createSuccessResult({ fromRevision: 3, toRevision: 1 }),
+      );
+
+      const result = await rollbackDeployTool.handler(
+        { ...params, toRevision: 1, timeoutSeconds: 30 },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      expect(mockK8sClient.rollbackDeployment).toHaveBeenCalledWith('production', 'web', 1);
+      expect(mockK8sClient.waitForDeploymentReady).toHaveBeenCalledWith(
+        'production',
+        'web',
+        30,
+        expect.any(Number),
+      );
+    });
+
+    it('should default to the default namespace', async () => {
+      const result = await rollbackDeployTool.handler(
+        { deploymentName: 'web' },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      expect(mockK8sClient.listDeploymentRevisions).toHaveBeenCalledWith('default', 'web');
+    });
+  });
+
+  describe('no rollout history', () => {
+    it('should fail with guidance when only one revision exists', async () => {
+      mockK8sClient.listDeploymentRevisions.mockReset();
+      mockK8sClient.listDeploymentRevisions.mockResolvedValue(
+        createSuccessResult([revision(1, true)]),
+      );
+
+      const result = await rollbackDeployTool.handler(params, createMockToolContext());
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toBe('Deployment web has no previous revision to roll back to');
+        expect(result.guidance?.hint).toContain('revisionHistoryLimit');
+        expect(result.guidance?.resolution).toContain('kubectl rollout history deployment/web');
+      }
+      expect(mockK8sClient.rollbackDeployment).not.toHaveBeenCalled();
+    });
+
+    it('should fail when the requested revision is not in the history', async () => {
+      const result = await rollbackDeployTool.handler(
+        { ...params, toRevision: 7 },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('Revision 7 not found');
+        expect(result.guidance?.hint).toBe('Available previous revisions: 2, 1');
+      }
+      expect(mockK8sClient.rollbackDeployment).not.toHaveBeenCalled();
+    });
+
+    it('should surface history lookup failures', async () => {
+      mockK8sClient.listDeploymentRevisions.mockReset();
+      mockK8sClient.listDeploymentRevisions.mockResolvedValue(
+        createFailureResult('Failed to list deployment revisions: deployments "web" not found'),
+      );
+
+      const result = await rollbackDeployTool.handler(params, createMockToolContext());
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('not found');
+      }
+    });
+  });
+
+  describe('rollout timeout', () => {
+    it('should report the last status and unready pods when the rollout does not stabilize', async () => {
+      mockK8sClient.waitForDeploymentReady.mockResolvedValue(
+        createFailureResult('Deployment did not become ready within 30 seconds.'),
+      );
+      mockK8sClient.getDeploymentStatus.mockResolvedValue(
+        createSuccessResult({ ready: false, readyReplicas: 1, totalReplicas: 2 }),
+      );
+      mockK8sClient.listDeploymentPods.mockResolvedValue(
+        createSuccessResult([
+          POD,
+          {
+            name: 'web-def',
+            phase: 'Running',
+            ready: false,
+            restarts: 4,
+            reason: 'CrashLoopBackOff',
+          },
+        ]),
+      );
+
+      const result = await rollbackDeployTool.handler(
+        { ...params, timeoutSeconds: 30 },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('did not stabilize within 30s');
+        expect(result.guidance?.hint).toBe('Unready pods: web-def (CrashLoopBackOff)');
+        expect(result.guidance?.details).toMatchObject({
+          fromRevision: 3,
+          toRevision: 2,
+          status: { readyReplicas: 1, totalReplicas: 2 },
+        });
+      }
+    });
+  });
+});
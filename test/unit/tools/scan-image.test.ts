@@ -61,6 +61,13 @@ jest.mock('../../../src/infra/security/scanner', () => ({
   createSecurityScanner: jest.fn(() => mockSecurityScanner),
 }));
 
+const mockWriteSarifReport = jest.fn();
+
+jest.mock('../../../src/infra/security/sarif', () => ({
+  toSarif: jest.fn(() => ({ version: '2.1.0', runs: [] })),
+  writeSarifReport: (...args: unknown[]) => mockWriteSarifReport(...args),
+}));
+
 jest.mock('../../../src/lib/logger', () => ({
   createTimer: jest.fn(() => mockTimer),
   createLogger: jest.fn(() => createMockLogger()),
@@ -352,6 +359,22 @@ describe('scanImage - Error Scenarios', () => {
         expect(result.guidance).toBeDefined();
       }
     });
+
+    it('should warn when the requested SARIF report cannot be written', async () => {
+      mockWriteSarifReport.mockResolvedValue(
+        createFailureResult('Failed to write SARIF report: EACCES: permission denied'),
+      );
+
+      const result = await scanImage({ ...config, outputFormat: 'sarif' }, createMockToolContext());
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.sarifPath).toBeUndefined();
+        expect(result.value.warnings).toEqual([
+          'SARIF report was requested but not written: Failed to write SARIF report: EACCES: permission denied',
+        ]);
+      }
+    });
   });
 
   describe('Severity Threshold Testing', () => {
@@ -0,0 +1,151 @@
+/**
+ * Unit Tests: Scan Images Batch Tool
+ * Tests concurrent scanning, per-image errors and the combined pass/fail
+ */
+
+import { jest } from '@jest/globals';
+import type { BasicScanResult } from '@/infra/security/scanner';
+import { Failure, Success, type Result } from '@/types';
+import { createMockLogger } from '../../__support__/utilities/mock-factories';
+
+const mockScanImage = jest.fn<(imageId: string) => Promise<Result<BasicScanResult>>>();
+const mockCreateSecurityScanner = jest.fn((..._args: unknown[]) => ({
+  scanImage: mockScanImage,
+  ping: jest.fn(),
+}));
+
+jest.mock('@/infra/security/scanner', () => ({
+  createSecurityScanner: (...args: unknown[]) => mockCreateSecurityScanner(...args),
+}));
+
+import scanImagesBatchTool from '@/tools/scan-images-batch/tool';
+
+function scanResult(
+  imageId: string,
+  counts: Partial<Record<'critical' | 'high' | 'medium' | 'low', number>> = {},
+): BasicScanResult {
+  const { critical = 0, high = 0, medium = 0, low = 0 } = counts;
+  return {
+    imageId,
+    vulnerabilities: [],
+    totalVulnerabilities: critical + high + medium + low,
+    criticalCount: critical,
+    highCount: high,
+    mediumCount: medium,
+    lowCount: low,
+    negligibleCount: 0,
+    unknownCount: 0,
+    scanDate: new Date('2025-01-01T00:00:00Z'),
+    scanner: 'trivy',
+  };
+}
+
+const FINDINGS: Record<string, Result<BasicScanResult>> = {
+  'api:1.0': Success(scanResult('api:1.0', { medium: 2 })),
+  'worker:1.0': Success(scanResult('worker:1.0', { critical: 1, high: 3 })),
+  'web:1.0': Success(scanResult('web:1.0')),
+  'missing:1.0': Failure('Image not found: missing:1.0', {
+    message: 'Image not found',
+    resolution: 'Build or pull the image first',
+  }),
+};
+
+describe('scanImagesBatchTool', () => {
+  let mockLogger: ReturnType<typeof createMockLogger>;
+  let inFlight: number;
+  let maxInFlight: number;
+
+  const context = () => ({ logger: mockLogger }) as any;
+
+  beforeEach(() => {
+    mockLogger = createMockLogger();
+    jest.clearAllMocks();
+    inFlight = 0;
+    maxInFlight = 0;
+
+    mockScanImage.mockImplementation(async (imageId) => {
+      inFlight++;
+      maxInFlight = Math.max(maxInFlight, inFlight);
+      await new Promise((resolve) => setTimeout(resolve, 5));
+      inFlight--;
+      if (imageId === 'crash:1.0') throw new Error('trivy exited with code 2');
+      return FINDINGS[imageId] ?? Success(scanResult(imageId));
+    });
+  });
+
+  it('should still report the other images when one cannot be scanned', async () => {
+    const result = await scanImagesBatchTool.handler(
+      { images: ['api:1.0', 'missing:1.0', 'web:1.0', 'crash:1.0'] },
+      context(),
+    );
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(result.value.images.map((i) => [i.image, i.status])).toEqual([
+      ['api:1.0', 'passed'],
+      ['missing:1.0', 'error'],
+      ['web:1.0', 'passed'],
+      ['crash:1.0', 'error'],
+    ]);
+    expect(result.value.images[0]?.vulnerabilities?.medium).toBe(2);
+    expect(result.value.images[1]?.error).toBe(
+      'Failed to scan image: Image not found: missing:1.0',
+    );
+    expect(result.value.images[1]?.guidance?.resolution).toBe('Build or pull the image first');
+    expect(result.value.images[3]?.error).toContain('trivy exited with code 2');
+    expect(mockScanImage).toHaveBeenCalledTimes(4);
+  });
+
+  it('should fail the batch when any image is at or above the threshold', async () => {
+    const result = await scanImagesBatchTool.handler(
+      { images: ['api:1.0', 'worker:1.0', 'web:1.0'], severity: 'high' },
+      context(),
+    );
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(result.value.passed).toBe(false);
+    expect(result.value.images[1]).toMatchObject({ status: 'failed', blockingCount: 4 });
+    expect(result.value.totals).toEqual({ images: 3, passed: 2, failed: 1, errored: 0 });
+    expect(result.value.summary).toBe(
+      '❌ 🔒 Batch scan failed. 2 of 3 images passed, 1 failed (threshold HIGH).',
+    );
+  });
+
+  it('should pass when every image is below the threshold', async () => {
+    const result = await scanImagesBatchTool.handler(
+      { images: ['api:1.0', 'web:1.0', 'api:1.0'], severity: 'CRITICAL', scanner: 'trivy' },
+      context(),
+    );
+
+    expect(result.ok && result.value.passed).toBe(true);
+    expect(result.ok && result.value.summary).toBe(
+      '✅ 🔒 Batch scan passed. 2 of 2 images passed (threshold CRITICAL).',
+    );
+    expect(mockCreateSecurityScanner).toHaveBeenCalledWith(expect.anything(), 'trivy');
+  });
+
+  it('should not scan more images at once than the concurrency limit', async () => {
+    const images = Array.from({ length: 7 }, (_, i) => `svc-${i}:1.0`);
+
+    const result = await scanImagesBatchTool.handler({ images, concurrency: 2 }, context());
+
+    expect(result.ok && result.value.totals.passed).toBe(7);
+    expect(maxInFlight).toBe(2);
+    expect(mockCreateSecurityScanner).toHaveBeenCalledTimes(1);
+  });
+
+  it('should fail when no image could be scanned', async () => {
+    const result = await scanImagesBatchTool.handler(
+      { images: ['missing:1.0', 'crash:1.0'] },
+      context(),
+    );
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.error).toBe(
+      'Failed to scan any of 2 images: Failed to scan image: Image not found: missing:1.0',
+    );
+    expect(result.guidance?.details?.images).toHaveLength(2);
+  });
+});
@@ -0,0 +1,347 @@
+/**
+ * Unit Tests: Scan Licenses Tool
+ * Tests license classification, policy evaluation and inventory sources
+ */
+
+import { jest } from '@jest/globals';
+import { mkdirSync, writeFileSync } from 'fs';
+import path from 'path';
+import { createTestTempDir } from '../../__support__/utilities/tmp-helpers';
+import {
+  evaluateLicense,
+  evaluateLicenses,
+  licenseCategory,
+} from '../../../src/tools/scan-licenses/license-policy';
+import { readLockfileLicenses } from '../../../src/tools/scan-licenses/lockfile-licenses';
+
+function createMockLogger() {
+  return {
+    info: jest.fn(),
+    warn: jest.fn(),
+    error: jest.fn(),
+    debug: jest.fn(),
+    trace: jest.fn(),
+    fatal: jest.fn(),
+    child: jest.fn().mockReturnThis(),
+  } as any;
+}
+
+const mockSbomGenerator = {
+  name: 'syft',
+  isAvailable: jest.fn<() => Promise<boolean>>(),
+  generate: jest.fn<(imageRef: string, format: string) => Promise<any>>(),
+};
+
+jest.mock('../../../src/infra/security/sbom', () => ({
+  ...jest.requireActual<typeof import('../../../src/infra/security/sbom')>(
+    '../../../src/infra/security/sbom',
+  ),
+  createSyftGenerator: jest.fn(() => mockSbomGenerator),
+}));
+
+jest.mock('../../../src/lib/logger', () => ({
+  createTimer: jest.fn(() => ({
+    end: jest.fn(),
+    error: jest.fn(),
+  })),
+  createLogger: jest.fn(() => createMockLogger()),
+}));
+
+import {
+  default as scanLicensesTool,
+  DENIED_LICENSES_ENV,
+} from '../../../src/tools/scan-licenses/tool';
+
+const FIXTURES = path.join(__dirname, '../../__support__/fixtures/licenses');
+const IMAGE_SBOM = path.join(FIXTURES, 'image-sbom.cdx.json');
+const NPM_APP = path.join(FIXTURES, 'npm-app');
+
+const OPEN_POLICY = { allowed: [], denied: [] };
+
+describe('license policy', () => {
+  it.each([
+    ['MIT', 'permissive'],
+    ['Apache-2.0', 'permissive'],
+    ['BSD-3-Clause', 'permissive'],
+    ['LGPL-2.1-only', 'weak-copyleft'],
+    ['MPL-2.0', 'weak-copyleft'],
+    ['GPL-3.0-or-later', 'copyleft'],
+    ['AGPL-3.0-only', 'copyleft'],
+    ['LicenseRef-Proprietary', 'unknown'],
+  ])('should classify %s as %s', (license, category) => {
+    expect(licenseCategory(license)).toBe(category);
+  });
+
+  it('should allow permissive licenses and flag the rest for review by default', () => {
+    expect(evaluateLicense('MIT', OPEN_POLICY).status).toBe('allowed');
+    expect(evaluateLicense('LGPL-2.1-only', OPEN_POLICY).status).toBe('review');
+    expect(evaluateLicenses(undefined, OPEN_POLICY)).toEqual({
+      license: 'UNKNOWN',
+      category: 'unknown',
+      status: 'review',
+    });
+  });
+
+  it('should match policy entries by ID, prefix pattern and category', () => {
+    const policy = { allowed: [], denied: ['gpl-*', 'unknown'] };
+
+    expect(evaluateLicense('GPL-3.0-only', policy).status).toBe('denied');
+    expect(evaluateLicense('LGPL-2.1-only', policy).status).toBe('review');
+    expect(evaluateLicense('LicenseRef-Proprietary', policy).status).toBe('denied');
+  });
+
+  it('should require an allow-list match once an allow list is set', () => {
+    const policy = { allowed: ['MIT', 'weak-copyleft'], denied: [] };
+
+    expect(evaluateLicense('MIT', policy).status).toBe('allowed');
+    expect(evaluateLicense('LGPL-3.0-only', policy).status).toBe('allowed');
+    expect(evaluateLicense('ISC', policy).status).toBe('review');
+  });
+
+  it('should let denied entries win over allowed ones', () => {
+    const policy = { allowed: ['copyleft'], denied: ['AGPL-*'] };
+
+    expect(evaluateLicense('GPL-2.0-only', policy).status).toBe('allowed');
+    expect(evaluateLicense('AGPL-3.0-only', policy).status).toBe('denied');
+  });
+
+  it('should evaluate SPDX expressions by their semantics', () => {
+    const policy = { allowed: [], denied: ['GPL-*'] };
+
+    expect(evaluateLicenses(['MIT OR GPL-3.0-only'], policy)).toMatchObject({
+      category: 'permissive',
+      status: 'allowed',
+    });
+    expect(evaluateLicenses(['MIT AND GPL-3.0-only'], policy).status).toBe('denied');
+    expect(evaluateLicenses(['(BSD-3-Clause OR GPL-2.0-only) AND Apache-2.0'], policy)).toEqual({
+      license: '(BSD-3-Clause OR GPL-2.0-only) AND Apache-2.0',
+      category: 'permissive',
+      status: 'allowed',
+    });
+    expect(
+      evaluateLicenses(['GPL-2.0-only WITH Classpath-exception-2.0'], OPEN_POLICY),
+    ).toMatchObject({ category: 'copyleft', status: 'review' });
+  });
+
+  it('should treat several declared licenses as all required', () => {
+    expect(evaluateLicenses(['MIT', 'GPL-2.0-only'], OPEN_POLICY)).toEqual({
+      license: 'MIT AND GPL-2.0-only',
+      category: 'copyleft',
+      status: 'review',
+    });
+  });
+
+  it('should normalize common license names and keep unparseable text as a name', () => {
+    expect(evaluateLicenses(['Apache License 2.0'], OPEN_POLICY)).toEqual({
+      license: 'Apache-2.0',
+      category: 'permissive',
+      status: 'allowed',
+    });
+    expect(evaluateLicenses(['SEE LICENSE IN LICENSE.txt'], OPEN_POLICY)).toEqual({
+      license: 'SEE LICENSE IN LICENSE.txt',
+      category: 'unknown',
+      status: 'review',
+    });
+  });
+});
+
+describe('readLockfileLicenses', () => {
+  it('should read production dependencies from a v3 package-lock.json', async () => {
+    const inventory = await readLockfileLicenses(NPM_APP);
+
+    expect(inventory.lockFiles).toEqual(['package-lock.json']);
+    expect(inventory.unsupportedLockFiles).toEqual([]);
+    expect(inventory.packages).toEqual([
+      { name: 'express', version: '4.19.2', type: 'npm', licenses: ['MIT'] },
+      { name: 'ms', version: '2.0.0', type: 'npm', licenses: ['MIT'] },
+      { name: 'ms', version: '2.1.3', type: 'npm', licenses: ['MIT'] },
+      { name: 'gpl-widget', version: '1.0.0', type: 'npm', licenses: ['GPL-3.0-only'] },
+      { name: 'mystery-pkg', version: '0.1.0', type: 'npm' },
+    ]);
+  });
+
+  it('should include devDependencies when asked', async () => {
+    const inventory = await readLockfileLicenses(NPM_APP, true);
+
+    expect(inventory.packages.map((pkg) => pkg.name)).toContain('jest');
+  });
+
+  it('should fall back to installed package.json files for v1 lockfiles', async () => {
+    const { dir, cleanup } = createTestTempDir('scan-licenses-');
+    try {
+      writeFileSync(
+        path.join(dir.name, 'package-lock.json'),
+        JSON.stringify({
+          lockfileVersion: 1,
+          dependencies: {
+            debug: { version: '2.6.9', dependencies: { ms: { version: '2.0.0' } } },
+            legacy: { version: '0.0.1' },
+          },
+        }),
+      );
+      mkdirSync(path.join(dir.name, 'node_modules/debug/node_modules/ms'), { recursive: true });
+      writeFileSync(
+        path.join(dir.name, 'node_modules/debug/package.json'),
+        JSON.stringify({ license: 'MIT' }),
+      );
+      writeFileSync(
+        path.join(dir.name, 'node_modules/debug/node_modules/ms/package.json'),
+        JSON.stringify({ licenses: [{ type: 'MIT' }, { type: 'Apache-2.0' }] }),
+      );
+
+      const inventory = await readLockfileLicenses(dir.name);
+
+      expect(inventory.packages).toEqual([
+        { name: 'debug', version: '2.6.9', type: 'npm', licenses: ['MIT'] },
+        { name: 'ms', version: '2.0.0', type: 'npm', licenses: ['MIT', 'Apache-2.0'] },
+        { name: 'legacy', version: '0.0.1', type: 'npm' },
+      ]);
+    } finally {
+      await cleanup();
+    }
+  });
+
+  it('should report lockfiles that carry no license data', async () => {
+    const inventory = await readLockfileLicenses(path.join(FIXTURES, 'poetry-app'));
+
+    expect(inventory).toEqual({
+      packages: [],
+      lockFiles: ['poetry.lock'],
+      unsupportedLockFiles: ['poetry.lock'],
+    });
+  });
+});
+
+describe('scanLicenses tool', () => {
+  let mockContext: any;
+  const originalDenied = process.env[DENIED_LICENSES_ENV];
+
+  beforeEach(() => {
+    jest.clearAllMocks();
+    delete process.env[DENIED_LICENSES_ENV];
+    mockContext = { logger: createMockLogger() } as any;
+  });
+
+  afterAll(() => {
+    if (originalDenied === undefined) {
+      delete process.env[DENIED_LICENSES_ENV];
+    } else {
+      process.env[DENIED_LICENSES_ENV] = originalDenied;
+    }
+  });
+
+  it('should pass with the default policy and list packages that need review', async () => {
+    const result = await scanLicensesTool.handler({ sbomPath: IMAGE_SBOM }, mockContext);
+
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(result.value.source).toBe('sbom');
+    expect(result.value.passed).toBe(true);
+    expect(result.value.packages).toHaveLength(6);
+    expect(result.value.categories).toEqual({
+      permissive: 3,
+      'weak-copyleft': 1,
+      copyleft: 1,
+      unknown: 1,
+    });
+    expect(result.value.statuses).toEqual({ allowed: 3, review: 3, denied: 0 });
+    expect(result.value.packages.slice(0, 3).map((pkg) => pkg.name)).toEqual([
+      'bash',
+      'chardet',
+      'internal-sdk',
+    ]);
+    expect(result.value.summary).toBe(
+      `✅ Scanned licenses of 6 packages in ${IMAGE_SBOM}: 3 permissive, 1 weak-copyleft, 1 copyleft, 1 unknown. 3 packages need review: bash (GPL-3.0-or-later), chardet (LGPL-2.1-only), internal-sdk (UNKNOWN).`,
+    );
+  });
+
+  it('should fail on denied licenses and return the full inventory in the details', async () => {
+    const result = await scanLicensesTool.handler(
+      { sbomPath: IMAGE_SBOM, deniedLicenses: ['GPL-*'] },
+      mockContext,
+    );
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.error).toBe(
+      'License policy failed: 1 package uses denied licenses (GPL-3.0-or-later)',
+    );
+    expect(result.guidance?.hint).toMatch(/^❌ 1 package uses denied licenses: bash/);
+    const report = (result.guidance?.details as any).report;
+    expect(report.passed).toBe(false);
+    expect(report.packages).toHaveLength(6);
+    expect(report.packages[0]).toMatchObject({ name: 'bash', status: 'denied' });
+    // The dual-licensed package can be taken under its BSD option
+    expect(report.packages.find((pkg: any) => pkg.name === 'node-forge')).toMatchObject({
+      license: '(BSD-3-Clause OR GPL-2.0-only)',
+      status: 'allowed',
+    });
+  });
+
+  it('should read the policy from the environment when no arguments are given', async () => {
+    process.env[DENIED_LICENSES_ENV] = 'unknown';
+
+    const result = await scanLicensesTool.handler({ path: NPM_APP }, mockContext);
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    const report = (result.guidance?.details as any).report;
+    expect(report.source).toBe('lockfile');
+    expect(report.policy).toEqual({ allowed: [], denied: ['unknown'] });
+    expect(report.statuses).toEqual({ allowed: 3, review: 1, denied: 1 });
+  });
+
+  it('should generate an SBOM with syft for images', async () => {
+    mockSbomGenerator.isAvailable.mockResolvedValue(true);
+    mockSbomGenerator.generate.mockResolvedValue({
+      ok: true,
+      value: JSON.stringify({
+        bomFormat: 'CycloneDX',
+        components: [
+          { name: 'zlib1g', version: '1.2.13', purl: 'pkg:deb/debian/zlib1g@1.2.13' },
+        ],
+      }),
+    });
+
+    const result = await scanLicensesTool.handler({ imageId: 'myapp:1.0' }, mockContext);
+
+    expect(mockSbomGenerator.generate).toHaveBeenCalledWith('myapp:1.0', 'cyclonedx');
+    expect(result.ok).toBe(true);
+    if (!result.ok) return;
+    expect(result.value.source).toBe('image');
+    expect(result.value.packages).toEqual([
+      { name: 'zlib1g', version: '1.2.13', type: 'deb', ...evaluateLicenses([], OPEN_POLICY) },
+    ]);
+  });
+
+  it('should explain how to install syft when it is missing', async () => {
+    mockSbomGenerator.isAvailable.mockResolvedValue(false);
+
+    const result = await scanLicensesTool.handler({ imageId: 'myapp:1.0' }, mockContext);
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.guidance?.resolution).toContain('Install syft');
+    expect(mockSbomGenerator.generate).not.toHaveBeenCalled();
+  });
+
+  it('should suggest an SBOM when the repository has no npm lockfile', async () => {
+    const result = await scanLicensesTool.handler(
+      { path: path.join(FIXTURES, 'poetry-app') },
+      mockContext,
+    );
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.guidance?.hint).toBe('poetry.lock does not record licenses');
+    expect(result.guidance?.resolution).toContain('syft dir:');
+  });
+
+  it('should fail when no scan target is given', async () => {
+    const result = await scanLicensesTool.handler({}, mockContext);
+
+    expect(result.ok).toBe(false);
+    if (result.ok) return;
+    expect(result.error).toContain('Nothing to scan');
+  });
+});
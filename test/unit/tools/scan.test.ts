@@ -4,6 +4,7 @@
  */
 
 import { jest } from '@jest/globals';
+import { readFileSync } from 'node:fs';
 
 // Jest mocks must be at the top to ensure proper hoisting
 
@@ -334,4 +335,268 @@ describe('scanImage', () => {
       }
     });
   });
+
+  describe('Severity Gate', () => {
+    const mixedFindings = {
+      vulnerabilities: [
+        {
+          id: 'CVE-2024-0001',
+          severity: 'CRITICAL' as const,
+          package: 'openssl',
+          version: '3.0.0',
+          fixedVersion: '3.0.1',
+          description: 'Critical issue',
+        },
+        {
+          id: 'CVE-2024-0002',
+          severity: 'HIGH' as const,
+          package: 'zlib',
+          version: '1.2.11',
+          description: 'High issue without a fix',
+        },
+        {
+          id: 'CVE-2024-0003',
+          severity: 'MEDIUM' as const,
+          package: 'curl',
+          version: '8.0.0',
+          fixedVersion: '8.0.1',
+          description: 'Medium issue',
+        },
+        {
+          id: 'CVE-2024-0004',
+          severity: 'LOW' as const,
+          package: 'busybox',
+          version: '1.36.0',
+          description: 'Low issue without a fix',
+        },
+      ],
+      criticalCount: 1,
+      highCount: 1,
+      mediumCount: 1,
+      lowCount: 1,
+      negligibleCount: 0,
+      unknownCount: 0,
+      totalVulnerabilities: 4,
+      scanDate: new Date('2023-01-01T12:00:00Z'),
+      imageId: 'sha256:mock-image-id',
+    };
+
+    beforeEach(() => {
+      mockSecurityScannerInstance.scanImage.mockResolvedValue(
+        createSuccessResult(mixedFindings) as any,
+      );
+    });
+
+    it.each([
+      ['critical', 1],
+      ['high', 2],
+      ['medium', 3],
+      ['low', 4],
+    ] as const)(
+      'should fail when findings exist at or above %s',
+      async (failOnSeverity, expectedCount) => {
+        const result = await scanImage(
+          { ...config, failOnSeverity },
+          createMockToolContext(),
+        );
+
+        expect(result.ok).toBe(false);
+        if (!result.ok) {
+          expect(result.error).toContain(`${expectedCount} vulnerabilit`);
+          expect(result.error).toContain(failOnSeverity.toUpperCase());
+        }
+      },
+    );
+
+    it('should return the full findings list with a gate failure', async () => {
+      const result = await scanImage(
+        { ...config, failOnSeverity: 'CRITICAL' },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        const details = result.guidance?.details as Record<string, any>;
+        expect(details.findings).toHaveLength(4);
+        expect(details.findings.map((f: { id: string }) => f.id)).toEqual([
+          'CVE-2024-0001',
+          'CVE-2024-0002',
+          'CVE-2024-0003',
+          'CVE-2024-0004',
+        ]);
+        expect(details.vulnerabilities.critical).toBe(1);
+      }
+    });
+
+    it('should pass the gate when only lower severities are present', async () => {
+      mockSecurityScannerInstance.scanImage.mockResolvedValue(
+        createSuccessResult({
+          ...mixedFindings,
+          vulnerabilities: mixedFindings.vulnerabilities.slice(2),
+          criticalCount: 0,
+          highCount: 0,
+          totalVulnerabilities: 2,
+        }) as any,
+      );
+
+      const result = await scanImage(
+        { ...config, failOnSeverity: 'critical' },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+    });
+
+    it('should not gate when failOnSeverity is not set', async () => {
+      const result = await scanImage(config, createMockToolContext());
+
+      expect(result.ok).toBe(true);
+    });
+  });
+
+  describe('Ignore Unfixed', () => {
+    beforeEach(() => {
+      mockSecurityScannerInstance.scanImage.mockResolvedValue(
+        createSuccessResult({
+          vulnerabilities: [
+            {
+              id: 'CVE-2024-0001',
+              severity: 'CRITICAL' as const,
+              package: 'openssl',
+              version: '3.0.0',
+              description: 'Critical issue without a fix',
+            },
+            {
+              id: 'CVE-2024-0002',
+              severity: 'HIGH' as const,
+              package: 'zlib',
+              version: '1.2.11',
+              fixedVersion: '1.2.12',
+              description: 'High issue',
+            },
+            {
+              id: 'CVE-2024-0003',
+              severity: 'LOW' as const,
+              package: 'busybox',
+              version: '1.36.0',
+              description: 'Low issue without a fix',
+            },
+          ],
+          criticalCount: 1,
+          highCount: 1,
+          mediumCount: 0,
+          lowCount: 1,
+          negligibleCount: 0,
+          unknownCount: 0,
+          totalVulnerabilities: 3,
+          scanDate: new Date('2023-01-01T12:00:00Z'),
+          imageId: 'sha256:mock-image-id',
+        }) as any,
+      );
+    });
+
+    it('should exclude vulnerabilities without a fix and recount', async () => {
+      const result = await scanImage({ ...config, ignoreUnfixed: true }, createMockToolContext());
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.vulnerabilities).toEqual({
+          critical: 0,
+          high: 1,
+          medium: 0,
+          low: 0,
+          negligible: 0,
+          unknown: 0,
+          total: 1,
+        });
+      }
+    });
+
+    it('should apply the gate to fixable findings only', async () => {
+      const gated = await scanImage(
+        { ...config, failOnSeverity: 'critical', ignoreUnfixed: true },
+        createMockToolContext(),
+      );
+      expect(gated.ok).toBe(true);
+
+      const ungated = await scanImage(
+        { ...config, failOnSeverity: 'critical' },
+        createMockToolContext(),
+      );
+      expect(ungated.ok).toBe(false);
+    });
+  });
+
+  describe('SARIF Output', () => {
+    it('should write a SARIF report alongside the JSON result', async () => {
+      mockSecurityScannerInstance.scanImage.mockResolvedValue(
+        createSuccessResult({
+          vulnerabilities: [
+            {
+              id: 'CVE-2024-0001',
+              severity: 'MEDIUM' as const,
+              package: 'curl',
+              version: '8.0.0',
+              description: 'Medium issue',
+            },
+          ],
+          criticalCount: 0,
+          highCount: 0,
+          mediumCount: 1,
+          lowCount: 0,
+          negligibleCount: 0,
+          unknownCount: 0,
+          totalVulnerabilities: 1,
+          scanDate: new Date('2023-01-01T12:00:00Z'),
+          imageId: 'sha256:mock-image-id',
+        }) as any,
+      );
+
+      const result = await scanImage(
+        {
+          ...config,
+          imageId: 'sarif-test:1.0',
+          outputFormat: 'sarif',
+          dockerfilePath: 'app/Dockerfile',
+        },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.vulnerabilities.medium).toBe(1);
+        expect(result.value.sarifPath).toMatch(/sarif-test_1\.0\.sarif$/);
+        const sarif = JSON.parse(readFileSync(result.value.sarifPath ?? '', 'utf-8'));
+        expect(sarif.runs[0].tool.driver.rules[0].id).toBe('CVE-2024-0001');
+        expect(sarif.runs[0].results[0].locations[0].physicalLocation.artifactLocation.uri).toBe(
+          'app/Dockerfile',
+        );
+        expect(sarif.runs[0].results[0].properties.imageRef).toBe('sarif-test:1.0');
+      }
+    });
+
+    it('should not write SARIF by default', async () => {
+      mockSecurityScannerInstance.scanImage.mockResolvedValue(
+        createSuccessResult({
+          vulnerabilities: [],
+          criticalCount: 0,
+          highCount: 0,
+          mediumCount: 0,
+          lowCount: 0,
+          negligibleCount: 0,
+          unknownCount: 0,
+          totalVulnerabilities: 0,
+          scanDate: new Date('2023-01-01T12:00:00Z'),
+          imageId: 'sha256:mock-image-id',
+        }) as any,
+      );
+
+      const result = await scanImage(config, createMockToolContext());
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.sarifPath).toBeUndefined();
+      }
+    });
+  });
 });
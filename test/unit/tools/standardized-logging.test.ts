@@ -15,15 +15,24 @@ import type { Logger } from 'pino';
  * All MCP tools that should follow standardized logging
  */
 const ALL_TOOLS = [
+  'analyze-image-layers',
   'analyze-repo',
   'build-image',
+  'cleanup-workspace',
+  'diff-images',
   'fix-dockerfile',
   'generate-dockerfile',
   'generate-k8s-manifests',
+  'list-workflows',
   'ops',
+  'optimize-dockerfile',
   'prepare-cluster',
   'push-image',
+  'resume-workflow',
+  'rollback-deploy',
   'scan-image',
+  'scan-images-batch',
+  'scan-licenses',
   'tag-image',
   'fix-dockerfile',
   'verify-deploy',
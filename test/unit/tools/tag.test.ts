@@ -34,6 +34,9 @@ function createMockLogger() {
 
 // Mock lib modules following analyze-repo pattern
 const mockDockerClient = {
+  ping: jest.fn(async () =>
+    createSuccessResult({ socketPath: '/var/run/docker.sock', version: '27.0.0' }),
+  ),
   tagImage: jest.fn(),
 };
 
@@ -292,6 +295,52 @@ describe('tagImage', () => {
       }
     });
 
+    it('should reject a tag with a digest', async () => {
+      const result = await tagImageTool.handler(
+        { ...config, tag: `myapp:v1@sha256:${'a'.repeat(64)}` },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('digest');
+      }
+      expect(mockDockerClient.tagImage).not.toHaveBeenCalled();
+    });
+
+    it('should reject a malformed image reference', async () => {
+      const result = await tagImageTool.handler(
+        { ...config, tag: 'MyApp:v1' },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.guidance?.hint).toContain('lowercase');
+      }
+      expect(mockDockerClient.tagImage).not.toHaveBeenCalled();
+    });
+
+    it('should stop before tagging when the Docker daemon is unreachable', async () => {
+      mockDockerClient.ping.mockResolvedValueOnce({
+        ok: false,
+        error: 'Docker is unavailable: Permission denied opening the Docker socket at /var/run/docker.sock',
+        guidance: {
+          message: 'Docker daemon is not reachable',
+          details: { code: 'DOCKER_UNAVAILABLE', socketPath: '/var/run/docker.sock' },
+        },
+      } as any);
+
+      const result = await tagImageTool.handler(config, createMockToolContext());
+
+      expect(result.ok).toBe(false);
+      if (!result.ok) {
+        expect(result.error).toContain('Permission denied');
+        expect(result.guidance?.details?.code).toBe('DOCKER_UNAVAILABLE');
+      }
+      expect(mockDockerClient.tagImage).not.toHaveBeenCalled();
+    });
+
     it('should handle Docker client tagging failures', async () => {
       mockDockerClient.tagImage.mockResolvedValue(
         createFailureResult('Failed to create tag: image not found'),
@@ -38,6 +38,8 @@ const mockK8sClient = {
   getDeploymentStatus: jest.fn(),
   listServices: jest.fn(),
   getService: jest.fn(),
+  listDeploymentPods: jest.fn(),
+  listPodEvents: jest.fn(),
 };
 
 jest.mock('../../../src/infra/kubernetes/client', () => ({
@@ -62,6 +64,7 @@ function createMockToolContext() {
 // Import these after mocks are set up
 import { default as verifyDeploymentTool } from '../../../src/tools/verify-deploy/tool';
 import type { VerifyDeploymentParams } from '../../../src/tools/verify-deploy/schema';
+import { getPartialResult, isCancelled } from '../../../src/types';
 
 describe('verify-deploy', () => {
   let mockLogger: ReturnType<typeof createMockLogger>;
@@ -94,6 +97,9 @@ describe('verify-deploy', () => {
         ready: true,
       }),
     );
+
+    mockK8sClient.listDeploymentPods.mockResolvedValue(createSuccessResult([]));
+    mockK8sClient.listPodEvents.mockResolvedValue(createSuccessResult([]));
   });
 
   describe('Happy Path', () => {
@@ -275,6 +281,142 @@ describe('verify-deploy', () => {
     });
   });
 
+  describe('Wait For Ready', () => {
+    it('should report ready pod counts through the progress reporter', async () => {
+      mockK8sClient.waitForDeploymentReady.mockImplementation(
+        async (_ns: string, _name: string, _timeout: number, _poll: number, onProgress: any) => {
+          for (const readyReplicas of [1, 3, 5]) {
+            await onProgress({ ready: readyReplicas === 5, readyReplicas, totalReplicas: 5 });
+          }
+          return createSuccessResult({ ready: true, readyReplicas: 5, totalReplicas: 5 });
+        },
+      );
+      const progress = jest.fn(async (..._args: unknown[]) => undefined);
+
+      const result = await verifyDeploymentTool.handler(
+        { ...config, timeoutSeconds: 120 },
+        { ...createMockToolContext(), progress },
+      );
+
+      expect(result.ok).toBe(true);
+      expect(mockK8sClient.waitForDeploymentReady).toHaveBeenCalledWith(
+        'production',
+        'test-app',
+        120,
+        expect.any(Number),
+        expect.any(Function),
+        undefined,
+      );
+      const steps = progress.mock.calls.map(([message, index, total]) => [message, index, total]);
+      expect(steps).toEqual([
+        ['1/5 pods ready', 1, 5],
+        ['3/5 pods ready', 3, 5],
+        ['5/5 pods ready', 5, 5],
+      ]);
+      // Each update carries step metadata so clients can extrapolate an ETA
+      expect(progress.mock.calls[0]?.[3]).toMatchObject({
+        step: '1/5 pods ready',
+        elapsedMs: expect.any(Number),
+        etaMs: expect.any(Number),
+      });
+      expect(progress.mock.calls[2]?.[3]).toMatchObject({ etaMs: 0 });
+    });
+
+    it('should keep reported progress increasing when ready pods drop', async () => {
+      mockK8sClient.waitForDeploymentReady.mockImplementation(
+        async (_ns: string, _name: string, _timeout: number, _poll: number, onProgress: any) => {
+          for (const readyReplicas of [2, 3, 1, 3, 4]) {
+            await onProgress({ ready: false, readyReplicas, totalReplicas: 4 });
+          }
+          return createSuccessResult({ ready: true, readyReplicas: 4, totalReplicas: 4 });
+        },
+      );
+      const progress = jest.fn(async (..._args: unknown[]) => undefined);
+
+      await verifyDeploymentTool.handler(config, { ...createMockToolContext(), progress });
+
+      expect(progress.mock.calls.map(([, index]) => index)).toEqual([2, 3, 4]);
+    });
+
+    it('should return the last seen status and pod events on timeout', async () => {
+      mockK8sClient.waitForDeploymentReady.mockResolvedValue(
+        createFailureResult('Deployment did not become ready within 60 seconds.'),
+      );
+      mockK8sClient.getDeploymentStatus.mockResolvedValue(
+        createSuccessResult({ ready: false, readyReplicas: 1, totalReplicas: 3 }),
+      );
+      mockK8sClient.listDeploymentPods.mockResolvedValue(
+        createSuccessResult([
+          { name: 'test-app-a', phase: 'Running', ready: true, restarts: 0 },
+          { name: 'test-app-b', phase: 'Pending', ready: false, restarts: 0 },
+        ]),
+      );
+      const events = [
+        {
+          pod: 'test-app-b',
+          type: 'Warning',
+          reason: 'FailedScheduling',
+          message: '0/1 nodes are available: insufficient memory',
+          count: 4,
+        },
+      ];
+      mockK8sClient.listPodEvents.mockResolvedValue(createSuccessResult(events));
+
+      const result = await verifyDeploymentTool.handler(config, createMockToolContext());
+
+      expect(mockK8sClient.listPodEvents).toHaveBeenCalledWith('production', ['test-app-b']);
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.ready).toBe(false);
+        expect(result.value.status.readyReplicas).toBe(1);
+        expect(result.value.status.totalReplicas).toBe(3);
+        expect(result.value.events).toEqual(events);
+        expect(result.value.summary).toContain('FailedScheduling: 0/1 nodes are available');
+      }
+    });
+
+    it('should return the status read so far when cancelled during the wait', async () => {
+      const controller = new AbortController();
+      mockK8sClient.waitForDeploymentReady.mockImplementation(
+        async (...args: any[]) => {
+          // The signal is the sixth argument; abort as if the client cancelled mid-wait
+          expect(args[5]).toBe(controller.signal);
+          controller.abort();
+          return createFailureResult('Cancelled while waiting for deployment test-app');
+        },
+      );
+      mockK8sClient.getDeploymentStatus.mockResolvedValue(
+        createSuccessResult({ ready: false, readyReplicas: 2, totalReplicas: 4 }),
+      );
+
+      const result = await verifyDeploymentTool.handler(config, {
+        ...createMockToolContext(),
+        signal: controller.signal,
+      });
+
+      expect(isCancelled(result)).toBe(true);
+      expect(getPartialResult(result)).toEqual({
+        namespace: 'production',
+        deploymentName: 'test-app',
+        ready: false,
+        readyReplicas: 2,
+        totalReplicas: 4,
+      });
+      expect(mockK8sClient.listPodEvents).not.toHaveBeenCalled();
+    });
+
+    it('should check status once without waiting when waitForReady is false', async () => {
+      const result = await verifyDeploymentTool.handler(
+        { ...config, waitForReady: false },
+        createMockToolContext(),
+      );
+
+      expect(result.ok).toBe(true);
+      expect(mockK8sClient.waitForDeploymentReady).not.toHaveBeenCalled();
+      expect(mockK8sClient.getDeploymentStatus).toHaveBeenCalledWith('production', 'test-app');
+    });
+  });
+
   describe('Check Types', () => {
     it('should handle pods check', async () => {
       config.checks = ['pods'];
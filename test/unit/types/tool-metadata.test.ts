@@ -2,7 +2,9 @@
  * Tests for tool metadata validation
  */
 
+import { z } from 'zod';
 import { validateAllToolMetadata, type ValidatableTool } from '@/types/tool-metadata';
+import { findSchemaIssues } from '@/lib/zod-utils';
 
 describe('Tool Metadata Validation', () => {
   describe('validateAllToolMetadata', () => {
@@ -263,4 +265,60 @@ describe('Tool Metadata Validation', () => {
       }
     });
   });
+
+  describe('input schema checks', () => {
+    const wellFormed = z.object({
+      path: z.string().min(1).describe('Path to the build context'),
+      tags: z.array(z.string()).optional().describe('Tags to apply'),
+      modules: z
+        .array(z.object({ name: z.string().describe('Module name') }))
+        .optional()
+        .describe('Modules to build'),
+    });
+
+    const incomplete = z
+      .object({
+        path: z.string().describe('Path to the build context'),
+        imageName: z.string().optional(),
+        since: z.date().optional().describe('Only rebuild after this time'),
+        modules: z
+          .array(z.object({ name: z.string(), port: z.number().describe('Listen port') }))
+          .describe('Modules to build'),
+      })
+      .refine((data) => data.path.length > 0);
+
+    it('should report undescribed and non-JSON fields, including nested ones', () => {
+      expect(findSchemaIssues(incomplete)).toEqual([
+        'imageName: missing description',
+        'since: date values cannot be passed as JSON',
+        'modules[].name: missing description',
+      ]);
+    });
+
+    it('should report nothing for a fully described schema', () => {
+      expect(findSchemaIssues(wellFormed)).toEqual([]);
+    });
+
+    it('should mark tools with schema issues as invalid', async () => {
+      const result = await validateAllToolMetadata([
+        { name: 'good-tool', metadata: { knowledgeEnhanced: false }, schema: wellFormed },
+        { name: 'bad-tool', metadata: { knowledgeEnhanced: false }, schema: incomplete },
+      ]);
+
+      expect(result.ok).toBe(true);
+      if (result.ok) {
+        expect(result.value.validTools).toEqual(['good-tool']);
+        expect(result.value.metadataErrors).toEqual([]);
+        expect(result.value.invalidTools).toEqual([
+          {
+            name: 'bad-tool',
+            issues: findSchemaIssues(incomplete),
+            suggestions: [
+              'Describe every input field with .describe() and use JSON-compatible types only',
+            ],
+          },
+        ]);
+      }
+    });
+  });
 });
@@ -0,0 +1,173 @@
+/**
+ * Tests for docker-compose validation
+ */
+
+import {
+  createComposeValidator,
+  ValidationSeverity,
+  ValidationCategory,
+  type ComposeValidatorInstance,
+} from '../../../src/validation';
+
+describe('ComposeValidator', () => {
+  let validator: ComposeValidatorInstance;
+
+  beforeEach(() => {
+    validator = createComposeValidator();
+  });
+
+  const failedRuleIds = (yamlContent: string): string[] =>
+    validator
+      .validate(yamlContent)
+      .results.filter((r) => !r.passed)
+      .map((r) => r.ruleId ?? '');
+
+  describe('Service Rules', () => {
+    test('should flag every problem in a misconfigured compose file', () => {
+      const compose = `
+version: '3.8'
+services:
+  web:
+    image: nginx:latest
+    network_mode: host
+    volumes:
+      - /var/run/docker.sock:/var/run/docker.sock
+      - ./html:/usr/share/nginx/html:ro
+  worker:
+    environment:
+      QUEUE: jobs
+    volumes:
+      - type: bind
+        source: /etc
+        target: /host-etc
+  cache:
+    image: redis
+    deploy:
+      resources:
+        limits:
+          cpus: '0.5'
+          memory: 256M
+      `.trim();
+
+      expect(failedRuleIds(compose).sort()).toEqual([
+        'cache-no-latest-tag',
+        'web-has-resource-limits',
+        'web-no-host-network',
+        'web-no-latest-tag',
+        'web-no-sensitive-bind-mounts',
+        'worker-has-image-or-build',
+        'worker-has-resource-limits',
+        'worker-no-sensitive-bind-mounts',
+      ]);
+
+      const report = validator.validate(compose);
+      const socketMount = report.results.find((r) => r.ruleId === 'web-no-sensitive-bind-mounts');
+      expect(socketMount?.metadata?.severity).toBe(ValidationSeverity.ERROR);
+      expect(socketMount?.metadata?.location).toBe('services.web');
+      expect(report.grade).toBe('F');
+    });
+
+    test('should pass a clean compose file', () => {
+      const compose = `
+services:
+  api:
+    build:
+      context: ./api
+    volumes:
+      - ./config:/app/config:ro
+      - data:/app/data
+    deploy:
+      resources:
+        limits:
+          cpus: '1'
+          memory: 512M
+  db:
+    image: postgres:16.4@sha256:3f1c4b0e8d9a7e6f5c4b3a2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f
+    volumes:
+      - type: volume
+        source: pgdata
+        target: /var/lib/postgresql/data
+    deploy:
+      resources:
+        limits:
+          cpus: '2'
+          memory: 1G
+volumes:
+  data:
+  pgdata:
+      `.trim();
+
+      const report = validator.validate(compose);
+
+      expect(report.results.every((r) => r.passed)).toBe(true);
+      expect(report.results).toHaveLength(2 * validator.getRules().length);
+      expect(report.score).toBe(100);
+      expect(report.grade).toBe('A');
+    });
+
+    test('should accept v2 service-level resource limits', () => {
+      const compose = `
+version: '2.4'
+services:
+  app:
+    image: registry.example.com:5000/team/app:1.4.2
+    mem_limit: 256m
+    cpus: 0.5
+      `.trim();
+
+      expect(failedRuleIds(compose)).toEqual([]);
+    });
+
+    test('should apply settings merged in from anchors', () => {
+      const compose = `
+x-defaults: &defaults
+  image: registry.example.com/team/app:1.4.2
+  deploy:
+    resources:
+      limits:
+        cpus: '0.5'
+        memory: 256M
+services:
+  api:
+    <<: *defaults
+  worker:
+    <<: *defaults
+    image: registry.example.com/team/app:latest
+    network_mode: host
+      `.trim();
+
+      expect(failedRuleIds(compose).sort()).toEqual([
+        'worker-no-host-network',
+        'worker-no-latest-tag',
+      ]);
+    });
+  });
+
+  describe('Error Handling', () => {
+    test('should handle invalid YAML syntax', () => {
+      const report = validator.validate('services:\n  web: [unclosed');
+
+      expect(report.results[0]?.ruleId).toBe('parse-error');
+      expect(report.grade).toBe('F');
+    });
+
+    test('should report files without services', () => {
+      expect(failedRuleIds('version: "3"\nnetworks:\n  default: {}')).toEqual(['no-services']);
+      expect(failedRuleIds('')).toEqual(['no-services']);
+    });
+
+    test('should reject unsupported compose versions', () => {
+      expect(failedRuleIds('version: "1"\nservices:\n  web:\n    image: nginx:1.27')).toEqual([
+        'unsupported-version',
+      ]);
+    });
+  });
+
+  describe('Rule Access', () => {
+    test('should filter rules by category', () => {
+      const security = validator.getCategory(ValidationCategory.SECURITY).map((r) => r.id);
+
+      expect(security).toEqual(['no-host-network', 'no-sensitive-bind-mounts']);
+    });
+  });
+});
@@ -0,0 +1,157 @@
+/**
+ * Tests for the Dockerfile optimizer
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import { optimizeDockerfile } from '@/validation/dockerfile-optimizer';
+import { validateDockerfileContent } from '@/validation/dockerfile-validator';
+
+const UNOPTIMIZED = `FROM node:20
+WORKDIR /app
+COPY . .
+RUN apt-get update
+RUN apt-get install -y curl
+# install deps
+RUN npm ci
+EXPOSE 3000
+USER node
+CMD ["node", "server.js"]
+`;
+
+describe('Dockerfile Optimizer', () => {
+  describe('deliberately unoptimized Dockerfile', () => {
+    const { suggestions, optimized = '' } = optimizeDockerfile(UNOPTIMIZED);
+
+    it('should return suggestions ordered by impact', () => {
+      expect(suggestions.map((s) => [s.id, s.impact, s.autoApplied])).toEqual([
+        ['system-packages-before-source', 'high', true],
+        ['dependencies-before-source', 'high', true],
+        ['slim-base-image', 'medium', false],
+        ['combine-run', 'medium', true],
+      ]);
+    });
+
+    it('should point suggestions at the original line numbers', () => {
+      const lines = Object.fromEntries(suggestions.map((s) => [s.id, s.lines]));
+
+      expect(lines['system-packages-before-source']).toEqual([4, 5]);
+      expect(lines['dependencies-before-source']).toEqual([7]);
+      expect(lines['slim-base-image']).toEqual([1]);
+    });
+
+    it('should install dependencies from the manifests before copying the source', () => {
+      expect(optimized).toContain('COPY package*.json ./\n# install deps\nRUN npm ci\nCOPY . .');
+    });
+
+    it('should move and merge system package installation above the source copy', () => {
+      expect(optimized).toContain('RUN apt-get update \\\n    && apt-get install -y curl');
+      expect(optimized.indexOf('apt-get update')).toBeLessThan(optimized.indexOf('COPY . .'));
+    });
+
+    it('should keep every other instruction', () => {
+      for (const line of ['FROM node:20', 'WORKDIR /app', 'EXPOSE 3000', 'USER node']) {
+        expect(optimized).toContain(line);
+      }
+      expect(optimized.trimEnd().endsWith('CMD ["node", "server.js"]')).toBe(true);
+    });
+
+    it('should not switch the base image automatically', () => {
+      expect(optimized).not.toContain('node:20-slim');
+    });
+
+    it('should satisfy the layer caching validation rule', async () => {
+      const before = await validateDockerfileContent(UNOPTIMIZED, { enableExternalLinter: false });
+      const after = await validateDockerfileContent(optimized, { enableExternalLinter: false });
+      const failed = (report: typeof before): string[] =>
+        report.results.filter((r) => !r.passed).map((r) => r.ruleId);
+
+      expect(failed(before)).toContain('layer-caching-optimization');
+      expect(failed(after)).not.toContain('layer-caching-optimization');
+      expect(after.errors).toBe(0);
+    });
+  });
+
+  describe('safety', () => {
+    it('should split a chained install from the steps that need the source', () => {
+      const { optimized = '' } = optimizeDockerfile(
+        'FROM python:3.12\nWORKDIR /src\nCOPY . .\nRUN pip install -r requirements.txt && python -m compileall .\n',
+      );
+
+      expect(optimized).toContain(
+        'COPY requirements.txt ./\nRUN pip install -r requirements.txt\nCOPY . .\nRUN python -m compileall .',
+      );
+    });
+
+    it('should not move an install across instructions that change the environment', () => {
+      const { suggestions, optimized } = optimizeDockerfile(
+        'FROM node:20-slim\nCOPY . .\nENV NODE_ENV=production\nRUN npm ci\n',
+      );
+
+      const deps = suggestions.find((s) => s.id === 'dependencies-before-source');
+      expect(deps?.autoApplied).toBe(false);
+      expect(deps?.description).toContain('Not applied automatically');
+      expect(optimized).toBeUndefined();
+    });
+
+    it('should not hoist a package install that uses a file from the build context', () => {
+      for (const install of ['apt-get install -y ./vendor/pkg.deb', 'apk add pkg.apk']) {
+        const { suggestions, optimized } = optimizeDockerfile(
+          `FROM debian:12\nWORKDIR /app\nCOPY . .\nRUN ${install}\n`,
+        );
+
+        expect(suggestions.map((s) => s.id)).not.toContain('system-packages-before-source');
+        expect(optimized).toBeUndefined();
+      }
+    });
+
+    it('should not hoist a package install across a copy that can change package sources', () => {
+      const cases = [
+        'FROM debian:12\nCOPY . .\nRUN apt-get update\n',
+        'FROM debian:12\nWORKDIR /app\nCOPY . .\nCOPY sources.list /etc/apt/sources.list.d/\nRUN apt-get update\n',
+      ];
+
+      for (const dockerfile of cases) {
+        const { suggestions, optimized } = optimizeDockerfile(dockerfile);
+        const hoist = suggestions.find((s) => s.id === 'system-packages-before-source');
+
+        expect(hoist?.autoApplied).toBe(false);
+        expect(hoist?.description).toContain('may add package sources or keys');
+        expect(optimized).toBeUndefined();
+      }
+    });
+
+    it('should not move commands that install a named package', () => {
+      const { suggestions } = optimizeDockerfile(
+        'FROM node:20-slim\nCOPY . .\nRUN npm install lodash\n',
+      );
+
+      expect(suggestions.map((s) => s.id)).not.toContain('dependencies-before-source');
+    });
+
+    it('should not merge RUN instructions that change directory', () => {
+      const { suggestions, optimized } = optimizeDockerfile(
+        'FROM alpine:3.19\nRUN cd /tmp\nRUN cd /opt\n',
+      );
+
+      expect(suggestions.find((s) => s.id === 'combine-run')?.autoApplied).toBe(false);
+      expect(optimized).toBeUndefined();
+    });
+
+    it('should only consider the final stage for slim base images', () => {
+      const { suggestions } = optimizeDockerfile(
+        'FROM node:20 AS build\nRUN npm run build\nFROM nginx:1.27-alpine\nCOPY --from=build /app/dist /usr/share/nginx/html\n',
+      );
+
+      expect(suggestions.map((s) => s.id)).not.toContain('slim-base-image');
+    });
+
+    it('should report nothing for an already optimized Dockerfile', () => {
+      const { suggestions, optimized } = optimizeDockerfile(
+        'FROM node:20-slim\nWORKDIR /app\nCOPY package*.json ./\nRUN npm ci\nCOPY . .\nCMD ["node", "server.js"]\n',
+      );
+
+      expect(suggestions).toEqual([]);
+      expect(optimized).toBeUndefined();
+    });
+  });
+});
@@ -0,0 +1,149 @@
+/**
+ * Tests for Dockerfile validation
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  revalidateDockerfile,
+  validateDockerfileContent,
+  type DockerfileValidationState,
+} from '@/validation/dockerfile-validator';
+import type { ValidationResult } from '@/validation/core-types';
+import { DEFAULT_SECRET_RULESET } from '@/validation/secret-rules';
+import { SECRETS_DOCKERFILE } from '../../__support__/fixtures/secrets/dockerfile-secrets';
+
+const DOCKERFILE = `FROM node:20-alpine
+WORKDIR /app
+COPY package*.json ./
+RUN npm ci
+COPY . .
+USER root
+EXPOSE 3000
+CMD ["node", "server.js"]`;
+
+const ALL_RULES = [
+  'no-root-user',
+  'no-sudo-install',
+  'specific-base-image',
+  'has-healthcheck',
+  'layer-caching-optimization',
+  'no-secrets',
+  'multi-stage-optimization',
+  'has-expose',
+  'workdir-set',
+];
+
+function validate(
+  content: string,
+  previous?: DockerfileValidationState,
+): DockerfileValidationState {
+  const result = revalidateDockerfile(content, previous);
+  if (!result.ok) throw new Error(result.error);
+  return result.value;
+}
+
+const failedRules = (state: DockerfileValidationState): string[] =>
+  state.report.results.filter((r) => !r.passed).map((r) => r.ruleId ?? '');
+
+describe('revalidateDockerfile', () => {
+  it('should evaluate every rule without a previous state', () => {
+    const state = validate(DOCKERFILE);
+
+    expect(state.evaluatedRules).toEqual(ALL_RULES);
+    expect(failedRules(state)).toEqual(['no-root-user', 'has-healthcheck']);
+  });
+
+  it('should only re-run rules that read the edited instruction', () => {
+    const initial = validate(DOCKERFILE);
+
+    const next = validate(DOCKERFILE.replace('USER root', 'USER node'), initial);
+
+    expect(next.evaluatedRules).toEqual(['no-root-user', 'multi-stage-optimization']);
+    expect(failedRules(next)).toEqual(['has-healthcheck']);
+
+    const carried = next.report.results.find((r) => r.ruleId === 'layer-caching-optimization');
+    const original = initial.report.results.find((r) => r.ruleId === 'layer-caching-optimization');
+    expect(carried).toBe(original);
+  });
+
+  it('should re-run rules for added lines and match a full validation', () => {
+    const initial = validate(DOCKERFILE);
+    const edited = DOCKERFILE.replace('WORKDIR /app', 'WORKDIR /app\nENV API_KEY=abc123');
+
+    const next = validate(edited, initial);
+
+    expect(next.evaluatedRules).toEqual(['no-secrets', 'multi-stage-optimization']);
+    expect(failedRules(next)).toContain('no-secrets');
+    expect(next.report.results).toEqual(validate(edited).report.results);
+  });
+
+  it('should re-run order-sensitive rules when instructions are reordered', () => {
+    const initial = validate(DOCKERFILE);
+    const reordered = DOCKERFILE.replace(
+      'COPY package*.json ./\nRUN npm ci\nCOPY . .',
+      'COPY . .\nCOPY package*.json ./\nRUN npm ci',
+    );
+
+    const next = validate(reordered, initial);
+
+    expect(next.evaluatedRules).toEqual(['layer-caching-optimization', 'multi-stage-optimization']);
+    expect(failedRules(next)).toContain('layer-caching-optimization');
+  });
+
+  it('should carry over everything but whole-file rules when instructions are unchanged', () => {
+    const initial = validate(DOCKERFILE);
+
+    const next = validate(DOCKERFILE.replace('RUN npm ci', '# Install deps\nRUN npm ci'), initial);
+
+    expect(next.evaluatedRules).toEqual(['multi-stage-optimization']);
+    expect(next.report.results).toEqual(initial.report.results);
+  });
+});
+
+describe('no-secrets rule', () => {
+  const noSecrets = async (
+    options: Parameters<typeof validateDockerfileContent>[1] = {},
+  ): Promise<ValidationResult | undefined> => {
+    const report = await validateDockerfileContent(SECRETS_DOCKERFILE, {
+      enableExternalLinter: false,
+      ...options,
+    });
+    return report.results.find((r) => r.ruleId === 'no-secrets');
+  };
+
+  it('should report every secret type with its rule, file, line and a redacted match', async () => {
+    const result = await noSecrets({ path: 'Dockerfile' });
+
+    expect(result?.passed).toBe(false);
+    expect(result?.errors).toEqual([
+      'Dockerfile:3: Slack bot token (xoxb****)',
+      'Dockerfile:4: Slack user token (xoxp****)',
+      'Dockerfile:5: Slack webhook URL (http****)',
+      'Dockerfile:6: GCP service account key ("typ****)',
+      'Dockerfile:7: GCP API key (AIza****)',
+      'Dockerfile:8: Stripe access token (sk_l****)',
+      'Dockerfile:9: Private key (----****)',
+      'Dockerfile:10: JSON Web Token (eyJh****)',
+    ]);
+    expect(result?.message).toContain('(found: slack-bot-token, slack-user-token,');
+  });
+
+  it('should pass when the allowlist covers every finding', async () => {
+    const result = await noSecrets({
+      secretRules: { ...DEFAULT_SECRET_RULESET, allowlist: { regexes: [/./] } },
+    });
+
+    expect(result?.passed).toBe(true);
+  });
+
+  it('should keep reporting hardcoded keyword assignments by variable name', async () => {
+    const report = await validateDockerfileContent(
+      `${DOCKERFILE}\nENV DB_PASSWORD=hunter2-but-longer`,
+      { enableExternalLinter: false },
+    );
+    const result = report.results.find((r) => r.ruleId === 'no-secrets');
+
+    expect(result?.message).toContain('(found: DB_PASSWORD)');
+    expect(result?.errors).toEqual(['Line 9: DB_PASSWORD (hunt****)']);
+  });
+});
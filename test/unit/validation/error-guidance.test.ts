@@ -0,0 +1,97 @@
+/**
+ * Tests for converting between validation results and error guidance
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  validationResultToGuidance,
+  guidanceToValidationResult,
+} from '@/validation/error-guidance';
+import {
+  ValidationCategory,
+  ValidationSeverity,
+  type ValidationResult,
+} from '@/validation/core-types';
+import type { ErrorGuidance } from '@/types';
+
+const failedResult: ValidationResult = {
+  isValid: false,
+  passed: false,
+  ruleId: 'no-root-user',
+  message: 'Container runs as root',
+  errors: ['Container runs as root'],
+  warnings: ['No HEALTHCHECK defined'],
+  suggestions: ['Add a non-root user', 'Switch to it with USER before CMD'],
+  metadata: {
+    severity: ValidationSeverity.ERROR,
+    category: ValidationCategory.SECURITY,
+    location: 'Dockerfile:12',
+    fixSuggestion: 'USER appuser',
+  },
+};
+
+describe('validation error guidance', () => {
+  describe('validationResultToGuidance', () => {
+    it('should map suggestions to the resolution and keep the rule details', () => {
+      expect(validationResultToGuidance(failedResult)).toEqual({
+        message: 'Container runs as root',
+        hint: 'USER appuser',
+        resolution: 'Add a non-root user\nSwitch to it with USER before CMD',
+        details: {
+          ruleId: 'no-root-user',
+          errors: ['Container runs as root'],
+          warnings: ['No HEALTHCHECK defined'],
+          severity: 'error',
+          category: 'security',
+          location: 'Dockerfile:12',
+        },
+        retryable: false,
+      });
+    });
+
+    it('should fall back to the first error when there is no message', () => {
+      const guidance = validationResultToGuidance({
+        isValid: false,
+        errors: ['Missing FROM instruction'],
+        warnings: [],
+      });
+
+      expect(guidance.message).toBe('Missing FROM instruction');
+      expect(guidance).not.toHaveProperty('resolution');
+      expect(guidance).not.toHaveProperty('hint');
+    });
+  });
+
+  describe('guidanceToValidationResult', () => {
+    it('should round-trip a validation result without losing fields', () => {
+      expect(guidanceToValidationResult(validationResultToGuidance(failedResult))).toEqual(
+        failedResult,
+      );
+    });
+
+    it('should round-trip guidance produced from a validation result', () => {
+      const guidance = validationResultToGuidance(failedResult);
+
+      expect(validationResultToGuidance(guidanceToValidationResult(guidance))).toEqual(guidance);
+    });
+
+    it('should map plain guidance onto the closest fields', () => {
+      const guidance: ErrorGuidance = {
+        message: 'Image tag is invalid',
+        hint: 'Tags cannot contain uppercase letters',
+        resolution: 'Use a lowercase tag\n\nRe-run the build',
+        details: { severity: 'not-a-severity', statusCode: 400 },
+      };
+
+      expect(guidanceToValidationResult(guidance)).toEqual({
+        isValid: false,
+        passed: false,
+        message: 'Image tag is invalid',
+        errors: ['Image tag is invalid'],
+        warnings: [],
+        suggestions: ['Use a lowercase tag', 'Re-run the build'],
+        metadata: { fixSuggestion: 'Tags cannot contain uppercase letters' },
+      });
+    });
+  });
+});
@@ -2,7 +2,13 @@
  * Tests for Kubernetes validation using YAML parser
  */
 
-import { createKubernetesValidator, ValidationSeverity, type KubernetesValidatorInstance } from '../../../src/validation';
+import {
+  createKubernetesValidator,
+  createK8sApiVersionValidator,
+  createK8sLabelConventionValidator,
+  ValidationSeverity,
+  type KubernetesValidatorInstance,
+} from '../../../src/validation';
 
 describe('KubernetesValidator', () => {
   let validator: KubernetesValidatorInstance;
@@ -404,4 +410,196 @@ spec:
       expect(report.results[0].ruleId).toBe('no-documents');
     });
   });
-});
\ No newline at end of file
+
+  describe('Validation Context', () => {
+    const deployment = (...images: string[]) =>
+      `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+spec:
+  template:
+    spec:
+      containers:
+${images.map((image, i) => `      - name: c${i}\n        image: ${image}`).join('\n')}
+      `.trim();
+
+    const registryResult = (manifest: string, allowedRegistries?: string[]) =>
+      validator
+        .validate(manifest, allowedRegistries ? { allowedRegistries } : undefined)
+        .results.find((r) => r.ruleId === 'my-app-trusted-registry');
+
+    test('should allow any registry without an allowlist', () => {
+      expect(registryResult(deployment('nginx:1.27'))?.passed).toBe(true);
+    });
+
+    test('should enforce the allowlist passed for the call', () => {
+      const allowed = ['myregistry.azurecr.io', 'ghcr.io/acme/'];
+
+      expect(
+        registryResult(
+          deployment('myregistry.azurecr.io/app:1.0', 'ghcr.io/acme/sidecar:2.1'),
+          allowed,
+        )?.passed,
+      ).toBe(true);
+
+      const untrusted = registryResult(deployment('ghcr.io/other/sidecar:2.1'), allowed);
+      expect(untrusted?.passed).toBe(false);
+      expect(untrusted?.metadata?.severity).toBe(ValidationSeverity.ERROR);
+
+      // Images without a registry host resolve to Docker Hub
+      expect(registryResult(deployment('nginx:1.27'), allowed)?.passed).toBe(false);
+      expect(registryResult(deployment('nginx:1.27'), ['docker.io'])?.passed).toBe(true);
+    });
+
+    test('should not leak context into later calls', () => {
+      const manifest = deployment('nginx:1.27');
+
+      expect(registryResult(manifest, ['myregistry.azurecr.io'])?.passed).toBe(false);
+      expect(registryResult(manifest)?.passed).toBe(true);
+    });
+  });
+
+  describe('API Versions', () => {
+    const ingress = (apiVersion: string) =>
+      `
+apiVersion: ${apiVersion}
+kind: Ingress
+metadata:
+  name: web
+spec:
+  rules:
+  - host: example.com
+      `.trim();
+
+    test('should flag an API version removed before the target version', () => {
+      const report = createK8sApiVersionValidator('1.29').validate(ingress('extensions/v1beta1'));
+
+      const removed = report.results.find((r) => r.ruleId === 'web-api-version-removed');
+      expect(removed?.passed).toBe(false);
+      expect(removed?.metadata?.severity).toBe(ValidationSeverity.ERROR);
+      expect(removed?.suggestions?.[0]).toContain('networking.k8s.io/v1');
+      expect(report.errors).toBe(1);
+    });
+
+    test('should warn about an API version deprecated but still served', () => {
+      const report = createK8sApiVersionValidator('v1.20.4').validate(
+        ingress('networking.k8s.io/v1beta1'),
+      );
+
+      const deprecated = report.results.find((r) => r.ruleId === 'web-api-version-deprecated');
+      expect(deprecated?.passed).toBe(false);
+      expect(deprecated?.metadata?.severity).toBe(ValidationSeverity.WARNING);
+      expect(deprecated?.suggestions?.[0]).toContain('removed in 1.22');
+      expect(report.errors).toBe(0);
+    });
+
+    test('should pass a current API version', () => {
+      const report = createK8sApiVersionValidator('1.29').validate(ingress('networking.k8s.io/v1'));
+
+      expect(report.failed).toBe(0);
+      expect(report.score).toBe(100);
+      expect(report.results.every((r) => r.passed)).toBe(true);
+    });
+
+    test('should only check API versions when a target version is given', () => {
+      const manifest = ingress('extensions/v1beta1');
+      const apiResult = (context?: { targetVersion: string }) =>
+        validator
+          .validate(manifest, context)
+          .results.find((r) => r.ruleId === 'web-api-version-removed');
+
+      expect(apiResult()?.passed).toBe(true);
+      expect(apiResult({ targetVersion: '1.22' })?.passed).toBe(false);
+    });
+  });
+
+  describe('Label Conventions', () => {
+    const labelValidator = createK8sLabelConventionValidator({
+      requiredLabels: [
+        'app.kubernetes.io/name',
+        { key: 'version', pattern: '^\\d+\\.\\d+\\.\\d+$' },
+      ],
+      recommendedLabels: ['app.kubernetes.io/part-of'],
+      requiredAnnotations: [{ key: 'example.com/cost-center', pattern: /^cc-\d{4}$/ }],
+    });
+
+    test('should pass a deployment that follows the conventions', () => {
+      const manifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    app.kubernetes.io/name: web
+    app.kubernetes.io/part-of: shop
+    version: "1.4.2"
+  annotations:
+    example.com/cost-center: cc-1234
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.27
+      `.trim();
+
+      const report = labelValidator.validate(manifest);
+
+      expect(report.results).toHaveLength(4);
+      expect(report.failed).toBe(0);
+      expect(report.score).toBe(100);
+    });
+
+    test('should report missing and malformed keys per resource with the field path', () => {
+      const manifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    version: latest
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.27
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-svc
+  labels:
+    app.kubernetes.io/name: web
+    version: "1.4.2"
+  annotations:
+    example.com/cost-center: cc-1234
+spec:
+  selector:
+    app: web
+      `.trim();
+
+      const report = labelValidator.validate(manifest);
+      const failed = report.results.filter((r) => !r.passed);
+
+      expect(failed.map((r) => r.ruleId)).toEqual([
+        'web-label-app.kubernetes.io/name',
+        'web-label-version',
+        'web-label-app.kubernetes.io/part-of',
+        'web-annotation-example.com/cost-center',
+        'web-svc-label-app.kubernetes.io/part-of',
+      ]);
+      expect(report.errors).toBe(3);
+      expect(report.warnings).toBe(2);
+
+      const name = failed[0];
+      expect(name?.metadata?.location).toBe('Deployment/web');
+      expect(name?.errors[0]).toContain('metadata.labels["app.kubernetes.io/name"]');
+      expect(name?.suggestions).toEqual(['Add metadata.labels["app.kubernetes.io/name"]']);
+      expect(failed[1]?.suggestions?.[0]).toContain('from "latest"');
+      expect(failed[3]?.errors[0]).toContain('metadata.annotations["example.com/cost-center"]');
+    });
+  });
+});
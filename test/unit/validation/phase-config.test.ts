@@ -0,0 +1,140 @@
+/**
+ * Tests for building validation phases from config
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import {
+  parsePhaseConfig,
+  buildValidationPhases,
+  type ValidatorRegistry,
+} from '@/validation/phase-config';
+import { runValidationPhases } from '@/validation/phases';
+import { ValidationSeverity, type ValidationReport } from '@/validation/core-types';
+
+/**
+ * Report with a single result; errors when the rule fails
+ */
+function reportWith(ruleId: string, passed: boolean): ValidationReport {
+  return {
+    results: [
+      {
+        ruleId,
+        isValid: passed,
+        passed,
+        errors: passed ? [] : [`${ruleId} failed`],
+        warnings: [],
+        metadata: { severity: ValidationSeverity.ERROR },
+      },
+    ],
+    score: passed ? 100 : 85,
+    grade: passed ? 'A' : 'B',
+    passed: passed ? 1 : 0,
+    failed: passed ? 0 : 1,
+    errors: passed ? 0 : 1,
+    warnings: 0,
+    info: 0,
+    timestamp: new Date().toISOString(),
+  };
+}
+
+const registry: ValidatorRegistry<string> = {
+  parse: () => (content) => reportWith('parse', content.startsWith('FROM')),
+  'max-lines': (options) => (content) =>
+    reportWith('max-lines', content.split('\n').length <= Number(options.limit ?? 100)),
+  secrets: () => (content) => reportWith('secrets', !/PASSWORD=/.test(content)),
+};
+
+describe('parsePhaseConfig', () => {
+  it('should accept YAML with string and object validator references', () => {
+    const result = parsePhaseConfig(`
+failFast: true
+phases:
+  - name: syntax
+    validators: [parse]
+  - name: policy
+    validators:
+      - secrets
+      - name: max-lines
+        options: { limit: 2 }
+`);
+
+    expect(result.ok).toBe(true);
+    if (result.ok) {
+      expect(result.value.failFast).toBe(true);
+      expect(result.value.phases[1]?.validators).toEqual([
+        'secrets',
+        { name: 'max-lines', options: { limit: 2 } },
+      ]);
+    }
+  });
+
+  it('should accept JSON content', () => {
+    const result = parsePhaseConfig('{"phases":[{"name":"syntax","validators":["parse"]}]}');
+
+    expect(result.ok).toBe(true);
+  });
+
+  it('should reject configs without phases', () => {
+    const result = parsePhaseConfig('failFast: true');
+
+    expect(result.ok).toBe(false);
+    if (!result.ok) {
+      expect(result.error).toContain('phases');
+    }
+  });
+});
+
+describe('buildValidationPhases', () => {
+  it('should build phases that run with per-validator options', async () => {
+    const config = parsePhaseConfig(`
+failFast: true
+phases:
+  - name: syntax
+    validators: [parse]
+  - name: policy
+    validators:
+      - secrets
+      - name: max-lines
+        options: { limit: 2 }
+`);
+    if (!config.ok) throw new Error(config.error);
+
+    const pipeline = buildValidationPhases(config.value, registry);
+    expect(pipeline.ok).toBe(true);
+    if (!pipeline.ok) return;
+
+    expect(pipeline.value.options).toEqual({ failFast: true });
+
+    const report = await runValidationPhases(
+      'FROM node:20\nENV PASSWORD=hunter2\nCMD ["node"]',
+      pipeline.value.phases,
+      pipeline.value.options,
+    );
+
+    expect(report.phases.map((phase) => phase.status)).toEqual(['passed', 'failed']);
+    expect(report.results.filter((r) => !r.passed).map((r) => r.ruleId)).toEqual([
+      'secrets',
+      'max-lines',
+    ]);
+  });
+
+  it('should fail listing every unregistered validator', () => {
+    const result = buildValidationPhases(
+      {
+        phases: [
+          { name: 'syntax', validators: ['parse', 'lint'] },
+          { name: 'security', validators: [{ name: 'trivy' }] },
+        ],
+      },
+      registry,
+    );
+
+    expect(result.ok).toBe(false);
+    if (!result.ok) {
+      expect(result.error).toBe(
+        'Unknown validators in validation config: syntax/lint, security/trivy',
+      );
+      expect(result.guidance?.hint).toContain('parse, max-lines, secrets');
+    }
+  });
+});
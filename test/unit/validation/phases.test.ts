@@ -0,0 +1,138 @@
+/**
+ * Tests for phased validation
+ */
+
+import { describe, it, expect, jest } from '@jest/globals';
+import { runValidationPhases, type PhaseValidator } from '@/validation/phases';
+import { ValidationSeverity, type ValidationReport } from '@/validation/core-types';
+
+/**
+ * Report with a single result of the given severity
+ */
+function reportWith(
+  ruleId: string,
+  passed: boolean,
+  severity: ValidationSeverity,
+): ValidationReport {
+  return {
+    results: [
+      {
+        ruleId,
+        isValid: passed,
+        passed,
+        errors: passed ? [] : [`${ruleId} failed`],
+        warnings: [],
+        metadata: { severity },
+      },
+    ],
+    score: passed ? 100 : 0,
+    grade: passed ? 'A' : 'F',
+    passed: passed ? 1 : 0,
+    failed: passed ? 0 : 1,
+    errors: !passed && severity === ValidationSeverity.ERROR ? 1 : 0,
+    warnings: severity === ValidationSeverity.WARNING ? 1 : 0,
+    info: severity === ValidationSeverity.INFO ? 1 : 0,
+    timestamp: new Date().toISOString(),
+  };
+}
+
+const parseError: PhaseValidator<string> = () =>
+  reportWith('parse-error', false, ValidationSeverity.ERROR);
+
+describe('runValidationPhases', () => {
+  it('should skip later phases after a failing phase when failFast is set', async () => {
+    const securityScan = jest.fn(() => reportWith('no-root-user', true, ValidationSeverity.INFO));
+
+    const report = await runValidationPhases(
+      'FROM',
+      [
+        { name: 'parse', validators: [parseError] },
+        { name: 'security', validators: [securityScan] },
+      ],
+      { failFast: true },
+    );
+
+    expect(securityScan).not.toHaveBeenCalled();
+    expect(report.phases).toEqual([
+      { name: 'parse', status: 'failed', errors: 1 },
+      { name: 'security', status: 'skipped', errors: 0 },
+    ]);
+    expect(report.results.map((result) => result.ruleId)).toEqual(['parse-error']);
+    expect(report.grade).toBe('F');
+  });
+
+  it('should run every phase without failFast and merge their results', async () => {
+    const securityScan = jest.fn(() => reportWith('no-root-user', true, ValidationSeverity.INFO));
+
+    const report = await runValidationPhases('FROM', [
+      { name: 'parse', validators: [parseError] },
+      { name: 'security', validators: [securityScan] },
+    ]);
+
+    expect(securityScan).toHaveBeenCalledWith('FROM');
+    expect(report.phases.map((phase) => phase.status)).toEqual(['failed', 'passed']);
+    expect(report.results.map((result) => result.ruleId)).toEqual(['parse-error', 'no-root-user']);
+  });
+
+  it('should not treat warnings as a failed phase', async () => {
+    const report = await runValidationPhases(
+      'FROM node:latest',
+      [
+        {
+          name: 'best-practice',
+          validators: [
+            () => reportWith('pin-base-image', false, ValidationSeverity.WARNING),
+            async () => reportWith('has-healthcheck', true, ValidationSeverity.INFO),
+          ],
+        },
+        {
+          name: 'security',
+          validators: [() => reportWith('no-secrets', true, ValidationSeverity.INFO)],
+        },
+      ],
+      { failFast: true },
+    );
+
+    expect(report.phases).toEqual([
+      { name: 'best-practice', status: 'passed', errors: 0 },
+      { name: 'security', status: 'passed', errors: 0 },
+    ]);
+    expect(report.warnings).toBe(1);
+  });
+});
+
+describe('runValidationPhases timing', () => {
+  const slow =
+    (ms: number): PhaseValidator<string> =>
+    () =>
+      new Promise((resolve) =>
+        setTimeout(() => resolve(reportWith(`slow-${ms}`, true, ValidationSeverity.INFO)), ms),
+      );
+
+  it('should record phase and validator durations when enabled', async () => {
+    const report = await runValidationPhases(
+      'FROM',
+      [
+        { name: 'parse', validators: [slow(5), slow(30)] },
+        { name: 'security', validators: [parseError] },
+        { name: 'lint', validators: [slow(5)] },
+      ],
+      { timing: true, failFast: true },
+    );
+
+    const [parse, security, lint] = report.phases;
+    expect(parse?.validatorDurationsMs).toHaveLength(2);
+    expect(parse?.validatorDurationsMs?.[1]).toBeGreaterThanOrEqual(25);
+    expect(parse?.durationMs).toBeGreaterThanOrEqual(parse?.validatorDurationsMs?.[1] ?? 0);
+    expect(security?.durationMs).toBeGreaterThanOrEqual(0);
+    expect(lint).toEqual({ name: 'lint', status: 'skipped', errors: 0 });
+    expect(report.durationMs).toBeGreaterThanOrEqual(parse?.durationMs ?? 0);
+  });
+
+  it('should leave durations out by default', async () => {
+    const report = await runValidationPhases('FROM', [{ name: 'parse', validators: [slow(1)] }]);
+
+    expect(report.durationMs).toBeUndefined();
+    expect(report.phases[0]).toEqual({ name: 'parse', status: 'passed', errors: 0 });
+  });
+});
@@ -0,0 +1,94 @@
+/**
+ * Tests for validation report summaries
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import { summarizeReport } from '@/validation/report-summary';
+import { runValidationPhases } from '@/validation/phases';
+import {
+  ValidationSeverity,
+  type ValidationReport,
+  type ValidationResult,
+} from '@/validation/core-types';
+
+function result(ruleId: string, passed: boolean, severity: ValidationSeverity): ValidationResult {
+  return {
+    ruleId,
+    isValid: passed,
+    passed,
+    errors: passed || severity !== ValidationSeverity.ERROR ? [] : [`${ruleId} failed`],
+    warnings: passed || severity !== ValidationSeverity.WARNING ? [] : [`${ruleId} failed`],
+    metadata: { severity },
+  };
+}
+
+function report(results: ValidationResult[]): ValidationReport {
+  const failedWith = (severity: ValidationSeverity): number =>
+    results.filter((r) => !r.passed && r.metadata?.severity === severity).length;
+  const passed = results.filter((r) => r.passed).length;
+
+  return {
+    results,
+    score: 70,
+    grade: 'C',
+    passed,
+    failed: results.length - passed,
+    errors: failedWith(ValidationSeverity.ERROR),
+    warnings: failedWith(ValidationSeverity.WARNING),
+    info: failedWith(ValidationSeverity.INFO),
+    timestamp: new Date().toISOString(),
+  };
+}
+
+describe('summarizeReport', () => {
+  it('should count failed results by severity and rule', () => {
+    const validated = report([
+      result('web-no-root', false, ValidationSeverity.ERROR),
+      result('api-no-root', false, ValidationSeverity.ERROR),
+      result('pin-tag', false, ValidationSeverity.WARNING),
+      result('pin-tag', false, ValidationSeverity.WARNING),
+      result('has-labels', false, ValidationSeverity.INFO),
+      result('has-healthcheck', true, ValidationSeverity.WARNING),
+    ]);
+
+    const summary = summarizeReport(validated);
+
+    expect(summary).toEqual({
+      total: 6,
+      passed: 1,
+      failed: 5,
+      bySeverity: { error: 2, warning: 2, info: 1 },
+      byRule: { 'web-no-root': 1, 'api-no-root': 1, 'pin-tag': 2, 'has-labels': 1 },
+      byPhase: {},
+    });
+    expect(summary.bySeverity.error).toBe(validated.errors);
+    expect(summary.bySeverity.warning).toBe(validated.warnings);
+    expect(summary.failed).toBe(validated.failed);
+  });
+
+  it('should include phase outcomes and timing from phased reports', async () => {
+    const phased = await runValidationPhases(
+      'FROM node',
+      [
+        {
+          name: 'syntax',
+          validators: [() => report([result('parse', true, ValidationSeverity.INFO)])],
+        },
+        {
+          name: 'security',
+          validators: [() => report([result('no-root', false, ValidationSeverity.ERROR)])],
+        },
+      ],
+      { timing: true },
+    );
+
+    const summary = summarizeReport(phased);
+
+    expect(summary.byPhase).toEqual({
+      syntax: { status: 'passed', errors: 0, durationMs: expect.any(Number) },
+      security: { status: 'failed', errors: 1, durationMs: expect.any(Number) },
+    });
+    expect(summary.durationMs).toBe(phased.durationMs);
+    expect(summary.byRule).toEqual({ 'no-root': 1 });
+  });
+});
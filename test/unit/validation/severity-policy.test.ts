@@ -0,0 +1,146 @@
+/**
+ * Tests for severity escalation and downgrade
+ */
+
+import { describe, it, expect } from '@jest/globals';
+import { applySeverityPolicy } from '@/validation/severity-policy';
+import { runValidationPhases } from '@/validation/phases';
+import {
+  ValidationSeverity,
+  type ValidationReport,
+  type ValidationResult,
+} from '@/validation/core-types';
+
+function finding(
+  ruleId: string,
+  severity: ValidationSeverity,
+  passed = false,
+  location?: string,
+): ValidationResult {
+  return {
+    ruleId,
+    isValid: passed,
+    passed,
+    errors: !passed && severity === ValidationSeverity.ERROR ? [`${ruleId} failed`] : [],
+    warnings: !passed && severity === ValidationSeverity.WARNING ? [`${ruleId} failed`] : [],
+    metadata: { severity, ...(location && { location }) },
+  };
+}
+
+function report(results: ValidationResult[]): ValidationReport {
+  const failedWith = (severity: ValidationSeverity): number =>
+    results.filter((r) => !r.passed && r.metadata?.severity === severity).length;
+
+  return {
+    results,
+    score: 81,
+    grade: 'B',
+    passed: results.filter((r) => r.passed).length,
+    failed: results.filter((r) => !r.passed).length,
+    errors: failedWith(ValidationSeverity.ERROR),
+    warnings: failedWith(ValidationSeverity.WARNING),
+    info: failedWith(ValidationSeverity.INFO),
+    timestamp: new Date().toISOString(),
+  };
+}
+
+const findings = report([
+  finding('no-root-user', ValidationSeverity.ERROR),
+  finding('specific-base-image', ValidationSeverity.WARNING),
+  finding('web-no-latest-tag', ValidationSeverity.WARNING, false, 'Deployment/web'),
+  finding('has-healthcheck', ValidationSeverity.INFO),
+  finding('no-sudo-install', ValidationSeverity.WARNING, true),
+]);
+
+const severityOf = (validated: ValidationReport, ruleId: string): string | undefined =>
+  validated.results.find((r) => r.ruleId === ruleId)?.metadata?.severity;
+
+describe('applySeverityPolicy', () => {
+  it('should promote failed warnings to errors', () => {
+    const strict = applySeverityPolicy(findings, { warningsAsErrors: true });
+
+    expect(strict.errors).toBe(3);
+    expect(strict.warnings).toBe(0);
+    expect(strict.info).toBe(1);
+    expect(severityOf(strict, 'web-no-latest-tag')).toBe(ValidationSeverity.ERROR);
+    expect(strict.results[1]).toMatchObject({
+      errors: ['specific-base-image failed'],
+      warnings: [],
+    });
+    // Passed results and the validator's score are untouched
+    expect(severityOf(strict, 'no-sudo-install')).toBe(ValidationSeverity.WARNING);
+    expect(strict.score).toBe(findings.score);
+  });
+
+  it('should demote selected rules, including resource-scoped IDs', () => {
+    const gradual = applySeverityPolicy(
+      report([
+        finding('no-root-user', ValidationSeverity.ERROR),
+        finding('web-no-sensitive-bind-mounts', ValidationSeverity.ERROR, false, 'services.web'),
+        finding('no-secrets', ValidationSeverity.ERROR),
+      ]),
+      { downgrade: ['no-root-user', 'no-sensitive-bind-mounts'] },
+    );
+
+    expect(gradual.errors).toBe(1);
+    expect(gradual.warnings).toBe(2);
+    expect(gradual.results[0]).toMatchObject({
+      errors: [],
+      warnings: ['no-root-user failed'],
+      metadata: { severity: ValidationSeverity.WARNING },
+    });
+    expect(severityOf(gradual, 'no-secrets')).toBe(ValidationSeverity.ERROR);
+  });
+
+  it('should not demote rules whose IDs merely end with a downgraded ID', () => {
+    const exact = applySeverityPolicy(
+      report([
+        finding('no-secrets', ValidationSeverity.ERROR),
+        finding('no-root-user', ValidationSeverity.ERROR),
+        finding('api-no-secrets', ValidationSeverity.ERROR, false, 'Deployment/api'),
+        finding('web-no-root-user', ValidationSeverity.ERROR, false, 'services.web'),
+      ]),
+      { downgrade: ['secrets', 'root-user', 'api-no'] },
+    );
+
+    expect(exact.errors).toBe(4);
+    expect(exact.warnings).toBe(0);
+  });
+
+  it('should keep downgraded rules as warnings when warnings are errors', () => {
+    const combined = applySeverityPolicy(findings, {
+      warningsAsErrors: true,
+      downgrade: ['no-root-user', 'specific-base-image'],
+    });
+
+    expect(severityOf(combined, 'no-root-user')).toBe(ValidationSeverity.WARNING);
+    expect(severityOf(combined, 'specific-base-image')).toBe(ValidationSeverity.WARNING);
+    expect(severityOf(combined, 'web-no-latest-tag')).toBe(ValidationSeverity.ERROR);
+    expect(combined.errors).toBe(1);
+    expect(combined.warnings).toBe(2);
+  });
+
+  it('should not modify the original report', () => {
+    applySeverityPolicy(findings, { warningsAsErrors: true });
+
+    expect(findings.errors).toBe(1);
+    expect(severityOf(findings, 'specific-base-image')).toBe(ValidationSeverity.WARNING);
+  });
+
+  it('should fail a phase on escalated warnings', async () => {
+    const phases = [
+      { name: 'best-practice', validators: [() => report([findings.results[1]!])] },
+      { name: 'security', validators: [() => report([])] },
+    ];
+
+    const relaxed = await runValidationPhases('FROM node', phases, { failFast: true });
+    const strict = await runValidationPhases('FROM node', phases, {
+      failFast: true,
+      severity: { warningsAsErrors: true },
+    });
+
+    expect(relaxed.phases.map((phase) => phase.status)).toEqual(['passed', 'passed']);
+    expect(strict.phases.map((phase) => phase.status)).toEqual(['failed', 'skipped']);
+    expect(strict.errors).toBe(1);
+  });
+});